@@ -12,6 +12,42 @@ const (
 	ReconcileSuccessCondition string = "ReconcileSuccess"
 	// ReadyCondition indicates the cluster is ready to receive traffic.
 	ReadyCondition string = "Ready"
+	// ElasticsearchHealthyCondition indicates the cluster's elasticsearch datastores are healthy.
+	ElasticsearchHealthyCondition string = "ESHealthy"
+	// ElasticsearchMappingInSyncCondition indicates the visibility indices mappings match the expected search attributes.
+	ElasticsearchMappingInSyncCondition string = "ESMappingInSync"
+	// DrainingCondition indicates the cluster frontend rejects new workflow executions.
+	DrainingCondition string = "Draining"
+	// ReplicationHealthyCondition indicates the cluster is connected to all its remote clusters within the allowed replication lag.
+	ReplicationHealthyCondition string = "ReplicationHealthy"
+	// ShardDiagnosticsCompletedCondition indicates the last shard diagnostics requested using the temporal.io/diagnose-shards annotation completed.
+	ShardDiagnosticsCompletedCondition string = "ShardDiagnosticsCompleted"
+	// OverloadedCondition indicates a monitored task queue backlog exceeds the allowed maximum.
+	OverloadedCondition string = "Overloaded"
+	// DatastoreAvailableCondition indicates the cluster's datastores can be reached by the persistence jobs.
+	DatastoreAvailableCondition string = "DatastoreAvailable"
+	// CanaryHealthyCondition indicates the last canary workflow completed successfully.
+	CanaryHealthyCondition string = "CanaryHealthy"
+	// MetadataMismatchCondition indicates the metadata persisted by the cluster doesn't match its spec.
+	MetadataMismatchCondition string = "MetadataMismatch"
+	// PersistenceChangeBlockedCondition indicates a datastore endpoint change is held until it's confirmed.
+	PersistenceChangeBlockedCondition string = "PersistenceChangeBlocked"
+	// UIReadyCondition indicates the cluster UI is ready. It doesn't affect the cluster Ready condition.
+	UIReadyCondition string = "UIReady"
+	// AdminToolsReadyCondition indicates the cluster admin tools are ready. It doesn't affect the cluster Ready condition.
+	AdminToolsReadyCondition string = "AdminToolsReady"
+	// ServiceDegradedCondition indicates a temporal service container is crash looping.
+	ServiceDegradedCondition string = "ServiceDegraded"
+	// SizingWarningCondition indicates the cluster history shards, replicas and resources aren't mutually sensible.
+	SizingWarningCondition string = "SizingWarning"
+	// ClusterClientValidatedCondition indicates the client credentials were successfully used to reach the cluster.
+	ClusterClientValidatedCondition string = "Validated"
+	// ClusterClientPermissionsGrantedCondition indicates the permissions requested by the client are granted by the cluster.
+	ClusterClientPermissionsGrantedCondition string = "PermissionsGranted"
+	// ClusterClientAddressResolvedCondition indicates the frontend address matching the client access is resolved.
+	ClusterClientAddressResolvedCondition string = "AddressResolved"
+	// OperatorConfigAppliedCondition indicates the operator runs with the settings of its TemporalOperatorConfig.
+	OperatorConfigAppliedCondition string = "Applied"
 )
 
 const (
@@ -29,12 +65,126 @@ const (
 	PersistenceReconciliationFailedReason string = "PersistenceReconciliationFailed"
 	// ResourcesReconciliationFailedReason signals an error while reconciling cluster resources.
 	ResourcesReconciliationFailedReason string = "ResoucesReconciliationFailed"
+	// ImageDigestsResolutionFailedReason signals an error while resolving the cluster images digests.
+	ImageDigestsResolutionFailedReason string = "ImageDigestsResolutionFailed"
+	// ImageVerificationFailedReason signals a temporal image signature can't be verified.
+	ImageVerificationFailedReason string = "ImageVerificationFailed"
+	// ActionFailedReason signals an error while running an operation requested using an action annotation.
+	ActionFailedReason string = "ActionFailed"
+	// ClusterOverridesFailedReason signals the TemporalClusterOverrides selecting the cluster can't be applied.
+	ClusterOverridesFailedReason string = "ClusterOverridesFailed"
+	// ComponentReadyReason signals an optional cluster component is ready.
+	ComponentReadyReason string = "ComponentReady"
+	// ComponentNotReadyReason signals an optional cluster component is not ready yet.
+	ComponentNotReadyReason string = "ComponentNotReady"
+	// ComponentReconcileFailedReason signals an error while reconciling an optional cluster component.
+	ComponentReconcileFailedReason string = "ComponentReconcileFailed"
 	// TemporalClusterValidationFailedReason signals an error while validation desired cluster version.
 	TemporalClusterValidationFailedReason string = "TemporalClusterValidationFailed"
 	// TemporalNamespaceCreatedReason signals a successful namespace creation.
 	TemporalNamespaceCreatedReason string = "TemporalNamespaceCreated"
+	// TemporalNamespaceReplicatedReason signals a global namespace was replicated to a standby cluster.
+	TemporalNamespaceReplicatedReason string = "TemporalNamespaceReplicated"
+	// TemporalNamespaceNotReplicatedReason signals a global namespace was not replicated to a standby cluster yet.
+	TemporalNamespaceNotReplicatedReason string = "TemporalNamespaceNotReplicated"
 	// TemporalScheduleCreatedReason signals a successful schedule creation.
 	TemporalScheduleCreatedReason string = "TemporalScheduleCreated"
+	// ElasticsearchHealthyReason signals all elasticsearch datastores reported a green or yellow health.
+	ElasticsearchHealthyReason string = "ElasticsearchHealthy"
+	// ElasticsearchUnhealthyReason signals an elasticsearch datastore reported a red health or can't be reached.
+	ElasticsearchUnhealthyReason string = "ElasticsearchUnhealthy"
+	// ElasticsearchMappingInSyncReason signals all checked visibility indices mappings match the expected search attributes.
+	ElasticsearchMappingInSyncReason string = "MappingInSync"
+	// ElasticsearchMappingDriftReason signals a visibility index mapping misses fields or has type conflicts.
+	ElasticsearchMappingDriftReason string = "MappingDrift"
+	// ElasticsearchMappingUnknownReason signals a visibility index mapping can't be retrieved.
+	ElasticsearchMappingUnknownReason string = "MappingUnknown"
+	// ReplicationHealthyReason signals all remote clusters are connected within the allowed replication lag.
+	ReplicationHealthyReason string = "ReplicationHealthy"
+	// ReplicationUnhealthyReason signals a remote cluster is not connected or lags behind.
+	ReplicationUnhealthyReason string = "ReplicationUnhealthy"
+	// ReplicationStatusUnknownReason signals the replication status can't be retrieved from the cluster.
+	ReplicationStatusUnknownReason string = "ReplicationStatusUnknown"
+	// ShardDiagnosticsCompletedReason signals the inspected shards report is stored.
+	ShardDiagnosticsCompletedReason string = "ShardDiagnosticsCompleted"
+	// ShardDiagnosticsFailedReason signals the shards can't be inspected.
+	ShardDiagnosticsFailedReason string = "ShardDiagnosticsFailed"
+	// DrainEnabledReason signals the frontend proxies reject new workflow executions.
+	DrainEnabledReason string = "DrainEnabled"
+	// DrainPendingReason signals the frontend pods are being rolled out to enter or leave the drain mode.
+	DrainPendingReason string = "DrainPending"
+	// TaskQueueBacklogExceededReason signals a monitored task queue backlog exceeds the allowed maximum.
+	TaskQueueBacklogExceededReason string = "TaskQueueBacklogExceeded"
+	// TaskQueueBacklogWithinLimitReason signals all monitored task queues backlogs are within the allowed maximum.
+	TaskQueueBacklogWithinLimitReason string = "TaskQueueBacklogWithinLimit"
+	// WorkloadUnknownReason signals the workload of the monitored task queues can't be retrieved.
+	WorkloadUnknownReason string = "WorkloadUnknown"
+	// SmokeTestNotPassedReason signals that the post-rollout smoke test did not pass yet.
+	SmokeTestNotPassedReason string = "SmokeTestNotPassed"
+	// DatastoreAvailableReason signals the last persistence reconciliation succeeded.
+	DatastoreAvailableReason string = "DatastoreAvailable"
+	// DatastoreFailingReason signals the last persistence reconciliations failed.
+	DatastoreFailingReason string = "DatastoreFailing"
+	// DatastoreCircuitOpenReason signals persistence reconciliations are paused after too many consecutive failures.
+	DatastoreCircuitOpenReason string = "CircuitOpen"
+	// CanarySucceededReason signals the last canary workflow completed successfully.
+	CanarySucceededReason string = "CanarySucceeded"
+	// CanaryFailedReason signals the last canary workflow failed.
+	CanaryFailedReason string = "CanaryFailed"
+	// WorkerDeploymentReadyReason signals all the worker pods are ready.
+	WorkerDeploymentReadyReason string = "WorkersReady"
+	// WorkerDeploymentNotReadyReason signals not all the worker pods are ready.
+	WorkerDeploymentNotReadyReason string = "WorkersNotReady"
+	// WorkerDeploymentReconcileErrorReason signals the worker deployment can't be reconciled.
+	WorkerDeploymentReconcileErrorReason string = "ReconcileError"
+	// DatastoreMigrationBlockedReason signals a datastore database changed without allowing its migration.
+	DatastoreMigrationBlockedReason string = "DatastoreMigrationBlocked"
+	// PersistenceChangeBlockedReason signals a datastore endpoint changed without confirming the change.
+	PersistenceChangeBlockedReason string = "PersistenceChangeBlocked"
+	// PersistenceUnchangedReason signals the datastores endpoints match the ones the cluster has been set up with.
+	PersistenceUnchangedReason string = "PersistenceUnchanged"
+	// MetadataMismatchReason signals the cluster name or history shard count persisted by the cluster doesn't match the spec.
+	MetadataMismatchReason string = "MetadataMismatch"
+	// MetadataMatchesReason signals the metadata persisted by the cluster matches the spec.
+	MetadataMatchesReason string = "MetadataMatches"
+	// ContainersCrashLoopingReason signals a temporal service container is crash looping.
+	ContainersCrashLoopingReason string = "CrashLoopBackOff"
+	// ContainersHealthyReason signals no temporal service container is crash looping.
+	ContainersHealthyReason string = "ContainersHealthy"
+	// SizingIssuesReason signals the cluster sizing doesn't match the temporal benchmarks recommendations.
+	SizingIssuesReason string = "SizingIssues"
+	// SizingSensibleReason signals the cluster sizing matches the temporal benchmarks recommendations.
+	SizingSensibleReason string = "SizingSensible"
+	// ClusterClientValidatedReason signals the client credentials were accepted by the cluster.
+	ClusterClientValidatedReason string = "ConnectionSucceeded"
+	// ClusterClientValidationFailedReason signals the cluster can't be reached using the client credentials.
+	ClusterClientValidationFailedReason string = "ConnectionFailed"
+	// ClusterClientPermissionsGrantedReason signals the client permissions are added to the cluster claim mapper rules.
+	ClusterClientPermissionsGrantedReason string = "PermissionsGranted"
+	// ClusterClientPermissionsNotAllowedReason signals the cluster doesn't allow clients to request permissions.
+	ClusterClientPermissionsNotAllowedReason string = "ClientPermissionsNotAllowed"
+	// ClusterClientAddressResolvedReason signals the frontend address matching the client access is reported in the client status and secret.
+	ClusterClientAddressResolvedReason string = "AddressResolved"
+	// ClusterClientNoExternalHostnameReason signals the cluster doesn't publish any hostname for the frontend.
+	ClusterClientNoExternalHostnameReason string = "NoExternalHostname"
+	// ClusterCloneSyncedReason signals the cloned cluster spec is written from the source cluster.
+	ClusterCloneSyncedReason string = "ClusterSynced"
+	// ClusterCloneSourceNotFoundReason signals the cluster referenced by the clone doesn't exist.
+	ClusterCloneSourceNotFoundReason string = "SourceNotFound"
+	// ClusterCloneConflictReason signals a cluster with the clone target name exists and isn't managed by the clone.
+	ClusterCloneConflictReason string = "ClusterConflict"
+	// ClusterCloneReconcileErrorReason signals an error while writing the cloned cluster.
+	ClusterCloneReconcileErrorReason string = "ReconcileError"
+	// ServiceScalerReadyReason signals the scaled service runs the desired replicas.
+	ServiceScalerReadyReason string = "ServiceScaled"
+	// ServiceScalerProgressingReason signals the scaled service doesn't run the desired replicas yet.
+	ServiceScalerProgressingReason string = "ScalingInProgress"
+	// ServiceScalerReconcileErrorReason signals an error while writing the service replicas to the cluster.
+	ServiceScalerReconcileErrorReason string = "ReconcileError"
+	// OperatorConfigAppliedReason signals all the operator config settings are applied.
+	OperatorConfigAppliedReason string = "ConfigApplied"
+	// OperatorConfigRestartRequiredReason signals settings applied on start changed and require an operator restart.
+	OperatorConfigRestartRequiredReason string = "RestartRequired"
 )
 
 // SetTemporalClusterReconcileSuccess sets the ReconcileSuccessCondition status for a temporal cluster.
@@ -63,12 +213,181 @@ func SetTemporalClusterReconcileError(c *TemporalCluster, status metav1.Conditio
 	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
 }
 
+// SetTemporalClusterElasticsearchHealthy sets the ElasticsearchHealthyCondition status for a temporal cluster.
+func SetTemporalClusterElasticsearchHealthy(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ElasticsearchHealthyCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalClusterElasticsearchMappingInSync sets the ElasticsearchMappingInSyncCondition status for a temporal cluster.
+func SetTemporalClusterElasticsearchMappingInSync(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ElasticsearchMappingInSyncCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalClusterReplicationHealthy sets the ReplicationHealthyCondition status for a temporal cluster.
+func SetTemporalClusterReplicationHealthy(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReplicationHealthyCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalClusterShardDiagnosticsCompleted sets the ShardDiagnosticsCompletedCondition status for a temporal cluster.
+func SetTemporalClusterShardDiagnosticsCompleted(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ShardDiagnosticsCompletedCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalClusterDraining sets the DrainingCondition status for a temporal cluster.
+func SetTemporalClusterDraining(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               DrainingCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalClusterOverloaded sets the OverloadedCondition status for a temporal cluster.
+func SetTemporalClusterOverloaded(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               OverloadedCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalClusterServiceDegraded sets the ServiceDegradedCondition status for a temporal cluster.
+func SetTemporalClusterServiceDegraded(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ServiceDegradedCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalClusterSizingWarning sets the SizingWarningCondition status for a temporal cluster.
+func SetTemporalClusterSizingWarning(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               SizingWarningCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalClusterCanaryHealthy sets the CanaryHealthyCondition status for a temporal cluster.
+func SetTemporalClusterCanaryHealthy(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               CanaryHealthyCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalClusterMetadataMismatch sets the MetadataMismatchCondition status for a temporal cluster.
+func SetTemporalClusterMetadataMismatch(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               MetadataMismatchCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalClusterPersistenceChangeBlocked sets the PersistenceChangeBlockedCondition status for a temporal cluster.
+func SetTemporalClusterPersistenceChangeBlocked(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               PersistenceChangeBlockedCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalClusterDatastoreAvailable sets the DatastoreAvailableCondition status for a temporal cluster.
+func SetTemporalClusterDatastoreAvailable(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               DatastoreAvailableCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
 // GetTemporalClusterReadyCondition returns the ready condition for the provided cluster if found.
 func GetTemporalClusterReadyCondition(c *TemporalCluster) (*metav1.Condition, bool) {
 	condition := apimeta.FindStatusCondition(c.Status.Conditions, ReadyCondition)
 	return condition, condition != nil
 }
 
+// SetTemporalClusterComponentReady sets the provided component ready condition status for a temporal cluster.
+func SetTemporalClusterComponentReady(c *TemporalCluster, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
 // SetTemporalClusterReady sets the ReadyCondition status for a temporal cluster.
 func SetTemporalClusterReady(c *TemporalCluster, status metav1.ConditionStatus, reason, message string) {
 	condition := metav1.Condition{
@@ -159,3 +478,81 @@ func SetTemporalScheduleReconcileError(s *TemporalSchedule, status metav1.Condit
 	}
 	apimeta.SetStatusCondition(&s.Status.Conditions, condition)
 }
+
+// SetTemporalClusterClientValidated sets the ClusterClientValidatedCondition status for a temporal cluster client.
+func SetTemporalClusterClientValidated(c *TemporalClusterClient, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ClusterClientValidatedCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalClusterClientPermissionsGranted sets the ClusterClientPermissionsGrantedCondition status for a temporal cluster client.
+func SetTemporalClusterClientPermissionsGranted(c *TemporalClusterClient, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ClusterClientPermissionsGrantedCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalClusterClientAddressResolved sets the ClusterClientAddressResolvedCondition status for a temporal cluster client.
+func SetTemporalClusterClientAddressResolved(c *TemporalClusterClient, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ClusterClientAddressResolvedCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalWorkerDeploymentReady sets the ReadyCondition status for a temporal worker deployment.
+func SetTemporalWorkerDeploymentReady(w *TemporalWorkerDeployment, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReadyCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: w.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&w.Status.Conditions, condition)
+}
+
+// SetTemporalClusterCloneReady sets the ReadyCondition status for a temporal cluster clone.
+func SetTemporalClusterCloneReady(c *TemporalClusterClone, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReadyCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: c.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&c.Status.Conditions, condition)
+}
+
+// SetTemporalServiceScalerReady sets the ReadyCondition status for a temporal service scaler.
+func SetTemporalServiceScalerReady(s *TemporalServiceScaler, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               ReadyCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: s.GetGeneration(),
+		Reason:             reason,
+		Status:             status,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&s.Status.Conditions, condition)
+}
@@ -0,0 +1,94 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AccessPolicySubject identifies the clients a TemporalAccessPolicy grants roles to.
+// +kubebuilder:validation:XValidation:rule="[has(self.commonName), has(self.dnsName), has(self.jwtSubject)].filter(x, x).size() == 1",message="exactly one of commonName, dnsName or jwtSubject is required"
+type AccessPolicySubject struct {
+	// CommonName is a glob pattern matched against the client certificate subject common name.
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+	// DNSName is a glob pattern matched against the client certificate DNS subject alternative names.
+	// +optional
+	DNSName string `json:"dnsName,omitempty"`
+	// JWTSubject is a glob pattern matched against the subject claim of the client JWT token.
+	// +optional
+	JWTSubject string `json:"jwtSubject,omitempty"`
+}
+
+// TemporalAccessPolicySpec defines the desired state of TemporalAccessPolicy.
+type TemporalAccessPolicySpec struct {
+	// ClusterRef is the reference to the temporal cluster the policy applies to.
+	// The cluster must be in the policy namespace.
+	ClusterRef corev1.LocalObjectReference `json:"clusterRef"`
+	// Subjects are the clients the roles are granted to.
+	// +kubebuilder:validation:MinItems=1
+	Subjects []AccessPolicySubject `json:"subjects"`
+	// SystemRoles are the roles granted on the whole cluster.
+	// +optional
+	SystemRoles []TemporalRole `json:"systemRoles,omitempty"`
+	// Namespaces are the roles granted per namespace.
+	// +optional
+	Namespaces []CertificateClaimMapperNamespaceRoles `json:"namespaces,omitempty"`
+}
+
+// ClaimMapperRules returns the claim mapper rules granting the policy roles to each subject.
+func (s *TemporalAccessPolicySpec) ClaimMapperRules() []CertificateClaimMapperRule {
+	rules := []CertificateClaimMapperRule{}
+	for _, subject := range s.Subjects {
+		rules = append(rules, CertificateClaimMapperRule{
+			CommonName:  subject.CommonName,
+			DNSName:     subject.DNSName,
+			JWTSubject:  subject.JWTSubject,
+			SystemRoles: s.SystemRoles,
+			Namespaces:  s.Namespaces,
+		})
+	}
+	return rules
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterRef.name"
+
+// A TemporalAccessPolicy grants Temporal roles to clients identified by their certificate or JWT token.
+// Policies are compiled into the cluster claim mapper rules, which requires
+// spec.authorization.certificateClaimMapper to be set on the cluster.
+type TemporalAccessPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TemporalAccessPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TemporalAccessPolicyList contains a list of TemporalAccessPolicy.
+type TemporalAccessPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalAccessPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalAccessPolicy{}, &TemporalAccessPolicyList{})
+}
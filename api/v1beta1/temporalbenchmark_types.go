@@ -0,0 +1,189 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultBenchmarkImage is the omes image used to run benchmarks when none is provided.
+	DefaultBenchmarkImage = "temporaliotest/omes:go-latest"
+	// DefaultBenchmarkScenario is the omes scenario run when none is provided.
+	DefaultBenchmarkScenario = "workflow_with_single_noop_activity"
+	// DefaultBenchmarkNamespace is the temporal namespace benchmarks run in when none is provided.
+	DefaultBenchmarkNamespace = "default"
+)
+
+// TemporalBenchmarkPhase is the phase of a benchmark run.
+type TemporalBenchmarkPhase string
+
+const (
+	// BenchmarkPending means the benchmark waits for the referenced cluster to be ready.
+	BenchmarkPending TemporalBenchmarkPhase = "Pending"
+	// BenchmarkRunning means the benchmark job is running.
+	BenchmarkRunning TemporalBenchmarkPhase = "Running"
+	// BenchmarkSucceeded means the benchmark job completed successfully.
+	BenchmarkSucceeded TemporalBenchmarkPhase = "Succeeded"
+	// BenchmarkFailed means the benchmark job failed or the benchmark can't be run.
+	BenchmarkFailed TemporalBenchmarkPhase = "Failed"
+)
+
+// TemporalBenchmarkSpec defines the desired state of TemporalBenchmark.
+// A benchmark runs once: its spec can't be changed after creation, create a new benchmark instead.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+// +kubebuilder:validation:XValidation:rule="!(has(self.iterations) && has(self.duration))",message="only one of iterations or duration can be set"
+type TemporalBenchmarkSpec struct {
+	// Reference to the temporal cluster the benchmark runs against.
+	ClusterRef ObjectReference `json:"clusterRef"`
+	// Namespace is the temporal namespace the benchmark workflows are started in.
+	// The namespace must exist in the cluster.
+	// Defaults to "default".
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Scenario is the name of the omes scenario to run.
+	// Defaults to "workflow_with_single_noop_activity".
+	// +optional
+	Scenario string `json:"scenario,omitempty"`
+	// Iterations is the number of scenario iterations to run.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Iterations *int32 `json:"iterations,omitempty"`
+	// Duration is the duration the scenario is run for.
+	// If neither iterations nor duration are set, the scenario default is used.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// MaxConcurrent is the maximum number of concurrent scenario iterations.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+	// Image is the omes image running the scenario and its worker.
+	// Defaults to "temporaliotest/omes:go-latest".
+	// +optional
+	Image string `json:"image,omitempty"`
+	// ImagePullSecrets are the secrets used to pull the omes image.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Resources are the compute resources of the benchmark pod.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// GetNamespace returns the temporal namespace of the benchmark.
+func (s *TemporalBenchmarkSpec) GetNamespace() string {
+	if s.Namespace == "" {
+		return DefaultBenchmarkNamespace
+	}
+	return s.Namespace
+}
+
+// GetScenario returns the omes scenario of the benchmark.
+func (s *TemporalBenchmarkSpec) GetScenario() string {
+	if s.Scenario == "" {
+		return DefaultBenchmarkScenario
+	}
+	return s.Scenario
+}
+
+// GetImage returns the omes image of the benchmark.
+func (s *TemporalBenchmarkSpec) GetImage() string {
+	if s.Image == "" {
+		return DefaultBenchmarkImage
+	}
+	return s.Image
+}
+
+// TemporalBenchmarkSummary holds the results of a benchmark run.
+type TemporalBenchmarkSummary struct {
+	// Duration is the duration of the benchmark run.
+	Duration metav1.Duration `json:"duration"`
+	// CompletedWorkflows is the number of benchmark workflows that completed.
+	// +optional
+	CompletedWorkflows *int64 `json:"completedWorkflows,omitempty"`
+	// FailedWorkflows is the number of benchmark workflows that failed or timed out.
+	// +optional
+	FailedWorkflows *int64 `json:"failedWorkflows,omitempty"`
+	// WorkflowsPerSecond is the completed workflows throughput over the run duration.
+	// +optional
+	WorkflowsPerSecond string `json:"workflowsPerSecond,omitempty"`
+	// Message holds details about why workflow counts could not be collected, if any.
+	// Counting workflows requires advanced visibility on the cluster.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// TemporalBenchmarkStatus defines the observed state of TemporalBenchmark.
+type TemporalBenchmarkStatus struct {
+	// Phase is the current phase of the benchmark.
+	// +optional
+	Phase TemporalBenchmarkPhase `json:"phase,omitempty"`
+	// RunID is the omes run id, used to name the benchmark task queue (omes-<runID>).
+	// +optional
+	RunID string `json:"runId,omitempty"`
+	// JobName is the name of the Job running the benchmark.
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+	// StartTime is the time the benchmark job was created.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the benchmark job finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Summary holds the results of the benchmark, once finished.
+	// +optional
+	Summary *TemporalBenchmarkSummary `json:"summary,omitempty"`
+	// Message holds a human readable explanation of the current phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IsFinished returns true if the benchmark reached a terminal phase.
+func (b *TemporalBenchmark) IsFinished() bool {
+	return b.Status.Phase == BenchmarkSucceeded || b.Status.Phase == BenchmarkFailed
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="Scenario",type="string",JSONPath=".spec.scenario"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Completed",type="integer",JSONPath=".status.summary.completedWorkflows"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// A TemporalBenchmark runs an omes load test scenario against a temporal cluster.
+type TemporalBenchmark struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TemporalBenchmarkSpec   `json:"spec,omitempty"`
+	Status TemporalBenchmarkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TemporalBenchmarkList contains a list of TemporalBenchmark.
+type TemporalBenchmarkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalBenchmark `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalBenchmark{}, &TemporalBenchmarkList{})
+}
@@ -18,9 +18,12 @@
 package v1beta1
 
 import (
+	"strings"
 	"time"
 
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/gocql/gocql"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -33,6 +36,29 @@ const (
 	defaultTemporalUIImage   = "temporalio/ui"
 
 	defaultTemporalAdmintoolsImage = "temporalio/admin-tools"
+
+	defaultOAuth2ProxyImage   = "quay.io/oauth2-proxy/oauth2-proxy"
+	defaultOAuth2ProxyVersion = "v7.6.0"
+
+	defaultElasticsearchImage   = "docker.elastic.co/elasticsearch/elasticsearch"
+	defaultElasticsearchStorage = "5Gi"
+
+	defaultGRPCWebImage   = "envoyproxy/envoy"
+	defaultGRPCWebVersion = "v1.30.1"
+	defaultGRPCWebPort    = 8080
+
+	defaultFrontendProxyImage   = "envoyproxy/envoy"
+	defaultFrontendProxyVersion = "v1.30.1"
+
+	// DefaultClusterDomain is the default DNS domain of kubernetes clusters.
+	DefaultClusterDomain = "cluster.local"
+
+	// ManagedElasticsearchPort is the http port of the managed Elasticsearch instances.
+	ManagedElasticsearchPort = 9200
+
+	// MinHighAvailabilityReplicas is the minimum number of replicas per service
+	// when the cluster runs in high availability mode.
+	MinHighAvailabilityReplicas int32 = 2
 )
 
 // Default set default fields values.
@@ -47,12 +73,126 @@ func (s *DatastoreSpec) Default() {
 		if s.Cassandra.ConnectTimeout == nil {
 			s.Cassandra.ConnectTimeout = &metav1.Duration{Duration: 10 * time.Second}
 		}
+
+		// Pin ScyllaDB consistency levels, so that they are also used by the schema tool
+		// and don't depend on the server defaults.
+		if s.Cassandra.IsScylla() {
+			if s.Cassandra.Consistency == nil {
+				s.Cassandra.Consistency = &CassandraConsistencySpec{}
+			}
+			if s.Cassandra.Consistency.Consistency == nil {
+				s.Cassandra.Consistency.Consistency = ptr.To(gocql.LocalQuorum)
+			}
+			if s.Cassandra.Consistency.SerialConsistency == nil {
+				s.Cassandra.Consistency.SerialConsistency = ptr.To(gocql.LocalSerial)
+			}
+		}
 	}
 
 	if s.Elasticsearch != nil {
 		if s.Elasticsearch.Indices.Visibility == "" {
 			s.Elasticsearch.Indices.Visibility = "temporal_visibility_v1"
 		}
+		if s.Elasticsearch.Managed.IsEnabled() {
+			s.Elasticsearch.Managed.Default(s.Elasticsearch.Version)
+		}
+	}
+}
+
+// defaultElasticsearchImageVersions are the latest tested Elasticsearch releases, by store version.
+var defaultElasticsearchImageVersions = map[string]string{
+	"v7": "7.17.22",
+	"v8": "8.14.3",
+}
+
+// Default set default fields values.
+func (s *ManagedElasticsearchSpec) Default(storeVersion string) {
+	if s.Image == "" {
+		s.Image = defaultElasticsearchImage
+	}
+	if s.ImageVersion == "" {
+		s.ImageVersion = defaultElasticsearchImageVersions[storeVersion]
+	}
+	if s.Storage == nil {
+		s.Storage = ptr.To(resource.MustParse(defaultElasticsearchStorage))
+	}
+}
+
+// defaultReplicas returns the default number of replicas for each temporal service.
+func (c *TemporalCluster) defaultReplicas() int32 {
+	if c.Spec.HighAvailability {
+		return MinHighAvailabilityReplicas
+	}
+	return 1
+}
+
+// Default set default fields values.
+func (s *TemporalUIOAuth2ProxySpec) Default() {
+	if s.Image == "" {
+		s.Image = defaultOAuth2ProxyImage
+	}
+
+	if s.Version == "" {
+		s.Version = defaultOAuth2ProxyVersion
+	}
+
+	if s.ClientSecretRef.Key == "" {
+		s.ClientSecretRef.Key = "client-secret"
+	}
+
+	if s.CookieSecretRef.Key == "" {
+		s.CookieSecretRef.Key = "cookie-secret"
+	}
+
+	if len(s.EmailDomains) == 0 {
+		s.EmailDomains = []string{"*"}
+	}
+}
+
+// imageFromRegistry returns the reference of the provided image in the registry,
+// replacing the registry host of the image if any.
+func imageFromRegistry(registry, image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && strings.ContainsAny(parts[0], ".:") {
+		image = parts[1]
+	}
+	return strings.TrimSuffix(registry, "/") + "/" + image
+}
+
+// DefaultImagesFromRegistry sets the images the user didn't specify to the default images pulled from the provided registry.
+// It must be called before TemporalCluster.Default.
+func (c *TemporalCluster) DefaultImagesFromRegistry(registry string) {
+	if registry == "" {
+		return
+	}
+
+	if c.Spec.Image == "" {
+		c.Spec.Image = imageFromRegistry(registry, defaultTemporalImage)
+	}
+
+	if c.Spec.UI == nil {
+		c.Spec.UI = new(TemporalUISpec)
+	}
+	if c.Spec.UI.Image == "" {
+		c.Spec.UI.Image = imageFromRegistry(registry, defaultTemporalUIImage)
+	}
+	if c.Spec.UI.OAuth2Proxy != nil && c.Spec.UI.OAuth2Proxy.Image == "" {
+		c.Spec.UI.OAuth2Proxy.Image = imageFromRegistry(registry, defaultOAuth2ProxyImage)
+	}
+
+	if c.Spec.GRPCWeb != nil && c.Spec.GRPCWeb.Image == "" {
+		c.Spec.GRPCWeb.Image = imageFromRegistry(registry, defaultGRPCWebImage)
+	}
+
+	if c.FrontendProxyEnabled() && c.Spec.Services.Frontend.Proxy.Image == "" {
+		c.Spec.Services.Frontend.Proxy.Image = imageFromRegistry(registry, defaultFrontendProxyImage)
+	}
+
+	if c.Spec.AdminTools == nil {
+		c.Spec.AdminTools = new(TemporalAdminToolsSpec)
+	}
+	if c.Spec.AdminTools.Image == "" {
+		c.Spec.AdminTools.Image = imageFromRegistry(registry, defaultTemporalAdmintoolsImage)
 	}
 }
 
@@ -64,6 +204,9 @@ func (c *TemporalCluster) Default() {
 	if c.Spec.Image == "" {
 		c.Spec.Image = defaultTemporalImage
 	}
+	if c.Spec.ClusterDomain == "" {
+		c.Spec.ClusterDomain = DefaultClusterDomain
+	}
 
 	if c.Spec.Log == nil {
 		c.Spec.Log = new(LogSpec)
@@ -86,7 +229,7 @@ func (c *TemporalCluster) Default() {
 		c.Spec.Services.Frontend = new(ServiceSpec)
 	}
 	if c.Spec.Services.Frontend.Replicas == nil {
-		c.Spec.Services.Frontend.Replicas = ptr.To[int32](1)
+		c.Spec.Services.Frontend.Replicas = ptr.To(c.defaultReplicas())
 	}
 	if c.Spec.Services.Frontend.Port == nil {
 		c.Spec.Services.Frontend.Port = ptr.To(7233)
@@ -97,10 +240,27 @@ func (c *TemporalCluster) Default() {
 	if c.Spec.Services.Frontend.HTTPPort == nil {
 		c.Spec.Services.Frontend.HTTPPort = ptr.To(7243)
 	}
+	if proxy := c.Spec.Services.Frontend.Proxy; proxy.IsEnabled() {
+		if proxy.Image == "" {
+			proxy.Image = defaultFrontendProxyImage
+		}
+		if proxy.Version == "" {
+			proxy.Version = defaultFrontendProxyVersion
+		}
+	}
 	// Internal Frontend specs
+	// When the frontend is exposed through the proxy sidecar, system workers can't reach it.
+	// Spawn the internal frontend (which only trusts internode identities) unless the user explicitly configured it.
+	// It isn't spawned for clusters using authorization: enabling it on existing clusters would roll out
+	// a new deployment and change the internode routing without any spec change, the webhook requires it to be configured instead.
+	if c.Spec.Services.InternalFrontend == nil &&
+		c.Spec.Services.Frontend.Proxy.IsEnabled() &&
+		c.Spec.Version.GreaterOrEqual(version.V1_20_0) {
+		c.Spec.Services.InternalFrontend = &InternalFrontendServiceSpec{Enabled: true}
+	}
 	if c.Spec.Services.InternalFrontend.IsEnabled() {
 		if c.Spec.Services.InternalFrontend.Replicas == nil {
-			c.Spec.Services.InternalFrontend.Replicas = ptr.To[int32](1)
+			c.Spec.Services.InternalFrontend.Replicas = ptr.To(c.defaultReplicas())
 		}
 		if c.Spec.Services.InternalFrontend.Port == nil {
 			c.Spec.Services.InternalFrontend.Port = ptr.To(7236)
@@ -117,7 +277,7 @@ func (c *TemporalCluster) Default() {
 		c.Spec.Services.History = new(ServiceSpec)
 	}
 	if c.Spec.Services.History.Replicas == nil {
-		c.Spec.Services.History.Replicas = ptr.To[int32](1)
+		c.Spec.Services.History.Replicas = ptr.To(c.defaultReplicas())
 	}
 	if c.Spec.Services.History.Port == nil {
 		c.Spec.Services.History.Port = ptr.To(7234)
@@ -133,7 +293,7 @@ func (c *TemporalCluster) Default() {
 		c.Spec.Services.Matching = new(ServiceSpec)
 	}
 	if c.Spec.Services.Matching.Replicas == nil {
-		c.Spec.Services.Matching.Replicas = ptr.To[int32](1)
+		c.Spec.Services.Matching.Replicas = ptr.To(c.defaultReplicas())
 	}
 	if c.Spec.Services.Matching.Port == nil {
 		c.Spec.Services.Matching.Port = ptr.To(7235)
@@ -149,7 +309,7 @@ func (c *TemporalCluster) Default() {
 		c.Spec.Services.Worker = new(ServiceSpec)
 	}
 	if c.Spec.Services.Worker.Replicas == nil {
-		c.Spec.Services.Worker.Replicas = ptr.To[int32](1)
+		c.Spec.Services.Worker.Replicas = ptr.To(c.defaultReplicas())
 	}
 	if c.Spec.Services.Worker.Port == nil {
 		c.Spec.Services.Worker.Port = ptr.To(7239)
@@ -189,6 +349,12 @@ func (c *TemporalCluster) Default() {
 		c.Spec.Persistence.AdvancedVisibilityStore.Default()
 	}
 
+	for _, store := range c.Spec.Persistence.GetDatastores() {
+		if store.ManagedElasticsearchEnabled() && store.Elasticsearch.URL == "" {
+			store.Elasticsearch.URL = c.ManagedElasticsearchURL(store)
+		}
+	}
+
 	if c.Spec.UI == nil {
 		c.Spec.UI = new(TemporalUISpec)
 	}
@@ -202,7 +368,26 @@ func (c *TemporalCluster) Default() {
 	}
 
 	if c.Spec.UI.Replicas == nil {
-		c.Spec.UI.Replicas = ptr.To[int32](1)
+		c.Spec.UI.Replicas = ptr.To(c.defaultReplicas())
+	}
+
+	if c.Spec.UI.OAuth2Proxy != nil {
+		c.Spec.UI.OAuth2Proxy.Default()
+	}
+
+	if c.Spec.GRPCWeb != nil {
+		if c.Spec.GRPCWeb.Image == "" {
+			c.Spec.GRPCWeb.Image = defaultGRPCWebImage
+		}
+		if c.Spec.GRPCWeb.Version == "" {
+			c.Spec.GRPCWeb.Version = defaultGRPCWebVersion
+		}
+		if c.Spec.GRPCWeb.Replicas == nil {
+			c.Spec.GRPCWeb.Replicas = ptr.To(c.defaultReplicas())
+		}
+		if c.Spec.GRPCWeb.Port == nil {
+			c.Spec.GRPCWeb.Port = ptr.To[int32](defaultGRPCWebPort)
+		}
 	}
 
 	if c.Spec.AdminTools == nil {
@@ -214,6 +399,12 @@ func (c *TemporalCluster) Default() {
 	}
 
 	if c.Spec.MTLS != nil {
+		if c.Spec.MTLS.Provider == "" {
+			c.Spec.MTLS.Provider = CertManagerMTLSProvider
+		}
+		if c.Spec.MTLS.IssuerRef != nil && c.Spec.MTLS.IssuerRef.Kind == "" {
+			c.Spec.MTLS.IssuerRef.Kind = ClusterIssuerCertificateIssuerKind
+		}
 		if c.Spec.MTLS.RefreshInterval == nil {
 			c.Spec.MTLS.RefreshInterval = &metav1.Duration{Duration: time.Hour}
 		}
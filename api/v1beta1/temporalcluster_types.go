@@ -19,8 +19,11 @@ package v1beta1
 
 import (
 	"fmt"
+	"net"
 	"path"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
 	"github.com/gocql/gocql"
@@ -28,12 +31,74 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"go.temporal.io/server/common/primitives"
 	"golang.org/x/exp/slices"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 )
 
+// DiffAnnotation makes the operator report the changes it would apply to the cluster
+// child resources instead of applying them, when set to "true".
+const DiffAnnotation = "temporal.io/diff"
+
+// MigrateDatastoresAnnotation allows changing the SQL database name of the default and visibility datastores
+// when set to "true". The operator then stops the services and copies the existing database to the new one.
+const MigrateDatastoresAnnotation = "temporal.io/migrate-datastores"
+
+// ConfirmPersistenceChangeAnnotation confirms changes to the datastores connection address, SQL plugin or
+// database name when set to "true". Without it, the operator stops reconciling the cluster on such changes.
+const ConfirmPersistenceChangeAnnotation = "temporal.io/confirm-persistence-change"
+
+// ScaleDownNextStepAnnotation is set by the operator on service deployments being scaled down
+// one pod at a time, and holds the time the next pod can be removed at.
+const ScaleDownNextStepAnnotation = "temporal.io/scale-down-next-step"
+
+// RestartServiceAnnotation triggers a rolling restart of the listed services pods.
+// It holds a comma-separated list of temporal service names, or "all" to restart every service.
+// The operator clears the annotation once the restart is scheduled.
+const RestartServiceAnnotation = "temporal.io/restart-service"
+
+// RerunSchemaSetupAnnotation makes the operator run the datastores setup schema jobs again when set to "true".
+// The operator clears the annotation once the jobs are scheduled.
+const RerunSchemaSetupAnnotation = "temporal.io/rerun-schema-setup"
+
+// RefreshCertificatesAnnotation makes cert-manager issue again the cluster mTLS leaf certificates when set to "true".
+// The operator clears the annotation once the certificates secrets are deleted.
+const RefreshCertificatesAnnotation = "temporal.io/refresh-certificates"
+
+// DebugPodAnnotation makes the operator attach an ephemeral debug container to the named temporal service pod.
+// The container runs the admin tools image, with the service volumes and certificates mounted.
+// The operator clears the annotation once the container is attached.
+const DebugPodAnnotation = "temporal.io/debug-pod"
+
+// DebugImageAnnotation overrides the image of the debug container attached using DebugPodAnnotation.
+// The operator clears it along with DebugPodAnnotation.
+const DebugImageAnnotation = "temporal.io/debug-image"
+
+// DiagnoseShardsAnnotation makes the operator inspect the listed history shards and store a report
+// in the "<cluster>-shard-diagnostics" ConfigMap. It holds "all", a shard id or a range of shard ids like "1-128".
+// The operator clears the annotation once the report is stored.
+const DiagnoseShardsAnnotation = "temporal.io/diagnose-shards"
+
+// DeletionReportAnnotation makes the operator store in the "<cluster>-deletion-report" ConfigMap the resources
+// and data that deleting the cluster would delete or keep, when set to "true". Nothing is deleted.
+// The operator clears the annotation once the report is stored.
+const DeletionReportAnnotation = "temporal.io/deletion-report"
+
+// AllServices is the RestartServiceAnnotation value restarting every temporal service.
+const AllServices = "all"
+
+// ActionAnnotations are the annotations triggering one-off operations on the cluster.
+var ActionAnnotations = []string{
+	RestartServiceAnnotation,
+	RerunSchemaSetupAnnotation,
+	RefreshCertificatesAnnotation,
+}
+
 // LogSpec contains the temporal logging configuration.
 type LogSpec struct {
 	// Stdout is true if the output needs to goto standard out; default is stderr.
@@ -100,9 +165,333 @@ type ServiceSpec struct {
 	// InitContainers adds a list of init containers to the service's deployment.
 	// +optional
 	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+	// ExtraArgs adds arguments to the service container.
+	// They are passed to the image entrypoint, use them to set flags that can't be
+	// expressed in the server configuration file.
+	// +optional
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+	// GracefulShutdown configures how the service pods leave the cluster when they are terminated.
+	// +optional
+	GracefulShutdown *GracefulShutdownSpec `json:"gracefulShutdown,omitempty"`
+	// DeploymentStrategy is the strategy used to replace the service pods.
+	// Defaults to RollingUpdate. Use Recreate when a migration requires all the service pods
+	// running the previous version to be stopped before starting new ones.
+	// +optional
+	DeploymentStrategy *appsv1.DeploymentStrategy `json:"deploymentStrategy,omitempty"`
+	// Autoscaler configures how node autoscalers (cluster-autoscaler, Karpenter) may evict the service pods
+	// when consolidating nodes. Restricting evictions of history pods avoids constant shard ownership changes.
+	// +optional
+	Autoscaler *AutoscalerSpec `json:"autoscaler,omitempty"`
+	// MemoryProtection configures how the service pods are protected against memory pressure.
+	// +optional
+	MemoryProtection *MemoryProtectionSpec `json:"memoryProtection,omitempty"`
+	// Traffic configures how the frontend Service routes client connections to the frontend pods.
+	// Only supported by the frontend service.
+	// +optional
+	Traffic *ServiceTrafficSpec `json:"traffic,omitempty"`
+	// Proxy serves the frontend through an authenticating proxy sidecar: the frontend binds its ports
+	// on localhost only and is reachable through the sidecar only.
+	// Only supported by the frontend service.
+	// +optional
+	Proxy *FrontendProxySpec `json:"proxy,omitempty"`
+	// PostStart is the handler run right after the service container is created,
+	// for instance to prime caches or wait for a sidecar. Kubernetes doesn't start
+	// probing the container until the handler completes, and restarts it if the handler fails.
+	// +optional
+	PostStart *corev1.LifecycleHandler `json:"postStart,omitempty"`
+	// Warmup holds the pods out of the service readiness until they acquired their history shards,
+	// so rollouts don't replace the next pod while shards are still moving.
+	// Only supported by the history service.
+	// +optional
+	Warmup *WarmupSpec `json:"warmup,omitempty"`
+	// Drain puts the cluster in drain mode: the frontend proxy rejects new workflow executions
+	// while in-flight executions keep progressing. Useful before migrations or planned failovers.
+	// Only supported by the frontend service, requires the frontend proxy.
+	// +optional
+	Drain bool `json:"drain,omitempty"`
 	// ServiceAccountOverride
 }
 
+// ShardsAcquiredConditionType is the pod readiness gate set by the operator once a history pod
+// acquired its share of the history shards.
+const ShardsAcquiredConditionType corev1.PodConditionType = "temporal.io/shards-acquired"
+
+// WarmupSpec configures the warmup of the history pods.
+type WarmupSpec struct {
+	// Enabled adds a readiness gate on the history pods, set by the operator once the pod owns at least
+	// half of its even share of the history shards, or once the timeout elapsed.
+	// Requires internode mTLS to be disabled or provided by cert-manager, as the operator asks each history pod which shards it owns.
+	Enabled bool `json:"enabled"`
+	// Timeout is the maximum time a pod is held out of readiness once its containers are ready.
+	// Defaults to 2 minutes.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// IsEnabled returns true if the warmup readiness gate is enabled.
+func (s *WarmupSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// GetTimeout returns the maximum time a pod is held out of readiness.
+func (s *WarmupSpec) GetTimeout() time.Duration {
+	if s == nil || s.Timeout == nil {
+		return 2 * time.Minute
+	}
+	return s.Timeout.Duration
+}
+
+// FrontendProxySpec configures the authenticating proxy sidecar exposing the frontend.
+// The frontend binds its gRPC and membership ports on localhost (rpc.bindOnLocalHost), the other
+// services bind on their pod IP. The sidecar terminates the clients TLS connections, requires a client
+// certificate issued by the frontend CA and forwards the requests to the frontend.
+type FrontendProxySpec struct {
+	// Enabled defines if the frontend is only exposed through the proxy sidecar.
+	// Requires the internal frontend and mTLS using cert-manager for the frontend.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// Image defines the envoy docker image the proxy should run.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Version defines the envoy image tag.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// AllowedClientNames restricts the clients allowed to connect to the DNS names of their certificates.
+	// Clients presenting any certificate issued by the frontend CA are allowed if empty.
+	// +optional
+	AllowedClientNames []string `json:"allowedClientNames,omitempty"`
+	// Compute Resources required by the proxy.
+	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Mirroring shadows a share of the read-only frontend requests to the frontend running the target
+	// version during blue/green upgrades, so its errors can be compared before switching the traffic.
+	// +optional
+	Mirroring *FrontendMirroringSpec `json:"mirroring,omitempty"`
+}
+
+// FrontendMirroringSpec configures the mirroring of the frontend read-only requests to the target version.
+// Mirrored requests are fire and forget: their responses are discarded and never reach the clients.
+type FrontendMirroringSpec struct {
+	// Enabled mirrors the read-only requests served by the proxy to the blue/green target frontend.
+	// Requires the BlueGreen upgrade strategy.
+	Enabled bool `json:"enabled"`
+	// Percentage is the share of the read-only requests mirrored to the target frontend.
+	// Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percentage *int32 `json:"percentage,omitempty"`
+	// Duration is how long the requests are mirrored to the ready target frontends
+	// before the frontend traffic is switched to them.
+	// Defaults to 10 minutes.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// IsEnabled returns true if the frontend requests mirroring is enabled.
+func (s *FrontendMirroringSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// GetPercentage returns the share of the read-only requests mirrored, defaulting to 10.
+func (s *FrontendMirroringSpec) GetPercentage() int32 {
+	if s == nil || s.Percentage == nil {
+		return 10
+	}
+	return *s.Percentage
+}
+
+// GetDuration returns how long the requests are mirrored before switching the traffic, defaulting to 10 minutes.
+func (s *FrontendMirroringSpec) GetDuration() time.Duration {
+	if s == nil || s.Duration == nil {
+		return 10 * time.Minute
+	}
+	return s.Duration.Duration
+}
+
+// IsEnabled returns true if the frontend is exposed through the proxy sidecar.
+func (s *FrontendProxySpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+const (
+	// TopologyModeAnnotation enables topology aware routing on a Service.
+	TopologyModeAnnotation = "service.kubernetes.io/topology-mode"
+	// TopologyAwareHintsAnnotation enables topology aware routing on a Service, on kubernetes versions older than 1.27.
+	TopologyAwareHintsAnnotation = "service.kubernetes.io/topology-aware-hints"
+	// TrafficReadyConditionType is the pod readiness gate set by the operator once a frontend pod
+	// has been ready for the configured readiness gate delay.
+	TrafficReadyConditionType corev1.PodConditionType = "temporal.io/traffic-ready"
+)
+
+// ServiceTrafficSpec configures how a Service routes client connections to the service pods.
+type ServiceTrafficSpec struct {
+	// PublishNotReadyAddresses keeps the pods in the Service endpoints while they are not ready.
+	// Terminating pods keep receiving connections until their preStop delay ends, so long-poll clients
+	// aren't all reset when the pods readiness flips. Pair it with gracefulShutdown.preStopDelay.
+	// +optional
+	PublishNotReadyAddresses bool `json:"publishNotReadyAddresses,omitempty"`
+	// TopologyAwareRouting sets the "service.kubernetes.io/topology-mode" annotation to "Auto",
+	// keeping client connections in their zone when enough endpoints are available.
+	// On kubernetes versions older than 1.27, the "service.kubernetes.io/topology-aware-hints" annotation is used instead.
+	// +optional
+	TopologyAwareRouting bool `json:"topologyAwareRouting,omitempty"`
+	// ReadinessGateDelay adds the "temporal.io/traffic-ready" readiness gate to the pods.
+	// The operator sets it once the pod containers have been ready for the delay, giving time for the
+	// membership ring to converge before the pod receives traffic. Rollouts wait for the gate before
+	// replacing the next pods.
+	// +optional
+	ReadinessGateDelay *metav1.Duration `json:"readinessGateDelay,omitempty"`
+}
+
+// GetServiceAnnotations returns the Service annotations matching the traffic spec.
+// If topologyMode is false, the annotation supported by kubernetes versions older than 1.27 is used.
+func (s *ServiceTrafficSpec) GetServiceAnnotations(topologyMode bool) map[string]string {
+	annotations := map[string]string{}
+	if s != nil && s.TopologyAwareRouting {
+		if topologyMode {
+			annotations[TopologyModeAnnotation] = "Auto"
+		} else {
+			annotations[TopologyAwareHintsAnnotation] = "auto"
+		}
+	}
+	return annotations
+}
+
+// ReadinessGateEnabled returns true if the operator manages a readiness gate on the pods.
+func (s *ServiceTrafficSpec) ReadinessGateEnabled() bool {
+	return s != nil && s.ReadinessGateDelay != nil
+}
+
+// MemoryProtectionSpec configures how a service is protected against memory pressure.
+type MemoryProtectionSpec struct {
+	// Guaranteed sets the service container limits to its requests, giving the pods the Guaranteed QoS class.
+	// The kubelet assigns the lowest oom_score_adj to guaranteed pods: they are the last ones killed
+	// when the node runs out of memory. Requires cpu and memory requests to be set.
+	// +optional
+	Guaranteed bool `json:"guaranteed,omitempty"`
+	// HeadroomPercent is the share of the container memory limit kept out of the Go heap.
+	// The operator sets GOMEMLIMIT to the remaining share, so the garbage collector reclaims memory
+	// before the cgroup limit is reached and the container is OOM killed. Requires a memory limit.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=90
+	// +optional
+	HeadroomPercent *int32 `json:"headroomPercent,omitempty"`
+}
+
+// GetResources returns the service container resources, with the limits
+// set to the requests when the guaranteed QoS class is requested.
+func (s *MemoryProtectionSpec) GetResources(resources corev1.ResourceRequirements) corev1.ResourceRequirements {
+	result := *resources.DeepCopy()
+	if s == nil || !s.Guaranteed {
+		return result
+	}
+
+	if result.Limits == nil {
+		result.Limits = corev1.ResourceList{}
+	}
+
+	for name, quantity := range result.Requests {
+		if _, ok := result.Limits[name]; !ok {
+			result.Limits[name] = quantity
+		}
+	}
+
+	return result
+}
+
+// GoMemoryLimit returns the GOMEMLIMIT value in bytes for the provided container resources,
+// or 0 if no headroom is configured or the container has no memory limit.
+func (s *MemoryProtectionSpec) GoMemoryLimit(resources corev1.ResourceRequirements) int64 {
+	if s == nil || s.HeadroomPercent == nil {
+		return 0
+	}
+
+	limit := resources.Limits.Memory().Value()
+	return limit / 100 * int64(100-*s.HeadroomPercent)
+}
+
+const (
+	// SafeToEvictAnnotation tells cluster-autoscaler if a pod can be evicted when scaling down nodes.
+	SafeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+	// DoNotDisruptAnnotation prevents Karpenter from voluntarily disrupting the node running the pod.
+	DoNotDisruptAnnotation = "karpenter.sh/do-not-disrupt"
+)
+
+// AutoscalerSpec contains the node autoscalers scheduling hints for a service pods.
+type AutoscalerSpec struct {
+	// SafeToEvict sets the "cluster-autoscaler.kubernetes.io/safe-to-evict" annotation on the service pods.
+	// Set it to false to prevent cluster-autoscaler from removing the nodes running the service pods.
+	// +optional
+	SafeToEvict *bool `json:"safeToEvict,omitempty"`
+	// DoNotDisrupt sets the "karpenter.sh/do-not-disrupt" annotation on the service pods,
+	// preventing Karpenter from consolidating the nodes running them.
+	// +optional
+	DoNotDisrupt bool `json:"doNotDisrupt,omitempty"`
+}
+
+// GetPodAnnotations returns the pod annotations matching the autoscaler scheduling hints.
+func (s *AutoscalerSpec) GetPodAnnotations() map[string]string {
+	annotations := map[string]string{}
+	if s == nil {
+		return annotations
+	}
+
+	if s.SafeToEvict != nil {
+		annotations[SafeToEvictAnnotation] = strconv.FormatBool(*s.SafeToEvict)
+	}
+
+	if s.DoNotDisrupt {
+		annotations[DoNotDisruptAnnotation] = "true"
+	}
+
+	return annotations
+}
+
+// GracefulShutdownSpec configures how a service leaves the cluster when its pods are terminated.
+type GracefulShutdownSpec struct {
+	// PreStopDelay delays the termination signal sent to the service container using a preStop hook,
+	// giving time for the pod to be removed from the service endpoints before it stops serving requests.
+	// +optional
+	PreStopDelay *metav1.Duration `json:"preStopDelay,omitempty"`
+	// DrainDuration is how long the service keeps serving requests after evicting itself from the
+	// membership ring, letting its peers take over its shards or task queues.
+	// It sets the "<service>.shutdownDrainDuration" dynamic config key, so it requires spec.dynamicConfig to be set.
+	// Only supported by the frontend, history and matching services.
+	// +optional
+	DrainDuration *metav1.Duration `json:"drainDuration,omitempty"`
+	// CoordinatedScaleDown makes the operator remove pods one at a time when the service replicas are reduced,
+	// waiting for the previously removed pod to drain before removing the next one.
+	// It avoids schedule-to-start latency spikes caused by many task queue partitions moving at once.
+	// Only supported by the matching service, requires drainDuration to be set.
+	// +optional
+	CoordinatedScaleDown bool `json:"coordinatedScaleDown,omitempty"`
+}
+
+// CoordinatedScaleDownEnabled returns true if pods are removed one at a time on scale-down.
+func (s *GracefulShutdownSpec) CoordinatedScaleDownEnabled() bool {
+	return s != nil && s.CoordinatedScaleDown && s.DrainDuration != nil
+}
+
+// GetTerminationGracePeriod returns the pod termination grace period needed to shut down gracefully,
+// on top of the provided base period.
+func (s *GracefulShutdownSpec) GetTerminationGracePeriod(base time.Duration) time.Duration {
+	if s == nil {
+		return base
+	}
+
+	if s.PreStopDelay != nil {
+		base += s.PreStopDelay.Duration
+	}
+
+	if s.DrainDuration != nil {
+		base += s.DrainDuration.Duration
+	}
+
+	return base
+}
+
 // InternalFrontendServiceSpec contains temporal internal frontend service specifications.
 type InternalFrontendServiceSpec struct {
 	ServiceSpec `json:",inline"`
@@ -163,6 +552,38 @@ func (s *ServicesSpec) GetServiceSpec(name primitives.ServiceName) (*ServiceSpec
 	}
 }
 
+// SetServiceReplicas sets the replicas of a service from its name.
+func (s *ServicesSpec) SetServiceReplicas(name primitives.ServiceName, replicas int32) error {
+	var spec **ServiceSpec
+	switch name {
+	case primitives.FrontendService:
+		spec = &s.Frontend
+	case primitives.InternalFrontendService:
+		if s.InternalFrontend == nil {
+			s.InternalFrontend = &InternalFrontendServiceSpec{}
+		}
+		s.InternalFrontend.Replicas = &replicas
+		return nil
+	case primitives.HistoryService:
+		spec = &s.History
+	case primitives.MatchingService:
+		spec = &s.Matching
+	case primitives.WorkerService:
+		spec = &s.Worker
+	case primitives.AllServices, primitives.ServerService, primitives.UnitTestService:
+		fallthrough
+	default:
+		return fmt.Errorf("unknown service %s", name)
+	}
+
+	if *spec == nil {
+		*spec = &ServiceSpec{}
+	}
+	(*spec).Replicas = &replicas
+
+	return nil
+}
+
 // ServiceSpecOverride provides the ability to override the generated manifests of a temporal service.
 type ServiceSpecOverride struct {
 	// Override configuration for the temporal service Deployment.
@@ -291,6 +712,7 @@ type ElasticsearchSpec struct {
 	// +kubebuilder:validation:Pattern=`^v(6|7|8)$`
 	Version string `json:"version"`
 	// URL is the connection url to connect to the instance.
+	// Defaults to the managed instance service url when managed is enabled.
 	// +kubebuilder:validation:Pattern=`^https?:\/\/.+$`
 	URL string `json:"url"`
 	// Username is the username to be used for the connection.
@@ -309,6 +731,92 @@ type ElasticsearchSpec struct {
 	// EnableHealthcheck enables or disables healthcheck on the temporal cluster's es client.
 	// +optional
 	EnableHealthcheck bool `json:"enableHealthcheck"`
+	// Managed makes the operator run a single-node Elasticsearch instance for the cluster.
+	// It's meant to evaluate advanced visibility on development and test clusters only:
+	// the instance runs without security, replication nor backups.
+	// +optional
+	Managed *ManagedElasticsearchSpec `json:"managed,omitempty"`
+	// SearchAttributes are the custom search attributes expected in the visibility indices mappings, by name.
+	// +optional
+	SearchAttributes map[string]SearchAttributeType `json:"searchAttributes,omitempty"`
+	// MappingCheck periodically compares the visibility indices mappings with the system search attributes
+	// of the cluster version and the declared custom search attributes.
+	// +optional
+	MappingCheck *ElasticsearchMappingCheckSpec `json:"mappingCheck,omitempty"`
+}
+
+// SearchAttributeType is the type of a custom search attribute.
+// +kubebuilder:validation:Enum=Keyword;Text;Int;Double;Bool;Datetime;KeywordList
+type SearchAttributeType string
+
+const (
+	// SearchAttributeTypeKeyword is a keyword search attribute.
+	SearchAttributeTypeKeyword SearchAttributeType = "Keyword"
+	// SearchAttributeTypeText is a full-text search attribute.
+	SearchAttributeTypeText SearchAttributeType = "Text"
+	// SearchAttributeTypeInt is an integer search attribute.
+	SearchAttributeTypeInt SearchAttributeType = "Int"
+	// SearchAttributeTypeDouble is a floating point search attribute.
+	SearchAttributeTypeDouble SearchAttributeType = "Double"
+	// SearchAttributeTypeBool is a boolean search attribute.
+	SearchAttributeTypeBool SearchAttributeType = "Bool"
+	// SearchAttributeTypeDatetime is a date time search attribute.
+	SearchAttributeTypeDatetime SearchAttributeType = "Datetime"
+	// SearchAttributeTypeKeywordList is a list of keywords search attribute.
+	SearchAttributeTypeKeywordList SearchAttributeType = "KeywordList"
+)
+
+// ElasticsearchMappingCheckSpec configures the visibility indices mappings check.
+type ElasticsearchMappingCheckSpec struct {
+	// Enabled defines if the operator should check the visibility indices mappings.
+	Enabled bool `json:"enabled"`
+	// Interval is the duration between two checks. Defaults to 10 minutes.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// AutoRepair adds the missing fields to the indices mappings.
+	// Type conflicts can't be fixed without reindexing and are only reported.
+	// +optional
+	AutoRepair bool `json:"autoRepair,omitempty"`
+}
+
+// IsEnabled returns true if the visibility indices mappings should be checked.
+func (s *ElasticsearchMappingCheckSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// GetInterval returns the duration between two checks.
+func (s *ElasticsearchMappingCheckSpec) GetInterval() time.Duration {
+	if s == nil || s.Interval == nil || s.Interval.Duration <= 0 {
+		return 10 * time.Minute
+	}
+	return s.Interval.Duration
+}
+
+// ManagedElasticsearchSpec configures the single-node Elasticsearch instance run by the operator.
+type ManagedElasticsearchSpec struct {
+	// Enabled defines if the operator should run the Elasticsearch instance.
+	Enabled bool `json:"enabled"`
+	// Image defines the Elasticsearch docker image.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// ImageVersion defines the Elasticsearch image tag. Defaults to the latest tested release of the store version.
+	// +optional
+	ImageVersion string `json:"imageVersion,omitempty"`
+	// Storage is the size of the instance data volume.
+	// +optional
+	Storage *resource.Quantity `json:"storage,omitempty"`
+	// StorageClassName is the storage class of the instance data volume.
+	// Defaults to the kubernetes cluster default storage class.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Resources are the compute resources of the instance container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// IsEnabled returns true if the operator runs the Elasticsearch instance.
+func (s *ManagedElasticsearchSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
 }
 
 // CassandraConsistencySpec sets the consistency level for regular & serial queries to Cassandra.
@@ -350,6 +858,27 @@ type CassandraSpec struct {
 	// DisableInitialHostLookup instructs the gocql client to connect only using the supplied hosts.
 	// +optional
 	DisableInitialHostLookup bool `json:"disableInitialHostLookup"`
+	// Variant is the Cassandra compatible database the datastore runs on.
+	// Setting it to "scylla" adjusts the defaults to ScyllaDB known behaviors.
+	// +kubebuilder:validation:Enum=cassandra;scylla
+	// +kubebuilder:default:=cassandra
+	// +optional
+	Variant CassandraVariant `json:"variant,omitempty"`
+}
+
+// CassandraVariant is the Cassandra compatible database a datastore runs on.
+type CassandraVariant string
+
+const (
+	// ApacheCassandraVariant is Apache Cassandra.
+	ApacheCassandraVariant CassandraVariant = "cassandra"
+	// ScyllaCassandraVariant is ScyllaDB.
+	ScyllaCassandraVariant CassandraVariant = "scylla"
+)
+
+// IsScylla returns true if the datastore runs on ScyllaDB.
+func (c *CassandraSpec) IsScylla() bool {
+	return c != nil && c.Variant == ScyllaCassandraVariant
 }
 
 type DatastoreType string
@@ -401,6 +930,31 @@ type DatastoreSpec struct {
 	// SkipCreate instructs the operator to skip creating the database for SQL datastores or to skip creating keyspace for Cassandra. Use this option if your database or keyspace has already been provisioned by an administrator.
 	// +optional
 	SkipCreate bool `json:"skipCreate"`
+	// SchemaToolExtraArgs adds arguments to the schema tool (temporal-sql-tool or temporal-cassandra-tool)
+	// commands run by the schema jobs. They are appended as-is after the connection arguments.
+	// +optional
+	SchemaToolExtraArgs []string `json:"schemaToolExtraArgs,omitempty"`
+	// ServiceAlias makes the operator create an ExternalName service aliasing the datastore host
+	// in the cluster namespace, and connect to the datastore through it.
+	// Only supported for SQL datastores.
+	// +optional
+	ServiceAlias *DatastoreServiceAliasSpec `json:"serviceAlias,omitempty"`
+}
+
+// DatastoreServiceAliasSpec configures the ExternalName service aliasing a datastore host.
+type DatastoreServiceAliasSpec struct {
+	// Enabled defines if the operator should create the alias service.
+	Enabled bool `json:"enabled"`
+}
+
+// ManagedElasticsearchEnabled returns true if the datastore is an Elasticsearch instance run by the operator.
+func (s *DatastoreSpec) ManagedElasticsearchEnabled() bool {
+	return s != nil && s.Elasticsearch != nil && s.Elasticsearch.Managed.IsEnabled()
+}
+
+// ServiceAliasEnabled returns true if the datastore is reached through an ExternalName service alias.
+func (s *DatastoreSpec) ServiceAliasEnabled() bool {
+	return s.ServiceAlias != nil && s.ServiceAlias.Enabled && s.SQL != nil
 }
 
 // LowerCaseName returns the datastore name in lower case.
@@ -436,6 +990,19 @@ func (s *DatastoreSpec) IsSQL() bool {
 	return slices.Contains(SQLDataStores, s.GetType())
 }
 
+// GetDatabaseName returns the SQL database name or the cassandra keyspace of the datastore.
+// It returns empty for other datastore types.
+func (s *DatastoreSpec) GetDatabaseName() string {
+	switch {
+	case s.SQL != nil:
+		return s.SQL.DatabaseName
+	case s.Cassandra != nil:
+		return s.Cassandra.Keyspace
+	default:
+		return ""
+	}
+}
+
 const (
 	dataStoreTLSCertificateBasePath = "/etc/tls/datastores"
 	dataStoreTLSCAPrefix            = "ca"
@@ -447,6 +1014,8 @@ const (
 	DataStoreClientTLSKeyFileName = "client.key"
 	// DataStoreClientTLSCaFileName is the default client TLS ca file name.
 	DataStoreClientTLSCaFileName = "ca.pem"
+	// DecryptedPasswordSecretKey is the key of the password in the secrets holding decrypted datastores passwords.
+	DecryptedPasswordSecretKey = "password"
 )
 
 // GetTLSKeyFileMountPath returns the client TLS cert mount path.
@@ -495,8 +1064,198 @@ type TemporalPersistenceSpec struct {
 	// +optional
 	SecondaryVisibilityStore *DatastoreSpec `json:"secondaryVisibilityStore,omitempty"`
 	// AdvancedVisibilityStore holds the advanced visibility datastore specs.
+	// Deprecated: starting from temporal 1.21, use an Elasticsearch visibility store instead.
+	// This field will be removed in v1beta2.
 	// +optional
 	AdvancedVisibilityStore *DatastoreSpec `json:"advancedVisibilityStore,omitempty"`
+	// SecretDecryption enables operator-side decryption of the datastores passwords.
+	// When set, the values referenced by the datastores passwordSecretRef are expected to be encrypted:
+	// the operator decrypts them and provides the plain text passwords to the cluster using secrets it owns.
+	// +optional
+	SecretDecryption *SecretDecryptionSpec `json:"secretDecryption,omitempty"`
+	// RateLimits protects the datastores by limiting the queries temporal services send to them.
+	// Limits are applied through the dynamic config, so they require spec.dynamicConfig to be set.
+	// Keys explicitly set in spec.dynamicConfig.values take precedence.
+	// +optional
+	RateLimits *PersistenceRateLimitsSpec `json:"rateLimits,omitempty"`
+	// Hooks are user-provided SQL or CQL scripts run against the datastores
+	// before and after the schema setup and update jobs.
+	// +optional
+	Hooks *PersistenceHooksSpec `json:"hooks,omitempty"`
+}
+
+// PersistenceHooksSpec defines the scripts run around the schema jobs.
+// Scripts are run by the schema tool of the datastore, they must be idempotent
+// as jobs are retried on failure.
+type PersistenceHooksSpec struct {
+	// PreSetup scripts are run after the database creation, before the schema setup.
+	// +optional
+	PreSetup []PersistenceHook `json:"preSetup,omitempty"`
+	// PostSetup scripts are run after the schema setup.
+	// +optional
+	PostSetup []PersistenceHook `json:"postSetup,omitempty"`
+	// PreUpdate scripts are run before each schema update.
+	// +optional
+	PreUpdate []PersistenceHook `json:"preUpdate,omitempty"`
+	// PostUpdate scripts are run after each schema update.
+	// +optional
+	PostUpdate []PersistenceHook `json:"postUpdate,omitempty"`
+}
+
+// PersistenceHookPhase is the moment a persistence hook is run at.
+type PersistenceHookPhase string
+
+const (
+	PreSetupPersistenceHookPhase   PersistenceHookPhase = "pre-setup"
+	PostSetupPersistenceHookPhase  PersistenceHookPhase = "post-setup"
+	PreUpdatePersistenceHookPhase  PersistenceHookPhase = "pre-update"
+	PostUpdatePersistenceHookPhase PersistenceHookPhase = "post-update"
+)
+
+// PersistenceHookPhases lists the persistence hooks phases in their run order.
+var PersistenceHookPhases = []PersistenceHookPhase{
+	PreSetupPersistenceHookPhase,
+	PostSetupPersistenceHookPhase,
+	PreUpdatePersistenceHookPhase,
+	PostUpdatePersistenceHookPhase,
+}
+
+// Get returns the hooks of the provided phase.
+func (s *PersistenceHooksSpec) Get(phase PersistenceHookPhase) []PersistenceHook {
+	if s == nil {
+		return nil
+	}
+
+	switch phase {
+	case PreSetupPersistenceHookPhase:
+		return s.PreSetup
+	case PostSetupPersistenceHookPhase:
+		return s.PostSetup
+	case PreUpdatePersistenceHookPhase:
+		return s.PreUpdate
+	case PostUpdatePersistenceHookPhase:
+		return s.PostUpdate
+	default:
+		return nil
+	}
+}
+
+// PersistenceHook is a script run against a datastore.
+type PersistenceHook struct {
+	// Name of the hook, unique per phase.
+	//+kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// Store is the name of the datastore the script is run against.
+	//+kubebuilder:validation:Enum=default;visibility;secondaryVisibility
+	Store string `json:"store"`
+	// ConfigMapRef is the reference to the ConfigMap key holding the script.
+	ConfigMapRef ConfigMapKeyReference `json:"configMapRef"`
+}
+
+// MountPath returns the path the hook script is mounted at in the schema jobs.
+func (h *PersistenceHook) MountPath(phase PersistenceHookPhase) string {
+	return path.Join(PersistenceHooksMountPath, string(phase), h.Name)
+}
+
+// PersistenceHooksMountPath is the path the persistence hooks scripts are mounted at in the schema jobs.
+const PersistenceHooksMountPath = "/etc/temporal/hooks"
+
+// ConfigMapKeyReference contains enough information to locate the referenced Kubernetes ConfigMap key.
+type ConfigMapKeyReference struct {
+	// Name of the ConfigMap.
+	// +required
+	Name string `json:"name"`
+	// Key in the ConfigMap.
+	// +required
+	Key string `json:"key"`
+}
+
+// PersistenceRateLimitsSpec defines the datastores rate limits.
+type PersistenceRateLimitsSpec struct {
+	// Frontend limits the queries sent by the frontend service.
+	// +optional
+	Frontend *ServicePersistenceRateLimitsSpec `json:"frontend,omitempty"`
+	// History limits the queries sent by the history service.
+	// +optional
+	History *ServicePersistenceRateLimitsSpec `json:"history,omitempty"`
+	// Matching limits the queries sent by the matching service.
+	// +optional
+	Matching *ServicePersistenceRateLimitsSpec `json:"matching,omitempty"`
+	// Worker limits the queries sent by the worker service.
+	// +optional
+	Worker *ServicePersistenceRateLimitsSpec `json:"worker,omitempty"`
+	// TaskScheduler limits the rate at which history tasks are scheduled.
+	// When unset, the task scheduler falls back to the history persistence limits.
+	// +optional
+	TaskScheduler *TaskSchedulerRateLimitsSpec `json:"taskScheduler,omitempty"`
+	// VisibilityStore limits the queries sent to the visibility store by each host,
+	// overriding the service limits for this store.
+	// +optional
+	VisibilityStore *VisibilityStoreRateLimitsSpec `json:"visibilityStore,omitempty"`
+}
+
+// ServicePersistenceRateLimitsSpec defines the datastores rate limits of a temporal service.
+type ServicePersistenceRateLimitsSpec struct {
+	// MaxQPS is the maximum number of queries per second each host of the service can send.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxQPS *int32 `json:"maxQPS,omitempty"`
+	// GlobalMaxQPS is the maximum number of queries per second all the hosts of the service can send.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	GlobalMaxQPS *int32 `json:"globalMaxQPS,omitempty"`
+}
+
+// TaskSchedulerRateLimitsSpec defines the history task scheduler rate limits.
+type TaskSchedulerRateLimitsSpec struct {
+	// MaxQPS is the maximum number of tasks per second each history host can schedule.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxQPS *int32 `json:"maxQPS,omitempty"`
+	// GlobalMaxQPS is the maximum number of tasks per second all history hosts can schedule.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	GlobalMaxQPS *int32 `json:"globalMaxQPS,omitempty"`
+	// NamespaceMaxQPS is the maximum number of tasks per second each history host can schedule for a namespace.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NamespaceMaxQPS *int32 `json:"namespaceMaxQPS,omitempty"`
+	// GlobalNamespaceMaxQPS is the maximum number of tasks per second all history hosts can schedule for a namespace.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	GlobalNamespaceMaxQPS *int32 `json:"globalNamespaceMaxQPS,omitempty"`
+}
+
+// VisibilityStoreRateLimitsSpec defines the visibility store rate limits.
+type VisibilityStoreRateLimitsSpec struct {
+	// MaxReadQPS is the maximum number of read queries per second each host can send.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxReadQPS *int32 `json:"maxReadQPS,omitempty"`
+	// MaxWriteQPS is the maximum number of write queries per second each host can send.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxWriteQPS *int32 `json:"maxWriteQPS,omitempty"`
+}
+
+// SecretDecryptionProvider is the name of a provider decrypting secret material.
+type SecretDecryptionProvider string
+
+const (
+	// AESGCMSecretDecryptionProvider decrypts base64 encoded AES-GCM sealed values, prefixed by their nonce.
+	AESGCMSecretDecryptionProvider SecretDecryptionProvider = "aesgcm"
+)
+
+// SecretDecryptionSpec defines how the operator decrypts secret material.
+type SecretDecryptionSpec struct {
+	// Provider is the name of the provider decrypting the values.
+	// "aesgcm" is built in, other providers (like SOPS or cloud KMS) can be registered by custom operator builds.
+	// +kubebuilder:default:=aesgcm
+	// +optional
+	Provider SecretDecryptionProvider `json:"provider,omitempty"`
+	// KeyRef references the secret holding the key given to the provider.
+	// Defaults to the "key" key of the secret.
+	KeyRef *SecretKeyReference `json:"keyRef"`
 }
 
 func (p *TemporalPersistenceSpec) GetDatastores() []*DatastoreSpec {
@@ -574,26 +1333,161 @@ type TemporalUISpec struct {
 	// Service is an optional service resource configuration for the UI.
 	// +optional
 	Service *ObjectMetaOverride `json:"service,omitempty"`
-}
-
-// TemporalAdminToolsSpec defines parameters for the temporal admin tools within a Temporal cluster deployment.
-// Note that deployed admin tools version is the same as the cluster's version.
-type TemporalAdminToolsSpec struct {
-	// Enabled defines if the operator should deploy the admin tools alongside the cluster.
+	// PublicPath is the path the UI is served from, like "/temporal".
+	// Useful when the UI is hosted behind an existing reverse proxy or ingress on a subpath.
+	// When set, the operator-managed ingress routes this path to the UI.
+	// +kubebuilder:validation:Pattern=`^(/[^/]+)+$`
 	// +optional
-	Enabled bool `json:"enabled"`
-	// Image defines the temporal admin tools docker image the instance should run.
+	PublicPath string `json:"publicPath,omitempty"`
+	// ForwardHeaders is the list of HTTP request headers the UI forwards to the frontend
+	// as gRPC metadata, like the identity headers set by a trusted reverse proxy.
 	// +optional
-	Image string `json:"image"`
-	// Compute Resources required by the ui.
-	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	ForwardHeaders []string `json:"forwardHeaders,omitempty"`
+	// BannerText is a text displayed on top of every UI page.
+	// Useful to tell which environment is displayed when one UI is deployed per cluster.
 	// +optional
-	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	BannerText string `json:"bannerText,omitempty"`
+	// DefaultNamespace is the namespace displayed when opening the UI.
+	// +optional
+	DefaultNamespace string `json:"defaultNamespace,omitempty"`
+	// ShowTemporalSystemNamespace displays the temporal-system namespace in the UI.
+	// +optional
+	ShowTemporalSystemNamespace bool `json:"showTemporalSystemNamespace,omitempty"`
+	// DisableWriteActions disables all the UI actions modifying workflows, like cancel, terminate or signal.
+	// +optional
+	DisableWriteActions bool `json:"disableWriteActions,omitempty"`
+	// Codec configures the remote codec server used by the UI to decode payloads.
+	// +optional
+	Codec *TemporalUICodecSpec `json:"codec,omitempty"`
+	// Env adds environment variables to the UI container.
+	// They take precedence over the variables set by the operator, allowing any UI server option to be set.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// OAuth2Proxy deploys oauth2-proxy in front of the UI to authenticate users
+	// against an OpenID Connect provider.
+	// +optional
+	OAuth2Proxy *TemporalUIOAuth2ProxySpec `json:"oauth2Proxy,omitempty"`
+}
+
+// TemporalUIOAuth2ProxySpec defines the oauth2-proxy sidecar deployed in front of the UI.
+type TemporalUIOAuth2ProxySpec struct {
+	// Image defines the oauth2-proxy docker image.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Version defines the oauth2-proxy image tag.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// IssuerURL is the OpenID Connect issuer URL.
+	IssuerURL string `json:"issuerURL"`
+	// ClientID is the OAuth2 client ID.
+	ClientID string `json:"clientID"`
+	// ClientSecretRef references the secret key holding the OAuth2 client secret.
+	// Defaults to the "client-secret" key.
+	ClientSecretRef SecretKeyReference `json:"clientSecretRef"`
+	// CookieSecretRef references the secret key holding the secret used to sign the session cookies.
+	// Defaults to the "cookie-secret" key.
+	CookieSecretRef SecretKeyReference `json:"cookieSecretRef"`
+	// AllowedGroups restricts the UI access to members of the given groups.
+	// +optional
+	AllowedGroups []string `json:"allowedGroups,omitempty"`
+	// EmailDomains restricts the UI access to the given email domains. Defaults to all domains.
+	// +optional
+	EmailDomains []string `json:"emailDomains,omitempty"`
+	// ExtraArgs are additional arguments passed to oauth2-proxy.
+	// +optional
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+	// Resources are the compute resources of the oauth2-proxy container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// TemporalUICodecSpec defines the remote codec server used by the UI.
+type TemporalUICodecSpec struct {
+	// Endpoint is the URL of the codec server.
+	Endpoint string `json:"endpoint"`
+	// PassAccessToken sends the user access token to the codec server.
+	// +optional
+	PassAccessToken bool `json:"passAccessToken,omitempty"`
+	// IncludeCredentials includes cross-origin credentials in the requests to the codec server.
+	// +optional
+	IncludeCredentials bool `json:"includeCredentials,omitempty"`
+}
+
+// TemporalAdminToolsSpec defines parameters for the temporal admin tools within a Temporal cluster deployment.
+// Note that deployed admin tools version is the same as the cluster's version.
+type TemporalAdminToolsSpec struct {
+	// Enabled defines if the operator should deploy the admin tools alongside the cluster.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// Image defines the temporal admin tools docker image the instance should run.
+	// +optional
+	Image string `json:"image"`
+	// Compute Resources required by the ui.
+	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 	// Overrides adds some overrides to the resources deployed for the ui.
 	// +optional
 	Overrides *ServiceSpecOverride `json:"overrides,omitempty"`
 }
 
+// GRPCWebSpec defines the gRPC-web proxy deployed in front of the frontend service.
+// The proxy translates gRPC-web requests from browsers into gRPC requests to the frontend.
+type GRPCWebSpec struct {
+	// Enabled defines if the operator should deploy the gRPC-web proxy alongside the cluster.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// Image defines the envoy docker image the proxy should run.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Version defines the envoy image tag.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Number of desired replicas for the proxy. Default to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Port is the port the proxy listens on. Default to 8080.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+	// Compute Resources required by the proxy.
+	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// CORS configures the cross-origin requests allowed by the proxy.
+	// Cross-origin requests are rejected if not set.
+	// +optional
+	CORS *GRPCWebCORSSpec `json:"cors,omitempty"`
+	// Service is an optional service resource configuration for the proxy.
+	// +optional
+	Service *ObjectMetaOverride `json:"service,omitempty"`
+}
+
+// GRPCWebCORSSpec defines the cross-origin requests allowed by the gRPC-web proxy.
+type GRPCWebCORSSpec struct {
+	// AllowedOrigins are the origins allowed to call the proxy, like "https://app.example.com".
+	// Use "*" to allow any origin.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Pattern=`^(\*|https?://[^\s,"]+)$`
+	AllowedOrigins []string `json:"allowedOrigins"`
+	// AllowedHeaders are the request headers allowed in addition to the gRPC-web ones.
+	// +kubebuilder:validation:items:Pattern=`^[A-Za-z0-9-]+$`
+	// +optional
+	AllowedHeaders []string `json:"allowedHeaders,omitempty"`
+	// ExposedHeaders are the response headers exposed to the browser in addition to grpc-status and grpc-message.
+	// +kubebuilder:validation:items:Pattern=`^[A-Za-z0-9-]+$`
+	// +optional
+	ExposedHeaders []string `json:"exposedHeaders,omitempty"`
+	// AllowCredentials allows the requests to include credentials, like cookies.
+	// +optional
+	AllowCredentials bool `json:"allowCredentials,omitempty"`
+	// MaxAge is the duration the browser can cache the preflight requests results.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
 // MTLSProvider is the enum for support mTLS provider.
 type MTLSProvider string
 
@@ -682,13 +1576,39 @@ type CertificatesDurationSpec struct {
 	InternodeCertificate *metav1.Duration `json:"internodeCertificate"`
 }
 
+// CertificateIssuerKind is the kind of a cert-manager issuer.
+// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+type CertificateIssuerKind string
+
+const (
+	// IssuerCertificateIssuerKind references a namespaced Issuer, living in the cluster namespace.
+	IssuerCertificateIssuerKind CertificateIssuerKind = "Issuer"
+	// ClusterIssuerCertificateIssuerKind references a ClusterIssuer.
+	ClusterIssuerCertificateIssuerKind CertificateIssuerKind = "ClusterIssuer"
+)
+
+// CertificateIssuerReference references a cert-manager issuer.
+type CertificateIssuerReference struct {
+	// Name is the issuer name.
+	Name string `json:"name"`
+	// Kind is the issuer kind.
+	// +kubebuilder:default=ClusterIssuer
+	// +optional
+	Kind CertificateIssuerKind `json:"kind,omitempty"`
+}
+
 // MTLSSpec defines parameters for the temporal encryption in transit with mTLS.
 type MTLSSpec struct {
 	// Provider defines the tool used to manage mTLS certificates.
-	// +kubebuilder:default=cert-manager
+	// Defaults to the operator default mTLS provider, or cert-manager.
 	// +kubebuilder:validation:Enum=cert-manager;linkerd;istio
 	// +optional
-	Provider MTLSProvider `json:"provider"`
+	Provider MTLSProvider `json:"provider,omitempty"`
+	// IssuerRef references the cert-manager issuer signing the cluster root CA certificate.
+	// When not set, the root CA certificate is self-signed, unless the operator has a default ClusterIssuer.
+	// Useless if mTLS provider is not cert-manager.
+	// +optional
+	IssuerRef *CertificateIssuerReference `json:"issuerRef,omitempty"`
 	// Internode allows configuration of the internode traffic encryption.
 	// Useless if mTLS provider is not cert-manager.
 	// +optional
@@ -714,6 +1634,11 @@ type MTLSSpec struct {
 	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
 }
 
+// ExternalIssuerEnabled returns true if the root CA certificate is signed by a user provided issuer.
+func (m *MTLSSpec) ExternalIssuerEnabled() bool {
+	return m.IssuerRef != nil && m.IssuerRef.Name != ""
+}
+
 func (m *MTLSSpec) InternodeEnabled() bool {
 	return m.Internode != nil && m.Internode.Enabled
 }
@@ -748,9 +1673,33 @@ type PrometheusScrapeConfig struct {
 	ServiceMonitor *PrometheusScrapeConfigServiceMonitor `json:"serviceMonitor,omitempty"`
 }
 
+// PrometheusRuleSpec is the configuration for prometheus operator PrometheusRule.
+type PrometheusRuleSpec struct {
+	// Enabled defines if the operator should create a PrometheusRule alerting on the cluster schema jobs.
+	// The alerts are based on the operator metrics, which should be scraped by prometheus.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// Labels adds extra labels to the PrometheusRule.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// SchemaJobAlertThreshold is the duration a schema job can fail or run before alerting.
+	// Defaults to 15m.
+	// +optional
+	SchemaJobAlertThreshold *metav1.Duration `json:"schemaJobAlertThreshold,omitempty"`
+}
+
+// GetSchemaJobAlertThreshold returns the duration a schema job can fail or run before alerting.
+func (s *PrometheusRuleSpec) GetSchemaJobAlertThreshold() time.Duration {
+	if s.SchemaJobAlertThreshold == nil {
+		return 15 * time.Minute
+	}
+	return s.SchemaJobAlertThreshold.Duration
+}
+
 // PrometheusSpec is the configuration for prometheus reporter.
 type PrometheusSpec struct {
 	// Deprecated. Address for prometheus to serve metrics from.
+	// Use ListenPort instead, this field will be removed in v1beta2.
 	// +optional
 	// +deprecated
 	ListenAddress string `json:"listenAddress"`
@@ -760,6 +1709,9 @@ type PrometheusSpec struct {
 	// ScrapeConfig is the prometheus scrape configuration.
 	// +optional
 	ScrapeConfig *PrometheusScrapeConfig `json:"scrapeConfig,omitempty"`
+	// PrometheusRule is the configuration of the alerting rules created by the operator.
+	// +optional
+	PrometheusRule *PrometheusRuleSpec `json:"prometheusRule,omitempty"`
 }
 
 // MetricsSpec determines parameters for configuring metrics endpoints.
@@ -786,12 +1738,49 @@ type MetricsSpec struct {
 	// Prometheus reporter configuration.
 	// +optional
 	Prometheus *PrometheusSpec `json:"prometheus,omitempty"`
+	// Datadog autodiscovery configuration.
+	// +optional
+	Datadog *DatadogSpec `json:"datadog,omitempty"`
 }
 
 func (m *MetricsSpec) IsEnabled() bool {
 	return m != nil && m.Enabled
 }
 
+// DatadogSpec is the configuration of the Datadog autodiscovery annotations.
+// The annotations configure the Datadog agent openmetrics check to scrape
+// the prometheus endpoint of the temporal services.
+type DatadogSpec struct {
+	// Enabled adds the Datadog autodiscovery annotations on the temporal services pods.
+	// Requires the prometheus reporter to be configured.
+	Enabled bool `json:"enabled"`
+	// Namespace prefixes every metric name sent to Datadog.
+	// Defaults to "temporal".
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Metrics is the list of metric names or regular expressions collected by the check.
+	// Replaces the operator's default Temporal metric filters when set.
+	// +optional
+	Metrics []string `json:"metrics,omitempty"`
+	// Tags are added to every metric collected by the check.
+	// The "temporal_cluster" and "temporal_service" tags are always added.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+}
+
+// DatadogEnabled returns true if the Datadog autodiscovery annotations should be added.
+func (m *MetricsSpec) DatadogEnabled() bool {
+	return m.IsEnabled() && m.Datadog != nil && m.Datadog.Enabled
+}
+
+// GetNamespace returns the Datadog metrics namespace.
+func (s *DatadogSpec) GetNamespace() string {
+	if s == nil || s.Namespace == "" {
+		return "temporal"
+	}
+	return s.Namespace
+}
+
 // Constraints is an alias for temporal's dynamicconfig.Constraints.
 // It describes under what conditions a ConstrainedValue should be used.
 type Constraints struct {
@@ -821,16 +1810,175 @@ type ConstrainedValue struct {
 	// Value json.RawMessage `json:"value"`
 }
 
+// QueueProcessorProfile is a named set of history queue processors tuning values.
+// +kubebuilder:validation:Enum=low-latency;high-throughput;resource-constrained
+type QueueProcessorProfile string
+
+const (
+	// LowLatencyQueueProcessorProfile polls queues more often to reduce tasks dispatch latency.
+	LowLatencyQueueProcessorProfile QueueProcessorProfile = "low-latency"
+	// HighThroughputQueueProcessorProfile processes larger batches with more workers.
+	HighThroughputQueueProcessorProfile QueueProcessorProfile = "high-throughput"
+	// ResourceConstrainedQueueProcessorProfile reduces the CPU and database load of queue processors.
+	ResourceConstrainedQueueProcessorProfile QueueProcessorProfile = "resource-constrained"
+)
+
+// ServerFeature is a temporal server feature which can be enabled using spec.features.
+// +kubebuilder:validation:Enum=UpdateWorkflowExecution;EagerActivities;EagerWorkflowStart;Nexus
+type ServerFeature string
+
+const (
+	// UpdateWorkflowExecutionServerFeature enables workflow updates.
+	UpdateWorkflowExecutionServerFeature ServerFeature = "UpdateWorkflowExecution"
+	// EagerActivitiesServerFeature enables eager activities execution.
+	EagerActivitiesServerFeature ServerFeature = "EagerActivities"
+	// EagerWorkflowStartServerFeature enables eager workflows start.
+	EagerWorkflowStartServerFeature ServerFeature = "EagerWorkflowStart"
+	// NexusServerFeature enables Nexus.
+	NexusServerFeature ServerFeature = "Nexus"
+)
+
+// Edition is a temporal server build edition.
+// +kubebuilder:validation:Enum=OSS;Custom
+type Edition string
+
+const (
+	// OSSEdition is the open source temporal server build.
+	OSSEdition Edition = "OSS"
+	// CustomEdition is a custom temporal server build, like a fork, supporting the capabilities it declares.
+	CustomEdition Edition = "Custom"
+)
+
+// EditionCapability is an optional config block whose support depends on the temporal server build.
+// +kubebuilder:validation:Enum=Archival;AdvancedVisibility;Replication;Authorization;InternalFrontend;DynamicConfig;MTLS
+type EditionCapability string
+
+const (
+	ArchivalEditionCapability           EditionCapability = "Archival"
+	AdvancedVisibilityEditionCapability EditionCapability = "AdvancedVisibility"
+	ReplicationEditionCapability        EditionCapability = "Replication"
+	AuthorizationEditionCapability      EditionCapability = "Authorization"
+	InternalFrontendEditionCapability   EditionCapability = "InternalFrontend"
+	DynamicConfigEditionCapability      EditionCapability = "DynamicConfig"
+	MTLSEditionCapability               EditionCapability = "MTLS"
+)
+
+// EditionSpec describes the temporal server build edition.
+type EditionSpec struct {
+	// Name is the edition of the temporal server build. Defaults to OSS.
+	// +optional
+	Name Edition `json:"name,omitempty"`
+	// Capabilities lists the optional config blocks supported by a Custom edition build.
+	// Ignored for the OSS edition.
+	// +optional
+	// +listType=set
+	Capabilities []EditionCapability `json:"capabilities,omitempty"`
+}
+
+// GetName returns the edition name.
+func (s *EditionSpec) GetName() Edition {
+	if s == nil || s.Name == "" {
+		return OSSEdition
+	}
+	return s.Name
+}
+
+// ExternalConfigKey is the key of the external config ConfigMaps holding the temporal server configuration.
+const ExternalConfigKey = "config_template.yaml"
+
+// ExternalConfigSpec references ConfigMaps holding user provided temporal server configurations.
+// Each ConfigMap must contain the configuration under the "config_template.yaml" key.
+type ExternalConfigSpec struct {
+	// ConfigMapRef is the ConfigMap used by services without a dedicated ConfigMap.
+	// +optional
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+	// Services allows using a dedicated ConfigMap per service.
+	// +optional
+	Services *ExternalConfigServicesSpec `json:"services,omitempty"`
+}
+
+// ExternalConfigServicesSpec references the dedicated ConfigMaps of each service.
+type ExternalConfigServicesSpec struct {
+	// +optional
+	Frontend *corev1.LocalObjectReference `json:"frontend,omitempty"`
+	// +optional
+	InternalFrontend *corev1.LocalObjectReference `json:"internalFrontend,omitempty"`
+	// +optional
+	History *corev1.LocalObjectReference `json:"history,omitempty"`
+	// +optional
+	Matching *corev1.LocalObjectReference `json:"matching,omitempty"`
+	// +optional
+	Worker *corev1.LocalObjectReference `json:"worker,omitempty"`
+}
+
+// IsEnabled returns true if services consume external configurations.
+func (s *ExternalConfigSpec) IsEnabled() bool {
+	return s != nil && (s.ConfigMapRef != nil || s.Services != nil)
+}
+
+// ConfigMapName returns the name of the ConfigMap holding the provided service configuration.
+// Returns an empty string if the service has no external configuration.
+func (s *ExternalConfigSpec) ConfigMapName(service primitives.ServiceName) string {
+	if s == nil {
+		return ""
+	}
+
+	if s.Services != nil {
+		var ref *corev1.LocalObjectReference
+		switch service {
+		case primitives.FrontendService:
+			ref = s.Services.Frontend
+		case primitives.InternalFrontendService:
+			ref = s.Services.InternalFrontend
+		case primitives.HistoryService:
+			ref = s.Services.History
+		case primitives.MatchingService:
+			ref = s.Services.Matching
+		case primitives.WorkerService:
+			ref = s.Services.Worker
+		}
+		if ref != nil && ref.Name != "" {
+			return ref.Name
+		}
+	}
+
+	if s.ConfigMapRef != nil {
+		return s.ConfigMapRef.Name
+	}
+
+	return ""
+}
+
 // DynamicConfigSpec is the configuration for temporal dynamic config.
 type DynamicConfigSpec struct {
 	// PollInterval defines how often the config should be updated by checking provided values.
 	// Defaults to 10s.
 	// +optional
 	PollInterval *metav1.Duration `json:"pollInterval"`
+	// QueueProcessorProfile expands into a vetted set of dynamic config values tuning
+	// the history transfer, timer and visibility queue processors.
+	// Keys explicitly set in values take precedence over the profile.
+	// +optional
+	QueueProcessorProfile QueueProcessorProfile `json:"queueProcessorProfile,omitempty"`
+	// AutoTune derives history cache sizes and queue processor worker counts from
+	// the number of history shards and the history service resources.
+	// Keys explicitly set in values or by the queue processor profile take precedence.
+	// +optional
+	AutoTune bool `json:"autoTune,omitempty"`
+	// VersionDefaults applies the operator built-in dynamic config defaults for the cluster version.
+	// They have the lowest precedence: values set by the queue processor profile, auto-tuning,
+	// in values or derived from other spec fields override them.
+	// +optional
+	VersionDefaults bool `json:"versionDefaults,omitempty"`
 	// Values contains all dynamic config keys and their constrained values.
 	Values map[string][]ConstrainedValue `json:"values"`
 }
 
+// GetFilePath returns the path of the dynamic config file in temporal services pods.
+func (DynamicConfigSpec) GetFilePath() string {
+	return "/etc/temporal/config/dynamic/dynamic_config.yaml"
+}
+
 // ClusterArchivalSpec is the configuration for cluster-wide archival config.
 type ClusterArchivalSpec struct {
 	// Enabled defines if the archival is enabled for the cluster.
@@ -838,10 +1986,15 @@ type ClusterArchivalSpec struct {
 	// +optional
 	Enabled bool `json:"enabled"`
 	// Provider defines the archival provider for the cluster.
-	// The same provider is used for both history and visibility,
+	// The same provider is used for both history and visibility unless spec.archival.visibilityProvider is set,
 	// but some config can be changed using spec.archival.[history|visibility].config.
 	// +optional
 	Provider *ArchivalProvider `json:"provider,omitempty"`
+	// VisibilityProvider defines the archival provider used for visibility records, when they must be archived
+	// using a different provider, bucket or credentials than the history.
+	// Provider can be omitted when only visibility records are archived.
+	// +optional
+	VisibilityProvider *ArchivalProvider `json:"visibilityProvider,omitempty"`
 	// History is the default config for the history archival.
 	// +optional
 	History *ArchivalSpec `json:"history,omitempty"`
@@ -854,6 +2007,43 @@ func (s *ClusterArchivalSpec) IsEnabled() bool {
 	return s != nil && s.Enabled
 }
 
+// GetVisibilityProvider returns the archival provider used for visibility records.
+func (s *ClusterArchivalSpec) GetVisibilityProvider() *ArchivalProvider {
+	if s == nil {
+		return nil
+	}
+	if s.VisibilityProvider != nil {
+		return s.VisibilityProvider
+	}
+	return s.Provider
+}
+
+// Providers returns the archival providers used by the cluster, the history one first.
+func (s *ClusterArchivalSpec) Providers() []*ArchivalProvider {
+	result := []*ArchivalProvider{}
+	if s == nil {
+		return result
+	}
+	if s.Provider != nil {
+		result = append(result, s.Provider)
+	}
+	if s.VisibilityProvider != nil {
+		result = append(result, s.VisibilityProvider)
+	}
+	return result
+}
+
+// S3Provider returns the first archival provider of the cluster using s3, if any.
+// S3 credentials are process-wide, so all s3 providers share the same credentials.
+func (s *ClusterArchivalSpec) S3Provider() *S3Archiver {
+	for _, provider := range s.Providers() {
+		if provider.S3 != nil {
+			return provider.S3
+		}
+	}
+	return nil
+}
+
 type ArchivalProviderKind string
 
 const (
@@ -874,6 +2064,10 @@ type ArchivalProvider struct {
 }
 
 func (p *ArchivalProvider) Kind() ArchivalProviderKind {
+	if p == nil {
+		return UnknownArchivalProviderKind
+	}
+
 	if p.Filestore != nil {
 		return FileStoreArchivalProviderKind
 	}
@@ -917,6 +2111,54 @@ type FilestoreArchiver struct {
 	// It's recommend to leave it empty and use the default value of "0766" to avoid read/write issues.
 	// +kubebuilder:default:="0766"
 	DirPermissions string `json:"dirPermissions"`
+	// Volume is the persistent volume the archived files are written to.
+	// Without it, archived files are written to the pods ephemeral storage and lost when pods are replaced.
+	// +optional
+	Volume *FilestoreVolumeSpec `json:"volume,omitempty"`
+}
+
+// FilestoreVolumeSpec is the persistent volume used by the filestore archival provider.
+// The volume is mounted in the frontend, internal frontend, history and worker pods:
+// unless all those pods run on the same node, it must support the ReadWriteMany access mode.
+// +kubebuilder:validation:XValidation:rule="has(self.claimName) != has(self.claimTemplate)",message="exactly one of claimName or claimTemplate must be set"
+type FilestoreVolumeSpec struct {
+	// MountPath is the path the volume is mounted at in the pods.
+	// The history and visibility archival paths must be located under it.
+	// +kubebuilder:validation:Pattern=`^/`
+	MountPath string `json:"mountPath"`
+	// ClaimName is the name of an existing PersistentVolumeClaim in the cluster namespace.
+	// +optional
+	ClaimName string `json:"claimName,omitempty"`
+	// ClaimTemplate is the spec of the PersistentVolumeClaim created by the operator.
+	// The claim is owned by the cluster: it's deleted with the cluster or when the template is removed.
+	// +optional
+	ClaimTemplate *corev1.PersistentVolumeClaimSpec `json:"claimTemplate,omitempty"`
+}
+
+// ArchivalVolume returns the filestore archival persistent volume, if any.
+func (c *TemporalCluster) ArchivalVolume() *FilestoreVolumeSpec {
+	archival := c.Spec.Archival
+	if !archival.IsEnabled() {
+		return nil
+	}
+
+	for _, provider := range archival.Providers() {
+		if provider.Filestore != nil && provider.Filestore.Volume != nil {
+			return provider.Filestore.Volume
+		}
+	}
+
+	return nil
+}
+
+// ArchivalVolumeClaimName returns the name of the PersistentVolumeClaim used for filestore archival.
+func (c *TemporalCluster) ArchivalVolumeClaimName() string {
+	volume := c.ArchivalVolume()
+	if volume != nil && volume.ClaimName != "" {
+		return volume.ClaimName
+	}
+
+	return c.ChildResourceName("archival")
 }
 
 // AuthorizationSpec defines the specifications for authorization in the temporal cluster. It contains fields
@@ -941,6 +2183,87 @@ type AuthorizationSpec struct {
 	// to use the default JWT claim mapper (defaultJWTClaimMapper).
 	// +optional
 	ClaimMapper string `json:"claimMapper"`
+
+	// CertificateClaimMapper defines rules mapping the frontend clients certificates to Temporal roles.
+	// The rules are rendered in the file CertificateClaimMapperRulesPath of the frontend pods, to be loaded by a custom
+	// server build registering a claim mapper based on the github.com/alexandrevilain/temporal-operator/pkg/certclaims package.
+	// +optional
+	CertificateClaimMapper *CertificateClaimMapperSpec `json:"certificateClaimMapper,omitempty"`
+}
+
+// IsEnabled returns true if an authorizer is configured for the cluster.
+func (a *AuthorizationSpec) IsEnabled() bool {
+	return a != nil && a.Authorizer != ""
+}
+
+// CertificateClaimMapperEnabled returns true if certificate claim mapping rules are configured for the cluster.
+func (a *AuthorizationSpec) CertificateClaimMapperEnabled() bool {
+	return a != nil && a.CertificateClaimMapper != nil
+}
+
+const (
+	// CertificateClaimMapperRulesFileName is the name of the file holding the certificate claim mapper rules.
+	CertificateClaimMapperRulesFileName = "claim_mapper_rules.json"
+	// CertificateClaimMapperRulesPath is the path of the certificate claim mapper rules in the frontend pods.
+	CertificateClaimMapperRulesPath = "/etc/temporal/config/" + CertificateClaimMapperRulesFileName
+)
+
+// TemporalRole is a Temporal authorization role.
+// +kubebuilder:validation:Enum=read;write;worker;admin
+type TemporalRole string
+
+const (
+	ReadTemporalRole   TemporalRole = "read"
+	WriteTemporalRole  TemporalRole = "write"
+	WorkerTemporalRole TemporalRole = "worker"
+	AdminTemporalRole  TemporalRole = "admin"
+)
+
+// CertificateClaimMapperSpec defines rules mapping the frontend clients certificates to Temporal roles.
+type CertificateClaimMapperSpec struct {
+	// Rules are evaluated against each client certificate.
+	// Roles granted by all matching rules are merged.
+	Rules []CertificateClaimMapperRule `json:"rules"`
+	// AllowClientPermissions allows TemporalClusterClients referencing the cluster to request namespaces roles
+	// using spec.permissions. The operator then adds a rule matching each client certificate.
+	// +optional
+	AllowClientPermissions bool `json:"allowClientPermissions,omitempty"`
+}
+
+// ClientPermissionsAllowed returns true if TemporalClusterClients can request roles on the cluster.
+func (a *AuthorizationSpec) ClientPermissionsAllowed() bool {
+	return a.CertificateClaimMapperEnabled() && a.CertificateClaimMapper.AllowClientPermissions
+}
+
+// CertificateClaimMapperRule grants roles to the clients whose certificate matches.
+// When both commonName and dnsName are set, the certificate must match both.
+// Rules setting jwtSubject match JWT tokens instead of certificates.
+// +kubebuilder:validation:XValidation:rule="has(self.commonName) || has(self.dnsName) || has(self.jwtSubject)",message="commonName, dnsName or jwtSubject is required"
+// +kubebuilder:validation:XValidation:rule="!has(self.jwtSubject) || !(has(self.commonName) || has(self.dnsName))",message="jwtSubject can't be set with commonName or dnsName"
+type CertificateClaimMapperRule struct {
+	// CommonName is a glob pattern matched against the certificate subject common name.
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+	// DNSName is a glob pattern matched against the certificate DNS subject alternative names.
+	// +optional
+	DNSName string `json:"dnsName,omitempty"`
+	// JWTSubject is a glob pattern matched against the subject claim of JWT tokens.
+	// +optional
+	JWTSubject string `json:"jwtSubject,omitempty"`
+	// SystemRoles are the roles granted on the whole cluster.
+	// +optional
+	SystemRoles []TemporalRole `json:"systemRoles,omitempty"`
+	// Namespaces are the roles granted per namespace.
+	// +optional
+	Namespaces []CertificateClaimMapperNamespaceRoles `json:"namespaces,omitempty"`
+}
+
+// CertificateClaimMapperNamespaceRoles defines the roles granted on a namespace.
+type CertificateClaimMapperNamespaceRoles struct {
+	// Namespace is the name of the Temporal namespace.
+	Namespace string `json:"namespace"`
+	// Roles are the roles granted on the namespace.
+	Roles []TemporalRole `json:"roles"`
 }
 
 // AuthorizationSpecJWTKeyProvider defines the configuration for a JWT key provider within the AuthorizationSpec.
@@ -997,88 +2320,1023 @@ func (GCSArchiver) CredentialsFileMountPath() string {
 	return "/etc/archival/credentials.json"
 }
 
-// TemporalClusterSpec defines the desired state of Cluster.
-type TemporalClusterSpec struct {
-	// Image defines the temporal server docker image the cluster should use for each services.
-	// +optional
-	Image string `json:"image"`
-	// Version defines the temporal version the cluster to be deployed.
-	// This version impacts the underlying persistence schemas versions.
-	// +optional
-	Version *version.Version `json:"version"`
-	// Log defines temporal cluster's logger configuration.
-	// +optional
-	Log *LogSpec `json:"log,omitempty"`
-	// JobTTLSecondsAfterFinished is amount of time to keep job pods after jobs are completed.
-	// Defaults to 300 seconds.
-	// +optional
-	//+kubebuilder:default:=300
-	//+kubebuilder:validation:Minimum=1
-	JobTTLSecondsAfterFinished *int32 `json:"jobTtlSecondsAfterFinished"`
-	// JobResources allows set resources for setup/update jobs.
-	// +optional
-	JobResources corev1.ResourceRequirements `json:"jobResources,omitempty"`
-	// JobInitContainers adds a list of init containers to the setup's jobs.
-	// +optional
-	JobInitContainers []corev1.Container `json:"jobInitContainers,omitempty"`
-	// NumHistoryShards is the desired number of history shards.
-	// This field is immutable.
-	//+kubebuilder:validation:Minimum=1
-	NumHistoryShards int32 `json:"numHistoryShards"`
-	// Services allows customizations for each temporal services deployment.
-	// +optional
-	Services *ServicesSpec `json:"services,omitempty"`
-	// Persistence defines temporal persistence configuration.
-	Persistence TemporalPersistenceSpec `json:"persistence"`
-	// An optional list of references to secrets in the same namespace
-	// to use for pulling temporal images from registries.
+// VisibilityCredentialsFileMountPath returns the path the credentials file is mounted at
+// when the archiver is used as visibility provider.
+func (GCSArchiver) VisibilityCredentialsFileMountPath() string {
+	return "/etc/archival-visibility/credentials.json"
+}
+
+// ExposeHostnamesSpec defines the DNS hostnames published for the cluster endpoints.
+type ExposeHostnamesSpec struct {
+	// Frontend is the list of hostnames pointing to the frontend service.
 	// +optional
-	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
-	// UI allows configuration of the optional temporal web ui deployed alongside the cluster.
+	Frontend []string `json:"frontend,omitempty"`
+	// UI is the list of hostnames pointing to the UI service and ingress.
 	// +optional
-	UI *TemporalUISpec `json:"ui,omitempty"`
-	// AdminTools allows configuration of the optional admin tool pod deployed alongside the cluster.
+	UI []string `json:"ui,omitempty"`
+	// TTL is the DNS records TTL in seconds.
 	// +optional
-	AdminTools *TemporalAdminToolsSpec `json:"admintools,omitempty"`
-	// MTLS allows configuration of the network traffic encryption for the cluster.
+	TTL *int64 `json:"ttl,omitempty"`
+}
+
+// ExposeSpec defines how the cluster endpoints are published outside of kubernetes.
+type ExposeSpec struct {
+	// Hostnames adds external-dns annotations on generated Services and Ingresses
+	// so DNS records are created automatically.
 	// +optional
-	MTLS *MTLSSpec `json:"mTLS,omitempty"` //nolint:tagliatelle
-	// Metrics allows configuration of scraping endpoints for stats. prometheus or m3.
+	Hostnames *ExposeHostnamesSpec `json:"hostnames,omitempty"`
+	// ClientLoadBalancing creates an additional headless frontend Service only targeting ready pods,
+	// suited for gRPC client-side load balancing (round_robin).
+	// Connection information is added to TemporalClusterClient secrets.
 	// +optional
-	Metrics *MetricsSpec `json:"metrics,omitempty"`
-	// DynamicConfig allows advanced configuration for the temporal cluster.
+	ClientLoadBalancing bool `json:"clientLoadBalancing,omitempty"`
+	// ExternalFrontend maps the frontend Service to an existing externally-managed endpoint
+	// instead of the cluster's frontend pods, so clients keep a stable address during migrations.
 	// +optional
-	DynamicConfig *DynamicConfigSpec `json:"dynamicConfig,omitempty"`
-	// Archival allows Workflow Execution Event Histories and Visibility data backups for the temporal cluster.
+	ExternalFrontend *ExternalFrontendSpec `json:"externalFrontend,omitempty"`
+}
+
+// ExternalFrontendSpec defines the externally-managed endpoint the frontend Service routes to.
+// Exactly one of externalName or addresses must be set.
+type ExternalFrontendSpec struct {
+	// ExternalName is the DNS name aliased by the frontend Service, using a Service of type ExternalName.
 	// +optional
-	Archival *ClusterArchivalSpec `json:"archival,omitempty"`
-	// Authorization allows authorization configuration for the temporal cluster.
+	ExternalName string `json:"externalName,omitempty"`
+	// Addresses are the IP addresses the frontend Service routes to,
+	// using a selector-less Service and operator-managed Endpoints.
 	// +optional
-	Authorization *AuthorizationSpec `json:"authorization,omitempty"`
+	Addresses []string `json:"addresses,omitempty"`
 }
 
-// ServiceStatus reports a service status.
-type ServiceStatus struct {
-	// Name of the temporal service.
-	Name string `json:"name"`
+// GetExternalFrontend returns the externally-managed frontend endpoint, if any.
+func (e *ExposeSpec) GetExternalFrontend() *ExternalFrontendSpec {
+	if e == nil {
+		return nil
+	}
+	return e.ExternalFrontend
+}
+
+// GetFrontendHostnames returns the hostnames published for the frontend.
+func (e *ExposeSpec) GetFrontendHostnames() []string {
+	if e == nil || e.Hostnames == nil {
+		return nil
+	}
+	return e.Hostnames.Frontend
+}
+
+// GetUIHostnames returns the hostnames published for the UI.
+func (e *ExposeSpec) GetUIHostnames() []string {
+	if e == nil || e.Hostnames == nil {
+		return nil
+	}
+	return e.Hostnames.UI
+}
+
+// GetTTL returns the DNS records TTL, if any.
+func (e *ExposeSpec) GetTTL() *int64 {
+	if e == nil || e.Hostnames == nil {
+		return nil
+	}
+	return e.Hostnames.TTL
+}
+
+// ClientLoadBalancingEnabled returns true if the headless frontend Service for client-side load balancing is enabled.
+func (e *ExposeSpec) ClientLoadBalancingEnabled() bool {
+	return e != nil && e.ClientLoadBalancing
+}
+
+// BackstageKubernetesIDLabel is the label used by the Backstage kubernetes plugin to find the resources of a catalog entity.
+const BackstageKubernetesIDLabel = "backstage.io/kubernetes-id"
+
+// CatalogSpec defines how the cluster is published to developer portals catalogs.
+type CatalogSpec struct {
+	// Enabled defines if the cluster metadata is published.
+	Enabled bool `json:"enabled"`
+	// ComponentID is the catalog entity the cluster resources are attached to,
+	// set on the cluster resources and pods using the backstage.io/kubernetes-id label.
+	// Defaults to the cluster name.
+	// +optional
+	ComponentID string `json:"componentId,omitempty"`
+}
+
+// IsEnabled returns true if the cluster metadata is published.
+func (s *CatalogSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// CatalogComponentID returns the catalog entity the cluster resources are attached to.
+func (c *TemporalCluster) CatalogComponentID() string {
+	if c.Spec.Catalog != nil && c.Spec.Catalog.ComponentID != "" {
+		return c.Spec.Catalog.ComponentID
+	}
+	return c.GetName()
+}
+
+// UpgradeStrategyType defines how the operator rolls out a new temporal version.
+// +kubebuilder:validation:Enum=RollingUpdate;BlueGreen
+type UpgradeStrategyType string
+
+const (
+	// RollingUpdateUpgradeStrategyType updates services deployments in place.
+	RollingUpdateUpgradeStrategyType UpgradeStrategyType = "RollingUpdate"
+	// BlueGreenUpgradeStrategyType deploys a new set of services deployments for the target version
+	// alongside the current one, and switches the frontend Service to it once it's healthy.
+	BlueGreenUpgradeStrategyType UpgradeStrategyType = "BlueGreen"
+)
+
+// UpgradeStrategySpec defines how version upgrades are rolled out.
+type UpgradeStrategySpec struct {
+	// Type is the upgrade strategy type.
+	// +kubebuilder:default=RollingUpdate
+	// +optional
+	Type UpgradeStrategyType `json:"type,omitempty"`
+}
+
+// IsBlueGreen returns true if the blue/green upgrade strategy is selected.
+func (s *UpgradeStrategySpec) IsBlueGreen() bool {
+	return s != nil && s.Type == BlueGreenUpgradeStrategyType
+}
+
+// SmokeTestSpec defines the workflow-level smoke test run after each rollout.
+type SmokeTestSpec struct {
+	// Enabled defines if the smoke test should be run after each rollout.
+	// The cluster is only marked as ready once the smoke test passed.
+	Enabled bool `json:"enabled"`
+	// Namespace is the reserved temporal namespace the smoke test workflows run in.
+	// It's registered by the operator if it doesn't exist.
+	// +kubebuilder:default=temporal-operator-smoke-test
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Timeout is the maximum duration of a smoke test run.
+	// Defaults to 30s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// IsEnabled returns true if the smoke test is enabled.
+func (s *SmokeTestSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// GetNamespace returns the namespace the smoke test workflows run in.
+func (s *SmokeTestSpec) GetNamespace() string {
+	if s == nil || s.Namespace == "" {
+		return "temporal-operator-smoke-test"
+	}
+	return s.Namespace
+}
+
+// GetTimeout returns the maximum duration of a smoke test run.
+func (s *SmokeTestSpec) GetTimeout() time.Duration {
+	if s == nil || s.Timeout == nil {
+		return 30 * time.Second
+	}
+	return s.Timeout.Duration
+}
+
+// CanarySpec defines the synthetic workflows periodically run to check the cluster health.
+type CanarySpec struct {
+	// Enabled defines if the canary workflows should be run.
+	Enabled bool `json:"enabled"`
+	// Namespace is the dedicated temporal namespace the canary workflows run in.
+	// It's registered by the operator if it doesn't exist.
+	// +kubebuilder:default=temporal-operator-canary
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Interval is the interval between two canary runs.
+	// Defaults to 1m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Timeout is the maximum duration of a canary run.
+	// Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// IsEnabled returns true if the canary is enabled.
+func (s *CanarySpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// GetNamespace returns the namespace the canary workflows run in.
+func (s *CanarySpec) GetNamespace() string {
+	if s == nil || s.Namespace == "" {
+		return "temporal-operator-canary"
+	}
+	return s.Namespace
+}
+
+// GetInterval returns the interval between two canary runs.
+func (s *CanarySpec) GetInterval() time.Duration {
+	if s == nil || s.Interval == nil {
+		return time.Minute
+	}
+	return s.Interval.Duration
+}
+
+// GetTimeout returns the maximum duration of a canary run.
+func (s *CanarySpec) GetTimeout() time.Duration {
+	if s == nil || s.Timeout == nil {
+		return 10 * time.Second
+	}
+	return s.Timeout.Duration
+}
+
+// AlertmanagerSilenceSpec defines the alertmanager silence created during rollouts.
+type AlertmanagerSilenceSpec struct {
+	// URLSecretRef references the secret key holding the alertmanager URL.
+	// The key defaults to "url".
+	URLSecretRef SecretKeyReference `json:"urlSecretRef"`
+	// Matchers are the alert labels matched by the silence.
+	// Defaults to the cluster's namespace (namespace=<cluster namespace>).
+	// +optional
+	Matchers map[string]string `json:"matchers,omitempty"`
+	// Duration is the maximum duration of the silence.
+	// The silence is expired as soon as the rollout completes. Defaults to 30 minutes.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// GetDuration returns the maximum duration of the silence.
+func (s *AlertmanagerSilenceSpec) GetDuration() time.Duration {
+	if s.Duration == nil {
+		return 30 * time.Minute
+	}
+	return s.Duration.Duration
+}
+
+// RolloutNotificationsSpec defines how the operator notifies about rollouts it initiates.
+type RolloutNotificationsSpec struct {
+	// WebhookURLSecretRef references the secret key holding a webhook URL.
+	// The operator posts a JSON event to this URL when a rollout starts and completes.
+	// The key defaults to "url".
+	// +optional
+	WebhookURLSecretRef *SecretKeyReference `json:"webhookURLSecretRef,omitempty"` //nolint:tagliatelle
+	// Alertmanager creates an alertmanager silence for the duration of rollouts
+	// so expected restarts don't page on-call.
+	// +optional
+	Alertmanager *AlertmanagerSilenceSpec `json:"alertmanager,omitempty"`
+}
+
+// RolloutPolicySpec defines the rollout policy of the cluster's services.
+type RolloutPolicySpec struct {
+	// Notifications allows notifying external systems about rollouts.
+	// +optional
+	Notifications *RolloutNotificationsSpec `json:"notifications,omitempty"`
+	// ServiceOrder lists temporal services which must never roll out at the same time.
+	// A listed service only starts rolling out once no other listed service is rolling out,
+	// and services whose pods must be replaced in the same reconciliation roll out in the listed order.
+	// For instance, [frontend, matching] prevents frontend and matching pods from being replaced simultaneously,
+	// which could stall task dispatch entirely.
+	// +kubebuilder:validation:items:Enum=frontend;internal-frontend;history;matching;worker
+	// +listType=set
+	// +optional
+	ServiceOrder []string `json:"serviceOrder,omitempty"`
+}
+
+// GetServiceOrder returns the services which must never roll out at the same time, in their rollout order.
+func (s *RolloutPolicySpec) GetServiceOrder() []string {
+	if s == nil {
+		return nil
+	}
+	return s.ServiceOrder
+}
+
+// NotificationsEnabled returns true if rollout notifications are configured.
+func (s *RolloutPolicySpec) NotificationsEnabled() bool {
+	return s != nil && s.Notifications != nil && (s.Notifications.WebhookURLSecretRef != nil || s.Notifications.Alertmanager != nil)
+}
+
+// RemoteClusterSpec defines a remote cluster taking part in replication.
+type RemoteClusterSpec struct {
+	// Name is the name of the remote temporal cluster.
+	Name string `json:"name"`
+	// Address is the remote cluster frontend address (host:port).
+	// When set, or when clusterRef is set, the operator registers the remote cluster once the cluster is ready.
+	// +optional
+	Address string `json:"address,omitempty"`
+	// ClusterRef references a TemporalCluster managed by the operator in the same kubernetes cluster.
+	// Its frontend address is used unless address is set. When the remote cluster uses frontend mTLS with cert-manager,
+	// the operator issues a client certificate from the remote cluster CA so the cluster services can connect to it.
+	// +optional
+	ClusterRef *ObjectReference `json:"clusterRef,omitempty"`
+	// TLS configures the mTLS connection to the remote cluster frontend.
+	// Takes precedence over the client certificate issued when using clusterRef.
+	// +optional
+	TLS *RemoteClusterTLSSpec `json:"tls,omitempty"`
+}
+
+// RemoteClusterTLSSpec configures the mTLS connection to a remote cluster frontend.
+type RemoteClusterTLSSpec struct {
+	// SecretRef references a secret in the cluster namespace holding the "ca.crt" key to trust the remote frontend certificate,
+	// and the "tls.crt" and "tls.key" keys presented to the remote frontend.
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+	// ServerName is the name expected in the remote frontend certificate.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// RemoteClusterConnection is the resolved connection to a remote cluster.
+// +kubebuilder:object:generate=false
+type RemoteClusterConnection struct {
+	// Name is the name of the remote temporal cluster.
+	Name string
+	// Address is the remote cluster frontend address (host:port).
+	Address string
+	// TLS is the mTLS configuration used to connect to the remote frontend, if any.
+	TLS *RemoteClusterTLSSpec
+	// Ready is true once the remote cluster can be registered.
+	Ready bool
+}
+
+// TLSMountPath returns the path the remote cluster TLS secret is mounted at in the services pods.
+func (c RemoteClusterConnection) TLSMountPath() string {
+	return path.Join("/etc/temporal/config/certs/remote", c.Name)
+}
+
+// ReplicationSpec defines the multi-cluster replication configuration of the cluster.
+type ReplicationSpec struct {
+	// Enabled enables global namespaces on the cluster.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// InitialFailoverVersion is the unique failover version of the cluster.
+	// It must be lower than FailoverVersionIncrement.
+	// +optional
+	//+kubebuilder:default:=1
+	//+kubebuilder:validation:Minimum=1
+	InitialFailoverVersion int64 `json:"initialFailoverVersion,omitempty"`
+	// FailoverVersionIncrement is the increment of each cluster version when failover happens.
+	// It must be identical on all clusters taking part in replication.
+	// +optional
+	//+kubebuilder:default:=10
+	//+kubebuilder:validation:Minimum=2
+	FailoverVersionIncrement int64 `json:"failoverVersionIncrement,omitempty"`
+	// RemoteClusters lists the remote clusters this cluster replicates with.
+	// Their connection health and replication lag are reported in status.replication.
+	// +optional
+	RemoteClusters []RemoteClusterSpec `json:"remoteClusters,omitempty"`
+	// MaxReplicationLag is the replication lag above which replication to a remote cluster
+	// is considered unhealthy. Defaults to 5 minutes.
+	// +optional
+	MaxReplicationLag *metav1.Duration `json:"maxReplicationLag,omitempty"`
+	// ActiveCluster is the name of the cluster all global namespaces managed by the operator
+	// on this cluster should be active on. Changing it fails over those namespaces.
+	// TemporalNamespace's activeClusterName takes precedence over this value.
+	// +optional
+	ActiveCluster string `json:"activeCluster,omitempty"`
+	// Role is the role of the cluster in the replication topology.
+	// Global namespaces are registered and updated by the operator managing the active cluster only:
+	// on standby clusters, the operator waits for them to be replicated.
+	// Standby clusters require activeCluster to be set to the name of the active cluster.
+	// Defaults to active.
+	// +kubebuilder:validation:Enum=active;standby
+	// +optional
+	Role ReplicationRole `json:"role,omitempty"`
+}
+
+// ReplicationRole is the role of a cluster in a replication topology.
+type ReplicationRole string
+
+const (
+	// ActiveReplicationRole is the role of the cluster global namespaces are registered on.
+	ActiveReplicationRole ReplicationRole = "active"
+	// StandbyReplicationRole is the role of clusters global namespaces are replicated to.
+	StandbyReplicationRole ReplicationRole = "standby"
+)
+
+// IsEnabled returns true if replication is enabled.
+func (s *ReplicationSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// IsStandby returns true if the cluster is a standby cluster of the replication topology.
+func (s *ReplicationSpec) IsStandby() bool {
+	return s.IsEnabled() && s.Role == StandbyReplicationRole
+}
+
+// GetInitialFailoverVersion returns the cluster initial failover version.
+func (s *ReplicationSpec) GetInitialFailoverVersion() int64 {
+	if s == nil || s.InitialFailoverVersion == 0 {
+		return 1
+	}
+	return s.InitialFailoverVersion
+}
+
+// GetMaxReplicationLag returns the replication lag above which replication is considered unhealthy.
+func (s *ReplicationSpec) GetMaxReplicationLag() time.Duration {
+	if s == nil || s.MaxReplicationLag == nil {
+		return 5 * time.Minute
+	}
+	return s.MaxReplicationLag.Duration
+}
+
+// GetFailoverVersionIncrement returns the failover version increment.
+func (s *ReplicationSpec) GetFailoverVersionIncrement() int64 {
+	if s == nil || s.FailoverVersionIncrement == 0 {
+		return 10
+	}
+	return s.FailoverVersionIncrement
+}
+
+// Platform is the kubernetes distribution the cluster is deployed on.
+// +kubebuilder:validation:Enum=kubernetes;openshift
+type Platform string
+
+const (
+	// KubernetesPlatform is the default platform.
+	KubernetesPlatform Platform = "kubernetes"
+	// OpenShiftPlatform lets security context constraints assign pods users and exposes the ui using Routes.
+	OpenShiftPlatform Platform = "openshift"
+)
+
+// PodSecurityProfile is a named set of security settings applied to the pods managed for a cluster.
+// +kubebuilder:validation:Enum=restricted
+type PodSecurityProfile string
+
+const (
+	// RestrictedPodSecurityProfile complies with the "restricted" Pod Security Standard:
+	// RuntimeDefault seccomp profile, non-root users, no privilege escalation and all capabilities dropped.
+	RestrictedPodSecurityProfile PodSecurityProfile = "restricted"
+)
+
+// JobHistoryLimitsSpec defines the number of finished setup/update jobs to keep.
+type JobHistoryLimitsSpec struct {
+	// SuccessfulJobsHistoryLimit is the number of succeeded jobs to keep.
+	// Defaults to 3.
+	// +optional
+	//+kubebuilder:default:=3
+	//+kubebuilder:validation:Minimum=0
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+	// FailedJobsHistoryLimit is the number of failed jobs to keep.
+	// The last failed job is always kept for debugging.
+	// Defaults to 1.
+	// +optional
+	//+kubebuilder:default:=1
+	//+kubebuilder:validation:Minimum=1
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+}
+
+// GetSuccessfulJobsHistoryLimit returns the number of succeeded jobs to keep.
+func (s *JobHistoryLimitsSpec) GetSuccessfulJobsHistoryLimit() int {
+	if s.SuccessfulJobsHistoryLimit == nil {
+		return 3
+	}
+	return int(*s.SuccessfulJobsHistoryLimit)
+}
+
+// GetFailedJobsHistoryLimit returns the number of failed jobs to keep, which is at least 1.
+func (s *JobHistoryLimitsSpec) GetFailedJobsHistoryLimit() int {
+	if s.FailedJobsHistoryLimit == nil || *s.FailedJobsHistoryLimit < 1 {
+		return 1
+	}
+	return int(*s.FailedJobsHistoryLimit)
+}
+
+// PodSecuritySpec configures the security settings of the pods managed for a cluster.
+type PodSecuritySpec struct {
+	// Profile applies a validated set of security settings to all the pods managed for the cluster.
+	// +optional
+	Profile PodSecurityProfile `json:"profile,omitempty"`
+	// SeccompProfile is the seccomp profile of the pods managed for the cluster.
+	// It takes precedence over the profile.
+	// +optional
+	SeccompProfile *corev1.SeccompProfile `json:"seccompProfile,omitempty"`
+	// AppArmorProfile is the AppArmor profile of the pods managed for the cluster.
+	// Requires kubernetes >= 1.30.
+	// +optional
+	AppArmorProfile *corev1.AppArmorProfile `json:"appArmorProfile,omitempty"`
+	// HostUsers runs the pods in the host user namespace. Set it to false to run the pods in their own
+	// user namespace, mapping the containers users, including root, to unprivileged host users.
+	// Requires kubernetes >= 1.33, or the UserNamespacesSupport feature gate on older versions.
+	// +optional
+	HostUsers *bool `json:"hostUsers,omitempty"`
+}
+
+// IsRestricted returns true if the restricted profile is selected.
+func (s *PodSecuritySpec) IsRestricted() bool {
+	return s != nil && s.Profile == RestrictedPodSecurityProfile
+}
+
+// ImageVerificationSpec configures the verification of the temporal images cosign signatures.
+type ImageVerificationSpec struct {
+	// PublicKeySecretRef references the PEM encoded cosign public key the temporal server,
+	// admin tools and ui images must be signed with.
+	// The key defaults to "cosign.pub".
+	PublicKeySecretRef SecretKeyReference `json:"publicKeySecretRef"`
+}
+
+// TemporalClusterSpec defines the desired state of Cluster.
+type TemporalClusterSpec struct {
+	// Image defines the temporal server docker image the cluster should use for each services.
+	// +optional
+	Image string `json:"image"`
+	// Edition describes the temporal server build the cluster runs. Config blocks not supported by the edition
+	// are rejected at admission instead of making the services crash on unknown configuration.
+	// Defaults to the OSS edition.
+	// +optional
+	Edition *EditionSpec `json:"edition,omitempty"`
+	// ImagePullPolicy is the pull policy of the containers deployed for the cluster.
+	// Defaults to IfNotPresent.
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// ResolveImageDigests makes the operator resolve the cluster images tags to digests when they change,
+	// and pin the pods to those digests so all replicas run identical images.
+	// Registries credentials are read from spec.imagePullSecrets.
+	// +optional
+	ResolveImageDigests bool `json:"resolveImageDigests,omitempty"`
+	// Platform is the kubernetes distribution the cluster is deployed on.
+	// On openshift, pods users are assigned by security context constraints
+	// and the ui is exposed using Routes instead of an Ingress.
+	// Defaults to kubernetes.
+	// +optional
+	Platform Platform `json:"platform,omitempty"`
+	// ClusterDomain is the DNS domain of the kubernetes cluster, used to render the fully qualified names
+	// of the cluster services, like the mTLS certificates server names.
+	// Only set it if the kubernetes cluster doesn't use the default domain.
+	// +kubebuilder:default=cluster.local
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +optional
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+	// PodSecurity configures the security settings of the pods managed for the cluster:
+	// services, ui, admin tools and schema jobs.
+	// +optional
+	PodSecurity *PodSecuritySpec `json:"podSecurity,omitempty"`
+	// ImageVerification makes the operator verify the temporal images signatures before rolling them out.
+	// It requires spec.resolveImageDigests to be enabled.
+	// +optional
+	ImageVerification *ImageVerificationSpec `json:"imageVerification,omitempty"`
+	// Version defines the temporal version the cluster to be deployed.
+	// This version impacts the underlying persistence schemas versions.
+	// +optional
+	Version *version.Version `json:"version"`
+	// Log defines temporal cluster's logger configuration.
+	// +optional
+	Log *LogSpec `json:"log,omitempty"`
+	// TimeZone is the IANA time zone name, like Europe/Paris, the cluster schedules are interpreted in:
+	// the backup CronJob schedule and the TemporalSchedules not setting their own time zone.
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// JobTTLSecondsAfterFinished is amount of time to keep job pods after jobs are completed.
+	// Defaults to 300 seconds.
+	// +optional
+	//+kubebuilder:default:=300
+	//+kubebuilder:validation:Minimum=1
+	JobTTLSecondsAfterFinished *int32 `json:"jobTtlSecondsAfterFinished"`
+	// JobResources allows set resources for setup/update jobs.
+	// +optional
+	JobResources corev1.ResourceRequirements `json:"jobResources,omitempty"`
+	// JobInitContainers adds a list of init containers to the setup's jobs.
+	// +optional
+	JobInitContainers []corev1.Container `json:"jobInitContainers,omitempty"`
+	// JobActiveDeadlineSeconds is the duration in seconds a setup/update job may run before being
+	// terminated and marked as failed, so that stuck schema jobs fail fast.
+	// +optional
+	//+kubebuilder:validation:Minimum=1
+	JobActiveDeadlineSeconds *int64 `json:"jobActiveDeadlineSeconds,omitempty"`
+	// JobBackoffLimit is the number of retries before marking a setup/update job as failed.
+	// Defaults to the kubernetes default (6).
+	// +optional
+	//+kubebuilder:validation:Minimum=0
+	JobBackoffLimit *int32 `json:"jobBackoffLimit,omitempty"`
+	// JobNodeSelector constrains the setup/update jobs pods to nodes with the given labels.
+	// +optional
+	JobNodeSelector map[string]string `json:"jobNodeSelector,omitempty"`
+	// JobTolerations are the setup/update jobs pods tolerations.
+	// +optional
+	JobTolerations []corev1.Toleration `json:"jobTolerations,omitempty"`
+	// JobAffinity is the setup/update jobs pods affinity.
+	// +optional
+	JobAffinity *corev1.Affinity `json:"jobAffinity,omitempty"`
+	// JobPriorityClassName is the setup/update jobs pods priority class name.
+	// +optional
+	JobPriorityClassName string `json:"jobPriorityClassName,omitempty"`
+	// JobHistoryLimits makes the operator delete the finished setup/update jobs beyond the provided limits,
+	// in addition to jobTtlSecondsAfterFinished.
+	// +optional
+	JobHistoryLimits *JobHistoryLimitsSpec `json:"jobHistoryLimits,omitempty"`
+	// NumHistoryShards is the desired number of history shards.
+	// This field is immutable.
+	//+kubebuilder:validation:Minimum=1
+	NumHistoryShards int32 `json:"numHistoryShards"`
+	// ClusterMetadata allows setting metadata recorded by temporal in the cluster metadata.
+	// +optional
+	ClusterMetadata *ClusterMetadataSpec `json:"clusterMetadata,omitempty"`
+	// Services allows customizations for each temporal services deployment.
+	// +optional
+	Services *ServicesSpec `json:"services,omitempty"`
+	// HighAvailability enables an opinionated highly available deployment mode:
+	// each service runs at least 2 replicas spread across zones with pod anti-affinity,
+	// and is protected by a PodDisruptionBudget.
+	// +optional
+	HighAvailability bool `json:"highAvailability,omitempty"`
+	// Persistence defines temporal persistence configuration.
+	Persistence TemporalPersistenceSpec `json:"persistence"`
+	// An optional list of references to secrets in the same namespace
+	// to use for pulling temporal images from registries.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// UI allows configuration of the optional temporal web ui deployed alongside the cluster.
+	// +optional
+	UI *TemporalUISpec `json:"ui,omitempty"`
+	// AdminTools allows configuration of the optional admin tool pod deployed alongside the cluster.
+	// +optional
+	AdminTools *TemporalAdminToolsSpec `json:"admintools,omitempty"`
+	// GRPCWeb allows configuration of the optional gRPC-web proxy deployed in front of the frontend,
+	// for browser based tooling.
+	// +optional
+	GRPCWeb *GRPCWebSpec `json:"grpcWeb,omitempty"`
+	// MTLS allows configuration of the network traffic encryption for the cluster.
+	// +optional
+	MTLS *MTLSSpec `json:"mTLS,omitempty"` //nolint:tagliatelle
+	// Metrics allows configuration of scraping endpoints for stats. prometheus or m3.
+	// +optional
+	Metrics *MetricsSpec `json:"metrics,omitempty"`
+	// DynamicConfig allows advanced configuration for the temporal cluster.
+	// +optional
+	DynamicConfig *DynamicConfigSpec `json:"dynamicConfig,omitempty"`
+	// Features enables well-known temporal server features, without having to know the dynamic config
+	// keys enabling them in the cluster version. Requires spec.dynamicConfig to be set.
+	// Keys explicitly set in spec.dynamicConfig.values take precedence.
+	// +optional
+	// +listType=set
+	Features []ServerFeature `json:"features,omitempty"`
+	// ExternalConfig makes services consume temporal server configurations managed outside of the operator,
+	// for users generating them with their own tooling. The operator keeps managing Deployments, Services and Certificates.
+	// +optional
+	ExternalConfig *ExternalConfigSpec `json:"externalConfig,omitempty"`
+	// Archival allows Workflow Execution Event Histories and Visibility data backups for the temporal cluster.
+	// +optional
+	Archival *ClusterArchivalSpec `json:"archival,omitempty"`
+	// Authorization allows authorization configuration for the temporal cluster.
+	// +optional
+	Authorization *AuthorizationSpec `json:"authorization,omitempty"`
+	// Expose allows configuration of how the cluster endpoints are published outside of kubernetes.
+	// +optional
+	Expose *ExposeSpec `json:"expose,omitempty"`
+	// Catalog publishes the cluster metadata for developer portals, like the Backstage software catalog.
+	// +optional
+	Catalog *CatalogSpec `json:"catalog,omitempty"`
+	// UpgradeStrategy defines how temporal version upgrades are rolled out.
+	// Defaults to an in-place rolling update of each service.
+	// +optional
+	UpgradeStrategy *UpgradeStrategySpec `json:"upgradeStrategy,omitempty"`
+	// SmokeTest allows running a workflow-level smoke test after each rollout
+	// before marking the cluster as ready.
+	// +optional
+	SmokeTest *SmokeTestSpec `json:"smokeTest,omitempty"`
+	// Canary allows periodically running a synthetic workflow through the frontend
+	// to check the cluster health end-to-end.
+	// +optional
+	Canary *CanarySpec `json:"canary,omitempty"`
+	// Replication allows configuration of multi-cluster replication.
+	// +optional
+	Replication *ReplicationSpec `json:"replication,omitempty"`
+	// RolloutPolicy allows configuration of the rollouts initiated by the operator.
+	// +optional
+	RolloutPolicy *RolloutPolicySpec `json:"rolloutPolicy,omitempty"`
+	// Priority orders the clusters reconciliations when the operator has a backlog, like after an operator restart:
+	// clusters with a higher priority are reconciled first. Defaults to 0, use a negative priority for dev or preview clusters.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+	// WorkloadMonitoring periodically reports the backlog of task queues in status.workload.
+	// +optional
+	WorkloadMonitoring *WorkloadMonitoringSpec `json:"workloadMonitoring,omitempty"`
+	// CrashDump collects a debug bundle from the services containers restarting repeatedly,
+	// preserving evidence for postmortems before the pods are replaced.
+	// +optional
+	CrashDump *CrashDumpSpec `json:"crashDump,omitempty"`
+	// ResourceAdvisor periodically samples the services resources usage from metrics-server and publishes
+	// non-binding resources recommendations in status.recommendations.
+	// +optional
+	ResourceAdvisor *ResourceAdvisorSpec `json:"resourceAdvisor,omitempty"`
+	// TemplateRef references the TemporalClusterTemplate the cluster instantiates.
+	// The template spec is applied on admission, overriding the values set in the cluster spec.
+	// +optional
+	TemplateRef *TemporalClusterTemplateReference `json:"templateRef,omitempty"`
+	// Backup periodically exports the cluster configuration to an object storage bucket.
+	// +optional
+	Backup *BackupSpec `json:"backup,omitempty"`
+}
+
+// TemporalClusterTemplateReference references a TemporalClusterTemplate and the size to instantiate.
+type TemporalClusterTemplateReference struct {
+	// Name is the name of the TemporalClusterTemplate.
+	Name string `json:"name"`
+	// Size is the name of the template size to instantiate.
+	// Defaults to the template default size.
+	// +optional
+	Size string `json:"size,omitempty"`
+}
+
+// MonitoredTaskQueueSpec references a task queue whose workload is reported.
+type MonitoredTaskQueueSpec struct {
+	// Namespace is the name of the Temporal namespace of the task queue.
+	Namespace string `json:"namespace"`
+	// Name of the task queue.
+	Name string `json:"name"`
+	// Type of the task queue. Defaults to Workflow.
+	// +kubebuilder:default:=Workflow
+	// +optional
+	Type TaskQueueType `json:"type,omitempty"`
+}
+
+// WorkloadMonitoringSpec defines the task queues whose workload is reported in the cluster status.
+type WorkloadMonitoringSpec struct {
+	// TaskQueues lists the task queues whose backlog is reported.
+	TaskQueues []MonitoredTaskQueueSpec `json:"taskQueues"`
+	// Interval is the interval between two workload snapshots. Defaults to 1 minute.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// MaxBacklog is the task queue backlog above which the cluster is reported as overloaded
+	// using the Overloaded condition. The condition is not reported if not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxBacklog *int64 `json:"maxBacklog,omitempty"`
+}
+
+// IsEnabled returns true if workload monitoring is configured.
+func (s *WorkloadMonitoringSpec) IsEnabled() bool {
+	return s != nil && len(s.TaskQueues) > 0
+}
+
+// GetInterval returns the interval between two workload snapshots.
+func (s *WorkloadMonitoringSpec) GetInterval() time.Duration {
+	if s == nil || s.Interval == nil {
+		return time.Minute
+	}
+	return s.Interval.Duration
+}
+
+// CrashDumpSpec configures the debug bundles collected from crash looping services containers.
+type CrashDumpSpec struct {
+	// Enabled defines if the operator should collect debug bundles.
+	// Enabling it sets GOTRACEBACK=all on the services containers, so panics dump all goroutines in the collected logs.
+	Enabled bool `json:"enabled"`
+	// Services lists the services whose containers are watched. Defaults to history and matching.
+	// +kubebuilder:validation:items:Enum=frontend;internal-frontend;history;matching;worker
+	// +listType=set
+	// +optional
+	Services []string `json:"services,omitempty"`
+	// RestartThreshold is the number of restarts of a container from which a bundle is collected. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RestartThreshold *int32 `json:"restartThreshold,omitempty"`
+	// LogLines is the number of lines of the crashed container logs kept in the bundle. Defaults to 1000.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	LogLines *int64 `json:"logLines,omitempty"`
+	// MaxBundles is the number of bundles kept for the cluster, older bundles are deleted. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxBundles *int32 `json:"maxBundles,omitempty"`
+}
+
+// IsEnabled returns true if debug bundles should be collected.
+func (s *CrashDumpSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// GetServices returns the services whose containers are watched.
+func (s *CrashDumpSpec) GetServices() []string {
+	if s == nil || len(s.Services) == 0 {
+		return []string{"history", "matching"}
+	}
+	return s.Services
+}
+
+// GetRestartThreshold returns the number of restarts from which a bundle is collected.
+func (s *CrashDumpSpec) GetRestartThreshold() int32 {
+	if s == nil || s.RestartThreshold == nil {
+		return 3
+	}
+	return *s.RestartThreshold
+}
+
+// GetLogLines returns the number of lines of the crashed container logs kept in the bundle.
+func (s *CrashDumpSpec) GetLogLines() int64 {
+	if s == nil || s.LogLines == nil {
+		return 1000
+	}
+	return *s.LogLines
+}
+
+// GetMaxBundles returns the number of bundles kept for the cluster.
+func (s *CrashDumpSpec) GetMaxBundles() int {
+	if s == nil || s.MaxBundles == nil {
+		return 5
+	}
+	return int(*s.MaxBundles)
+}
+
+// ResourceAdvisorSpec configures the services resources recommendations.
+type ResourceAdvisorSpec struct {
+	// Interval is the interval between two usage samples. Defaults to 5 minutes.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Window is the duration during which the peak usage is tracked before being reset. Defaults to 7 days.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+	// Margin is the percentage added to the peak usage to compute the recommended requests. Defaults to 20.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Margin *int32 `json:"margin,omitempty"`
+}
+
+// IsEnabled returns true if resources recommendations are enabled.
+func (s *ResourceAdvisorSpec) IsEnabled() bool {
+	return s != nil
+}
+
+// GetInterval returns the interval between two usage samples.
+func (s *ResourceAdvisorSpec) GetInterval() time.Duration {
+	if s == nil || s.Interval == nil {
+		return 5 * time.Minute
+	}
+	return s.Interval.Duration
+}
+
+// GetWindow returns the duration during which the peak usage is tracked.
+func (s *ResourceAdvisorSpec) GetWindow() time.Duration {
+	if s == nil || s.Window == nil {
+		return 7 * 24 * time.Hour
+	}
+	return s.Window.Duration
+}
+
+// GetMargin returns the percentage added to the peak usage.
+func (s *ResourceAdvisorSpec) GetMargin() int32 {
+	if s == nil || s.Margin == nil {
+		return 20
+	}
+	return *s.Margin
+}
+
+// BackupS3Spec defines the access to an S3 bucket.
+type BackupS3Spec struct {
+	// Region is the aws s3 region.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Use Endpoint if you want to use s3-compatible object storage.
+	// +optional
+	Endpoint *string `json:"endpoint,omitempty"`
+	// Use credentials if you want to use aws credentials from secret.
+	// Pods use the service account credentials otherwise.
+	// +optional
+	Credentials *S3Credentials `json:"credentials,omitempty"`
+}
+
+// BackupGCSSpec defines the access to a Google Cloud Storage bucket.
+type BackupGCSSpec struct {
+	// CredentialsRef is the secret key selector containing Google Cloud Storage credentials file.
+	// Pods use the service account credentials otherwise.
+	// +optional
+	CredentialsRef *corev1.SecretKeySelector `json:"credentialsRef,omitempty"`
+}
+
+// CredentialsFileMountPath returns the path the credentials file is mounted at.
+func (BackupGCSSpec) CredentialsFileMountPath() string {
+	return "/etc/backup/credentials/credentials.json"
+}
+
+// BackupSpec defines the periodic export of the cluster spec, rendered configuration,
+// dynamic config and namespace list to an object storage bucket.
+type BackupSpec struct {
+	// Enabled defines if the backup is enabled.
+	Enabled bool `json:"enabled"`
+	// Schedule is the cron schedule of the exports. Defaults to every day at midnight.
+	// +kubebuilder:default:="0 0 * * *"
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// URL is the bucket URL the exports are written to, like s3://bucket/prefix or gs://bucket/prefix.
+	// Each export is written in a directory named after its date.
+	// +kubebuilder:validation:Pattern=`^(s3|gs)://.+`
+	URL string `json:"url"`
+	// S3 configures the access to the bucket for s3:// URLs.
+	// +optional
+	S3 *BackupS3Spec `json:"s3,omitempty"`
+	// GCS configures the access to the bucket for gs:// URLs.
+	// +optional
+	GCS *BackupGCSSpec `json:"gcs,omitempty"`
+	// Image is the image uploading the exports. It must provide the aws or gcloud CLI.
+	// Defaults to amazon/aws-cli for s3:// URLs and google/cloud-sdk:slim for gs:// URLs.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// ServiceAccountName is the service account the export pods run with,
+	// allowing the use of cloud workload identities.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Resources of the export containers.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// IsEnabled returns true if the backup is enabled.
+func (s *BackupSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// IsGCS returns true if the exports are written to a Google Cloud Storage bucket.
+func (s *BackupSpec) IsGCS() bool {
+	return strings.HasPrefix(s.URL, "gs://")
+}
+
+// GetImage returns the image uploading the exports.
+func (s *BackupSpec) GetImage() string {
+	switch {
+	case s.Image != "":
+		return s.Image
+	case s.IsGCS():
+		return "google/cloud-sdk:slim"
+	default:
+		return "amazon/aws-cli"
+	}
+}
+
+// ServiceStatus reports a service status.
+type ServiceStatus struct {
+	// Name of the temporal service.
+	Name string `json:"name"`
 	// Current observed version of the service.
 	Version string `json:"version"`
 	// Ready defines if the service is ready.
 	Ready bool `json:"ready"`
+	// RestartedAt is the time the service restart was last requested using the temporal.io/restart-service annotation.
+	// +optional
+	RestartedAt *metav1.Time `json:"restartedAt,omitempty"`
+}
+
+// DatastoreStatus contains the current status of a datastore.
+type DatastoreStatus struct {
+	// Created indicates if the database or keyspace has been created.
+	Created bool `json:"created"`
+	// Setup indicates if tables have been set up.
+	Setup bool `json:"setup"`
+	// Type indicates the datastore type.
+	// +optional
+	Type DatastoreType `json:"type"`
+	// SchemaVersion report the current schema version.
+	// +optional
+	SchemaVersion *version.Version `json:"schemaVersion,omitempty"`
+	// DatabaseName is the SQL database or the cassandra keyspace the datastore has been set up in.
+	// +optional
+	DatabaseName string `json:"databaseName,omitempty"`
+	// ConnectAddr is the address of the SQL server the datastore has been set up in.
+	// +optional
+	ConnectAddr string `json:"connectAddr,omitempty"`
+	// PluginName is the SQL plugin the datastore has been set up with.
+	// +optional
+	PluginName string `json:"pluginName,omitempty"`
+}
+
+// RecordEndpoint records the connection address, SQL plugin and database name of the provided datastore spec.
+func (s *DatastoreStatus) RecordEndpoint(spec *DatastoreSpec) {
+	s.DatabaseName = spec.GetDatabaseName()
+	if spec.SQL != nil {
+		s.ConnectAddr = spec.SQL.ConnectAddr
+		s.PluginName = spec.SQL.PluginName
+	}
+}
+
+// EndpointChanges returns the differences between the endpoint the datastore has been set up in and the provided spec.
+// Switching between versions of the same SQL plugin, like postgres to postgres12, is not reported.
+func (s *DatastoreStatus) EndpointChanges(spec *DatastoreSpec) []string {
+	changes := []string{}
+
+	if s.DatabaseName != "" && s.DatabaseName != spec.GetDatabaseName() {
+		changes = append(changes, fmt.Sprintf("database name changed from %q to %q", s.DatabaseName, spec.GetDatabaseName()))
+	}
+
+	if spec.SQL == nil {
+		return changes
+	}
+
+	if s.ConnectAddr != "" && s.ConnectAddr != spec.SQL.ConnectAddr {
+		changes = append(changes, fmt.Sprintf("connect address changed from %q to %q", s.ConnectAddr, spec.SQL.ConnectAddr))
+	}
+
+	if s.PluginName != "" && sqlPluginFamily(s.PluginName) != sqlPluginFamily(spec.SQL.PluginName) {
+		changes = append(changes, fmt.Sprintf("plugin changed from %q to %q", s.PluginName, spec.SQL.PluginName))
+	}
+
+	return changes
 }
 
-// DatastoreStatus contains the current status of a datastore.
-type DatastoreStatus struct {
-	// Created indicates if the database or keyspace has been created.
-	Created bool `json:"created"`
-	// Setup indicates if tables have been set up.
-	Setup bool `json:"setup"`
-	// Type indicates the datastore type.
-	// +optional
-	Type DatastoreType `json:"type"`
-	// SchemaVersion report the current schema version.
-	// +optional
-	SchemaVersion *version.Version `json:"schemaVersion,omitempty"`
+// sqlPluginFamily returns the database engine of the provided SQL plugin.
+func sqlPluginFamily(plugin string) string {
+	for _, family := range []string{string(PostgresSQLDatastore), string(MySQLDatastore)} {
+		if strings.HasPrefix(plugin, family) {
+			return family
+		}
+	}
+	return plugin
+}
+
+// MigrationPending returns true if the provided datastore spec references another database
+// than the one the datastore has been set up in.
+func (s *DatastoreStatus) MigrationPending(spec *DatastoreSpec) bool {
+	return s != nil && s.DatabaseName != "" && s.DatabaseName != spec.GetDatabaseName()
 }
 
 // TemporalPersistenceStatus contains temporal persistence status.
@@ -1093,16 +3351,482 @@ type TemporalPersistenceStatus struct {
 	// AdvancedVisibilityStore holds the advanced visibility datastore status.
 	// +optional
 	AdvancedVisibilityStore *DatastoreStatus `json:"advancedVisibilityStore,omitempty"`
+	// MigrationProgress reports the progress of the running schema update job, if any.
+	// +optional
+	MigrationProgress *MigrationProgress `json:"migrationProgress,omitempty"`
+	// ConsecutiveFailures is the number of consecutive failed persistence reconciliations.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+	// LastFailureTime is the time of the last failed persistence reconciliation.
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+}
+
+// MigrationProgress reports the progress of a running schema update job,
+// parsed from the schema tool output.
+type MigrationProgress struct {
+	// Store is the name of the datastore being updated.
+	Store string `json:"store"`
+	// Job is the name of the schema update job.
+	Job string `json:"job"`
+	// CurrentVersion is the last schema version applied to the datastore.
+	// +optional
+	CurrentVersion string `json:"currentVersion,omitempty"`
+	// ApplyingVersion is the schema version being applied.
+	// +optional
+	ApplyingVersion string `json:"applyingVersion,omitempty"`
+	// AppliedSteps is the number of schema versions applied by the job so far.
+	AppliedSteps int32 `json:"appliedSteps"`
+	// TotalSteps is the number of schema versions the job has to apply, zero until known.
+	TotalSteps int32 `json:"totalSteps"`
+	// LastUpdateTime is the last time the progress was reported.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// BlueGreenPhase is the phase of a blue/green upgrade.
+type BlueGreenPhase string
+
+const (
+	// BlueGreenDeployingPhase means the target version deployments are being rolled out
+	// while the frontend Service still routes traffic to the current version.
+	BlueGreenDeployingPhase BlueGreenPhase = "Deploying"
+	// BlueGreenSwitchedPhase means the frontend Service routes traffic to the target version deployments
+	// while the current deployments are upgraded in place.
+	BlueGreenSwitchedPhase BlueGreenPhase = "Switched"
+)
+
+// BlueGreenStatus defines the state of an ongoing blue/green upgrade.
+type BlueGreenStatus struct {
+	// TargetVersion is the version being rolled out.
+	TargetVersion string `json:"targetVersion"`
+	// Phase is the current upgrade phase.
+	Phase BlueGreenPhase `json:"phase"`
+	// MirroringStartTime is the time the frontend proxies started mirroring
+	// the read-only requests to the ready target frontends.
+	// +optional
+	MirroringStartTime *metav1.Time `json:"mirroringStartTime,omitempty"`
+}
+
+// SmokeTestStatus defines the result of a smoke test run.
+type SmokeTestStatus struct {
+	// Version is the cluster version the smoke test ran against.
+	Version string `json:"version"`
+	// Succeeded is true if the smoke test workflow completed successfully.
+	Succeeded bool `json:"succeeded"`
+	// Running is true while a smoke test run is in progress.
+	// +optional
+	Running bool `json:"running,omitempty"`
+	// LastRunTime is the start time of the last smoke test run.
+	LastRunTime metav1.Time `json:"lastRunTime"`
+	// Message holds the error of the last failed smoke test run.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CanaryStatus reports the result of the canary workflows.
+type CanaryStatus struct {
+	// LastRunTime is the time of the last canary run.
+	LastRunTime metav1.Time `json:"lastRunTime"`
+	// LastSuccessTime is the time of the last successful canary run.
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+	// Latency is the duration of the last successful canary run.
+	// +optional
+	Latency *metav1.Duration `json:"latency,omitempty"`
+	// ConsecutiveFailures is the number of canary runs which failed since the last successful one.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+	// Message holds the error of the last failed canary run.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RemoteClusterReplicationStatus reports the replication health to a remote cluster.
+type RemoteClusterReplicationStatus struct {
+	// Name of the remote cluster.
+	Name string `json:"name"`
+	// Connected is true if the remote cluster is registered and its connection is enabled.
+	Connected bool `json:"connected"`
+	// Lag is the maximum replication lag to the remote cluster across all history shards.
+	// +optional
+	Lag *metav1.Duration `json:"lag,omitempty"`
+	// Message holds the reason why replication to the remote cluster is unhealthy.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ReplicationStatus defines the observed state of replication.
+type ReplicationStatus struct {
+	// RemoteClusters holds the replication health to each remote cluster.
+	// +optional
+	RemoteClusters []RemoteClusterReplicationStatus `json:"remoteClusters,omitempty"`
+	// LastCheckTime is the time of the last replication health check.
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+}
+
+// TaskQueueWorkloadStatus reports the workload of a task queue.
+type TaskQueueWorkloadStatus struct {
+	// Namespace is the name of the Temporal namespace of the task queue.
+	Namespace string `json:"namespace"`
+	// Name of the task queue.
+	Name string `json:"name"`
+	// Type of the task queue.
+	Type TaskQueueType `json:"type"`
+	// BacklogCountHint is the approximate number of tasks waiting in the task queue root partition.
+	// +optional
+	BacklogCountHint int64 `json:"backlogCountHint"`
+	// Pollers is the number of workers recently polling the task queue.
+	// +optional
+	Pollers int32 `json:"pollers"`
+	// Message holds the reason why the task queue workload can't be retrieved, if any.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// WorkloadStatus is a snapshot of the cluster workload.
+type WorkloadStatus struct {
+	// TaskQueues holds the workload of the monitored task queues.
+	// +optional
+	TaskQueues []TaskQueueWorkloadStatus `json:"taskQueues,omitempty"`
+	// LastCheckTime is the time of the snapshot.
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+}
+
+// RecommendationsStatus holds the services resources recommendations.
+type RecommendationsStatus struct {
+	// Services holds the recommendations of each service.
+	// +optional
+	Services []ResourceRecommendation `json:"services,omitempty"`
+	// LastSampleTime is the time of the last usage sample.
+	LastSampleTime metav1.Time `json:"lastSampleTime"`
+}
+
+// ResourceRecommendation is a non-binding resources recommendation for a temporal service pods.
+type ResourceRecommendation struct {
+	// Service is the name of the temporal service.
+	Service string `json:"service"`
+	// PeakCPU is the highest CPU usage of a single pod of the service observed since the window start.
+	PeakCPU resource.Quantity `json:"peakCPU"`
+	// PeakMemory is the highest memory usage of a single pod of the service observed since the window start.
+	PeakMemory resource.Quantity `json:"peakMemory"`
+	// CPU is the recommended CPU request.
+	CPU resource.Quantity `json:"cpu"`
+	// Memory is the recommended memory request and limit.
+	Memory resource.Quantity `json:"memory"`
+	// Samples is the number of usage samples taken since the window start.
+	Samples int32 `json:"samples"`
+	// WindowStart is the time from which the peak usage is tracked.
+	WindowStart metav1.Time `json:"windowStart"`
+}
+
+// CatalogStatus holds the cluster metadata published for developer portals catalogs.
+type CatalogStatus struct {
+	// ComponentID is the backstage.io/kubernetes-id label value set on the cluster resources.
+	ComponentID string `json:"componentId"`
+	// FrontendAddress is the in-cluster address of the frontend service.
+	FrontendAddress string `json:"frontendAddress"`
+	// FrontendHostnames are the hostnames published for the frontend outside of kubernetes.
+	// +optional
+	FrontendHostnames []string `json:"frontendHostnames,omitempty"`
+	// UIURL is the URL of the UI, when enabled.
+	// +optional
+	UIURL string `json:"uiUrl,omitempty"`
+	// Namespaces are the names of the temporal namespaces managed for the cluster, sorted.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// InventoryEntry references a child resource created for the cluster.
+type InventoryEntry struct {
+	// Group is the API group of the resource.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Version is the API version of the resource.
+	Version string `json:"version"`
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+	// Name is the name of the resource, in the cluster namespace.
+	Name string `json:"name"`
+	// UID is the UID of the resource, used to only delete the resource created by the operator.
+	UID types.UID `json:"uid"`
+}
+
+// GroupVersionKind returns the GroupVersionKind of the referenced resource.
+func (e InventoryEntry) GroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: e.Group, Version: e.Version, Kind: e.Kind}
+}
+
+// ManagedResourceStatus reports the state of a child resource managed for the cluster.
+type ManagedResourceStatus struct {
+	// APIVersion is the API version of the resource.
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+	// Namespace is the namespace of the resource, empty for cluster-scoped resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Healthy is true when the resource reached its desired state, like a ready deployment or an issued certificate.
+	Healthy bool `json:"healthy"`
+	// LastAppliedHash is the hash of the resource desired state last applied by the operator.
+	// It changes each time the operator updates the resource.
+	LastAppliedHash string `json:"lastAppliedHash"`
+}
+
+// ClusterMetadataSpec is the metadata recorded by temporal in the cluster metadata.
+type ClusterMetadataSpec struct {
+	// Tags are arbitrary key/value pairs describing the cluster, like its region or environment.
+	// They are persisted by temporal in the cluster metadata, returned by the admin DescribeCluster API,
+	// and shared with the remote clusters connected using replication.
+	// Requires temporal >= 1.21.0.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// GetTags returns the cluster metadata tags.
+func (s *ClusterMetadataSpec) GetTags() map[string]string {
+	if s == nil {
+		return nil
+	}
+	return s.Tags
+}
+
+// ClusterInfoStatus is the cluster metadata reported by the running cluster frontend.
+type ClusterInfoStatus struct {
+	// ClusterID is the unique id of the cluster, generated when its persistence is initialized.
+	// +optional
+	ClusterID string `json:"clusterId,omitempty"`
+	// ClusterName is the name of the cluster as persisted in the cluster metadata.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+	// ServerVersion is the version of the temporal server.
+	// +optional
+	ServerVersion string `json:"serverVersion,omitempty"`
+	// HistoryShardCount is the number of history shards of the cluster.
+	// +optional
+	HistoryShardCount int32 `json:"historyShardCount,omitempty"`
+	// InitialFailoverVersion is the initial failover version of the cluster.
+	// +optional
+	InitialFailoverVersion int64 `json:"initialFailoverVersion,omitempty"`
+	// Tags are the cluster metadata tags reported by the admin DescribeCluster API.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+	// Message describes the differences between the cluster metadata and the spec, if any.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastCheckTime is the time the cluster metadata was retrieved.
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+}
+
+// CrashDumpStatus describes a debug bundle collected from a crash looping container.
+type CrashDumpStatus struct {
+	// Pod is the name of the crashed pod.
+	Pod string `json:"pod"`
+	// Container is the name of the crashed container.
+	Container string `json:"container"`
+	// RestartCount is the container restart count when the bundle was collected.
+	RestartCount int32 `json:"restartCount"`
+	// BundleRef references the ConfigMap holding the bundle.
+	BundleRef corev1.LocalObjectReference `json:"bundleRef"`
+	// CollectionTime is the time the bundle was collected.
+	CollectionTime metav1.Time `json:"collectionTime"`
+}
+
+// ShardDiagnosticsStatus summarizes the last shard diagnostics requested using the temporal.io/diagnose-shards annotation.
+type ShardDiagnosticsStatus struct {
+	// ShardRange is the range of inspected shard ids.
+	ShardRange string `json:"shardRange"`
+	// InspectedShards is the number of inspected shards.
+	InspectedShards int32 `json:"inspectedShards"`
+	// UnownedShards is the number of shards not owned by any ready history host.
+	// +optional
+	UnownedShards int32 `json:"unownedShards,omitempty"`
+	// StaleShards is the number of shards whose info wasn't persisted recently by their owner.
+	// +optional
+	StaleShards int32 `json:"staleShards,omitempty"`
+	// StolenShards is the number of shards whose ownership changed since their last renewal.
+	// +optional
+	StolenShards int32 `json:"stolenShards,omitempty"`
+	// UnreachableShards is the number of shards whose owner didn't answer.
+	// +optional
+	UnreachableShards int32 `json:"unreachableShards,omitempty"`
+	// ReportRef is the ConfigMap holding the detailed report.
+	ReportRef corev1.LocalObjectReference `json:"reportRef"`
+	// Time is the time the shards were inspected.
+	Time metav1.Time `json:"time"`
+}
+
+// DynamicConfigSource is the spec field a dynamic config value is rendered from.
+type DynamicConfigSource string
+
+const (
+	// ValuesDynamicConfigSource is spec.dynamicConfig.values.
+	ValuesDynamicConfigSource DynamicConfigSource = "Values"
+	// QueueProcessorProfileDynamicConfigSource is spec.dynamicConfig.queueProcessorProfile.
+	QueueProcessorProfileDynamicConfigSource DynamicConfigSource = "QueueProcessorProfile"
+	// AutoTuneDynamicConfigSource is spec.dynamicConfig.autoTune.
+	AutoTuneDynamicConfigSource DynamicConfigSource = "AutoTune"
+	// FeaturesDynamicConfigSource is spec.features.
+	FeaturesDynamicConfigSource DynamicConfigSource = "Features"
+	// GracefulShutdownDynamicConfigSource is spec.services.[service].gracefulShutdown.
+	GracefulShutdownDynamicConfigSource DynamicConfigSource = "GracefulShutdown"
+	// PersistenceRateLimitsDynamicConfigSource is spec.persistence.rateLimits.
+	PersistenceRateLimitsDynamicConfigSource DynamicConfigSource = "PersistenceRateLimits"
+	// NamespaceRateLimitsDynamicConfigSource is the TemporalNamespaces spec.rateLimits.
+	NamespaceRateLimitsDynamicConfigSource DynamicConfigSource = "NamespaceRateLimits"
+	// NamespaceTaskQueuesDynamicConfigSource is the TemporalNamespaces spec.taskQueues.
+	NamespaceTaskQueuesDynamicConfigSource DynamicConfigSource = "NamespaceTaskQueues"
+	// NamespaceLimitsDynamicConfigSource is the TemporalNamespaces spec.limits.
+	NamespaceLimitsDynamicConfigSource DynamicConfigSource = "NamespaceLimits"
+	// NamespaceVisibilityStoreDynamicConfigSource is the TemporalNamespaces spec.visibilityStore.
+	NamespaceVisibilityStoreDynamicConfigSource DynamicConfigSource = "NamespaceVisibilityStore"
+	// VersionDefaultsDynamicConfigSource is the operator built-in defaults enabled by spec.dynamicConfig.versionDefaults.
+	VersionDefaultsDynamicConfigSource DynamicConfigSource = "VersionDefaults"
+)
+
+// DynamicConfigKeyStatus reports where the values of a dynamic config key are rendered from.
+type DynamicConfigKeyStatus struct {
+	// Key is the dynamic config key.
+	Key string `json:"key"`
+	// Sources are the spec fields the key values are rendered from.
+	Sources []DynamicConfigSource `json:"sources"`
+	// OverriddenSources are the spec fields whose values for the key are ignored,
+	// as a value with the same constraints is set by a source taking precedence.
+	// +optional
+	OverriddenSources []DynamicConfigSource `json:"overriddenSources,omitempty"`
+}
+
+// DynamicConfigStatus reports the effective dynamic config of the cluster.
+type DynamicConfigStatus struct {
+	// ConfigMapName is the name of the ConfigMap holding the effective dynamic config.
+	ConfigMapName string `json:"configMapName"`
+	// Keys reports where the values of each key of the effective dynamic config are rendered from.
+	// +optional
+	Keys []DynamicConfigKeyStatus `json:"keys,omitempty"`
+}
+
+// RolloutStatus defines the state of an ongoing rollout.
+type RolloutStatus struct {
+	// StartTime is the time the rollout started.
+	StartTime metav1.Time `json:"startTime"`
+	// SilenceID is the id of the alertmanager silence created for the rollout.
+	// +optional
+	SilenceID string `json:"silenceID,omitempty"` //nolint:tagliatelle
+}
+
+// ElasticsearchMappingStatus reports the drift between a visibility index mapping and the expected search attributes.
+type ElasticsearchMappingStatus struct {
+	// Datastore is the name of the elasticsearch datastore.
+	Datastore string `json:"datastore"`
+	// Index is the name of the checked visibility index.
+	Index string `json:"index"`
+	// MissingFields lists the expected search attributes missing from the index mapping.
+	// +optional
+	MissingFields []string `json:"missingFields,omitempty"`
+	// TypeConflicts lists the search attributes mapped with an unexpected type.
+	// +optional
+	TypeConflicts []ElasticsearchFieldConflict `json:"typeConflicts,omitempty"`
+	// RepairedFields lists the missing fields added to the index mapping by the last check, when autoRepair is enabled.
+	// +optional
+	RepairedFields []string `json:"repairedFields,omitempty"`
+	// LastCheckTime is the time of the last check.
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+}
+
+// ElasticsearchFieldConflict describes a search attribute mapped with an unexpected type.
+type ElasticsearchFieldConflict struct {
+	// Field is the search attribute name.
+	Field string `json:"field"`
+	// ExpectedType is the expected elasticsearch field type.
+	ExpectedType string `json:"expectedType"`
+	// ActualType is the elasticsearch field type found in the index mapping.
+	ActualType string `json:"actualType"`
+}
+
+// HasDrift returns true if the index mapping doesn't match the expected search attributes.
+func (s *ElasticsearchMappingStatus) HasDrift() bool {
+	return len(s.MissingFields) > 0 || len(s.TypeConflicts) > 0
 }
 
 // TemporalClusterStatus defines the observed state of Cluster.
 type TemporalClusterStatus struct {
 	// Version holds the current temporal version.
 	Version string `json:"version,omitempty"`
+	// SupportedVersionRange holds the temporal versions range supported by the operator managing the cluster.
+	// +optional
+	SupportedVersionRange string `json:"supportedVersionRange,omitempty"`
 	// Services holds all services statuses.
 	Services []ServiceStatus `json:"services,omitempty"`
 	// Persistence holds all datastores statuses.
 	Persistence *TemporalPersistenceStatus `json:"persistence,omitempty"`
+	// SmokeTest holds the result of the last smoke test run.
+	// +optional
+	SmokeTest *SmokeTestStatus `json:"smokeTest,omitempty"`
+	// Canary holds the result of the canary workflows.
+	// +optional
+	Canary *CanaryStatus `json:"canary,omitempty"`
+	// BlueGreen holds the state of the ongoing blue/green upgrade, if any.
+	// +optional
+	BlueGreen *BlueGreenStatus `json:"blueGreen,omitempty"`
+	// Replication holds the replication health to the remote clusters.
+	// +optional
+	Replication *ReplicationStatus `json:"replication,omitempty"`
+	// Rollout holds the state of the ongoing rollout, if any.
+	// Only tracked when rollout notifications are configured.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+	// Workload holds the last snapshot of the monitored task queues workload.
+	// +optional
+	Workload *WorkloadStatus `json:"workload,omitempty"`
+	// ClusterInfo holds the cluster metadata reported by the running cluster.
+	// +optional
+	ClusterInfo *ClusterInfoStatus `json:"clusterInfo,omitempty"`
+	// ShardDiagnostics summarizes the last shard diagnostics requested using the temporal.io/diagnose-shards annotation.
+	// +optional
+	ShardDiagnostics *ShardDiagnosticsStatus `json:"shardDiagnostics,omitempty"`
+	// CrashDumps lists the debug bundles collected from crash looping containers, when spec.crashDump is enabled.
+	// +optional
+	CrashDumps []CrashDumpStatus `json:"crashDumps,omitempty"`
+	// DynamicConfig reports the effective dynamic config of the cluster,
+	// and which spec fields its keys are rendered from.
+	// +optional
+	DynamicConfig *DynamicConfigStatus `json:"dynamicConfig,omitempty"`
+	// ImageDigests maps the cluster images tagged references to the digest references pods are pinned to.
+	// Only set when spec.resolveImageDigests is enabled.
+	// +optional
+	ImageDigests map[string]string `json:"imageDigests,omitempty"`
+	// VerifiedImages lists the digest references of the temporal images whose signature has been verified.
+	// Only set when spec.imageVerification is enabled.
+	// +optional
+	VerifiedImages []string `json:"verifiedImages,omitempty"`
+	// Recommendations holds the services resources recommendations, when spec.resourceAdvisor is set.
+	// +optional
+	Recommendations *RecommendationsStatus `json:"recommendations,omitempty"`
+	// Catalog holds the cluster metadata published for developer portals, when spec.catalog is enabled.
+	// +optional
+	Catalog *CatalogStatus `json:"catalog,omitempty"`
+	// AppliedOverrides lists the TemporalClusterOverrides applied to the cluster spec, in their application order.
+	// +optional
+	AppliedOverrides []string `json:"appliedOverrides,omitempty"`
+	// Inventory lists the child resources created for the cluster by the last reconciliation.
+	// Resources no longer rendered by the operator, for instance after an operator upgrade, are deleted.
+	// +optional
+	Inventory []InventoryEntry `json:"inventory,omitempty"`
+	// Resources lists the child resources managed for the cluster with their health,
+	// allowing tools to render the managed resources tree without relying on labels selectors.
+	// +optional
+	Resources []ManagedResourceStatus `json:"resources,omitempty"`
+	// ElasticsearchMappings reports the drift between the visibility indices mappings and the expected search attributes,
+	// for elasticsearch datastores with mappingCheck enabled.
+	// +optional
+	ElasticsearchMappings []ElasticsearchMappingStatus `json:"elasticsearchMappings,omitempty"`
+	// LastReconcileTime is the time of the last reconciliation of the cluster.
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+	// LastReconcileError holds the error returned by the last reconciliation, if any.
+	// +optional
+	LastReconcileError string `json:"lastReconcileError,omitempty"`
 	// Conditions represent the latest available observations of the Cluster state.
 	Conditions []metav1.Condition `json:"conditions"`
 }
@@ -1122,6 +3846,16 @@ func (s *TemporalClusterStatus) AddServiceStatus(status *ServiceStatus) {
 	}
 }
 
+// ServiceRestartedAt returns the time the restart of the provided service was last requested, if any.
+func (s *TemporalClusterStatus) ServiceRestartedAt(name string) *metav1.Time {
+	for _, serviceStatus := range s.Services {
+		if serviceStatus.Name == name {
+			return serviceStatus.RestartedAt
+		}
+	}
+	return nil
+}
+
 // +genclient
 // +genclient:Namespaced
 // +kubebuilder:object:root=true
@@ -1157,7 +3891,15 @@ func (c *TemporalCluster) ServerName() string {
 
 // FQDNSuffix returns the cluster's FQDN suffix.
 func (c *TemporalCluster) FQDNSuffix() string {
-	return fmt.Sprintf("%s.svc.cluster.local", c.Namespace)
+	return fmt.Sprintf("%s.svc.%s", c.Namespace, c.GetClusterDomain())
+}
+
+// GetClusterDomain returns the DNS domain of the kubernetes cluster.
+func (c *TemporalCluster) GetClusterDomain() string {
+	if c.Spec.ClusterDomain == "" {
+		return DefaultClusterDomain
+	}
+	return c.Spec.ClusterDomain
 }
 
 // MTLSEnabled returns true if mTLS is enabled for internode or frontend using cert-manager.
@@ -1167,15 +3909,314 @@ func (c *TemporalCluster) MTLSWithCertManagerEnabled() bool {
 		c.Spec.MTLS.Provider == CertManagerMTLSProvider
 }
 
+// HistoryHostsReachable returns true if the operator can call the history hosts directly.
+// With internode mTLS, the operator authenticates using the internode certificate, only available with cert-manager.
+func (c *TemporalCluster) HistoryHostsReachable() bool {
+	return c.Spec.MTLS == nil || !c.Spec.MTLS.InternodeEnabled() || c.MTLSWithCertManagerEnabled()
+}
+
+// podStartupEstimate is the estimated time needed for a temporal service pod to be scheduled, pull its image and become ready.
+const podStartupEstimate = time.Minute
+
+// EstimatedRolloutDuration returns a conservative estimate of the time needed to replace all the cluster's services pods,
+// one at a time: each pod is stopped gracefully, then replaced by a new one which has to become ready.
+func (c *TemporalCluster) EstimatedRolloutDuration() time.Duration {
+	var total time.Duration
+	for _, name := range c.DeployedServices() {
+		var spec *ServiceSpec
+		if c.Spec.Services != nil {
+			spec, _ = c.Spec.Services.GetServiceSpec(name)
+		}
+		if spec == nil {
+			spec = &ServiceSpec{}
+		}
+
+		replicas := int32(1)
+		if spec.Replicas != nil && *spec.Replicas > replicas {
+			replicas = *spec.Replicas
+		}
+
+		perPod := spec.GracefulShutdown.GetTerminationGracePeriod(30*time.Second) + podStartupEstimate
+		if spec.Traffic.ReadinessGateEnabled() {
+			perPod += spec.Traffic.ReadinessGateDelay.Duration
+		}
+
+		total += time.Duration(replicas) * perPod
+	}
+	return total
+}
+
+// CertificateRenewalWindow returns the minimum time before expiry the cluster's certificates should be renewed:
+// the services have to reload the renewed certificates, then all pods may have to be rolled.
+func (c *TemporalCluster) CertificateRenewalWindow() time.Duration {
+	refreshInterval := time.Hour
+	if c.Spec.MTLS != nil && c.Spec.MTLS.RefreshInterval != nil {
+		refreshInterval = c.Spec.MTLS.RefreshInterval.Duration
+	}
+	return refreshInterval + c.EstimatedRolloutDuration()
+}
+
+// CertificateRenewBefore returns the renewBefore of a certificate with the provided duration.
+// When the configured renewBefore, or cert-manager's default of a third of the duration, is shorter
+// than the certificate renewal window, the window is used instead as long as it fits in the certificate duration.
+func (c *TemporalCluster) CertificateRenewBefore(duration *metav1.Duration) *metav1.Duration {
+	renewBefore := c.Spec.MTLS.RenewBefore
+	if duration == nil {
+		return renewBefore
+	}
+
+	current := duration.Duration / 3
+	if renewBefore != nil {
+		current = renewBefore.Duration
+	}
+
+	window := c.CertificateRenewalWindow()
+	if current >= window || window >= duration.Duration {
+		return renewBefore
+	}
+
+	return &metav1.Duration{Duration: window}
+}
+
+// GetDatastorePasswordSecretRef returns the reference to the secret holding the plain text password of the provided datastore.
+// If persistence secret decryption is enabled, it references the secret holding the password decrypted by the operator.
+func (c *TemporalCluster) GetDatastorePasswordSecretRef(store *DatastoreSpec) *SecretKeyReference {
+	if store.PasswordSecretRef == nil || c.Spec.Persistence.SecretDecryption == nil {
+		return store.PasswordSecretRef
+	}
+
+	return &SecretKeyReference{
+		Name: c.ChildResourceName(fmt.Sprintf("%s-datastore-password", slug.Make(store.Name))),
+		Key:  DecryptedPasswordSecretKey,
+	}
+}
+
 // ChildResourceName returns child resource name using the cluster's name.
 func (c *TemporalCluster) ChildResourceName(resource string) string {
 	return fmt.Sprintf("%s-%s", c.Name, resource)
 }
 
+// ManagedElasticsearchName returns the name of the resources running the managed Elasticsearch instance of the provided datastore.
+func (c *TemporalCluster) ManagedElasticsearchName(store *DatastoreSpec) string {
+	return c.ChildResourceName(fmt.Sprintf("%s-elasticsearch", store.LowerCaseName()))
+}
+
+// ManagedElasticsearchURL returns the url of the managed Elasticsearch instance of the provided datastore.
+func (c *TemporalCluster) ManagedElasticsearchURL(store *DatastoreSpec) string {
+	return fmt.Sprintf("http://%s.%s:%d", c.ManagedElasticsearchName(store), c.FQDNSuffix(), ManagedElasticsearchPort)
+}
+
+// DatastoreAliasServiceName returns the name of the ExternalName service aliasing the provided datastore host.
+func (c *TemporalCluster) DatastoreAliasServiceName(store *DatastoreSpec) string {
+	return c.ChildResourceName(fmt.Sprintf("%s-datastore", store.LowerCaseName()))
+}
+
+// GetDatastoreConnectAddr returns the address the provided SQL datastore is reached at,
+// which is its alias service if enabled.
+func (c *TemporalCluster) GetDatastoreConnectAddr(store *DatastoreSpec) string {
+	if !store.ServiceAliasEnabled() {
+		return store.SQL.ConnectAddr
+	}
+
+	_, port, err := net.SplitHostPort(store.SQL.ConnectAddr)
+	if err != nil {
+		return store.SQL.ConnectAddr
+	}
+
+	return net.JoinHostPort(c.DatastoreAliasServiceName(store), port)
+}
+
 func (c *TemporalCluster) GetPublicClientAddress() string {
 	return fmt.Sprintf("%s.%s:%d", c.ChildResourceName("frontend"), c.GetNamespace(), *c.Spec.Services.Frontend.Port)
 }
 
+// GetInClusterClientAddress returns the address of the frontend service using its fully qualified domain name,
+// for clients running in any namespace of the kubernetes cluster.
+func (c *TemporalCluster) GetInClusterClientAddress() string {
+	return fmt.Sprintf("%s.%s:%d", c.ChildResourceName("frontend"), c.FQDNSuffix(), *c.Spec.Services.Frontend.Port)
+}
+
+// GetExternalClientAddress returns the address of the frontend for clients running outside of the kubernetes cluster,
+// using the first hostname published in spec.expose.hostnames.frontend.
+// It returns false if no hostname is published for the frontend.
+func (c *TemporalCluster) GetExternalClientAddress() (string, bool) {
+	hostnames := c.Spec.Expose.GetFrontendHostnames()
+	if len(hostnames) == 0 {
+		return "", false
+	}
+	return net.JoinHostPort(hostnames[0], strconv.Itoa(*c.Spec.Services.Frontend.Port)), true
+}
+
+// GetLocalFrontendAddress returns the address of the cluster's own frontend pods.
+// It differs from the public client address when the frontend Service is mapped to an external endpoint.
+func (c *TemporalCluster) GetLocalFrontendAddress() string {
+	if c.Spec.Expose.GetExternalFrontend() == nil {
+		return c.GetPublicClientAddress()
+	}
+	return fmt.Sprintf("%s.%s:%d", c.ChildResourceName("frontend-headless"), c.GetNamespace(), *c.Spec.Services.Frontend.Port)
+}
+
+// GetClientLoadBalancingTarget returns the gRPC target resolving all ready frontend pods,
+// to be used with client-side load balancing.
+func (c *TemporalCluster) GetClientLoadBalancingTarget() string {
+	return fmt.Sprintf("dns:///%s.%s:%d", c.ChildResourceName("frontend-lb"), c.GetNamespace(), *c.Spec.Services.Frontend.Port)
+}
+
+// GetInternalClientAddress returns the address of the internal frontend service.
+// Calls made through this address are not subject to the cluster's authorization.
+func (c *TemporalCluster) GetInternalClientAddress() string {
+	return fmt.Sprintf("%s.%s:%d", c.ChildResourceName("internal-frontend-headless"), c.GetNamespace(), *c.Spec.Services.InternalFrontend.Port)
+}
+
+// UseInternalFrontendForSystemCalls returns true if system callers (the worker service and the operator)
+// should use the internal frontend to bypass the cluster's authorization.
+func (c *TemporalCluster) UseInternalFrontendForSystemCalls() bool {
+	return c.Spec.Authorization.IsEnabled() && c.Spec.Services.InternalFrontend.IsEnabled()
+}
+
+// DeployedServices returns the temporal services deployed for the cluster.
+func (c *TemporalCluster) DeployedServices() []primitives.ServiceName {
+	services := []primitives.ServiceName{
+		primitives.FrontendService,
+		primitives.HistoryService,
+		primitives.MatchingService,
+		primitives.WorkerService,
+	}
+	if c.Spec.Services != nil && c.Spec.Services.InternalFrontend.IsEnabled() {
+		services = append(services, primitives.InternalFrontendService)
+	}
+	return services
+}
+
+// IsOpenShift returns true if the cluster is deployed on openshift.
+func (c *TemporalCluster) IsOpenShift() bool {
+	return c.Spec.Platform == OpenShiftPlatform
+}
+
+// ServerImage returns the temporal server image reference.
+func (c *TemporalCluster) ServerImage() string {
+	return c.pinnedImage(fmt.Sprintf("%s:%s", c.Spec.Image, c.Spec.Version))
+}
+
+// GetTimeZone returns the time zone the cluster schedules are interpreted in.
+func (c *TemporalCluster) GetTimeZone() string {
+	if c.Spec.TimeZone == "" {
+		return "UTC"
+	}
+	return c.Spec.TimeZone
+}
+
+// AdminToolsImage returns the temporal admin tools image reference.
+func (c *TemporalCluster) AdminToolsImage() string {
+	return c.pinnedImage(fmt.Sprintf("%s:%s", c.Spec.AdminTools.Image, c.Spec.Version))
+}
+
+// UIImage returns the temporal ui image reference.
+func (c *TemporalCluster) UIImage() string {
+	return c.pinnedImage(fmt.Sprintf("%s:%s", c.Spec.UI.Image, c.Spec.UI.Version))
+}
+
+// OAuth2ProxyImage returns the temporal ui oauth2-proxy image reference.
+func (c *TemporalCluster) OAuth2ProxyImage() string {
+	return c.pinnedImage(fmt.Sprintf("%s:%s", c.Spec.UI.OAuth2Proxy.Image, c.Spec.UI.OAuth2Proxy.Version))
+}
+
+// GRPCWebImage returns the gRPC-web proxy image reference.
+func (c *TemporalCluster) GRPCWebImage() string {
+	return c.pinnedImage(fmt.Sprintf("%s:%s", c.Spec.GRPCWeb.Image, c.Spec.GRPCWeb.Version))
+}
+
+// GRPCWebEnabled returns true if the gRPC-web proxy is deployed for the cluster.
+func (c *TemporalCluster) GRPCWebEnabled() bool {
+	return c.Spec.GRPCWeb != nil && c.Spec.GRPCWeb.Enabled
+}
+
+// FrontendProxyEnabled returns true if the frontend is exposed through the proxy sidecar.
+func (c *TemporalCluster) FrontendProxyEnabled() bool {
+	return c.Spec.Services != nil && c.Spec.Services.Frontend != nil && c.Spec.Services.Frontend.Proxy.IsEnabled()
+}
+
+// FrontendMirroringEnabled returns true if the frontend proxy mirrors the read-only requests to the blue/green target frontend.
+func (c *TemporalCluster) FrontendMirroringEnabled() bool {
+	return c.FrontendProxyEnabled() && c.Spec.Services.Frontend.Proxy.Mirroring.IsEnabled()
+}
+
+// FrontendProxyImage returns the frontend proxy sidecar image reference.
+func (c *TemporalCluster) FrontendProxyImage() string {
+	proxy := c.Spec.Services.Frontend.Proxy
+	return c.pinnedImage(fmt.Sprintf("%s:%s", proxy.Image, proxy.Version))
+}
+
+// Images returns the tagged references of the images deployed for the cluster.
+func (c *TemporalCluster) Images() []string {
+	images := c.TemporalImages()
+
+	if c.Spec.UI != nil && c.Spec.UI.Enabled && c.Spec.UI.OAuth2Proxy != nil {
+		images = append(images, fmt.Sprintf("%s:%s", c.Spec.UI.OAuth2Proxy.Image, c.Spec.UI.OAuth2Proxy.Version))
+	}
+
+	if c.GRPCWebEnabled() {
+		images = append(images, fmt.Sprintf("%s:%s", c.Spec.GRPCWeb.Image, c.Spec.GRPCWeb.Version))
+	}
+
+	if c.FrontendProxyEnabled() {
+		proxy := c.Spec.Services.Frontend.Proxy
+		images = append(images, fmt.Sprintf("%s:%s", proxy.Image, proxy.Version))
+	}
+
+	return images
+}
+
+// TemporalImages returns the tagged references of the temporal server, admin tools and ui images deployed for the cluster.
+func (c *TemporalCluster) TemporalImages() []string {
+	images := []string{fmt.Sprintf("%s:%s", c.Spec.Image, c.Spec.Version)}
+
+	if c.Spec.AdminTools != nil {
+		images = append(images, fmt.Sprintf("%s:%s", c.Spec.AdminTools.Image, c.Spec.Version))
+	}
+
+	if c.Spec.UI != nil && c.Spec.UI.Enabled {
+		images = append(images, fmt.Sprintf("%s:%s", c.Spec.UI.Image, c.Spec.UI.Version))
+	}
+
+	return images
+}
+
+// pinnedImage returns the digest reference resolved for the provided image,
+// or the image itself if digests resolution is disabled or pending.
+func (c *TemporalCluster) pinnedImage(image string) string {
+	if !c.Spec.ResolveImageDigests {
+		return image
+	}
+	if pinned, ok := c.Status.ImageDigests[image]; ok {
+		return pinned
+	}
+	return image
+}
+
+// GetImagePullPolicy returns the pull policy of the containers deployed for the cluster.
+func (c *TemporalCluster) GetImagePullPolicy() corev1.PullPolicy {
+	if c.Spec.ImagePullPolicy == "" {
+		return corev1.PullIfNotPresent
+	}
+	return c.Spec.ImagePullPolicy
+}
+
+// IsDraining returns true if the cluster frontend is in drain mode.
+func (c *TemporalCluster) IsDraining() bool {
+	return c.Spec.Services != nil && c.Spec.Services.Frontend != nil && c.Spec.Services.Frontend.Drain
+}
+
+// IsBlueGreenUpgradeInProgress returns true if a blue/green upgrade is ongoing.
+func (c *TemporalCluster) IsBlueGreenUpgradeInProgress() bool {
+	return c.Status.BlueGreen != nil
+}
+
+// IsBlueGreenSwitched returns true if the frontend traffic is routed to the blue/green target deployments.
+func (c *TemporalCluster) IsBlueGreenSwitched() bool {
+	return c.IsBlueGreenUpgradeInProgress() && c.Status.BlueGreen.Phase == BlueGreenSwitchedPhase
+}
+
 // IsReady returns true if the TemporalCluster's conditions reports it ready.
 func (c *TemporalCluster) IsReady() bool {
 	for _, condition := range c.Status.Conditions {
@@ -0,0 +1,95 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/common/primitives"
+	"k8s.io/utils/ptr"
+)
+
+func TestServicesSpecServiceReplicas(t *testing.T) {
+	tests := map[string]struct {
+		services *v1beta1.ServicesSpec
+		service  primitives.ServiceName
+		current  *int32
+	}{
+		"frontend": {
+			services: &v1beta1.ServicesSpec{Frontend: &v1beta1.ServiceSpec{Replicas: ptr.To[int32](2)}},
+			service:  primitives.FrontendService,
+			current:  ptr.To[int32](2),
+		},
+		"history not set": {
+			services: &v1beta1.ServicesSpec{},
+			service:  primitives.HistoryService,
+		},
+		"matching": {
+			services: &v1beta1.ServicesSpec{Matching: &v1beta1.ServiceSpec{Replicas: ptr.To[int32](3)}},
+			service:  primitives.MatchingService,
+			current:  ptr.To[int32](3),
+		},
+		"worker": {
+			services: &v1beta1.ServicesSpec{Worker: &v1beta1.ServiceSpec{}},
+			service:  primitives.WorkerService,
+		},
+		"internal frontend": {
+			services: &v1beta1.ServicesSpec{InternalFrontend: &v1beta1.InternalFrontendServiceSpec{
+				Enabled:     true,
+				ServiceSpec: v1beta1.ServiceSpec{Replicas: ptr.To[int32](4)},
+			}},
+			service: primitives.InternalFrontendService,
+			current: ptr.To[int32](4),
+		},
+		"internal frontend not set": {
+			services: &v1beta1.ServicesSpec{},
+			service:  primitives.InternalFrontendService,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			spec, err := test.services.GetServiceSpec(test.service)
+			require.NoError(tt, err)
+			if test.current != nil {
+				require.NotNil(tt, spec)
+				assert.Equal(tt, test.current, spec.Replicas)
+			} else if spec != nil {
+				assert.Nil(tt, spec.Replicas)
+			}
+
+			require.NoError(tt, test.services.SetServiceReplicas(test.service, 7))
+
+			spec, err = test.services.GetServiceSpec(test.service)
+			require.NoError(tt, err)
+			require.NotNil(tt, spec)
+			assert.Equal(tt, ptr.To[int32](7), spec.Replicas)
+		})
+	}
+}
+
+func TestServicesSpecServiceReplicasUnknownService(t *testing.T) {
+	services := &v1beta1.ServicesSpec{}
+
+	_, err := services.GetServiceSpec(primitives.AllServices)
+	assert.EqualError(t, err, "unknown service all")
+	assert.EqualError(t, services.SetServiceReplicas(primitives.AllServices, 1), "unknown service all")
+}
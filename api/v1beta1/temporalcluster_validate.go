@@ -3,6 +3,8 @@ package v1beta1
 import (
 	"time"
 
+	"go.temporal.io/server/common/primitives"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -19,7 +21,45 @@ func (m *MTLSSpec) Validate() (admission.Warnings, field.ErrorList) {
 		if m.RenewBefore.Duration < 5*time.Minute {
 			errs = append(errs, field.Invalid(field.NewPath("spec.mTLS.renewBefore"), m.RenewBefore, "must be at least 5 minutes"))
 		}
+
+		if m.CertificatesDuration != nil {
+			durations := []struct {
+				name     string
+				duration *metav1.Duration
+			}{
+				{"rootCACertificate", m.CertificatesDuration.RootCACertificate},
+				{"intermediateCAsCertificates", m.CertificatesDuration.IntermediateCAsCertificates},
+				{"clientCertificates", m.CertificatesDuration.ClientCertificates},
+				{"frontendCertificate", m.CertificatesDuration.FrontendCertificate},
+				{"internodeCertificate", m.CertificatesDuration.InternodeCertificate},
+			}
+			for _, d := range durations {
+				if d.duration != nil && m.RenewBefore.Duration >= d.duration.Duration {
+					errs = append(errs, field.Invalid(field.NewPath("spec.mTLS.renewBefore"), m.RenewBefore, "must be lower than spec.mTLS.certificatesDuration."+d.name))
+				}
+			}
+		}
 	}
 
 	return warns, errs
 }
+
+// Validate ensures every deployed service resolves to an external config ConfigMap.
+func (s *ExternalConfigSpec) Validate(services []primitives.ServiceName) field.ErrorList {
+	var errs field.ErrorList
+
+	if !s.IsEnabled() {
+		return nil
+	}
+
+	for _, service := range services {
+		if s.ConfigMapName(service) == "" {
+			errs = append(errs, field.Required(
+				field.NewPath("spec", "externalConfig", "configMapRef"),
+				"service "+string(service)+" has no external config ConfigMap, set spec.externalConfig.configMapRef or a dedicated ConfigMap for the service",
+			))
+		}
+	}
+
+	return errs
+}
@@ -19,25 +19,91 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// ClusterClientAddressKey is the client secret key holding the cluster frontend address.
+	ClusterClientAddressKey = "address"
+	// ClusterClientServerNameKey is the client secret key holding the server name to use for TLS.
+	ClusterClientServerNameKey = "server-name"
+	// ClusterClientLoadBalancingTargetKey is the client secret key holding the gRPC target
+	// to use for client-side load balancing, when enabled on the cluster.
+	ClusterClientLoadBalancingTargetKey = "lb-target"
+	// ClusterClientServiceConfigKey is the client secret key holding the gRPC service config
+	// enabling round_robin load balancing, when enabled on the cluster.
+	ClusterClientServiceConfigKey = "grpc-service-config"
+)
+
+// ClusterClientAccess defines from where a client reaches the cluster frontend.
+// +kubebuilder:validation:Enum=internal;external
+type ClusterClientAccess string
+
+const (
+	// ClusterClientAccessInternal is used by clients running in the kubernetes cluster,
+	// reaching the frontend using its in-cluster FQDN.
+	ClusterClientAccessInternal ClusterClientAccess = "internal"
+	// ClusterClientAccessExternal is used by clients running outside of the kubernetes cluster,
+	// reaching the frontend using the hostname published in the cluster spec.expose.hostnames.frontend.
+	ClusterClientAccessExternal ClusterClientAccess = "external"
+)
+
 // TemporalClusterClientSpec defines the desired state of ClusterClient.
 type TemporalClusterClientSpec struct {
 	// Reference to the temporal cluster the client will get access to.
 	ClusterRef ObjectReference `json:"clusterRef"`
+	// Access defines from where the client reaches the cluster, selecting the frontend address
+	// reported in the client status and secret.
+	// Use "internal" for clients running in the kubernetes cluster and "external" for the others.
+	// +kubebuilder:default=internal
+	// +optional
+	Access ClusterClientAccess `json:"access,omitempty"`
+	// ServiceAccountName is the name of a ServiceAccount, in the client namespace, consuming the client secret.
+	// When set, the operator creates a Role and a RoleBinding granting this ServiceAccount read access to the client secret only.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Permissions are the roles granted to the client certificate per Temporal namespace.
+	// They are only applied if the cluster allows client permissions using spec.authorization.certificateClaimMapper.allowClientPermissions.
+	// +optional
+	Permissions []CertificateClaimMapperNamespaceRoles `json:"permissions,omitempty"`
+}
+
+// ClusterClientServerStatus reports the temporal server reached using the client credentials.
+type ClusterClientServerStatus struct {
+	// Version is the temporal server version.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Capabilities lists the capabilities advertised by the temporal server.
+	// +optional
+	Capabilities []string `json:"capabilities,omitempty"`
 }
 
 // TemporalClusterClientStatus defines the observed state of ClusterClient.
 type TemporalClusterClientStatus struct {
 	// ServerName is the hostname returned by the certificate.
 	ServerName string `json:"serverName"`
+	// Address is the frontend address the client should connect to, resolved according to spec.access.
+	// +optional
+	Address string `json:"address,omitempty"`
 	// Reference to the Kubernetes Secret containing the certificate for the client.
 	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+	// Server reports the temporal server reached during the last successful validation of the client credentials.
+	// +optional
+	Server *ClusterClientServerStatus `json:"server,omitempty"`
+	// LastValidationTime is the time the client credentials were last validated.
+	// +optional
+	LastValidationTime *metav1.Time `json:"lastValidationTime,omitempty"`
+	// Conditions represent the latest available observations of the client state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Address",type="string",JSONPath=".status.address"
+//+kubebuilder:printcolumn:name="Validated",type="string",JSONPath=".status.conditions[?(@.type == 'Validated')].status"
+//+kubebuilder:printcolumn:name="Server Version",type="string",JSONPath=".status.server.version"
 
 // A TemporalClusterClient creates a new mTLS client in the targeted temporal cluster.
 type TemporalClusterClient struct {
@@ -60,3 +126,16 @@ type TemporalClusterClientList struct {
 func init() {
 	SchemeBuilder.Register(&TemporalClusterClient{}, &TemporalClusterClientList{})
 }
+
+// IsValidated returns true if the client credentials were successfully validated against the cluster.
+func (c *TemporalClusterClient) IsValidated() bool {
+	return apimeta.IsStatusConditionTrue(c.Status.Conditions, ClusterClientValidatedCondition)
+}
+
+// GetAccess returns from where the client reaches the cluster, defaulting to internal.
+func (c *TemporalClusterClient) GetAccess() ClusterClientAccess {
+	if c.Spec.Access == "" {
+		return ClusterClientAccessInternal
+	}
+	return c.Spec.Access
+}
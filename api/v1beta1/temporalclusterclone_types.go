@@ -0,0 +1,160 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterCloneUpdatePolicy defines when the cloned cluster spec is written.
+// +kubebuilder:validation:Enum=Once;Continuous
+type ClusterCloneUpdatePolicy string
+
+const (
+	// OnceClusterCloneUpdatePolicy creates the cloned cluster, then leaves its spec untouched.
+	OnceClusterCloneUpdatePolicy ClusterCloneUpdatePolicy = "Once"
+	// ContinuousClusterCloneUpdatePolicy keeps the cloned cluster spec in sync with the source cluster.
+	ContinuousClusterCloneUpdatePolicy ClusterCloneUpdatePolicy = "Continuous"
+)
+
+// TemporalClusterCloneSpec defines the cluster created from the spec of another cluster.
+type TemporalClusterCloneSpec struct {
+	// ClusterRef references the cluster whose spec is cloned.
+	ClusterRef ObjectReference `json:"clusterRef"`
+	// ClusterName is the name of the created cluster, in the namespace of the clone.
+	// Defaults to the clone name.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+	// Replicas sets the replicas of every temporal service of the created cluster,
+	// usually to run a smaller copy of the source cluster.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Overrides is applied on top of the source cluster spec, usually to point the created cluster
+	// to its own datastores. Fields set in overrides replace the source values, other values are kept.
+	// The $(CLUSTER_NAME) and $(CLUSTER_NAMESPACE) variables are replaced by the created cluster name and namespace.
+	// The result is validated against the TemporalCluster schema when the cluster is written.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Overrides *TemporalClusterSpec `json:"overrides,omitempty"`
+	// UpdatePolicy defines when the created cluster spec is written: Once, when the cluster is created,
+	// or Continuous, on every source cluster change. Defaults to Once.
+	// +kubebuilder:default=Once
+	// +optional
+	UpdatePolicy ClusterCloneUpdatePolicy `json:"updatePolicy,omitempty"`
+}
+
+// GetClusterName returns the name of the cluster created by the clone.
+func (c *TemporalClusterClone) GetClusterName() string {
+	if c.Spec.ClusterName != "" {
+		return c.Spec.ClusterName
+	}
+	return c.GetName()
+}
+
+// ClusterSpec returns the spec of the cluster created from the provided source cluster.
+// Fields bound to the source cluster identity, like its exposed hostnames, its replication settings
+// and its backup destination, are not cloned. They can be set using the clone overrides.
+func (c *TemporalClusterClone) ClusterSpec(source *TemporalCluster) (*TemporalClusterSpec, error) {
+	spec := source.Spec.DeepCopy()
+	spec.Expose = nil
+	spec.Replication = nil
+	spec.Backup = nil
+	if spec.Catalog != nil {
+		spec.Catalog.ComponentID = ""
+	}
+
+	if c.Spec.Replicas != nil {
+		if spec.Services == nil {
+			spec.Services = &ServicesSpec{}
+		}
+		services := []**ServiceSpec{&spec.Services.Frontend, &spec.Services.History, &spec.Services.Matching, &spec.Services.Worker}
+		for _, service := range services {
+			if *service == nil {
+				*service = &ServiceSpec{}
+			}
+			(*service).Replicas = c.Spec.Replicas
+		}
+		if spec.Services.InternalFrontend != nil {
+			spec.Services.InternalFrontend.Replicas = c.Spec.Replicas
+		}
+	}
+
+	if c.Spec.Overrides == nil {
+		return spec, nil
+	}
+
+	target := &TemporalCluster{}
+	target.SetName(c.GetClusterName())
+	target.SetNamespace(c.GetNamespace())
+
+	overrides, err := expandTemplateVariables(c.Spec.Overrides, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeSpec(spec, overrides, TemporalClusterSpec{})
+}
+
+// TemporalClusterCloneStatus defines the observed state of TemporalClusterClone.
+type TemporalClusterCloneStatus struct {
+	// ClusterName is the name of the created cluster.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+	// SourceGeneration is the generation of the source cluster the created cluster spec was last written from.
+	// +optional
+	SourceGeneration int64 `json:"sourceGeneration,omitempty"`
+	// LastSyncTime is the time the created cluster spec was last written.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Conditions represent the latest available observations of the clone state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.clusterRef.name"
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".status.clusterName"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type == 'Ready')].status"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// A TemporalClusterClone creates a TemporalCluster from the spec of another cluster, with selective overrides.
+// It's used to stamp staging environments mirroring the production clusters settings.
+type TemporalClusterClone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TemporalClusterCloneSpec   `json:"spec,omitempty"`
+	Status TemporalClusterCloneStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TemporalClusterCloneList contains a list of TemporalClusterClone.
+type TemporalClusterCloneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalClusterClone `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalClusterClone{}, &TemporalClusterCloneList{})
+}
@@ -0,0 +1,149 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newCloneSource() *v1beta1.TemporalCluster {
+	return &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "temporal"},
+		Spec: v1beta1.TemporalClusterSpec{
+			NumHistoryShards: 512,
+			Persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore: &v1beta1.DatastoreSpec{
+					Name: "default",
+					SQL: &v1beta1.SQLSpec{
+						User:         "temporal",
+						PluginName:   "postgres",
+						DatabaseName: "temporal",
+						ConnectAddr:  "prod-postgres:5432",
+					},
+				},
+			},
+			Services: &v1beta1.ServicesSpec{
+				Frontend: &v1beta1.ServiceSpec{Replicas: ptr.To[int32](5)},
+				History:  &v1beta1.ServiceSpec{Replicas: ptr.To[int32](10)},
+				InternalFrontend: &v1beta1.InternalFrontendServiceSpec{
+					Enabled:     true,
+					ServiceSpec: v1beta1.ServiceSpec{Replicas: ptr.To[int32](3)},
+				},
+			},
+			Expose: &v1beta1.ExposeSpec{
+				Hostnames: &v1beta1.ExposeHostnamesSpec{Frontend: []string{"temporal.example.com"}},
+			},
+			Replication: &v1beta1.ReplicationSpec{Enabled: true, InitialFailoverVersion: 1},
+			Backup:      &v1beta1.BackupSpec{Enabled: true, URL: "s3://backups/prod"},
+			Catalog:     &v1beta1.CatalogSpec{Enabled: true, ComponentID: "temporal-prod"},
+		},
+	}
+}
+
+func TestTemporalClusterCloneClusterSpec(t *testing.T) {
+	source := newCloneSource()
+	clone := &v1beta1.TemporalClusterClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "temporal-staging"},
+	}
+
+	spec, err := clone.ClusterSpec(source)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(512), spec.NumHistoryShards)
+	assert.Equal(t, "prod-postgres:5432", spec.Persistence.DefaultStore.SQL.ConnectAddr)
+	assert.Equal(t, int32(10), *spec.Services.History.Replicas)
+	// The fields bound to the source cluster identity are not cloned.
+	assert.Nil(t, spec.Expose)
+	assert.Nil(t, spec.Replication)
+	assert.Nil(t, spec.Backup)
+	assert.True(t, spec.Catalog.Enabled)
+	assert.Empty(t, spec.Catalog.ComponentID)
+	// The source cluster is left untouched.
+	assert.NotNil(t, source.Spec.Expose)
+	assert.Equal(t, "temporal-prod", source.Spec.Catalog.ComponentID)
+}
+
+func TestTemporalClusterCloneClusterSpecReplicas(t *testing.T) {
+	source := newCloneSource()
+	clone := &v1beta1.TemporalClusterClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "temporal-staging"},
+		Spec: v1beta1.TemporalClusterCloneSpec{
+			Replicas: ptr.To[int32](1),
+		},
+	}
+
+	spec, err := clone.ClusterSpec(source)
+	require.NoError(t, err)
+
+	// Services not set in the source spec get the clone replicas too.
+	for name, service := range map[string]*v1beta1.ServiceSpec{
+		"frontend":         spec.Services.Frontend,
+		"history":          spec.Services.History,
+		"matching":         spec.Services.Matching,
+		"worker":           spec.Services.Worker,
+		"internalFrontend": &spec.Services.InternalFrontend.ServiceSpec,
+	} {
+		if assert.NotNil(t, service, name) {
+			assert.Equal(t, int32(1), *service.Replicas, name)
+		}
+	}
+	assert.Equal(t, int32(10), *source.Spec.Services.History.Replicas)
+}
+
+func TestTemporalClusterCloneClusterSpecOverrides(t *testing.T) {
+	source := newCloneSource()
+	clone := &v1beta1.TemporalClusterClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "clone", Namespace: "temporal-staging"},
+		Spec: v1beta1.TemporalClusterCloneSpec{
+			ClusterName: "staging",
+			Overrides: &v1beta1.TemporalClusterSpec{
+				Persistence: v1beta1.TemporalPersistenceSpec{
+					DefaultStore: &v1beta1.DatastoreSpec{
+						SQL: &v1beta1.SQLSpec{
+							DatabaseName: "$(CLUSTER_NAME)",
+							ConnectAddr:  "postgres.$(CLUSTER_NAMESPACE):5432",
+						},
+					},
+				},
+				Expose: &v1beta1.ExposeSpec{
+					Hostnames: &v1beta1.ExposeHostnamesSpec{Frontend: []string{"temporal.staging.example.com"}},
+				},
+			},
+		},
+	}
+
+	spec, err := clone.ClusterSpec(source)
+	require.NoError(t, err)
+
+	// Overridden fields are replaced, using the created cluster name and namespace.
+	assert.Equal(t, "staging", spec.Persistence.DefaultStore.SQL.DatabaseName)
+	assert.Equal(t, "postgres.temporal-staging:5432", spec.Persistence.DefaultStore.SQL.ConnectAddr)
+	// Other fields are kept from the source cluster.
+	assert.Equal(t, "temporal", spec.Persistence.DefaultStore.SQL.User)
+	assert.Equal(t, "default", spec.Persistence.DefaultStore.Name)
+	assert.Equal(t, int32(5), *spec.Services.Frontend.Replicas)
+	// Fields not cloned can be set using the overrides.
+	assert.Equal(t, []string{"temporal.staging.example.com"}, spec.Expose.Hostnames.Frontend)
+	assert.Equal(t, "temporal", source.Spec.Persistence.DefaultStore.SQL.DatabaseName)
+}
@@ -0,0 +1,135 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// TemporalClusterOverrideSpec defines the patch applied to the selected clusters.
+type TemporalClusterOverrideSpec struct {
+	// ClusterSelector selects the clusters of the override namespace the patch is applied to,
+	// usually using an environment label.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+	// Priority orders the overrides applied to the same cluster: overrides with a higher priority are applied last,
+	// so their values win. Overrides with the same priority are applied by name.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+	// Patch is strategically merged on top of the selected clusters spec. Fields set in the patch replace
+	// the cluster values, other values are kept.
+	// The $(CLUSTER_NAME) and $(CLUSTER_NAMESPACE) variables are replaced by the cluster name and namespace.
+	// The patched spec is used by the operator to render the cluster resources, the stored cluster spec is left untouched.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Patch TemporalClusterSpec `json:"patch"`
+}
+
+// Matches returns true if the override applies to the provided cluster.
+func (o *TemporalClusterOverride) Matches(cluster *TemporalCluster) (bool, error) {
+	if o.GetNamespace() != cluster.GetNamespace() {
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&o.Spec.ClusterSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid cluster selector: %w", err)
+	}
+
+	return selector.Matches(labels.Set(cluster.GetLabels())), nil
+}
+
+// Apply merges the override patch on top of the provided cluster spec.
+func (o *TemporalClusterOverride) Apply(cluster *TemporalCluster) error {
+	patch, err := expandTemplateVariables(&o.Spec.Patch, cluster)
+	if err != nil {
+		return fmt.Errorf("can't expand override %s variables: %w", o.GetName(), err)
+	}
+
+	spec, err := mergeSpec(&cluster.Spec, patch, TemporalClusterSpec{})
+	if err != nil {
+		return fmt.Errorf("can't apply override %s: %w", o.GetName(), err)
+	}
+
+	cluster.Spec = *spec
+
+	return nil
+}
+
+// ApplyClusterOverrides applies the overrides matching the provided cluster, in priority order,
+// and returns the names of the applied overrides.
+func ApplyClusterOverrides(cluster *TemporalCluster, overrides []TemporalClusterOverride) ([]string, error) {
+	matching := []*TemporalClusterOverride{}
+	for i := range overrides {
+		ok, err := overrides[i].Matches(cluster)
+		if err != nil {
+			return nil, fmt.Errorf("override %s: %w", overrides[i].GetName(), err)
+		}
+		if ok {
+			matching = append(matching, &overrides[i])
+		}
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].Spec.Priority != matching[j].Spec.Priority {
+			return matching[i].Spec.Priority < matching[j].Spec.Priority
+		}
+		return matching[i].GetName() < matching[j].GetName()
+	})
+
+	applied := make([]string, 0, len(matching))
+	for _, override := range matching {
+		if err := override.Apply(cluster); err != nil {
+			return nil, err
+		}
+		applied = append(applied, override.GetName())
+	}
+
+	return applied, nil
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Priority",type="integer",JSONPath=".spec.priority"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:webhook:path=/validate-temporal-io-v1beta1-temporalclusteroverride,mutating=false,failurePolicy=fail,sideEffects=None,groups=temporal.io,resources=temporalclusteroverrides,verbs=create;update,versions=v1beta1,name=vtemporalclusteroverride.kb.io,admissionReviewVersions=v1
+
+// A TemporalClusterOverride patches the spec of the TemporalClusters it selects, letting a single
+// Git-managed base cluster spec be specialized per environment without duplicating it.
+type TemporalClusterOverride struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TemporalClusterOverrideSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TemporalClusterOverrideList contains a list of TemporalClusterOverride.
+type TemporalClusterOverrideList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalClusterOverride `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalClusterOverride{}, &TemporalClusterOverrideList{})
+}
@@ -0,0 +1,175 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newOverride(name string, priority int32, selector map[string]string, patch v1beta1.TemporalClusterSpec) v1beta1.TemporalClusterOverride {
+	return v1beta1.TemporalClusterOverride{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "temporal"},
+		Spec: v1beta1.TemporalClusterOverrideSpec{
+			ClusterSelector: metav1.LabelSelector{MatchLabels: selector},
+			Priority:        priority,
+			Patch:           patch,
+		},
+	}
+}
+
+func newOverriddenCluster() *v1beta1.TemporalCluster {
+	return &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prod",
+			Namespace: "temporal",
+			Labels:    map[string]string{"environment": "production"},
+		},
+		Spec: v1beta1.TemporalClusterSpec{
+			NumHistoryShards: 512,
+			Persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore: &v1beta1.DatastoreSpec{
+					Name: "default",
+					SQL: &v1beta1.SQLSpec{
+						User:         "temporal",
+						PluginName:   "postgres",
+						DatabaseName: "temporal",
+						ConnectAddr:  "postgres:5432",
+					},
+				},
+			},
+			Services: &v1beta1.ServicesSpec{
+				Frontend: &v1beta1.ServiceSpec{Replicas: ptr.To[int32](1)},
+				History:  &v1beta1.ServiceSpec{Replicas: ptr.To[int32](1)},
+			},
+		},
+	}
+}
+
+func TestApplyClusterOverrides(t *testing.T) {
+	production := map[string]string{"environment": "production"}
+	historyReplicas := func(replicas int32) v1beta1.TemporalClusterSpec {
+		return v1beta1.TemporalClusterSpec{
+			Services: &v1beta1.ServicesSpec{History: &v1beta1.ServiceSpec{Replicas: ptr.To(replicas)}},
+		}
+	}
+
+	tests := map[string]struct {
+		overrides       []v1beta1.TemporalClusterOverride
+		expectedApplied []string
+		expectedErr     string
+		check           func(*testing.T, *v1beta1.TemporalCluster)
+	}{
+		"no overrides": {
+			expectedApplied: []string{},
+			check: func(t *testing.T, cluster *v1beta1.TemporalCluster) {
+				assert.Equal(t, newOverriddenCluster().Spec, cluster.Spec)
+			},
+		},
+		"keeps fields not set in the patch": {
+			overrides:       []v1beta1.TemporalClusterOverride{newOverride("production", 0, production, historyReplicas(5))},
+			expectedApplied: []string{"production"},
+			check: func(t *testing.T, cluster *v1beta1.TemporalCluster) {
+				assert.Equal(t, int32(5), *cluster.Spec.Services.History.Replicas)
+				assert.Equal(t, int32(1), *cluster.Spec.Services.Frontend.Replicas)
+				assert.Equal(t, int32(512), cluster.Spec.NumHistoryShards)
+				assert.Equal(t, "postgres:5432", cluster.Spec.Persistence.DefaultStore.SQL.ConnectAddr)
+			},
+		},
+		"applies by priority then name": {
+			overrides: []v1beta1.TemporalClusterOverride{
+				newOverride("c-high", 10, production, historyReplicas(10)),
+				newOverride("b-low", 0, production, historyReplicas(2)),
+				newOverride("a-low", 0, production, historyReplicas(3)),
+			},
+			expectedApplied: []string{"a-low", "b-low", "c-high"},
+			check: func(t *testing.T, cluster *v1beta1.TemporalCluster) {
+				assert.Equal(t, int32(10), *cluster.Spec.Services.History.Replicas)
+			},
+		},
+		"skips overrides not matching the labels": {
+			overrides:       []v1beta1.TemporalClusterOverride{newOverride("staging", 0, map[string]string{"environment": "staging"}, historyReplicas(5))},
+			expectedApplied: []string{},
+			check: func(t *testing.T, cluster *v1beta1.TemporalCluster) {
+				assert.Equal(t, int32(1), *cluster.Spec.Services.History.Replicas)
+			},
+		},
+		"skips overrides from other namespaces": {
+			overrides: func() []v1beta1.TemporalClusterOverride {
+				override := newOverride("production", 0, production, historyReplicas(5))
+				override.Namespace = "other"
+				return []v1beta1.TemporalClusterOverride{override}
+			}(),
+			expectedApplied: []string{},
+			check: func(t *testing.T, cluster *v1beta1.TemporalCluster) {
+				assert.Equal(t, int32(1), *cluster.Spec.Services.History.Replicas)
+			},
+		},
+		"expands cluster variables": {
+			overrides: []v1beta1.TemporalClusterOverride{
+				newOverride("production", 0, production, v1beta1.TemporalClusterSpec{
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{ConnectAddr: "$(CLUSTER_NAME)-postgres.$(CLUSTER_NAMESPACE):5432"},
+						},
+					},
+				}),
+			},
+			expectedApplied: []string{"production"},
+			check: func(t *testing.T, cluster *v1beta1.TemporalCluster) {
+				assert.Equal(t, "prod-postgres.temporal:5432", cluster.Spec.Persistence.DefaultStore.SQL.ConnectAddr)
+				assert.Equal(t, "temporal", cluster.Spec.Persistence.DefaultStore.SQL.User)
+			},
+		},
+		"invalid selector": {
+			overrides: []v1beta1.TemporalClusterOverride{
+				func() v1beta1.TemporalClusterOverride {
+					override := newOverride("broken", 0, nil, historyReplicas(5))
+					override.Spec.ClusterSelector.MatchExpressions = []metav1.LabelSelectorRequirement{
+						{Key: "environment", Operator: "Unknown"},
+					}
+					return override
+				}(),
+			},
+			expectedErr: "override broken: invalid cluster selector",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := newOverriddenCluster()
+
+			applied, err := v1beta1.ApplyClusterOverrides(cluster, test.overrides)
+			if test.expectedErr != "" {
+				assert.ErrorContains(tt, err, test.expectedErr)
+				return
+			}
+			require.NoError(tt, err)
+
+			assert.Equal(tt, test.expectedApplied, applied)
+			if test.check != nil {
+				test.check(tt, cluster)
+			}
+		})
+	}
+}
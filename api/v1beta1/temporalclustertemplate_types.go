@@ -0,0 +1,238 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/utils/strings/slices"
+)
+
+// TemporalClusterTemplateSize defines a size clusters instantiating the template can choose.
+type TemporalClusterTemplateSize struct {
+	// Name is the name of the size.
+	Name string `json:"name"`
+	// Services overrides the template services spec, usually to set replicas and resources.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Services *ServicesSpec `json:"services,omitempty"`
+}
+
+// TemporalClusterTemplateSpec defines the desired state of TemporalClusterTemplate.
+type TemporalClusterTemplateSpec struct {
+	// Template is the cluster spec enforced on clusters instantiating the template.
+	// Fields set in the template override the values set in the cluster spec.
+	// The $(CLUSTER_NAME) and $(CLUSTER_NAMESPACE) variables are replaced by the cluster name and namespace,
+	// for instance to give each cluster its own database.
+	// The template is validated against the TemporalCluster schema once applied to a cluster.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Template TemporalClusterSpec `json:"template"`
+	// Sizes are the sizes clusters instantiating the template can choose from.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Sizes []TemporalClusterTemplateSize `json:"sizes,omitempty"`
+	// DefaultSize is the size used by clusters not choosing one.
+	// +optional
+	DefaultSize string `json:"defaultSize,omitempty"`
+	// AllowedNamespaces restricts the namespaces allowed to instantiate the template.
+	// All namespaces are allowed if empty.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+// GetSize returns the size matching the provided name, or the default size if name is empty.
+// It returns nil if no size is found.
+func (s *TemporalClusterTemplateSpec) GetSize(name string) *TemporalClusterTemplateSize {
+	if name == "" {
+		name = s.DefaultSize
+	}
+	for i := range s.Sizes {
+		if s.Sizes[i].Name == name {
+			return &s.Sizes[i]
+		}
+	}
+	return nil
+}
+
+// IsNamespaceAllowed returns true if clusters in the provided namespace can instantiate the template.
+func (s *TemporalClusterTemplateSpec) IsNamespaceAllowed(namespace string) bool {
+	return len(s.AllowedNamespaces) == 0 || slices.Contains(s.AllowedNamespaces, namespace)
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.template.version"
+//+kubebuilder:printcolumn:name="Default Size",type="string",JSONPath=".spec.defaultSize"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// A TemporalClusterTemplate defines the standards platform admins enforce on the TemporalClusters instantiating it.
+type TemporalClusterTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TemporalClusterTemplateSpec `json:"spec,omitempty"`
+}
+
+// Apply applies the template, and the size the cluster references, to the provided cluster spec.
+// Fields set in the template override the cluster spec values, other cluster spec values are kept.
+func (t *TemporalClusterTemplate) Apply(cluster *TemporalCluster) error {
+	ref := cluster.Spec.TemplateRef
+	if ref == nil {
+		return nil
+	}
+
+	patch := t.Spec.Template.DeepCopy()
+
+	if ref.Size != "" || t.Spec.DefaultSize != "" {
+		size := t.Spec.GetSize(ref.Size)
+		if size == nil {
+			return fmt.Errorf("size %q not found in template %s", ref.Size, t.GetName())
+		}
+		if size.Services != nil {
+			services, err := mergeSpec(patch.Services, size.Services, ServicesSpec{})
+			if err != nil {
+				return fmt.Errorf("can't apply size %s: %w", size.Name, err)
+			}
+			patch.Services = services
+		}
+	}
+
+	patch, err := expandTemplateVariables(patch, cluster)
+	if err != nil {
+		return fmt.Errorf("can't expand template %s variables: %w", t.GetName(), err)
+	}
+
+	spec, err := mergeSpec(&cluster.Spec, patch, TemporalClusterSpec{})
+	if err != nil {
+		return fmt.Errorf("can't apply template %s: %w", t.GetName(), err)
+	}
+
+	cluster.Spec = *spec
+	// The template can't reference another template.
+	cluster.Spec.TemplateRef = ref
+
+	return nil
+}
+
+// expandTemplateVariables replaces the $(CLUSTER_NAME) and $(CLUSTER_NAMESPACE) variables
+// in the provided spec by the cluster name and namespace.
+func expandTemplateVariables(spec *TemporalClusterSpec, cluster *TemporalCluster) (*TemporalClusterSpec, error) {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	replacer := strings.NewReplacer(
+		"$(CLUSTER_NAME)", cluster.GetName(),
+		"$(CLUSTER_NAMESPACE)", cluster.GetNamespace(),
+	)
+
+	result := &TemporalClusterSpec{}
+	if err := json.Unmarshal([]byte(replacer.Replace(string(raw))), result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// mergeSpec returns the result of the strategic merge of override on top of original.
+// Empty values of override are ignored, so that fields not set in override are kept.
+func mergeSpec[T any](original, override *T, dataStruct T) (*T, error) {
+	result := new(T)
+	if override == nil {
+		override = result
+	}
+	if original == nil {
+		original = result
+	}
+
+	originalJSON, err := json.Marshal(original)
+	if err != nil {
+		return nil, err
+	}
+
+	overrideFields := map[string]any{}
+	overrideJSON, err := json.Marshal(override)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(overrideJSON, &overrideFields); err != nil {
+		return nil, err
+	}
+
+	overrideJSON, err = json.Marshal(pruneEmptyFields(overrideFields))
+	if err != nil {
+		return nil, err
+	}
+
+	patchedJSON, err := strategicpatch.StrategicMergePatch(originalJSON, overrideJSON, dataStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(patchedJSON, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// pruneEmptyFields recursively removes null, empty strings, zero numbers and empty objects from the provided fields.
+func pruneEmptyFields(fields map[string]any) map[string]any {
+	for key, value := range fields {
+		switch v := value.(type) {
+		case nil:
+			delete(fields, key)
+		case string:
+			if v == "" {
+				delete(fields, key)
+			}
+		case float64:
+			if v == 0 {
+				delete(fields, key)
+			}
+		case map[string]any:
+			if len(pruneEmptyFields(v)) == 0 {
+				delete(fields, key)
+			}
+		}
+	}
+	return fields
+}
+
+//+kubebuilder:object:root=true
+
+// TemporalClusterTemplateList contains a list of TemporalClusterTemplate.
+type TemporalClusterTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalClusterTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalClusterTemplate{}, &TemporalClusterTemplateList{})
+}
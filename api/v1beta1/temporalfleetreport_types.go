@@ -0,0 +1,129 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TemporalFleetReportSpec defines the clusters summarized by a TemporalFleetReport.
+type TemporalFleetReportSpec struct {
+	// ClusterSelector selects the clusters summarized in the report.
+	// All clusters are summarized if not set.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// Namespaces restricts the namespaces the summarized clusters are selected in.
+	// All namespaces are selected if empty.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// CertificateExpiryThreshold is the remaining validity below which a cluster certificate is reported as expiring.
+	// Defaults to 30 days.
+	// +optional
+	CertificateExpiryThreshold *metav1.Duration `json:"certificateExpiryThreshold,omitempty"`
+}
+
+// GetCertificateExpiryThreshold returns the remaining validity below which a certificate is reported as expiring.
+func (s *TemporalFleetReportSpec) GetCertificateExpiryThreshold() metav1.Duration {
+	if s.CertificateExpiryThreshold == nil {
+		return metav1.Duration{Duration: 30 * 24 * time.Hour}
+	}
+	return *s.CertificateExpiryThreshold
+}
+
+// FleetVersionCount is the number of clusters running a temporal version.
+type FleetVersionCount struct {
+	// Version is the temporal version.
+	Version string `json:"version"`
+	// Clusters is the number of clusters running the version.
+	Clusters int32 `json:"clusters"`
+}
+
+// FleetClusterReference references a summarized cluster.
+type FleetClusterReference struct {
+	// Namespace is the cluster namespace.
+	Namespace string `json:"namespace"`
+	// Name is the cluster name.
+	Name string `json:"name"`
+	// Reason is the reason the cluster is reported.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// FleetExpiringCertificate is a cluster certificate expiring soon.
+type FleetExpiringCertificate struct {
+	// Namespace is the certificate and cluster namespace.
+	Namespace string `json:"namespace"`
+	// Cluster is the name of the cluster owning the certificate.
+	Cluster string `json:"cluster"`
+	// Name is the certificate name.
+	Name string `json:"name"`
+	// NotAfter is the certificate expiration time.
+	NotAfter metav1.Time `json:"notAfter"`
+}
+
+// TemporalFleetReportStatus holds the summary of the selected clusters.
+type TemporalFleetReportStatus struct {
+	// Clusters is the number of summarized clusters.
+	Clusters int32 `json:"clusters"`
+	// ReadyClusters is the number of summarized clusters being ready.
+	ReadyClusters int32 `json:"readyClusters"`
+	// Versions is the distribution of the temporal versions run by the clusters.
+	// +optional
+	Versions []FleetVersionCount `json:"versions,omitempty"`
+	// UnreadyClusters lists the clusters not being ready.
+	// +optional
+	UnreadyClusters []FleetClusterReference `json:"unreadyClusters,omitempty"`
+	// ExpiringCertificates lists the clusters certificates expiring within the spec.certificateExpiryThreshold.
+	// +optional
+	ExpiringCertificates []FleetExpiringCertificate `json:"expiringCertificates,omitempty"`
+	// LastUpdateTime is the time the report was last updated.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Clusters",type="integer",JSONPath=".status.clusters"
+//+kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyClusters"
+//+kubebuilder:printcolumn:name="Updated",type="date",JSONPath=".status.lastUpdateTime"
+
+// A TemporalFleetReport summarizes the state of the TemporalClusters managed by the operator.
+// The operator maintains the report status, so fleet dashboards and tooling can read it instead of listing every cluster.
+type TemporalFleetReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TemporalFleetReportSpec   `json:"spec,omitempty"`
+	Status TemporalFleetReportStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TemporalFleetReportList contains a list of TemporalFleetReport.
+type TemporalFleetReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalFleetReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalFleetReport{}, &TemporalFleetReportList{})
+}
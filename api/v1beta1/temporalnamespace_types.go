@@ -18,6 +18,7 @@
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,6 +32,145 @@ type TemporalNamespaceArchivalSpec struct {
 	Visibility *ArchivalSpec `json:"visibility,omitempty"`
 }
 
+// TemporalNamespaceRateLimitsSpec defines the namespace rate limits.
+// Limits are applied through the cluster dynamic config and are hot reloaded by the temporal services.
+type TemporalNamespaceRateLimitsSpec struct {
+	// FrontendRPS is the per frontend instance rate limit for the namespace (frontend.namespaceRPS).
+	// +optional
+	FrontendRPS *int32 `json:"frontendRPS,omitempty"`
+	// FrontendBurst is the per frontend instance burst for the namespace (frontend.namespaceBurst).
+	// +optional
+	FrontendBurst *int32 `json:"frontendBurst,omitempty"`
+	// GlobalFrontendRPS is the cluster-wide rate limit for the namespace, shared
+	// across all frontend instances (frontend.globalNamespaceRPS).
+	// +optional
+	GlobalFrontendRPS *int32 `json:"globalFrontendRPS,omitempty"`
+	// VisibilityRPS is the per frontend instance rate limit for the namespace
+	// visibility requests (frontend.namespaceRPS.visibility).
+	// +optional
+	VisibilityRPS *int32 `json:"visibilityRPS,omitempty"`
+}
+
+// TaskQueueType is the type of a task queue.
+// +kubebuilder:validation:Enum=Workflow;Activity
+type TaskQueueType string
+
+const (
+	// WorkflowTaskQueueType is the type of task queues holding workflow tasks.
+	WorkflowTaskQueueType TaskQueueType = "Workflow"
+	// ActivityTaskQueueType is the type of task queues holding activity tasks.
+	ActivityTaskQueueType TaskQueueType = "Activity"
+)
+
+// TaskQueueConfigSpec defines the configuration of a task queue of the namespace.
+type TaskQueueConfigSpec struct {
+	// Name of the task queue.
+	Name string `json:"name"`
+	// Type of the task queue the configuration applies to.
+	// If not set, the configuration applies to both workflow and activity task queues.
+	// +optional
+	Type TaskQueueType `json:"type,omitempty"`
+	// ReadPartitions is the number of partitions pollers read from (matching.numTaskqueueReadPartitions).
+	// When decreasing the number of partitions, decrease writePartitions first and wait for the
+	// removed partitions to be drained before decreasing readPartitions.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ReadPartitions *int32 `json:"readPartitions,omitempty"`
+	// WritePartitions is the number of partitions tasks are written to (matching.numTaskqueueWritePartitions).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	WritePartitions *int32 `json:"writePartitions,omitempty"`
+	// ForwarderMaxRatePerSecond is the rate limit at which tasks and polls are forwarded
+	// from the child partitions to the root partition (matching.forwarderMaxRatePerSecond).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ForwarderMaxRatePerSecond *int32 `json:"forwarderMaxRatePerSecond,omitempty"`
+	// LongPollExpirationInterval is the duration of pollers long polls (matching.longPollExpirationInterval).
+	// +optional
+	LongPollExpirationInterval *metav1.Duration `json:"longPollExpirationInterval,omitempty"`
+}
+
+// TemporalNamespaceTaskQueuesSpec defines the namespace task queues configuration.
+// The configuration is applied through the cluster dynamic config and is hot reloaded by the temporal services.
+type TemporalNamespaceTaskQueuesSpec struct {
+	// StickyTTL is the duration after which a sticky workflow task queue is considered
+	// unused and workflow tasks are dispatched to the normal task queue (history.stickyTTL).
+	// +optional
+	StickyTTL *metav1.Duration `json:"stickyTTL,omitempty"`
+	// Queues holds per task queue configurations.
+	// +optional
+	Queues []TaskQueueConfigSpec `json:"queues,omitempty"`
+}
+
+// SizeLimitSpec defines a size limit: exceeding the warn size logs a warning, exceeding the error size fails the request.
+type SizeLimitSpec struct {
+	// Warn is the size from which a warning is logged.
+	// +optional
+	Warn *resource.Quantity `json:"warn,omitempty"`
+	// Error is the size from which the request fails.
+	// +optional
+	Error *resource.Quantity `json:"error,omitempty"`
+}
+
+// CountLimitSpec defines a count limit: exceeding the warn count logs a warning, exceeding the error count fails the request.
+// +kubebuilder:validation:XValidation:rule="!has(self.warn) || !has(self.error) || self.warn <= self.error",message="warn must be lower than or equal to error"
+type CountLimitSpec struct {
+	// Warn is the count from which a warning is logged.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Warn *int32 `json:"warn,omitempty"`
+	// Error is the count from which the request fails.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Error *int32 `json:"error,omitempty"`
+}
+
+// TemporalNamespaceLimitsSpec defines the namespace guardrails.
+// The limits are applied through the cluster dynamic config and are hot reloaded by the temporal services.
+type TemporalNamespaceLimitsSpec struct {
+	// DefaultWorkflowTaskTimeout is the workflow task timeout of the workflows started
+	// without one (system.defaultWorkflowTaskTimeout).
+	// +optional
+	DefaultWorkflowTaskTimeout *metav1.Duration `json:"defaultWorkflowTaskTimeout,omitempty"`
+	// BlobSize limits the size of the payloads, like workflow inputs or activity results (limit.blobSize).
+	// +optional
+	BlobSize *SizeLimitSpec `json:"blobSize,omitempty"`
+	// MemoSize limits the size of the workflows memo (limit.memoSize).
+	// +optional
+	MemoSize *SizeLimitSpec `json:"memoSize,omitempty"`
+	// HistorySize limits the size of the workflows history (limit.historySize).
+	// Workflows exceeding the error size are terminated.
+	// +optional
+	HistorySize *SizeLimitSpec `json:"historySize,omitempty"`
+	// HistoryCount limits the number of events of the workflows history (limit.historyCount).
+	// Workflows exceeding the error count are terminated.
+	// +optional
+	HistoryCount *CountLimitSpec `json:"historyCount,omitempty"`
+	// MaxPendingActivities is the maximum number of pending activities of a workflow (limit.numPendingActivities.error).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPendingActivities *int32 `json:"maxPendingActivities,omitempty"`
+	// MaxPendingChildExecutions is the maximum number of pending child workflows of a workflow (limit.numPendingChildExecutions.error).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPendingChildExecutions *int32 `json:"maxPendingChildExecutions,omitempty"`
+	// MaxPendingSignals is the maximum number of pending signals sent by a workflow (limit.numPendingSignals.error).
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPendingSignals *int32 `json:"maxPendingSignals,omitempty"`
+}
+
+// NamespaceVisibilityStore is the cluster visibility store a namespace reads its visibility records from.
+// +kubebuilder:validation:Enum=Primary;Secondary
+type NamespaceVisibilityStore string
+
+const (
+	// PrimaryNamespaceVisibilityStore reads visibility records from the cluster visibility store.
+	PrimaryNamespaceVisibilityStore NamespaceVisibilityStore = "Primary"
+	// SecondaryNamespaceVisibilityStore reads visibility records from the cluster secondary visibility store.
+	SecondaryNamespaceVisibilityStore NamespaceVisibilityStore = "Secondary"
+)
+
 // TemporalNamespaceSpec defines the desired state of Namespace.
 type TemporalNamespaceSpec struct {
 	// Reference to the temporal cluster the namespace will be created.
@@ -57,6 +197,7 @@ type TemporalNamespaceSpec struct {
 	Clusters []string `json:"clusters,omitempty"`
 	// The name of active Temporal Cluster.
 	// Only applicable if the namespace is a global namespace.
+	// Overrides the referenced cluster's spec.replication.activeCluster.
 	// +optional
 	ActiveClusterName string `json:"activeClusterName,omitempty"`
 	// AllowDeletion makes the controller delete the Temporal namespace if the
@@ -67,12 +208,44 @@ type TemporalNamespaceSpec struct {
 	// If not set, the default cluster configuration is used.
 	// +optional
 	Archival *TemporalNamespaceArchivalSpec `json:"archival,omitempty"`
+	// RateLimits defines the namespace rate limits.
+	// The referenced cluster should have dynamic config enabled (spec.dynamicConfig).
+	// +optional
+	RateLimits *TemporalNamespaceRateLimitsSpec `json:"rateLimits,omitempty"`
+	// TaskQueues defines the namespace task queues configuration.
+	// The referenced cluster should have dynamic config enabled (spec.dynamicConfig).
+	// +optional
+	TaskQueues *TemporalNamespaceTaskQueuesSpec `json:"taskQueues,omitempty"`
+	// Limits defines the namespace guardrails, like payloads and history size limits.
+	// The referenced cluster should have dynamic config enabled (spec.dynamicConfig).
+	// +optional
+	Limits *TemporalNamespaceLimitsSpec `json:"limits,omitempty"`
+	// VisibilityStore is the cluster visibility store the namespace visibility queries are served from
+	// (system.enableReadFromSecondaryVisibility).
+	// Only applied if the referenced cluster has a secondary visibility store (spec.persistence.secondaryVisibilityStore)
+	// and dynamic config enabled (spec.dynamicConfig).
+	// If not set, the cluster default is used.
+	// +optional
+	VisibilityStore NamespaceVisibilityStore `json:"visibilityStore,omitempty"`
+	// SearchAttributeAliases maps search attribute names to the pre-allocated custom search attribute
+	// fields they alias in the namespace (e.g. CustomerId: CustomKeywordField).
+	// It allows workflows to query and upsert the field using the alias name, easing the migration
+	// from predefined CustomKeywordField-style attributes to named attributes.
+	// Aliases removed from this map are removed from the namespace.
+	// +optional
+	SearchAttributeAliases map[string]string `json:"searchAttributeAliases,omitempty"`
 }
 
 // TemporalNamespaceStatus defines the observed state of Namespace.
 type TemporalNamespaceStatus struct {
 	// Conditions represent the latest available observations of the Namespace state.
 	Conditions []metav1.Condition `json:"conditions"`
+	// ActiveClusterName is the name of the cluster the global namespace was last set active on.
+	// +optional
+	ActiveClusterName string `json:"activeClusterName,omitempty"`
+	// SearchAttributeAliases are the search attribute aliases last applied by the operator on the namespace.
+	// +optional
+	SearchAttributeAliases map[string]string `json:"searchAttributeAliases,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -97,6 +270,22 @@ func (c *TemporalNamespace) IsReady() bool {
 	return false
 }
 
+// GetActiveClusterName returns the name of the cluster the namespace should be active on.
+// The namespace's activeClusterName takes precedence over the cluster's spec.replication.activeCluster.
+// It returns an empty string for non-global namespaces or if no active cluster is set.
+func (c *TemporalNamespace) GetActiveClusterName(cluster *TemporalCluster) string {
+	if !c.Spec.IsGlobalNamespace {
+		return ""
+	}
+	if c.Spec.ActiveClusterName != "" {
+		return c.Spec.ActiveClusterName
+	}
+	if cluster.Spec.Replication.IsEnabled() {
+		return cluster.Spec.Replication.ActiveCluster
+	}
+	return ""
+}
+
 //+kubebuilder:object:root=true
 
 // TemporalNamespaceList contains a list of Namespace.
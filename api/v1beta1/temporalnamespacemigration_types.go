@@ -0,0 +1,174 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TemporalNamespaceMigrationPhase is the phase of a namespace migration.
+type TemporalNamespaceMigrationPhase string
+
+const (
+	// NamespaceMigrationPending means the migration waits for the clusters to be ready and checks they replicate with each other.
+	NamespaceMigrationPending TemporalNamespaceMigrationPhase = "Pending"
+	// NamespaceMigrationReplicating means the namespace is made global and replicated to the target cluster.
+	NamespaceMigrationReplicating TemporalNamespaceMigrationPhase = "Replicating"
+	// NamespaceMigrationSyncing means the migration waits for the replication lag to the target cluster to be low enough.
+	NamespaceMigrationSyncing TemporalNamespaceMigrationPhase = "Syncing"
+	// NamespaceMigrationFailingOver means the namespace is being made active on the target cluster.
+	NamespaceMigrationFailingOver TemporalNamespaceMigrationPhase = "FailingOver"
+	// NamespaceMigrationRemovingOrigin means the source cluster is being removed from the namespace clusters.
+	NamespaceMigrationRemovingOrigin TemporalNamespaceMigrationPhase = "RemovingOrigin"
+	// NamespaceMigrationCompleted means the namespace only lives on the target cluster.
+	NamespaceMigrationCompleted TemporalNamespaceMigrationPhase = "Completed"
+	// NamespaceMigrationFailed means the namespace can't be migrated.
+	NamespaceMigrationFailed TemporalNamespaceMigrationPhase = "Failed"
+)
+
+// namespaceMigrationSteps lists the phases a migration goes through, in order.
+var namespaceMigrationSteps = []TemporalNamespaceMigrationPhase{
+	NamespaceMigrationPending,
+	NamespaceMigrationReplicating,
+	NamespaceMigrationSyncing,
+	NamespaceMigrationFailingOver,
+	NamespaceMigrationRemovingOrigin,
+	NamespaceMigrationCompleted,
+}
+
+// TemporalNamespaceMigrationSpec defines the namespace migrated and the cluster it's migrated to.
+// A migration runs once: its spec can't be changed after creation, create a new migration instead.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type TemporalNamespaceMigrationSpec struct {
+	// NamespaceRef references the TemporalNamespace to migrate, in the namespace of the migration.
+	// The cluster it references is the source cluster of the migration.
+	NamespaceRef corev1.LocalObjectReference `json:"namespaceRef"`
+	// TargetClusterRef references the cluster the namespace is migrated to.
+	// Both clusters must have replication enabled and list each other in their remote clusters.
+	TargetClusterRef ObjectReference `json:"targetClusterRef"`
+	// MaxReplicationLag is the replication lag to the target cluster under which the namespace is failed over.
+	// Defaults to the source cluster spec.replication.maxReplicationLag.
+	// +optional
+	MaxReplicationLag *metav1.Duration `json:"maxReplicationLag,omitempty"`
+	// SyncTimeout is the maximum time the migration waits for the source cluster to report its replication lag
+	// to the target cluster. The migration fails once it elapsed, leaving the namespace active on the source cluster.
+	// Defaults to 15 minutes.
+	// +optional
+	SyncTimeout *metav1.Duration `json:"syncTimeout,omitempty"`
+}
+
+// TemporalNamespaceMigrationStatus defines the observed state of TemporalNamespaceMigration.
+type TemporalNamespaceMigrationStatus struct {
+	// Phase is the current phase of the migration.
+	// +optional
+	Phase TemporalNamespaceMigrationPhase `json:"phase,omitempty"`
+	// Progress is the number of completed migration steps out of the total, e.g. 2/5.
+	// +optional
+	Progress string `json:"progress,omitempty"`
+	// SourceClusterRef references the cluster the namespace was migrated from.
+	// +optional
+	SourceClusterRef *ObjectReference `json:"sourceClusterRef,omitempty"`
+	// ReplicationLag is the last observed replication lag from the source cluster to the target cluster.
+	// +optional
+	ReplicationLag *metav1.Duration `json:"replicationLag,omitempty"`
+	// PhaseStartTime is the time the migration entered its current phase.
+	// +optional
+	PhaseStartTime *metav1.Time `json:"phaseStartTime,omitempty"`
+	// StartTime is the time the migration started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the migration completed or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Message holds a human readable explanation of the current phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IsFinished returns true if the migration reached a terminal phase.
+func (m *TemporalNamespaceMigration) IsFinished() bool {
+	return m.Status.Phase == NamespaceMigrationCompleted || m.Status.Phase == NamespaceMigrationFailed
+}
+
+// SetPhase sets the migration phase and its progress.
+func (m *TemporalNamespaceMigration) SetPhase(phase TemporalNamespaceMigrationPhase, message string) {
+	if m.Status.Phase != phase {
+		now := metav1.Now()
+		m.Status.PhaseStartTime = &now
+	}
+	m.Status.Phase = phase
+	m.Status.Message = message
+	for i, step := range namespaceMigrationSteps {
+		if step == phase {
+			m.Status.Progress = fmt.Sprintf("%d/%d", i, len(namespaceMigrationSteps)-1)
+		}
+	}
+}
+
+// GetMaxReplicationLag returns the replication lag under which the namespace is failed over to the target cluster.
+func (m *TemporalNamespaceMigration) GetMaxReplicationLag(source *TemporalCluster) time.Duration {
+	if m.Spec.MaxReplicationLag != nil {
+		return m.Spec.MaxReplicationLag.Duration
+	}
+	return source.Spec.Replication.GetMaxReplicationLag()
+}
+
+// GetSyncTimeout returns the maximum time the migration waits for the source cluster to report its replication lag.
+func (m *TemporalNamespaceMigration) GetSyncTimeout() time.Duration {
+	if m.Spec.SyncTimeout == nil {
+		return 15 * time.Minute
+	}
+	return m.Spec.SyncTimeout.Duration
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Namespace",type="string",JSONPath=".spec.namespaceRef.name"
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".status.sourceClusterRef.name"
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".spec.targetClusterRef.name"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Progress",type="string",JSONPath=".status.progress"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// A TemporalNamespaceMigration migrates a namespace between two operator-managed clusters using replication:
+// the namespace is made global and replicated to the target cluster, failed over to it once in sync,
+// then removed from the source cluster.
+type TemporalNamespaceMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TemporalNamespaceMigrationSpec   `json:"spec,omitempty"`
+	Status TemporalNamespaceMigrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TemporalNamespaceMigrationList contains a list of TemporalNamespaceMigration.
+type TemporalNamespaceMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalNamespaceMigration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalNamespaceMigration{}, &TemporalNamespaceMigrationList{})
+}
@@ -0,0 +1,128 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperatorConcurrencySpec defines the number of reconciliations the operator runs in parallel.
+type OperatorConcurrencySpec struct {
+	// NamespaceWorkers is the number of TemporalNamespaces reconciled in parallel.
+	// Changes are applied when the operator restarts.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NamespaceWorkers *int32 `json:"namespaceWorkers,omitempty"`
+	// NamespaceRateLimit is the maximum number of TemporalNamespaces reconciliations per second and per cluster.
+	// Set to 0 to disable.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	NamespaceRateLimit *int32 `json:"namespaceRateLimit,omitempty"`
+	// NamespaceRateLimitBurst is the number of TemporalNamespaces reconciliations allowed in a burst per cluster.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NamespaceRateLimitBurst *int32 `json:"namespaceRateLimitBurst,omitempty"`
+	// MaxConcurrentClusterRollouts is the maximum number of TemporalClusters rolling out their pods at the same time
+	// after operator initiated changes. Set to 0 to disable.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConcurrentClusterRollouts *int32 `json:"maxConcurrentClusterRollouts,omitempty"`
+}
+
+// OperatorDefaultsSpec defines the values applied to the clusters that don't specify them.
+type OperatorDefaultsSpec struct {
+	// MTLSProvider is the mTLS provider of clusters enabling mTLS without provider.
+	// +kubebuilder:validation:Enum=cert-manager;linkerd;istio
+	// +optional
+	MTLSProvider MTLSProvider `json:"mTLSProvider,omitempty"`
+	// ClusterIssuer is the cert-manager ClusterIssuer signing the root CA of clusters using cert-manager without issuer.
+	// +optional
+	ClusterIssuer string `json:"clusterIssuer,omitempty"`
+	// ImageRegistry is the registry the default images of clusters are pulled from.
+	// +optional
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+	// ImagePullSecrets are the image pull secrets of clusters without image pull secrets.
+	// +optional
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+}
+
+// OperatorNotificationsSpec defines where the clusters lifecycle events are sent to.
+type OperatorNotificationsSpec struct {
+	// WebhookURL is the webhook URL clusters lifecycle events are sent to.
+	// It can be overridden per cluster using annotations.
+	// +optional
+	WebhookURL string `json:"webhookURL,omitempty"`
+}
+
+// TemporalOperatorConfigSpec defines the operator runtime settings.
+// Unset fields keep the value set by the operator flags.
+type TemporalOperatorConfigSpec struct {
+	// WatchNamespaces restricts the namespaces the operator watches.
+	// All namespaces are watched if empty. Changes are applied when the operator restarts.
+	// +optional
+	WatchNamespaces []string `json:"watchNamespaces,omitempty"`
+	// Concurrency defines the number of reconciliations run in parallel.
+	// +optional
+	Concurrency *OperatorConcurrencySpec `json:"concurrency,omitempty"`
+	// Defaults are the values applied to the clusters that don't specify them.
+	// +optional
+	Defaults *OperatorDefaultsSpec `json:"defaults,omitempty"`
+	// Notifications defines where the clusters lifecycle events are sent to.
+	// +optional
+	Notifications *OperatorNotificationsSpec `json:"notifications,omitempty"`
+}
+
+// TemporalOperatorConfigStatus defines the observed state of TemporalOperatorConfig.
+type TemporalOperatorConfigStatus struct {
+	// ObservedGeneration is the last generation applied by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions represent the latest available observations of the config state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Applied",type="string",JSONPath=".status.conditions[?(@.type == 'Applied')].status"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// A TemporalOperatorConfig holds the operator runtime settings.
+// The operator reads the config named by its --operator-config flag and reloads it at runtime,
+// so the operator can be tuned without being redeployed.
+type TemporalOperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TemporalOperatorConfigSpec   `json:"spec,omitempty"`
+	Status TemporalOperatorConfigStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TemporalOperatorConfigList contains a list of TemporalOperatorConfig.
+type TemporalOperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalOperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalOperatorConfig{}, &TemporalOperatorConfigList{})
+}
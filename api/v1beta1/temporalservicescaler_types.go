@@ -0,0 +1,87 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TemporalServiceScalerSpec defines the desired replicas of a temporal service.
+type TemporalServiceScalerSpec struct {
+	// ClusterRef references the scaled cluster, in the namespace of the scaler.
+	ClusterRef corev1.LocalObjectReference `json:"clusterRef"`
+	// Service is the name of the scaled temporal service.
+	// +kubebuilder:validation:Enum=frontend;internal-frontend;history;matching;worker
+	Service string `json:"service"`
+	// Replicas is the desired number of pods of the service.
+	// It's written to the cluster spec. Defaults to the cluster current service replicas.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// TemporalServiceScalerStatus defines the observed state of TemporalServiceScaler.
+type TemporalServiceScalerStatus struct {
+	// Replicas is the number of pods of the service.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// ReadyReplicas is the number of ready pods of the service.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// Selector is the label selector of the service pods, used by autoscalers to collect the pods metrics.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+	// Conditions represent the latest available observations of the scaler state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterRef.name"
+//+kubebuilder:printcolumn:name="Service",type="string",JSONPath=".spec.service"
+//+kubebuilder:printcolumn:name="Desired",type="integer",JSONPath=".spec.replicas"
+//+kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type == 'Ready')].status"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// A TemporalServiceScaler exposes the replicas of a single temporal service through the scale subresource,
+// so kubectl scale and autoscalers like the HorizontalPodAutoscaler can scale the service
+// without editing the whole TemporalCluster spec.
+type TemporalServiceScaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TemporalServiceScalerSpec   `json:"spec,omitempty"`
+	Status TemporalServiceScalerStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TemporalServiceScalerList contains a list of TemporalServiceScaler.
+type TemporalServiceScalerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalServiceScaler `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalServiceScaler{}, &TemporalServiceScalerList{})
+}
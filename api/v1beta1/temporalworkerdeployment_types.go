@@ -0,0 +1,169 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TemporalWorkerDeploymentSpec defines the desired state of TemporalWorkerDeployment.
+type TemporalWorkerDeploymentSpec struct {
+	// Reference to the temporal cluster the workers connect to.
+	ClusterRef ObjectReference `json:"clusterRef"`
+	// ClusterClientRef references a TemporalClusterClient, in the worker deployment namespace,
+	// whose secret is mounted in the worker pods. Required when the cluster frontend uses mTLS.
+	// +optional
+	ClusterClientRef *corev1.LocalObjectReference `json:"clusterClientRef,omitempty"`
+	// Namespace is the temporal namespace the workers poll.
+	Namespace string `json:"namespace"`
+	// TaskQueues are the task queues the workers poll.
+	// +kubebuilder:validation:MinItems=1
+	TaskQueues []string `json:"taskQueues"`
+	// Image is the worker image.
+	Image string `json:"image"`
+	// ImagePullPolicy is the worker image pull policy.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// ImagePullSecrets are the secrets used to pull the worker image.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Command overrides the worker image entrypoint.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args are the arguments of the worker entrypoint.
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Env are additional environment variables of the worker container.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Resources are the compute resources of the worker container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// ServiceAccountName is the name of the ServiceAccount running the worker pods.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Replicas is the number of worker pods. Ignored when autoscaling is set.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Autoscaling scales the workers on the task queues backlog, using KEDA.
+	// +optional
+	Autoscaling *WorkerAutoscalingSpec `json:"autoscaling,omitempty"`
+}
+
+// GetReplicas returns the number of worker pods when autoscaling is disabled.
+func (s *TemporalWorkerDeploymentSpec) GetReplicas() int32 {
+	if s.Replicas == nil {
+		return 1
+	}
+	return *s.Replicas
+}
+
+// WorkerAutoscalingSpec defines how the workers are scaled using the KEDA temporal scaler.
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must be lower than or equal to maxReplicas"
+type WorkerAutoscalingSpec struct {
+	// MinReplicas is the minimum number of worker pods. Set it to 0 to scale the workers to zero.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the maximum number of worker pods.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// TargetQueueSize is the task queue backlog per worker pod the autoscaler targets.
+	// Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetQueueSize *int32 `json:"targetQueueSize,omitempty"`
+}
+
+// GetMinReplicas returns the minimum number of worker pods.
+func (s *WorkerAutoscalingSpec) GetMinReplicas() int32 {
+	if s.MinReplicas == nil {
+		return 1
+	}
+	return *s.MinReplicas
+}
+
+// GetTargetQueueSize returns the task queue backlog per worker pod the autoscaler targets.
+func (s *WorkerAutoscalingSpec) GetTargetQueueSize() int32 {
+	if s.TargetQueueSize == nil {
+		return 5
+	}
+	return *s.TargetQueueSize
+}
+
+// TemporalWorkerDeploymentStatus defines the observed state of TemporalWorkerDeployment.
+type TemporalWorkerDeploymentStatus struct {
+	// Replicas is the number of worker pods.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// ReadyReplicas is the number of ready worker pods.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// Conditions represent the latest available observations of the worker deployment state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterRef.name"
+//+kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas"
+//+kubebuilder:printcolumn:name="Ready Replicas",type="integer",JSONPath=".status.readyReplicas"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type == 'Ready')].status"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// A TemporalWorkerDeployment manages the Deployment of an application's temporal workers.
+type TemporalWorkerDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TemporalWorkerDeploymentSpec   `json:"spec,omitempty"`
+	Status TemporalWorkerDeploymentStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TemporalWorkerDeploymentList contains a list of TemporalWorkerDeployment.
+type TemporalWorkerDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemporalWorkerDeployment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemporalWorkerDeployment{}, &TemporalWorkerDeploymentList{})
+}
+
+// IsReady returns true if the worker deployment Ready condition is True.
+func (w *TemporalWorkerDeployment) IsReady() bool {
+	return apimeta.IsStatusConditionTrue(w.Status.Conditions, ReadyCondition)
+}
+
+// SelectorLabels returns the labels selecting the worker deployment resources.
+// The operator cache only holds the objects whose part-of label is known to internal/cache.
+func (w *TemporalWorkerDeployment) SelectorLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":    w.GetName(),
+		"app.kubernetes.io/part-of": "temporal-worker",
+	}
+}
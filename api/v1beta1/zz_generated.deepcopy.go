@@ -26,20 +26,64 @@ import (
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
 	"github.com/gocql/gocql"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
-	"k8s.io/api/core/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPolicySubject) DeepCopyInto(out *AccessPolicySubject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPolicySubject.
+func (in *AccessPolicySubject) DeepCopy() *AccessPolicySubject {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPolicySubject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerSilenceSpec) DeepCopyInto(out *AlertmanagerSilenceSpec) {
+	*out = *in
+	out.URLSecretRef = in.URLSecretRef
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerSilenceSpec.
+func (in *AlertmanagerSilenceSpec) DeepCopy() *AlertmanagerSilenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerSilenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArchivalProvider) DeepCopyInto(out *ArchivalProvider) {
 	*out = *in
 	if in.Filestore != nil {
 		in, out := &in.Filestore, &out.Filestore
 		*out = new(FilestoreArchiver)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.S3 != nil {
 		in, out := &in.S3, &out.S3
@@ -82,6 +126,11 @@ func (in *ArchivalSpec) DeepCopy() *ArchivalSpec {
 func (in *AuthorizationSpec) DeepCopyInto(out *AuthorizationSpec) {
 	*out = *in
 	in.JWTKeyProvider.DeepCopyInto(&out.JWTKeyProvider)
+	if in.CertificateClaimMapper != nil {
+		in, out := &in.CertificateClaimMapper, &out.CertificateClaimMapper
+		*out = new(CertificateClaimMapperSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationSpec.
@@ -104,7 +153,7 @@ func (in *AuthorizationSpecJWTKeyProvider) DeepCopyInto(out *AuthorizationSpecJW
 	}
 	if in.RefreshInterval != nil {
 		in, out := &in.RefreshInterval, &out.RefreshInterval
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 }
@@ -120,1350 +169,4877 @@ func (in *AuthorizationSpecJWTKeyProvider) DeepCopy() *AuthorizationSpecJWTKeyPr
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CassandraConsistencySpec) DeepCopyInto(out *CassandraConsistencySpec) {
+func (in *AutoscalerSpec) DeepCopyInto(out *AutoscalerSpec) {
 	*out = *in
-	if in.Consistency != nil {
-		in, out := &in.Consistency, &out.Consistency
-		*out = new(gocql.Consistency)
-		**out = **in
-	}
-	if in.SerialConsistency != nil {
-		in, out := &in.SerialConsistency, &out.SerialConsistency
-		*out = new(gocql.SerialConsistency)
+	if in.SafeToEvict != nil {
+		in, out := &in.SafeToEvict, &out.SafeToEvict
+		*out = new(bool)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraConsistencySpec.
-func (in *CassandraConsistencySpec) DeepCopy() *CassandraConsistencySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerSpec.
+func (in *AutoscalerSpec) DeepCopy() *AutoscalerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CassandraConsistencySpec)
+	out := new(AutoscalerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CassandraSpec) DeepCopyInto(out *CassandraSpec) {
+func (in *BackupGCSSpec) DeepCopyInto(out *BackupGCSSpec) {
 	*out = *in
-	if in.Hosts != nil {
-		in, out := &in.Hosts, &out.Hosts
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ConnectTimeout != nil {
-		in, out := &in.ConnectTimeout, &out.ConnectTimeout
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.Consistency != nil {
-		in, out := &in.Consistency, &out.Consistency
-		*out = new(CassandraConsistencySpec)
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraSpec.
-func (in *CassandraSpec) DeepCopy() *CassandraSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupGCSSpec.
+func (in *BackupGCSSpec) DeepCopy() *BackupGCSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CassandraSpec)
+	out := new(BackupGCSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificatesDurationSpec) DeepCopyInto(out *CertificatesDurationSpec) {
+func (in *BackupS3Spec) DeepCopyInto(out *BackupS3Spec) {
 	*out = *in
-	if in.RootCACertificate != nil {
-		in, out := &in.RootCACertificate, &out.RootCACertificate
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.IntermediateCAsCertificates != nil {
-		in, out := &in.IntermediateCAsCertificates, &out.IntermediateCAsCertificates
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.ClientCertificates != nil {
-		in, out := &in.ClientCertificates, &out.ClientCertificates
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.FrontendCertificate != nil {
-		in, out := &in.FrontendCertificate, &out.FrontendCertificate
-		*out = new(metav1.Duration)
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
 		**out = **in
 	}
-	if in.InternodeCertificate != nil {
-		in, out := &in.InternodeCertificate, &out.InternodeCertificate
-		*out = new(metav1.Duration)
-		**out = **in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(S3Credentials)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesDurationSpec.
-func (in *CertificatesDurationSpec) DeepCopy() *CertificatesDurationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupS3Spec.
+func (in *BackupS3Spec) DeepCopy() *BackupS3Spec {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificatesDurationSpec)
+	out := new(BackupS3Spec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterArchivalSpec) DeepCopyInto(out *ClusterArchivalSpec) {
+func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
 	*out = *in
-	if in.Provider != nil {
-		in, out := &in.Provider, &out.Provider
-		*out = new(ArchivalProvider)
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(BackupS3Spec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.History != nil {
-		in, out := &in.History, &out.History
-		*out = new(ArchivalSpec)
-		**out = **in
-	}
-	if in.Visibility != nil {
-		in, out := &in.Visibility, &out.Visibility
-		*out = new(ArchivalSpec)
-		**out = **in
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(BackupGCSSpec)
+		(*in).DeepCopyInto(*out)
 	}
+	in.Resources.DeepCopyInto(&out.Resources)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterArchivalSpec.
-func (in *ClusterArchivalSpec) DeepCopy() *ClusterArchivalSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSpec.
+func (in *BackupSpec) DeepCopy() *BackupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterArchivalSpec)
+	out := new(BackupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConstrainedValue) DeepCopyInto(out *ConstrainedValue) {
+func (in *BlueGreenStatus) DeepCopyInto(out *BlueGreenStatus) {
 	*out = *in
-	out.Constraints = in.Constraints
-	if in.Value != nil {
-		in, out := &in.Value, &out.Value
-		*out = new(apiextensionsv1.JSON)
-		(*in).DeepCopyInto(*out)
+	if in.MirroringStartTime != nil {
+		in, out := &in.MirroringStartTime, &out.MirroringStartTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConstrainedValue.
-func (in *ConstrainedValue) DeepCopy() *ConstrainedValue {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueGreenStatus.
+func (in *BlueGreenStatus) DeepCopy() *BlueGreenStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ConstrainedValue)
+	out := new(BlueGreenStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Constraints) DeepCopyInto(out *Constraints) {
+func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
 	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Constraints.
-func (in *Constraints) DeepCopy() *Constraints {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanarySpec.
+func (in *CanarySpec) DeepCopy() *CanarySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Constraints)
+	out := new(CanarySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatastoreSpec) DeepCopyInto(out *DatastoreSpec) {
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
 	*out = *in
-	if in.SQL != nil {
-		in, out := &in.SQL, &out.SQL
-		*out = new(SQLSpec)
-		(*in).DeepCopyInto(*out)
+	in.LastRunTime.DeepCopyInto(&out.LastRunTime)
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Elasticsearch != nil {
-		in, out := &in.Elasticsearch, &out.Elasticsearch
-		*out = new(ElasticsearchSpec)
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(v1.Duration)
 		**out = **in
 	}
-	if in.Cassandra != nil {
-		in, out := &in.Cassandra, &out.Cassandra
-		*out = new(CassandraSpec)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
+	if in == nil {
+		return nil
 	}
-	if in.PasswordSecretRef != nil {
-		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
-		*out = new(SecretKeyReference)
+	out := new(CanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraConsistencySpec) DeepCopyInto(out *CassandraConsistencySpec) {
+	*out = *in
+	if in.Consistency != nil {
+		in, out := &in.Consistency, &out.Consistency
+		*out = new(gocql.Consistency)
 		**out = **in
 	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(DatastoreTLSSpec)
-		(*in).DeepCopyInto(*out)
+	if in.SerialConsistency != nil {
+		in, out := &in.SerialConsistency, &out.SerialConsistency
+		*out = new(gocql.SerialConsistency)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreSpec.
-func (in *DatastoreSpec) DeepCopy() *DatastoreSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraConsistencySpec.
+func (in *CassandraConsistencySpec) DeepCopy() *CassandraConsistencySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DatastoreSpec)
+	out := new(CassandraConsistencySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatastoreStatus) DeepCopyInto(out *DatastoreStatus) {
+func (in *CassandraSpec) DeepCopyInto(out *CassandraSpec) {
 	*out = *in
-	if in.SchemaVersion != nil {
-		in, out := &in.SchemaVersion, &out.SchemaVersion
-		*out = new(version.Version)
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectTimeout != nil {
+		in, out := &in.ConnectTimeout, &out.ConnectTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Consistency != nil {
+		in, out := &in.Consistency, &out.Consistency
+		*out = new(CassandraConsistencySpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreStatus.
-func (in *DatastoreStatus) DeepCopy() *DatastoreStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraSpec.
+func (in *CassandraSpec) DeepCopy() *CassandraSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DatastoreStatus)
+	out := new(CassandraSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatastoreTLSSpec) DeepCopyInto(out *DatastoreTLSSpec) {
+func (in *CatalogSpec) DeepCopyInto(out *CatalogSpec) {
 	*out = *in
-	if in.CertFileRef != nil {
-		in, out := &in.CertFileRef, &out.CertFileRef
-		*out = new(SecretKeyReference)
-		**out = **in
-	}
-	if in.KeyFileRef != nil {
-		in, out := &in.KeyFileRef, &out.KeyFileRef
-		*out = new(SecretKeyReference)
-		**out = **in
-	}
-	if in.CaFileRef != nil {
-		in, out := &in.CaFileRef, &out.CaFileRef
-		*out = new(SecretKeyReference)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreTLSSpec.
-func (in *DatastoreTLSSpec) DeepCopy() *DatastoreTLSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogSpec.
+func (in *CatalogSpec) DeepCopy() *CatalogSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DatastoreTLSSpec)
+	out := new(CatalogSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentOverride) DeepCopyInto(out *DeploymentOverride) {
+func (in *CatalogStatus) DeepCopyInto(out *CatalogStatus) {
 	*out = *in
-	if in.ObjectMetaOverride != nil {
-		in, out := &in.ObjectMetaOverride, &out.ObjectMetaOverride
-		*out = new(ObjectMetaOverride)
-		(*in).DeepCopyInto(*out)
+	if in.FrontendHostnames != nil {
+		in, out := &in.FrontendHostnames, &out.FrontendHostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(DeploymentOverrideSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentOverride.
-func (in *DeploymentOverride) DeepCopy() *DeploymentOverride {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogStatus.
+func (in *CatalogStatus) DeepCopy() *CatalogStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentOverride)
+	out := new(CatalogStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentOverrideSpec) DeepCopyInto(out *DeploymentOverrideSpec) {
+func (in *CertificateClaimMapperNamespaceRoles) DeepCopyInto(out *CertificateClaimMapperNamespaceRoles) {
 	*out = *in
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(PodTemplateSpecOverride)
-		(*in).DeepCopyInto(*out)
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]TemporalRole, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentOverrideSpec.
-func (in *DeploymentOverrideSpec) DeepCopy() *DeploymentOverrideSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateClaimMapperNamespaceRoles.
+func (in *CertificateClaimMapperNamespaceRoles) DeepCopy() *CertificateClaimMapperNamespaceRoles {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentOverrideSpec)
+	out := new(CertificateClaimMapperNamespaceRoles)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DynamicConfigSpec) DeepCopyInto(out *DynamicConfigSpec) {
+func (in *CertificateClaimMapperRule) DeepCopyInto(out *CertificateClaimMapperRule) {
 	*out = *in
-	if in.PollInterval != nil {
-		in, out := &in.PollInterval, &out.PollInterval
-		*out = new(metav1.Duration)
-		**out = **in
+	if in.SystemRoles != nil {
+		in, out := &in.SystemRoles, &out.SystemRoles
+		*out = make([]TemporalRole, len(*in))
+		copy(*out, *in)
 	}
-	if in.Values != nil {
-		in, out := &in.Values, &out.Values
-		*out = make(map[string][]ConstrainedValue, len(*in))
-		for key, val := range *in {
-			var outVal []ConstrainedValue
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]ConstrainedValue, len(*in))
-				for i := range *in {
-					(*in)[i].DeepCopyInto(&(*out)[i])
-				}
-			}
-			(*out)[key] = outVal
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]CertificateClaimMapperNamespaceRoles, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicConfigSpec.
-func (in *DynamicConfigSpec) DeepCopy() *DynamicConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateClaimMapperRule.
+func (in *CertificateClaimMapperRule) DeepCopy() *CertificateClaimMapperRule {
 	if in == nil {
 		return nil
 	}
-	out := new(DynamicConfigSpec)
+	out := new(CertificateClaimMapperRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchIndices) DeepCopyInto(out *ElasticsearchIndices) {
+func (in *CertificateClaimMapperSpec) DeepCopyInto(out *CertificateClaimMapperSpec) {
 	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]CertificateClaimMapperRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchIndices.
-func (in *ElasticsearchIndices) DeepCopy() *ElasticsearchIndices {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateClaimMapperSpec.
+func (in *CertificateClaimMapperSpec) DeepCopy() *CertificateClaimMapperSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchIndices)
+	out := new(CertificateClaimMapperSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchSpec) DeepCopyInto(out *ElasticsearchSpec) {
+func (in *CertificateIssuerReference) DeepCopyInto(out *CertificateIssuerReference) {
 	*out = *in
-	out.Indices = in.Indices
-	out.CloseIdleConnectionsInterval = in.CloseIdleConnectionsInterval
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSpec.
-func (in *ElasticsearchSpec) DeepCopy() *ElasticsearchSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIssuerReference.
+func (in *CertificateIssuerReference) DeepCopy() *CertificateIssuerReference {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchSpec)
+	out := new(CertificateIssuerReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FilestoreArchiver) DeepCopyInto(out *FilestoreArchiver) {
+func (in *CertificatesDurationSpec) DeepCopyInto(out *CertificatesDurationSpec) {
 	*out = *in
+	if in.RootCACertificate != nil {
+		in, out := &in.RootCACertificate, &out.RootCACertificate
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.IntermediateCAsCertificates != nil {
+		in, out := &in.IntermediateCAsCertificates, &out.IntermediateCAsCertificates
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ClientCertificates != nil {
+		in, out := &in.ClientCertificates, &out.ClientCertificates
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FrontendCertificate != nil {
+		in, out := &in.FrontendCertificate, &out.FrontendCertificate
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.InternodeCertificate != nil {
+		in, out := &in.InternodeCertificate, &out.InternodeCertificate
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilestoreArchiver.
-func (in *FilestoreArchiver) DeepCopy() *FilestoreArchiver {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesDurationSpec.
+func (in *CertificatesDurationSpec) DeepCopy() *CertificatesDurationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FilestoreArchiver)
+	out := new(CertificatesDurationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FrontendMTLSSpec) DeepCopyInto(out *FrontendMTLSSpec) {
+func (in *ClusterArchivalSpec) DeepCopyInto(out *ClusterArchivalSpec) {
 	*out = *in
-	if in.ExtraDNSNames != nil {
-		in, out := &in.ExtraDNSNames, &out.ExtraDNSNames
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(ArchivalProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VisibilityProvider != nil {
+		in, out := &in.VisibilityProvider, &out.VisibilityProvider
+		*out = new(ArchivalProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(ArchivalSpec)
+		**out = **in
+	}
+	if in.Visibility != nil {
+		in, out := &in.Visibility, &out.Visibility
+		*out = new(ArchivalSpec)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendMTLSSpec.
-func (in *FrontendMTLSSpec) DeepCopy() *FrontendMTLSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterArchivalSpec.
+func (in *ClusterArchivalSpec) DeepCopy() *ClusterArchivalSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FrontendMTLSSpec)
+	out := new(ClusterArchivalSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCSArchiver) DeepCopyInto(out *GCSArchiver) {
+func (in *ClusterClientServerStatus) DeepCopyInto(out *ClusterClientServerStatus) {
 	*out = *in
-	if in.CredentialsRef != nil {
-		in, out := &in.CredentialsRef, &out.CredentialsRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSArchiver.
-func (in *GCSArchiver) DeepCopy() *GCSArchiver {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClientServerStatus.
+func (in *ClusterClientServerStatus) DeepCopy() *ClusterClientServerStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GCSArchiver)
+	out := new(ClusterClientServerStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalFrontendServiceSpec) DeepCopyInto(out *InternalFrontendServiceSpec) {
+func (in *ClusterInfoStatus) DeepCopyInto(out *ClusterInfoStatus) {
 	*out = *in
-	in.ServiceSpec.DeepCopyInto(&out.ServiceSpec)
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFrontendServiceSpec.
-func (in *InternalFrontendServiceSpec) DeepCopy() *InternalFrontendServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInfoStatus.
+func (in *ClusterInfoStatus) DeepCopy() *ClusterInfoStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalFrontendServiceSpec)
+	out := new(ClusterInfoStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternodeMTLSSpec) DeepCopyInto(out *InternodeMTLSSpec) {
+func (in *ClusterMetadataSpec) DeepCopyInto(out *ClusterMetadataSpec) {
 	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternodeMTLSSpec.
-func (in *InternodeMTLSSpec) DeepCopy() *InternodeMTLSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetadataSpec.
+func (in *ClusterMetadataSpec) DeepCopy() *ClusterMetadataSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InternodeMTLSSpec)
+	out := new(ClusterMetadataSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogSpec) DeepCopyInto(out *LogSpec) {
+func (in *ConfigMapKeyReference) DeepCopyInto(out *ConfigMapKeyReference) {
 	*out = *in
-	if in.Stdout != nil {
-		in, out := &in.Stdout, &out.Stdout
-		*out = new(bool)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSpec.
-func (in *LogSpec) DeepCopy() *LogSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeyReference.
+func (in *ConfigMapKeyReference) DeepCopy() *ConfigMapKeyReference {
 	if in == nil {
 		return nil
 	}
-	out := new(LogSpec)
+	out := new(ConfigMapKeyReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MTLSSpec) DeepCopyInto(out *MTLSSpec) {
+func (in *ConstrainedValue) DeepCopyInto(out *ConstrainedValue) {
 	*out = *in
-	if in.Internode != nil {
-		in, out := &in.Internode, &out.Internode
-		*out = new(InternodeMTLSSpec)
-		**out = **in
-	}
-	if in.Frontend != nil {
-		in, out := &in.Frontend, &out.Frontend
-		*out = new(FrontendMTLSSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CertificatesDuration != nil {
-		in, out := &in.CertificatesDuration, &out.CertificatesDuration
-		*out = new(CertificatesDurationSpec)
+	out.Constraints = in.Constraints
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.RefreshInterval != nil {
-		in, out := &in.RefreshInterval, &out.RefreshInterval
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.RenewBefore != nil {
-		in, out := &in.RenewBefore, &out.RenewBefore
-		*out = new(metav1.Duration)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MTLSSpec.
-func (in *MTLSSpec) DeepCopy() *MTLSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConstrainedValue.
+func (in *ConstrainedValue) DeepCopy() *ConstrainedValue {
 	if in == nil {
 		return nil
 	}
-	out := new(MTLSSpec)
+	out := new(ConstrainedValue)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricsSpec) DeepCopyInto(out *MetricsSpec) {
+func (in *Constraints) DeepCopyInto(out *Constraints) {
 	*out = *in
-	if in.ExcludeTags != nil {
-		in, out := &in.ExcludeTags, &out.ExcludeTags
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
-	}
-	if in.PerUnitHistogramBoundaries != nil {
-		in, out := &in.PerUnitHistogramBoundaries, &out.PerUnitHistogramBoundaries
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
-	}
-	if in.Prefix != nil {
-		in, out := &in.Prefix, &out.Prefix
-		*out = new(string)
-		**out = **in
-	}
-	if in.Prometheus != nil {
-		in, out := &in.Prometheus, &out.Prometheus
-		*out = new(PrometheusSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsSpec.
-func (in *MetricsSpec) DeepCopy() *MetricsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Constraints.
+func (in *Constraints) DeepCopy() *Constraints {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricsSpec)
+	out := new(Constraints)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectMetaOverride) DeepCopyInto(out *ObjectMetaOverride) {
+func (in *CountLimitSpec) DeepCopyInto(out *CountLimitSpec) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Warn != nil {
+		in, out := &in.Warn, &out.Warn
+		*out = new(int32)
+		**out = **in
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Error != nil {
+		in, out := &in.Error, &out.Error
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectMetaOverride.
-func (in *ObjectMetaOverride) DeepCopy() *ObjectMetaOverride {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CountLimitSpec.
+func (in *CountLimitSpec) DeepCopy() *CountLimitSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectMetaOverride)
+	out := new(CountLimitSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+func (in *CrashDumpSpec) DeepCopyInto(out *CrashDumpSpec) {
 	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestartThreshold != nil {
+		in, out := &in.RestartThreshold, &out.RestartThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LogLines != nil {
+		in, out := &in.LogLines, &out.LogLines
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxBundles != nil {
+		in, out := &in.MaxBundles, &out.MaxBundles
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
-func (in *ObjectReference) DeepCopy() *ObjectReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashDumpSpec.
+func (in *CrashDumpSpec) DeepCopy() *CrashDumpSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectReference)
+	out := new(CrashDumpSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodTemplateSpecOverride) DeepCopyInto(out *PodTemplateSpecOverride) {
+func (in *CrashDumpStatus) DeepCopyInto(out *CrashDumpStatus) {
 	*out = *in
-	if in.ObjectMetaOverride != nil {
-		in, out := &in.ObjectMetaOverride, &out.ObjectMetaOverride
-		*out = new(ObjectMetaOverride)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = new(apiextensionsv1.JSON)
-		(*in).DeepCopyInto(*out)
-	}
+	out.BundleRef = in.BundleRef
+	in.CollectionTime.DeepCopyInto(&out.CollectionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateSpecOverride.
-func (in *PodTemplateSpecOverride) DeepCopy() *PodTemplateSpecOverride {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashDumpStatus.
+func (in *CrashDumpStatus) DeepCopy() *CrashDumpStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PodTemplateSpecOverride)
+	out := new(CrashDumpStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrometheusScrapeConfig) DeepCopyInto(out *PrometheusScrapeConfig) {
+func (in *DatadogSpec) DeepCopyInto(out *DatadogSpec) {
 	*out = *in
-	if in.ServiceMonitor != nil {
-		in, out := &in.ServiceMonitor, &out.ServiceMonitor
-		*out = new(PrometheusScrapeConfigServiceMonitor)
-		(*in).DeepCopyInto(*out)
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeConfig.
-func (in *PrometheusScrapeConfig) DeepCopy() *PrometheusScrapeConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogSpec.
+func (in *DatadogSpec) DeepCopy() *DatadogSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PrometheusScrapeConfig)
+	out := new(DatadogSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrometheusScrapeConfigServiceMonitor) DeepCopyInto(out *PrometheusScrapeConfigServiceMonitor) {
+func (in *DatastoreServiceAliasSpec) DeepCopyInto(out *DatastoreServiceAliasSpec) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreServiceAliasSpec.
+func (in *DatastoreServiceAliasSpec) DeepCopy() *DatastoreServiceAliasSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Override != nil {
-		in, out := &in.Override, &out.Override
-		*out = new(monitoringv1.ServiceMonitorSpec)
+	out := new(DatastoreServiceAliasSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatastoreSpec) DeepCopyInto(out *DatastoreSpec) {
+	*out = *in
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(SQLSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.MetricRelabelConfigs != nil {
-		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
-		*out = make([]*monitoringv1.RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(monitoringv1.RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
+	if in.Elasticsearch != nil {
+		in, out := &in.Elasticsearch, &out.Elasticsearch
+		*out = new(ElasticsearchSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cassandra != nil {
+		in, out := &in.Cassandra, &out.Cassandra
+		*out = new(CassandraSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(DatastoreTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SchemaToolExtraArgs != nil {
+		in, out := &in.SchemaToolExtraArgs, &out.SchemaToolExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAlias != nil {
+		in, out := &in.ServiceAlias, &out.ServiceAlias
+		*out = new(DatastoreServiceAliasSpec)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeConfigServiceMonitor.
-func (in *PrometheusScrapeConfigServiceMonitor) DeepCopy() *PrometheusScrapeConfigServiceMonitor {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreSpec.
+func (in *DatastoreSpec) DeepCopy() *DatastoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PrometheusScrapeConfigServiceMonitor)
+	out := new(DatastoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
+func (in *DatastoreStatus) DeepCopyInto(out *DatastoreStatus) {
 	*out = *in
-	if in.ListenPort != nil {
-		in, out := &in.ListenPort, &out.ListenPort
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ScrapeConfig != nil {
-		in, out := &in.ScrapeConfig, &out.ScrapeConfig
-		*out = new(PrometheusScrapeConfig)
+	if in.SchemaVersion != nil {
+		in, out := &in.SchemaVersion, &out.SchemaVersion
+		*out = new(version.Version)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusSpec.
-func (in *PrometheusSpec) DeepCopy() *PrometheusSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreStatus.
+func (in *DatastoreStatus) DeepCopy() *DatastoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PrometheusSpec)
+	out := new(DatastoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+func (in *DatastoreTLSSpec) DeepCopyInto(out *DatastoreTLSSpec) {
 	*out = *in
-	if in.InitialInterval != nil {
-		in, out := &in.InitialInterval, &out.InitialInterval
-		*out = new(metav1.Duration)
+	if in.CertFileRef != nil {
+		in, out := &in.CertFileRef, &out.CertFileRef
+		*out = new(SecretKeyReference)
 		**out = **in
 	}
-	if in.BackoffCoefficient != nil {
-		in, out := &in.BackoffCoefficient, &out.BackoffCoefficient
-		x := (*in).DeepCopy()
-		*out = &x
-	}
-	if in.MaximumInterval != nil {
-		in, out := &in.MaximumInterval, &out.MaximumInterval
-		*out = new(metav1.Duration)
+	if in.KeyFileRef != nil {
+		in, out := &in.KeyFileRef, &out.KeyFileRef
+		*out = new(SecretKeyReference)
 		**out = **in
 	}
-	if in.NonRetryableErrorTypes != nil {
-		in, out := &in.NonRetryableErrorTypes, &out.NonRetryableErrorTypes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.CaFileRef != nil {
+		in, out := &in.CaFileRef, &out.CaFileRef
+		*out = new(SecretKeyReference)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
-func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreTLSSpec.
+func (in *DatastoreTLSSpec) DeepCopy() *DatastoreTLSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RetryPolicy)
+	out := new(DatastoreTLSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *S3Archiver) DeepCopyInto(out *S3Archiver) {
+func (in *DeploymentOverride) DeepCopyInto(out *DeploymentOverride) {
 	*out = *in
-	if in.Endpoint != nil {
-		in, out := &in.Endpoint, &out.Endpoint
-		*out = new(string)
-		**out = **in
-	}
-	if in.RoleName != nil {
-		in, out := &in.RoleName, &out.RoleName
-		*out = new(string)
-		**out = **in
+	if in.ObjectMetaOverride != nil {
+		in, out := &in.ObjectMetaOverride, &out.ObjectMetaOverride
+		*out = new(ObjectMetaOverride)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Credentials != nil {
-		in, out := &in.Credentials, &out.Credentials
-		*out = new(S3Credentials)
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(DeploymentOverrideSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Archiver.
-func (in *S3Archiver) DeepCopy() *S3Archiver {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentOverride.
+func (in *DeploymentOverride) DeepCopy() *DeploymentOverride {
 	if in == nil {
 		return nil
 	}
-	out := new(S3Archiver)
+	out := new(DeploymentOverride)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *S3Credentials) DeepCopyInto(out *S3Credentials) {
+func (in *DeploymentOverrideSpec) DeepCopyInto(out *DeploymentOverrideSpec) {
 	*out = *in
-	if in.AccessKeyIDRef != nil {
-		in, out := &in.AccessKeyIDRef, &out.AccessKeyIDRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SecretAccessKeyRef != nil {
-		in, out := &in.SecretAccessKeyRef, &out.SecretAccessKeyRef
-		*out = new(v1.SecretKeySelector)
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(PodTemplateSpecOverride)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Credentials.
-func (in *S3Credentials) DeepCopy() *S3Credentials {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentOverrideSpec.
+func (in *DeploymentOverrideSpec) DeepCopy() *DeploymentOverrideSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(S3Credentials)
+	out := new(DeploymentOverrideSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SQLSpec) DeepCopyInto(out *SQLSpec) {
+func (in *DynamicConfigKeyStatus) DeepCopyInto(out *DynamicConfigKeyStatus) {
 	*out = *in
-	if in.ConnectAttributes != nil {
-		in, out := &in.ConnectAttributes, &out.ConnectAttributes
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]DynamicConfigSource, len(*in))
+		copy(*out, *in)
 	}
-	out.MaxConnLifetime = in.MaxConnLifetime
-	if in.GCPServiceAccount != nil {
-		in, out := &in.GCPServiceAccount, &out.GCPServiceAccount
-		*out = new(string)
-		**out = **in
+	if in.OverriddenSources != nil {
+		in, out := &in.OverriddenSources, &out.OverriddenSources
+		*out = make([]DynamicConfigSource, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLSpec.
-func (in *SQLSpec) DeepCopy() *SQLSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicConfigKeyStatus.
+func (in *DynamicConfigKeyStatus) DeepCopy() *DynamicConfigKeyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SQLSpec)
+	out := new(DynamicConfigKeyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Schedule) DeepCopyInto(out *Schedule) {
+func (in *DynamicConfigSpec) DeepCopyInto(out *DynamicConfigSpec) {
 	*out = *in
-	in.Action.DeepCopyInto(&out.Action)
-	in.Spec.DeepCopyInto(&out.Spec)
-	if in.Policy != nil {
-		in, out := &in.Policy, &out.Policy
-		*out = new(SchedulePolicies)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.State != nil {
-		in, out := &in.State, &out.State
-		*out = new(ScheduleState)
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string][]ConstrainedValue, len(*in))
+		for key, val := range *in {
+			var outVal []ConstrainedValue
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]ConstrainedValue, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Schedule.
-func (in *Schedule) DeepCopy() *Schedule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicConfigSpec.
+func (in *DynamicConfigSpec) DeepCopy() *DynamicConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Schedule)
+	out := new(DynamicConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduleAction) DeepCopyInto(out *ScheduleAction) {
+func (in *DynamicConfigStatus) DeepCopyInto(out *DynamicConfigStatus) {
 	*out = *in
-	in.Workflow.DeepCopyInto(&out.Workflow)
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]DynamicConfigKeyStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleAction.
-func (in *ScheduleAction) DeepCopy() *ScheduleAction {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicConfigStatus.
+func (in *DynamicConfigStatus) DeepCopy() *DynamicConfigStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduleAction)
+	out := new(DynamicConfigStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduleCalendarSpec) DeepCopyInto(out *ScheduleCalendarSpec) {
+func (in *EditionSpec) DeepCopyInto(out *EditionSpec) {
 	*out = *in
-	if in.Second != nil {
-		in, out := &in.Second, &out.Second
-		*out = make([]ScheduleSecondMinuteRange, len(*in))
-		copy(*out, *in)
-	}
-	if in.Minute != nil {
-		in, out := &in.Minute, &out.Minute
-		*out = make([]ScheduleSecondMinuteRange, len(*in))
-		copy(*out, *in)
-	}
-	if in.Hour != nil {
-		in, out := &in.Hour, &out.Hour
-		*out = make([]ScheduleHourRange, len(*in))
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]EditionCapability, len(*in))
 		copy(*out, *in)
 	}
-	if in.DayOfMonth != nil {
-		in, out := &in.DayOfMonth, &out.DayOfMonth
-		*out = make([]ScheduleDayOfMonthRange, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EditionSpec.
+func (in *EditionSpec) DeepCopy() *EditionSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Month != nil {
-		in, out := &in.Month, &out.Month
+	out := new(EditionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchFieldConflict) DeepCopyInto(out *ElasticsearchFieldConflict) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchFieldConflict.
+func (in *ElasticsearchFieldConflict) DeepCopy() *ElasticsearchFieldConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchFieldConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchIndices) DeepCopyInto(out *ElasticsearchIndices) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchIndices.
+func (in *ElasticsearchIndices) DeepCopy() *ElasticsearchIndices {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchIndices)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMappingCheckSpec) DeepCopyInto(out *ElasticsearchMappingCheckSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMappingCheckSpec.
+func (in *ElasticsearchMappingCheckSpec) DeepCopy() *ElasticsearchMappingCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMappingCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMappingStatus) DeepCopyInto(out *ElasticsearchMappingStatus) {
+	*out = *in
+	if in.MissingFields != nil {
+		in, out := &in.MissingFields, &out.MissingFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TypeConflicts != nil {
+		in, out := &in.TypeConflicts, &out.TypeConflicts
+		*out = make([]ElasticsearchFieldConflict, len(*in))
+		copy(*out, *in)
+	}
+	if in.RepairedFields != nil {
+		in, out := &in.RepairedFields, &out.RepairedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMappingStatus.
+func (in *ElasticsearchMappingStatus) DeepCopy() *ElasticsearchMappingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMappingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSpec) DeepCopyInto(out *ElasticsearchSpec) {
+	*out = *in
+	out.Indices = in.Indices
+	out.CloseIdleConnectionsInterval = in.CloseIdleConnectionsInterval
+	if in.Managed != nil {
+		in, out := &in.Managed, &out.Managed
+		*out = new(ManagedElasticsearchSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SearchAttributes != nil {
+		in, out := &in.SearchAttributes, &out.SearchAttributes
+		*out = make(map[string]SearchAttributeType, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MappingCheck != nil {
+		in, out := &in.MappingCheck, &out.MappingCheck
+		*out = new(ElasticsearchMappingCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSpec.
+func (in *ElasticsearchSpec) DeepCopy() *ElasticsearchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposeHostnamesSpec) DeepCopyInto(out *ExposeHostnamesSpec) {
+	*out = *in
+	if in.Frontend != nil {
+		in, out := &in.Frontend, &out.Frontend
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UI != nil {
+		in, out := &in.UI, &out.UI
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeHostnamesSpec.
+func (in *ExposeHostnamesSpec) DeepCopy() *ExposeHostnamesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposeHostnamesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposeSpec) DeepCopyInto(out *ExposeSpec) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = new(ExposeHostnamesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalFrontend != nil {
+		in, out := &in.ExternalFrontend, &out.ExternalFrontend
+		*out = new(ExternalFrontendSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeSpec.
+func (in *ExposeSpec) DeepCopy() *ExposeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalConfigServicesSpec) DeepCopyInto(out *ExternalConfigServicesSpec) {
+	*out = *in
+	if in.Frontend != nil {
+		in, out := &in.Frontend, &out.Frontend
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.InternalFrontend != nil {
+		in, out := &in.InternalFrontend, &out.InternalFrontend
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Matching != nil {
+		in, out := &in.Matching, &out.Matching
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Worker != nil {
+		in, out := &in.Worker, &out.Worker
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalConfigServicesSpec.
+func (in *ExternalConfigServicesSpec) DeepCopy() *ExternalConfigServicesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalConfigServicesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalConfigSpec) DeepCopyInto(out *ExternalConfigSpec) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = new(ExternalConfigServicesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalConfigSpec.
+func (in *ExternalConfigSpec) DeepCopy() *ExternalConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalFrontendSpec) DeepCopyInto(out *ExternalFrontendSpec) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalFrontendSpec.
+func (in *ExternalFrontendSpec) DeepCopy() *ExternalFrontendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalFrontendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilestoreArchiver) DeepCopyInto(out *FilestoreArchiver) {
+	*out = *in
+	if in.Volume != nil {
+		in, out := &in.Volume, &out.Volume
+		*out = new(FilestoreVolumeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilestoreArchiver.
+func (in *FilestoreArchiver) DeepCopy() *FilestoreArchiver {
+	if in == nil {
+		return nil
+	}
+	out := new(FilestoreArchiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilestoreVolumeSpec) DeepCopyInto(out *FilestoreVolumeSpec) {
+	*out = *in
+	if in.ClaimTemplate != nil {
+		in, out := &in.ClaimTemplate, &out.ClaimTemplate
+		*out = new(corev1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilestoreVolumeSpec.
+func (in *FilestoreVolumeSpec) DeepCopy() *FilestoreVolumeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FilestoreVolumeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetClusterReference) DeepCopyInto(out *FleetClusterReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetClusterReference.
+func (in *FleetClusterReference) DeepCopy() *FleetClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetExpiringCertificate) DeepCopyInto(out *FleetExpiringCertificate) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetExpiringCertificate.
+func (in *FleetExpiringCertificate) DeepCopy() *FleetExpiringCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetExpiringCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetVersionCount) DeepCopyInto(out *FleetVersionCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetVersionCount.
+func (in *FleetVersionCount) DeepCopy() *FleetVersionCount {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetVersionCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrontendMTLSSpec) DeepCopyInto(out *FrontendMTLSSpec) {
+	*out = *in
+	if in.ExtraDNSNames != nil {
+		in, out := &in.ExtraDNSNames, &out.ExtraDNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendMTLSSpec.
+func (in *FrontendMTLSSpec) DeepCopy() *FrontendMTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FrontendMTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrontendMirroringSpec) DeepCopyInto(out *FrontendMirroringSpec) {
+	*out = *in
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendMirroringSpec.
+func (in *FrontendMirroringSpec) DeepCopy() *FrontendMirroringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FrontendMirroringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrontendProxySpec) DeepCopyInto(out *FrontendProxySpec) {
+	*out = *in
+	if in.AllowedClientNames != nil {
+		in, out := &in.AllowedClientNames, &out.AllowedClientNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Mirroring != nil {
+		in, out := &in.Mirroring, &out.Mirroring
+		*out = new(FrontendMirroringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendProxySpec.
+func (in *FrontendProxySpec) DeepCopy() *FrontendProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FrontendProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCSArchiver) DeepCopyInto(out *GCSArchiver) {
+	*out = *in
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSArchiver.
+func (in *GCSArchiver) DeepCopy() *GCSArchiver {
+	if in == nil {
+		return nil
+	}
+	out := new(GCSArchiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCWebCORSSpec) DeepCopyInto(out *GRPCWebCORSSpec) {
+	*out = *in
+	if in.AllowedOrigins != nil {
+		in, out := &in.AllowedOrigins, &out.AllowedOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedHeaders != nil {
+		in, out := &in.AllowedHeaders, &out.AllowedHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposedHeaders != nil {
+		in, out := &in.ExposedHeaders, &out.ExposedHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCWebCORSSpec.
+func (in *GRPCWebCORSSpec) DeepCopy() *GRPCWebCORSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCWebCORSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCWebSpec) DeepCopyInto(out *GRPCWebSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.CORS != nil {
+		in, out := &in.CORS, &out.CORS
+		*out = new(GRPCWebCORSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ObjectMetaOverride)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCWebSpec.
+func (in *GRPCWebSpec) DeepCopy() *GRPCWebSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCWebSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GracefulShutdownSpec) DeepCopyInto(out *GracefulShutdownSpec) {
+	*out = *in
+	if in.PreStopDelay != nil {
+		in, out := &in.PreStopDelay, &out.PreStopDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DrainDuration != nil {
+		in, out := &in.DrainDuration, &out.DrainDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GracefulShutdownSpec.
+func (in *GracefulShutdownSpec) DeepCopy() *GracefulShutdownSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GracefulShutdownSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationSpec) DeepCopyInto(out *ImageVerificationSpec) {
+	*out = *in
+	out.PublicKeySecretRef = in.PublicKeySecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationSpec.
+func (in *ImageVerificationSpec) DeepCopy() *ImageVerificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalFrontendServiceSpec) DeepCopyInto(out *InternalFrontendServiceSpec) {
+	*out = *in
+	in.ServiceSpec.DeepCopyInto(&out.ServiceSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFrontendServiceSpec.
+func (in *InternalFrontendServiceSpec) DeepCopy() *InternalFrontendServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalFrontendServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternodeMTLSSpec) DeepCopyInto(out *InternodeMTLSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternodeMTLSSpec.
+func (in *InternodeMTLSSpec) DeepCopy() *InternodeMTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InternodeMTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryEntry) DeepCopyInto(out *InventoryEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryEntry.
+func (in *InventoryEntry) DeepCopy() *InventoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobHistoryLimitsSpec) DeepCopyInto(out *JobHistoryLimitsSpec) {
+	*out = *in
+	if in.SuccessfulJobsHistoryLimit != nil {
+		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedJobsHistoryLimit != nil {
+		in, out := &in.FailedJobsHistoryLimit, &out.FailedJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobHistoryLimitsSpec.
+func (in *JobHistoryLimitsSpec) DeepCopy() *JobHistoryLimitsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobHistoryLimitsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSpec) DeepCopyInto(out *LogSpec) {
+	*out = *in
+	if in.Stdout != nil {
+		in, out := &in.Stdout, &out.Stdout
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSpec.
+func (in *LogSpec) DeepCopy() *LogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MTLSSpec) DeepCopyInto(out *MTLSSpec) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertificateIssuerReference)
+		**out = **in
+	}
+	if in.Internode != nil {
+		in, out := &in.Internode, &out.Internode
+		*out = new(InternodeMTLSSpec)
+		**out = **in
+	}
+	if in.Frontend != nil {
+		in, out := &in.Frontend, &out.Frontend
+		*out = new(FrontendMTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificatesDuration != nil {
+		in, out := &in.CertificatesDuration, &out.CertificatesDuration
+		*out = new(CertificatesDurationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MTLSSpec.
+func (in *MTLSSpec) DeepCopy() *MTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedElasticsearchSpec) DeepCopyInto(out *ManagedElasticsearchSpec) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedElasticsearchSpec.
+func (in *ManagedElasticsearchSpec) DeepCopy() *ManagedElasticsearchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedElasticsearchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedResourceStatus) DeepCopyInto(out *ManagedResourceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedResourceStatus.
+func (in *ManagedResourceStatus) DeepCopy() *ManagedResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryProtectionSpec) DeepCopyInto(out *MemoryProtectionSpec) {
+	*out = *in
+	if in.HeadroomPercent != nil {
+		in, out := &in.HeadroomPercent, &out.HeadroomPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryProtectionSpec.
+func (in *MemoryProtectionSpec) DeepCopy() *MemoryProtectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryProtectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsSpec) DeepCopyInto(out *MetricsSpec) {
+	*out = *in
+	if in.ExcludeTags != nil {
+		in, out := &in.ExcludeTags, &out.ExcludeTags
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.PerUnitHistogramBoundaries != nil {
+		in, out := &in.PerUnitHistogramBoundaries, &out.PerUnitHistogramBoundaries
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Datadog != nil {
+		in, out := &in.Datadog, &out.Datadog
+		*out = new(DatadogSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsSpec.
+func (in *MetricsSpec) DeepCopy() *MetricsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationProgress) DeepCopyInto(out *MigrationProgress) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationProgress.
+func (in *MigrationProgress) DeepCopy() *MigrationProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoredTaskQueueSpec) DeepCopyInto(out *MonitoredTaskQueueSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoredTaskQueueSpec.
+func (in *MonitoredTaskQueueSpec) DeepCopy() *MonitoredTaskQueueSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoredTaskQueueSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectMetaOverride) DeepCopyInto(out *ObjectMetaOverride) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectMetaOverride.
+func (in *ObjectMetaOverride) DeepCopy() *ObjectMetaOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectMetaOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
+func (in *ObjectReference) DeepCopy() *ObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConcurrencySpec) DeepCopyInto(out *OperatorConcurrencySpec) {
+	*out = *in
+	if in.NamespaceWorkers != nil {
+		in, out := &in.NamespaceWorkers, &out.NamespaceWorkers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NamespaceRateLimit != nil {
+		in, out := &in.NamespaceRateLimit, &out.NamespaceRateLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NamespaceRateLimitBurst != nil {
+		in, out := &in.NamespaceRateLimitBurst, &out.NamespaceRateLimitBurst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrentClusterRollouts != nil {
+		in, out := &in.MaxConcurrentClusterRollouts, &out.MaxConcurrentClusterRollouts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConcurrencySpec.
+func (in *OperatorConcurrencySpec) DeepCopy() *OperatorConcurrencySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConcurrencySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorDefaultsSpec) DeepCopyInto(out *OperatorDefaultsSpec) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorDefaultsSpec.
+func (in *OperatorDefaultsSpec) DeepCopy() *OperatorDefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorDefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorNotificationsSpec) DeepCopyInto(out *OperatorNotificationsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorNotificationsSpec.
+func (in *OperatorNotificationsSpec) DeepCopy() *OperatorNotificationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorNotificationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceHook) DeepCopyInto(out *PersistenceHook) {
+	*out = *in
+	out.ConfigMapRef = in.ConfigMapRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceHook.
+func (in *PersistenceHook) DeepCopy() *PersistenceHook {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceHooksSpec) DeepCopyInto(out *PersistenceHooksSpec) {
+	*out = *in
+	if in.PreSetup != nil {
+		in, out := &in.PreSetup, &out.PreSetup
+		*out = make([]PersistenceHook, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostSetup != nil {
+		in, out := &in.PostSetup, &out.PostSetup
+		*out = make([]PersistenceHook, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreUpdate != nil {
+		in, out := &in.PreUpdate, &out.PreUpdate
+		*out = make([]PersistenceHook, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostUpdate != nil {
+		in, out := &in.PostUpdate, &out.PostUpdate
+		*out = make([]PersistenceHook, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceHooksSpec.
+func (in *PersistenceHooksSpec) DeepCopy() *PersistenceHooksSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceHooksSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceRateLimitsSpec) DeepCopyInto(out *PersistenceRateLimitsSpec) {
+	*out = *in
+	if in.Frontend != nil {
+		in, out := &in.Frontend, &out.Frontend
+		*out = new(ServicePersistenceRateLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(ServicePersistenceRateLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Matching != nil {
+		in, out := &in.Matching, &out.Matching
+		*out = new(ServicePersistenceRateLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Worker != nil {
+		in, out := &in.Worker, &out.Worker
+		*out = new(ServicePersistenceRateLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TaskScheduler != nil {
+		in, out := &in.TaskScheduler, &out.TaskScheduler
+		*out = new(TaskSchedulerRateLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VisibilityStore != nil {
+		in, out := &in.VisibilityStore, &out.VisibilityStore
+		*out = new(VisibilityStoreRateLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceRateLimitsSpec.
+func (in *PersistenceRateLimitsSpec) DeepCopy() *PersistenceRateLimitsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceRateLimitsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecuritySpec) DeepCopyInto(out *PodSecuritySpec) {
+	*out = *in
+	if in.SeccompProfile != nil {
+		in, out := &in.SeccompProfile, &out.SeccompProfile
+		*out = new(corev1.SeccompProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AppArmorProfile != nil {
+		in, out := &in.AppArmorProfile, &out.AppArmorProfile
+		*out = new(corev1.AppArmorProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostUsers != nil {
+		in, out := &in.HostUsers, &out.HostUsers
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecuritySpec.
+func (in *PodSecuritySpec) DeepCopy() *PodSecuritySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecuritySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplateSpecOverride) DeepCopyInto(out *PodTemplateSpecOverride) {
+	*out = *in
+	if in.ObjectMetaOverride != nil {
+		in, out := &in.ObjectMetaOverride, &out.ObjectMetaOverride
+		*out = new(ObjectMetaOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateSpecOverride.
+func (in *PodTemplateSpecOverride) DeepCopy() *PodTemplateSpecOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplateSpecOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRuleSpec) DeepCopyInto(out *PrometheusRuleSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SchemaJobAlertThreshold != nil {
+		in, out := &in.SchemaJobAlertThreshold, &out.SchemaJobAlertThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRuleSpec.
+func (in *PrometheusRuleSpec) DeepCopy() *PrometheusRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusScrapeConfig) DeepCopyInto(out *PrometheusScrapeConfig) {
+	*out = *in
+	if in.ServiceMonitor != nil {
+		in, out := &in.ServiceMonitor, &out.ServiceMonitor
+		*out = new(PrometheusScrapeConfigServiceMonitor)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeConfig.
+func (in *PrometheusScrapeConfig) DeepCopy() *PrometheusScrapeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusScrapeConfigServiceMonitor) DeepCopyInto(out *PrometheusScrapeConfigServiceMonitor) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Override != nil {
+		in, out := &in.Override, &out.Override
+		*out = new(monitoringv1.ServiceMonitorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricRelabelConfigs != nil {
+		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeConfigServiceMonitor.
+func (in *PrometheusScrapeConfigServiceMonitor) DeepCopy() *PrometheusScrapeConfigServiceMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeConfigServiceMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
+	*out = *in
+	if in.ListenPort != nil {
+		in, out := &in.ListenPort, &out.ListenPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScrapeConfig != nil {
+		in, out := &in.ScrapeConfig, &out.ScrapeConfig
+		*out = new(PrometheusScrapeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrometheusRule != nil {
+		in, out := &in.PrometheusRule, &out.PrometheusRule
+		*out = new(PrometheusRuleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusSpec.
+func (in *PrometheusSpec) DeepCopy() *PrometheusSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecommendationsStatus) DeepCopyInto(out *RecommendationsStatus) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]ResourceRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastSampleTime.DeepCopyInto(&out.LastSampleTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecommendationsStatus.
+func (in *RecommendationsStatus) DeepCopy() *RecommendationsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RecommendationsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterReplicationStatus) DeepCopyInto(out *RemoteClusterReplicationStatus) {
+	*out = *in
+	if in.Lag != nil {
+		in, out := &in.Lag, &out.Lag
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterReplicationStatus.
+func (in *RemoteClusterReplicationStatus) DeepCopy() *RemoteClusterReplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterReplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterSpec) DeepCopyInto(out *RemoteClusterSpec) {
+	*out = *in
+	if in.ClusterRef != nil {
+		in, out := &in.ClusterRef, &out.ClusterRef
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RemoteClusterTLSSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterSpec.
+func (in *RemoteClusterSpec) DeepCopy() *RemoteClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterTLSSpec) DeepCopyInto(out *RemoteClusterTLSSpec) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterTLSSpec.
+func (in *RemoteClusterTLSSpec) DeepCopy() *RemoteClusterTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSpec) DeepCopyInto(out *ReplicationSpec) {
+	*out = *in
+	if in.RemoteClusters != nil {
+		in, out := &in.RemoteClusters, &out.RemoteClusters
+		*out = make([]RemoteClusterSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxReplicationLag != nil {
+		in, out := &in.MaxReplicationLag, &out.MaxReplicationLag
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSpec.
+func (in *ReplicationSpec) DeepCopy() *ReplicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationStatus) DeepCopyInto(out *ReplicationStatus) {
+	*out = *in
+	if in.RemoteClusters != nil {
+		in, out := &in.RemoteClusters, &out.RemoteClusters
+		*out = make([]RemoteClusterReplicationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationStatus.
+func (in *ReplicationStatus) DeepCopy() *ReplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceAdvisorSpec) DeepCopyInto(out *ResourceAdvisorSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Margin != nil {
+		in, out := &in.Margin, &out.Margin
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceAdvisorSpec.
+func (in *ResourceAdvisorSpec) DeepCopy() *ResourceAdvisorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceAdvisorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendation) DeepCopyInto(out *ResourceRecommendation) {
+	*out = *in
+	out.PeakCPU = in.PeakCPU.DeepCopy()
+	out.PeakMemory = in.PeakMemory.DeepCopy()
+	out.CPU = in.CPU.DeepCopy()
+	out.Memory = in.Memory.DeepCopy()
+	in.WindowStart.DeepCopyInto(&out.WindowStart)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendation.
+func (in *ResourceRecommendation) DeepCopy() *ResourceRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.InitialInterval != nil {
+		in, out := &in.InitialInterval, &out.InitialInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BackoffCoefficient != nil {
+		in, out := &in.BackoffCoefficient, &out.BackoffCoefficient
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaximumInterval != nil {
+		in, out := &in.MaximumInterval, &out.MaximumInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NonRetryableErrorTypes != nil {
+		in, out := &in.NonRetryableErrorTypes, &out.NonRetryableErrorTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutNotificationsSpec) DeepCopyInto(out *RolloutNotificationsSpec) {
+	*out = *in
+	if in.WebhookURLSecretRef != nil {
+		in, out := &in.WebhookURLSecretRef, &out.WebhookURLSecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.Alertmanager != nil {
+		in, out := &in.Alertmanager, &out.Alertmanager
+		*out = new(AlertmanagerSilenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutNotificationsSpec.
+func (in *RolloutNotificationsSpec) DeepCopy() *RolloutNotificationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutNotificationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutPolicySpec) DeepCopyInto(out *RolloutPolicySpec) {
+	*out = *in
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(RolloutNotificationsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceOrder != nil {
+		in, out := &in.ServiceOrder, &out.ServiceOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPolicySpec.
+func (in *RolloutPolicySpec) DeepCopy() *RolloutPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Archiver) DeepCopyInto(out *S3Archiver) {
+	*out = *in
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.RoleName != nil {
+		in, out := &in.RoleName, &out.RoleName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(S3Credentials)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Archiver.
+func (in *S3Archiver) DeepCopy() *S3Archiver {
+	if in == nil {
+		return nil
+	}
+	out := new(S3Archiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Credentials) DeepCopyInto(out *S3Credentials) {
+	*out = *in
+	if in.AccessKeyIDRef != nil {
+		in, out := &in.AccessKeyIDRef, &out.AccessKeyIDRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretAccessKeyRef != nil {
+		in, out := &in.SecretAccessKeyRef, &out.SecretAccessKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Credentials.
+func (in *S3Credentials) DeepCopy() *S3Credentials {
+	if in == nil {
+		return nil
+	}
+	out := new(S3Credentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLSpec) DeepCopyInto(out *SQLSpec) {
+	*out = *in
+	if in.ConnectAttributes != nil {
+		in, out := &in.ConnectAttributes, &out.ConnectAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.MaxConnLifetime = in.MaxConnLifetime
+	if in.GCPServiceAccount != nil {
+		in, out := &in.GCPServiceAccount, &out.GCPServiceAccount
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLSpec.
+func (in *SQLSpec) DeepCopy() *SQLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Schedule) DeepCopyInto(out *Schedule) {
+	*out = *in
+	in.Action.DeepCopyInto(&out.Action)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(SchedulePolicies)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(ScheduleState)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Schedule.
+func (in *Schedule) DeepCopy() *Schedule {
+	if in == nil {
+		return nil
+	}
+	out := new(Schedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleAction) DeepCopyInto(out *ScheduleAction) {
+	*out = *in
+	in.Workflow.DeepCopyInto(&out.Workflow)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleAction.
+func (in *ScheduleAction) DeepCopy() *ScheduleAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleCalendarSpec) DeepCopyInto(out *ScheduleCalendarSpec) {
+	*out = *in
+	if in.Second != nil {
+		in, out := &in.Second, &out.Second
+		*out = make([]ScheduleSecondMinuteRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Minute != nil {
+		in, out := &in.Minute, &out.Minute
+		*out = make([]ScheduleSecondMinuteRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hour != nil {
+		in, out := &in.Hour, &out.Hour
+		*out = make([]ScheduleHourRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.DayOfMonth != nil {
+		in, out := &in.DayOfMonth, &out.DayOfMonth
+		*out = make([]ScheduleDayOfMonthRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Month != nil {
+		in, out := &in.Month, &out.Month
 		*out = make([]ScheduleMonthRange, len(*in))
 		copy(*out, *in)
 	}
-	if in.Year != nil {
-		in, out := &in.Year, &out.Year
-		*out = make([]ScheduleYearRange, len(*in))
+	if in.Year != nil {
+		in, out := &in.Year, &out.Year
+		*out = make([]ScheduleYearRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.DayOfWeek != nil {
+		in, out := &in.DayOfWeek, &out.DayOfWeek
+		*out = make([]ScheduleDayOfWeekRange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleCalendarSpec.
+func (in *ScheduleCalendarSpec) DeepCopy() *ScheduleCalendarSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleCalendarSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleDayOfMonthRange) DeepCopyInto(out *ScheduleDayOfMonthRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleDayOfMonthRange.
+func (in *ScheduleDayOfMonthRange) DeepCopy() *ScheduleDayOfMonthRange {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleDayOfMonthRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleDayOfWeekRange) DeepCopyInto(out *ScheduleDayOfWeekRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleDayOfWeekRange.
+func (in *ScheduleDayOfWeekRange) DeepCopy() *ScheduleDayOfWeekRange {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleDayOfWeekRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleHourRange) DeepCopyInto(out *ScheduleHourRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleHourRange.
+func (in *ScheduleHourRange) DeepCopy() *ScheduleHourRange {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleHourRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleIntervalSpec) DeepCopyInto(out *ScheduleIntervalSpec) {
+	*out = *in
+	out.Every = in.Every
+	if in.Offset != nil {
+		in, out := &in.Offset, &out.Offset
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleIntervalSpec.
+func (in *ScheduleIntervalSpec) DeepCopy() *ScheduleIntervalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleIntervalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleMonthRange) DeepCopyInto(out *ScheduleMonthRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleMonthRange.
+func (in *ScheduleMonthRange) DeepCopy() *ScheduleMonthRange {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleMonthRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulePolicies) DeepCopyInto(out *SchedulePolicies) {
+	*out = *in
+	if in.CatchupWindow != nil {
+		in, out := &in.CatchupWindow, &out.CatchupWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulePolicies.
+func (in *SchedulePolicies) DeepCopy() *SchedulePolicies {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulePolicies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleSecondMinuteRange) DeepCopyInto(out *ScheduleSecondMinuteRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleSecondMinuteRange.
+func (in *ScheduleSecondMinuteRange) DeepCopy() *ScheduleSecondMinuteRange {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleSecondMinuteRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleSpec) DeepCopyInto(out *ScheduleSpec) {
+	*out = *in
+	if in.Calendars != nil {
+		in, out := &in.Calendars, &out.Calendars
+		*out = make([]ScheduleCalendarSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Intervals != nil {
+		in, out := &in.Intervals, &out.Intervals
+		*out = make([]ScheduleIntervalSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Crons != nil {
+		in, out := &in.Crons, &out.Crons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeCalendars != nil {
+		in, out := &in.ExcludeCalendars, &out.ExcludeCalendars
+		*out = make([]ScheduleCalendarSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartAt != nil {
+		in, out := &in.StartAt, &out.StartAt
+		*out = (*in).DeepCopy()
+	}
+	if in.EndAt != nil {
+		in, out := &in.EndAt, &out.EndAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleSpec.
+func (in *ScheduleSpec) DeepCopy() *ScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleState) DeepCopyInto(out *ScheduleState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleState.
+func (in *ScheduleState) DeepCopy() *ScheduleState {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleWorkflowAction) DeepCopyInto(out *ScheduleWorkflowAction) {
+	*out = *in
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkflowExecutionTimeout != nil {
+		in, out := &in.WorkflowExecutionTimeout, &out.WorkflowExecutionTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.WorkflowRunTimeout != nil {
+		in, out := &in.WorkflowRunTimeout, &out.WorkflowRunTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.WorkflowTaskTimeout != nil {
+		in, out := &in.WorkflowTaskTimeout, &out.WorkflowTaskTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Memo != nil {
+		in, out := &in.Memo, &out.Memo
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SearchAttributes != nil {
+		in, out := &in.SearchAttributes, &out.SearchAttributes
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleWorkflowAction.
+func (in *ScheduleWorkflowAction) DeepCopy() *ScheduleWorkflowAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleWorkflowAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleYearRange) DeepCopyInto(out *ScheduleYearRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleYearRange.
+func (in *ScheduleYearRange) DeepCopy() *ScheduleYearRange {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleYearRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretDecryptionSpec) DeepCopyInto(out *SecretDecryptionSpec) {
+	*out = *in
+	if in.KeyRef != nil {
+		in, out := &in.KeyRef, &out.KeyRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretDecryptionSpec.
+func (in *SecretDecryptionSpec) DeepCopy() *SecretDecryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretDecryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePersistenceRateLimitsSpec) DeepCopyInto(out *ServicePersistenceRateLimitsSpec) {
+	*out = *in
+	if in.MaxQPS != nil {
+		in, out := &in.MaxQPS, &out.MaxQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GlobalMaxQPS != nil {
+		in, out := &in.GlobalMaxQPS, &out.GlobalMaxQPS
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePersistenceRateLimitsSpec.
+func (in *ServicePersistenceRateLimitsSpec) DeepCopy() *ServicePersistenceRateLimitsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePersistenceRateLimitsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int)
+		**out = **in
+	}
+	if in.MembershipPort != nil {
+		in, out := &in.MembershipPort, &out.MembershipPort
+		*out = new(int)
+		**out = **in
+	}
+	if in.HTTPPort != nil {
+		in, out := &in.HTTPPort, &out.HTTPPort
+		*out = new(int)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(ServiceSpecOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GracefulShutdown != nil {
+		in, out := &in.GracefulShutdown, &out.GracefulShutdown
+		*out = new(GracefulShutdownSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(appsv1.DeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaler != nil {
+		in, out := &in.Autoscaler, &out.Autoscaler
+		*out = new(AutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryProtection != nil {
+		in, out := &in.MemoryProtection, &out.MemoryProtection
+		*out = new(MemoryProtectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Traffic != nil {
+		in, out := &in.Traffic, &out.Traffic
+		*out = new(ServiceTrafficSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(FrontendProxySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostStart != nil {
+		in, out := &in.PostStart, &out.PostStart
+		*out = new(corev1.LifecycleHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Warmup != nil {
+		in, out := &in.Warmup, &out.Warmup
+		*out = new(WarmupSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
+func (in *ServiceSpec) DeepCopy() *ServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceSpecOverride) DeepCopyInto(out *ServiceSpecOverride) {
+	*out = *in
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(DeploymentOverride)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpecOverride.
+func (in *ServiceSpecOverride) DeepCopy() *ServiceSpecOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceSpecOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceStatus) DeepCopyInto(out *ServiceStatus) {
+	*out = *in
+	if in.RestartedAt != nil {
+		in, out := &in.RestartedAt, &out.RestartedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceStatus.
+func (in *ServiceStatus) DeepCopy() *ServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceTrafficSpec) DeepCopyInto(out *ServiceTrafficSpec) {
+	*out = *in
+	if in.ReadinessGateDelay != nil {
+		in, out := &in.ReadinessGateDelay, &out.ReadinessGateDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceTrafficSpec.
+func (in *ServiceTrafficSpec) DeepCopy() *ServiceTrafficSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceTrafficSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicesSpec) DeepCopyInto(out *ServicesSpec) {
+	*out = *in
+	if in.Frontend != nil {
+		in, out := &in.Frontend, &out.Frontend
+		*out = new(ServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InternalFrontend != nil {
+		in, out := &in.InternalFrontend, &out.InternalFrontend
+		*out = new(InternalFrontendServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(ServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Matching != nil {
+		in, out := &in.Matching, &out.Matching
+		*out = new(ServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Worker != nil {
+		in, out := &in.Worker, &out.Worker
+		*out = new(ServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(ServiceSpecOverride)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicesSpec.
+func (in *ServicesSpec) DeepCopy() *ServicesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardDiagnosticsStatus) DeepCopyInto(out *ShardDiagnosticsStatus) {
+	*out = *in
+	out.ReportRef = in.ReportRef
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardDiagnosticsStatus.
+func (in *ShardDiagnosticsStatus) DeepCopy() *ShardDiagnosticsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardDiagnosticsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SizeLimitSpec) DeepCopyInto(out *SizeLimitSpec) {
+	*out = *in
+	if in.Warn != nil {
+		in, out := &in.Warn, &out.Warn
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Error != nil {
+		in, out := &in.Error, &out.Error
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SizeLimitSpec.
+func (in *SizeLimitSpec) DeepCopy() *SizeLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SizeLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmokeTestSpec) DeepCopyInto(out *SmokeTestSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmokeTestSpec.
+func (in *SmokeTestSpec) DeepCopy() *SmokeTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SmokeTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmokeTestStatus) DeepCopyInto(out *SmokeTestStatus) {
+	*out = *in
+	in.LastRunTime.DeepCopyInto(&out.LastRunTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmokeTestStatus.
+func (in *SmokeTestStatus) DeepCopy() *SmokeTestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SmokeTestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskQueueConfigSpec) DeepCopyInto(out *TaskQueueConfigSpec) {
+	*out = *in
+	if in.ReadPartitions != nil {
+		in, out := &in.ReadPartitions, &out.ReadPartitions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WritePartitions != nil {
+		in, out := &in.WritePartitions, &out.WritePartitions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ForwarderMaxRatePerSecond != nil {
+		in, out := &in.ForwarderMaxRatePerSecond, &out.ForwarderMaxRatePerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LongPollExpirationInterval != nil {
+		in, out := &in.LongPollExpirationInterval, &out.LongPollExpirationInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskQueueConfigSpec.
+func (in *TaskQueueConfigSpec) DeepCopy() *TaskQueueConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskQueueConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskQueueWorkloadStatus) DeepCopyInto(out *TaskQueueWorkloadStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskQueueWorkloadStatus.
+func (in *TaskQueueWorkloadStatus) DeepCopy() *TaskQueueWorkloadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskQueueWorkloadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskSchedulerRateLimitsSpec) DeepCopyInto(out *TaskSchedulerRateLimitsSpec) {
+	*out = *in
+	if in.MaxQPS != nil {
+		in, out := &in.MaxQPS, &out.MaxQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GlobalMaxQPS != nil {
+		in, out := &in.GlobalMaxQPS, &out.GlobalMaxQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NamespaceMaxQPS != nil {
+		in, out := &in.NamespaceMaxQPS, &out.NamespaceMaxQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GlobalNamespaceMaxQPS != nil {
+		in, out := &in.GlobalNamespaceMaxQPS, &out.GlobalNamespaceMaxQPS
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskSchedulerRateLimitsSpec.
+func (in *TaskSchedulerRateLimitsSpec) DeepCopy() *TaskSchedulerRateLimitsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskSchedulerRateLimitsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalAccessPolicy) DeepCopyInto(out *TemporalAccessPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalAccessPolicy.
+func (in *TemporalAccessPolicy) DeepCopy() *TemporalAccessPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalAccessPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalAccessPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalAccessPolicyList) DeepCopyInto(out *TemporalAccessPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalAccessPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalAccessPolicyList.
+func (in *TemporalAccessPolicyList) DeepCopy() *TemporalAccessPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalAccessPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalAccessPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalAccessPolicySpec) DeepCopyInto(out *TemporalAccessPolicySpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]AccessPolicySubject, len(*in))
+		copy(*out, *in)
+	}
+	if in.SystemRoles != nil {
+		in, out := &in.SystemRoles, &out.SystemRoles
+		*out = make([]TemporalRole, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]CertificateClaimMapperNamespaceRoles, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalAccessPolicySpec.
+func (in *TemporalAccessPolicySpec) DeepCopy() *TemporalAccessPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalAccessPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalAdminToolsSpec) DeepCopyInto(out *TemporalAdminToolsSpec) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(ServiceSpecOverride)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalAdminToolsSpec.
+func (in *TemporalAdminToolsSpec) DeepCopy() *TemporalAdminToolsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalAdminToolsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalBenchmark) DeepCopyInto(out *TemporalBenchmark) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalBenchmark.
+func (in *TemporalBenchmark) DeepCopy() *TemporalBenchmark {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalBenchmark)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalBenchmark) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalBenchmarkList) DeepCopyInto(out *TemporalBenchmarkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalBenchmark, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalBenchmarkList.
+func (in *TemporalBenchmarkList) DeepCopy() *TemporalBenchmarkList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalBenchmarkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalBenchmarkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalBenchmarkSpec) DeepCopyInto(out *TemporalBenchmarkSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	if in.Iterations != nil {
+		in, out := &in.Iterations, &out.Iterations
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalBenchmarkSpec.
+func (in *TemporalBenchmarkSpec) DeepCopy() *TemporalBenchmarkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalBenchmarkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalBenchmarkStatus) DeepCopyInto(out *TemporalBenchmarkStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(TemporalBenchmarkSummary)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalBenchmarkStatus.
+func (in *TemporalBenchmarkStatus) DeepCopy() *TemporalBenchmarkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalBenchmarkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalBenchmarkSummary) DeepCopyInto(out *TemporalBenchmarkSummary) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.CompletedWorkflows != nil {
+		in, out := &in.CompletedWorkflows, &out.CompletedWorkflows
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FailedWorkflows != nil {
+		in, out := &in.FailedWorkflows, &out.FailedWorkflows
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalBenchmarkSummary.
+func (in *TemporalBenchmarkSummary) DeepCopy() *TemporalBenchmarkSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalBenchmarkSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalCluster) DeepCopyInto(out *TemporalCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalCluster.
+func (in *TemporalCluster) DeepCopy() *TemporalCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterClient) DeepCopyInto(out *TemporalClusterClient) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClient.
+func (in *TemporalClusterClient) DeepCopy() *TemporalClusterClient {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterClient)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalClusterClient) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterClientList) DeepCopyInto(out *TemporalClusterClientList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalClusterClient, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClientList.
+func (in *TemporalClusterClientList) DeepCopy() *TemporalClusterClientList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterClientList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalClusterClientList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterClientSpec) DeepCopyInto(out *TemporalClusterClientSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]CertificateClaimMapperNamespaceRoles, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClientSpec.
+func (in *TemporalClusterClientSpec) DeepCopy() *TemporalClusterClientSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterClientSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterClientStatus) DeepCopyInto(out *TemporalClusterClientStatus) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = new(ClusterClientServerStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastValidationTime != nil {
+		in, out := &in.LastValidationTime, &out.LastValidationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClientStatus.
+func (in *TemporalClusterClientStatus) DeepCopy() *TemporalClusterClientStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterClientStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterClone) DeepCopyInto(out *TemporalClusterClone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClone.
+func (in *TemporalClusterClone) DeepCopy() *TemporalClusterClone {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterClone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalClusterClone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterCloneList) DeepCopyInto(out *TemporalClusterCloneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalClusterClone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterCloneList.
+func (in *TemporalClusterCloneList) DeepCopy() *TemporalClusterCloneList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterCloneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalClusterCloneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterCloneSpec) DeepCopyInto(out *TemporalClusterCloneSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(TemporalClusterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterCloneSpec.
+func (in *TemporalClusterCloneSpec) DeepCopy() *TemporalClusterCloneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterCloneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterCloneStatus) DeepCopyInto(out *TemporalClusterCloneStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterCloneStatus.
+func (in *TemporalClusterCloneStatus) DeepCopy() *TemporalClusterCloneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterCloneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterList) DeepCopyInto(out *TemporalClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterList.
+func (in *TemporalClusterList) DeepCopy() *TemporalClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterOverride) DeepCopyInto(out *TemporalClusterOverride) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterOverride.
+func (in *TemporalClusterOverride) DeepCopy() *TemporalClusterOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalClusterOverride) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterOverrideList) DeepCopyInto(out *TemporalClusterOverrideList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalClusterOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterOverrideList.
+func (in *TemporalClusterOverrideList) DeepCopy() *TemporalClusterOverrideList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterOverrideList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalClusterOverrideList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterOverrideSpec) DeepCopyInto(out *TemporalClusterOverrideSpec) {
+	*out = *in
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	in.Patch.DeepCopyInto(&out.Patch)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterOverrideSpec.
+func (in *TemporalClusterOverrideSpec) DeepCopy() *TemporalClusterOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterSpec) DeepCopyInto(out *TemporalClusterSpec) {
+	*out = *in
+	if in.Edition != nil {
+		in, out := &in.Edition, &out.Edition
+		*out = new(EditionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(PodSecuritySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageVerification != nil {
+		in, out := &in.ImageVerification, &out.ImageVerification
+		*out = new(ImageVerificationSpec)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(version.Version)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Log != nil {
+		in, out := &in.Log, &out.Log
+		*out = new(LogSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobTTLSecondsAfterFinished != nil {
+		in, out := &in.JobTTLSecondsAfterFinished, &out.JobTTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	in.JobResources.DeepCopyInto(&out.JobResources)
+	if in.JobInitContainers != nil {
+		in, out := &in.JobInitContainers, &out.JobInitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.JobActiveDeadlineSeconds != nil {
+		in, out := &in.JobActiveDeadlineSeconds, &out.JobActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.JobBackoffLimit != nil {
+		in, out := &in.JobBackoffLimit, &out.JobBackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.JobNodeSelector != nil {
+		in, out := &in.JobNodeSelector, &out.JobNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.JobTolerations != nil {
+		in, out := &in.JobTolerations, &out.JobTolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.JobAffinity != nil {
+		in, out := &in.JobAffinity, &out.JobAffinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobHistoryLimits != nil {
+		in, out := &in.JobHistoryLimits, &out.JobHistoryLimits
+		*out = new(JobHistoryLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterMetadata != nil {
+		in, out := &in.ClusterMetadata, &out.ClusterMetadata
+		*out = new(ClusterMetadataSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = new(ServicesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Persistence.DeepCopyInto(&out.Persistence)
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.UI != nil {
+		in, out := &in.UI, &out.UI
+		*out = new(TemporalUISpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminTools != nil {
+		in, out := &in.AdminTools, &out.AdminTools
+		*out = new(TemporalAdminToolsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPCWeb != nil {
+		in, out := &in.GRPCWeb, &out.GRPCWeb
+		*out = new(GRPCWebSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MTLS != nil {
+		in, out := &in.MTLS, &out.MTLS
+		*out = new(MTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(MetricsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DynamicConfig != nil {
+		in, out := &in.DynamicConfig, &out.DynamicConfig
+		*out = new(DynamicConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]ServerFeature, len(*in))
 		copy(*out, *in)
 	}
-	if in.DayOfWeek != nil {
-		in, out := &in.DayOfWeek, &out.DayOfWeek
-		*out = make([]ScheduleDayOfWeekRange, len(*in))
+	if in.ExternalConfig != nil {
+		in, out := &in.ExternalConfig, &out.ExternalConfig
+		*out = new(ExternalConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Archival != nil {
+		in, out := &in.Archival, &out.Archival
+		*out = new(ClusterArchivalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(AuthorizationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(ExposeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = new(CatalogSpec)
+		**out = **in
+	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(UpgradeStrategySpec)
+		**out = **in
+	}
+	if in.SmokeTest != nil {
+		in, out := &in.SmokeTest, &out.SmokeTest
+		*out = new(SmokeTestSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanarySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(ReplicationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolloutPolicy != nil {
+		in, out := &in.RolloutPolicy, &out.RolloutPolicy
+		*out = new(RolloutPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadMonitoring != nil {
+		in, out := &in.WorkloadMonitoring, &out.WorkloadMonitoring
+		*out = new(WorkloadMonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CrashDump != nil {
+		in, out := &in.CrashDump, &out.CrashDump
+		*out = new(CrashDumpSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceAdvisor != nil {
+		in, out := &in.ResourceAdvisor, &out.ResourceAdvisor
+		*out = new(ResourceAdvisorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(TemporalClusterTemplateReference)
+		**out = **in
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterSpec.
+func (in *TemporalClusterSpec) DeepCopy() *TemporalClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterStatus) DeepCopyInto(out *TemporalClusterStatus) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]ServiceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(TemporalPersistenceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SmokeTest != nil {
+		in, out := &in.SmokeTest, &out.SmokeTest
+		*out = new(SmokeTestStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(BlueGreenStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(ReplicationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterInfo != nil {
+		in, out := &in.ClusterInfo, &out.ClusterInfo
+		*out = new(ClusterInfoStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShardDiagnostics != nil {
+		in, out := &in.ShardDiagnostics, &out.ShardDiagnostics
+		*out = new(ShardDiagnosticsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CrashDumps != nil {
+		in, out := &in.CrashDumps, &out.CrashDumps
+		*out = make([]CrashDumpStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DynamicConfig != nil {
+		in, out := &in.DynamicConfig, &out.DynamicConfig
+		*out = new(DynamicConfigStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageDigests != nil {
+		in, out := &in.ImageDigests, &out.ImageDigests
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VerifiedImages != nil {
+		in, out := &in.VerifiedImages, &out.VerifiedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Recommendations != nil {
+		in, out := &in.Recommendations, &out.Recommendations
+		*out = new(RecommendationsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = new(CatalogStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AppliedOverrides != nil {
+		in, out := &in.AppliedOverrides, &out.AppliedOverrides
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = make([]InventoryEntry, len(*in))
 		copy(*out, *in)
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ManagedResourceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.ElasticsearchMappings != nil {
+		in, out := &in.ElasticsearchMappings, &out.ElasticsearchMappings
+		*out = make([]ElasticsearchMappingStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterStatus.
+func (in *TemporalClusterStatus) DeepCopy() *TemporalClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterTemplate) DeepCopyInto(out *TemporalClusterTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterTemplate.
+func (in *TemporalClusterTemplate) DeepCopy() *TemporalClusterTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalClusterTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterTemplateList) DeepCopyInto(out *TemporalClusterTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalClusterTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterTemplateList.
+func (in *TemporalClusterTemplateList) DeepCopy() *TemporalClusterTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalClusterTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterTemplateReference) DeepCopyInto(out *TemporalClusterTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterTemplateReference.
+func (in *TemporalClusterTemplateReference) DeepCopy() *TemporalClusterTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalClusterTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalClusterTemplateSize) DeepCopyInto(out *TemporalClusterTemplateSize) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = new(ServicesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleCalendarSpec.
-func (in *ScheduleCalendarSpec) DeepCopy() *ScheduleCalendarSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterTemplateSize.
+func (in *TemporalClusterTemplateSize) DeepCopy() *TemporalClusterTemplateSize {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduleCalendarSpec)
+	out := new(TemporalClusterTemplateSize)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduleDayOfMonthRange) DeepCopyInto(out *ScheduleDayOfMonthRange) {
+func (in *TemporalClusterTemplateSpec) DeepCopyInto(out *TemporalClusterTemplateSpec) {
 	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Sizes != nil {
+		in, out := &in.Sizes, &out.Sizes
+		*out = make([]TemporalClusterTemplateSize, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleDayOfMonthRange.
-func (in *ScheduleDayOfMonthRange) DeepCopy() *ScheduleDayOfMonthRange {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterTemplateSpec.
+func (in *TemporalClusterTemplateSpec) DeepCopy() *TemporalClusterTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduleDayOfMonthRange)
+	out := new(TemporalClusterTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduleDayOfWeekRange) DeepCopyInto(out *ScheduleDayOfWeekRange) {
+func (in *TemporalFleetReport) DeepCopyInto(out *TemporalFleetReport) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleDayOfWeekRange.
-func (in *ScheduleDayOfWeekRange) DeepCopy() *ScheduleDayOfWeekRange {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalFleetReport.
+func (in *TemporalFleetReport) DeepCopy() *TemporalFleetReport {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduleDayOfWeekRange)
+	out := new(TemporalFleetReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalFleetReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduleHourRange) DeepCopyInto(out *ScheduleHourRange) {
+func (in *TemporalFleetReportList) DeepCopyInto(out *TemporalFleetReportList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalFleetReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleHourRange.
-func (in *ScheduleHourRange) DeepCopy() *ScheduleHourRange {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalFleetReportList.
+func (in *TemporalFleetReportList) DeepCopy() *TemporalFleetReportList {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduleHourRange)
+	out := new(TemporalFleetReportList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalFleetReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduleIntervalSpec) DeepCopyInto(out *ScheduleIntervalSpec) {
+func (in *TemporalFleetReportSpec) DeepCopyInto(out *TemporalFleetReportSpec) {
 	*out = *in
-	out.Every = in.Every
-	if in.Offset != nil {
-		in, out := &in.Offset, &out.Offset
-		*out = new(metav1.Duration)
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificateExpiryThreshold != nil {
+		in, out := &in.CertificateExpiryThreshold, &out.CertificateExpiryThreshold
+		*out = new(v1.Duration)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleIntervalSpec.
-func (in *ScheduleIntervalSpec) DeepCopy() *ScheduleIntervalSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalFleetReportSpec.
+func (in *TemporalFleetReportSpec) DeepCopy() *TemporalFleetReportSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduleIntervalSpec)
+	out := new(TemporalFleetReportSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduleMonthRange) DeepCopyInto(out *ScheduleMonthRange) {
+func (in *TemporalFleetReportStatus) DeepCopyInto(out *TemporalFleetReportStatus) {
 	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]FleetVersionCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnreadyClusters != nil {
+		in, out := &in.UnreadyClusters, &out.UnreadyClusters
+		*out = make([]FleetClusterReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpiringCertificates != nil {
+		in, out := &in.ExpiringCertificates, &out.ExpiringCertificates
+		*out = make([]FleetExpiringCertificate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleMonthRange.
-func (in *ScheduleMonthRange) DeepCopy() *ScheduleMonthRange {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalFleetReportStatus.
+func (in *TemporalFleetReportStatus) DeepCopy() *TemporalFleetReportStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduleMonthRange)
+	out := new(TemporalFleetReportStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchedulePolicies) DeepCopyInto(out *SchedulePolicies) {
+func (in *TemporalNamespace) DeepCopyInto(out *TemporalNamespace) {
 	*out = *in
-	if in.CatchupWindow != nil {
-		in, out := &in.CatchupWindow, &out.CatchupWindow
-		*out = new(metav1.Duration)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulePolicies.
-func (in *SchedulePolicies) DeepCopy() *SchedulePolicies {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespace.
+func (in *TemporalNamespace) DeepCopy() *TemporalNamespace {
 	if in == nil {
 		return nil
 	}
-	out := new(SchedulePolicies)
+	out := new(TemporalNamespace)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalNamespace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduleSecondMinuteRange) DeepCopyInto(out *ScheduleSecondMinuteRange) {
+func (in *TemporalNamespaceArchivalSpec) DeepCopyInto(out *TemporalNamespaceArchivalSpec) {
 	*out = *in
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(ArchivalSpec)
+		**out = **in
+	}
+	if in.Visibility != nil {
+		in, out := &in.Visibility, &out.Visibility
+		*out = new(ArchivalSpec)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleSecondMinuteRange.
-func (in *ScheduleSecondMinuteRange) DeepCopy() *ScheduleSecondMinuteRange {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceArchivalSpec.
+func (in *TemporalNamespaceArchivalSpec) DeepCopy() *TemporalNamespaceArchivalSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduleSecondMinuteRange)
+	out := new(TemporalNamespaceArchivalSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduleSpec) DeepCopyInto(out *ScheduleSpec) {
+func (in *TemporalNamespaceLimitsSpec) DeepCopyInto(out *TemporalNamespaceLimitsSpec) {
 	*out = *in
-	if in.Calendars != nil {
-		in, out := &in.Calendars, &out.Calendars
-		*out = make([]ScheduleCalendarSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.DefaultWorkflowTaskTimeout != nil {
+		in, out := &in.DefaultWorkflowTaskTimeout, &out.DefaultWorkflowTaskTimeout
+		*out = new(v1.Duration)
+		**out = **in
 	}
-	if in.Intervals != nil {
-		in, out := &in.Intervals, &out.Intervals
-		*out = make([]ScheduleIntervalSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.BlobSize != nil {
+		in, out := &in.BlobSize, &out.BlobSize
+		*out = new(SizeLimitSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Crons != nil {
-		in, out := &in.Crons, &out.Crons
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MemoSize != nil {
+		in, out := &in.MemoSize, &out.MemoSize
+		*out = new(SizeLimitSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ExcludeCalendars != nil {
-		in, out := &in.ExcludeCalendars, &out.ExcludeCalendars
-		*out = make([]ScheduleCalendarSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.HistorySize != nil {
+		in, out := &in.HistorySize, &out.HistorySize
+		*out = new(SizeLimitSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.StartAt != nil {
-		in, out := &in.StartAt, &out.StartAt
-		*out = (*in).DeepCopy()
+	if in.HistoryCount != nil {
+		in, out := &in.HistoryCount, &out.HistoryCount
+		*out = new(CountLimitSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.EndAt != nil {
-		in, out := &in.EndAt, &out.EndAt
-		*out = (*in).DeepCopy()
+	if in.MaxPendingActivities != nil {
+		in, out := &in.MaxPendingActivities, &out.MaxPendingActivities
+		*out = new(int32)
+		**out = **in
 	}
-	if in.Jitter != nil {
-		in, out := &in.Jitter, &out.Jitter
-		*out = new(metav1.Duration)
+	if in.MaxPendingChildExecutions != nil {
+		in, out := &in.MaxPendingChildExecutions, &out.MaxPendingChildExecutions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPendingSignals != nil {
+		in, out := &in.MaxPendingSignals, &out.MaxPendingSignals
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleSpec.
-func (in *ScheduleSpec) DeepCopy() *ScheduleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceLimitsSpec.
+func (in *TemporalNamespaceLimitsSpec) DeepCopy() *TemporalNamespaceLimitsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduleSpec)
+	out := new(TemporalNamespaceLimitsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduleState) DeepCopyInto(out *ScheduleState) {
+func (in *TemporalNamespaceList) DeepCopyInto(out *TemporalNamespaceList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalNamespace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleState.
-func (in *ScheduleState) DeepCopy() *ScheduleState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceList.
+func (in *TemporalNamespaceList) DeepCopy() *TemporalNamespaceList {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduleState)
+	out := new(TemporalNamespaceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalNamespaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduleWorkflowAction) DeepCopyInto(out *ScheduleWorkflowAction) {
+func (in *TemporalNamespaceMigration) DeepCopyInto(out *TemporalNamespaceMigration) {
 	*out = *in
-	if in.Inputs != nil {
-		in, out := &in.Inputs, &out.Inputs
-		*out = new(apiextensionsv1.JSON)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.WorkflowExecutionTimeout != nil {
-		in, out := &in.WorkflowExecutionTimeout, &out.WorkflowExecutionTimeout
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.WorkflowRunTimeout != nil {
-		in, out := &in.WorkflowRunTimeout, &out.WorkflowRunTimeout
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.WorkflowTaskTimeout != nil {
-		in, out := &in.WorkflowTaskTimeout, &out.WorkflowTaskTimeout
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.RetryPolicy != nil {
-		in, out := &in.RetryPolicy, &out.RetryPolicy
-		*out = new(RetryPolicy)
-		(*in).DeepCopyInto(*out)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceMigration.
+func (in *TemporalNamespaceMigration) DeepCopy() *TemporalNamespaceMigration {
+	if in == nil {
+		return nil
 	}
-	if in.Memo != nil {
-		in, out := &in.Memo, &out.Memo
-		*out = new(apiextensionsv1.JSON)
-		(*in).DeepCopyInto(*out)
+	out := new(TemporalNamespaceMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalNamespaceMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.SearchAttributes != nil {
-		in, out := &in.SearchAttributes, &out.SearchAttributes
-		*out = new(apiextensionsv1.JSON)
-		(*in).DeepCopyInto(*out)
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalNamespaceMigrationList) DeepCopyInto(out *TemporalNamespaceMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalNamespaceMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleWorkflowAction.
-func (in *ScheduleWorkflowAction) DeepCopy() *ScheduleWorkflowAction {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceMigrationList.
+func (in *TemporalNamespaceMigrationList) DeepCopy() *TemporalNamespaceMigrationList {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduleWorkflowAction)
+	out := new(TemporalNamespaceMigrationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalNamespaceMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduleYearRange) DeepCopyInto(out *ScheduleYearRange) {
+func (in *TemporalNamespaceMigrationSpec) DeepCopyInto(out *TemporalNamespaceMigrationSpec) {
 	*out = *in
+	out.NamespaceRef = in.NamespaceRef
+	out.TargetClusterRef = in.TargetClusterRef
+	if in.MaxReplicationLag != nil {
+		in, out := &in.MaxReplicationLag, &out.MaxReplicationLag
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SyncTimeout != nil {
+		in, out := &in.SyncTimeout, &out.SyncTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleYearRange.
-func (in *ScheduleYearRange) DeepCopy() *ScheduleYearRange {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceMigrationSpec.
+func (in *TemporalNamespaceMigrationSpec) DeepCopy() *TemporalNamespaceMigrationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduleYearRange)
+	out := new(TemporalNamespaceMigrationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+func (in *TemporalNamespaceMigrationStatus) DeepCopyInto(out *TemporalNamespaceMigrationStatus) {
 	*out = *in
+	if in.SourceClusterRef != nil {
+		in, out := &in.SourceClusterRef, &out.SourceClusterRef
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	if in.ReplicationLag != nil {
+		in, out := &in.ReplicationLag, &out.ReplicationLag
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PhaseStartTime != nil {
+		in, out := &in.PhaseStartTime, &out.PhaseStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
-func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceMigrationStatus.
+func (in *TemporalNamespaceMigrationStatus) DeepCopy() *TemporalNamespaceMigrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretKeyReference)
+	out := new(TemporalNamespaceMigrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
+func (in *TemporalNamespaceRateLimitsSpec) DeepCopyInto(out *TemporalNamespaceRateLimitsSpec) {
 	*out = *in
-	if in.Port != nil {
-		in, out := &in.Port, &out.Port
-		*out = new(int)
+	if in.FrontendRPS != nil {
+		in, out := &in.FrontendRPS, &out.FrontendRPS
+		*out = new(int32)
 		**out = **in
 	}
-	if in.MembershipPort != nil {
-		in, out := &in.MembershipPort, &out.MembershipPort
-		*out = new(int)
+	if in.FrontendBurst != nil {
+		in, out := &in.FrontendBurst, &out.FrontendBurst
+		*out = new(int32)
 		**out = **in
 	}
-	if in.HTTPPort != nil {
-		in, out := &in.HTTPPort, &out.HTTPPort
-		*out = new(int)
+	if in.GlobalFrontendRPS != nil {
+		in, out := &in.GlobalFrontendRPS, &out.GlobalFrontendRPS
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
+	if in.VisibilityRPS != nil {
+		in, out := &in.VisibilityRPS, &out.VisibilityRPS
 		*out = new(int32)
 		**out = **in
 	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Overrides != nil {
-		in, out := &in.Overrides, &out.Overrides
-		*out = new(ServiceSpecOverride)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
-func (in *ServiceSpec) DeepCopy() *ServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceRateLimitsSpec.
+func (in *TemporalNamespaceRateLimitsSpec) DeepCopy() *TemporalNamespaceRateLimitsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceSpec)
+	out := new(TemporalNamespaceRateLimitsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceSpecOverride) DeepCopyInto(out *ServiceSpecOverride) {
+func (in *TemporalNamespaceSpec) DeepCopyInto(out *TemporalNamespaceSpec) {
 	*out = *in
-	if in.Deployment != nil {
-		in, out := &in.Deployment, &out.Deployment
-		*out = new(DeploymentOverride)
+	out.ClusterRef = in.ClusterRef
+	if in.RetentionPeriod != nil {
+		in, out := &in.RetentionPeriod, &out.RetentionPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Archival != nil {
+		in, out := &in.Archival, &out.Archival
+		*out = new(TemporalNamespaceArchivalSpec)
 		(*in).DeepCopyInto(*out)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpecOverride.
-func (in *ServiceSpecOverride) DeepCopy() *ServiceSpecOverride {
-	if in == nil {
-		return nil
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = new(TemporalNamespaceRateLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TaskQueues != nil {
+		in, out := &in.TaskQueues, &out.TaskQueues
+		*out = new(TemporalNamespaceTaskQueuesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(TemporalNamespaceLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SearchAttributeAliases != nil {
+		in, out := &in.SearchAttributeAliases, &out.SearchAttributeAliases
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	out := new(ServiceSpecOverride)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceStatus) DeepCopyInto(out *ServiceStatus) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceStatus.
-func (in *ServiceStatus) DeepCopy() *ServiceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceSpec.
+func (in *TemporalNamespaceSpec) DeepCopy() *TemporalNamespaceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceStatus)
+	out := new(TemporalNamespaceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServicesSpec) DeepCopyInto(out *ServicesSpec) {
+func (in *TemporalNamespaceStatus) DeepCopyInto(out *TemporalNamespaceStatus) {
 	*out = *in
-	if in.Frontend != nil {
-		in, out := &in.Frontend, &out.Frontend
-		*out = new(ServiceSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.InternalFrontend != nil {
-		in, out := &in.InternalFrontend, &out.InternalFrontend
-		*out = new(InternalFrontendServiceSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.History != nil {
-		in, out := &in.History, &out.History
-		*out = new(ServiceSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Matching != nil {
-		in, out := &in.Matching, &out.Matching
-		*out = new(ServiceSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Worker != nil {
-		in, out := &in.Worker, &out.Worker
-		*out = new(ServiceSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Overrides != nil {
-		in, out := &in.Overrides, &out.Overrides
-		*out = new(ServiceSpecOverride)
-		(*in).DeepCopyInto(*out)
+	if in.SearchAttributeAliases != nil {
+		in, out := &in.SearchAttributeAliases, &out.SearchAttributeAliases
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicesSpec.
-func (in *ServicesSpec) DeepCopy() *ServicesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceStatus.
+func (in *TemporalNamespaceStatus) DeepCopy() *TemporalNamespaceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ServicesSpec)
+	out := new(TemporalNamespaceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalAdminToolsSpec) DeepCopyInto(out *TemporalAdminToolsSpec) {
+func (in *TemporalNamespaceTaskQueuesSpec) DeepCopyInto(out *TemporalNamespaceTaskQueuesSpec) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Overrides != nil {
-		in, out := &in.Overrides, &out.Overrides
-		*out = new(ServiceSpecOverride)
-		(*in).DeepCopyInto(*out)
+	if in.StickyTTL != nil {
+		in, out := &in.StickyTTL, &out.StickyTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Queues != nil {
+		in, out := &in.Queues, &out.Queues
+		*out = make([]TaskQueueConfigSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalAdminToolsSpec.
-func (in *TemporalAdminToolsSpec) DeepCopy() *TemporalAdminToolsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceTaskQueuesSpec.
+func (in *TemporalNamespaceTaskQueuesSpec) DeepCopy() *TemporalNamespaceTaskQueuesSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalAdminToolsSpec)
+	out := new(TemporalNamespaceTaskQueuesSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalCluster) DeepCopyInto(out *TemporalCluster) {
+func (in *TemporalOperatorConfig) DeepCopyInto(out *TemporalOperatorConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1471,18 +5047,18 @@ func (in *TemporalCluster) DeepCopyInto(out *TemporalCluster) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalCluster.
-func (in *TemporalCluster) DeepCopy() *TemporalCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalOperatorConfig.
+func (in *TemporalOperatorConfig) DeepCopy() *TemporalOperatorConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalCluster)
+	out := new(TemporalOperatorConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalCluster) DeepCopyObject() runtime.Object {
+func (in *TemporalOperatorConfig) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1490,26 +5066,31 @@ func (in *TemporalCluster) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterClient) DeepCopyInto(out *TemporalClusterClient) {
+func (in *TemporalOperatorConfigList) DeepCopyInto(out *TemporalOperatorConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemporalOperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClient.
-func (in *TemporalClusterClient) DeepCopy() *TemporalClusterClient {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalOperatorConfigList.
+func (in *TemporalOperatorConfigList) DeepCopy() *TemporalOperatorConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterClient)
+	out := new(TemporalOperatorConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalClusterClient) DeepCopyObject() runtime.Object {
+func (in *TemporalOperatorConfigList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1517,99 +5098,209 @@ func (in *TemporalClusterClient) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterClientList) DeepCopyInto(out *TemporalClusterClientList) {
+func (in *TemporalOperatorConfigSpec) DeepCopyInto(out *TemporalOperatorConfigSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]TemporalClusterClient, len(*in))
+	if in.WatchNamespaces != nil {
+		in, out := &in.WatchNamespaces, &out.WatchNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(OperatorConcurrencySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(OperatorDefaultsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(OperatorNotificationsSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalOperatorConfigSpec.
+func (in *TemporalOperatorConfigSpec) DeepCopy() *TemporalOperatorConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalOperatorConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalOperatorConfigStatus) DeepCopyInto(out *TemporalOperatorConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClientList.
-func (in *TemporalClusterClientList) DeepCopy() *TemporalClusterClientList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalOperatorConfigStatus.
+func (in *TemporalOperatorConfigStatus) DeepCopy() *TemporalOperatorConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemporalOperatorConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalPersistenceSpec) DeepCopyInto(out *TemporalPersistenceSpec) {
+	*out = *in
+	if in.DefaultStore != nil {
+		in, out := &in.DefaultStore, &out.DefaultStore
+		*out = new(DatastoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VisibilityStore != nil {
+		in, out := &in.VisibilityStore, &out.VisibilityStore
+		*out = new(DatastoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecondaryVisibilityStore != nil {
+		in, out := &in.SecondaryVisibilityStore, &out.SecondaryVisibilityStore
+		*out = new(DatastoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdvancedVisibilityStore != nil {
+		in, out := &in.AdvancedVisibilityStore, &out.AdvancedVisibilityStore
+		*out = new(DatastoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretDecryption != nil {
+		in, out := &in.SecretDecryption, &out.SecretDecryption
+		*out = new(SecretDecryptionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = new(PersistenceRateLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(PersistenceHooksSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalPersistenceSpec.
+func (in *TemporalPersistenceSpec) DeepCopy() *TemporalPersistenceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterClientList)
+	out := new(TemporalPersistenceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalClusterClientList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterClientSpec) DeepCopyInto(out *TemporalClusterClientSpec) {
+func (in *TemporalPersistenceStatus) DeepCopyInto(out *TemporalPersistenceStatus) {
 	*out = *in
-	out.ClusterRef = in.ClusterRef
+	if in.DefaultStore != nil {
+		in, out := &in.DefaultStore, &out.DefaultStore
+		*out = new(DatastoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VisibilityStore != nil {
+		in, out := &in.VisibilityStore, &out.VisibilityStore
+		*out = new(DatastoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecondaryVisibilityStore != nil {
+		in, out := &in.SecondaryVisibilityStore, &out.SecondaryVisibilityStore
+		*out = new(DatastoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdvancedVisibilityStore != nil {
+		in, out := &in.AdvancedVisibilityStore, &out.AdvancedVisibilityStore
+		*out = new(DatastoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MigrationProgress != nil {
+		in, out := &in.MigrationProgress, &out.MigrationProgress
+		*out = new(MigrationProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClientSpec.
-func (in *TemporalClusterClientSpec) DeepCopy() *TemporalClusterClientSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalPersistenceStatus.
+func (in *TemporalPersistenceStatus) DeepCopy() *TemporalPersistenceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterClientSpec)
+	out := new(TemporalPersistenceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterClientStatus) DeepCopyInto(out *TemporalClusterClientStatus) {
+func (in *TemporalSchedule) DeepCopyInto(out *TemporalSchedule) {
 	*out = *in
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterClientStatus.
-func (in *TemporalClusterClientStatus) DeepCopy() *TemporalClusterClientStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalSchedule.
+func (in *TemporalSchedule) DeepCopy() *TemporalSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterClientStatus)
+	out := new(TemporalSchedule)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemporalSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterList) DeepCopyInto(out *TemporalClusterList) {
+func (in *TemporalScheduleList) DeepCopyInto(out *TemporalScheduleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]TemporalCluster, len(*in))
+		*out = make([]TemporalSchedule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterList.
-func (in *TemporalClusterList) DeepCopy() *TemporalClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalScheduleList.
+func (in *TemporalScheduleList) DeepCopy() *TemporalScheduleList {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterList)
+	out := new(TemporalScheduleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalClusterList) DeepCopyObject() runtime.Object {
+func (in *TemporalScheduleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1617,123 +5308,56 @@ func (in *TemporalClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterSpec) DeepCopyInto(out *TemporalClusterSpec) {
+func (in *TemporalScheduleSpec) DeepCopyInto(out *TemporalScheduleSpec) {
 	*out = *in
-	if in.Version != nil {
-		in, out := &in.Version, &out.Version
-		*out = new(version.Version)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Log != nil {
-		in, out := &in.Log, &out.Log
-		*out = new(LogSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.JobTTLSecondsAfterFinished != nil {
-		in, out := &in.JobTTLSecondsAfterFinished, &out.JobTTLSecondsAfterFinished
-		*out = new(int32)
-		**out = **in
-	}
-	in.JobResources.DeepCopyInto(&out.JobResources)
-	if in.JobInitContainers != nil {
-		in, out := &in.JobInitContainers, &out.JobInitContainers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Services != nil {
-		in, out := &in.Services, &out.Services
-		*out = new(ServicesSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	in.Persistence.DeepCopyInto(&out.Persistence)
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.UI != nil {
-		in, out := &in.UI, &out.UI
-		*out = new(TemporalUISpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AdminTools != nil {
-		in, out := &in.AdminTools, &out.AdminTools
-		*out = new(TemporalAdminToolsSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.MTLS != nil {
-		in, out := &in.MTLS, &out.MTLS
-		*out = new(MTLSSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Metrics != nil {
-		in, out := &in.Metrics, &out.Metrics
-		*out = new(MetricsSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DynamicConfig != nil {
-		in, out := &in.DynamicConfig, &out.DynamicConfig
-		*out = new(DynamicConfigSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Archival != nil {
-		in, out := &in.Archival, &out.Archival
-		*out = new(ClusterArchivalSpec)
+	out.NamespaceRef = in.NamespaceRef
+	in.Schedule.DeepCopyInto(&out.Schedule)
+	if in.Memo != nil {
+		in, out := &in.Memo, &out.Memo
+		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Authorization != nil {
-		in, out := &in.Authorization, &out.Authorization
-		*out = new(AuthorizationSpec)
+	if in.SearchAttributes != nil {
+		in, out := &in.SearchAttributes, &out.SearchAttributes
+		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterSpec.
-func (in *TemporalClusterSpec) DeepCopy() *TemporalClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalScheduleSpec.
+func (in *TemporalScheduleSpec) DeepCopy() *TemporalScheduleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterSpec)
+	out := new(TemporalScheduleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalClusterStatus) DeepCopyInto(out *TemporalClusterStatus) {
+func (in *TemporalScheduleStatus) DeepCopyInto(out *TemporalScheduleStatus) {
 	*out = *in
-	if in.Services != nil {
-		in, out := &in.Services, &out.Services
-		*out = make([]ServiceStatus, len(*in))
-		copy(*out, *in)
-	}
-	if in.Persistence != nil {
-		in, out := &in.Persistence, &out.Persistence
-		*out = new(TemporalPersistenceStatus)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalClusterStatus.
-func (in *TemporalClusterStatus) DeepCopy() *TemporalClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalScheduleStatus.
+func (in *TemporalScheduleStatus) DeepCopy() *TemporalScheduleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalClusterStatus)
+	out := new(TemporalScheduleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalNamespace) DeepCopyInto(out *TemporalNamespace) {
+func (in *TemporalServiceScaler) DeepCopyInto(out *TemporalServiceScaler) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1741,18 +5365,18 @@ func (in *TemporalNamespace) DeepCopyInto(out *TemporalNamespace) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespace.
-func (in *TemporalNamespace) DeepCopy() *TemporalNamespace {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalServiceScaler.
+func (in *TemporalServiceScaler) DeepCopy() *TemporalServiceScaler {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalNamespace)
+	out := new(TemporalServiceScaler)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalNamespace) DeepCopyObject() runtime.Object {
+func (in *TemporalServiceScaler) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1760,56 +5384,31 @@ func (in *TemporalNamespace) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalNamespaceArchivalSpec) DeepCopyInto(out *TemporalNamespaceArchivalSpec) {
-	*out = *in
-	if in.History != nil {
-		in, out := &in.History, &out.History
-		*out = new(ArchivalSpec)
-		**out = **in
-	}
-	if in.Visibility != nil {
-		in, out := &in.Visibility, &out.Visibility
-		*out = new(ArchivalSpec)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceArchivalSpec.
-func (in *TemporalNamespaceArchivalSpec) DeepCopy() *TemporalNamespaceArchivalSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(TemporalNamespaceArchivalSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalNamespaceList) DeepCopyInto(out *TemporalNamespaceList) {
+func (in *TemporalServiceScalerList) DeepCopyInto(out *TemporalServiceScalerList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]TemporalNamespace, len(*in))
+		*out = make([]TemporalServiceScaler, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceList.
-func (in *TemporalNamespaceList) DeepCopy() *TemporalNamespaceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalServiceScalerList.
+func (in *TemporalServiceScalerList) DeepCopy() *TemporalServiceScalerList {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalNamespaceList)
+	out := new(TemporalServiceScalerList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalNamespaceList) DeepCopyObject() runtime.Object {
+func (in *TemporalServiceScalerList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1817,137 +5416,195 @@ func (in *TemporalNamespaceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalNamespaceSpec) DeepCopyInto(out *TemporalNamespaceSpec) {
+func (in *TemporalServiceScalerSpec) DeepCopyInto(out *TemporalServiceScalerSpec) {
 	*out = *in
 	out.ClusterRef = in.ClusterRef
-	if in.RetentionPeriod != nil {
-		in, out := &in.RetentionPeriod, &out.RetentionPeriod
-		*out = new(metav1.Duration)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Data != nil {
-		in, out := &in.Data, &out.Data
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalServiceScalerSpec.
+func (in *TemporalServiceScalerSpec) DeepCopy() *TemporalServiceScalerSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Clusters != nil {
-		in, out := &in.Clusters, &out.Clusters
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out := new(TemporalServiceScalerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalServiceScalerStatus) DeepCopyInto(out *TemporalServiceScalerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Archival != nil {
-		in, out := &in.Archival, &out.Archival
-		*out = new(TemporalNamespaceArchivalSpec)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalServiceScalerStatus.
+func (in *TemporalServiceScalerStatus) DeepCopy() *TemporalServiceScalerStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(TemporalServiceScalerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemporalUICodecSpec) DeepCopyInto(out *TemporalUICodecSpec) {
+	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceSpec.
-func (in *TemporalNamespaceSpec) DeepCopy() *TemporalNamespaceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalUICodecSpec.
+func (in *TemporalUICodecSpec) DeepCopy() *TemporalUICodecSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalNamespaceSpec)
+	out := new(TemporalUICodecSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalNamespaceStatus) DeepCopyInto(out *TemporalNamespaceStatus) {
+func (in *TemporalUIIngressSpec) DeepCopyInto(out *TemporalUIIngressSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = make([]networkingv1.IngressTLS, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalNamespaceStatus.
-func (in *TemporalNamespaceStatus) DeepCopy() *TemporalNamespaceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalUIIngressSpec.
+func (in *TemporalUIIngressSpec) DeepCopy() *TemporalUIIngressSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalNamespaceStatus)
+	out := new(TemporalUIIngressSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalPersistenceSpec) DeepCopyInto(out *TemporalPersistenceSpec) {
+func (in *TemporalUIOAuth2ProxySpec) DeepCopyInto(out *TemporalUIOAuth2ProxySpec) {
 	*out = *in
-	if in.DefaultStore != nil {
-		in, out := &in.DefaultStore, &out.DefaultStore
-		*out = new(DatastoreSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VisibilityStore != nil {
-		in, out := &in.VisibilityStore, &out.VisibilityStore
-		*out = new(DatastoreSpec)
-		(*in).DeepCopyInto(*out)
+	out.ClientSecretRef = in.ClientSecretRef
+	out.CookieSecretRef = in.CookieSecretRef
+	if in.AllowedGroups != nil {
+		in, out := &in.AllowedGroups, &out.AllowedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.SecondaryVisibilityStore != nil {
-		in, out := &in.SecondaryVisibilityStore, &out.SecondaryVisibilityStore
-		*out = new(DatastoreSpec)
-		(*in).DeepCopyInto(*out)
+	if in.EmailDomains != nil {
+		in, out := &in.EmailDomains, &out.EmailDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.AdvancedVisibilityStore != nil {
-		in, out := &in.AdvancedVisibilityStore, &out.AdvancedVisibilityStore
-		*out = new(DatastoreSpec)
-		(*in).DeepCopyInto(*out)
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
+	in.Resources.DeepCopyInto(&out.Resources)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalPersistenceSpec.
-func (in *TemporalPersistenceSpec) DeepCopy() *TemporalPersistenceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalUIOAuth2ProxySpec.
+func (in *TemporalUIOAuth2ProxySpec) DeepCopy() *TemporalUIOAuth2ProxySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalPersistenceSpec)
+	out := new(TemporalUIOAuth2ProxySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalPersistenceStatus) DeepCopyInto(out *TemporalPersistenceStatus) {
+func (in *TemporalUISpec) DeepCopyInto(out *TemporalUISpec) {
 	*out = *in
-	if in.DefaultStore != nil {
-		in, out := &in.DefaultStore, &out.DefaultStore
-		*out = new(DatastoreStatus)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(ServiceSpecOverride)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.VisibilityStore != nil {
-		in, out := &in.VisibilityStore, &out.VisibilityStore
-		*out = new(DatastoreStatus)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(TemporalUIIngressSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.SecondaryVisibilityStore != nil {
-		in, out := &in.SecondaryVisibilityStore, &out.SecondaryVisibilityStore
-		*out = new(DatastoreStatus)
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ObjectMetaOverride)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.AdvancedVisibilityStore != nil {
-		in, out := &in.AdvancedVisibilityStore, &out.AdvancedVisibilityStore
-		*out = new(DatastoreStatus)
+	if in.ForwardHeaders != nil {
+		in, out := &in.ForwardHeaders, &out.ForwardHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Codec != nil {
+		in, out := &in.Codec, &out.Codec
+		*out = new(TemporalUICodecSpec)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OAuth2Proxy != nil {
+		in, out := &in.OAuth2Proxy, &out.OAuth2Proxy
+		*out = new(TemporalUIOAuth2ProxySpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalPersistenceStatus.
-func (in *TemporalPersistenceStatus) DeepCopy() *TemporalPersistenceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalUISpec.
+func (in *TemporalUISpec) DeepCopy() *TemporalUISpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalPersistenceStatus)
+	out := new(TemporalUISpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalSchedule) DeepCopyInto(out *TemporalSchedule) {
+func (in *TemporalWorkerDeployment) DeepCopyInto(out *TemporalWorkerDeployment) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1955,18 +5612,18 @@ func (in *TemporalSchedule) DeepCopyInto(out *TemporalSchedule) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalSchedule.
-func (in *TemporalSchedule) DeepCopy() *TemporalSchedule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalWorkerDeployment.
+func (in *TemporalWorkerDeployment) DeepCopy() *TemporalWorkerDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalSchedule)
+	out := new(TemporalWorkerDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalSchedule) DeepCopyObject() runtime.Object {
+func (in *TemporalWorkerDeployment) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1974,31 +5631,31 @@ func (in *TemporalSchedule) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalScheduleList) DeepCopyInto(out *TemporalScheduleList) {
+func (in *TemporalWorkerDeploymentList) DeepCopyInto(out *TemporalWorkerDeploymentList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]TemporalSchedule, len(*in))
+		*out = make([]TemporalWorkerDeployment, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalScheduleList.
-func (in *TemporalScheduleList) DeepCopy() *TemporalScheduleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalWorkerDeploymentList.
+func (in *TemporalWorkerDeploymentList) DeepCopy() *TemporalWorkerDeploymentList {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalScheduleList)
+	out := new(TemporalWorkerDeploymentList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TemporalScheduleList) DeepCopyObject() runtime.Object {
+func (in *TemporalWorkerDeploymentList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2006,125 +5663,218 @@ func (in *TemporalScheduleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalScheduleSpec) DeepCopyInto(out *TemporalScheduleSpec) {
+func (in *TemporalWorkerDeploymentSpec) DeepCopyInto(out *TemporalWorkerDeploymentSpec) {
 	*out = *in
-	out.NamespaceRef = in.NamespaceRef
-	in.Schedule.DeepCopyInto(&out.Schedule)
-	if in.Memo != nil {
-		in, out := &in.Memo, &out.Memo
-		*out = new(apiextensionsv1.JSON)
-		(*in).DeepCopyInto(*out)
+	out.ClusterRef = in.ClusterRef
+	if in.ClusterClientRef != nil {
+		in, out := &in.ClusterClientRef, &out.ClusterClientRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
-	if in.SearchAttributes != nil {
-		in, out := &in.SearchAttributes, &out.SearchAttributes
-		*out = new(apiextensionsv1.JSON)
+	if in.TaskQueues != nil {
+		in, out := &in.TaskQueues, &out.TaskQueues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(WorkerAutoscalingSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalScheduleSpec.
-func (in *TemporalScheduleSpec) DeepCopy() *TemporalScheduleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalWorkerDeploymentSpec.
+func (in *TemporalWorkerDeploymentSpec) DeepCopy() *TemporalWorkerDeploymentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalScheduleSpec)
+	out := new(TemporalWorkerDeploymentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalScheduleStatus) DeepCopyInto(out *TemporalScheduleStatus) {
+func (in *TemporalWorkerDeploymentStatus) DeepCopyInto(out *TemporalWorkerDeploymentStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalScheduleStatus.
-func (in *TemporalScheduleStatus) DeepCopy() *TemporalScheduleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalWorkerDeploymentStatus.
+func (in *TemporalWorkerDeploymentStatus) DeepCopy() *TemporalWorkerDeploymentStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalScheduleStatus)
+	out := new(TemporalWorkerDeploymentStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalUIIngressSpec) DeepCopyInto(out *TemporalUIIngressSpec) {
+func (in *UpgradeStrategySpec) DeepCopyInto(out *UpgradeStrategySpec) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStrategySpec.
+func (in *UpgradeStrategySpec) DeepCopy() *UpgradeStrategySpec {
+	if in == nil {
+		return nil
 	}
-	if in.IngressClassName != nil {
-		in, out := &in.IngressClassName, &out.IngressClassName
-		*out = new(string)
+	out := new(UpgradeStrategySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VisibilityStoreRateLimitsSpec) DeepCopyInto(out *VisibilityStoreRateLimitsSpec) {
+	*out = *in
+	if in.MaxReadQPS != nil {
+		in, out := &in.MaxReadQPS, &out.MaxReadQPS
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Hosts != nil {
-		in, out := &in.Hosts, &out.Hosts
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MaxWriteQPS != nil {
+		in, out := &in.MaxWriteQPS, &out.MaxWriteQPS
+		*out = new(int32)
+		**out = **in
 	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = make([]networkingv1.IngressTLS, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VisibilityStoreRateLimitsSpec.
+func (in *VisibilityStoreRateLimitsSpec) DeepCopy() *VisibilityStoreRateLimitsSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(VisibilityStoreRateLimitsSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalUIIngressSpec.
-func (in *TemporalUIIngressSpec) DeepCopy() *TemporalUIIngressSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmupSpec) DeepCopyInto(out *WarmupSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmupSpec.
+func (in *WarmupSpec) DeepCopy() *WarmupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalUIIngressSpec)
+	out := new(WarmupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemporalUISpec) DeepCopyInto(out *TemporalUISpec) {
+func (in *WorkerAutoscalingSpec) DeepCopyInto(out *WorkerAutoscalingSpec) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
 		*out = new(int32)
 		**out = **in
 	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Overrides != nil {
-		in, out := &in.Overrides, &out.Overrides
-		*out = new(ServiceSpecOverride)
-		(*in).DeepCopyInto(*out)
+	if in.TargetQueueSize != nil {
+		in, out := &in.TargetQueueSize, &out.TargetQueueSize
+		*out = new(int32)
+		**out = **in
 	}
-	if in.Ingress != nil {
-		in, out := &in.Ingress, &out.Ingress
-		*out = new(TemporalUIIngressSpec)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerAutoscalingSpec.
+func (in *WorkerAutoscalingSpec) DeepCopy() *WorkerAutoscalingSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Service != nil {
-		in, out := &in.Service, &out.Service
-		*out = new(ObjectMetaOverride)
-		(*in).DeepCopyInto(*out)
+	out := new(WorkerAutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadMonitoringSpec) DeepCopyInto(out *WorkloadMonitoringSpec) {
+	*out = *in
+	if in.TaskQueues != nil {
+		in, out := &in.TaskQueues, &out.TaskQueues
+		*out = make([]MonitoredTaskQueueSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBacklog != nil {
+		in, out := &in.MaxBacklog, &out.MaxBacklog
+		*out = new(int64)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemporalUISpec.
-func (in *TemporalUISpec) DeepCopy() *TemporalUISpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadMonitoringSpec.
+func (in *WorkloadMonitoringSpec) DeepCopy() *WorkloadMonitoringSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TemporalUISpec)
+	out := new(WorkloadMonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadStatus) DeepCopyInto(out *WorkloadStatus) {
+	*out = *in
+	if in.TaskQueues != nil {
+		in, out := &in.TaskQueues, &out.TaskQueues
+		*out = make([]TaskQueueWorkloadStatus, len(*in))
+		copy(*out, *in)
+	}
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadStatus.
+func (in *WorkloadStatus) DeepCopy() *WorkloadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadStatus)
 	in.DeepCopyInto(out)
 	return out
 }
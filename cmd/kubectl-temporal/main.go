@@ -0,0 +1,260 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Command kubectl-temporal is a kubectl plugin opening a temporal CLI session against a TemporalCluster.
+// It forwards a local port to the cluster frontend and configures the CLI mTLS client certificate,
+// so the CLI can be used from a workstation without exposing the frontend.
+//
+// Usage:
+//
+//	kubectl temporal [flags] CLUSTER [-- CLI ARGS...]
+//	kubectl temporal support-bundle [flags] CLUSTER
+//
+// Without CLI arguments, a shell is started with the CLI environment set.
+// The support-bundle command collects the cluster state into a tarball to attach to bug reports.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/alexandrevilain/temporal-operator/pkg/kubernetes"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	temporalCLI = "temporal"
+	tctlCLI     = "tctl"
+)
+
+type options struct {
+	namespace string
+	cli       string
+	verbose   bool
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == supportBundleCommand {
+		supportBundleMain(os.Args[2:])
+		return
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	overrides := &clientcmd.ConfigOverrides{}
+	opts := options{}
+
+	flags := flag.NewFlagSet("kubectl-temporal", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: kubectl temporal [flags] CLUSTER [-- CLI ARGS...]\n\n")
+		fmt.Fprintf(flags.Output(), "Opens a temporal CLI session against the provided TemporalCluster.\n")
+		fmt.Fprintf(flags.Output(), "Without CLI arguments, a shell is started with the CLI environment set.\n")
+		fmt.Fprintf(flags.Output(), "Run kubectl temporal %s --help to collect a support bundle.\n\n", supportBundleCommand)
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&loadingRules.ExplicitPath, "kubeconfig", "", "Path to the kubeconfig file.")
+	flags.StringVar(&overrides.CurrentContext, "context", "", "The kubeconfig context to use.")
+	flags.StringVar(&opts.namespace, "namespace", "", "The namespace of the TemporalCluster. Defaults to the kubeconfig context namespace.")
+	flags.StringVar(&opts.namespace, "n", "", "Shorthand for --namespace.")
+	flags.StringVar(&opts.cli, "cli", "", "The CLI to run (temporal or tctl). Defaults to the CLI supported by the cluster version.")
+	flags.BoolVar(&opts.verbose, "v", false, "Print port forwarding logs.")
+	_ = flags.Parse(os.Args[1:])
+
+	if flags.NArg() < 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+
+	err := run(ctx, kubeConfig, opts, flags.Arg(0), flags.Args()[1:])
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, kubeConfig clientcmd.ClientConfig, opts options, clusterName string, args []string) error {
+	restConfig, err := kubeConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("can't load kubeconfig: %w", err)
+	}
+
+	if opts.namespace == "" {
+		opts.namespace, _, err = kubeConfig.Namespace()
+		if err != nil {
+			return fmt.Errorf("can't get kubeconfig namespace: %w", err)
+		}
+	}
+
+	c, err := newClient(restConfig)
+	if err != nil {
+		return err
+	}
+
+	cluster := &v1beta1.TemporalCluster{}
+	err = c.Get(ctx, client.ObjectKey{Namespace: opts.namespace, Name: clusterName}, cluster)
+	if err != nil {
+		return fmt.Errorf("can't get TemporalCluster %s/%s: %w", opts.namespace, clusterName, err)
+	}
+
+	cli := opts.cli
+	if cli == "" {
+		cli = defaultCLI(cluster)
+	}
+	if cli != temporalCLI && cli != tctlCLI {
+		return fmt.Errorf("unsupported cli %q, should be %s or %s", cli, temporalCLI, tctlCLI)
+	}
+
+	var out io.Writer = io.Discard
+	if opts.verbose {
+		out = os.Stderr
+	}
+
+	// The port forward reconnects to another frontend pod if the current one goes away during the session.
+	selector := labels.SelectorFromSet(metadata.LabelsSelector(cluster, meta.FrontendService))
+	portForward, err := kubernetes.ForwardPortToReadyPods(ctx, restConfig, c, cluster.GetNamespace(), selector, int32(*cluster.Spec.Services.Frontend.Port), out)
+	if err != nil {
+		return fmt.Errorf("can't forward port to TemporalCluster %s/%s frontend: %w", cluster.GetNamespace(), cluster.GetName(), err)
+	}
+	defer portForward.Close()
+
+	env := map[string]string{
+		envName(cli, "ADDRESS"): portForward.Address(),
+	}
+
+	if cluster.MTLSWithCertManagerEnabled() && cluster.Spec.MTLS.FrontendEnabled() {
+		certsDir, err := os.MkdirTemp("", "kubectl-temporal-")
+		if err != nil {
+			return fmt.Errorf("can't create certificates directory: %w", err)
+		}
+		defer os.RemoveAll(certsDir)
+
+		err = writeClientCertificate(ctx, c, cluster, certsDir)
+		if err != nil {
+			return err
+		}
+
+		for _, envVar := range certmanager.GetTLSEnvironmentVariables(cluster, envPrefix(cli), certsDir) {
+			env[envVar.Name] = envVar.Value
+		}
+	}
+
+	// The command is not bound to ctx: interrupts are received by the whole process group
+	// and handled by the CLI or the shell itself.
+	command := exec.Command(cli, args...)
+	if len(args) == 0 {
+		shell := os.Getenv("SHELL")
+		if shell == "" {
+			shell = "/bin/sh"
+		}
+		fmt.Fprintf(os.Stderr, "Forwarding %s to %s/%s, run %s commands and exit the shell to stop.\n", portForward.Address(), cluster.GetNamespace(), cluster.GetName(), cli)
+		command = exec.Command(shell)
+	}
+
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	command.Env = os.Environ()
+	for name, value := range env {
+		command.Env = append(command.Env, fmt.Sprintf("%s=%s", name, value))
+	}
+
+	return command.Run()
+}
+
+// newClient returns a kubernetes client knowing the operator's custom resources.
+func newClient(restConfig *rest.Config) (client.Client, error) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("can't create kubernetes client: %w", err)
+	}
+
+	return c, nil
+}
+
+// defaultCLI returns the CLI shipped in the admin-tools image of the cluster version.
+// The temporal CLI replaced tctl starting with temporal 1.20.
+func defaultCLI(cluster *v1beta1.TemporalCluster) string {
+	if cluster.Spec.Version.GreaterOrEqual(version.V1_20_0) {
+		return temporalCLI
+	}
+	return tctlCLI
+}
+
+func envPrefix(cli string) string {
+	if cli == tctlCLI {
+		return "TEMPORAL_CLI"
+	}
+	return "TEMPORAL"
+}
+
+func envName(cli, name string) string {
+	return fmt.Sprintf("%s_%s", envPrefix(cli), name)
+}
+
+// writeClientCertificate writes the admin-tools client certificate in the provided directory.
+// The frontend certificate is used, as the operator does, if admin-tools are not enabled on the cluster.
+func writeClientCertificate(ctx context.Context, c client.Client, cluster *v1beta1.TemporalCluster, dir string) error {
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: cluster.GetNamespace(), Name: cluster.ChildResourceName(certmanager.AdmintoolsFrontendClientCertificate)}, secret)
+	if apierrors.IsNotFound(err) {
+		err = c.Get(ctx, client.ObjectKey{Namespace: cluster.GetNamespace(), Name: cluster.ChildResourceName(certmanager.FrontendCertificate)}, secret)
+	}
+	if err != nil {
+		return fmt.Errorf("can't get client certificate: %w", err)
+	}
+
+	for _, key := range []string{certmanager.TLSCA, certmanager.TLSCert, certmanager.TLSKey} {
+		err := os.WriteFile(filepath.Join(dir, key), secret.Data[key], 0o600)
+		if err != nil {
+			return fmt.Errorf("can't write %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,467 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/supportbundle"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const supportBundleCommand = "support-bundle"
+
+type supportBundleOptions struct {
+	namespace         string
+	output            string
+	operatorNamespace string
+	operatorSelector  string
+	logLines          int64
+}
+
+// bundleCollector collects the state of a cluster into a support bundle.
+// Collection errors don't abort the bundle: they are reported in its errors.txt file.
+type bundleCollector struct {
+	client    client.Client
+	clientset kubeclient.Interface
+	opts      supportBundleOptions
+	cluster   *v1beta1.TemporalCluster
+	bundle    *supportbundle.Writer
+	errors    []string
+}
+
+func supportBundleMain(args []string) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	overrides := &clientcmd.ConfigOverrides{}
+	opts := supportBundleOptions{}
+
+	flags := flag.NewFlagSet("kubectl-temporal "+supportBundleCommand, flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: kubectl temporal %s [flags] CLUSTER\n\n", supportBundleCommand)
+		fmt.Fprintf(flags.Output(), "Collects the state of the provided TemporalCluster into a tarball to attach to bug reports.\n")
+		fmt.Fprintf(flags.Output(), "Secrets are never collected and credentials found in the rendered configurations are redacted.\n\n")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&loadingRules.ExplicitPath, "kubeconfig", "", "Path to the kubeconfig file.")
+	flags.StringVar(&overrides.CurrentContext, "context", "", "The kubeconfig context to use.")
+	flags.StringVar(&opts.namespace, "namespace", "", "The namespace of the TemporalCluster. Defaults to the kubeconfig context namespace.")
+	flags.StringVar(&opts.namespace, "n", "", "Shorthand for --namespace.")
+	flags.StringVar(&opts.output, "output", "", "Path of the written tarball. Defaults to <cluster>-support-bundle-<timestamp>.tar.gz.")
+	flags.StringVar(&opts.output, "o", "", "Shorthand for --output.")
+	flags.StringVar(&opts.operatorNamespace, "operator-namespace", "temporal-system", "The namespace the operator runs in.")
+	flags.StringVar(&opts.operatorSelector, "operator-selector", "control-plane=controller-manager", "The label selector of the operator pods.")
+	flags.Int64Var(&opts.logLines, "log-lines", 5000, "Number of operator log lines searched for the cluster log entries.")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+
+	err := runSupportBundle(ctx, kubeConfig, opts, flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func runSupportBundle(ctx context.Context, kubeConfig clientcmd.ClientConfig, opts supportBundleOptions, clusterName string) error {
+	restConfig, err := kubeConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("can't load kubeconfig: %w", err)
+	}
+
+	if opts.namespace == "" {
+		opts.namespace, _, err = kubeConfig.Namespace()
+		if err != nil {
+			return fmt.Errorf("can't get kubeconfig namespace: %w", err)
+		}
+	}
+
+	c, err := newClient(restConfig)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := kubeclient.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("can't create kubernetes clientset: %w", err)
+	}
+
+	cluster := &v1beta1.TemporalCluster{}
+	err = c.Get(ctx, client.ObjectKey{Namespace: opts.namespace, Name: clusterName}, cluster)
+	if err != nil {
+		return fmt.Errorf("can't get TemporalCluster %s/%s: %w", opts.namespace, clusterName, err)
+	}
+
+	now := time.Now().UTC()
+	root := fmt.Sprintf("%s-support-bundle-%s", clusterName, now.Format("20060102-150405"))
+	if opts.output == "" {
+		opts.output = root + ".tar.gz"
+	}
+
+	f, err := os.Create(opts.output)
+	if err != nil {
+		return fmt.Errorf("can't create %s: %w", opts.output, err)
+	}
+	defer f.Close()
+
+	collector := &bundleCollector{
+		client:    c,
+		clientset: clientset,
+		opts:      opts,
+		cluster:   cluster,
+		bundle:    supportbundle.NewWriter(f, root),
+	}
+
+	err = collector.collect(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = collector.bundle.Close()
+	if err != nil {
+		return fmt.Errorf("can't write %s: %w", opts.output, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Support bundle written to %s.\n", opts.output)
+	if len(collector.errors) > 0 {
+		fmt.Fprintf(os.Stderr, "%d items couldn't be collected, see errors.txt in the bundle.\n", len(collector.errors))
+	}
+
+	return f.Close()
+}
+
+// collect writes the bundle files. It only returns an error if the bundle can't be written.
+func (b *bundleCollector) collect(ctx context.Context) error {
+	steps := []struct {
+		name string
+		run  func(context.Context) error
+	}{
+		{"cluster", b.collectCluster},
+		{"configmaps", b.collectConfigMaps},
+		{"pods", b.collectPods},
+		{"jobs", b.collectJobs},
+		{"events", b.collectEvents},
+		{"certificates", b.collectCertificates},
+		{"operator logs", b.collectOperatorLogs},
+	}
+
+	for _, step := range steps {
+		err := step.run(ctx)
+		if err != nil {
+			b.errors = append(b.errors, fmt.Sprintf("%s: %s", step.name, err))
+		}
+	}
+
+	if len(b.errors) == 0 {
+		return nil
+	}
+
+	return b.bundle.Add("errors.txt", []byte(strings.Join(b.errors, "\n")+"\n"))
+}
+
+// matchingLabels selects the cluster child resources.
+func (b *bundleCollector) matchingLabels() client.ListOption {
+	return client.MatchingLabels(b.cluster.SelectorLabels())
+}
+
+func (b *bundleCollector) collectCluster(_ context.Context) error {
+	cluster := b.cluster.DeepCopy()
+	cluster.ManagedFields = nil
+	delete(cluster.Annotations, corev1.LastAppliedConfigAnnotation)
+
+	if err := b.bundle.AddJSON("cluster.json", cluster); err != nil {
+		return err
+	}
+
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "Cluster: %s/%s\n", cluster.GetNamespace(), cluster.GetName())
+	fmt.Fprintf(&summary, "Version: %s\n", cluster.Spec.Version)
+	fmt.Fprintf(&summary, "Generation: %d\n\n", cluster.GetGeneration())
+
+	fmt.Fprintf(&summary, "Conditions:\n")
+	for _, condition := range cluster.Status.Conditions {
+		fmt.Fprintf(&summary, "  %s=%s (%s) since %s: %s\n", condition.Type, condition.Status, condition.Reason, condition.LastTransitionTime.UTC().Format(time.RFC3339), condition.Message)
+	}
+
+	fmt.Fprintf(&summary, "\nSchema versions:\n")
+	stores := []struct {
+		name   string
+		status *v1beta1.DatastoreStatus
+	}{
+		{v1beta1.DefaultStoreName, cluster.Status.Persistence.DefaultStore},
+		{v1beta1.VisibilityStoreName, cluster.Status.Persistence.VisibilityStore},
+		{v1beta1.SecondaryVisibilityStoreName, cluster.Status.Persistence.SecondaryVisibilityStore},
+		{v1beta1.AdvancedVisibilityStoreName, cluster.Status.Persistence.AdvancedVisibilityStore},
+	}
+	for _, store := range stores {
+		if store.status == nil {
+			continue
+		}
+		schemaVersion := "unknown"
+		if store.status.SchemaVersion != nil {
+			schemaVersion = store.status.SchemaVersion.String()
+		}
+		fmt.Fprintf(&summary, "  %s (%s): %s, created=%t, setup=%t\n", store.name, store.status.Type, schemaVersion, store.status.Created, store.status.Setup)
+	}
+
+	return b.bundle.Add("summary.txt", []byte(summary.String()))
+}
+
+// collectConfigMaps writes the rendered configurations, with the credentials redacted.
+func (b *bundleCollector) collectConfigMaps(ctx context.Context) error {
+	configMaps := &corev1.ConfigMapList{}
+	err := b.client.List(ctx, configMaps, client.InNamespace(b.cluster.GetNamespace()), b.matchingLabels())
+	if err != nil {
+		return err
+	}
+
+	for _, configMap := range configMaps.Items {
+		for key, value := range configMap.Data {
+			content := []byte(value)
+			switch path.Ext(key) {
+			case ".yaml", ".yml", ".json":
+				content, err = supportbundle.RedactYAML(content)
+				if err != nil {
+					// Never write a configuration that can't be redacted.
+					content = []byte(fmt.Sprintf("# can't redact the configuration, omitted: %s\n", err))
+				}
+			}
+
+			err = b.bundle.Add(path.Join("configmaps", configMap.GetName(), key), content)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type podSummary struct {
+	Name              string                   `json:"name"`
+	NodeName          string                   `json:"nodeName,omitempty"`
+	Phase             corev1.PodPhase          `json:"phase"`
+	StartTime         *metav1.Time             `json:"startTime,omitempty"`
+	Conditions        []corev1.PodCondition    `json:"conditions,omitempty"`
+	ContainerStatuses []corev1.ContainerStatus `json:"containerStatuses,omitempty"`
+}
+
+func (b *bundleCollector) collectPods(ctx context.Context) error {
+	pods := &corev1.PodList{}
+	err := b.client.List(ctx, pods, client.InNamespace(b.cluster.GetNamespace()), b.matchingLabels())
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]podSummary, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		summaries = append(summaries, podSummary{
+			Name:              pod.GetName(),
+			NodeName:          pod.Spec.NodeName,
+			Phase:             pod.Status.Phase,
+			StartTime:         pod.Status.StartTime,
+			Conditions:        pod.Status.Conditions,
+			ContainerStatuses: append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...),
+		})
+	}
+
+	return b.bundle.AddJSON("pods.json", summaries)
+}
+
+type jobSummary struct {
+	Name   string            `json:"name"`
+	Status batchv1.JobStatus `json:"status"`
+}
+
+func (b *bundleCollector) collectJobs(ctx context.Context) error {
+	jobs := &batchv1.JobList{}
+	err := b.client.List(ctx, jobs, client.InNamespace(b.cluster.GetNamespace()))
+	if err != nil {
+		return err
+	}
+
+	summaries := []jobSummary{}
+	for _, job := range jobs.Items {
+		if !metav1.IsControlledBy(&job, b.cluster) {
+			continue
+		}
+		summaries = append(summaries, jobSummary{
+			Name:   job.GetName(),
+			Status: job.Status,
+		})
+	}
+
+	return b.bundle.AddJSON("jobs.json", summaries)
+}
+
+type eventSummary struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Reason  string    `json:"reason"`
+	Object  string    `json:"object"`
+	Count   int32     `json:"count,omitempty"`
+	Message string    `json:"message"`
+}
+
+// collectEvents writes the events of the cluster and of its child resources, oldest first.
+func (b *bundleCollector) collectEvents(ctx context.Context) error {
+	events := &corev1.EventList{}
+	err := b.client.List(ctx, events, client.InNamespace(b.cluster.GetNamespace()))
+	if err != nil {
+		return err
+	}
+
+	prefix := b.cluster.GetName() + "-"
+	summaries := []eventSummary{}
+	for _, event := range events.Items {
+		name := event.InvolvedObject.Name
+		if name != b.cluster.GetName() && !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		eventTime := event.LastTimestamp.Time
+		if eventTime.IsZero() {
+			eventTime = event.EventTime.Time
+		}
+
+		summaries = append(summaries, eventSummary{
+			Time:    eventTime.UTC(),
+			Type:    event.Type,
+			Reason:  event.Reason,
+			Object:  fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, name),
+			Count:   event.Count,
+			Message: event.Message,
+		})
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Time.Before(summaries[j].Time)
+	})
+
+	return b.bundle.AddJSON("events.json", summaries)
+}
+
+type certificateSummary struct {
+	Name        string `json:"name"`
+	Ready       string `json:"ready"`
+	NotAfter    string `json:"notAfter,omitempty"`
+	RenewalTime string `json:"renewalTime,omitempty"`
+}
+
+// collectCertificates writes the expiry of the cluster cert-manager certificates.
+// The cert-manager scheme isn't registered, as cert-manager is optional.
+func (b *bundleCollector) collectCertificates(ctx context.Context) error {
+	certificates := &unstructured.UnstructuredList{}
+	certificates.SetGroupVersionKind(schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "CertificateList"})
+	err := b.client.List(ctx, certificates, client.InNamespace(b.cluster.GetNamespace()), b.matchingLabels())
+	if err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	summaries := make([]certificateSummary, 0, len(certificates.Items))
+	for _, certificate := range certificates.Items {
+		summary := certificateSummary{Name: certificate.GetName()}
+		summary.NotAfter, _, _ = unstructured.NestedString(certificate.Object, "status", "notAfter")
+		summary.RenewalTime, _, _ = unstructured.NestedString(certificate.Object, "status", "renewalTime")
+
+		conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+		for _, c := range conditions {
+			condition, ok := c.(map[string]any)
+			if ok && condition["type"] == "Ready" {
+				summary.Ready, _ = condition["status"].(string)
+			}
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return b.bundle.AddJSON("certificates.json", summaries)
+}
+
+// collectOperatorLogs writes the operator log lines mentioning the cluster.
+func (b *bundleCollector) collectOperatorLogs(ctx context.Context) error {
+	pods, err := b.clientset.CoreV1().Pods(b.opts.operatorNamespace).List(ctx, metav1.ListOptions{LabelSelector: b.opts.operatorSelector})
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods.Items {
+		err := b.collectPodLogs(ctx, pod)
+		if err != nil {
+			b.errors = append(b.errors, fmt.Sprintf("operator logs: %s: %s", pod.GetName(), err))
+		}
+	}
+
+	return nil
+}
+
+func (b *bundleCollector) collectPodLogs(ctx context.Context, pod corev1.Pod) error {
+	stream, err := b.clientset.CoreV1().Pods(pod.GetNamespace()).GetLogs(pod.GetName(), &corev1.PodLogOptions{
+		Container: "manager",
+		TailLines: &b.opts.logLines,
+	}).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	var lines strings.Builder
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.Contains(line, b.cluster.GetName()) && strings.Contains(line, b.cluster.GetNamespace()) {
+			lines.WriteString(line)
+			lines.WriteByte('\n')
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return b.bundle.Add(path.Join("logs", pod.GetName()+".log"), []byte(lines.String()))
+}
@@ -18,11 +18,17 @@
 package controllers
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/alexandrevilain/controller-tools/pkg/discovery"
 	"github.com/alexandrevilain/controller-tools/pkg/reconciler"
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type Base struct {
@@ -52,3 +58,38 @@ func New(crclient client.Client, scheme *runtime.Scheme, recorder record.EventRe
 		},
 	}
 }
+
+// reconcileUnstructuredBuilders reconciles the resources of builders building unstructured objects.
+// Their types aren't registered in the scheme, so they can't be reconciled by the resource reconciler.
+// Resources of disabled builders are deleted.
+func (b *Base) reconcileUnstructuredBuilders(ctx context.Context, builders []resource.Builder) error {
+	logger := log.FromContext(ctx)
+
+	for _, builder := range builders {
+		object := builder.Build()
+		kind := object.GetObjectKind().GroupVersionKind().Kind
+
+		if !builder.Enabled() {
+			err := b.Delete(ctx, object)
+			if client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("can't delete %s %s: %w", kind, object.GetName(), err)
+			}
+			if err == nil {
+				logger.Info("Deleted resource", "kind", kind, "name", object.GetName())
+			}
+			continue
+		}
+
+		result, err := controllerutil.CreateOrUpdate(ctx, b.Client, object, func() error {
+			return builder.Update(object)
+		})
+		if err != nil {
+			return fmt.Errorf("can't reconcile %s %s: %w", kind, object.GetName(), err)
+		}
+		if result != controllerutil.OperationResultNone {
+			logger.Info("Reconciled resource", "kind", kind, "name", object.GetName(), "result", result)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alexandrevilain/controller-tools/pkg/discovery"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/cache"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeDiscovery reports all the kinds as supported by the api server.
+type fakeDiscovery struct {
+	discovery.Manager
+}
+
+func (fakeDiscovery) IsGVKSupported(schema.GroupVersionKind) (bool, error) {
+	return true, nil
+}
+
+// newFakeBase returns a reconciler base using a fake client populated with the provided objects.
+func newFakeBase(t *testing.T, objects ...client.Object) Base {
+	c, scheme := newFakeClient(t, objects...)
+	return New(c, scheme, record.NewFakeRecorder(100), fakeDiscovery{})
+}
+
+// newCachedFakeBase returns a reconciler base reading objects like the manager cached client:
+// the objects of the kinds restricted in the cache options are only found if they match the cache selector.
+func newCachedFakeBase(t *testing.T, objects ...client.Object) Base {
+	c, scheme := newFakeClient(t, objects...)
+
+	selectors := map[schema.GroupVersionKind]labels.Selector{}
+	for object, byObject := range cache.Options(nil).ByObject {
+		gvk, err := apiutil.GVKForObject(object, scheme)
+		require.NoError(t, err)
+		selectors[gvk] = byObject.Label
+	}
+
+	return New(&cachedClient{Client: c, selectors: selectors}, scheme, record.NewFakeRecorder(100), fakeDiscovery{})
+}
+
+func newFakeClient(t *testing.T, objects ...client.Object) (client.Client, *runtime.Scheme) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(
+			&v1beta1.TemporalCluster{},
+			&v1beta1.TemporalClusterClient{},
+			&v1beta1.TemporalClusterClone{},
+			&v1beta1.TemporalNamespace{},
+			&v1beta1.TemporalNamespaceMigration{},
+			&v1beta1.TemporalServiceScaler{},
+			&v1beta1.TemporalWorkerDeployment{},
+		).
+		WithIndex(&batchv1.Job{}, ownerKey, addResourceToIndex).
+		WithIndex(&v1beta1.TemporalClusterClient{}, clusterRefNameField, func(rawObj client.Object) []string {
+			return []string{rawObj.(*v1beta1.TemporalClusterClient).Spec.ClusterRef.Name}
+		}).
+		Build()
+
+	return c, scheme
+}
+
+// cachedClient hides the objects the manager cache doesn't hold.
+type cachedClient struct {
+	client.Client
+	selectors map[schema.GroupVersionKind]labels.Selector
+}
+
+func (c *cachedClient) selector(obj runtime.Object) labels.Selector {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return nil
+	}
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	return c.selectors[gvk]
+}
+
+func (c *cachedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	selector := c.selector(obj)
+	if selector == nil {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+
+	found := obj.DeepCopyObject().(client.Object)
+	err := c.Client.Get(ctx, key, found, opts...)
+	if err != nil {
+		return err
+	}
+	if !selector.Matches(labels.Set(found.GetLabels())) {
+		gvk, _ := apiutil.GVKForObject(obj, c.Scheme())
+		return apierrors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: strings.ToLower(gvk.Kind) + "s"}, key.Name)
+	}
+
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(found).Elem())
+	return nil
+}
+
+func (c *cachedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	err := c.Client.List(ctx, list, opts...)
+	if err != nil {
+		return err
+	}
+
+	selector := c.selector(list)
+	if selector == nil {
+		return nil
+	}
+
+	items, err := apimeta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	cached := []runtime.Object{}
+	for _, item := range items {
+		if selector.Matches(labels.Set(item.(client.Object).GetLabels())) {
+			cached = append(cached, item)
+		}
+	}
+	return apimeta.SetList(list, cached)
+}
@@ -0,0 +1,75 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"golang.org/x/exp/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// accessPoliciesClaimRules returns the claim mapper rules compiled from the TemporalAccessPolicies referencing the cluster.
+// It returns no rules if the cluster has no certificate claim mapper configured.
+func (r *TemporalClusterReconciler) accessPoliciesClaimRules(ctx context.Context, cluster *v1beta1.TemporalCluster) ([]v1beta1.CertificateClaimMapperRule, error) {
+	if !cluster.Spec.Authorization.CertificateClaimMapperEnabled() {
+		return nil, nil
+	}
+
+	policies := &v1beta1.TemporalAccessPolicyList{}
+	err := r.List(ctx, policies, client.InNamespace(cluster.GetNamespace()))
+	if err != nil {
+		return nil, fmt.Errorf("can't list access policies: %w", err)
+	}
+
+	// Sort policies to keep the rendered configuration, and so its hash, stable.
+	slices.SortFunc(policies.Items, func(a, b v1beta1.TemporalAccessPolicy) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	rules := []v1beta1.CertificateClaimMapperRule{}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if policy.Spec.ClusterRef.Name != cluster.GetName() || !policy.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		rules = append(rules, policy.Spec.ClaimMapperRules()...)
+	}
+
+	return rules, nil
+}
+
+// accessPolicyToClusterMapfunc enqueues the cluster referenced by the provided TemporalAccessPolicy,
+// as policies are rendered in the cluster claim mapper rules.
+func (r *TemporalClusterReconciler) accessPolicyToClusterMapfunc(_ context.Context, o client.Object) []reconcile.Request {
+	policy, ok := o.(*v1beta1.TemporalAccessPolicy)
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: client.ObjectKey{Namespace: policy.GetNamespace(), Name: policy.Spec.ClusterRef.Name},
+		},
+	}
+}
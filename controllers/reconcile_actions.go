@@ -0,0 +1,191 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/strings/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// setupSchemaJobs are the names of the persistence jobs run again by the temporal.io/rerun-schema-setup annotation.
+var setupSchemaJobs = []string{
+	"setup-default-schema",
+	"setup-visibility-schema",
+	"setup-secondary-visibility-schema",
+	"setup-advanced-visibility-schema",
+}
+
+// restartedServices returns the services listed in the temporal.io/restart-service annotation value.
+func restartedServices(value string) []string {
+	services := []string{}
+	for _, service := range strings.Split(value, ",") {
+		service = strings.TrimSpace(service)
+		if service != "" {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+// reconcileActions runs the one-off operations requested using the action annotations,
+// then removes the annotations so each action is run only once.
+// Annotations whose action failed are kept so the action is retried on the next reconciliation.
+func (r *TemporalClusterReconciler) reconcileActions(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	annotations := cluster.GetAnnotations()
+
+	if value, ok := annotations[v1beta1.RestartServiceAnnotation]; ok {
+		r.restartServices(ctx, cluster, restartedServices(value))
+		delete(annotations, v1beta1.RestartServiceAnnotation)
+	}
+
+	if value, ok := annotations[v1beta1.RerunSchemaSetupAnnotation]; ok {
+		if value == "true" {
+			if err := r.rerunSchemaSetup(ctx, cluster); err != nil {
+				return fmt.Errorf("can't rerun schema setup: %w", err)
+			}
+		}
+		delete(annotations, v1beta1.RerunSchemaSetupAnnotation)
+	}
+
+	if value, ok := annotations[v1beta1.RefreshCertificatesAnnotation]; ok {
+		if value == "true" {
+			if err := r.refreshCertificates(ctx, cluster); err != nil {
+				return fmt.Errorf("can't refresh certificates: %w", err)
+			}
+		}
+		delete(annotations, v1beta1.RefreshCertificatesAnnotation)
+	}
+
+	if podName, ok := annotations[v1beta1.DebugPodAnnotation]; ok {
+		if err := r.attachDebugContainer(ctx, cluster, podName, annotations[v1beta1.DebugImageAnnotation]); err != nil {
+			return fmt.Errorf("can't attach debug container: %w", err)
+		}
+		delete(annotations, v1beta1.DebugPodAnnotation)
+		delete(annotations, v1beta1.DebugImageAnnotation)
+	}
+
+	if value, ok := annotations[v1beta1.DiagnoseShardsAnnotation]; ok {
+		if err := r.diagnoseShards(ctx, cluster, value); err != nil {
+			return fmt.Errorf("can't diagnose shards: %w", err)
+		}
+		delete(annotations, v1beta1.DiagnoseShardsAnnotation)
+	}
+
+	if value, ok := annotations[v1beta1.DeletionReportAnnotation]; ok {
+		if value == "true" {
+			if err := r.reportDeletion(ctx, cluster); err != nil {
+				return fmt.Errorf("can't report deletion: %w", err)
+			}
+		}
+		delete(annotations, v1beta1.DeletionReportAnnotation)
+	}
+
+	cluster.SetAnnotations(annotations)
+
+	return nil
+}
+
+// restartServices records the restart request time in the services statuses.
+// The deployments builders copy it in the pods annotations, rolling the services pods.
+func (r *TemporalClusterReconciler) restartServices(ctx context.Context, cluster *v1beta1.TemporalCluster, services []string) {
+	now := metav1.Now()
+	for i := range cluster.Status.Services {
+		service := &cluster.Status.Services[i]
+		if !slices.Contains(services, v1beta1.AllServices) && !slices.Contains(services, service.Name) {
+			continue
+		}
+		service.RestartedAt = &now
+		log.FromContext(ctx).Info("Restarting service", "service", service.Name)
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "ServiceRestarted", "Restart of service %s requested", service.Name)
+	}
+}
+
+// rerunSchemaSetup marks the datastores schemas as not set up, and deletes the previous setup jobs
+// so the persistence reconciliation creates them again.
+func (r *TemporalClusterReconciler) rerunSchemaSetup(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	r.reconcilePersistenceStatus(cluster)
+
+	for _, name := range setupSchemaJobs {
+		job := &batchv1.Job{}
+		job.SetName(cluster.ChildResourceName(name))
+		job.SetNamespace(cluster.GetNamespace())
+		err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	for _, store := range []*v1beta1.DatastoreStatus{
+		cluster.Status.Persistence.DefaultStore,
+		cluster.Status.Persistence.VisibilityStore,
+		cluster.Status.Persistence.SecondaryVisibilityStore,
+		cluster.Status.Persistence.AdvancedVisibilityStore,
+	} {
+		if store != nil {
+			store.Setup = false
+		}
+	}
+
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "SchemaSetupRerun", "Datastores schema setup jobs scheduled again")
+
+	return nil
+}
+
+// refreshCertificates deletes the secrets of the cluster mTLS leaf certificates,
+// cert-manager then issues them again. Certificate authorities are left untouched.
+func (r *TemporalClusterReconciler) refreshCertificates(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	if !cluster.MTLSWithCertManagerEnabled() {
+		return nil
+	}
+
+	certificates := &certmanagerv1.CertificateList{}
+	err := r.List(ctx, certificates, client.InNamespace(cluster.GetNamespace()), client.MatchingFields{ownerKey: cluster.GetName()})
+	if err != nil {
+		return err
+	}
+
+	for _, certificate := range certificates.Items {
+		if certificate.Spec.IsCA {
+			continue
+		}
+
+		secret := &corev1.Secret{}
+		secret.SetName(certificate.Spec.SecretName)
+		secret.SetNamespace(certificate.GetNamespace())
+		if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		log.FromContext(ctx).Info("Refreshing certificate", "certificate", certificate.GetName())
+	}
+
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "CertificatesRefreshed", "Cluster mTLS certificates scheduled for renewal")
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	temporalconfig "github.com/alexandrevilain/temporal-operator/pkg/temporal/config"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// logAutoTuneRecommendations logs the dynamic config values derived by the auto-tuner and their rationale.
+func (r *TemporalClusterReconciler) logAutoTuneRecommendations(ctx context.Context, cluster *v1beta1.TemporalCluster) {
+	if cluster.Spec.DynamicConfig == nil || !cluster.Spec.DynamicConfig.AutoTune || cluster.Spec.Services == nil {
+		return
+	}
+
+	logger := log.FromContext(ctx)
+
+	values, err := temporalconfig.DynamicConfigToYamlDynamicConfig(cluster.Spec.DynamicConfig)
+	if err != nil {
+		logger.Error(err, "Can't compute dynamic config for auto-tuning")
+		return
+	}
+
+	for _, recommendation := range temporalconfig.AutoTuneRecommendations(cluster.Spec.NumHistoryShards, cluster.Spec.Services.History) {
+		if _, ok := values[recommendation.Key]; ok {
+			logger.Info("Auto-tuned dynamic config value overridden by spec", "key", recommendation.Key, "recommended", recommendation.Value)
+			continue
+		}
+		logger.Info("Auto-tuned dynamic config value", "key", recommendation.Key, "value", recommendation.Value, "rationale", recommendation.Rationale)
+	}
+}
@@ -0,0 +1,151 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/base"
+	"github.com/alexandrevilain/temporal-operator/pkg/status"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"go.temporal.io/server/common/primitives"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const blueGreenRequeueInterval = 10 * time.Second
+
+// startBlueGreenUpgrade starts a blue/green upgrade when the cluster uses the blue/green
+// upgrade strategy and its desired version differs from the observed one.
+func (r *TemporalClusterReconciler) startBlueGreenUpgrade(cluster *v1beta1.TemporalCluster) {
+	if !cluster.Spec.UpgradeStrategy.IsBlueGreen() {
+		// Strategy changed during the upgrade: fallback to a rolling update.
+		cluster.Status.BlueGreen = nil
+		return
+	}
+
+	targetVersion := cluster.Spec.Version.String()
+
+	if cluster.IsBlueGreenUpgradeInProgress() {
+		if cluster.Status.BlueGreen.TargetVersion != targetVersion {
+			cluster.Status.BlueGreen = &v1beta1.BlueGreenStatus{
+				TargetVersion: targetVersion,
+				Phase:         v1beta1.BlueGreenDeployingPhase,
+			}
+		}
+		return
+	}
+
+	// Nothing to upgrade for newly created clusters or clusters already running the desired version.
+	if cluster.Status.Version == "" || cluster.Status.Version == targetVersion {
+		return
+	}
+
+	cluster.Status.BlueGreen = &v1beta1.BlueGreenStatus{
+		TargetVersion: targetVersion,
+		Phase:         v1beta1.BlueGreenDeployingPhase,
+	}
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "BlueGreenUpgradeStarted", "Deploying version %s alongside version %s", targetVersion, cluster.Status.Version)
+}
+
+// blueGreenCurrentCluster returns the cluster the current deployments should be built from.
+// Until the frontend traffic is switched, the current deployments are kept on the observed version.
+func blueGreenCurrentCluster(cluster *v1beta1.TemporalCluster) (*v1beta1.TemporalCluster, error) {
+	if !cluster.IsBlueGreenUpgradeInProgress() || cluster.IsBlueGreenSwitched() {
+		return cluster, nil
+	}
+
+	currentVersion, err := version.NewVersionFromString(cluster.Status.Version)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse cluster observed version: %w", err)
+	}
+
+	current := cluster.DeepCopy()
+	current.Spec.Version = currentVersion
+
+	return current, nil
+}
+
+// blueGreenDeploymentNames returns the names of the deployments running the target version.
+func blueGreenDeploymentNames(cluster *v1beta1.TemporalCluster) []string {
+	services := []primitives.ServiceName{
+		primitives.FrontendService,
+		primitives.HistoryService,
+		primitives.MatchingService,
+		primitives.WorkerService,
+	}
+
+	if cluster.Spec.Services.InternalFrontend.IsEnabled() {
+		services = append(services, primitives.InternalFrontendService)
+	}
+
+	names := make([]string, 0, len(services))
+	for _, service := range services {
+		names = append(names, cluster.ChildResourceName(base.BlueGreenComponentName(string(service))))
+	}
+
+	return names
+}
+
+// progressBlueGreenUpgrade moves the ongoing blue/green upgrade to its next phase:
+// the frontend traffic is switched once all target deployments are ready,
+// then the upgrade completes once the current deployments are running the target version.
+func (r *TemporalClusterReconciler) progressBlueGreenUpgrade(cluster *v1beta1.TemporalCluster, objects []client.Object) (time.Duration, error) {
+	if !cluster.IsBlueGreenUpgradeInProgress() {
+		return 0, nil
+	}
+
+	switch cluster.Status.BlueGreen.Phase {
+	case v1beta1.BlueGreenDeployingPhase:
+		ready, err := status.DeploymentsReady(cluster, objects, blueGreenDeploymentNames(cluster))
+		if err != nil {
+			return 0, fmt.Errorf("can't check blue/green deployments readiness: %w", err)
+		}
+		if !ready {
+			return blueGreenRequeueInterval, nil
+		}
+
+		// Hold the switch while the read-only requests are mirrored to the ready target frontends.
+		if cluster.FrontendMirroringEnabled() {
+			if cluster.Status.BlueGreen.MirroringStartTime == nil {
+				cluster.Status.BlueGreen.MirroringStartTime = &metav1.Time{Time: time.Now()}
+				r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "FrontendMirroringStarted", "Mirroring frontend read-only requests to version %s", cluster.Status.BlueGreen.TargetVersion)
+			}
+			remaining := time.Until(cluster.Status.BlueGreen.MirroringStartTime.Add(cluster.Spec.Services.Frontend.Proxy.Mirroring.GetDuration()))
+			if remaining > 0 {
+				return min(remaining, blueGreenRequeueInterval), nil
+			}
+		}
+
+		cluster.Status.BlueGreen.Phase = v1beta1.BlueGreenSwitchedPhase
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "BlueGreenTrafficSwitched", "Frontend traffic switched to version %s", cluster.Status.BlueGreen.TargetVersion)
+	case v1beta1.BlueGreenSwitchedPhase:
+		if !status.ObservedVersionMatchesDesiredVersion(cluster) || !cluster.IsReady() {
+			return blueGreenRequeueInterval, nil
+		}
+
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "BlueGreenUpgradeCompleted", "Version %s rolled out", cluster.Status.BlueGreen.TargetVersion)
+		cluster.Status.BlueGreen = nil
+	}
+
+	// Requeue shortly so the resources reflect the new phase.
+	return time.Second, nil
+}
@@ -0,0 +1,98 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileCanary runs the canary workflow once per interval, if enabled, and reports its result
+// in status.canary, the CanaryHealthy condition and the operator metrics.
+// It returns the duration after which the canary should be run again.
+func (r *TemporalClusterReconciler) reconcileCanary(ctx context.Context, cluster *v1beta1.TemporalCluster) time.Duration {
+	spec := cluster.Spec.Canary
+	if !spec.IsEnabled() {
+		cluster.Status.Canary = nil
+		apimeta.RemoveStatusCondition(&cluster.Status.Conditions, v1beta1.CanaryHealthyCondition)
+		return 0
+	}
+
+	interval := spec.GetInterval()
+
+	if !cluster.IsReady() {
+		return interval
+	}
+
+	// Avoid running the canary on every reconciliation.
+	if cluster.Status.Canary != nil && time.Since(cluster.Status.Canary.LastRunTime.Time) < interval {
+		return interval - time.Since(cluster.Status.Canary.LastRunTime.Time)
+	}
+
+	start := time.Now()
+	err := r.runCanary(ctx, cluster)
+	latency := time.Since(start)
+
+	metrics.ObserveCanaryRun(cluster.GetNamespace(), cluster.GetName(), latency, err)
+
+	if cluster.Status.Canary == nil {
+		cluster.Status.Canary = &v1beta1.CanaryStatus{}
+	}
+	status := cluster.Status.Canary
+	status.LastRunTime = metav1.NewTime(start)
+
+	if err != nil {
+		log.FromContext(ctx).Info("Canary failed", "error", err.Error())
+		status.ConsecutiveFailures++
+		status.Message = err.Error()
+		v1beta1.SetTemporalClusterCanaryHealthy(cluster, metav1.ConditionFalse, v1beta1.CanaryFailedReason, err.Error())
+		return interval
+	}
+
+	lastSuccessTime := status.LastRunTime
+	status.LastSuccessTime = &lastSuccessTime
+	status.Latency = &metav1.Duration{Duration: latency}
+	status.ConsecutiveFailures = 0
+	status.Message = ""
+	v1beta1.SetTemporalClusterCanaryHealthy(cluster, metav1.ConditionTrue, v1beta1.CanarySucceededReason,
+		fmt.Sprintf("Canary workflow completed in %s", latency.Round(time.Millisecond)))
+
+	return interval
+}
+
+func (r *TemporalClusterReconciler) runCanary(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	ctx, cancel := context.WithTimeout(ctx, cluster.Spec.Canary.GetTimeout())
+	defer cancel()
+
+	namespace := cluster.Spec.Canary.GetNamespace()
+
+	client, err := r.ClientManager.Client(ctx, cluster, namespace)
+	if err != nil {
+		return fmt.Errorf("can't create cluster client: %w", err)
+	}
+
+	return temporal.RunCanary(ctx, client, namespace)
+}
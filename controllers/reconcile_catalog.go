@@ -0,0 +1,124 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/ui"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// catalogBuilder wraps a resource builder to attach the built objects, and the pods they manage,
+// to the cluster catalog entity using the backstage.io/kubernetes-id label.
+type catalogBuilder struct {
+	resource.Builder
+	cluster *v1beta1.TemporalCluster
+}
+
+func withCatalogMetadata(cluster *v1beta1.TemporalCluster, builders []resource.Builder) []resource.Builder {
+	if !cluster.Spec.Catalog.IsEnabled() {
+		return builders
+	}
+
+	result := make([]resource.Builder, 0, len(builders))
+	for _, builder := range builders {
+		result = append(result, &catalogBuilder{
+			Builder: builder,
+			cluster: cluster,
+		})
+	}
+	return result
+}
+
+func (b *catalogBuilder) Update(object client.Object) error {
+	err := b.Builder.Update(object)
+	if err != nil {
+		return err
+	}
+
+	labels := map[string]string{
+		v1beta1.BackstageKubernetesIDLabel: b.cluster.CatalogComponentID(),
+	}
+
+	object.SetLabels(metadata.Merge(object.GetLabels(), labels))
+
+	switch o := object.(type) {
+	case *appsv1.Deployment:
+		o.Spec.Template.Labels = metadata.Merge(o.Spec.Template.Labels, labels)
+	case *batchv1.CronJob:
+		o.Spec.JobTemplate.Spec.Template.Labels = metadata.Merge(o.Spec.JobTemplate.Spec.Template.Labels, labels)
+	case *batchv1.Job:
+		// Jobs pod templates are immutable, only label the pods of jobs being created.
+		if o.GetResourceVersion() == "" {
+			o.Spec.Template.Labels = metadata.Merge(o.Spec.Template.Labels, labels)
+		}
+	}
+
+	return nil
+}
+
+// catalogStatus returns the cluster metadata published for developer portals, or nil if the catalog isn't enabled.
+func catalogStatus(cluster *v1beta1.TemporalCluster, namespaces []v1beta1.TemporalNamespace) *v1beta1.CatalogStatus {
+	if !cluster.Spec.Catalog.IsEnabled() {
+		return nil
+	}
+
+	result := &v1beta1.CatalogStatus{
+		ComponentID:       cluster.CatalogComponentID(),
+		FrontendAddress:   fmt.Sprintf("%s.%s:%d", cluster.ChildResourceName(meta.FrontendService), cluster.FQDNSuffix(), *cluster.Spec.Services.Frontend.Port),
+		FrontendHostnames: cluster.Spec.Expose.GetFrontendHostnames(),
+		UIURL:             uiURL(cluster),
+	}
+
+	for _, namespace := range namespaces {
+		result.Namespaces = append(result.Namespaces, namespace.GetName())
+	}
+	sort.Strings(result.Namespaces)
+
+	return result
+}
+
+// uiURL returns the URL of the cluster UI: its first public host when exposed, its in-cluster service otherwise.
+func uiURL(cluster *v1beta1.TemporalCluster) string {
+	spec := cluster.Spec.UI
+	if spec == nil || !spec.Enabled {
+		return ""
+	}
+
+	if spec.Ingress != nil && len(spec.Ingress.Hosts) > 0 {
+		scheme := "http"
+		if len(spec.Ingress.TLS) > 0 {
+			scheme = "https"
+		}
+		return fmt.Sprintf("%s://%s%s", scheme, spec.Ingress.Hosts[0], spec.PublicPath)
+	}
+
+	if hostnames := cluster.Spec.Expose.GetUIHostnames(); len(hostnames) > 0 {
+		return fmt.Sprintf("http://%s%s", hostnames[0], spec.PublicPath)
+	}
+
+	return fmt.Sprintf("http://%s.%s:%d%s", cluster.ChildResourceName("ui"), cluster.FQDNSuffix(), ui.UIServicePort, spec.PublicPath)
+}
@@ -0,0 +1,86 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// datastoreRetryIn returns how long the cluster persistence reconciliation should wait before being retried.
+// It returns 0 if the datastore circuit breaker is closed or if its cool-down period elapsed.
+// A spec change resets the circuit breaker, allowing users to fix the persistence configuration without waiting.
+func (r *TemporalClusterReconciler) datastoreRetryIn(cluster *v1beta1.TemporalCluster, specChanged bool) time.Duration {
+	status := cluster.Status.Persistence
+	if status == nil || status.ConsecutiveFailures == 0 {
+		return 0
+	}
+
+	if specChanged {
+		status.ConsecutiveFailures = 0
+		status.LastFailureTime = nil
+		return 0
+	}
+
+	if status.LastFailureTime == nil {
+		return 0
+	}
+
+	return r.DatastoreBackoff.RetryIn(status.ConsecutiveFailures, status.LastFailureTime.Time, time.Now())
+}
+
+// recordDatastoreFailure records a failed persistence reconciliation and returns the delay before the next attempt.
+func (r *TemporalClusterReconciler) recordDatastoreFailure(ctx context.Context, cluster *v1beta1.TemporalCluster, err error) time.Duration {
+	status := cluster.Status.Persistence
+	if status == nil {
+		status = new(v1beta1.TemporalPersistenceStatus)
+		cluster.Status.Persistence = status
+	}
+
+	now := metav1.Now()
+	status.ConsecutiveFailures++
+	status.LastFailureTime = &now
+
+	delay := r.DatastoreBackoff.Delay(status.ConsecutiveFailures)
+
+	if r.DatastoreBackoff.IsOpen(status.ConsecutiveFailures) {
+		message := fmt.Sprintf("Persistence reconciliation failed %d times in a row, next attempt in %s: %v", status.ConsecutiveFailures, delay, err)
+		log.FromContext(ctx).Info("Datastore circuit breaker is open", "failures", status.ConsecutiveFailures, "retryIn", delay)
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, v1beta1.DatastoreCircuitOpenReason, message)
+		v1beta1.SetTemporalClusterDatastoreAvailable(cluster, metav1.ConditionFalse, v1beta1.DatastoreCircuitOpenReason, message)
+		return delay
+	}
+
+	v1beta1.SetTemporalClusterDatastoreAvailable(cluster, metav1.ConditionFalse, v1beta1.DatastoreFailingReason, err.Error())
+	return delay
+}
+
+// recordDatastoreSuccess resets the datastore circuit breaker after a successful persistence reconciliation.
+func (r *TemporalClusterReconciler) recordDatastoreSuccess(cluster *v1beta1.TemporalCluster) {
+	if cluster.Status.Persistence != nil {
+		cluster.Status.Persistence.ConsecutiveFailures = 0
+		cluster.Status.Persistence.LastFailureTime = nil
+	}
+	v1beta1.SetTemporalClusterDatastoreAvailable(cluster, metav1.ConditionTrue, v1beta1.DatastoreAvailableReason, "")
+}
@@ -0,0 +1,66 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"golang.org/x/exp/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// clusterClientsClaimRules returns the certificate claim mapper rules granting the permissions requested by the
+// TemporalClusterClients referencing the cluster. It returns no rules if the cluster doesn't allow client permissions.
+func (r *TemporalClusterReconciler) clusterClientsClaimRules(ctx context.Context, cluster *v1beta1.TemporalCluster) ([]v1beta1.CertificateClaimMapperRule, error) {
+	if !cluster.Spec.Authorization.ClientPermissionsAllowed() {
+		return nil, nil
+	}
+
+	clusterClients := &v1beta1.TemporalClusterClientList{}
+	err := r.List(ctx, clusterClients, client.MatchingFields{clusterRefNameField: cluster.GetName()})
+	if err != nil {
+		return nil, fmt.Errorf("can't list cluster clients: %w", err)
+	}
+
+	rules := []v1beta1.CertificateClaimMapperRule{}
+	for i := range clusterClients.Items {
+		clusterClient := &clusterClients.Items[i]
+		if clusterClient.Spec.ClusterRef.NamespacedName(clusterClient) != client.ObjectKeyFromObject(cluster) {
+			continue
+		}
+		if len(clusterClient.Spec.Permissions) == 0 || !clusterClient.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		rules = append(rules, v1beta1.CertificateClaimMapperRule{
+			DNSName:    certmanager.GenericFrontendClientDNSName(cluster, clusterClient.GetName()),
+			Namespaces: clusterClient.Spec.Permissions,
+		})
+	}
+
+	// Sort rules to keep the rendered configuration, and so its hash, stable.
+	slices.SortFunc(rules, func(a, b v1beta1.CertificateClaimMapperRule) int {
+		return strings.Compare(a.DNSName, b.DNSName)
+	})
+
+	return rules, nil
+}
@@ -0,0 +1,147 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// clusterInfoCheckInterval is the interval between two retrievals of the cluster metadata.
+	clusterInfoCheckInterval = 5 * time.Minute
+	// clusterInfoMismatchReason is the reason of the event emitted when the cluster metadata doesn't match the spec.
+	clusterInfoMismatchReason = "ClusterInfoMismatch"
+)
+
+// reconcileClusterInfo retrieves the metadata of the running cluster and reports it in status.clusterInfo,
+// so differences with the spec, for instance after a restore from backup, are visible.
+// The metadata is retrieved at most every clusterInfoCheckInterval, unless force is true.
+// It returns the duration after which the metadata should be retrieved again.
+func (r *TemporalClusterReconciler) reconcileClusterInfo(ctx context.Context, cluster *v1beta1.TemporalCluster, force bool) time.Duration {
+	if !cluster.IsReady() {
+		return clusterInfoCheckInterval
+	}
+
+	// Avoid querying the cluster on every reconciliation.
+	previous := cluster.Status.ClusterInfo
+	if !force && previous != nil && time.Since(previous.LastCheckTime.Time) < clusterInfoCheckInterval {
+		return clusterInfoCheckInterval - time.Since(previous.LastCheckTime.Time)
+	}
+
+	client, err := r.ClientManager.Client(ctx, cluster, "")
+	if err != nil {
+		log.FromContext(ctx).Info("Can't get cluster info", "error", err.Error())
+		return clusterInfoCheckInterval
+	}
+
+	info, err := temporal.GetClusterInfo(ctx, client.WorkflowService(), client.OperatorService())
+	if err != nil {
+		log.FromContext(ctx).Info("Can't get cluster info", "error", err.Error())
+		return clusterInfoCheckInterval
+	}
+
+	mismatches := clusterInfoMismatches(cluster, info)
+
+	tags, err := r.clusterTags(ctx, cluster)
+	if err != nil {
+		log.FromContext(ctx).Info("Can't get cluster tags", "error", err.Error())
+	} else {
+		info.Tags = tags
+		// Tags are persisted by the services on startup, they differ from the spec until the services are rolled.
+		if expected := cluster.Spec.ClusterMetadata.GetTags(); !maps.Equal(tags, expected) {
+			mismatches = append(mismatches, fmt.Sprintf("tags are %v, expected %v", tags, expected))
+		}
+	}
+
+	info.Message = strings.Join(mismatches, "; ")
+	// Only emit an event when the mismatch is detected.
+	if info.Message != "" && (previous == nil || previous.Message != info.Message) {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, clusterInfoMismatchReason, info.Message)
+	}
+
+	cluster.Status.ClusterInfo = info
+
+	return clusterInfoCheckInterval
+}
+
+// clusterTags returns the cluster metadata tags reported by the admin API of the running cluster.
+func (r *TemporalClusterReconciler) clusterTags(ctx context.Context, cluster *v1beta1.TemporalCluster) (map[string]string, error) {
+	admin, conn, err := temporal.GetClusterAdminClient(ctx, r.Client, cluster, r.ClientManager.DialOptions(cluster)...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return temporal.GetClusterTags(ctx, admin)
+}
+
+// checkClusterMetadata reports in the MetadataMismatch condition whether the cluster name and history shard count
+// persisted by the cluster match its spec. It returns false when they don't, as rolling pods with a configuration
+// disagreeing with the persisted metadata, typically after restoring the database of another cluster, can't be undone.
+func checkClusterMetadata(cluster *v1beta1.TemporalCluster) bool {
+	if cluster.Status.ClusterInfo == nil {
+		return true
+	}
+
+	mismatches := clusterMetadataMismatches(cluster, cluster.Status.ClusterInfo)
+	if len(mismatches) > 0 {
+		v1beta1.SetTemporalClusterMetadataMismatch(cluster, metav1.ConditionTrue, v1beta1.MetadataMismatchReason,
+			fmt.Sprintf("Refusing to update the cluster pods: %s", strings.Join(mismatches, "; ")))
+		return false
+	}
+
+	v1beta1.SetTemporalClusterMetadataMismatch(cluster, metav1.ConditionFalse, v1beta1.MetadataMatchesReason, "")
+	return true
+}
+
+// clusterInfoMismatches returns the differences between the metadata reported by the cluster and its spec.
+func clusterInfoMismatches(cluster *v1beta1.TemporalCluster, info *v1beta1.ClusterInfoStatus) []string {
+	mismatches := clusterMetadataMismatches(cluster, info)
+
+	expectedFailoverVersion := cluster.Spec.Replication.GetInitialFailoverVersion()
+	if info.InitialFailoverVersion != 0 && info.InitialFailoverVersion != expectedFailoverVersion {
+		mismatches = append(mismatches, fmt.Sprintf("initial failover version is %d, expected %d", info.InitialFailoverVersion, expectedFailoverVersion))
+	}
+
+	return mismatches
+}
+
+// clusterMetadataMismatches returns the differences between the immutable metadata persisted by the cluster and its spec.
+func clusterMetadataMismatches(cluster *v1beta1.TemporalCluster, info *v1beta1.ClusterInfoStatus) []string {
+	mismatches := []string{}
+
+	if info.ClusterName != "" && info.ClusterName != cluster.GetName() {
+		mismatches = append(mismatches, fmt.Sprintf("cluster name is %q, expected %q", info.ClusterName, cluster.GetName()))
+	}
+
+	if info.HistoryShardCount != 0 && info.HistoryShardCount != cluster.Spec.NumHistoryShards {
+		mismatches = append(mismatches, fmt.Sprintf("history shard count is %d, expected %d", info.HistoryShardCount, cluster.Spec.NumHistoryShards))
+	}
+
+	return mismatches
+}
@@ -0,0 +1,128 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/admintools"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/ui"
+	"github.com/alexandrevilain/temporal-operator/pkg/status"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// clusterComponent is an optional cluster component, reconciled independently of the temporal services.
+type clusterComponent struct {
+	// name is the name of the component deployment, relative to the cluster.
+	name string
+	// condition is the type of the condition reporting the component readiness.
+	condition string
+	enabled   bool
+	builders  []resource.Builder
+	// unstructuredBuilders build objects whose types aren't registered in the scheme, like openshift routes.
+	unstructuredBuilders []resource.Builder
+}
+
+// components returns the optional components of the provided cluster.
+func (r *TemporalClusterReconciler) components(cluster *v1beta1.TemporalCluster, configHash string) []clusterComponent {
+	uiBuilders := []resource.Builder{
+		ui.NewDeploymentBuilder(cluster, r.Scheme, configHash),
+		ui.NewServiceBuilder(cluster, r.Scheme),
+		ui.NewIngressBuilder(cluster, r.Scheme),
+		ui.NewFrontendClientCertificateBuilder(cluster, r.Scheme),
+	}
+
+	uiRouteBuilders := []resource.Builder{}
+	if r.AvailableAPIs.Routes {
+		// Always add the first route builder so that the route is removed when the ui is no longer exposed.
+		routes := 1
+		if cluster.Spec.UI != nil && cluster.Spec.UI.Ingress != nil {
+			routes = max(routes, len(cluster.Spec.UI.Ingress.Hosts))
+		}
+		for i := 0; i < routes; i++ {
+			uiRouteBuilders = append(uiRouteBuilders, ui.NewRouteBuilder(cluster, r.Scheme, i))
+		}
+	}
+
+	return []clusterComponent{
+		{
+			name:                 "ui",
+			condition:            v1beta1.UIReadyCondition,
+			enabled:              cluster.Spec.UI != nil && cluster.Spec.UI.Enabled,
+			builders:             uiBuilders,
+			unstructuredBuilders: uiRouteBuilders,
+		},
+		{
+			name:      "admintools",
+			condition: v1beta1.AdminToolsReadyCondition,
+			enabled:   cluster.Spec.AdminTools != nil && cluster.Spec.AdminTools.Enabled,
+			builders: []resource.Builder{
+				admintools.NewDeploymentBuilder(cluster, r.Scheme, configHash),
+				admintools.NewFrontendClientCertificateBuilder(cluster, r.Scheme),
+			},
+		},
+	}
+}
+
+// reconcileComponents reconciles the optional components of the cluster.
+// Each component readiness is reported in its own condition: a failing component doesn't fail
+// the cluster reconciliation nor affect the cluster Ready condition.
+func (r *TemporalClusterReconciler) reconcileComponents(ctx context.Context, cluster *v1beta1.TemporalCluster, configHash string, specChanged bool, gate *rolloutGate) {
+	logger := log.FromContext(ctx)
+
+	for _, component := range r.components(cluster, configHash) {
+		// Disabled components builders are still reconciled to delete their resources.
+		objects, err := r.Reconciler.ReconcileBuilders(ctx, cluster, withSemanticEquality(cluster, specChanged, gate, nil, withCatalogMetadata(cluster, component.builders)))
+		if err == nil {
+			err = r.reconcileUnstructuredBuilders(ctx, withCatalogMetadata(cluster, component.unstructuredBuilders))
+		}
+		if !component.enabled {
+			apimeta.RemoveStatusCondition(&cluster.Status.Conditions, component.condition)
+			if err != nil {
+				logger.Error(err, "Can't delete disabled component resources", "component", component.name)
+			}
+			continue
+		}
+
+		if err != nil {
+			logger.Error(err, "Can't reconcile component", "component", component.name)
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, v1beta1.ComponentReconcileFailedReason, fmt.Sprintf("Can't reconcile %s: %s", component.name, err))
+			v1beta1.SetTemporalClusterComponentReady(cluster, component.condition, metav1.ConditionFalse, v1beta1.ComponentReconcileFailedReason, err.Error())
+			continue
+		}
+
+		ready, err := status.DeploymentsReady(cluster, objects, []string{cluster.ChildResourceName(component.name)})
+		if err != nil {
+			v1beta1.SetTemporalClusterComponentReady(cluster, component.condition, metav1.ConditionUnknown, v1beta1.ComponentReconcileFailedReason, err.Error())
+			continue
+		}
+
+		if !ready {
+			v1beta1.SetTemporalClusterComponentReady(cluster, component.condition, metav1.ConditionFalse, v1beta1.ComponentNotReadyReason, "")
+			continue
+		}
+
+		v1beta1.SetTemporalClusterComponentReady(cluster, component.condition, metav1.ConditionTrue, v1beta1.ComponentReadyReason, "")
+	}
+}
@@ -0,0 +1,220 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// crashDumpLogsKey is the key of the crash dump ConfigMap holding the crashed container logs.
+	crashDumpLogsKey = "previous.log"
+	// crashDumpTerminationKey is the key of the crash dump ConfigMap holding the container termination state.
+	crashDumpTerminationKey = "termination.txt"
+	// crashDumpMembershipKey is the key of the crash dump ConfigMap holding the cluster membership rings.
+	crashDumpMembershipKey = "membership.txt"
+
+	// maxCrashDumpLogsSize keeps the bundle below the ConfigMap size limit. The most recent logs are kept.
+	maxCrashDumpLogsSize = 900 * 1024
+)
+
+// crashDumpCollected returns true if a bundle was already collected for the provided pod container.
+func crashDumpCollected(cluster *v1beta1.TemporalCluster, pod, container string) bool {
+	for _, dump := range cluster.Status.CrashDumps {
+		if dump.Pod == pod && dump.Container == container {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileCrashDumps collects a debug bundle from the services containers which restarted more than
+// the configured threshold, before their pods are replaced. Bundles are stored in ConfigMaps,
+// listed in status.crashDumps, and a single bundle is collected per pod container.
+// Only the most recent bundles are kept.
+func (r *TemporalClusterReconciler) reconcileCrashDumps(ctx context.Context, cluster *v1beta1.TemporalCluster) {
+	spec := cluster.Spec.CrashDump
+	if !spec.IsEnabled() {
+		cluster.Status.CrashDumps = nil
+		return
+	}
+
+	if r.Clientset == nil {
+		return
+	}
+
+	pods := &corev1.PodList{}
+	err := r.List(ctx, pods, client.InNamespace(cluster.GetNamespace()), client.MatchingLabels(cluster.SelectorLabels()))
+	if err != nil {
+		log.FromContext(ctx).Info("Can't list services pods", "error", err.Error())
+		return
+	}
+
+	services := map[string]bool{}
+	for _, service := range spec.GetServices() {
+		services[service] = true
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !services[pod.Labels["app.kubernetes.io/component"]] {
+			continue
+		}
+
+		for _, container := range pod.Status.ContainerStatuses {
+			if container.Name != "service" || container.RestartCount < spec.GetRestartThreshold() || container.LastTerminationState.Terminated == nil {
+				continue
+			}
+
+			if crashDumpCollected(cluster, pod.GetName(), container.Name) {
+				continue
+			}
+
+			err := r.collectCrashDump(ctx, cluster, pod, container)
+			if err != nil {
+				log.FromContext(ctx).Info("Can't collect crash dump", "pod", pod.GetName(), "container", container.Name, "error", err.Error())
+			}
+		}
+	}
+
+	r.pruneCrashDumps(ctx, cluster, spec.GetMaxBundles())
+}
+
+// collectCrashDump stores the crashed container logs, its termination state and the cluster membership rings
+// in the "<pod>-crash-dump" ConfigMap.
+func (r *TemporalClusterReconciler) collectCrashDump(ctx context.Context, cluster *v1beta1.TemporalCluster, pod *corev1.Pod, container corev1.ContainerStatus) error {
+	logLines := cluster.Spec.CrashDump.GetLogLines()
+	logs, err := r.Clientset.CoreV1().Pods(pod.GetNamespace()).GetLogs(pod.GetName(), &corev1.PodLogOptions{
+		Container: container.Name,
+		Previous:  true,
+		TailLines: &logLines,
+	}).DoRaw(ctx)
+	if err != nil {
+		return fmt.Errorf("can't get previous container logs: %w", err)
+	}
+	if len(logs) > maxCrashDumpLogsSize {
+		logs = logs[len(logs)-maxCrashDumpLogsSize:]
+	}
+
+	terminated := container.LastTerminationState.Terminated
+	termination := fmt.Sprintf("pod: %s\nnode: %s\ncontainer: %s\nrestarts: %d\nexit code: %d\nreason: %s\nmessage: %s\nstarted at: %s\nfinished at: %s\n",
+		pod.GetName(), pod.Spec.NodeName, container.Name, container.RestartCount, terminated.ExitCode, terminated.Reason, terminated.Message,
+		terminated.StartedAt.Format(time.RFC3339), terminated.FinishedAt.Format(time.RFC3339))
+
+	data := map[string]string{
+		crashDumpLogsKey:        string(logs),
+		crashDumpTerminationKey: termination,
+	}
+
+	// The membership rings tell whether the crashed host was still considered a member of the cluster.
+	membership, err := r.clusterMembership(ctx, cluster)
+	if err != nil {
+		data[crashDumpMembershipKey] = fmt.Sprintf("can't get cluster membership: %s\n", err.Error())
+	} else {
+		data[crashDumpMembershipKey] = membership
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.GetName() + "-crash-dump",
+			Namespace: cluster.GetNamespace(),
+		},
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = metadata.GetLabels(cluster, "crash-dump", cluster.Spec.Version, cluster.Labels)
+		configMap.Data = data
+		return controllerutil.SetControllerReference(cluster, configMap, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("can't store crash dump: %w", err)
+	}
+
+	cluster.Status.CrashDumps = append(cluster.Status.CrashDumps, v1beta1.CrashDumpStatus{
+		Pod:            pod.GetName(),
+		Container:      container.Name,
+		RestartCount:   container.RestartCount,
+		BundleRef:      corev1.LocalObjectReference{Name: configMap.GetName()},
+		CollectionTime: metav1.Now(),
+	})
+
+	log.FromContext(ctx).Info("Crash dump collected", "pod", pod.GetName(), "container", container.Name, "bundle", configMap.GetName())
+	r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "CrashDumpCollected",
+		"Container %s of pod %s restarted %d times, debug bundle stored in ConfigMap %s", container.Name, pod.GetName(), container.RestartCount, configMap.GetName())
+
+	return nil
+}
+
+// clusterMembership returns the cluster membership rings as seen by the cluster frontend.
+func (r *TemporalClusterReconciler) clusterMembership(ctx context.Context, cluster *v1beta1.TemporalCluster) (string, error) {
+	admin, conn, err := temporal.GetClusterAdminClient(ctx, r.Client, cluster, r.ClientManager.DialOptions(cluster)...)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	return temporal.GetMembership(ctx, admin)
+}
+
+// pruneCrashDumps deletes the oldest bundles when more than maxBundles were collected.
+func (r *TemporalClusterReconciler) pruneCrashDumps(ctx context.Context, cluster *v1beta1.TemporalCluster, maxBundles int) {
+	dumps := cluster.Status.CrashDumps
+	if len(dumps) <= maxBundles {
+		return
+	}
+
+	pruned := []string{}
+	for _, dump := range dumps[:len(dumps)-maxBundles] {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      dump.BundleRef.Name,
+				Namespace: cluster.GetNamespace(),
+			},
+		}
+		err := r.Delete(ctx, configMap)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.FromContext(ctx).Info("Can't delete crash dump", "bundle", dump.BundleRef.Name, "error", err.Error())
+			continue
+		}
+		pruned = append(pruned, dump.BundleRef.Name)
+	}
+
+	kept := []v1beta1.CrashDumpStatus{}
+	for _, dump := range dumps {
+		if !slices.Contains(pruned, dump.BundleRef.Name) {
+			kept = append(kept, dump)
+		}
+	}
+	cluster.Status.CrashDumps = kept
+
+	log.FromContext(ctx).Info("Crash dumps pruned", "bundles", strings.Join(pruned, ", "))
+}
@@ -0,0 +1,170 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporalclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func crashingPod(cluster *v1beta1.TemporalCluster, name, service string, restarts int32) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster.GetNamespace(),
+			Labels:    metadata.LabelsSelector(cluster, service),
+		},
+		Spec: corev1.PodSpec{NodeName: "node-1"},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:         "service",
+					RestartCount: restarts,
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestReconcileCrashDumps(t *testing.T) {
+	tests := map[string]struct {
+		crashDump         *v1beta1.CrashDumpSpec
+		previous          []v1beta1.CrashDumpStatus
+		pods              func(cluster *v1beta1.TemporalCluster) []client.Object
+		expectedBundles   []string
+		expectedDeleted   []string
+		expectedCollected []string
+	}{
+		"disabled": {
+			previous: []v1beta1.CrashDumpStatus{
+				{Pod: "fake-history-0", Container: "service", BundleRef: corev1.LocalObjectReference{Name: "fake-history-0-crash-dump"}},
+			},
+			pods: func(cluster *v1beta1.TemporalCluster) []client.Object {
+				return []client.Object{crashingPod(cluster, "fake-history-1", "history", 5)}
+			},
+		},
+		"collects crash looping watched services containers": {
+			crashDump: &v1beta1.CrashDumpSpec{Enabled: true},
+			pods: func(cluster *v1beta1.TemporalCluster) []client.Object {
+				return []client.Object{
+					crashingPod(cluster, "fake-history-0", "history", 5),
+					crashingPod(cluster, "fake-matching-0", "matching", 1),
+					crashingPod(cluster, "fake-frontend-0", "frontend", 5),
+				}
+			},
+			expectedBundles:   []string{"fake-history-0-crash-dump"},
+			expectedCollected: []string{"fake-history-0-crash-dump"},
+		},
+		"collects a single bundle per pod container": {
+			crashDump: &v1beta1.CrashDumpSpec{Enabled: true},
+			previous: []v1beta1.CrashDumpStatus{
+				{Pod: "fake-history-0", Container: "service", BundleRef: corev1.LocalObjectReference{Name: "fake-history-0-crash-dump"}},
+			},
+			pods: func(cluster *v1beta1.TemporalCluster) []client.Object {
+				return []client.Object{crashingPod(cluster, "fake-history-0", "history", 8)}
+			},
+			expectedBundles: []string{"fake-history-0-crash-dump"},
+		},
+		"prunes the oldest bundles": {
+			crashDump: &v1beta1.CrashDumpSpec{Enabled: true, MaxBundles: ptr.To[int32](1)},
+			previous: []v1beta1.CrashDumpStatus{
+				{Pod: "fake-history-old", Container: "service", BundleRef: corev1.LocalObjectReference{Name: "fake-history-old-crash-dump"}},
+			},
+			pods: func(cluster *v1beta1.TemporalCluster) []client.Object {
+				return []client.Object{
+					crashingPod(cluster, "fake-history-0", "history", 5),
+					&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "fake-history-old-crash-dump", Namespace: cluster.GetNamespace()}},
+				}
+			},
+			expectedBundles:   []string{"fake-history-0-crash-dump"},
+			expectedDeleted:   []string{"fake-history-old-crash-dump"},
+			expectedCollected: []string{"fake-history-0-crash-dump"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			ctx := context.Background()
+
+			// Frontend mTLS without the client certificate secret: the membership can't be retrieved.
+			cluster := &v1beta1.TemporalCluster{
+				TypeMeta:   v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: "fake", Namespace: "default"},
+				Spec: v1beta1.TemporalClusterSpec{
+					CrashDump: test.crashDump,
+					MTLS: &v1beta1.MTLSSpec{
+						Provider: v1beta1.CertManagerMTLSProvider,
+						Frontend: &v1beta1.FrontendMTLSSpec{Enabled: true},
+					},
+				},
+				Status: v1beta1.TemporalClusterStatus{
+					CrashDumps: test.previous,
+				},
+			}
+
+			base := newFakeBase(tt, append(test.pods(cluster), cluster)...)
+			r := &TemporalClusterReconciler{
+				Base:          base,
+				ClientManager: temporalclient.NewManager(base.Client, &temporalclient.CallOptions{}),
+				Clientset:     kubernetesfake.NewSimpleClientset(),
+			}
+
+			r.reconcileCrashDumps(ctx, cluster)
+
+			bundles := []string{}
+			for _, dump := range cluster.Status.CrashDumps {
+				bundles = append(bundles, dump.BundleRef.Name)
+			}
+			if test.expectedBundles == nil {
+				assert.Empty(tt, cluster.Status.CrashDumps)
+			} else {
+				assert.Equal(tt, test.expectedBundles, bundles)
+			}
+
+			for _, name := range test.expectedDeleted {
+				err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &corev1.ConfigMap{})
+				assert.True(tt, apierrors.IsNotFound(err), "bundle %s should be deleted", name)
+			}
+
+			for _, name := range test.expectedCollected {
+				configMap := &corev1.ConfigMap{}
+				require.NoError(tt, r.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, configMap))
+
+				assert.Equal(tt, "fake logs", configMap.Data[crashDumpLogsKey])
+				assert.Contains(tt, configMap.Data[crashDumpTerminationKey], "restarts: 5\nexit code: 137\nreason: OOMKilled\n")
+				assert.Contains(tt, configMap.Data[crashDumpMembershipKey], "can't get cluster membership")
+				assert.True(tt, metav1.IsControlledBy(configMap, cluster))
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/status"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// crashLoopCheckInterval is the interval between two crash loop checks while a service is degraded.
+const crashLoopCheckInterval = time.Minute
+
+// reconcileServiceDegraded reports the crash looping containers of the cluster's services in the ServiceDegraded condition,
+// with their last termination reason and message.
+// A warning event is recorded each time the reported crashes change.
+func (r *TemporalClusterReconciler) reconcileServiceDegraded(ctx context.Context, cluster *v1beta1.TemporalCluster) time.Duration {
+	pods := &corev1.PodList{}
+	err := r.List(ctx, pods, client.InNamespace(cluster.GetNamespace()), client.MatchingLabels(cluster.SelectorLabels()))
+	if err != nil {
+		log.FromContext(ctx).Info("Can't list services pods", "error", err.Error())
+		return crashLoopCheckInterval
+	}
+
+	crashes := status.CrashLoopingContainers(pods.Items)
+	if len(crashes) == 0 {
+		v1beta1.SetTemporalClusterServiceDegraded(cluster, metav1.ConditionFalse, v1beta1.ContainersHealthyReason, "")
+		return 0
+	}
+
+	messages := make([]string, 0, len(crashes))
+	for _, crash := range crashes {
+		messages = append(messages, crash.String())
+	}
+	message := strings.Join(messages, "; ")
+
+	previous := apimeta.FindStatusCondition(cluster.Status.Conditions, v1beta1.ServiceDegradedCondition)
+	if previous == nil || previous.Status != metav1.ConditionTrue || previous.Message != message {
+		for _, crash := range crashes {
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, "ContainerRestartStorm", crash.String())
+		}
+	}
+
+	v1beta1.SetTemporalClusterServiceDegraded(cluster, metav1.ConditionTrue, v1beta1.ContainersCrashLoopingReason, message)
+
+	return crashLoopCheckInterval
+}
+
+// podToClusterMapfunc enqueues the cluster owning the temporal service pod.
+func (r *TemporalClusterReconciler) podToClusterMapfunc(_ context.Context, o client.Object) []reconcile.Request {
+	labels := o.GetLabels()
+	name, ok := labels["app.kubernetes.io/name"]
+	if !ok || labels["app.kubernetes.io/part-of"] != "temporal" {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: name},
+		},
+	}
+}
+
+// podRestartsChangedPredicate only accepts pods updates changing their containers restart count or waiting reason.
+func podRestartsChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, ok := e.ObjectOld.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+			newPod, ok := e.ObjectNew.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+			return podRestartsSummary(oldPod) != podRestartsSummary(newPod)
+		},
+	}
+}
+
+// podRestartsSummary returns a string summarizing the restart count and waiting reason of the pod containers.
+func podRestartsSummary(pod *corev1.Pod) string {
+	var b strings.Builder
+	for _, container := range pod.Status.ContainerStatuses {
+		b.WriteString(container.Name)
+		b.WriteString(":")
+		b.WriteString(strconv.Itoa(int(container.RestartCount)))
+		if container.State.Waiting != nil {
+			b.WriteString(":")
+			b.WriteString(container.State.Waiting.Reason)
+		}
+		b.WriteString(";")
+	}
+	return b.String()
+}
@@ -0,0 +1,114 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/grpcweb"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// datastoreMigrationCheckInterval is the interval at which the services scale down is checked before migrating datastores.
+const datastoreMigrationCheckInterval = 10 * time.Second
+
+// migratedDatastores returns the datastores supporting database migrations.
+func migratedDatastores(cluster *v1beta1.TemporalCluster) []datastoreState {
+	return []datastoreState{
+		{spec: cluster.Spec.Persistence.DefaultStore, status: cluster.Status.Persistence.DefaultStore},
+		{spec: cluster.Spec.Persistence.VisibilityStore, status: cluster.Status.Persistence.VisibilityStore},
+	}
+}
+
+// reconcileDatastoreMigration prepares the migration of datastores whose database changed: if the migration is allowed
+// using the temporal.io/migrate-datastores annotation, services are stopped so no write happens during the copy.
+// The copy itself is run by the migrate persistence jobs.
+func (r *TemporalClusterReconciler) reconcileDatastoreMigration(ctx context.Context, cluster *v1beta1.TemporalCluster) (time.Duration, error) {
+	r.reconcilePersistenceStatus(cluster)
+
+	pending := []string{}
+	for _, store := range migratedDatastores(cluster) {
+		if store.status.MigrationPending(store.spec) {
+			if !store.spec.IsSQL() {
+				return 0, fmt.Errorf("%s database changed from %s to %s: only SQL datastores can be migrated", store.spec.Name, store.status.DatabaseName, store.spec.GetDatabaseName())
+			}
+			pending = append(pending, fmt.Sprintf("%s database changed from %s to %s", store.spec.Name, store.status.DatabaseName, store.spec.GetDatabaseName()))
+		}
+	}
+
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	if cluster.GetAnnotations()[v1beta1.MigrateDatastoresAnnotation] != "true" {
+		return 0, fmt.Errorf("%s: set the %s annotation to \"true\" to migrate the existing data", strings.Join(pending, ", "), v1beta1.MigrateDatastoresAnnotation)
+	}
+
+	stopped, err := r.stopServices(ctx, cluster)
+	if err != nil {
+		return 0, fmt.Errorf("can't stop services before migrating datastores: %w", err)
+	}
+
+	if !stopped {
+		log.FromContext(ctx).Info("Waiting for services to stop before migrating datastores")
+		return datastoreMigrationCheckInterval, nil
+	}
+
+	return 0, nil
+}
+
+// stopServices scales the cluster services deployments down to zero.
+// It returns true once no service pod is running.
+// Replicas are restored by the next resources reconciliation.
+func (r *TemporalClusterReconciler) stopServices(ctx context.Context, cluster *v1beta1.TemporalCluster) (bool, error) {
+	deployments := &appsv1.DeploymentList{}
+	err := r.List(ctx, deployments, client.InNamespace(cluster.GetNamespace()), client.MatchingFields{ownerKey: cluster.GetName()})
+	if err != nil {
+		return false, err
+	}
+
+	stopped := true
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+
+		switch deployment.Labels["app.kubernetes.io/component"] {
+		case "ui", "admintools", grpcweb.ComponentName:
+			continue
+		}
+
+		if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+			deployment.Spec.Replicas = ptr.To[int32](0)
+			if err := r.Update(ctx, deployment); err != nil {
+				return false, err
+			}
+		}
+
+		if deployment.Status.Replicas > 0 {
+			stopped = false
+		}
+	}
+
+	return stopped, nil
+}
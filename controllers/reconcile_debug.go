@@ -0,0 +1,124 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// debugContainerPrefix prefixes the names of the ephemeral debug containers attached by the operator.
+const debugContainerPrefix = "debug-"
+
+// attachDebugContainer adds an ephemeral debug container to the provided temporal service pod.
+// The container shares the temporal container volumes, including its certificates, and environment,
+// and is configured to reach the cluster frontend using tctl and the temporal CLI.
+// Missing pods or pods not belonging to the cluster are reported using a warning event.
+func (r *TemporalClusterReconciler) attachDebugContainer(ctx context.Context, cluster *v1beta1.TemporalCluster, podName, image string) error {
+	if r.Clientset == nil {
+		return nil
+	}
+
+	pods := r.Clientset.CoreV1().Pods(cluster.GetNamespace())
+
+	pod, err := pods.Get(ctx, podName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "DebugContainerFailed", "Pod %s not found", podName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !labels.SelectorFromSet(cluster.SelectorLabels()).Matches(labels.Set(pod.GetLabels())) {
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "DebugContainerFailed", "Pod %s doesn't belong to the cluster", podName)
+		return nil
+	}
+
+	if image == "" {
+		image = cluster.AdminToolsImage()
+	}
+
+	container := debugContainer(cluster, pod, image, time.Now())
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, container)
+
+	_, err = pods.UpdateEphemeralContainers(ctx, podName, pod, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	log.FromContext(ctx).Info("Debug container attached", "pod", podName, "container", container.Name)
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "DebugContainerAttached",
+		"Debug container %s attached to pod %s, run: kubectl exec -it -n %s %s -c %s -- sh",
+		container.Name, podName, pod.GetNamespace(), podName, container.Name)
+
+	return nil
+}
+
+// debugContainer returns the ephemeral debug container attached to the provided pod.
+func debugContainer(cluster *v1beta1.TemporalCluster, pod *corev1.Pod, image string, now time.Time) corev1.EphemeralContainer {
+	container := corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     fmt.Sprintf("%s%d", debugContainerPrefix, now.Unix()),
+			Image:                    image,
+			ImagePullPolicy:          cluster.GetImagePullPolicy(),
+			Command:                  []string{"sleep", "infinity"},
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: ptr.To(false),
+			},
+		},
+	}
+
+	for _, c := range pod.Spec.Containers {
+		if c.Name != "service" {
+			continue
+		}
+		container.TargetContainerName = c.Name
+		container.Env = append(container.Env, c.Env...)
+		container.EnvFrom = append(container.EnvFrom, c.EnvFrom...)
+		container.VolumeMounts = append(container.VolumeMounts, c.VolumeMounts...)
+	}
+
+	address := fmt.Sprintf("%s:%d", cluster.ChildResourceName(meta.FrontendService), *cluster.Spec.Services.Frontend.Port)
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "TEMPORAL_CLI_ADDRESS", Value: address},
+		corev1.EnvVar{Name: "TEMPORAL_ADDRESS", Value: address},
+	)
+
+	if cluster.MTLSWithCertManagerEnabled() && cluster.Spec.MTLS.FrontendEnabled() {
+		certsMountPath := cluster.Spec.MTLS.Frontend.GetCertificateMountPath()
+		container.Env = append(container.Env, certmanager.GetTLSEnvironmentVariables(cluster, "TEMPORAL_CLI", certsMountPath)...)
+		container.Env = append(container.Env, certmanager.GetTLSEnvironmentVariables(cluster, "TEMPORAL", certsMountPath)...)
+	}
+
+	return container
+}
@@ -0,0 +1,117 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/hash"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/pkg/decryption"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	defaultSecretDecryptionKey = "key"
+	defaultPasswordSecretKey   = "password"
+)
+
+// reconcileSecretDecryption decrypts the cluster's datastores passwords and stores the plain text values
+// in secrets owned by the cluster, referenced by the services and the schema jobs.
+func (r *TemporalClusterReconciler) reconcileSecretDecryption(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	spec := cluster.Spec.Persistence.SecretDecryption
+	if spec == nil {
+		return nil
+	}
+
+	key, err := r.getSecretKeyValue(ctx, cluster.GetNamespace(), spec.KeyRef, defaultSecretDecryptionKey)
+	if err != nil {
+		return fmt.Errorf("can't get decryption key: %w", err)
+	}
+
+	decrypter, err := decryption.NewDecrypter(spec.Provider, key)
+	if err != nil {
+		return err
+	}
+
+	for _, store := range cluster.Spec.Persistence.GetDatastores() {
+		if store.PasswordSecretRef == nil {
+			continue
+		}
+
+		encrypted, err := r.getSecretKeyValue(ctx, cluster.GetNamespace(), store.PasswordSecretRef, defaultPasswordSecretKey)
+		if err != nil {
+			return fmt.Errorf("can't get %s datastore password: %w", store.Name, err)
+		}
+
+		password, err := decrypter.Decrypt(ctx, encrypted)
+		if err != nil {
+			return fmt.Errorf("can't decrypt %s datastore password: %w", store.Name, err)
+		}
+
+		ref := cluster.GetDatastorePasswordSecretRef(store)
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ref.Name,
+				Namespace: cluster.GetNamespace(),
+			},
+		}
+
+		_, err = controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+			secret.Labels = metadata.GetLabels(cluster, "persistence", cluster.Spec.Version, cluster.Labels)
+			secret.Type = corev1.SecretTypeOpaque
+			secret.Data = map[string][]byte{
+				ref.Key: password,
+			}
+			return controllerutil.SetControllerReference(cluster, secret, r.Scheme)
+		})
+		if err != nil {
+			return fmt.Errorf("can't write %s datastore decrypted password: %w", store.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// decryptedSecretsHash returns a hash of the encrypted datastores passwords.
+// As passwords are injected as environment variables, services are rolled out when this hash changes.
+// It returns an empty string if secret decryption is disabled.
+func (r *TemporalClusterReconciler) decryptedSecretsHash(ctx context.Context, cluster *v1beta1.TemporalCluster) (string, error) {
+	if cluster.Spec.Persistence.SecretDecryption == nil {
+		return "", nil
+	}
+
+	values := map[string][]byte{}
+	for _, store := range cluster.Spec.Persistence.GetDatastores() {
+		if store.PasswordSecretRef == nil {
+			continue
+		}
+
+		value, err := r.getSecretKeyValue(ctx, cluster.GetNamespace(), store.PasswordSecretRef, defaultPasswordSecretKey)
+		if err != nil {
+			return "", fmt.Errorf("can't get %s datastore password: %w", store.Name, err)
+		}
+		values[store.Name] = value
+	}
+
+	return hash.Sha256(values)
+}
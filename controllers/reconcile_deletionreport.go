@@ -0,0 +1,252 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// deletionReportKey is the key of the deletion report ConfigMap holding the report.
+const deletionReportKey = "report.txt"
+
+// deletionReport lists the effects of deleting a TemporalCluster.
+type deletionReport struct {
+	// Deleted are the resources garbage collected by kubernetes with the cluster.
+	Deleted []string
+	// DeletedData are the data deleted along with the garbage collected resources.
+	DeletedData []string
+	// Retained are the resources kept after the cluster is deleted.
+	Retained []string
+	// RetainedData are the data kept after the cluster is deleted.
+	RetainedData []string
+}
+
+// render returns the report in a human readable form.
+func (d *deletionReport) render(cluster *v1beta1.TemporalCluster) string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "Deleting TemporalCluster %s/%s would:\n", cluster.GetNamespace(), cluster.GetName())
+
+	sections := []struct {
+		title string
+		items []string
+	}{
+		{"Delete the following resources, garbage collected by kubernetes", d.Deleted},
+		{"Delete the following data", d.DeletedData},
+		{"Keep the following resources", d.Retained},
+		{"Keep the following data", d.RetainedData},
+	}
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+		fmt.Fprintf(sb, "\n%s:\n", section.title)
+		for _, item := range section.items {
+			fmt.Fprintf(sb, "  - %s\n", item)
+		}
+	}
+
+	return sb.String()
+}
+
+// reportDeletion stores in the "<cluster>-deletion-report" ConfigMap the resources and data that deleting the cluster
+// would delete or keep, without deleting anything, and summarizes it in an event.
+func (r *TemporalClusterReconciler) reportDeletion(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	report, err := r.buildDeletionReport(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.ChildResourceName("deletion-report"),
+			Namespace: cluster.GetNamespace(),
+		},
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = metadata.GetLabels(cluster, "deletion-report", cluster.Spec.Version, cluster.Labels)
+		configMap.Data = map[string]string{deletionReportKey: report.render(cluster)}
+		return controllerutil.SetControllerReference(cluster, configMap, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("can't store deletion report: %w", err)
+	}
+
+	log.FromContext(ctx).Info("Deletion report stored", "report", configMap.GetName())
+
+	eventType := corev1.EventTypeNormal
+	if len(report.DeletedData) > 0 {
+		eventType = corev1.EventTypeWarning
+	}
+	r.Recorder.Eventf(cluster, eventType, "DeletionReportStored",
+		"Deleting the cluster would delete %d resources and %d data volumes, and keep %d resources and %d data locations, see ConfigMap %s",
+		len(report.Deleted), len(report.DeletedData), len(report.Retained), len(report.RetainedData), configMap.GetName())
+
+	return nil
+}
+
+// buildDeletionReport lists the effects of deleting the provided cluster.
+// Child resources are taken from the cluster inventory, its owned jobs and managed Elasticsearch instances,
+// while the resources referencing the cluster and the datastores are kept, as the operator doesn't run any cleanup on deletion.
+func (r *TemporalClusterReconciler) buildDeletionReport(ctx context.Context, cluster *v1beta1.TemporalCluster) (*deletionReport, error) {
+	report := &deletionReport{}
+
+	for _, entry := range cluster.Status.Inventory {
+		report.Deleted = append(report.Deleted, fmt.Sprintf("%s %s", entry.Kind, entry.Name))
+		if entry.Kind == "PersistentVolumeClaim" {
+			report.DeletedData = append(report.DeletedData,
+				fmt.Sprintf("PersistentVolumeClaim %s: its volume is deleted unless its storage class reclaim policy is Retain", entry.Name))
+		}
+	}
+
+	jobs := &batchv1.JobList{}
+	err := r.List(ctx, jobs, client.InNamespace(cluster.GetNamespace()), client.MatchingFields{ownerKey: cluster.GetName()})
+	if err != nil {
+		return nil, fmt.Errorf("can't list cluster jobs: %w", err)
+	}
+	for _, job := range jobs.Items {
+		report.Deleted = append(report.Deleted, fmt.Sprintf("Job %s", job.GetName()))
+	}
+
+	retained, err := r.clusterReferences(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	report.Retained = append(report.Retained, retained...)
+
+	stores := cluster.Spec.Persistence.GetDatastoresMap()
+	names := make([]string, 0, len(stores))
+	for name := range stores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		store := stores[name]
+		if store == nil {
+			continue
+		}
+		if store.PasswordSecretRef != nil {
+			report.Retained = append(report.Retained, fmt.Sprintf("Secret %s: password of the %s datastore", store.PasswordSecretRef.Name, name))
+		}
+		if store.ManagedElasticsearchEnabled() {
+			elasticsearch := cluster.ManagedElasticsearchName(store)
+			report.Deleted = append(report.Deleted,
+				fmt.Sprintf("Deployment %s", elasticsearch),
+				fmt.Sprintf("Service %s", elasticsearch),
+				fmt.Sprintf("PersistentVolumeClaim %s", elasticsearch),
+			)
+			report.DeletedData = append(report.DeletedData,
+				fmt.Sprintf("PersistentVolumeClaim %s: the managed Elasticsearch data of the %s datastore", elasticsearch, name))
+			continue
+		}
+		report.RetainedData = append(report.RetainedData, fmt.Sprintf("%s: %s", name, describeDatastore(store)))
+	}
+
+	if cluster.MTLSWithCertManagerEnabled() {
+		report.Retained = append(report.Retained,
+			"Secrets of the cert-manager certificates, unless cert-manager runs with --enable-certificate-owner-ref")
+	}
+
+	if cluster.Spec.Archival.IsEnabled() {
+		for _, provider := range cluster.Spec.Archival.Providers() {
+			if provider.Filestore != nil {
+				if volume := cluster.ArchivalVolume(); volume != nil && volume.ClaimName != "" {
+					report.RetainedData = append(report.RetainedData, fmt.Sprintf("archival: files archived on PersistentVolumeClaim %s", volume.ClaimName))
+				}
+				continue
+			}
+			report.RetainedData = append(report.RetainedData, fmt.Sprintf("archival: histories and visibility records archived using the %s provider", provider.Kind()))
+		}
+	}
+
+	sort.Strings(report.Deleted)
+
+	return report, nil
+}
+
+// clusterReferences returns the resources referencing the provided cluster, which aren't deleted with it.
+func (r *TemporalClusterReconciler) clusterReferences(ctx context.Context, cluster *v1beta1.TemporalCluster) ([]string, error) {
+	key := client.ObjectKeyFromObject(cluster)
+	result := []string{}
+
+	namespaces := &v1beta1.TemporalNamespaceList{}
+	if err := r.List(ctx, namespaces); err != nil {
+		return nil, fmt.Errorf("can't list temporal namespaces: %w", err)
+	}
+	for i := range namespaces.Items {
+		namespace := &namespaces.Items[i]
+		if namespace.Spec.ClusterRef.NamespacedName(namespace) == key {
+			result = append(result, fmt.Sprintf("TemporalNamespace %s/%s: the namespace and its workflows stay in the cluster datastores", namespace.GetNamespace(), namespace.GetName()))
+		}
+	}
+
+	clusterClients := &v1beta1.TemporalClusterClientList{}
+	if err := r.List(ctx, clusterClients, client.MatchingFields{clusterRefNameField: cluster.GetName()}); err != nil {
+		return nil, fmt.Errorf("can't list cluster clients: %w", err)
+	}
+	for i := range clusterClients.Items {
+		clusterClient := &clusterClients.Items[i]
+		// Clients issued by the operator for replication are owned by the cluster and part of its inventory.
+		if clusterClient.Spec.ClusterRef.NamespacedName(clusterClient) != key || metav1.IsControlledBy(clusterClient, cluster) {
+			continue
+		}
+		result = append(result, fmt.Sprintf("TemporalClusterClient %s/%s and its credentials secret", clusterClient.GetNamespace(), clusterClient.GetName()))
+	}
+
+	workerDeployments := &v1beta1.TemporalWorkerDeploymentList{}
+	if err := r.List(ctx, workerDeployments); err != nil {
+		return nil, fmt.Errorf("can't list worker deployments: %w", err)
+	}
+	for i := range workerDeployments.Items {
+		workerDeployment := &workerDeployments.Items[i]
+		if workerDeployment.Spec.ClusterRef.NamespacedName(workerDeployment) == key {
+			result = append(result, fmt.Sprintf("TemporalWorkerDeployment %s/%s", workerDeployment.GetNamespace(), workerDeployment.GetName()))
+		}
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// describeDatastore returns where the provided datastore keeps its data.
+func describeDatastore(store *v1beta1.DatastoreSpec) string {
+	switch {
+	case store.SQL != nil:
+		return fmt.Sprintf("%s database %s on %s", store.GetType(), store.SQL.DatabaseName, store.SQL.ConnectAddr)
+	case store.Cassandra != nil:
+		return fmt.Sprintf("cassandra keyspace %s on %s", store.Cassandra.Keyspace, strings.Join(store.Cassandra.Hosts, ","))
+	case store.Elasticsearch != nil:
+		return fmt.Sprintf("elasticsearch indices on %s", store.Elasticsearch.URL)
+	default:
+		return string(store.GetType())
+	}
+}
@@ -0,0 +1,129 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReportDeletion(t *testing.T) {
+	cluster := &v1beta1.TemporalCluster{
+		TypeMeta:   v1beta1.TemporalClusterTypeMeta,
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "demo", UID: "cluster-uid"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore: &v1beta1.DatastoreSpec{
+					SQL: &v1beta1.SQLSpec{
+						PluginName:   "postgres12",
+						DatabaseName: "temporal",
+						ConnectAddr:  "postgres:5432",
+					},
+					PasswordSecretRef: &v1beta1.SecretKeyReference{Name: "postgres-password"},
+				},
+				VisibilityStore: &v1beta1.DatastoreSpec{
+					Cassandra: &v1beta1.CassandraSpec{
+						Keyspace: "temporal_visibility",
+						Hosts:    []string{"cassandra-0", "cassandra-1"},
+					},
+				},
+			},
+		},
+		Status: v1beta1.TemporalClusterStatus{
+			Inventory: []v1beta1.InventoryEntry{
+				{Group: "apps", Version: "v1", Kind: "Deployment", Name: "prod-frontend"},
+				{Version: "v1", Kind: "PersistentVolumeClaim", Name: "prod-archival"},
+			},
+		},
+	}
+
+	owner := []metav1.OwnerReference{
+		*metav1.NewControllerRef(cluster, v1beta1.GroupVersion.WithKind("TemporalCluster")),
+	}
+
+	objects := []client.Object{
+		cluster,
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod-setup-default-schema", Namespace: "demo", OwnerReferences: owner},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-setup-default-schema", Namespace: "demo"},
+		},
+		&v1beta1.TemporalNamespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "demo"},
+			Spec:       v1beta1.TemporalNamespaceSpec{ClusterRef: v1beta1.ObjectReference{Name: "prod"}},
+		},
+		&v1beta1.TemporalNamespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "demo"},
+			Spec:       v1beta1.TemporalNamespaceSpec{ClusterRef: v1beta1.ObjectReference{Name: "staging"}},
+		},
+		&v1beta1.TemporalClusterClient{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "demo"},
+			Spec:       v1beta1.TemporalClusterClientSpec{ClusterRef: v1beta1.ObjectReference{Name: "prod"}},
+		},
+		// Replication clients are owned by the cluster.
+		&v1beta1.TemporalClusterClient{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod-replication", Namespace: "demo", OwnerReferences: owner},
+			Spec:       v1beta1.TemporalClusterClientSpec{ClusterRef: v1beta1.ObjectReference{Name: "prod"}},
+		},
+	}
+
+	r := &TemporalClusterReconciler{Base: newFakeBase(t, objects...)}
+
+	err := r.reportDeletion(context.Background(), cluster)
+	require.NoError(t, err)
+
+	configMap := &corev1.ConfigMap{}
+	err = r.Get(context.Background(), client.ObjectKey{Namespace: "demo", Name: "prod-deletion-report"}, configMap)
+	require.NoError(t, err)
+
+	expected := `Deleting TemporalCluster demo/prod would:
+
+Delete the following resources, garbage collected by kubernetes:
+  - Deployment prod-frontend
+  - Job prod-setup-default-schema
+  - PersistentVolumeClaim prod-archival
+
+Delete the following data:
+  - PersistentVolumeClaim prod-archival: its volume is deleted unless its storage class reclaim policy is Retain
+
+Keep the following resources:
+  - TemporalClusterClient demo/worker and its credentials secret
+  - TemporalNamespace demo/payments: the namespace and its workflows stay in the cluster datastores
+  - Secret postgres-password: password of the defaultStore datastore
+
+Keep the following data:
+  - defaultStore: postgres12 database temporal on postgres:5432
+  - visibilityStore: cassandra keyspace temporal_visibility on cassandra-0,cassandra-1
+`
+	assert.Equal(t, expected, configMap.Data[deletionReportKey])
+	assert.True(t, metav1.IsControlledBy(configMap, cluster))
+
+	recorder := r.Recorder.(*record.FakeRecorder)
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning DeletionReportStored Deleting the cluster would delete 3 resources and 1 data volumes, and keep 3 resources and 2 data locations, see ConfigMap prod-deletion-report", <-recorder.Events)
+}
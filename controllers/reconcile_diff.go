@@ -0,0 +1,242 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/config"
+	"github.com/pmezard/go-difflib/difflib"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// diffRefreshInterval is the interval at which the diff report is refreshed while a diff is requested.
+const diffRefreshInterval = time.Minute
+
+// diffRequested returns true if the user asked for a dry-run diff instead of applying changes.
+func diffRequested(cluster *v1beta1.TemporalCluster) bool {
+	return cluster.GetAnnotations()[v1beta1.DiffAnnotation] == "true"
+}
+
+// reconcileResourcesDiff computes the changes the operator would apply to the cluster child resources,
+// without applying them. The report is written in the "<cluster>-diff" ConfigMap, one key per changed resource,
+// and summarized in an event.
+func (r *TemporalClusterReconciler) reconcileResourcesDiff(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	report := map[string]string{}
+
+	clientRules, err := r.clusterClientsClaimRules(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	policyRules, err := r.accessPoliciesClaimRules(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	clientRules = append(clientRules, policyRules...)
+
+	remoteClusters, err := r.remoteClusterConnections(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	configMapObject, err := r.diffBuilder(ctx, config.NewConfigmapBuilder(cluster, r.Scheme, clientRules, remoteClusters), report)
+	if err != nil {
+		return err
+	}
+
+	configMap, ok := configMapObject.(*corev1.ConfigMap)
+	if !ok {
+		return errors.New("can't cast configmap object to *corev1.ConfigMap")
+	}
+
+	// Use the desired configmap hash, as it would be computed when applying changes.
+	configHash, err := r.configHash(ctx, cluster, configMap)
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := r.listClusterNamespaces(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("can't list cluster namespaces: %w", err)
+	}
+
+	builders, err := r.resourceBuilders(cluster, configHash, namespaces, remoteClusters)
+	if err != nil {
+		return err
+	}
+
+	for _, component := range r.components(cluster, configHash) {
+		builders = append(builders, component.builders...)
+	}
+
+	for _, builder := range builders {
+		_, err := r.diffBuilder(ctx, builder, report)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = r.writeDiffReport(ctx, cluster, report)
+	if err != nil {
+		return fmt.Errorf("can't write diff report: %w", err)
+	}
+
+	if len(report) == 0 {
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "NoDriftDetected", "Live resources match the desired state")
+		return nil
+	}
+
+	keys := make([]string, 0, len(report))
+	for key := range report {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "DriftDetected", "%d resources differ from the desired state (see ConfigMap %s): %s", len(report), cluster.ChildResourceName("diff"), strings.Join(keys, ", "))
+
+	return nil
+}
+
+// diffBuilder adds the diff between the live and desired objects of the builder to the report.
+// It returns the desired object.
+func (r *TemporalClusterReconciler) diffBuilder(ctx context.Context, builder resource.Builder, report map[string]string) (client.Object, error) {
+	desired := builder.Build()
+
+	gvk, err := apiutil.GVKForObject(desired, r.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	key := strings.ToLower(fmt.Sprintf("%s-%s", gvk.Kind, desired.GetName()))
+
+	err = r.Get(ctx, client.ObjectKeyFromObject(desired), desired)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("can't get %s: %w", key, err)
+		}
+
+		if !builder.Enabled() {
+			return desired, nil
+		}
+
+		desired = builder.Build()
+		err = builder.Update(desired)
+		if err != nil {
+			return nil, err
+		}
+
+		report[key], err = diffObjects(nil, desired)
+		if gvk.Kind == "Secret" {
+			report[key] = "resource would be created (content redacted)"
+		}
+		return desired, err
+	}
+
+	if !builder.Enabled() {
+		report[key] = "resource would be deleted"
+		return desired, nil
+	}
+
+	live := desired.DeepCopyObject().(client.Object)
+
+	err = builder.Update(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiequality.Semantic.DeepEqual(live, desired) {
+		return desired, nil
+	}
+
+	report[key], err = diffObjects(live, desired)
+	if gvk.Kind == "Secret" {
+		report[key] = "resource would be updated (content redacted)"
+	}
+	return desired, err
+}
+
+// diffObjects returns a unified diff between the JSON representations of the provided objects.
+func diffObjects(live, desired client.Object) (string, error) {
+	liveLines := []string{}
+	if live != nil {
+		live.SetManagedFields(nil)
+		out, err := json.MarshalIndent(live, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		liveLines = difflib.SplitLines(string(out))
+	}
+
+	desired.SetManagedFields(nil)
+	out, err := json.MarshalIndent(desired, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        liveLines,
+		B:        difflib.SplitLines(string(out)),
+		FromFile: "live",
+		ToFile:   "desired",
+		Context:  3,
+	})
+}
+
+// writeDiffReport stores the diff report in the "<cluster>-diff" ConfigMap.
+func (r *TemporalClusterReconciler) writeDiffReport(ctx context.Context, cluster *v1beta1.TemporalCluster, report map[string]string) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.ChildResourceName("diff"),
+			Namespace: cluster.GetNamespace(),
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = metadata.GetLabels(cluster, "diff", cluster.Spec.Version, cluster.Labels)
+		configMap.Data = report
+		return controllerutil.SetControllerReference(cluster, configMap, r.Scheme)
+	})
+
+	return err
+}
+
+// deleteDiffReport deletes the diff report once the diff annotation is removed.
+func (r *TemporalClusterReconciler) deleteDiffReport(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: cluster.GetNamespace(), Name: cluster.ChildResourceName("diff")}, configMap)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	return client.IgnoreNotFound(r.Delete(ctx, configMap))
+}
@@ -0,0 +1,48 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"go.temporal.io/server/common/primitives"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// reconcileDrainCondition reports the drain mode of the cluster in its Draining condition.
+// The drain mode is applied by the frontend proxies, so it only takes effect once the frontend pods are rolled out:
+// the condition is pending until then, and is kept while the pods leaving the drain mode are rolled out.
+func reconcileDrainCondition(cluster *v1beta1.TemporalCluster) {
+	frontendReady := false
+	for _, service := range cluster.Status.Services {
+		if service.Name == string(primitives.FrontendService) {
+			frontendReady = service.Ready
+		}
+	}
+
+	switch {
+	case cluster.IsDraining() && frontendReady:
+		v1beta1.SetTemporalClusterDraining(cluster, metav1.ConditionTrue, v1beta1.DrainEnabledReason, "New workflow executions are rejected by the frontend proxies")
+	case cluster.IsDraining():
+		v1beta1.SetTemporalClusterDraining(cluster, metav1.ConditionFalse, v1beta1.DrainPendingReason, "Frontend pods are being rolled out to enter the drain mode")
+	case apimeta.IsStatusConditionTrue(cluster.Status.Conditions, v1beta1.DrainingCondition) && !frontendReady:
+		v1beta1.SetTemporalClusterDraining(cluster, metav1.ConditionTrue, v1beta1.DrainPendingReason, "Frontend pods are being rolled out to leave the drain mode")
+	default:
+		apimeta.RemoveStatusCondition(&cluster.Status.Conditions, v1beta1.DrainingCondition)
+	}
+}
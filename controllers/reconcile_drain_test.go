@@ -0,0 +1,94 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcileDrainCondition(t *testing.T) {
+	tests := map[string]struct {
+		drain          bool
+		frontendReady  bool
+		previous       *metav1.Condition
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		"not draining": {},
+		"entering drain mode": {
+			drain:          true,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: v1beta1.DrainPendingReason,
+		},
+		"draining": {
+			drain:          true,
+			frontendReady:  true,
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: v1beta1.DrainEnabledReason,
+		},
+		"leaving drain mode": {
+			previous:       &metav1.Condition{Type: v1beta1.DrainingCondition, Status: metav1.ConditionTrue, Reason: v1beta1.DrainEnabledReason},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: v1beta1.DrainPendingReason,
+		},
+		"left drain mode": {
+			previous:      &metav1.Condition{Type: v1beta1.DrainingCondition, Status: metav1.ConditionTrue, Reason: v1beta1.DrainPendingReason},
+			frontendReady: true,
+		},
+		"drain mode cancelled before the rollout": {
+			previous: &metav1.Condition{Type: v1beta1.DrainingCondition, Status: metav1.ConditionFalse, Reason: v1beta1.DrainPendingReason},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				Spec: v1beta1.TemporalClusterSpec{
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{Drain: test.drain},
+					},
+				},
+				Status: v1beta1.TemporalClusterStatus{
+					Services: []v1beta1.ServiceStatus{
+						{Name: "frontend", Ready: test.frontendReady},
+					},
+				},
+			}
+			if test.previous != nil {
+				cluster.Status.Conditions = []metav1.Condition{*test.previous}
+			}
+
+			reconcileDrainCondition(cluster)
+
+			condition := apimeta.FindStatusCondition(cluster.Status.Conditions, v1beta1.DrainingCondition)
+			if test.expectedReason == "" {
+				assert.Nil(tt, condition)
+				return
+			}
+			if assert.NotNil(tt, condition) {
+				assert.Equal(tt, test.expectedStatus, condition.Status)
+				assert.Equal(tt, test.expectedReason, condition.Reason)
+			}
+		})
+	}
+}
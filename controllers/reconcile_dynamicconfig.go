@@ -0,0 +1,47 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	temporalconfig "github.com/alexandrevilain/temporal-operator/pkg/temporal/config"
+)
+
+// reconcileDynamicConfigStatus reports the effective dynamic config of the cluster and which spec fields its keys are rendered from,
+// so that conflicts between spec.dynamicConfig.values and the operator managed keys can be spotted.
+func (r *TemporalClusterReconciler) reconcileDynamicConfigStatus(cluster *v1beta1.TemporalCluster, namespaces []v1beta1.TemporalNamespace) error {
+	if cluster.Spec.DynamicConfig == nil {
+		cluster.Status.DynamicConfig = nil
+		return nil
+	}
+
+	_, keys, err := temporalconfig.RenderDynamicConfig(cluster, namespaces)
+	if err != nil {
+		return fmt.Errorf("can't render dynamic config: %w", err)
+	}
+
+	cluster.Status.DynamicConfig = &v1beta1.DynamicConfigStatus{
+		ConfigMapName: cluster.ChildResourceName(meta.ServiceDynamicConfig),
+		Keys:          keys,
+	}
+
+	return nil
+}
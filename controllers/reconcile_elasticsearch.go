@@ -0,0 +1,285 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/hash"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/elasticsearch"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// elasticsearchHealthCheckInterval is the interval between two elasticsearch cluster health checks.
+	elasticsearchHealthCheckInterval = time.Minute
+
+	defaultElasticsearchPasswordSecretKey = "password"
+)
+
+// elasticsearchDatastores returns the cluster's elasticsearch datastores.
+func elasticsearchDatastores(cluster *v1beta1.TemporalCluster) []*v1beta1.DatastoreSpec {
+	result := []*v1beta1.DatastoreSpec{}
+	for _, store := range cluster.Spec.Persistence.GetDatastores() {
+		if store.GetType() == v1beta1.ElasticsearchDatastore {
+			result = append(result, store)
+		}
+	}
+	return result
+}
+
+// elasticsearchSecretRef is a secret reference used by an elasticsearch datastore, with its default key.
+type elasticsearchSecretRef struct {
+	ref        *v1beta1.SecretKeyReference
+	defaultKey string
+}
+
+// elasticsearchSecretRefs returns all secrets references used by the provided elasticsearch datastore, with their default key.
+func elasticsearchSecretRefs(store *v1beta1.DatastoreSpec) []elasticsearchSecretRef {
+	refs := []elasticsearchSecretRef{}
+	if store.PasswordSecretRef != nil {
+		refs = append(refs, elasticsearchSecretRef{store.PasswordSecretRef, defaultElasticsearchPasswordSecretKey})
+	}
+	if store.TLS != nil && store.TLS.Enabled {
+		if store.TLS.CaFileRef != nil {
+			refs = append(refs, elasticsearchSecretRef{store.TLS.CaFileRef, v1beta1.DataStoreClientTLSCaFileName})
+		}
+		if store.TLS.CertFileRef != nil {
+			refs = append(refs, elasticsearchSecretRef{store.TLS.CertFileRef, v1beta1.DataStoreClientTLSCertFileName})
+		}
+		if store.TLS.KeyFileRef != nil {
+			refs = append(refs, elasticsearchSecretRef{store.TLS.KeyFileRef, v1beta1.DataStoreClientTLSKeyFileName})
+		}
+	}
+	return refs
+}
+
+// secretKey returns the key referenced by the provided secret reference, or the default key if it's unset.
+func secretKey(ref *v1beta1.SecretKeyReference, defaultKey string) string {
+	if ref.Key == "" {
+		return defaultKey
+	}
+	return ref.Key
+}
+
+func (r *TemporalClusterReconciler) getSecretKeyValue(ctx context.Context, namespace string, ref *v1beta1.SecretKeyReference, defaultKey string) ([]byte, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	key := secretKey(ref, defaultKey)
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in secret %q", key, ref.Name)
+	}
+	return value, nil
+}
+
+// elasticsearchSecretsHash returns a hash of all secrets values used by the cluster's elasticsearch datastores.
+// As credentials are injected as environment variables, services are rolled out when this hash changes.
+// It returns an empty string if the cluster has no elasticsearch datastore.
+func (r *TemporalClusterReconciler) elasticsearchSecretsHash(ctx context.Context, cluster *v1beta1.TemporalCluster) (string, error) {
+	values := map[string][]byte{}
+	for _, store := range elasticsearchDatastores(cluster) {
+		for _, secretRef := range elasticsearchSecretRefs(store) {
+			value, err := r.getSecretKeyValue(ctx, cluster.GetNamespace(), secretRef.ref, secretRef.defaultKey)
+			if err != nil {
+				return "", fmt.Errorf("can't get %s datastore secret: %w", store.Name, err)
+			}
+			// Values are keyed by the resolved key: references to the same secret may omit it.
+			values[fmt.Sprintf("%s/%s", secretRef.ref.Name, secretKey(secretRef.ref, secretRef.defaultKey))] = value
+		}
+	}
+
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	return hash.Sha256(values)
+}
+
+// elasticsearchTLSConfig returns the tls config used to reach the provided elasticsearch datastore.
+func (r *TemporalClusterReconciler) elasticsearchTLSConfig(ctx context.Context, cluster *v1beta1.TemporalCluster, store *v1beta1.DatastoreSpec) (*tls.Config, error) {
+	if store.TLS == nil || !store.TLS.Enabled {
+		return nil, nil //nolint:nilnil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         store.TLS.ServerName,
+		InsecureSkipVerify: !store.TLS.EnableHostVerification, //nolint:gosec
+	}
+
+	if store.TLS.CaFileRef != nil {
+		ca, err := r.getSecretKeyValue(ctx, cluster.GetNamespace(), store.TLS.CaFileRef, v1beta1.DataStoreClientTLSCaFileName)
+		if err != nil {
+			return nil, err
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("failed to add elasticsearch CA certificate")
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if store.TLS.CertFileRef != nil && store.TLS.KeyFileRef != nil {
+		cert, err := r.getSecretKeyValue(ctx, cluster.GetNamespace(), store.TLS.CertFileRef, v1beta1.DataStoreClientTLSCertFileName)
+		if err != nil {
+			return nil, err
+		}
+		key, err := r.getSecretKeyValue(ctx, cluster.GetNamespace(), store.TLS.KeyFileRef, v1beta1.DataStoreClientTLSKeyFileName)
+		if err != nil {
+			return nil, err
+		}
+		clientCert, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return tlsConfig, nil
+}
+
+// elasticsearchClient returns a client for the provided elasticsearch datastore.
+func (r *TemporalClusterReconciler) elasticsearchClient(ctx context.Context, cluster *v1beta1.TemporalCluster, store *v1beta1.DatastoreSpec) (*elasticsearch.Client, error) {
+	password, err := r.getSecretKeyValue(ctx, cluster.GetNamespace(), cluster.GetDatastorePasswordSecretRef(store), defaultElasticsearchPasswordSecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("can't get password: %w", err)
+	}
+
+	tlsConfig, err := r.elasticsearchTLSConfig(ctx, cluster, store)
+	if err != nil {
+		return nil, fmt.Errorf("can't get tls config: %w", err)
+	}
+
+	return elasticsearch.NewClient(store.Elasticsearch.URL, store.Elasticsearch.Username, string(password), tlsConfig), nil
+}
+
+func (r *TemporalClusterReconciler) checkElasticsearchHealth(ctx context.Context, cluster *v1beta1.TemporalCluster, store *v1beta1.DatastoreSpec) error {
+	client, err := r.elasticsearchClient(ctx, cluster, store)
+	if err != nil {
+		return err
+	}
+
+	health, err := client.ClusterHealth(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !health.IsHealthy() {
+		return fmt.Errorf("cluster health is %s", health.Status)
+	}
+
+	return nil
+}
+
+// reconcileElasticsearchHealth checks the health of the cluster's elasticsearch datastores and reports it
+// in the ESHealthy condition. It returns the duration after which the health should be checked again.
+func (r *TemporalClusterReconciler) reconcileElasticsearchHealth(ctx context.Context, cluster *v1beta1.TemporalCluster) time.Duration {
+	stores := elasticsearchDatastores(cluster)
+	if len(stores) == 0 {
+		return 0
+	}
+
+	messages := []string{}
+	for _, store := range stores {
+		err := r.checkElasticsearchHealth(ctx, cluster, store)
+		if err != nil {
+			log.FromContext(ctx).Info("Elasticsearch datastore is unhealthy", "datastore", store.Name, "error", err.Error())
+			messages = append(messages, fmt.Sprintf("%s: %s", store.Name, err.Error()))
+		}
+	}
+
+	if len(messages) > 0 {
+		v1beta1.SetTemporalClusterElasticsearchHealthy(cluster, metav1.ConditionFalse, v1beta1.ElasticsearchUnhealthyReason, strings.Join(messages, "; "))
+	} else {
+		v1beta1.SetTemporalClusterElasticsearchHealthy(cluster, metav1.ConditionTrue, v1beta1.ElasticsearchHealthyReason, "")
+	}
+
+	return elasticsearchHealthCheckInterval
+}
+
+// secretToClustersMapfunc enqueues clusters of the secret's namespace using the secret for their elasticsearch datastores
+// or for their persistence secret decryption.
+func (r *TemporalClusterReconciler) secretToClustersMapfunc(ctx context.Context, o client.Object) []reconcile.Request {
+	clusters := &v1beta1.TemporalClusterList{}
+	err := r.List(ctx, clusters, client.InNamespace(o.GetNamespace()))
+	if err != nil {
+		return nil
+	}
+
+	result := []reconcile.Request{}
+	for _, cluster := range clusters.Items {
+		cluster := cluster
+		if clusterUsesSecret(&cluster, o.GetName()) {
+			result = append(result, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&cluster),
+			})
+		}
+	}
+
+	return result
+}
+
+// clusterUsesSecret returns true if the provided secret name is used by the cluster's elasticsearch datastores
+// or by the cluster's persistence secret decryption.
+func clusterUsesSecret(cluster *v1beta1.TemporalCluster, name string) bool {
+	for _, store := range elasticsearchDatastores(cluster) {
+		for _, secretRef := range elasticsearchSecretRefs(store) {
+			if secretRef.ref.Name == name {
+				return true
+			}
+		}
+	}
+
+	spec := cluster.Spec.Persistence.SecretDecryption
+	if spec == nil {
+		return false
+	}
+
+	if spec.KeyRef != nil && spec.KeyRef.Name == name {
+		return true
+	}
+
+	for _, store := range cluster.Spec.Persistence.GetDatastores() {
+		if store.PasswordSecretRef != nil && store.PasswordSecretRef.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
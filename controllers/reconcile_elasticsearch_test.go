@@ -0,0 +1,84 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestElasticsearchSecretsHash(t *testing.T) {
+	// The TLS references share the same secret and rely on their default keys.
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "temporal"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore: &v1beta1.DatastoreSpec{
+					SQL: &v1beta1.SQLSpec{PluginName: "postgres"},
+				},
+				VisibilityStore: &v1beta1.DatastoreSpec{
+					Name:          "visibility",
+					Elasticsearch: &v1beta1.ElasticsearchSpec{URL: "https://elasticsearch:9200"},
+					TLS: &v1beta1.DatastoreTLSSpec{
+						Enabled:     true,
+						CaFileRef:   &v1beta1.SecretKeyReference{Name: "elasticsearch-tls"},
+						CertFileRef: &v1beta1.SecretKeyReference{Name: "elasticsearch-tls"},
+						KeyFileRef:  &v1beta1.SecretKeyReference{Name: "elasticsearch-tls"},
+					},
+				},
+			},
+		},
+	}
+
+	secret := func(key string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "elasticsearch-tls", Namespace: "temporal"},
+			Data: map[string][]byte{
+				v1beta1.DataStoreClientTLSCaFileName:   []byte("ca"),
+				v1beta1.DataStoreClientTLSCertFileName: []byte("cert"),
+				v1beta1.DataStoreClientTLSKeyFileName:  []byte(key),
+			},
+		}
+	}
+
+	hash := func(tt *testing.T, secret *corev1.Secret) string {
+		r := &TemporalClusterReconciler{Base: newFakeBase(tt, secret)}
+		result, err := r.elasticsearchSecretsHash(context.Background(), cluster)
+		require.NoError(tt, err)
+		return result
+	}
+
+	t.Run("stable", func(tt *testing.T) {
+		expected := hash(tt, secret("key"))
+		for i := 0; i < 10; i++ {
+			assert.Equal(tt, expected, hash(tt, secret("key")))
+		}
+	})
+
+	t.Run("key rotation", func(tt *testing.T) {
+		for i := 0; i < 10; i++ {
+			assert.NotEqual(tt, hash(tt, secret("key")), hash(tt, secret("rotated")))
+		}
+	})
+}
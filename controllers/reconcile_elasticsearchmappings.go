@@ -0,0 +1,208 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/elasticsearch"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// mappingCheckedDatastores returns the cluster's elasticsearch datastores with the mapping check enabled.
+// Elasticsearch v6 datastores are skipped as their mappings are nested under document types.
+func mappingCheckedDatastores(cluster *v1beta1.TemporalCluster) []*v1beta1.DatastoreSpec {
+	result := []*v1beta1.DatastoreSpec{}
+	for _, store := range elasticsearchDatastores(cluster) {
+		if store.Elasticsearch.MappingCheck.IsEnabled() && store.Elasticsearch.Version != "v6" {
+			result = append(result, store)
+		}
+	}
+	return result
+}
+
+// visibilityIndices returns the visibility indices of the provided elasticsearch datastore.
+func visibilityIndices(store *v1beta1.DatastoreSpec) []string {
+	indices := []string{}
+	if store.Elasticsearch.Indices.Visibility != "" {
+		indices = append(indices, store.Elasticsearch.Indices.Visibility)
+	}
+	if store.Elasticsearch.Indices.SecondaryVisibility != "" {
+		indices = append(indices, store.Elasticsearch.Indices.SecondaryVisibility)
+	}
+	return indices
+}
+
+// expectedSearchAttributes returns the fields, with their elasticsearch type, expected in the visibility indices
+// of the provided datastore: the system search attributes of the cluster version and the declared custom ones.
+func expectedSearchAttributes(cluster *v1beta1.TemporalCluster, store *v1beta1.DatastoreSpec) (map[string]string, error) {
+	fields := elasticsearch.SystemSearchAttributes(cluster.Spec.Version)
+	for name, searchAttributeType := range store.Elasticsearch.SearchAttributes {
+		fieldType, err := elasticsearch.FieldType(string(searchAttributeType))
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = fieldType
+	}
+	return fields, nil
+}
+
+// findElasticsearchMappingStatus returns the status of the provided datastore index, or nil if it was never checked.
+func findElasticsearchMappingStatus(statuses []v1beta1.ElasticsearchMappingStatus, datastore, index string) *v1beta1.ElasticsearchMappingStatus {
+	for i := range statuses {
+		if statuses[i].Datastore == datastore && statuses[i].Index == index {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// checkElasticsearchMapping compares the provided visibility index mapping with the expected search attributes.
+// When auto repair is enabled, the missing fields are added to the index mapping.
+func (r *TemporalClusterReconciler) checkElasticsearchMapping(ctx context.Context, cluster *v1beta1.TemporalCluster, store *v1beta1.DatastoreSpec, index string) (*v1beta1.ElasticsearchMappingStatus, error) {
+	expected, err := expectedSearchAttributes(cluster, store)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := r.elasticsearchClient(ctx, cluster, store)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, err := client.IndexMapping(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := elasticsearch.CompareMapping(expected, actual)
+
+	status := &v1beta1.ElasticsearchMappingStatus{
+		Datastore:     store.Name,
+		Index:         index,
+		MissingFields: drift.Missing,
+		LastCheckTime: metav1.Now(),
+	}
+	for _, conflict := range drift.Conflicts {
+		status.TypeConflicts = append(status.TypeConflicts, v1beta1.ElasticsearchFieldConflict{
+			Field:        conflict.Field,
+			ExpectedType: conflict.ExpectedType,
+			ActualType:   conflict.ActualType,
+		})
+	}
+
+	if !store.Elasticsearch.MappingCheck.AutoRepair || len(drift.Missing) == 0 {
+		return status, nil
+	}
+
+	missing := map[string]string{}
+	for _, field := range drift.Missing {
+		missing[field] = expected[field]
+	}
+
+	err = client.PutMapping(ctx, index, missing)
+	if err != nil {
+		log.FromContext(ctx).Info("Can't repair elasticsearch index mapping", "datastore", store.Name, "index", index, "error", err.Error())
+		return status, nil
+	}
+
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "ElasticsearchMappingRepaired", "Added fields %s to index %s of datastore %s", strings.Join(drift.Missing, ", "), index, store.Name)
+	status.RepairedFields = status.MissingFields
+	status.MissingFields = nil
+
+	return status, nil
+}
+
+// elasticsearchMappingDriftMessage describes the drift of the provided index mapping status.
+func elasticsearchMappingDriftMessage(status *v1beta1.ElasticsearchMappingStatus) string {
+	details := []string{}
+	if len(status.MissingFields) > 0 {
+		details = append(details, "missing fields "+strings.Join(status.MissingFields, ", "))
+	}
+	for _, conflict := range status.TypeConflicts {
+		details = append(details, fmt.Sprintf("field %s is %s instead of %s", conflict.Field, conflict.ActualType, conflict.ExpectedType))
+	}
+	return fmt.Sprintf("%s/%s: %s", status.Datastore, status.Index, strings.Join(details, ", "))
+}
+
+// reconcileElasticsearchMappings compares the visibility indices mappings of the elasticsearch datastores
+// with the expected search attributes and reports the drift in status.elasticsearchMappings and in the ESMappingInSync condition.
+// It returns the duration after which the mappings should be checked again.
+func (r *TemporalClusterReconciler) reconcileElasticsearchMappings(ctx context.Context, cluster *v1beta1.TemporalCluster) time.Duration {
+	stores := mappingCheckedDatastores(cluster)
+	if len(stores) == 0 {
+		cluster.Status.ElasticsearchMappings = nil
+		apimeta.RemoveStatusCondition(&cluster.Status.Conditions, v1beta1.ElasticsearchMappingInSyncCondition)
+		return 0
+	}
+
+	statuses := []v1beta1.ElasticsearchMappingStatus{}
+	drifts := []string{}
+	failures := []string{}
+	requeueAfter := time.Duration(0)
+
+	for _, store := range stores {
+		interval := store.Elasticsearch.MappingCheck.GetInterval()
+		for _, index := range visibilityIndices(store) {
+			status := findElasticsearchMappingStatus(cluster.Status.ElasticsearchMappings, store.Name, index)
+
+			// Avoid querying elasticsearch on every reconciliation.
+			if status != nil && time.Since(status.LastCheckTime.Time) < interval {
+				requeueAfter = minRequeueAfter(requeueAfter, interval-time.Since(status.LastCheckTime.Time))
+			} else {
+				checked, err := r.checkElasticsearchMapping(ctx, cluster, store, index)
+				if err != nil {
+					log.FromContext(ctx).Info("Can't check elasticsearch index mapping", "datastore", store.Name, "index", index, "error", err.Error())
+					failures = append(failures, fmt.Sprintf("%s/%s: %s", store.Name, index, err.Error()))
+				} else {
+					status = checked
+				}
+				requeueAfter = minRequeueAfter(requeueAfter, interval)
+			}
+
+			if status == nil {
+				continue
+			}
+
+			statuses = append(statuses, *status)
+			if status.HasDrift() {
+				drifts = append(drifts, elasticsearchMappingDriftMessage(status))
+			}
+		}
+	}
+
+	cluster.Status.ElasticsearchMappings = statuses
+
+	switch {
+	case len(drifts) > 0:
+		v1beta1.SetTemporalClusterElasticsearchMappingInSync(cluster, metav1.ConditionFalse, v1beta1.ElasticsearchMappingDriftReason, strings.Join(drifts, "; "))
+	case len(failures) > 0:
+		v1beta1.SetTemporalClusterElasticsearchMappingInSync(cluster, metav1.ConditionUnknown, v1beta1.ElasticsearchMappingUnknownReason, strings.Join(failures, "; "))
+	default:
+		v1beta1.SetTemporalClusterElasticsearchMappingInSync(cluster, metav1.ConditionTrue, v1beta1.ElasticsearchMappingInSyncReason, "")
+	}
+
+	return requeueAfter
+}
@@ -0,0 +1,104 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/hash"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// externalConfigHash validates the external config ConfigMaps used by the cluster's services
+// and returns a hash of their content, so services are rolled out when they change.
+// It returns an empty string if the cluster doesn't use external configurations.
+func (r *TemporalClusterReconciler) externalConfigHash(ctx context.Context, cluster *v1beta1.TemporalCluster) (string, error) {
+	if !cluster.Spec.ExternalConfig.IsEnabled() {
+		return "", nil
+	}
+
+	values := map[string]string{}
+	for _, service := range cluster.DeployedServices() {
+		name := cluster.Spec.ExternalConfig.ConfigMapName(service)
+		if name == "" {
+			return "", fmt.Errorf("no external config ConfigMap found for service %s", service)
+		}
+
+		// External config ConfigMaps aren't held by the manager cache.
+		configMap := &corev1.ConfigMap{}
+		err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: cluster.GetNamespace(), Name: name}, configMap)
+		if err != nil {
+			return "", fmt.Errorf("can't get %s external config ConfigMap %q: %w", service, name, err)
+		}
+
+		data, ok := configMap.Data[v1beta1.ExternalConfigKey]
+		if !ok {
+			return "", fmt.Errorf("key %q not found in %s external config ConfigMap %q", v1beta1.ExternalConfigKey, service, name)
+		}
+
+		if err := config.ValidateExternalConfig(service, data); err != nil {
+			return "", fmt.Errorf("invalid %s external config ConfigMap %q: %w", service, name, err)
+		}
+
+		values[name] = data
+	}
+
+	return hash.Sha256(values)
+}
+
+// configMapToClustersMapfunc enqueues clusters of the ConfigMap's namespace using it as external config.
+func (r *TemporalClusterReconciler) configMapToClustersMapfunc(ctx context.Context, o client.Object) []reconcile.Request {
+	clusters := &v1beta1.TemporalClusterList{}
+	err := r.List(ctx, clusters, client.InNamespace(o.GetNamespace()))
+	if err != nil {
+		return nil
+	}
+
+	result := []reconcile.Request{}
+	for _, cluster := range clusters.Items {
+		cluster := cluster
+		if clusterUsesExternalConfig(&cluster, o.GetName()) {
+			result = append(result, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&cluster),
+			})
+		}
+	}
+
+	return result
+}
+
+// clusterUsesExternalConfig returns true if the provided ConfigMap name is used as external config by the cluster.
+func clusterUsesExternalConfig(cluster *v1beta1.TemporalCluster, name string) bool {
+	if !cluster.Spec.ExternalConfig.IsEnabled() {
+		return false
+	}
+
+	for _, service := range cluster.DeployedServices() {
+		if cluster.Spec.ExternalConfig.ConfigMapName(service) == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,72 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestExternalConfigHash(t *testing.T) {
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "temporal"},
+		Spec: v1beta1.TemporalClusterSpec{
+			ExternalConfig: &v1beta1.ExternalConfigSpec{
+				ConfigMapRef: &corev1.LocalObjectReference{Name: "prod-temporal-config"},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		objects     []client.Object
+		expectedErr string
+	}{
+		"missing ConfigMap": {
+			expectedErr: `can't get frontend external config ConfigMap "prod-temporal-config"`,
+		},
+		// User-provided ConfigMaps aren't labeled, they must be read even if the manager cache doesn't hold them.
+		"unlabeled ConfigMap": {
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "prod-temporal-config", Namespace: "temporal"},
+					Data:       map[string]string{"other.yaml": ""},
+				},
+			},
+			expectedErr: `key "config_template.yaml" not found in frontend external config ConfigMap "prod-temporal-config"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			base := newCachedFakeBase(tt, test.objects...)
+			r := &TemporalClusterReconciler{
+				Base:      base,
+				APIReader: base.Client.(*cachedClient).Client,
+			}
+
+			_, err := r.externalConfigHash(context.Background(), cluster)
+			assert.ErrorContains(tt, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
+	"github.com/alexandrevilain/temporal-operator/pkg/ratelimit"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// fleetRolloutRetryInterval is the interval between two attempts to get a fleet rollout slot.
+const fleetRolloutRetryInterval = 30 * time.Second
+
+// rolloutGate holds back the pod rollouts initiated by the operator, like after an operator upgrade
+// changing the rendered manifests, while the other clusters hold all the fleet rollout slots.
+// Rollouts caused by a cluster spec change are never held back.
+type rolloutGate struct {
+	rollouts *ratelimit.Semaphore
+	key      string
+	// deferred lists the deployments whose rollout was held back.
+	deferred []string
+}
+
+func newRolloutGate(rollouts *ratelimit.Semaphore, cluster *v1beta1.TemporalCluster) *rolloutGate {
+	return &rolloutGate{
+		rollouts: rollouts,
+		key:      types.NamespacedName{Namespace: cluster.GetNamespace(), Name: cluster.GetName()}.String(),
+	}
+}
+
+// allow returns true if the deployment pods can be rolled out, taking a fleet rollout slot for the cluster.
+func (g *rolloutGate) allow(deployment string) bool {
+	if g == nil || g.rollouts.Acquire(g.key) {
+		return true
+	}
+	g.deferred = append(g.deferred, deployment)
+	return false
+}
+
+// reconcileFleetRollout reports the held back rollouts of the cluster, and gives its fleet rollout slot back
+// once its services are ready again.
+func (r *TemporalClusterReconciler) reconcileFleetRollout(ctx context.Context, cluster *v1beta1.TemporalCluster, gate *rolloutGate, servicesReady bool) time.Duration {
+	defer func() {
+		metrics.FleetRolloutsInProgress.Set(float64(r.FleetRollouts.Holding()))
+		metrics.FleetRolloutsWaiting.Set(float64(r.FleetRollouts.Waiting()))
+	}()
+
+	if len(gate.deferred) > 0 {
+		log.FromContext(ctx).Info("Rollout held back, too many clusters are rolling out", "deployments", gate.deferred)
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "RolloutDeferred",
+			"Rollout of %s held back until another cluster completes its rollout", strings.Join(gate.deferred, ", "))
+		return fleetRolloutRetryInterval
+	}
+
+	if servicesReady {
+		r.FleetRollouts.Release(gate.key)
+	}
+
+	return 0
+}
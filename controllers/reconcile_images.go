@@ -0,0 +1,156 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/registry"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// defaultImageVerificationPublicKey is the default key of the image verification public key in its secret.
+const defaultImageVerificationPublicKey = "cosign.pub"
+
+// reconcileImageDigests resolves the cluster images tags to digests when they change and records them
+// in status.imageDigests, pinning the pods of the next rollout to those digests.
+// Already resolved images are not resolved again, so all replicas keep running identical images.
+func (r *TemporalClusterReconciler) reconcileImageDigests(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	if !cluster.Spec.ResolveImageDigests {
+		cluster.Status.ImageDigests = nil
+		return nil
+	}
+
+	digests := map[string]string{}
+	missing := []string{}
+	for _, image := range cluster.Images() {
+		if pinned, ok := cluster.Status.ImageDigests[image]; ok {
+			digests[image] = pinned
+			continue
+		}
+		missing = append(missing, image)
+	}
+
+	if len(missing) > 0 {
+		credentials, err := r.registryCredentials(ctx, cluster)
+		if err != nil {
+			return err
+		}
+
+		client := registry.NewClient(credentials)
+		for _, image := range missing {
+			pinned, err := client.ResolveDigest(ctx, image)
+			if err != nil {
+				return err
+			}
+			digests[image] = pinned
+		}
+	}
+
+	cluster.Status.ImageDigests = digests
+
+	return nil
+}
+
+// registryCredentials returns the registries credentials found in the cluster's image pull secrets.
+func (r *TemporalClusterReconciler) registryCredentials(ctx context.Context, cluster *v1beta1.TemporalCluster) (map[string]registry.Credentials, error) {
+	result := map[string]registry.Credentials{}
+
+	for _, ref := range cluster.Spec.ImagePullSecrets {
+		secret := &corev1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: cluster.GetNamespace(), Name: ref.Name}, secret)
+		if err != nil {
+			return nil, fmt.Errorf("can't get image pull secret %s: %w", ref.Name, err)
+		}
+
+		if secret.Type != corev1.SecretTypeDockerConfigJson {
+			continue
+		}
+
+		credentials, err := registry.CredentialsFromDockerConfig(secret.Data[corev1.DockerConfigJsonKey])
+		if err != nil {
+			return nil, fmt.Errorf("can't read image pull secret %s: %w", ref.Name, err)
+		}
+
+		for host, c := range credentials {
+			result[host] = c
+		}
+	}
+
+	return result, nil
+}
+
+// reconcileImageVerification verifies the signatures of the temporal images pinned digests,
+// and records the verified digests in status.verifiedImages.
+// Already verified digests are not verified again.
+func (r *TemporalClusterReconciler) reconcileImageVerification(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	spec := cluster.Spec.ImageVerification
+	if spec == nil {
+		cluster.Status.VerifiedImages = nil
+		return nil
+	}
+
+	verified := []string{}
+	pending := []string{}
+	for _, image := range cluster.TemporalImages() {
+		pinned, ok := cluster.Status.ImageDigests[image]
+		if !ok {
+			return fmt.Errorf("can't verify %s: image digest is not resolved", image)
+		}
+
+		if slices.Contains(cluster.Status.VerifiedImages, pinned) {
+			verified = append(verified, pinned)
+			continue
+		}
+		pending = append(pending, pinned)
+	}
+
+	if len(pending) > 0 {
+		data, err := r.getSecretKeyValue(ctx, cluster.GetNamespace(), &spec.PublicKeySecretRef, defaultImageVerificationPublicKey)
+		if err != nil {
+			return fmt.Errorf("can't get image verification public key: %w", err)
+		}
+
+		key, err := registry.ParsePublicKey(data)
+		if err != nil {
+			return err
+		}
+
+		credentials, err := r.registryCredentials(ctx, cluster)
+		if err != nil {
+			return err
+		}
+
+		client := registry.NewClient(credentials)
+		for _, image := range pending {
+			err := client.VerifySignature(ctx, image, key)
+			if err != nil {
+				return err
+			}
+			verified = append(verified, image)
+		}
+	}
+
+	cluster.Status.VerifiedImages = verified
+
+	return nil
+}
@@ -0,0 +1,116 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// jobFinishTime returns whether the provided job finished, whether it succeeded and when it finished.
+func jobFinishTime(job *batchv1.Job) (bool, bool, time.Time) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, true, condition.LastTransitionTime.Time
+		case batchv1.JobFailed:
+			return true, false, condition.LastTransitionTime.Time
+		}
+	}
+	return false, false, time.Time{}
+}
+
+// jobsBeyondHistoryLimit returns the jobs exceeding the provided limit, the most recently finished jobs being kept.
+func jobsBeyondHistoryLimit(jobs []*batchv1.Job, finishTimes map[*batchv1.Job]time.Time, limit int) []*batchv1.Job {
+	if len(jobs) <= limit {
+		return nil
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return finishTimes[jobs[i]].After(finishTimes[jobs[j]])
+	})
+
+	return jobs[limit:]
+}
+
+// reconcileJobHistory deletes the cluster's finished setup/update jobs beyond the spec.jobHistoryLimits limits.
+// Jobs in activeJobs, which the persistence reconciliation still relies on, are never deleted.
+func (r *TemporalClusterReconciler) reconcileJobHistory(ctx context.Context, cluster *v1beta1.TemporalCluster, activeJobs map[string]bool) error {
+	limits := cluster.Spec.JobHistoryLimits
+	if limits == nil {
+		return nil
+	}
+
+	jobs := &batchv1.JobList{}
+	err := r.List(ctx, jobs, client.InNamespace(cluster.GetNamespace()), client.MatchingFields{ownerKey: cluster.GetName()})
+	if err != nil {
+		return err
+	}
+
+	succeeded := []*batchv1.Job{}
+	failed := []*batchv1.Job{}
+	finishTimes := map[*batchv1.Job]time.Time{}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if activeJobs[job.GetName()] || !job.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		finished, success, finishTime := jobFinishTime(job)
+		if !finished {
+			continue
+		}
+
+		finishTimes[job] = finishTime
+		if success {
+			succeeded = append(succeeded, job)
+		} else {
+			failed = append(failed, job)
+		}
+	}
+
+	toDelete := jobsBeyondHistoryLimit(succeeded, finishTimes, limits.GetSuccessfulJobsHistoryLimit())
+	toDelete = append(toDelete, jobsBeyondHistoryLimit(failed, finishTimes, limits.GetFailedJobsHistoryLimit())...)
+
+	for _, job := range toDelete {
+		log.FromContext(ctx).Info("Deleting finished job beyond history limit", "job", job.GetName())
+
+		err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("can't delete job %s: %w", job.GetName(), err)
+		}
+
+		metrics.ForgetSchemaJob(cluster.GetNamespace(), cluster.GetName(), job.GetName())
+	}
+
+	return nil
+}
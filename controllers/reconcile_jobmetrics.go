@@ -0,0 +1,60 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// schemaJobDuration returns the duration of the provided job, up to now if it's still running.
+func schemaJobDuration(job *batchv1.Job, now time.Time) time.Duration {
+	if job.Status.StartTime == nil {
+		return 0
+	}
+	end := now
+	if finished, _, finishTime := jobFinishTime(job); finished {
+		end = finishTime
+	}
+	return end.Sub(job.Status.StartTime.Time)
+}
+
+// reportSchemaJobsMetrics records the metrics of the provided cluster schema jobs.
+// Jobs which aren't created yet are ignored.
+func (r *TemporalClusterReconciler) reportSchemaJobsMetrics(ctx context.Context, cluster *v1beta1.TemporalCluster, names []string) error {
+	now := time.Now()
+	for _, name := range names {
+		job := &batchv1.Job{}
+		err := r.Get(ctx, client.ObjectKey{Namespace: cluster.GetNamespace(), Name: name}, job)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		metrics.ObserveSchemaJob(cluster.GetNamespace(), cluster.GetName(), name, schemaJobDuration(job, now), job.Status.Failed, job.Status.Succeeded > 0)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/persistence"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// managedElasticsearchStartupInterval is the interval at which the managed Elasticsearch instances are checked while starting.
+const managedElasticsearchStartupInterval = 10 * time.Second
+
+// reconcileManagedElasticsearch ensures the Elasticsearch instances run by the operator are up-to-date.
+// It returns a requeue delay while an instance is not ready, as the schema jobs can't set up its indices yet.
+func (r *TemporalClusterReconciler) reconcileManagedElasticsearch(ctx context.Context, cluster *v1beta1.TemporalCluster) (time.Duration, error) {
+	builders := []resource.Builder{}
+	for _, store := range cluster.Spec.Persistence.GetDatastores() {
+		builders = append(builders,
+			persistence.NewManagedElasticsearchVolumeClaimBuilder(cluster, r.Scheme, store),
+			persistence.NewManagedElasticsearchDeploymentBuilder(cluster, r.Scheme, store),
+			persistence.NewManagedElasticsearchServiceBuilder(cluster, r.Scheme, store),
+		)
+	}
+
+	objects, err := r.Reconciler.ReconcileBuilders(ctx, cluster, builders)
+	if err != nil {
+		return 0, fmt.Errorf("can't reconcile managed elasticsearch: %w", err)
+	}
+
+	for _, object := range objects {
+		deployment, ok := object.(*appsv1.Deployment)
+		if !ok {
+			continue
+		}
+
+		if deployment.Status.ObservedGeneration < deployment.GetGeneration() || deployment.Status.ReadyReplicas == 0 {
+			log.FromContext(ctx).Info("Waiting for managed elasticsearch to be ready", "deployment", deployment.GetName())
+			return managedElasticsearchStartupInterval, nil
+		}
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,128 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal/schema"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateSchemaJobsPrefixes maps the schema update jobs names prefixes to the datastore they update.
+var updateSchemaJobsPrefixes = map[string]string{
+	"update-default-schema-":             v1beta1.DefaultStoreName,
+	"update-visibility-schema-":          v1beta1.VisibilityStoreName,
+	"update-2nd-visibility-schema-":      v1beta1.SecondaryVisibilityStoreName,
+	"update-advanced-visibility-schema-": v1beta1.AdvancedVisibilityStoreName,
+}
+
+// reconcileMigrationProgress reports the progress of the running schema update job in status.persistence.migrationProgress,
+// by parsing the job logs.
+func (r *TemporalClusterReconciler) reconcileMigrationProgress(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	if r.Clientset == nil {
+		return nil
+	}
+
+	jobs := &batchv1.JobList{}
+	err := r.List(ctx, jobs, client.InNamespace(cluster.GetNamespace()), client.MatchingFields{ownerKey: cluster.GetName()})
+	if err != nil {
+		return err
+	}
+
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.Status.Active == 0 {
+			continue
+		}
+
+		store, ok := updateSchemaJobStore(job.Labels["app.kubernetes.io/component"])
+		if !ok {
+			continue
+		}
+
+		progress, err := r.getJobUpdateProgress(ctx, job)
+		if err != nil {
+			return fmt.Errorf("can't get job %s progress: %w", job.GetName(), err)
+		}
+
+		cluster.Status.Persistence.MigrationProgress = &v1beta1.MigrationProgress{
+			Store:          store,
+			Job:            job.GetName(),
+			LastUpdateTime: metav1.Now(),
+		}
+
+		if progress != nil {
+			cluster.Status.Persistence.MigrationProgress.CurrentVersion = progress.CurrentVersion
+			cluster.Status.Persistence.MigrationProgress.ApplyingVersion = progress.ApplyingVersion
+			cluster.Status.Persistence.MigrationProgress.AppliedSteps = progress.AppliedSteps
+			cluster.Status.Persistence.MigrationProgress.TotalSteps = progress.TotalSteps
+		}
+
+		return nil
+	}
+
+	// No schema update job is running.
+	cluster.Status.Persistence.MigrationProgress = nil
+
+	return nil
+}
+
+// updateSchemaJobStore returns the datastore updated by the provided job component name.
+func updateSchemaJobStore(component string) (string, bool) {
+	for prefix, store := range updateSchemaJobsPrefixes {
+		if strings.HasPrefix(component, prefix) {
+			return store, true
+		}
+	}
+	return "", false
+}
+
+// getJobUpdateProgress parses the logs of the job running pod.
+func (r *TemporalClusterReconciler) getJobUpdateProgress(ctx context.Context, job *batchv1.Job) (*schema.UpdateProgress, error) {
+	pods, err := r.Clientset.CoreV1().Pods(job.GetNamespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("job-name=%s", job.GetName()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		logs, err := r.Clientset.CoreV1().Pods(pod.GetNamespace()).GetLogs(pod.GetName(), &corev1.PodLogOptions{
+			Container: "schema-script-runner",
+		}).Stream(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer logs.Close()
+
+		return schema.ParseUpdateProgress(logs)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,118 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/notification"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// certificateExpiringThreshold is the remaining validity below which a certificate is reported as expiring.
+	certificateExpiringThreshold = 7 * 24 * time.Hour
+	// clusterUnhealthyThreshold is the duration after which a not ready cluster is reported as unhealthy.
+	// It avoids notifying about expected unavailability during rollouts.
+	clusterUnhealthyThreshold = 10 * time.Minute
+)
+
+// reconcileNotifications sends lifecycle notifications about the cluster, based on the outcome of the reconciliation.
+// observedVersion is the cluster status version before the reconciliation.
+func (r *TemporalClusterReconciler) reconcileNotifications(ctx context.Context, cluster *v1beta1.TemporalCluster, observedVersion string) {
+	if r.Notifier == nil {
+		return
+	}
+
+	desiredVersion := cluster.Spec.Version.String()
+
+	if observedVersion != "" && observedVersion != desiredVersion {
+		message := fmt.Sprintf("Upgrading cluster %s/%s from %s to %s", cluster.GetNamespace(), cluster.GetName(), observedVersion, desiredVersion)
+		r.Notifier.Notify(ctx, cluster, notification.UpgradeStartedEvent, desiredVersion, message)
+	}
+
+	if observedVersion != "" && observedVersion != cluster.Status.Version && cluster.Status.Version == desiredVersion {
+		message := fmt.Sprintf("Cluster %s/%s upgraded from %s to %s", cluster.GetNamespace(), cluster.GetName(), observedVersion, desiredVersion)
+		r.Notifier.Notify(ctx, cluster, notification.UpgradeCompletedEvent, desiredVersion, message)
+	}
+
+	if cond, ok := v1beta1.GetTemporalClusterReadyCondition(cluster); ok && cond.Status == metav1.ConditionFalse &&
+		time.Since(cond.LastTransitionTime.Time) > clusterUnhealthyThreshold {
+		message := fmt.Sprintf("Cluster %s/%s is not ready since %s: %s %s", cluster.GetNamespace(), cluster.GetName(), cond.LastTransitionTime, cond.Reason, cond.Message)
+		r.Notifier.Notify(ctx, cluster, notification.ClusterUnhealthyEvent, cond.LastTransitionTime.String(), message)
+	}
+
+	err := r.notifyFailedSchemaJobs(ctx, cluster)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Can't check schema jobs for notifications")
+	}
+
+	if r.AvailableAPIs.CertManager && cluster.MTLSWithCertManagerEnabled() {
+		err := r.notifyExpiringCertificates(ctx, cluster)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Can't check certificates for notifications")
+		}
+	}
+}
+
+func (r *TemporalClusterReconciler) notifyFailedSchemaJobs(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	jobs := &batchv1.JobList{}
+	err := r.List(ctx, jobs, client.InNamespace(cluster.GetNamespace()), client.MatchingFields{ownerKey: cluster.GetName()})
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs.Items {
+		for _, condition := range job.Status.Conditions {
+			if condition.Type != batchv1.JobFailed || condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			message := fmt.Sprintf("Schema job %s of cluster %s/%s failed: %s", job.GetName(), cluster.GetNamespace(), cluster.GetName(), condition.Message)
+			r.Notifier.Notify(ctx, cluster, notification.SchemaJobFailedEvent, string(job.GetUID()), message)
+		}
+	}
+
+	return nil
+}
+
+func (r *TemporalClusterReconciler) notifyExpiringCertificates(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	certificates := &certmanagerv1.CertificateList{}
+	err := r.List(ctx, certificates, client.InNamespace(cluster.GetNamespace()), client.MatchingFields{ownerKey: cluster.GetName()})
+	if err != nil {
+		return err
+	}
+
+	for _, certificate := range certificates.Items {
+		notAfter := certificate.Status.NotAfter
+		if notAfter == nil || time.Until(notAfter.Time) > certificateExpiringThreshold {
+			continue
+		}
+		message := fmt.Sprintf("Certificate %s of cluster %s/%s expires at %s", certificate.GetName(), cluster.GetNamespace(), cluster.GetName(), notAfter)
+		r.Notifier.Notify(ctx, cluster, notification.CertificateExpiringEvent, fmt.Sprintf("%s/%s", certificate.GetName(), notAfter), message)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// applyClusterOverrides applies the TemporalClusterOverrides selecting the cluster to its in-memory spec
+// and reports them in status.appliedOverrides. The caller restores the stored spec before patching the cluster,
+// so the cluster spec managed in Git is left untouched.
+// An event is recorded each time the applied overrides change.
+func (r *TemporalClusterReconciler) applyClusterOverrides(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	applied, err := temporal.ApplyClusterOverrides(ctx, r.Client, cluster)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Equal(applied, cluster.Status.AppliedOverrides) {
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "ClusterOverridesChanged", "Applied overrides: [%s]", strings.Join(applied, ", "))
+	}
+
+	cluster.Status.AppliedOverrides = nil
+	if len(applied) > 0 {
+		cluster.Status.AppliedOverrides = applied
+	}
+
+	return nil
+}
+
+// overrideToClustersMapfunc enqueues the clusters of the override namespace.
+// All clusters are enqueued, as clusters no longer selected by the override must be reconciled too.
+func (r *TemporalClusterReconciler) overrideToClustersMapfunc(ctx context.Context, o client.Object) []reconcile.Request {
+	clusters := &v1beta1.TemporalClusterList{}
+	err := r.List(ctx, clusters, client.InNamespace(o.GetNamespace()))
+	if err != nil {
+		return nil
+	}
+
+	result := make([]reconcile.Request, 0, len(clusters.Items))
+	for _, cluster := range clusters.Items {
+		result = append(result, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&cluster),
+		})
+	}
+
+	return result
+}
@@ -19,6 +19,8 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"path"
 	"strings"
@@ -31,6 +33,7 @@ import (
 	"github.com/alexandrevilain/temporal-operator/internal/resource/persistence"
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 func sanitizeVersionToName(version *version.Version) string {
@@ -103,6 +106,12 @@ func (r *TemporalClusterReconciler) reconcilePersistenceStatus(cluster *v1beta1.
 	}
 }
 
+// migrationHash returns a short hash of the datastore source and target databases, used to name its migration job.
+func migrationHash(spec *v1beta1.DatastoreSpec, status *v1beta1.DatastoreStatus) string {
+	sum := sha256.Sum256([]byte(status.DatabaseName + "/" + spec.GetDatabaseName()))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
 func getDatabaseScriptCommand(script string) []string {
 	return []string{path.Join("/etc/scripts", script)}
 }
@@ -125,8 +134,51 @@ func (r *TemporalClusterReconciler) reconcilePersistence(ctx context.Context, cl
 		return 0, fmt.Errorf("can't reconcile schema serviceaccount: %w", err)
 	}
 
+	// Ensure the datastores alias services exist before running jobs connecting through them.
+	aliasBuilders := []resource.Builder{}
+	for _, store := range cluster.Spec.Persistence.GetDatastores() {
+		aliasBuilders = append(aliasBuilders, persistence.NewDatastoreAliasServiceBuilder(cluster, r.Scheme, store))
+	}
+	_, err = r.Reconciler.ReconcileBuilders(ctx, cluster, aliasBuilders)
+	if err != nil {
+		return 0, fmt.Errorf("can't reconcile datastores alias services: %w", err)
+	}
+
+	// Ensure the managed Elasticsearch instances are running before setting up their indices.
+	requeueAfter, err := r.reconcileManagedElasticsearch(ctx, cluster)
+	if err != nil || requeueAfter > 0 {
+		return requeueAfter, err
+	}
+
 	// Then for each stores actions, check if the corresponding job is created and has successfully ran.
+	// Pending databases migrations run first, so the following jobs are run against the migrated databases.
 	jobs := []*reconciler.Job{
+		{
+			Name:    fmt.Sprintf("migrate-default-database-%s", migrationHash(cluster.Spec.Persistence.DefaultStore, cluster.Status.Persistence.DefaultStore)),
+			Command: getDatabaseScriptCommand(persistence.MigrateDefaultDatabaseScript),
+			Skip: func(owner runtime.Object) bool {
+				c := owner.(*v1beta1.TemporalCluster)
+				return !c.Status.Persistence.DefaultStore.MigrationPending(c.Spec.Persistence.DefaultStore)
+			},
+			ReportSuccess: func(owner runtime.Object) error {
+				c := owner.(*v1beta1.TemporalCluster)
+				c.Status.Persistence.DefaultStore.DatabaseName = c.Spec.Persistence.DefaultStore.GetDatabaseName()
+				return nil
+			},
+		},
+		{
+			Name:    fmt.Sprintf("migrate-visibility-database-%s", migrationHash(cluster.Spec.Persistence.VisibilityStore, cluster.Status.Persistence.VisibilityStore)),
+			Command: getDatabaseScriptCommand(persistence.MigrateVisibilityDatabaseScript),
+			Skip: func(owner runtime.Object) bool {
+				c := owner.(*v1beta1.TemporalCluster)
+				return !c.Status.Persistence.VisibilityStore.MigrationPending(c.Spec.Persistence.VisibilityStore)
+			},
+			ReportSuccess: func(owner runtime.Object) error {
+				c := owner.(*v1beta1.TemporalCluster)
+				c.Status.Persistence.VisibilityStore.DatabaseName = c.Spec.Persistence.VisibilityStore.GetDatabaseName()
+				return nil
+			},
+		},
 		{
 			Name:    "create-default-database",
 			Command: getDatabaseScriptCommand(persistence.CreateDefaultDatabaseScript),
@@ -315,5 +367,34 @@ func (r *TemporalClusterReconciler) reconcilePersistence(ctx context.Context, cl
 		return persistence.NewSchemaJobBuilder(cluster, scheme, name, command)
 	}
 
-	return r.Jobs.Reconcile(ctx, cluster, factory, jobs)
+	// Jobs reported as successful are skipped from now on, collect the pending ones first to report their final state.
+	pendingJobs := []string{}
+	for _, job := range jobs {
+		if !job.Skip(cluster) {
+			pendingJobs = append(pendingJobs, cluster.ChildResourceName(job.Name))
+		}
+	}
+
+	requeueAfter, err = r.Jobs.Reconcile(ctx, cluster, factory, jobs)
+
+	if metricsErr := r.reportSchemaJobsMetrics(ctx, cluster, pendingJobs); metricsErr != nil {
+		log.FromContext(ctx).Error(metricsErr, "Can't report schema jobs metrics")
+	}
+
+	if progressErr := r.reconcileMigrationProgress(ctx, cluster); progressErr != nil {
+		log.FromContext(ctx).Error(progressErr, "Can't report schema migration progress")
+	}
+
+	activeJobs := map[string]bool{}
+	for _, job := range jobs {
+		if !job.Skip(cluster) {
+			activeJobs[cluster.ChildResourceName(job.Name)] = true
+		}
+	}
+
+	if historyErr := r.reconcileJobHistory(ctx, cluster, activeJobs); historyErr != nil {
+		log.FromContext(ctx).Error(historyErr, "Can't delete finished jobs beyond history limits")
+	}
+
+	return requeueAfter, err
 }
@@ -0,0 +1,108 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// persistenceChangeConfirmedReason is the reason of the event emitted when a datastore endpoint change is confirmed.
+const persistenceChangeConfirmedReason = "PersistenceChangeConfirmed"
+
+// datastoreState holds the spec and the status of a datastore.
+type datastoreState struct {
+	spec   *v1beta1.DatastoreSpec
+	status *v1beta1.DatastoreStatus
+}
+
+// datastoreStates returns the spec and the status of each cluster datastore.
+func datastoreStates(cluster *v1beta1.TemporalCluster) []datastoreState {
+	states := []datastoreState{
+		{spec: cluster.Spec.Persistence.DefaultStore, status: cluster.Status.Persistence.DefaultStore},
+		{spec: cluster.Spec.Persistence.VisibilityStore, status: cluster.Status.Persistence.VisibilityStore},
+	}
+
+	if cluster.Spec.Persistence.SecondaryVisibilityStore != nil {
+		states = append(states, datastoreState{spec: cluster.Spec.Persistence.SecondaryVisibilityStore, status: cluster.Status.Persistence.SecondaryVisibilityStore})
+	}
+
+	if cluster.Spec.Persistence.AdvancedVisibilityStore != nil {
+		states = append(states, datastoreState{spec: cluster.Spec.Persistence.AdvancedVisibilityStore, status: cluster.Status.Persistence.AdvancedVisibilityStore})
+	}
+
+	return states
+}
+
+// reconcilePersistenceChanges records the endpoint each datastore has been set up in, and blocks the cluster
+// reconciliation when it changes: a mistyped address or database name would otherwise make the cluster
+// run on an empty database. Changes are applied once confirmed using the temporal.io/confirm-persistence-change annotation.
+// Database name changes allowed using the temporal.io/migrate-datastores annotation are left to the datastore migration.
+func (r *TemporalClusterReconciler) reconcilePersistenceChanges(cluster *v1beta1.TemporalCluster) error {
+	r.reconcilePersistenceStatus(cluster)
+
+	confirmed := cluster.GetAnnotations()[v1beta1.ConfirmPersistenceChangeAnnotation] == "true"
+	migrate := cluster.GetAnnotations()[v1beta1.MigrateDatastoresAnnotation] == "true"
+
+	changes := []string{}
+	for _, store := range datastoreStates(cluster) {
+		if !store.status.Setup {
+			continue
+		}
+
+		if store.status.DatabaseName == "" && store.status.ConnectAddr == "" && store.status.PluginName == "" {
+			store.status.RecordEndpoint(store.spec)
+			continue
+		}
+
+		storeChanges := store.status.EndpointChanges(store.spec)
+		if len(storeChanges) == 0 {
+			continue
+		}
+
+		if !confirmed {
+			if migrate && len(storeChanges) == 1 && store.status.MigrationPending(store.spec) {
+				continue
+			}
+			changes = append(changes, fmt.Sprintf("%s %s", store.spec.Name, strings.Join(storeChanges, ", ")))
+			continue
+		}
+
+		databaseName := store.status.DatabaseName
+		store.status.RecordEndpoint(store.spec)
+		if migrate {
+			store.status.DatabaseName = databaseName
+		}
+
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, persistenceChangeConfirmedReason,
+			fmt.Sprintf("Confirmed %s %s", store.spec.Name, strings.Join(storeChanges, ", ")))
+	}
+
+	if len(changes) > 0 {
+		message := fmt.Sprintf("%s: set the %s annotation to \"true\" to confirm the change", strings.Join(changes, "; "), v1beta1.ConfirmPersistenceChangeAnnotation)
+		v1beta1.SetTemporalClusterPersistenceChangeBlocked(cluster, metav1.ConditionTrue, v1beta1.PersistenceChangeBlockedReason, message)
+		return fmt.Errorf("persistence change blocked: %s", message)
+	}
+
+	v1beta1.SetTemporalClusterPersistenceChangeBlocked(cluster, metav1.ConditionFalse, v1beta1.PersistenceUnchangedReason, "")
+	return nil
+}
@@ -0,0 +1,102 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// inventoryFromObjects returns the inventory of the provided reconciled objects, sorted for stable status updates.
+func inventoryFromObjects(scheme *runtime.Scheme, objects []client.Object) ([]v1beta1.InventoryEntry, error) {
+	result := make([]v1beta1.InventoryEntry, 0, len(objects))
+	for _, object := range objects {
+		gvk, err := apiutil.GVKForObject(object, scheme)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, v1beta1.InventoryEntry{
+			Group:   gvk.Group,
+			Version: gvk.Version,
+			Kind:    gvk.Kind,
+			Name:    object.GetName(),
+			UID:     object.GetUID(),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// pruneOrphanedResources deletes the resources of the cluster's previous inventory missing from the provided one,
+// then records the provided inventory in the cluster status.
+// Deletions are conditioned on the recorded UID, so resources re-created by users are never deleted.
+func (r *TemporalClusterReconciler) pruneOrphanedResources(ctx context.Context, cluster *v1beta1.TemporalCluster, inventory []v1beta1.InventoryEntry) error {
+	logger := log.FromContext(ctx)
+
+	current := map[v1beta1.InventoryEntry]bool{}
+	for _, entry := range inventory {
+		current[entry] = true
+	}
+
+	for _, entry := range cluster.Status.Inventory {
+		if current[entry] || entry.UID == "" {
+			continue
+		}
+
+		object := &metav1.PartialObjectMetadata{}
+		object.SetGroupVersionKind(entry.GroupVersionKind())
+		object.SetNamespace(cluster.GetNamespace())
+		object.SetName(entry.Name)
+
+		uid := entry.UID
+		err := r.Delete(ctx, object, client.Preconditions{UID: &uid}, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil {
+			// The resource is already gone, has been re-created, or its API has been removed.
+			if apierrors.IsNotFound(err) || apierrors.IsConflict(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("can't prune %s %s: %w", entry.Kind, entry.Name, err)
+		}
+
+		logger.Info("Pruned orphaned resource", "kind", entry.Kind, "name", entry.Name)
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "ResourcePruned", "Deleted %s %s no longer managed by the operator", entry.Kind, entry.Name)
+	}
+
+	cluster.Status.Inventory = inventory
+
+	return nil
+}
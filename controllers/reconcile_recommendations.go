@@ -0,0 +1,66 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/recommendation"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileRecommendations samples the services pods usage from metrics-server and publishes
+// the resulting resources recommendations in status.recommendations.
+// It returns the duration after which the next sample should be taken.
+func (r *TemporalClusterReconciler) reconcileRecommendations(ctx context.Context, cluster *v1beta1.TemporalCluster) time.Duration {
+	spec := cluster.Spec.ResourceAdvisor
+	if !spec.IsEnabled() {
+		cluster.Status.Recommendations = nil
+		return 0
+	}
+
+	interval := spec.GetInterval()
+
+	// Avoid querying metrics-server on every reconciliation.
+	if previous := cluster.Status.Recommendations; previous != nil && time.Since(previous.LastSampleTime.Time) < interval {
+		return interval - time.Since(previous.LastSampleTime.Time)
+	}
+
+	// The metrics API doesn't support watches, query it directly instead of using the cached client.
+	data, err := r.Clientset.Discovery().RESTClient().Get().
+		AbsPath("/apis/metrics.k8s.io/v1beta1/namespaces", cluster.GetNamespace(), "pods").
+		Param("labelSelector", labels.SelectorFromSet(cluster.SelectorLabels()).String()).
+		DoRaw(ctx)
+	if err != nil {
+		log.FromContext(ctx).Info("Can't get services pods metrics, is metrics-server installed?", "error", err.Error())
+		return interval
+	}
+
+	usage, err := recommendation.ServicesPeakUsage(data)
+	if err != nil {
+		log.FromContext(ctx).Info("Can't compute services usage", "error", err.Error())
+		return interval
+	}
+
+	cluster.Status.Recommendations = recommendation.Update(cluster.Status.Recommendations, usage, spec, time.Now())
+
+	return interval
+}
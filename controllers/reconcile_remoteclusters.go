@@ -0,0 +1,185 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// replicationClientComponent is the component label of the clients issued to connect to remote clusters.
+const replicationClientComponent = "replication"
+
+// replicationClientName returns the name of the TemporalClusterClient issued to connect to the provided remote cluster.
+func replicationClientName(cluster *v1beta1.TemporalCluster, remote v1beta1.RemoteClusterSpec) string {
+	return cluster.ChildResourceName(fmt.Sprintf("replication-%s", remote.Name))
+}
+
+// remoteClusterRequiresClient returns true if the operator must issue a client certificate to connect to the remote cluster.
+func remoteClusterRequiresClient(remote v1beta1.RemoteClusterSpec, remoteCluster *v1beta1.TemporalCluster) bool {
+	return remote.TLS == nil &&
+		remoteCluster != nil &&
+		remoteCluster.MTLSWithCertManagerEnabled() &&
+		remoteCluster.Spec.MTLS.FrontendEnabled()
+}
+
+// getRemoteCluster returns the TemporalCluster referenced by the provided remote cluster, or nil if it doesn't reference one or doesn't exist.
+func (r *TemporalClusterReconciler) getRemoteCluster(ctx context.Context, cluster *v1beta1.TemporalCluster, remote v1beta1.RemoteClusterSpec) (*v1beta1.TemporalCluster, error) {
+	if remote.ClusterRef == nil {
+		return nil, nil
+	}
+
+	remoteCluster, err := temporal.GetEffectiveCluster(ctx, r.Client, remote.ClusterRef.NamespacedName(cluster))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("can't get remote cluster %s: %w", remote.Name, err)
+	}
+
+	return remoteCluster, nil
+}
+
+// reconcileReplicationClients issues, using TemporalClusterClients, the client certificates used to connect to
+// the referenced remote clusters having frontend mTLS enabled with cert-manager.
+// Clients of remote clusters no longer requiring them are deleted.
+func (r *TemporalClusterReconciler) reconcileReplicationClients(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	desired := map[string]bool{}
+
+	if cluster.Spec.Replication.IsEnabled() {
+		for _, remote := range cluster.Spec.Replication.RemoteClusters {
+			remoteCluster, err := r.getRemoteCluster(ctx, cluster, remote)
+			if err != nil {
+				return err
+			}
+
+			if !remoteClusterRequiresClient(remote, remoteCluster) {
+				continue
+			}
+
+			clusterClient := &v1beta1.TemporalClusterClient{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      replicationClientName(cluster, remote),
+					Namespace: cluster.GetNamespace(),
+				},
+			}
+
+			_, err = controllerutil.CreateOrUpdate(ctx, r.Client, clusterClient, func() error {
+				clusterClient.Labels = metadata.GetLabels(cluster, replicationClientComponent, cluster.Spec.Version, cluster.Labels)
+				clusterClient.Spec.ClusterRef = v1beta1.ObjectReference{
+					Name:      remoteCluster.GetName(),
+					Namespace: remoteCluster.GetNamespace(),
+				}
+				return controllerutil.SetControllerReference(cluster, clusterClient, r.Scheme)
+			})
+			if err != nil {
+				return fmt.Errorf("can't reconcile remote cluster %s client: %w", remote.Name, err)
+			}
+
+			desired[clusterClient.GetName()] = true
+		}
+	}
+
+	list := &v1beta1.TemporalClusterClientList{}
+	err := r.List(ctx, list,
+		client.InNamespace(cluster.GetNamespace()),
+		client.MatchingLabels(metadata.LabelsSelector(cluster, replicationClientComponent)),
+	)
+	if err != nil {
+		return fmt.Errorf("can't list replication clients: %w", err)
+	}
+
+	for _, clusterClient := range list.Items {
+		clusterClient := clusterClient
+		if desired[clusterClient.GetName()] || !metav1.IsControlledBy(&clusterClient, cluster) {
+			continue
+		}
+
+		err := r.Delete(ctx, &clusterClient)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("can't delete replication client %s: %w", clusterClient.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// remoteClusterConnections resolves the address and the TLS configuration used to connect to the cluster's remote clusters.
+// Remote clusters without a known address are omitted.
+// The TLS configuration of a remote cluster requiring a client certificate is only set once the certificate is issued,
+// so services never wait for a remote cluster to be ready.
+func (r *TemporalClusterReconciler) remoteClusterConnections(ctx context.Context, cluster *v1beta1.TemporalCluster) ([]v1beta1.RemoteClusterConnection, error) {
+	if !cluster.Spec.Replication.IsEnabled() {
+		return nil, nil
+	}
+
+	connections := []v1beta1.RemoteClusterConnection{}
+	for _, remote := range cluster.Spec.Replication.RemoteClusters {
+		remoteCluster, err := r.getRemoteCluster(ctx, cluster, remote)
+		if err != nil {
+			return nil, err
+		}
+
+		connection := v1beta1.RemoteClusterConnection{
+			Name:    remote.Name,
+			Address: remote.Address,
+			TLS:     remote.TLS,
+			Ready:   remote.ClusterRef == nil,
+		}
+
+		if remoteCluster != nil {
+			if connection.Address == "" {
+				connection.Address = remoteCluster.GetPublicClientAddress()
+			}
+			connection.Ready = remoteCluster.IsReady()
+		}
+
+		if connection.Address == "" {
+			continue
+		}
+
+		if remoteClusterRequiresClient(remote, remoteCluster) {
+			clusterClient := &v1beta1.TemporalClusterClient{}
+			err := r.Get(ctx, client.ObjectKey{Namespace: cluster.GetNamespace(), Name: replicationClientName(cluster, remote)}, clusterClient)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("can't get remote cluster %s client: %w", remote.Name, err)
+			}
+
+			if clusterClient.Status.SecretRef != nil && clusterClient.Status.SecretRef.Name != "" {
+				connection.TLS = &v1beta1.RemoteClusterTLSSpec{
+					SecretRef:  *clusterClient.Status.SecretRef,
+					ServerName: clusterClient.Status.ServerName,
+				}
+			} else {
+				connection.Ready = false
+			}
+		}
+
+		connections = append(connections, connection)
+	}
+
+	return connections, nil
+}
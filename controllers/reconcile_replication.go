@@ -0,0 +1,176 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"go.temporal.io/server/api/historyservice/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// replicationHealthCheckInterval is the interval between two replication health checks.
+const replicationHealthCheckInterval = time.Minute
+
+// reconcileReplicationHealth checks the connection and the replication lag to the cluster's remote clusters
+// and reports it in status.replication and the ReplicationHealthy condition.
+// It returns the duration after which the health should be checked again.
+func (r *TemporalClusterReconciler) reconcileReplicationHealth(ctx context.Context, cluster *v1beta1.TemporalCluster) time.Duration {
+	if !cluster.Spec.Replication.IsEnabled() || len(cluster.Spec.Replication.RemoteClusters) == 0 {
+		cluster.Status.Replication = nil
+		apimeta.RemoveStatusCondition(&cluster.Status.Conditions, v1beta1.ReplicationHealthyCondition)
+		return 0
+	}
+
+	if !cluster.IsReady() {
+		return replicationHealthCheckInterval
+	}
+
+	registrationErrors, err := r.registerRemoteClusters(ctx, cluster)
+	if err != nil {
+		log.FromContext(ctx).Info("Can't register remote clusters", "error", err.Error())
+	}
+
+	remotes, err := r.getRemoteClustersReplicationStatus(ctx, cluster)
+	if err != nil {
+		log.FromContext(ctx).Info("Can't get replication status", "error", err.Error())
+		v1beta1.SetTemporalClusterReplicationHealthy(cluster, metav1.ConditionUnknown, v1beta1.ReplicationStatusUnknownReason, err.Error())
+		return replicationHealthCheckInterval
+	}
+
+	maxLag := cluster.Spec.Replication.GetMaxReplicationLag()
+	messages := []string{}
+	for i, remote := range remotes {
+		if err, ok := registrationErrors[remote.Name]; ok && !remote.Connected {
+			remotes[i].Message = fmt.Sprintf("can't register remote cluster: %s", err)
+		}
+		if remote.Connected && remote.Lag != nil && remote.Lag.Duration > maxLag {
+			remotes[i].Message = fmt.Sprintf("replication lag %s exceeds %s", remote.Lag.Duration, maxLag)
+		}
+		if remotes[i].Message != "" {
+			messages = append(messages, fmt.Sprintf("%s: %s", remote.Name, remotes[i].Message))
+		}
+	}
+
+	cluster.Status.Replication = &v1beta1.ReplicationStatus{
+		RemoteClusters: remotes,
+		LastCheckTime:  metav1.Now(),
+	}
+
+	if len(messages) > 0 {
+		v1beta1.SetTemporalClusterReplicationHealthy(cluster, metav1.ConditionFalse, v1beta1.ReplicationUnhealthyReason, strings.Join(messages, "; "))
+	} else {
+		v1beta1.SetTemporalClusterReplicationHealthy(cluster, metav1.ConditionTrue, v1beta1.ReplicationHealthyReason, "")
+	}
+
+	return replicationHealthCheckInterval
+}
+
+// registerRemoteClusters registers the remote clusters having a known address using the admin API,
+// once they are ready to accept connections from the cluster.
+// It returns the registration error of each remote cluster that couldn't be registered.
+func (r *TemporalClusterReconciler) registerRemoteClusters(ctx context.Context, cluster *v1beta1.TemporalCluster) (map[string]error, error) {
+	connections, err := r.remoteClusterConnections(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	ready := []v1beta1.RemoteClusterConnection{}
+	for _, connection := range connections {
+		if connection.Ready {
+			ready = append(ready, connection)
+		}
+	}
+
+	if len(ready) == 0 {
+		return nil, nil
+	}
+
+	client, err := r.ClientManager.Client(ctx, cluster, "")
+	if err != nil {
+		return nil, fmt.Errorf("can't create cluster client: %w", err)
+	}
+
+	return temporal.RegisterRemoteClusters(ctx, client.OperatorService(), ready)
+}
+
+func (r *TemporalClusterReconciler) getRemoteClustersReplicationStatus(ctx context.Context, cluster *v1beta1.TemporalCluster) ([]v1beta1.RemoteClusterReplicationStatus, error) {
+	// The replication status is only served by the history hosts.
+	if !cluster.HistoryHostsReachable() {
+		return nil, errors.New("replication status can't be retrieved when internode mTLS isn't provided by cert-manager")
+	}
+
+	client, err := r.ClientManager.Client(ctx, cluster, "")
+	if err != nil {
+		return nil, fmt.Errorf("can't create cluster client: %w", err)
+	}
+
+	addresses, err := r.historyHostsAddresses(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := make([]historyservice.HistoryServiceClient, 0, len(addresses))
+	for _, address := range addresses {
+		history, conn, err := temporal.GetHistoryHostClient(ctx, r.Client, cluster, address, r.ClientManager.HostDialOptions()...)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		histories = append(histories, history)
+	}
+
+	return temporal.GetRemoteClustersReplicationStatus(ctx, client.OperatorService(), histories, cluster.Spec.Replication.RemoteClusters)
+}
+
+// historyHostsAddresses returns the gRPC addresses of the cluster ready history hosts.
+func (r *TemporalClusterReconciler) historyHostsAddresses(ctx context.Context, cluster *v1beta1.TemporalCluster) ([]string, error) {
+	endpoints := &corev1.Endpoints{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: cluster.GetNamespace(), Name: cluster.ChildResourceName("history-headless")}, endpoints)
+	if err != nil {
+		return nil, fmt.Errorf("can't get history hosts: %w", err)
+	}
+
+	port := strconv.Itoa(*cluster.Spec.Services.History.Port)
+
+	addresses := []string{}
+	for _, subset := range endpoints.Subsets {
+		for _, address := range subset.Addresses {
+			addresses = append(addresses, net.JoinHostPort(address.IP, port))
+		}
+	}
+
+	if len(addresses) == 0 {
+		return nil, errors.New("no ready history host")
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,136 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/alertmanager"
+	"github.com/alexandrevilain/temporal-operator/pkg/notification"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	defaultNotificationURLSecretKey = "url"
+	rolloutSilenceCreatedBy         = "temporal-operator"
+)
+
+// reconcileRolloutNotifications tracks rollouts initiated by the operator and notifies external systems about them.
+// A rollout starts when a ready cluster has services not ready anymore, and completes when all services are ready again.
+// Notification failures are reported as events and never fail the reconciliation.
+func (r *TemporalClusterReconciler) reconcileRolloutNotifications(ctx context.Context, cluster *v1beta1.TemporalCluster, wasReady, servicesReady bool) {
+	if !cluster.Spec.RolloutPolicy.NotificationsEnabled() {
+		cluster.Status.Rollout = nil
+		return
+	}
+
+	switch {
+	case cluster.Status.Rollout == nil && wasReady && !servicesReady:
+		r.startRollout(ctx, cluster)
+	case cluster.Status.Rollout != nil && servicesReady:
+		r.completeRollout(ctx, cluster)
+	}
+}
+
+func (r *TemporalClusterReconciler) startRollout(ctx context.Context, cluster *v1beta1.TemporalCluster) {
+	notifications := cluster.Spec.RolloutPolicy.Notifications
+
+	cluster.Status.Rollout = &v1beta1.RolloutStatus{
+		StartTime: metav1.Now(),
+	}
+
+	if notifications.Alertmanager != nil {
+		id, err := r.createRolloutSilence(ctx, cluster, notifications.Alertmanager)
+		if err != nil {
+			r.reportNotificationError(ctx, cluster, err)
+		} else {
+			cluster.Status.Rollout.SilenceID = id
+		}
+	}
+
+	r.sendRolloutNotification(ctx, cluster, notification.RolloutStartedEvent)
+}
+
+func (r *TemporalClusterReconciler) completeRollout(ctx context.Context, cluster *v1beta1.TemporalCluster) {
+	notifications := cluster.Spec.RolloutPolicy.Notifications
+
+	if notifications.Alertmanager != nil && cluster.Status.Rollout.SilenceID != "" {
+		url, err := r.getSecretKeyValue(ctx, cluster.GetNamespace(), &notifications.Alertmanager.URLSecretRef, defaultNotificationURLSecretKey)
+		if err == nil {
+			err = alertmanager.NewClient(string(url)).ExpireSilence(ctx, cluster.Status.Rollout.SilenceID)
+		}
+		if err != nil {
+			r.reportNotificationError(ctx, cluster, err)
+		}
+	}
+
+	r.sendRolloutNotification(ctx, cluster, notification.RolloutCompletedEvent)
+
+	cluster.Status.Rollout = nil
+}
+
+func (r *TemporalClusterReconciler) createRolloutSilence(ctx context.Context, cluster *v1beta1.TemporalCluster, spec *v1beta1.AlertmanagerSilenceSpec) (string, error) {
+	url, err := r.getSecretKeyValue(ctx, cluster.GetNamespace(), &spec.URLSecretRef, defaultNotificationURLSecretKey)
+	if err != nil {
+		return "", fmt.Errorf("can't get alertmanager url: %w", err)
+	}
+
+	matchers := spec.Matchers
+	if len(matchers) == 0 {
+		matchers = map[string]string{"namespace": cluster.GetNamespace()}
+	}
+
+	comment := fmt.Sprintf("Rollout of temporal cluster %s/%s", cluster.GetNamespace(), cluster.GetName())
+	silence := alertmanager.NewSilence(matchers, spec.GetDuration(), rolloutSilenceCreatedBy, comment)
+
+	return alertmanager.NewClient(string(url)).CreateSilence(ctx, silence)
+}
+
+func (r *TemporalClusterReconciler) sendRolloutNotification(ctx context.Context, cluster *v1beta1.TemporalCluster, eventType notification.EventType) {
+	ref := cluster.Spec.RolloutPolicy.Notifications.WebhookURLSecretRef
+	if ref == nil {
+		return
+	}
+
+	url, err := r.getSecretKeyValue(ctx, cluster.GetNamespace(), ref, defaultNotificationURLSecretKey)
+	if err != nil {
+		r.reportNotificationError(ctx, cluster, fmt.Errorf("can't get webhook url: %w", err))
+		return
+	}
+
+	err = notification.Send(ctx, string(url), &notification.Event{
+		Type:      eventType,
+		Cluster:   cluster.GetName(),
+		Namespace: cluster.GetNamespace(),
+		Version:   cluster.Spec.Version.String(),
+		Time:      time.Now(),
+	})
+	if err != nil {
+		r.reportNotificationError(ctx, cluster, err)
+	}
+}
+
+func (r *TemporalClusterReconciler) reportNotificationError(ctx context.Context, cluster *v1beta1.TemporalCluster, err error) {
+	log.FromContext(ctx).Error(err, "Can't notify about rollout")
+	r.Recorder.Event(cluster, corev1.EventTypeWarning, "RolloutNotificationFailed", err.Error())
+}
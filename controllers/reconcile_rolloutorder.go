@@ -0,0 +1,163 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// rolloutOrderRetryInterval is the interval between two checks of the rollouts held back by the services rollout order.
+const rolloutOrderRetryInterval = 10 * time.Second
+
+// rolloutSequencer holds back the rollout of the services listed in spec.rolloutPolicy.serviceOrder
+// while another listed service is rolling out.
+type rolloutSequencer struct {
+	// services maps the deployments of the listed services to their position in the rollout order.
+	services map[string]int
+	// rolling holds the deployments of the listed services with a rollout in progress.
+	rolling map[string]bool
+	// deferred lists the deployments whose rollout was held back.
+	deferred []string
+}
+
+// newRolloutSequencer returns the rollout sequencer of the cluster, or nil if no rollout order is defined.
+func (r *TemporalClusterReconciler) newRolloutSequencer(ctx context.Context, cluster *v1beta1.TemporalCluster) (*rolloutSequencer, error) {
+	order := cluster.Spec.RolloutPolicy.GetServiceOrder()
+	if len(order) == 0 {
+		return nil, nil
+	}
+
+	sequencer := &rolloutSequencer{
+		services: map[string]int{},
+		rolling:  map[string]bool{},
+	}
+
+	for i, service := range order {
+		name := cluster.ChildResourceName(service)
+		sequencer.services[name] = i
+
+		deployment := &appsv1.Deployment{}
+		err := r.Get(ctx, client.ObjectKey{Namespace: cluster.GetNamespace(), Name: name}, deployment)
+		if err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("can't get %s deployment: %w", service, err)
+			}
+			continue
+		}
+
+		sequencer.rolling[name] = deploymentRolling(deployment)
+	}
+
+	return sequencer, nil
+}
+
+// deploymentRolling returns true if the deployment pods are being replaced.
+func deploymentRolling(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	status := deployment.Status
+	return status.ObservedGeneration < deployment.GetGeneration() ||
+		status.UpdatedReplicas < replicas ||
+		status.Replicas > status.UpdatedReplicas ||
+		status.AvailableReplicas < status.UpdatedReplicas
+}
+
+// allow returns true if the deployment pods can be rolled out. Deployments not listed in the rollout order
+// are always allowed. Once allowed, a deployment holds back the rollout of the other listed services.
+func (s *rolloutSequencer) allow(deployment string) bool {
+	if s == nil {
+		return true
+	}
+
+	if _, ok := s.services[deployment]; !ok {
+		return true
+	}
+
+	for other, rolling := range s.rolling {
+		if other != deployment && rolling {
+			s.deferred = append(s.deferred, deployment)
+			return false
+		}
+	}
+
+	s.rolling[deployment] = true
+	return true
+}
+
+// order sorts the builders of the listed services deployments in the rollout order,
+// so the first listed service needing a rollout starts first. Other builders keep their position.
+func (s *rolloutSequencer) order(builders []resource.Builder) []resource.Builder {
+	if s == nil {
+		return builders
+	}
+
+	positions := []int{}
+	ordered := []resource.Builder{}
+	names := map[resource.Builder]string{}
+	for i, builder := range builders {
+		object := builder.Build()
+		if _, ok := object.(*appsv1.Deployment); !ok {
+			continue
+		}
+		if _, ok := s.services[object.GetName()]; ok {
+			positions = append(positions, i)
+			ordered = append(ordered, builder)
+			names[builder] = object.GetName()
+		}
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return s.services[names[ordered[i]]] < s.services[names[ordered[j]]]
+	})
+
+	result := make([]resource.Builder, len(builders))
+	copy(result, builders)
+	for i, position := range positions {
+		result[position] = ordered[i]
+	}
+
+	return result
+}
+
+// reconcileRolloutOrder reports the rollouts held back by the services rollout order.
+// It returns the duration after which the held back rollouts should be retried.
+func (r *TemporalClusterReconciler) reconcileRolloutOrder(ctx context.Context, cluster *v1beta1.TemporalCluster, sequencer *rolloutSequencer) time.Duration {
+	if sequencer == nil || len(sequencer.deferred) == 0 {
+		return 0
+	}
+
+	log.FromContext(ctx).Info("Rollout held back, another service is rolling out", "deployments", sequencer.deferred)
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "RolloutOrdered",
+		"Rollout of %s held back until the other services of the rollout order complete their rollout", strings.Join(sequencer.deferred, ", "))
+
+	return rolloutOrderRetryInterval
+}
@@ -0,0 +1,144 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"go.temporal.io/server/api/historyservice/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// shardDiagnosticsTimeout bounds the time spent inspecting shards during a reconciliation.
+const shardDiagnosticsTimeout = 2 * time.Minute
+
+// shardDiagnosticsReportKey is the key of the shard diagnostics ConfigMap holding the detailed report.
+const shardDiagnosticsReportKey = "report.json"
+
+// diagnoseShards inspects the history shards listed in the temporal.io/diagnose-shards annotation value,
+// stores the detailed report in the "<cluster>-shard-diagnostics" ConfigMap and summarizes it in status.shardDiagnostics.
+// Requests that can't be fulfilled are reported using the ShardDiagnosticsCompleted condition and a warning event.
+func (r *TemporalClusterReconciler) diagnoseShards(ctx context.Context, cluster *v1beta1.TemporalCluster, value string) error {
+	first, last, err := temporal.ParseShardRange(value, cluster.Spec.NumHistoryShards)
+	if err != nil {
+		r.shardDiagnosticsFailed(cluster, err.Error())
+		return nil
+	}
+
+	// Shards are inspected using the history hosts, which the operator can only authenticate to
+	// when the internode certificates are issued by cert-manager.
+	if !cluster.HistoryHostsReachable() {
+		r.shardDiagnosticsFailed(cluster, "Shards can't be inspected when internode mTLS isn't provided by cert-manager")
+		return nil
+	}
+
+	now := time.Now()
+	diagnostics, err := r.inspectShards(ctx, cluster, first, last, now)
+	if err != nil {
+		r.shardDiagnosticsFailed(cluster, fmt.Sprintf("Shards %d-%d can't be inspected: %s", first, last, err))
+		return nil
+	}
+
+	report, err := json.MarshalIndent(diagnostics, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.ChildResourceName("shard-diagnostics"),
+			Namespace: cluster.GetNamespace(),
+		},
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = metadata.GetLabels(cluster, "shard-diagnostics", cluster.Spec.Version, cluster.Labels)
+		configMap.Data = map[string]string{shardDiagnosticsReportKey: string(report)}
+		return controllerutil.SetControllerReference(cluster, configMap, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("can't store shard diagnostics report: %w", err)
+	}
+
+	status := &v1beta1.ShardDiagnosticsStatus{
+		ShardRange:        fmt.Sprintf("%d-%d", first, last),
+		InspectedShards:   int32(len(diagnostics.Shards)),
+		UnownedShards:     diagnostics.Count(temporal.ShardUnowned),
+		StaleShards:       diagnostics.Count(temporal.ShardStale),
+		StolenShards:      diagnostics.Count(temporal.ShardStolen),
+		UnreachableShards: diagnostics.Count(temporal.ShardUnreachable),
+		ReportRef:         corev1.LocalObjectReference{Name: configMap.GetName()},
+		Time:              metav1.NewTime(now),
+	}
+	cluster.Status.ShardDiagnostics = status
+
+	v1beta1.SetTemporalClusterShardDiagnosticsCompleted(cluster, metav1.ConditionTrue, v1beta1.ShardDiagnosticsCompletedReason, "")
+
+	log.FromContext(ctx).Info("Shards inspected", "range", status.ShardRange, "report", configMap.GetName())
+
+	unhealthy := status.UnownedShards + status.StaleShards + status.StolenShards + status.UnreachableShards
+	if unhealthy > 0 {
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "ShardDiagnosticsCompleted",
+			"%d of %d inspected shards are unhealthy (unowned: %d, unreachable: %d, stolen: %d, stale: %d), see ConfigMap %s",
+			unhealthy, status.InspectedShards, status.UnownedShards, status.UnreachableShards, status.StolenShards, status.StaleShards, configMap.GetName())
+	} else {
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "ShardDiagnosticsCompleted",
+			"%d inspected shards are healthy, see ConfigMap %s", status.InspectedShards, configMap.GetName())
+	}
+
+	return nil
+}
+
+// inspectShards queries the ready history hosts for the shards in the provided range.
+func (r *TemporalClusterReconciler) inspectShards(ctx context.Context, cluster *v1beta1.TemporalCluster, first, last int32, now time.Time) (*temporal.ShardDiagnostics, error) {
+	addresses, err := r.historyHostsAddresses(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := make(map[string]historyservice.HistoryServiceClient, len(addresses))
+	for _, address := range addresses {
+		history, conn, err := temporal.GetHistoryHostClient(ctx, r.Client, cluster, address, r.ClientManager.HostDialOptions()...)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		histories[address] = history
+	}
+
+	diagnosticsCtx, cancel := context.WithTimeout(ctx, shardDiagnosticsTimeout)
+	defer cancel()
+
+	return temporal.DiagnoseShards(diagnosticsCtx, histories, first, last, now)
+}
+
+// shardDiagnosticsFailed reports a shard diagnostics request which can't be fulfilled.
+func (r *TemporalClusterReconciler) shardDiagnosticsFailed(cluster *v1beta1.TemporalCluster, message string) {
+	v1beta1.SetTemporalClusterShardDiagnosticsCompleted(cluster, metav1.ConditionFalse, v1beta1.ShardDiagnosticsFailedReason, message)
+	r.Recorder.Event(cluster, corev1.EventTypeWarning, v1beta1.ShardDiagnosticsFailedReason, message)
+}
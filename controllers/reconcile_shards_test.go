@@ -0,0 +1,78 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiagnoseShardsFailure(t *testing.T) {
+	tests := map[string]struct {
+		value           string
+		mTLS            *v1beta1.MTLSSpec
+		expectedMessage string
+	}{
+		"invalid shard range": {
+			value:           "10-5",
+			expectedMessage: "invalid shard range",
+		},
+		"internode mTLS not provided by cert-manager": {
+			value: "all",
+			mTLS: &v1beta1.MTLSSpec{
+				Provider:  v1beta1.LinkerdMTLSProvider,
+				Internode: &v1beta1.InternodeMTLSSpec{Enabled: true},
+			},
+			expectedMessage: "internode mTLS isn't provided by cert-manager",
+		},
+		"no ready history host": {
+			value:           "1-4",
+			expectedMessage: "Shards 1-4 can't be inspected: can't get history hosts",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake", Namespace: "default"},
+				Spec: v1beta1.TemporalClusterSpec{
+					NumHistoryShards: 8,
+					MTLS:             test.mTLS,
+				},
+			}
+
+			r := &TemporalClusterReconciler{Base: newFakeBase(tt, cluster)}
+
+			err := r.diagnoseShards(context.Background(), cluster, test.value)
+			require.NoError(tt, err)
+
+			condition := apimeta.FindStatusCondition(cluster.Status.Conditions, v1beta1.ShardDiagnosticsCompletedCondition)
+			require.NotNil(tt, condition)
+			assert.Equal(tt, metav1.ConditionFalse, condition.Status)
+			assert.Equal(tt, v1beta1.ShardDiagnosticsFailedReason, condition.Reason)
+			assert.Contains(tt, condition.Message, test.expectedMessage)
+			assert.Nil(tt, cluster.Status.ShardDiagnostics)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/sizing"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// reconcileSizing reports the sizing issues of the cluster in the SizingWarning condition.
+// The admission webhook returns the same issues as warnings, the condition keeps them visible
+// after the cluster is applied and covers clusters admitted without the webhook.
+// A warning event is recorded each time the reported issues change.
+func (r *TemporalClusterReconciler) reconcileSizing(cluster *v1beta1.TemporalCluster) {
+	issues := sizing.Check(cluster)
+	if len(issues) == 0 {
+		v1beta1.SetTemporalClusterSizingWarning(cluster, metav1.ConditionFalse, v1beta1.SizingSensibleReason, "")
+		return
+	}
+
+	message := strings.Join(issues, " ")
+
+	previous := apimeta.FindStatusCondition(cluster.Status.Conditions, v1beta1.SizingWarningCondition)
+	if previous == nil || previous.Status != metav1.ConditionTrue || previous.Message != message {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, v1beta1.SizingIssuesReason, message)
+	}
+
+	v1beta1.SetTemporalClusterSizingWarning(cluster, metav1.ConditionTrue, v1beta1.SizingIssuesReason, message)
+}
@@ -0,0 +1,194 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	smokeTestRetryInterval = 30 * time.Second
+	// smokeTestPollInterval is the interval at which the result of a running smoke test is checked.
+	smokeTestPollInterval = 5 * time.Second
+)
+
+// smokeTestRun is a smoke test run in progress.
+type smokeTestRun struct {
+	version   string
+	startTime metav1.Time
+	cancel    context.CancelFunc
+	done      bool
+	err       error
+}
+
+// smokeTestRuns tracks the smoke tests running in the background, by cluster.
+// The zero value is ready to use.
+type smokeTestRuns struct {
+	mu   sync.Mutex
+	runs map[types.NamespacedName]*smokeTestRun
+}
+
+// start runs fn in the background for the provided cluster and version, and returns a copy of the started run.
+func (s *smokeTestRuns) start(ctx context.Context, key types.NamespacedName, version string, fn func(ctx context.Context) error) smokeTestRun {
+	ctx, cancel := context.WithCancel(ctx)
+	run := &smokeTestRun{
+		version:   version,
+		startTime: metav1.Now(),
+		cancel:    cancel,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.runs == nil {
+		s.runs = make(map[types.NamespacedName]*smokeTestRun)
+	}
+	s.runs[key] = run
+
+	go func() {
+		err := fn(ctx)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		run.done = true
+		run.err = err
+	}()
+
+	return *run
+}
+
+// get returns a copy of the cluster's run, if any.
+func (s *smokeTestRuns) get(key types.NamespacedName) (smokeTestRun, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, ok := s.runs[key]
+	if !ok {
+		return smokeTestRun{}, false
+	}
+	return *run, true
+}
+
+// forget cancels and removes the cluster's run, if any.
+func (s *smokeTestRuns) forget(key types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if run, ok := s.runs[key]; ok {
+		run.cancel()
+		delete(s.runs, key)
+	}
+}
+
+// reconcileSmokeTest runs the smoke test once per cluster version, if enabled.
+// The smoke test runs in the background: its progress and result are reported in the cluster status.
+// It returns true if the cluster passed the smoke test for its desired version,
+// and the duration after which the smoke test should be checked again.
+func (r *TemporalClusterReconciler) reconcileSmokeTest(ctx context.Context, cluster *v1beta1.TemporalCluster) (bool, time.Duration) {
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+
+	if !cluster.Spec.SmokeTest.IsEnabled() {
+		r.smokeTests.forget(key)
+		cluster.Status.SmokeTest = nil
+		return true, 0
+	}
+
+	desiredVersion := cluster.Spec.Version.String()
+	if cluster.Status.SmokeTest != nil && cluster.Status.SmokeTest.Version == desiredVersion && cluster.Status.SmokeTest.Succeeded {
+		return true, 0
+	}
+
+	run, found := r.smokeTests.get(key)
+	if found && run.version != desiredVersion {
+		// The desired version changed while the smoke test was running.
+		r.smokeTests.forget(key)
+		found = false
+	}
+
+	if !found {
+		if last := cluster.Status.SmokeTest; last != nil && !last.Running && last.Version == desiredVersion {
+			if retryAfter := time.Until(last.LastRunTime.Add(smokeTestRetryInterval)); retryAfter > 0 {
+				return false, retryAfter
+			}
+		}
+
+		// The smoke test outlives the reconciliation, so it doesn't use the reconciliation context.
+		runCtx := log.IntoContext(context.Background(), log.FromContext(ctx))
+		clusterCopy := cluster.DeepCopy()
+		run = r.smokeTests.start(runCtx, key, desiredVersion, func(ctx context.Context) error {
+			return r.runSmokeTest(ctx, clusterCopy)
+		})
+	}
+
+	if !run.done {
+		cluster.Status.SmokeTest = &v1beta1.SmokeTestStatus{
+			Version:     desiredVersion,
+			Running:     true,
+			LastRunTime: run.startTime,
+			Message:     smokeTestMessage(cluster.Status.SmokeTest, desiredVersion),
+		}
+		return false, smokeTestPollInterval
+	}
+
+	r.smokeTests.forget(key)
+
+	cluster.Status.SmokeTest = &v1beta1.SmokeTestStatus{
+		Version:     desiredVersion,
+		Succeeded:   run.err == nil,
+		LastRunTime: run.startTime,
+	}
+
+	if run.err != nil {
+		log.FromContext(ctx).Error(run.err, "Smoke test failed")
+		cluster.Status.SmokeTest.Message = run.err.Error()
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "SmokeTestFailed", run.err.Error())
+		return false, smokeTestRetryInterval
+	}
+
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "SmokeTestPassed", "Smoke test passed for version %s", desiredVersion)
+	return true, 0
+}
+
+// smokeTestMessage returns the error of the last failed run for the provided version, kept while the smoke test is retried.
+func smokeTestMessage(last *v1beta1.SmokeTestStatus, version string) string {
+	if last == nil || last.Version != version {
+		return ""
+	}
+	return last.Message
+}
+
+func (r *TemporalClusterReconciler) runSmokeTest(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	ctx, cancel := context.WithTimeout(ctx, cluster.Spec.SmokeTest.GetTimeout())
+	defer cancel()
+
+	namespace := cluster.Spec.SmokeTest.GetNamespace()
+
+	client, err := r.ClientManager.Client(ctx, cluster, namespace)
+	if err != nil {
+		return fmt.Errorf("can't create cluster client: %w", err)
+	}
+
+	return temporal.RunSmokeTest(ctx, client, namespace)
+}
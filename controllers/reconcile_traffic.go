@@ -0,0 +1,81 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// trafficReadinessRetryInterval is the interval between two attempts to set the traffic readiness gate of a pod.
+const trafficReadinessRetryInterval = 5 * time.Second
+
+// reconcileTrafficReadiness sets the traffic readiness gate of the frontend pods once their containers
+// have been ready for the configured delay, letting the Service route connections to them.
+func (r *TemporalClusterReconciler) reconcileTrafficReadiness(ctx context.Context, cluster *v1beta1.TemporalCluster) time.Duration {
+	traffic := cluster.Spec.Services.Frontend.Traffic
+	if !traffic.ReadinessGateEnabled() {
+		return 0
+	}
+
+	logger := log.FromContext(ctx)
+
+	pods := &corev1.PodList{}
+	err := r.List(ctx, pods, client.InNamespace(cluster.GetNamespace()), client.MatchingLabels(cluster.SelectorLabels()))
+	if err != nil {
+		logger.Info("Can't list services pods", "error", err.Error())
+		return trafficReadinessRetryInterval
+	}
+
+	now := time.Now()
+	requeueAfter := time.Duration(0)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		due, wait := status.ReadinessGateDue(pod, v1beta1.TrafficReadyConditionType, traffic.ReadinessGateDelay.Duration, now)
+		if !due {
+			requeueAfter = minRequeueAfter(requeueAfter, wait)
+			continue
+		}
+
+		patch := client.StrategicMergeFrom(pod.DeepCopy())
+		pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+			Type:               v1beta1.TrafficReadyConditionType,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(now),
+			Reason:             "ReadinessGateDelayElapsed",
+		})
+		err := r.Status().Patch(ctx, pod, patch)
+		if err != nil {
+			logger.Info("Can't set pod traffic readiness gate", "pod", pod.GetName(), "error", err.Error())
+			requeueAfter = minRequeueAfter(requeueAfter, trafficReadinessRetryInterval)
+			continue
+		}
+
+		logger.Info("Pod traffic readiness gate set", "pod", pod.GetName())
+	}
+
+	return requeueAfter
+}
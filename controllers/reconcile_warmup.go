@@ -0,0 +1,118 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/pkg/status"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"go.temporal.io/server/common/primitives"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// warmupCheckInterval is the interval between two checks of the shards owned by a warming up history pod.
+const warmupCheckInterval = 5 * time.Second
+
+// reconcileWarmup sets the shards acquired readiness gate of the history pods once they own at least
+// half of their even share of the history shards, or once the warmup timeout elapsed since their containers are ready.
+func (r *TemporalClusterReconciler) reconcileWarmup(ctx context.Context, cluster *v1beta1.TemporalCluster) time.Duration {
+	history := cluster.Spec.Services.History
+	if !history.Warmup.IsEnabled() {
+		return 0
+	}
+
+	logger := log.FromContext(ctx)
+
+	pods := &corev1.PodList{}
+	err := r.List(ctx, pods, client.InNamespace(cluster.GetNamespace()), client.MatchingLabels(metadata.LabelsSelector(cluster, string(primitives.HistoryService))))
+	if err != nil {
+		logger.Info("Can't list history pods", "error", err.Error())
+		return warmupCheckInterval
+	}
+
+	replicas := int32(1)
+	if history.Replicas != nil {
+		replicas = *history.Replicas
+	}
+	target := temporal.ShardsWarmupTarget(cluster.Spec.NumHistoryShards, replicas)
+	port := strconv.Itoa(*history.Port)
+
+	now := time.Now()
+	requeueAfter := time.Duration(0)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		due, wait := status.ReadinessGateDue(pod, v1beta1.ShardsAcquiredConditionType, 0, now)
+		if !due {
+			requeueAfter = minRequeueAfter(requeueAfter, wait)
+			continue
+		}
+
+		reason := "ShardsAcquired"
+		if timedOut, _ := status.ReadinessGateDue(pod, v1beta1.ShardsAcquiredConditionType, history.Warmup.GetTimeout(), now); timedOut {
+			reason = "WarmupTimeout"
+		} else {
+			owned, err := r.ownedShardsCount(ctx, cluster, net.JoinHostPort(pod.Status.PodIP, port))
+			if err != nil {
+				logger.V(1).Info("Can't get history pod owned shards", "pod", pod.GetName(), "error", err.Error())
+			}
+			if err != nil || owned < target {
+				requeueAfter = minRequeueAfter(requeueAfter, warmupCheckInterval)
+				continue
+			}
+		}
+
+		patch := client.StrategicMergeFrom(pod.DeepCopy())
+		pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+			Type:               v1beta1.ShardsAcquiredConditionType,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(now),
+			Reason:             reason,
+		})
+		err := r.Status().Patch(ctx, pod, patch)
+		if err != nil {
+			logger.Info("Can't set pod shards acquired readiness gate", "pod", pod.GetName(), "error", err.Error())
+			requeueAfter = minRequeueAfter(requeueAfter, warmupCheckInterval)
+			continue
+		}
+
+		logger.Info("Pod shards acquired readiness gate set", "pod", pod.GetName(), "reason", reason)
+	}
+
+	return requeueAfter
+}
+
+// ownedShardsCount returns the number of shards owned by the history host at the provided address.
+func (r *TemporalClusterReconciler) ownedShardsCount(ctx context.Context, cluster *v1beta1.TemporalCluster, address string) (int32, error) {
+	history, conn, err := temporal.GetHistoryHostClient(ctx, r.Client, cluster, address, r.ClientManager.HostDialOptions()...)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	return temporal.OwnedShardsCount(ctx, history)
+}
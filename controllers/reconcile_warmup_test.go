@@ -0,0 +1,130 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporalclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileWarmupShardsQueryFailure(t *testing.T) {
+	tests := map[string]struct {
+		containersReadyFor   time.Duration
+		expectedGate         bool
+		expectedReason       string
+		expectedRequeueAfter time.Duration
+	}{
+		"gate stays closed before the timeout": {
+			containersReadyFor:   30 * time.Second,
+			expectedRequeueAfter: warmupCheckInterval,
+		},
+		"gate opened once the timeout elapsed": {
+			containersReadyFor: 3 * time.Minute,
+			expectedGate:       true,
+			expectedReason:     "WarmupTimeout",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			// The internode certificate secret doesn't exist: the history host owned shards can't be queried.
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake", Namespace: "default"},
+				Spec: v1beta1.TemporalClusterSpec{
+					NumHistoryShards: 512,
+					MTLS: &v1beta1.MTLSSpec{
+						Provider:  v1beta1.CertManagerMTLSProvider,
+						Internode: &v1beta1.InternodeMTLSSpec{Enabled: true},
+					},
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							Port:     ptr.To(7234),
+							Replicas: ptr.To[int32](2),
+							Warmup: &v1beta1.WarmupSpec{
+								Enabled: true,
+								Timeout: &metav1.Duration{Duration: 2 * time.Minute},
+							},
+						},
+					},
+				},
+			}
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-history-0",
+					Namespace: "default",
+					Labels:    metadata.LabelsSelector(cluster, "history"),
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{ConditionType: v1beta1.ShardsAcquiredConditionType},
+					},
+				},
+				Status: corev1.PodStatus{
+					PodIP: "10.0.0.1",
+					Conditions: []corev1.PodCondition{
+						{
+							Type:               corev1.ContainersReady,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metav1.NewTime(time.Now().Add(-test.containersReadyFor)),
+						},
+					},
+				},
+			}
+
+			base := newFakeBase(tt, pod)
+			r := &TemporalClusterReconciler{
+				Base:          base,
+				ClientManager: temporalclient.NewManager(base.Client, &temporalclient.CallOptions{}),
+			}
+
+			requeueAfter := r.reconcileWarmup(context.Background(), cluster)
+			assert.Equal(tt, test.expectedRequeueAfter, requeueAfter)
+
+			result := &corev1.Pod{}
+			require.NoError(tt, r.Get(context.Background(), client.ObjectKeyFromObject(pod), result))
+
+			var gate *corev1.PodCondition
+			for i, condition := range result.Status.Conditions {
+				if condition.Type == v1beta1.ShardsAcquiredConditionType {
+					gate = &result.Status.Conditions[i]
+				}
+			}
+
+			if !test.expectedGate {
+				assert.Nil(tt, gate)
+				return
+			}
+
+			require.NotNil(tt, gate)
+			assert.Equal(tt, corev1.ConditionTrue, gate.Status)
+			assert.Equal(tt, test.expectedReason, gate.Reason)
+		})
+	}
+}
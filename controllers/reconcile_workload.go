@@ -0,0 +1,90 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileWorkload takes a snapshot of the monitored task queues workload and reports it in status.workload
+// and, if a maximum backlog is set, in the Overloaded condition.
+// It returns the duration after which the next snapshot should be taken.
+func (r *TemporalClusterReconciler) reconcileWorkload(ctx context.Context, cluster *v1beta1.TemporalCluster) time.Duration {
+	spec := cluster.Spec.WorkloadMonitoring
+	if !spec.IsEnabled() {
+		cluster.Status.Workload = nil
+		apimeta.RemoveStatusCondition(&cluster.Status.Conditions, v1beta1.OverloadedCondition)
+		return 0
+	}
+
+	interval := spec.GetInterval()
+
+	if !cluster.IsReady() {
+		return interval
+	}
+
+	// Avoid querying the cluster on every reconciliation.
+	if cluster.Status.Workload != nil && time.Since(cluster.Status.Workload.LastCheckTime.Time) < interval {
+		return interval - time.Since(cluster.Status.Workload.LastCheckTime.Time)
+	}
+
+	client, err := r.ClientManager.Client(ctx, cluster, "")
+	if err != nil {
+		log.FromContext(ctx).Info("Can't get workload", "error", err.Error())
+		if spec.MaxBacklog != nil {
+			v1beta1.SetTemporalClusterOverloaded(cluster, metav1.ConditionUnknown, v1beta1.WorkloadUnknownReason, err.Error())
+		}
+		return interval
+	}
+
+	taskQueues := temporal.GetTaskQueuesWorkload(ctx, client.WorkflowService(), spec.TaskQueues)
+
+	cluster.Status.Workload = &v1beta1.WorkloadStatus{
+		TaskQueues:    taskQueues,
+		LastCheckTime: metav1.Now(),
+	}
+
+	if spec.MaxBacklog == nil {
+		apimeta.RemoveStatusCondition(&cluster.Status.Conditions, v1beta1.OverloadedCondition)
+		return interval
+	}
+
+	messages := []string{}
+	for _, taskQueue := range taskQueues {
+		if taskQueue.BacklogCountHint > *spec.MaxBacklog {
+			messages = append(messages, fmt.Sprintf("%s/%s (%s): backlog %d exceeds %d", taskQueue.Namespace, taskQueue.Name, taskQueue.Type, taskQueue.BacklogCountHint, *spec.MaxBacklog))
+		}
+	}
+
+	if len(messages) > 0 {
+		v1beta1.SetTemporalClusterOverloaded(cluster, metav1.ConditionTrue, v1beta1.TaskQueueBacklogExceededReason, strings.Join(messages, "; "))
+	} else {
+		v1beta1.SetTemporalClusterOverloaded(cluster, metav1.ConditionFalse, v1beta1.TaskQueueBacklogWithinLimitReason, "")
+	}
+
+	return interval
+}
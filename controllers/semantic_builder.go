@@ -0,0 +1,97 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
+	"github.com/alexandrevilain/temporal-operator/pkg/kubernetes"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// semanticBuilder wraps a resource builder so that live objects are only updated when
+// their desired state semantically changed, ignoring the fields defaulted by the API server.
+// It prevents no-op reconciliations from sending updates and rolling pods out.
+type semanticBuilder struct {
+	resource.Builder
+	cluster *v1beta1.TemporalCluster
+	// specChanged is true if the cluster spec changed since the last reconciliation.
+	specChanged bool
+	// gate holds back the rollouts initiated by the operator.
+	gate *rolloutGate
+	// sequencer holds back the rollouts conflicting with the services rollout order.
+	sequencer *rolloutSequencer
+}
+
+func withSemanticEquality(cluster *v1beta1.TemporalCluster, specChanged bool, gate *rolloutGate, sequencer *rolloutSequencer, builders []resource.Builder) []resource.Builder {
+	result := make([]resource.Builder, 0, len(builders))
+	for _, builder := range sequencer.order(builders) {
+		result = append(result, &semanticBuilder{
+			Builder:     builder,
+			cluster:     cluster,
+			specChanged: specChanged,
+			gate:        gate,
+			sequencer:   sequencer,
+		})
+	}
+	return result
+}
+
+func (b *semanticBuilder) Update(object client.Object) error {
+	// Objects being created have nothing to compare with.
+	if object.GetResourceVersion() == "" {
+		return b.Builder.Update(object)
+	}
+
+	live := object.DeepCopyObject()
+
+	err := b.Builder.Update(object)
+	if err != nil {
+		return err
+	}
+
+	if kubernetes.SemanticallyEqual(object, live) {
+		// Restore the live object so that it's not updated.
+		reflect.ValueOf(object).Elem().Set(reflect.ValueOf(live).Elem())
+		return nil
+	}
+
+	deployment, ok := object.(*appsv1.Deployment)
+	if ok && kubernetes.PodTemplateChanged(deployment, live.(*appsv1.Deployment)) {
+		if !b.sequencer.allow(deployment.GetName()) || (!b.specChanged && !b.gate.allow(deployment.GetName())) {
+			// Keep the live pods until the other services complete their rollout and the cluster gets a fleet rollout slot.
+			deployment.Spec.Template = live.(*appsv1.Deployment).Spec.Template
+			if kubernetes.SemanticallyEqual(object, live) {
+				reflect.ValueOf(object).Elem().Set(reflect.ValueOf(live).Elem())
+			}
+			return nil
+		}
+
+		cause := metrics.OperatorRolloutCause
+		if b.specChanged {
+			cause = metrics.SpecRolloutCause
+		}
+		metrics.ObserveDeploymentRollout(b.cluster.GetNamespace(), b.cluster.GetName(), deployment.GetName(), cause)
+	}
+
+	return nil
+}
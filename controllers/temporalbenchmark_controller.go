@@ -0,0 +1,214 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/patch"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/logging"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/benchmark"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporalclient"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const benchmarkSummaryTimeout = 30 * time.Second
+
+// TemporalBenchmarkReconciler reconciles a TemporalBenchmark object.
+type TemporalBenchmarkReconciler struct {
+	Base
+
+	ClientManager *temporalclient.Manager
+}
+
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalbenchmarks,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalbenchmarks/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalbenchmarks/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *TemporalBenchmarkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	bench := &v1beta1.TemporalBenchmark{}
+	err := r.Get(ctx, req.NamespacedName, bench)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	ctx, logger := logging.WithCluster(ctx, bench.Spec.ClusterRef.Name, bench)
+
+	logger.Info("Starting reconciliation")
+
+	// Check if the resource has been marked for deletion
+	if !bench.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	// Benchmarks run only once.
+	if bench.IsFinished() {
+		return reconcile.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(bench, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the TemporalBenchmark object and status after each reconciliation.
+		err := patchHelper.Patch(ctx, bench)
+		if err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	if bench.Status.Phase == "" {
+		bench.Status.Phase = v1beta1.BenchmarkPending
+	}
+
+	if bench.Status.RunID == "" {
+		// Use a unique run id so that benchmarks re-created with the same name don't collide in temporal.
+		bench.Status.RunID = fmt.Sprintf("%s-%s", bench.GetName(), string(bench.GetUID())[:8])
+	}
+
+	cluster, err := temporal.GetEffectiveCluster(ctx, r.Client, bench.Spec.ClusterRef.NamespacedName(bench))
+	if err != nil {
+		bench.Status.Message = fmt.Sprintf("Can't get referenced cluster: %s", err)
+		return reconcile.Result{}, err
+	}
+
+	job := &batchv1.Job{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: bench.GetNamespace(), Name: benchmark.JobName(bench)}, job)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		return r.startBenchmark(ctx, bench, cluster)
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type { //nolint:exhaustive
+		case batchv1.JobComplete:
+			r.finishBenchmark(ctx, bench, cluster, v1beta1.BenchmarkSucceeded, condition.LastTransitionTime, "Benchmark completed")
+			return reconcile.Result{}, nil
+		case batchv1.JobFailed:
+			r.finishBenchmark(ctx, bench, cluster, v1beta1.BenchmarkFailed, condition.LastTransitionTime, fmt.Sprintf("Benchmark job failed: %s", condition.Message))
+			return reconcile.Result{}, nil
+		}
+	}
+
+	bench.Status.Phase = v1beta1.BenchmarkRunning
+	bench.Status.Message = "Benchmark is running"
+
+	return reconcile.Result{}, nil
+}
+
+// startBenchmark creates the benchmark job once the referenced cluster is ready.
+func (r *TemporalBenchmarkReconciler) startBenchmark(ctx context.Context, bench *v1beta1.TemporalBenchmark, cluster *v1beta1.TemporalCluster) (ctrl.Result, error) {
+	if !cluster.IsReady() {
+		log.FromContext(ctx).Info("Skipping benchmark until referenced cluster is ready")
+		bench.Status.Message = "Waiting for the referenced cluster to be ready"
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	if cluster.Spec.MTLS != nil && cluster.Spec.MTLS.FrontendEnabled() {
+		bench.Status.Phase = v1beta1.BenchmarkFailed
+		bench.Status.Message = "Benchmarks against clusters with frontend mTLS enabled are not supported"
+		r.Recorder.Event(bench, corev1.EventTypeWarning, "BenchmarkFailed", bench.Status.Message)
+		return reconcile.Result{}, nil
+	}
+
+	builder := benchmark.NewJobBuilder(bench, cluster, r.Scheme)
+	job := builder.Build()
+	if err := builder.Update(job); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.Create(ctx, job); err != nil {
+		return reconcile.Result{}, fmt.Errorf("can't create benchmark job: %w", err)
+	}
+
+	now := metav1.Now()
+	bench.Status.Phase = v1beta1.BenchmarkRunning
+	bench.Status.JobName = job.GetName()
+	bench.Status.StartTime = &now
+	bench.Status.Message = "Benchmark is running"
+
+	r.Recorder.Eventf(bench, corev1.EventTypeNormal, "BenchmarkStarted", "Started scenario %s against cluster %s", bench.Spec.GetScenario(), cluster.GetName())
+
+	return reconcile.Result{}, nil
+}
+
+// finishBenchmark records the benchmark outcome and collects its summary.
+func (r *TemporalBenchmarkReconciler) finishBenchmark(ctx context.Context, bench *v1beta1.TemporalBenchmark, cluster *v1beta1.TemporalCluster, phase v1beta1.TemporalBenchmarkPhase, completionTime metav1.Time, message string) {
+	bench.Status.Phase = phase
+	bench.Status.CompletionTime = &completionTime
+	bench.Status.Message = message
+
+	var duration time.Duration
+	if bench.Status.StartTime != nil {
+		duration = completionTime.Sub(bench.Status.StartTime.Time)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, benchmarkSummaryTimeout)
+	defer cancel()
+
+	namespace := bench.Spec.GetNamespace()
+
+	client, err := r.ClientManager.Client(ctx, cluster, namespace)
+	if err != nil {
+		bench.Status.Summary = &v1beta1.TemporalBenchmarkSummary{
+			Duration: metav1.Duration{Duration: duration},
+			Message:  fmt.Sprintf("can't create cluster client: %s", err),
+		}
+	} else {
+		bench.Status.Summary = temporal.GetBenchmarkSummary(ctx, client, namespace, bench.Status.RunID, duration)
+	}
+
+	eventType := corev1.EventTypeNormal
+	if phase == v1beta1.BenchmarkFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	r.Recorder.Event(bench, eventType, "Benchmark"+string(phase), message)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemporalBenchmarkReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.TemporalBenchmark{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
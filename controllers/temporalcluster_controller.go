@@ -29,13 +29,19 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/discovery"
+	"github.com/alexandrevilain/temporal-operator/internal/logging"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	istionetworkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
@@ -44,19 +50,30 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/workqueue"
 
 	"github.com/alexandrevilain/controller-tools/pkg/hash"
 	"github.com/alexandrevilain/controller-tools/pkg/patch"
 	"github.com/alexandrevilain/controller-tools/pkg/resource"
-	"github.com/alexandrevilain/temporal-operator/internal/resource/admintools"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/backup"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/base"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/config"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/frontendproxy"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/grpcweb"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/istio"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/prometheus"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/ui"
+	"github.com/alexandrevilain/temporal-operator/pkg/circuitbreaker"
+	"github.com/alexandrevilain/temporal-operator/pkg/notification"
+	"github.com/alexandrevilain/temporal-operator/pkg/priorityqueue"
+	"github.com/alexandrevilain/temporal-operator/pkg/ratelimit"
 	"github.com/alexandrevilain/temporal-operator/pkg/status"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporalclient"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
 )
 
 const (
@@ -68,43 +85,79 @@ type TemporalClusterReconciler struct {
 	Base
 
 	AvailableAPIs *discovery.AvailableAPIs
+	ClientManager *temporalclient.Manager
+	Notifier      *notification.Sink
+	// Clientset is used to read the schema jobs logs.
+	Clientset kubernetes.Interface
+	// DatastoreBackoff configures the backoff and circuit breaking of failing persistence reconciliations.
+	DatastoreBackoff circuitbreaker.Config
+	// FleetRollouts caps the number of clusters rolling out their pods after operator initiated changes.
+	FleetRollouts *ratelimit.Semaphore
+	// APIReader reads the objects the manager cache doesn't hold, like the external config ConfigMaps provided by users.
+	APIReader client.Reader
+	// MetadataCache watches the metadata of the objects the manager cache doesn't hold.
+	// If nil, external config ConfigMaps changes don't trigger reconciliations.
+	MetadataCache crcache.Cache
+
+	smokeTests smokeTestRuns
 }
 
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="",resources=events,verbs=get;create;patch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods/status,verbs=patch
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+//+kubebuilder:rbac:groups="",resources=pods/ephemeralcontainers,verbs=update
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="networking.k8s.io",resources=ingresses,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="route.openshift.io",resources=routes,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="cert-manager.io",resources=certificates;issuers,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="security.istio.io",resources=peerauthentications,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="networking.istio.io",resources=destinationrules,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="metrics.k8s.io",resources=pods,verbs=list
 //+kubebuilder:rbac:groups="monitoring.coreos.com",resources=servicemonitors,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="monitoring.coreos.com",resources=prometheusrules,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalclusters,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalclusters/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalclusters/finalizers,verbs=update
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalaccesspolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalclusteroverrides,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *TemporalClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
-	logger := log.FromContext(ctx)
-
-	logger.Info("Starting reconciliation")
-
 	cluster := &v1beta1.TemporalCluster{}
 	err := r.Get(ctx, req.NamespacedName, cluster)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			r.ClientManager.Forget(req.NamespacedName)
+			r.smokeTests.forget(req.NamespacedName)
+			r.FleetRollouts.Release(req.NamespacedName.String())
+			metrics.ForgetCluster(req.Namespace, req.Name)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
 
+	ctx, logger := logging.WithCluster(ctx, cluster.Name, cluster)
+
+	logger.Info("Starting reconciliation")
+
 	// Check if the resource has been marked for deletion
 	if !cluster.ObjectMeta.DeletionTimestamp.IsZero() {
 		logger.Info("Deleting temporal cluster", "name", cluster.Name)
+		r.ClientManager.Forget(req.NamespacedName)
+		r.smokeTests.forget(req.NamespacedName)
+		r.FleetRollouts.Release(req.NamespacedName.String())
+		metrics.ForgetCluster(req.Namespace, req.Name)
 		return reconcile.Result{}, nil
 	}
 
@@ -121,18 +174,101 @@ func (r *TemporalClusterReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}()
 
+	// Overrides only apply to the in-memory spec: restore the stored spec before patching the cluster.
+	storedSpec := cluster.Spec.DeepCopy()
+	defer func() {
+		cluster.Spec = *storedSpec
+	}()
+
+	start := time.Now()
+	defer func() {
+		// Record the reconciliation outcome before patching the status.
+		now := metav1.Now()
+		cluster.Status.LastReconcileTime = &now
+		cluster.Status.LastReconcileError = ""
+		if reterr != nil {
+			cluster.Status.LastReconcileError = reterr.Error()
+		}
+		metrics.ObserveClusterReconcile(cluster.Namespace, cluster.Name, time.Since(start), reterr)
+	}()
+
+	// Lifecycle notifications are sent once the reconciliation outcome is known, before patching the status.
+	observedVersion := cluster.Status.Version
+	defer r.reconcileNotifications(ctx, cluster, observedVersion)
+
+	cluster.Status.SupportedVersionRange = version.Compatibility.SupportedVersionRange()
+
+	if err := r.applyClusterOverrides(ctx, cluster); err != nil {
+		logger.Error(err, "Can't apply cluster overrides")
+		return r.handleErrorWithRequeue(cluster, v1beta1.ClusterOverridesFailedReason, err, time.Minute)
+	}
+
 	// Check the ready condition
 	cond, exists := v1beta1.GetTemporalClusterReadyCondition(cluster)
-	if !exists || cond.ObservedGeneration != cluster.GetGeneration() {
+	specChanged := !exists || cond.ObservedGeneration != cluster.GetGeneration()
+	if specChanged {
 		v1beta1.SetTemporalClusterReady(cluster, metav1.ConditionUnknown, v1beta1.ProgressingReason, "")
+		// Log the auto-tuned values once per spec change.
+		r.logAutoTuneRecommendations(ctx, cluster)
+	}
+
+	r.reconcileSizing(cluster)
+
+	if diffRequested(cluster) {
+		logger.Info("Diff requested, reporting changes without applying them")
+		if err := r.reconcileResourcesDiff(ctx, cluster); err != nil {
+			logger.Error(err, "Can't compute resources diff")
+			return r.handleErrorWithRequeue(cluster, v1beta1.ResourcesReconciliationFailedReason, err, 2*time.Second)
+		}
+		return r.handleSuccessWithRequeue(cluster, diffRefreshInterval)
+	}
+
+	if err := r.deleteDiffReport(ctx, cluster); err != nil {
+		logger.Error(err, "Can't delete resources diff report")
+	}
+
+	if err := r.reconcileActions(ctx, cluster); err != nil {
+		logger.Error(err, "Can't run requested actions")
+		return r.handleErrorWithRequeue(cluster, v1beta1.ActionFailedReason, err, 10*time.Second)
+	}
+
+	if err := r.reconcileSecretDecryption(ctx, cluster); err != nil {
+		logger.Error(err, "Can't decrypt persistence secrets")
+		return r.handleErrorWithRequeue(cluster, v1beta1.PersistenceReconciliationFailedReason, err, 2*time.Second)
+	}
+
+	if err := r.reconcileImageDigests(ctx, cluster); err != nil {
+		logger.Error(err, "Can't resolve images digests")
+		return r.handleErrorWithRequeue(cluster, v1beta1.ImageDigestsResolutionFailedReason, err, 10*time.Second)
+	}
+
+	if err := r.reconcileImageVerification(ctx, cluster); err != nil {
+		logger.Error(err, "Can't verify images signatures")
+		return r.handleErrorWithRequeue(cluster, v1beta1.ImageVerificationFailedReason, err, time.Minute)
+	}
+
+	if retryIn := r.datastoreRetryIn(cluster, specChanged); retryIn > 0 {
+		logger.Info("Datastore circuit breaker is open, skipping persistence reconciliation", "retryIn", retryIn)
+		return reconcile.Result{RequeueAfter: retryIn}, nil
+	}
+
+	if err := r.reconcilePersistenceChanges(cluster); err != nil {
+		logger.Error(err, "Can't reconcile persistence changes")
+		return r.handleErrorWithRequeue(cluster, v1beta1.PersistenceChangeBlockedReason, err, time.Minute)
+	}
+
+	if requeueAfter, err := r.reconcileDatastoreMigration(ctx, cluster); err != nil || requeueAfter > 0 {
+		if err != nil {
+			logger.Error(err, "Can't migrate datastores")
+			return r.handleErrorWithRequeue(cluster, v1beta1.DatastoreMigrationBlockedReason, err, time.Minute)
+		}
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
 	}
 
 	if requeueAfter, err := r.reconcilePersistence(ctx, cluster); err != nil || requeueAfter > 0 {
 		if err != nil {
 			logger.Error(err, "Can't reconcile persistence")
-			if requeueAfter == 0 {
-				requeueAfter = 2 * time.Second
-			}
+			requeueAfter = max(requeueAfter, r.recordDatastoreFailure(ctx, cluster, err))
 			return r.handleErrorWithRequeue(cluster, v1beta1.PersistenceReconciliationFailedReason, err, requeueAfter)
 		}
 		if requeueAfter > 0 {
@@ -140,68 +276,247 @@ func (r *TemporalClusterReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}
 
-	if err := r.reconcileResources(ctx, cluster); err != nil {
+	r.recordDatastoreSuccess(cluster)
+
+	// Refresh the cluster metadata before rolling pods for a spec change.
+	clusterInfoRequeueAfter := r.reconcileClusterInfo(ctx, cluster, specChanged)
+	if !checkClusterMetadata(cluster) {
+		logger.Info("Cluster metadata doesn't match the spec, skipping resources reconciliation")
+		return reconcile.Result{RequeueAfter: clusterInfoRequeueAfter}, nil
+	}
+
+	resourcesRequeueAfter, err := r.reconcileResources(ctx, cluster, specChanged)
+	if err != nil {
 		logger.Error(err, "Can't reconcile resources")
 		return r.handleErrorWithRequeue(cluster, v1beta1.ResourcesReconciliationFailedReason, err, 2*time.Second)
 	}
 
-	return r.handleSuccess(cluster)
+	r.reconcileCrashDumps(ctx, cluster)
+
+	requeueAfter := minRequeueAfter(resourcesRequeueAfter, r.reconcileElasticsearchHealth(ctx, cluster))
+	requeueAfter = minRequeueAfter(requeueAfter, r.reconcileElasticsearchMappings(ctx, cluster))
+	requeueAfter = minRequeueAfter(requeueAfter, r.reconcileReplicationHealth(ctx, cluster))
+	requeueAfter = minRequeueAfter(requeueAfter, r.reconcileWorkload(ctx, cluster))
+	requeueAfter = minRequeueAfter(requeueAfter, r.reconcileCanary(ctx, cluster))
+	requeueAfter = minRequeueAfter(requeueAfter, r.reconcileServiceDegraded(ctx, cluster))
+	requeueAfter = minRequeueAfter(requeueAfter, r.reconcileTrafficReadiness(ctx, cluster))
+	requeueAfter = minRequeueAfter(requeueAfter, r.reconcileWarmup(ctx, cluster))
+	requeueAfter = minRequeueAfter(requeueAfter, r.reconcileRecommendations(ctx, cluster))
+	requeueAfter = minRequeueAfter(requeueAfter, clusterInfoRequeueAfter)
+
+	return r.handleSuccessWithRequeue(cluster, requeueAfter)
 }
 
-func (r *TemporalClusterReconciler) reconcileResources(ctx context.Context, temporalCluster *v1beta1.TemporalCluster) error {
+func (r *TemporalClusterReconciler) reconcileResources(ctx context.Context, temporalCluster *v1beta1.TemporalCluster, specChanged bool) (time.Duration, error) {
+	r.startBlueGreenUpgrade(temporalCluster)
+
+	clientRules, err := r.clusterClientsClaimRules(ctx, temporalCluster)
+	if err != nil {
+		return 0, err
+	}
+
+	policyRules, err := r.accessPoliciesClaimRules(ctx, temporalCluster)
+	if err != nil {
+		return 0, err
+	}
+	clientRules = append(clientRules, policyRules...)
+
+	if err := r.reconcileReplicationClients(ctx, temporalCluster); err != nil {
+		return 0, err
+	}
+
+	remoteClusters, err := r.remoteClusterConnections(ctx, temporalCluster)
+	if err != nil {
+		return 0, err
+	}
+
 	// reconcile configmap first, then compute its hash.
 	configMapObject, err := r.Reconciler.ReconcileBuilder(ctx,
 		temporalCluster,
-		config.NewConfigmapBuilder(temporalCluster, r.Scheme))
+		config.NewConfigmapBuilder(temporalCluster, r.Scheme, clientRules, remoteClusters))
 	if err != nil {
-		return fmt.Errorf("can't reconcile configmap: %w", err)
+		return 0, fmt.Errorf("can't reconcile configmap: %w", err)
 	}
 
 	configMap, ok := configMapObject.(*corev1.ConfigMap)
 	if !ok {
-		return errors.New("can't cast configmap object to *corev1.ConfigMap")
+		return 0, errors.New("can't cast configmap object to *corev1.ConfigMap")
 	}
 
-	configHash, err := hash.Sha256(configMap.Data)
+	configHash, err := r.configHash(ctx, temporalCluster, configMap)
+	if err != nil {
+		return 0, err
+	}
+
+	namespaces, err := r.listClusterNamespaces(ctx, temporalCluster)
+	if err != nil {
+		return 0, fmt.Errorf("can't list cluster namespaces: %w", err)
+	}
+
+	builders, err := r.resourceBuilders(temporalCluster, configHash, namespaces, remoteClusters)
 	if err != nil {
-		return fmt.Errorf("can't compute configmap hash: %w", err)
+		return 0, err
 	}
 
-	builders, err := r.resourceBuilders(temporalCluster, configHash)
+	gate := newRolloutGate(r.FleetRollouts, temporalCluster)
+
+	sequencer, err := r.newRolloutSequencer(ctx, temporalCluster)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	objects, err := r.Reconciler.ReconcileBuilders(ctx, temporalCluster, builders)
+	objects, err := r.Reconciler.ReconcileBuilders(ctx, temporalCluster, withSemanticEquality(temporalCluster, specChanged, gate, sequencer, withCatalogMetadata(temporalCluster, builders)))
 	if err != nil {
-		return err
+		return 0, err
 	}
 
+	inventory, err := inventoryFromObjects(r.Scheme, append([]client.Object{configMap}, objects...))
+	if err != nil {
+		return 0, fmt.Errorf("can't compute resources inventory: %w", err)
+	}
+
+	if err := r.pruneOrphanedResources(ctx, temporalCluster, inventory); err != nil {
+		return 0, err
+	}
+
+	resources, err := status.ManagedResources(r.Scheme, append([]client.Object{configMap}, objects...))
+	if err != nil {
+		return 0, fmt.Errorf("can't compute managed resources status: %w", err)
+	}
+	temporalCluster.Status.Resources = resources
+	temporalCluster.Status.Catalog = catalogStatus(temporalCluster, namespaces)
+
 	statuses, err := status.ReconciledObjectsToServiceStatuses(temporalCluster, objects)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	for _, status := range statuses {
 		temporalCluster.Status.AddServiceStatus(status)
 	}
 
+	if err := r.reconcileDynamicConfigStatus(temporalCluster, namespaces); err != nil {
+		return 0, err
+	}
+
+	r.reconcileComponents(ctx, temporalCluster, configHash, specChanged, gate)
+
+	wasReady := temporalCluster.IsReady()
+	// servingVersion is true if the cluster was already ready with its desired version before this reconciliation.
+	servingVersion := wasReady && temporalCluster.Status.Version == temporalCluster.Spec.Version.String()
+
 	if status.ObservedVersionMatchesDesiredVersion(temporalCluster) {
 		temporalCluster.Status.Version = temporalCluster.Spec.Version.String()
 	}
 
-	if status.IsClusterReady(temporalCluster) {
-		v1beta1.SetTemporalClusterReady(temporalCluster, metav1.ConditionTrue, v1beta1.ServicesReadyReason, "")
-	} else {
+	var requeueAfter time.Duration
+
+	servicesReady := status.IsClusterReady(temporalCluster)
+
+	smokeTestPassed := true
+	if servicesReady {
+		smokeTestPassed, requeueAfter = r.reconcileSmokeTest(ctx, temporalCluster)
+	}
+
+	switch {
+	case !servicesReady:
 		v1beta1.SetTemporalClusterReady(temporalCluster, metav1.ConditionFalse, v1beta1.ServicesNotReadyReason, "")
+	case !smokeTestPassed && !servingVersion:
+		// The smoke test only gates the readiness of new versions: a failing smoke test doesn't
+		// mark a cluster already serving its version as not ready, it's reported in the status.
+		v1beta1.SetTemporalClusterReady(temporalCluster, metav1.ConditionFalse, v1beta1.SmokeTestNotPassedReason, temporalCluster.Status.SmokeTest.Message)
+	default:
+		v1beta1.SetTemporalClusterReady(temporalCluster, metav1.ConditionTrue, v1beta1.ServicesReadyReason, "")
+	}
+
+	r.reconcileRolloutNotifications(ctx, temporalCluster, wasReady, servicesReady)
+	reconcileDrainCondition(temporalCluster)
+	requeueAfter = minRequeueAfter(requeueAfter, r.reconcileFleetRollout(ctx, temporalCluster, gate, servicesReady))
+	requeueAfter = minRequeueAfter(requeueAfter, r.reconcileRolloutOrder(ctx, temporalCluster, sequencer))
+
+	blueGreenRequeueAfter, err := r.progressBlueGreenUpgrade(temporalCluster, objects)
+	if err != nil {
+		return 0, err
+	}
+
+	requeueAfter = minRequeueAfter(requeueAfter, blueGreenRequeueAfter)
+
+	return minRequeueAfter(requeueAfter, base.ScaleDownRequeueAfter(objects)), nil
+}
+
+// configHash returns the hash of the cluster configuration, used to restart services on configuration changes.
+func (r *TemporalClusterReconciler) configHash(ctx context.Context, cluster *v1beta1.TemporalCluster, configMap *corev1.ConfigMap) (string, error) {
+	configHash, err := hash.Sha256(configMap.Data)
+	if err != nil {
+		return "", fmt.Errorf("can't compute configmap hash: %w", err)
+	}
+
+	esSecretsHash, err := r.elasticsearchSecretsHash(ctx, cluster)
+	if err != nil {
+		return "", fmt.Errorf("can't compute elasticsearch secrets hash: %w", err)
+	}
+
+	decryptedSecretsHash, err := r.decryptedSecretsHash(ctx, cluster)
+	if err != nil {
+		return "", fmt.Errorf("can't compute decrypted secrets hash: %w", err)
+	}
+
+	externalConfigHash, err := r.externalConfigHash(ctx, cluster)
+	if err != nil {
+		return "", err
+	}
+
+	if esSecretsHash != "" || decryptedSecretsHash != "" || externalConfigHash != "" {
+		hashes := map[string]string{
+			"config": configHash,
+		}
+		if esSecretsHash != "" {
+			hashes["elasticsearch"] = esSecretsHash
+		}
+		// Only added when set to keep the hash of existing clusters stable.
+		if decryptedSecretsHash != "" {
+			hashes["decrypted"] = decryptedSecretsHash
+		}
+		if externalConfigHash != "" {
+			hashes["external"] = externalConfigHash
+		}
+
+		configHash, err = hash.Sha256(hashes)
+		if err != nil {
+			return "", fmt.Errorf("can't compute configmap hash: %w", err)
+		}
+	}
+
+	return configHash, nil
+}
+
+// listClusterNamespaces returns the TemporalNamespaces referencing the provided cluster.
+func (r *TemporalClusterReconciler) listClusterNamespaces(ctx context.Context, cluster *v1beta1.TemporalCluster) ([]v1beta1.TemporalNamespace, error) {
+	list := &v1beta1.TemporalNamespaceList{}
+	err := r.List(ctx, list, client.MatchingFields{clusterRefField: cluster.GetName()})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []v1beta1.TemporalNamespace{}
+	for _, namespace := range list.Items {
+		namespace := namespace
+		// As we're only indexing on spec.clusterRef.Name, ensure that referenced namespace is watching the cluster's namespace.
+		if namespace.Spec.ClusterRef.NamespacedName(&namespace) != client.ObjectKeyFromObject(cluster) {
+			continue
+		}
+		result = append(result, namespace)
 	}
 
-	return nil
+	return result, nil
 }
 
-func (r *TemporalClusterReconciler) resourceBuilders(temporalCluster *v1beta1.TemporalCluster, configHash string) ([]resource.Builder, error) {
+func (r *TemporalClusterReconciler) resourceBuilders(temporalCluster *v1beta1.TemporalCluster, configHash string, namespaces []v1beta1.TemporalNamespace, remoteClusters []v1beta1.RemoteClusterConnection) ([]resource.Builder, error) {
 	builders := []resource.Builder{
-		base.NewFrontendServiceBuilder(temporalCluster, r.Scheme),
+		base.NewFrontendServiceBuilder(temporalCluster, r.Scheme, r.AvailableAPIs.TopologyMode),
+		base.NewFrontendLoadBalancingServiceBuilder(temporalCluster, r.Scheme),
+		base.NewFrontendEndpointsBuilder(temporalCluster, r.Scheme),
+		base.NewFrontendMirrorServiceBuilder(temporalCluster, r.Scheme),
 	}
 
 	services := []primitives.ServiceName{
@@ -212,6 +527,11 @@ func (r *TemporalClusterReconciler) resourceBuilders(temporalCluster *v1beta1.Te
 		primitives.InternalFrontendService,
 	}
 
+	currentCluster, err := blueGreenCurrentCluster(temporalCluster)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, service := range services {
 		specs, err := temporalCluster.Spec.Services.GetServiceSpec(service)
 		if err != nil {
@@ -221,8 +541,10 @@ func (r *TemporalClusterReconciler) resourceBuilders(temporalCluster *v1beta1.Te
 		serviceName := string(service)
 
 		builders = append(builders, base.NewServiceAccountBuilder(serviceName, temporalCluster, r.Scheme))
-		builders = append(builders, base.NewDeploymentBuilder(serviceName, temporalCluster, r.Scheme, specs, configHash))
+		builders = append(builders, base.NewDeploymentBuilder(serviceName, currentCluster, r.Scheme, specs, configHash, r.AvailableAPIs.GRPCProbes, remoteClusters))
+		builders = append(builders, base.NewBlueGreenDeploymentBuilder(serviceName, temporalCluster, r.Scheme, specs, configHash, r.AvailableAPIs.GRPCProbes, remoteClusters))
 		builders = append(builders, base.NewHeadlessServiceBuilder(serviceName, temporalCluster, r.Scheme, specs))
+		builders = append(builders, base.NewPodDisruptionBudgetBuilder(serviceName, temporalCluster, r.Scheme))
 
 		builders = append(builders, istio.NewPeerAuthenticationBuilder(serviceName, temporalCluster, r.Scheme, specs))
 		builders = append(builders, istio.NewDestinationRuleBuilder(serviceName, temporalCluster, r.Scheme, specs))
@@ -230,7 +552,8 @@ func (r *TemporalClusterReconciler) resourceBuilders(temporalCluster *v1beta1.Te
 	}
 
 	builders = append(builders,
-		base.NewDynamicConfigmapBuilder(temporalCluster, r.Scheme),
+		base.NewDynamicConfigmapBuilder(temporalCluster, r.Scheme, namespaces),
+		base.NewArchivalVolumeClaimBuilder(temporalCluster, r.Scheme),
 		// mTLS
 		certmanager.NewMTLSBootstrapIssuerBuilder(temporalCluster, r.Scheme),
 		certmanager.NewMTLSRootCACertificateBuilder(temporalCluster, r.Scheme),
@@ -242,21 +565,28 @@ func (r *TemporalClusterReconciler) resourceBuilders(temporalCluster *v1beta1.Te
 		certmanager.NewMTLSFrontendIntermediateCAIssuerBuilder(temporalCluster, r.Scheme),
 		certmanager.NewMTLSFrontendCertificateBuilder(temporalCluster, r.Scheme),
 		certmanager.NewWorkerFrontendClientCertificateBuilder(temporalCluster, r.Scheme),
-		// UI:
-		ui.NewDeploymentBuilder(temporalCluster, r.Scheme, configHash),
-		ui.NewServiceBuilder(temporalCluster, r.Scheme),
-		ui.NewIngressBuilder(temporalCluster, r.Scheme),
-		ui.NewFrontendClientCertificateBuilder(temporalCluster, r.Scheme),
-		// Admin tools:
-		admintools.NewDeploymentBuilder(temporalCluster, r.Scheme, configHash),
-		admintools.NewFrontendClientCertificateBuilder(temporalCluster, r.Scheme),
+		frontendproxy.NewFrontendClientCertificateBuilder(temporalCluster, r.Scheme),
+		// gRPC-web proxy:
+		grpcweb.NewConfigmapBuilder(temporalCluster, r.Scheme),
+		grpcweb.NewDeploymentBuilder(temporalCluster, r.Scheme),
+		grpcweb.NewServiceBuilder(temporalCluster, r.Scheme),
+		grpcweb.NewFrontendClientCertificateBuilder(temporalCluster, r.Scheme),
+		// Backup:
+		backup.NewConfigmapBuilder(temporalCluster, r.Scheme),
+		backup.NewCronJobBuilder(temporalCluster, r.Scheme),
+		// Alerting:
+		prometheus.NewPrometheusRuleBuilder(temporalCluster, r.Scheme),
 	)
 
 	return builders, nil
 }
 
-func (r *TemporalClusterReconciler) handleSuccess(cluster *v1beta1.TemporalCluster) (ctrl.Result, error) {
-	return r.handleSuccessWithRequeue(cluster, 0)
+// minRequeueAfter returns the shortest non-zero requeue duration.
+func minRequeueAfter(a, b time.Duration) time.Duration {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
 }
 
 func (r *TemporalClusterReconciler) handleSuccessWithRequeue(cluster *v1beta1.TemporalCluster, requeueAfter time.Duration) (ctrl.Result, error) {
@@ -281,21 +611,77 @@ func (r *TemporalClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		}
 	}
 
-	controller := ctrl.NewControllerManagedBy(mgr).
+	clusterController := ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.TemporalCluster{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
 			predicate.LabelChangedPredicate{},
 			predicate.AnnotationChangedPredicate{},
 		))).
+		// Clusters with a higher spec.priority are reconciled first when the operator has a backlog.
+		WithOptions(controller.Options{
+			NewQueue: func(name string, rateLimiter ratelimiter.RateLimiter) workqueue.RateLimitingInterface {
+				return priorityqueue.NewRateLimitingQueue(name, rateLimiter, r.clusterPriority)
+			},
+		}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Service{}).
+		Owns(&corev1.Endpoints{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&networkingv1.Ingress{}).
-		Owns(&batchv1.Job{})
+		Owns(&batchv1.Job{}).
+		Owns(&batchv1.CronJob{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		// Replication clients are issued to connect to remote clusters.
+		Owns(&v1beta1.TemporalClusterClient{}).
+		Watches(
+			&v1beta1.TemporalNamespace{},
+			handler.EnqueueRequestsFromMapFunc(r.namespaceToClusterMapfunc),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		// Clients permissions are rendered in the cluster claim mapper rules.
+		Watches(
+			&v1beta1.TemporalClusterClient{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterClientToClusterMapfunc),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		// Access policies are rendered in the cluster claim mapper rules.
+		Watches(
+			&v1beta1.TemporalAccessPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.accessPolicyToClusterMapfunc),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		// Only watch secrets metadata, secrets aren't cached by the manager.
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.secretToClustersMapfunc),
+			builder.OnlyMetadata,
+		).
+		// Overrides are applied to the selected clusters spec.
+		Watches(
+			&v1beta1.TemporalClusterOverride{},
+			handler.EnqueueRequestsFromMapFunc(r.overrideToClustersMapfunc),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		// Containers restarts are reported in the ServiceDegraded condition.
+		Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.podToClusterMapfunc),
+			builder.WithPredicates(podRestartsChangedPredicate()),
+		)
+
+	// External config ConfigMaps are validated and hashed to roll out services on changes.
+	// They aren't labeled, so the manager cache doesn't hold them: only their metadata is watched.
+	if r.MetadataCache != nil {
+		configMap := &metav1.PartialObjectMetadata{}
+		configMap.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+		clusterController = clusterController.WatchesRawSource(
+			source.Kind[client.Object](r.MetadataCache, configMap, handler.EnqueueRequestsFromMapFunc(r.configMapToClustersMapfunc)),
+		)
+	}
 
 	if r.AvailableAPIs.CertManager {
-		controller = controller.
+		clusterController = clusterController.
 			Owns(&certmanagerv1.Issuer{}).
 			Owns(&certmanagerv1.Certificate{})
 
@@ -307,7 +693,7 @@ func (r *TemporalClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 
 	if r.AvailableAPIs.Istio {
-		controller = controller.
+		clusterController = clusterController.
 			Owns(&istiosecurityv1beta1.PeerAuthentication{}).
 			Owns(&istionetworkingv1beta1.DestinationRule{})
 
@@ -319,16 +705,66 @@ func (r *TemporalClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 
 	if r.AvailableAPIs.PrometheusOperator {
-		controller = controller.Owns(&monitoringv1.ServiceMonitor{})
+		clusterController = clusterController.
+			Owns(&monitoringv1.ServiceMonitor{}).
+			Owns(&monitoringv1.PrometheusRule{})
 
-		for _, resource := range []client.Object{&monitoringv1.ServiceMonitor{}} {
+		for _, resource := range []client.Object{&monitoringv1.ServiceMonitor{}, &monitoringv1.PrometheusRule{}} {
 			if err := mgr.GetFieldIndexer().IndexField(context.Background(), resource, ownerKey, addPromtheusOperatorResourceToIndex); err != nil {
 				return err
 			}
 		}
 	}
 
-	return controller.Complete(r)
+	if r.AvailableAPIs.Routes {
+		clusterController = clusterController.Owns(ui.NewRoute())
+	}
+
+	return clusterController.Complete(r)
+}
+
+// namespaceToClusterMapfunc enqueues the cluster referenced by the provided TemporalNamespace,
+// as namespaces rate limits are rendered in the cluster's dynamic config.
+// clusterPriority returns the priority of the cluster reconcile request, read from the cache.
+// Requests of unknown clusters, like deleted ones, have the default priority.
+func (r *TemporalClusterReconciler) clusterPriority(item any) int32 {
+	req, ok := item.(reconcile.Request)
+	if !ok {
+		return 0
+	}
+
+	cluster := &v1beta1.TemporalCluster{}
+	if err := r.Get(context.Background(), req.NamespacedName, cluster); err != nil {
+		return 0
+	}
+
+	return cluster.Spec.Priority
+}
+
+func (r *TemporalClusterReconciler) namespaceToClusterMapfunc(_ context.Context, o client.Object) []reconcile.Request {
+	namespace, ok := o.(*v1beta1.TemporalNamespace)
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: namespace.Spec.ClusterRef.NamespacedName(namespace),
+		},
+	}
+}
+
+func (r *TemporalClusterReconciler) clusterClientToClusterMapfunc(_ context.Context, o client.Object) []reconcile.Request {
+	clusterClient, ok := o.(*v1beta1.TemporalClusterClient)
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: clusterClient.Spec.ClusterRef.NamespacedName(clusterClient),
+		},
+	}
 }
 
 func addResourceToIndex(rawObj client.Object) []string {
@@ -373,6 +809,9 @@ func addPromtheusOperatorResourceToIndex(rawObj client.Object) []string {
 	case *monitoringv1.ServiceMonitor:
 		owner := metav1.GetControllerOf(resourceObject)
 		return validateAndGetOwner(owner)
+	case *monitoringv1.PrometheusRule:
+		owner := metav1.GetControllerOf(resourceObject)
+		return validateAndGetOwner(owner)
 	default:
 		return nil
 	}
@@ -0,0 +1,106 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/discovery"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/config"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/persistence"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testCachedCluster() *v1beta1.TemporalCluster {
+	store := func(database string) *v1beta1.DatastoreSpec {
+		return &v1beta1.DatastoreSpec{
+			SQL: &v1beta1.SQLSpec{
+				User:            "temporal",
+				PluginName:      "postgres",
+				DatabaseName:    database,
+				ConnectAddr:     "postgres:5432",
+				ConnectProtocol: "tcp",
+			},
+			PasswordSecretRef: &v1beta1.SecretKeyReference{Name: "postgres-password", Key: "PASSWORD"},
+			ServiceAlias:      &v1beta1.DatastoreServiceAliasSpec{Enabled: true},
+		}
+	}
+
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "temporal"},
+		Spec: v1beta1.TemporalClusterSpec{
+			NumHistoryShards: 1,
+			Persistence: v1beta1.TemporalPersistenceSpec{
+				DefaultStore:    store("temporal"),
+				VisibilityStore: store("temporal_visibility"),
+			},
+			DynamicConfig: &v1beta1.DynamicConfigSpec{},
+			UI:            &v1beta1.TemporalUISpec{Enabled: true},
+			AdminTools:    &v1beta1.TemporalAdminToolsSpec{Enabled: true},
+			GRPCWeb:       &v1beta1.GRPCWebSpec{Enabled: true},
+			Backup:        &v1beta1.BackupSpec{Enabled: true, URL: "s3://backups/prod"},
+		},
+	}
+	cluster.Default()
+
+	return cluster
+}
+
+// TestClusterBuildersCachedObjects ensures the objects of the kinds restricted in the manager cache are labeled
+// to be cached: the reconciler doesn't find the other ones, and tries to create them again on every reconcile.
+func TestClusterBuildersCachedObjects(t *testing.T) {
+	cluster := testCachedCluster()
+	r := &TemporalClusterReconciler{
+		Base:          newCachedFakeBase(t, cluster),
+		AvailableAPIs: &discovery.AvailableAPIs{},
+	}
+
+	builders := []resource.Builder{
+		config.NewConfigmapBuilder(cluster, r.Scheme, nil, nil),
+		persistence.NewSchemaScriptsConfigmapBuilder(cluster, r.Scheme),
+	}
+	for _, store := range cluster.Spec.Persistence.GetDatastores() {
+		builders = append(builders, persistence.NewDatastoreAliasServiceBuilder(cluster, r.Scheme, store))
+	}
+
+	resourceBuilders, err := r.resourceBuilders(cluster, "hash", nil, nil)
+	require.NoError(t, err)
+	builders = append(builders, resourceBuilders...)
+
+	for _, component := range r.components(cluster, "hash") {
+		builders = append(builders, component.builders...)
+	}
+
+	// Only keep the builders of the cached kinds, the other kinds aren't registered in the test scheme.
+	cached := []resource.Builder{}
+	for _, builder := range builders {
+		if r.Client.(*cachedClient).selector(builder.Build()) != nil {
+			cached = append(cached, builder)
+		}
+	}
+	require.NotEmpty(t, cached)
+
+	for i := 0; i < 2; i++ {
+		_, err := r.Reconciler.ReconcileBuilders(context.Background(), cluster, cached)
+		require.NoError(t, err, "reconcile %d", i+1)
+	}
+}
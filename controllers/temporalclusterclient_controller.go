@@ -20,6 +20,7 @@ package controllers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/alexandrevilain/controller-tools/pkg/patch"
@@ -27,10 +28,15 @@ import (
 	certmanagerapiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	certmanagermeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -38,11 +44,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/logging"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
 	"github.com/alexandrevilain/temporal-operator/pkg/kubernetes"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
+const (
+	// clientLoadBalancingServiceConfig is the gRPC service config enabling client-side round robin load balancing.
+	clientLoadBalancingServiceConfig = `{"loadBalancingConfig":[{"round_robin":{}}]}`
+	// clientValidationInterval is the interval between two validations of working client credentials.
+	clientValidationInterval = 10 * time.Minute
+	// clientValidationRetryInterval is the interval between two validations of failing client credentials.
+	clientValidationRetryInterval = 30 * time.Second
+)
+
 // TemporalClusterClientReconciler reconciles a ClusterClient object.
 type TemporalClusterClientReconciler struct {
 	Base
@@ -58,14 +75,11 @@ var (
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalclusterclients,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalclusterclients/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalclusterclients/finalizers,verbs=update
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *TemporalClusterClientReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
-	logger := log.FromContext(ctx)
-
-	logger.Info("Starting reconciliation")
-
 	clusterClient := &v1beta1.TemporalClusterClient{}
 	err := r.Get(ctx, req.NamespacedName, clusterClient)
 	if err != nil {
@@ -75,6 +89,10 @@ func (r *TemporalClusterClientReconciler) Reconcile(ctx context.Context, req ctr
 		return reconcile.Result{}, err
 	}
 
+	ctx, logger := logging.WithCluster(ctx, clusterClient.Spec.ClusterRef.Name, clusterClient)
+
+	logger.Info("Starting reconciliation")
+
 	// Check if the resource has been marked for deletion
 	if !clusterClient.ObjectMeta.DeletionTimestamp.IsZero() {
 		return reconcile.Result{}, nil
@@ -94,8 +112,7 @@ func (r *TemporalClusterClientReconciler) Reconcile(ctx context.Context, req ctr
 	}()
 
 	// Get referenced cluster.
-	cluster := &v1beta1.TemporalCluster{}
-	err = r.Get(ctx, clusterClient.Spec.ClusterRef.NamespacedName(clusterClient), cluster)
+	cluster, err := temporal.GetEffectiveCluster(ctx, r.Client, clusterClient.Spec.ClusterRef.NamespacedName(clusterClient))
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -111,6 +128,13 @@ func (r *TemporalClusterClientReconciler) Reconcile(ctx context.Context, req ctr
 	}
 
 	clusterClient.Status.ServerName = cluster.Spec.MTLS.Frontend.ServerName(cluster)
+
+	if !reconcileAddress(clusterClient, cluster) {
+		logger.Info("Skipping cluster client reconciliation until the cluster publishes a frontend hostname")
+
+		return reconcile.Result{}, nil
+	}
+
 	if clusterClient.Status.SecretRef == nil {
 		clusterClient.Status.SecretRef = &corev1.LocalObjectReference{
 			Name: "",
@@ -135,19 +159,203 @@ func (r *TemporalClusterClientReconciler) Reconcile(ctx context.Context, req ctr
 		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
 	}
 
+	originalSecret := client.ObjectKey{Namespace: certificate.GetNamespace(), Name: certificate.Spec.SecretName}
+
+	err = r.reconcileConnectionInfo(ctx, clusterClient, cluster, originalSecret)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if clusterClient.GetNamespace() != cluster.GetNamespace() {
-		originalSecret := client.ObjectKey{Namespace: certificate.GetNamespace(), Name: certificate.Spec.SecretName}
 		err = kubernetes.NewSecretCopier(r.Client, r.Scheme).Copy(ctx, clusterClient, originalSecret, clusterClient.GetNamespace())
 		if err != nil {
 			return reconcile.Result{}, err
 		}
 	}
 
+	err = r.reconcileSecretAccess(ctx, clusterClient, certificate.Spec.SecretName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	clusterClient.Status.SecretRef = &corev1.LocalObjectReference{
 		Name: certificate.Spec.SecretName,
 	}
 
-	return reconcile.Result{}, nil
+	reconcilePermissions(clusterClient, cluster)
+
+	return reconcile.Result{RequeueAfter: r.reconcileValidation(ctx, clusterClient, cluster, originalSecret)}, nil
+}
+
+// reconcileAddress resolves the frontend address matching the client access and reports it in the client status.
+// It returns false if the address can't be resolved.
+func reconcileAddress(clusterClient *v1beta1.TemporalClusterClient, cluster *v1beta1.TemporalCluster) bool {
+	address := cluster.GetInClusterClientAddress()
+	if clusterClient.GetAccess() == v1beta1.ClusterClientAccessExternal {
+		var ok bool
+		address, ok = cluster.GetExternalClientAddress()
+		if !ok {
+			clusterClient.Status.Address = ""
+			v1beta1.SetTemporalClusterClientAddressResolved(clusterClient, metav1.ConditionFalse, v1beta1.ClusterClientNoExternalHostnameReason,
+				"The cluster doesn't publish any frontend hostname, set spec.expose.hostnames.frontend")
+			return false
+		}
+	}
+
+	clusterClient.Status.Address = address
+	v1beta1.SetTemporalClusterClientAddressResolved(clusterClient, metav1.ConditionTrue, v1beta1.ClusterClientAddressResolvedReason,
+		fmt.Sprintf("Using %s access", clusterClient.GetAccess()))
+
+	return true
+}
+
+// reconcilePermissions reports in the client status whether the permissions it requests are granted by the cluster.
+// The permissions themselves are rendered by the cluster reconciler in the claim mapper rules.
+func reconcilePermissions(clusterClient *v1beta1.TemporalClusterClient, cluster *v1beta1.TemporalCluster) {
+	if len(clusterClient.Spec.Permissions) == 0 {
+		apimeta.RemoveStatusCondition(&clusterClient.Status.Conditions, v1beta1.ClusterClientPermissionsGrantedCondition)
+		return
+	}
+
+	if !cluster.Spec.Authorization.ClientPermissionsAllowed() {
+		v1beta1.SetTemporalClusterClientPermissionsGranted(clusterClient, metav1.ConditionFalse, v1beta1.ClusterClientPermissionsNotAllowedReason,
+			"The cluster doesn't allow clients to request permissions, set spec.authorization.certificateClaimMapper.allowClientPermissions")
+		return
+	}
+
+	v1beta1.SetTemporalClusterClientPermissionsGranted(clusterClient, metav1.ConditionTrue, v1beta1.ClusterClientPermissionsGrantedReason,
+		fmt.Sprintf("Permissions granted on %d namespace(s)", len(clusterClient.Spec.Permissions)))
+}
+
+// reconcileValidation tests the client credentials against the cluster frontend and reports the result in the client status,
+// so broken certificate chains are caught before applications use them. It returns the delay before the next validation.
+func (r *TemporalClusterClientReconciler) reconcileValidation(ctx context.Context, clusterClient *v1beta1.TemporalClusterClient, cluster *v1beta1.TemporalCluster, key client.ObjectKey) time.Duration {
+	now := metav1.Now()
+	clusterClient.Status.LastValidationTime = &now
+
+	server, err := r.validateClient(ctx, clusterClient, cluster, key)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Can't validate client credentials")
+		// Only emit an event when the validation starts failing.
+		if !apimeta.IsStatusConditionFalse(clusterClient.Status.Conditions, v1beta1.ClusterClientValidatedCondition) {
+			r.Recorder.Event(clusterClient, corev1.EventTypeWarning, v1beta1.ClusterClientValidationFailedReason, err.Error())
+		}
+		v1beta1.SetTemporalClusterClientValidated(clusterClient, metav1.ConditionFalse, v1beta1.ClusterClientValidationFailedReason, err.Error())
+		return clientValidationRetryInterval
+	}
+
+	clusterClient.Status.Server = server
+	v1beta1.SetTemporalClusterClientValidated(clusterClient, metav1.ConditionTrue, v1beta1.ClusterClientValidatedReason,
+		fmt.Sprintf("Connected to temporal server %s", server.Version))
+
+	return clientValidationInterval
+}
+
+// validateClient opens a connection to the cluster frontend using the client secret and returns the server information.
+func (r *TemporalClusterClientReconciler) validateClient(ctx context.Context, clusterClient *v1beta1.TemporalClusterClient, cluster *v1beta1.TemporalCluster, key client.ObjectKey) (*v1beta1.ClusterClientServerStatus, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, key, secret)
+	if err != nil {
+		return nil, fmt.Errorf("can't get client secret: %w", err)
+	}
+
+	tlsConfig, err := temporal.GetTlSConfigFromSecret(secret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client secret: %w", err)
+	}
+	tlsConfig.ServerName = clusterClient.Status.ServerName
+
+	return temporal.ValidateClientCredentials(ctx, cluster.GetPublicClientAddress(), tlsConfig)
+}
+
+// reconcileSecretAccess grants the client ServiceAccount, if any, read access to the client secret only.
+// The Role and RoleBinding are deleted when the ServiceAccount is removed from the client spec.
+func (r *TemporalClusterClientReconciler) reconcileSecretAccess(ctx context.Context, clusterClient *v1beta1.TemporalClusterClient, secretName string) error {
+	objectMeta := metav1.ObjectMeta{
+		Name:      fmt.Sprintf("%s-secret-reader", clusterClient.GetName()),
+		Namespace: clusterClient.GetNamespace(),
+	}
+	role := &rbacv1.Role{ObjectMeta: objectMeta}
+	roleBinding := &rbacv1.RoleBinding{ObjectMeta: objectMeta}
+
+	if clusterClient.Spec.ServiceAccountName == "" {
+		for _, object := range []client.Object{roleBinding, role} {
+			err := r.Delete(ctx, object)
+			if client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("can't delete client secret access: %w", err)
+			}
+		}
+		return nil
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, role, func() error {
+		role.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				ResourceNames: []string{secretName},
+				Verbs:         []string{"get", "watch"},
+			},
+		}
+		return controllerutil.SetControllerReference(clusterClient, role, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("can't create or update client secret role: %w", err)
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, roleBinding, func() error {
+		roleBinding.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.GetName(),
+		}
+		roleBinding.Subjects = []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      clusterClient.Spec.ServiceAccountName,
+				Namespace: clusterClient.GetNamespace(),
+			},
+		}
+		return controllerutil.SetControllerReference(clusterClient, roleBinding, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("can't create or update client secret role binding: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileConnectionInfo adds the information needed to connect to the cluster to the client secret.
+func (r *TemporalClusterClientReconciler) reconcileConnectionInfo(ctx context.Context, clusterClient *v1beta1.TemporalClusterClient, cluster *v1beta1.TemporalCluster, key client.ObjectKey) error {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, key, secret)
+	if err != nil {
+		return fmt.Errorf("can't get client secret: %w", err)
+	}
+
+	original := secret.DeepCopy()
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	secret.Data[v1beta1.ClusterClientAddressKey] = []byte(clusterClient.Status.Address)
+	secret.Data[v1beta1.ClusterClientServerNameKey] = []byte(cluster.Spec.MTLS.Frontend.ServerName(cluster))
+
+	// The load balancing target resolves the frontend pods IPs, which are only reachable from the kubernetes cluster.
+	if cluster.Spec.Expose.ClientLoadBalancingEnabled() && clusterClient.GetAccess() == v1beta1.ClusterClientAccessInternal {
+		secret.Data[v1beta1.ClusterClientLoadBalancingTargetKey] = []byte(cluster.GetClientLoadBalancingTarget())
+		secret.Data[v1beta1.ClusterClientServiceConfigKey] = []byte(clientLoadBalancingServiceConfig)
+	} else {
+		delete(secret.Data, v1beta1.ClusterClientLoadBalancingTargetKey)
+		delete(secret.Data, v1beta1.ClusterClientServiceConfigKey)
+	}
+
+	if apiequality.Semantic.DeepEqual(original.Data, secret.Data) {
+		return nil
+	}
+
+	return r.Patch(ctx, secret, client.MergeFrom(original))
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -193,7 +401,17 @@ func (r *TemporalClusterClientReconciler) SetupWithManager(mgr ctrl.Manager) err
 				))
 	}
 
-	controller.Owns(&corev1.Secret{})
+	// Frontend Services changes (e.g. client load balancing enabled) update the clients connection info.
+	controller = controller.Watches(
+		&corev1.Service{},
+		handler.EnqueueRequestsFromMapFunc(
+			EnqueueRequestForClusterClientReferencingOwnerCluster(r.Client),
+		))
+
+	// Only watch secrets metadata, secrets aren't cached by the manager.
+	controller.Owns(&corev1.Secret{}, builder.OnlyMetadata).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{})
 
 	return controller.Complete(r)
 }
@@ -0,0 +1,214 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/patch"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/logging"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// clusterCloneSourceRetryInterval is the interval at which a clone with a missing source cluster is retried.
+const clusterCloneSourceRetryInterval = time.Minute
+
+// errClusterCloneConflict is returned when the clone target cluster exists and isn't managed by the clone.
+var errClusterCloneConflict = errors.New("a cluster with the same name already exists and isn't managed by the clone")
+
+// TemporalClusterCloneReconciler reconciles a TemporalClusterClone object.
+type TemporalClusterCloneReconciler struct {
+	Base
+}
+
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalclusterclones,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalclusterclones/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalclusterclones/finalizers,verbs=update
+
+// Reconcile creates the cluster described by the clone from its source cluster spec.
+func (r *TemporalClusterCloneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	clone := &v1beta1.TemporalClusterClone{}
+	err := r.Get(ctx, req.NamespacedName, clone)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	ctx, logger := logging.WithCluster(ctx, clone.Spec.ClusterRef.Name, clone)
+
+	logger.Info("Starting reconciliation")
+
+	// Check if the resource has been marked for deletion
+	if !clone.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(clone, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the TemporalClusterClone object and status after each reconciliation.
+		err := patchHelper.Patch(ctx, clone)
+		if err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	source := &v1beta1.TemporalCluster{}
+	err = r.Get(ctx, clone.Spec.ClusterRef.NamespacedName(clone), source)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Source cluster not found, requeuing")
+			v1beta1.SetTemporalClusterCloneReady(clone, metav1.ConditionFalse, v1beta1.ClusterCloneSourceNotFoundReason, "The referenced cluster doesn't exist")
+			return reconcile.Result{RequeueAfter: clusterCloneSourceRetryInterval}, nil
+		}
+		v1beta1.SetTemporalClusterCloneReady(clone, metav1.ConditionFalse, v1beta1.ClusterCloneReconcileErrorReason, fmt.Sprintf("Can't get referenced cluster: %s", err))
+		return reconcile.Result{}, err
+	}
+
+	synced, err := r.reconcileClonedCluster(ctx, clone, source)
+	if err != nil {
+		reason := v1beta1.ClusterCloneReconcileErrorReason
+		if errors.Is(err, errClusterCloneConflict) {
+			reason = v1beta1.ClusterCloneConflictReason
+		}
+		v1beta1.SetTemporalClusterCloneReady(clone, metav1.ConditionFalse, reason, err.Error())
+		return reconcile.Result{}, err
+	}
+
+	clone.Status.ClusterName = clone.GetClusterName()
+	if synced {
+		now := metav1.Now()
+		clone.Status.SourceGeneration = source.GetGeneration()
+		clone.Status.LastSyncTime = &now
+		r.Recorder.Eventf(clone, corev1.EventTypeNormal, v1beta1.ClusterCloneSyncedReason, "Cluster %s written from cluster %s/%s", clone.GetClusterName(), source.GetNamespace(), source.GetName())
+	}
+
+	v1beta1.SetTemporalClusterCloneReady(clone, metav1.ConditionTrue, v1beta1.ClusterCloneSyncedReason, "")
+
+	return reconcile.Result{}, nil
+}
+
+// reconcileClonedCluster creates the cluster described by the clone, or updates its spec when the clone
+// uses the Continuous update policy. It returns true if the cluster spec was written.
+func (r *TemporalClusterCloneReconciler) reconcileClonedCluster(ctx context.Context, clone *v1beta1.TemporalClusterClone, source *v1beta1.TemporalCluster) (bool, error) {
+	spec, err := clone.ClusterSpec(source)
+	if err != nil {
+		return false, fmt.Errorf("can't compute cloned cluster spec: %w", err)
+	}
+
+	cluster := &v1beta1.TemporalCluster{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: clone.GetNamespace(), Name: clone.GetClusterName()}, cluster)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+
+		cluster = &v1beta1.TemporalCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clone.GetClusterName(),
+				Namespace: clone.GetNamespace(),
+			},
+			Spec: *spec,
+		}
+		err = controllerutil.SetControllerReference(clone, cluster, r.Scheme)
+		if err != nil {
+			return false, err
+		}
+
+		return true, r.Create(ctx, cluster)
+	}
+
+	if !metav1.IsControlledBy(cluster, clone) {
+		return false, errClusterCloneConflict
+	}
+
+	if clone.Spec.UpdatePolicy != v1beta1.ContinuousClusterCloneUpdatePolicy {
+		return false, nil
+	}
+
+	if equality.Semantic.DeepEqual(&cluster.Spec, spec) {
+		return false, nil
+	}
+
+	cluster.Spec = *spec
+
+	return true, r.Update(ctx, cluster)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemporalClusterCloneReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1beta1.TemporalClusterClone{}, clusterRefField, func(rawObj client.Object) []string {
+		clone := rawObj.(*v1beta1.TemporalClusterClone)
+		if clone.Spec.ClusterRef.Name == "" {
+			return nil
+		}
+		return []string{clone.Spec.ClusterRef.Name}
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.TemporalClusterClone{}).
+		Owns(&v1beta1.TemporalCluster{}).
+		Watches(
+			&v1beta1.TemporalCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToClonesMapfunc),
+		).
+		Complete(r)
+}
+
+func (r *TemporalClusterCloneReconciler) clusterToClonesMapfunc(ctx context.Context, o client.Object) []reconcile.Request {
+	clones := &v1beta1.TemporalClusterCloneList{}
+	err := r.List(ctx, clones, client.MatchingFields{clusterRefField: o.GetName()})
+	if err != nil {
+		return nil
+	}
+
+	result := []reconcile.Request{}
+	for _, clone := range clones.Items {
+		clone := clone
+		// As we're only indexing on spec.clusterRef.Name, ensure that the clone references the cluster's namespace.
+		if clone.Spec.ClusterRef.NamespacedName(&clone) != client.ObjectKeyFromObject(o) {
+			continue
+		}
+		result = append(result, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&clone),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,172 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func testCloneSource() *v1beta1.TemporalCluster {
+	return &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "temporal", Generation: 3},
+		Spec: v1beta1.TemporalClusterSpec{
+			NumHistoryShards: 512,
+			Services: &v1beta1.ServicesSpec{
+				History: &v1beta1.ServiceSpec{Replicas: ptr.To[int32](10)},
+			},
+		},
+	}
+}
+
+func testClusterClone(updatePolicy v1beta1.ClusterCloneUpdatePolicy) *v1beta1.TemporalClusterClone {
+	return &v1beta1.TemporalClusterClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "temporal"},
+		Spec: v1beta1.TemporalClusterCloneSpec{
+			ClusterRef:   v1beta1.ObjectReference{Name: "prod"},
+			Replicas:     ptr.To[int32](1),
+			UpdatePolicy: updatePolicy,
+		},
+	}
+}
+
+func reconcileClusterClone(t *testing.T, r *TemporalClusterCloneReconciler) (ctrl.Result, *v1beta1.TemporalClusterClone, error) {
+	t.Helper()
+
+	key := client.ObjectKey{Namespace: "temporal", Name: "staging"}
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+
+	clone := &v1beta1.TemporalClusterClone{}
+	require.NoError(t, r.Get(context.Background(), key, clone))
+
+	return result, clone, err
+}
+
+func TestTemporalClusterCloneReconcile(t *testing.T) {
+	tests := map[string]struct {
+		objects        []client.Object
+		expectedErr    string
+		expectedResult ctrl.Result
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		"creates the cluster": {
+			objects:        []client.Object{testCloneSource(), testClusterClone(v1beta1.OnceClusterCloneUpdatePolicy)},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: v1beta1.ClusterCloneSyncedReason,
+		},
+		"source cluster not found": {
+			objects:        []client.Object{testClusterClone(v1beta1.OnceClusterCloneUpdatePolicy)},
+			expectedResult: ctrl.Result{RequeueAfter: clusterCloneSourceRetryInterval},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: v1beta1.ClusterCloneSourceNotFoundReason,
+		},
+		"cluster not managed by the clone": {
+			objects: []client.Object{
+				testCloneSource(),
+				testClusterClone(v1beta1.OnceClusterCloneUpdatePolicy),
+				&v1beta1.TemporalCluster{ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "temporal"}},
+			},
+			expectedErr:    errClusterCloneConflict.Error(),
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: v1beta1.ClusterCloneConflictReason,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			r := &TemporalClusterCloneReconciler{Base: newFakeBase(tt, test.objects...)}
+
+			result, clone, err := reconcileClusterClone(tt, r)
+			if test.expectedErr != "" {
+				assert.ErrorContains(tt, err, test.expectedErr)
+			} else {
+				assert.NoError(tt, err)
+			}
+			assert.Equal(tt, test.expectedResult, result)
+
+			condition := apimeta.FindStatusCondition(clone.Status.Conditions, v1beta1.ReadyCondition)
+			require.NotNil(tt, condition)
+			assert.Equal(tt, test.expectedStatus, condition.Status)
+			assert.Equal(tt, test.expectedReason, condition.Reason)
+		})
+	}
+}
+
+func TestTemporalClusterCloneReconcileUpdatePolicy(t *testing.T) {
+	tests := map[string]struct {
+		updatePolicy             v1beta1.ClusterCloneUpdatePolicy
+		expectedShards           int32
+		expectedSourceGeneration int64
+	}{
+		"once": {
+			updatePolicy:             v1beta1.OnceClusterCloneUpdatePolicy,
+			expectedShards:           512,
+			expectedSourceGeneration: 3,
+		},
+		"continuous": {
+			updatePolicy:             v1beta1.ContinuousClusterCloneUpdatePolicy,
+			expectedShards:           1024,
+			expectedSourceGeneration: 4,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			r := &TemporalClusterCloneReconciler{Base: newFakeBase(tt, testCloneSource(), testClusterClone(test.updatePolicy))}
+			ctx := context.Background()
+
+			_, clone, err := reconcileClusterClone(tt, r)
+			require.NoError(tt, err)
+			assert.Equal(tt, "staging", clone.Status.ClusterName)
+			assert.Equal(tt, int64(3), clone.Status.SourceGeneration)
+			assert.NotNil(tt, clone.Status.LastSyncTime)
+
+			cluster := &v1beta1.TemporalCluster{}
+			require.NoError(tt, r.Get(ctx, client.ObjectKey{Namespace: "temporal", Name: "staging"}, cluster))
+			assert.True(tt, metav1.IsControlledBy(cluster, clone))
+			assert.Equal(tt, int32(512), cluster.Spec.NumHistoryShards)
+			assert.Equal(tt, int32(1), *cluster.Spec.Services.History.Replicas)
+
+			// The source cluster spec changes.
+			source := &v1beta1.TemporalCluster{}
+			require.NoError(tt, r.Get(ctx, client.ObjectKey{Namespace: "temporal", Name: "prod"}, source))
+			source.Spec.NumHistoryShards = 1024
+			source.Generation = 4
+			require.NoError(tt, r.Update(ctx, source))
+
+			_, clone, err = reconcileClusterClone(tt, r)
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expectedSourceGeneration, clone.Status.SourceGeneration)
+
+			require.NoError(tt, r.Get(ctx, client.ObjectKey{Namespace: "temporal", Name: "staging"}, cluster))
+			assert.Equal(tt, test.expectedShards, cluster.Spec.NumHistoryShards)
+			// The clone replicas are kept in both cases.
+			assert.Equal(tt, int32(1), *cluster.Spec.Services.History.Replicas)
+		})
+	}
+}
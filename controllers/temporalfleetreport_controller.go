@@ -0,0 +1,212 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/patch"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/discovery"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/strings/slices"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// fleetReportRefreshInterval is the interval at which fleet reports are refreshed.
+// Reports are refreshed periodically instead of on each cluster change, as clusters statuses change on every reconciliation.
+const fleetReportRefreshInterval = time.Minute
+
+// TemporalFleetReportReconciler maintains the TemporalFleetReport statuses.
+type TemporalFleetReportReconciler struct {
+	Base
+
+	AvailableAPIs *discovery.AvailableAPIs
+}
+
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalfleetreports,verbs=get;list;watch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalfleetreports/status,verbs=get;update;patch
+
+// Reconcile summarizes the clusters selected by the report in its status.
+func (r *TemporalFleetReportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	logger := log.FromContext(ctx)
+
+	report := &v1beta1.TemporalFleetReport{}
+	err := r.Get(ctx, req.NamespacedName, report)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if !report.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(report, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the TemporalFleetReport status after each reconciliation.
+		err := patchHelper.Patch(ctx, report)
+		if err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	clusters, err := r.fleetClusters(ctx, report)
+	if err != nil {
+		logger.Error(err, "Can't list fleet clusters")
+		return reconcile.Result{}, err
+	}
+
+	certificates := []certmanagerv1.Certificate{}
+	if r.AvailableAPIs.CertManager {
+		list := &certmanagerv1.CertificateList{}
+		if err := r.List(ctx, list); err != nil {
+			logger.Error(err, "Can't list fleet certificates")
+			return reconcile.Result{}, err
+		}
+		certificates = list.Items
+	}
+
+	report.Status = summarizeFleet(clusters, certificates, report.Spec.GetCertificateExpiryThreshold().Duration, time.Now())
+
+	return reconcile.Result{RequeueAfter: fleetReportRefreshInterval}, nil
+}
+
+// fleetClusters returns the clusters selected by the provided report.
+func (r *TemporalFleetReportReconciler) fleetClusters(ctx context.Context, report *v1beta1.TemporalFleetReport) ([]v1beta1.TemporalCluster, error) {
+	selector := labels.Everything()
+	if report.Spec.ClusterSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(report.Spec.ClusterSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	list := &v1beta1.TemporalClusterList{}
+	if err := r.List(ctx, list, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	clusters := []v1beta1.TemporalCluster{}
+	for _, cluster := range list.Items {
+		if len(report.Spec.Namespaces) > 0 && !slices.Contains(report.Spec.Namespaces, cluster.GetNamespace()) {
+			continue
+		}
+		clusters = append(clusters, cluster)
+	}
+
+	return clusters, nil
+}
+
+// summarizeFleet returns the report status summarizing the provided clusters.
+// Certificates not controlled by one of the clusters are ignored.
+func summarizeFleet(clusters []v1beta1.TemporalCluster, certificates []certmanagerv1.Certificate, threshold time.Duration, now time.Time) v1beta1.TemporalFleetReportStatus {
+	status := v1beta1.TemporalFleetReportStatus{
+		Clusters:             int32(len(clusters)),
+		Versions:             []v1beta1.FleetVersionCount{},
+		UnreadyClusters:      []v1beta1.FleetClusterReference{},
+		ExpiringCertificates: []v1beta1.FleetExpiringCertificate{},
+		LastUpdateTime:       &metav1.Time{Time: now},
+	}
+
+	versions := map[string]int32{}
+	fleet := map[string]bool{}
+	for i := range clusters {
+		cluster := &clusters[i]
+		fleet[cluster.GetNamespace()+"/"+cluster.GetName()] = true
+
+		version := cluster.Status.Version
+		if version == "" {
+			version = "unknown"
+		}
+		versions[version]++
+
+		if cluster.IsReady() {
+			status.ReadyClusters++
+			continue
+		}
+
+		reason := ""
+		if condition, ok := v1beta1.GetTemporalClusterReadyCondition(cluster); ok {
+			reason = condition.Reason
+		}
+		status.UnreadyClusters = append(status.UnreadyClusters, v1beta1.FleetClusterReference{
+			Namespace: cluster.GetNamespace(),
+			Name:      cluster.GetName(),
+			Reason:    reason,
+		})
+	}
+
+	for version, count := range versions {
+		status.Versions = append(status.Versions, v1beta1.FleetVersionCount{Version: version, Clusters: count})
+	}
+	sort.Slice(status.Versions, func(i, j int) bool {
+		return status.Versions[i].Version < status.Versions[j].Version
+	})
+
+	for i := range certificates {
+		certificate := &certificates[i]
+		owner := metav1.GetControllerOf(certificate)
+		if owner == nil || owner.Kind != "TemporalCluster" || !fleet[certificate.GetNamespace()+"/"+owner.Name] {
+			continue
+		}
+
+		notAfter := certificate.Status.NotAfter
+		if notAfter == nil || notAfter.Sub(now) > threshold {
+			continue
+		}
+
+		status.ExpiringCertificates = append(status.ExpiringCertificates, v1beta1.FleetExpiringCertificate{
+			Namespace: certificate.GetNamespace(),
+			Cluster:   owner.Name,
+			Name:      certificate.GetName(),
+			NotAfter:  *notAfter,
+		})
+	}
+	sort.Slice(status.ExpiringCertificates, func(i, j int) bool {
+		return status.ExpiringCertificates[i].NotAfter.Before(&status.ExpiringCertificates[j].NotAfter)
+	})
+
+	return status
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemporalFleetReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// Reports are refreshed periodically, status updates must not trigger a new reconciliation.
+		For(&v1beta1.TemporalFleetReport{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
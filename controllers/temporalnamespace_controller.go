@@ -33,6 +33,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -40,13 +41,25 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/logging"
+	"github.com/alexandrevilain/temporal-operator/internal/metrics"
+	"github.com/alexandrevilain/temporal-operator/pkg/ratelimit"
 	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporalclient"
 )
 
+// namespaceReplicationCheckInterval is the interval at which standby clusters check whether global namespaces were replicated.
+const namespaceReplicationCheckInterval = 30 * time.Second
+
 // TemporalNamespaceReconciler reconciles a Namespace object.
 type TemporalNamespaceReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme            *runtime.Scheme
+	ClusterOperations temporalclient.ClusterOperations
+	// RateLimiter limits the rate of namespaces reconciliations per cluster, so bulk creations don't saturate the frontend.
+	RateLimiter *ratelimit.KeyedLimiter
+	// MaxConcurrentReconciles is the number of namespaces reconciled in parallel.
+	MaxConcurrentReconciles int
 }
 
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalnamespaces,verbs=get;list;watch;create;update;patch;delete
@@ -56,19 +69,20 @@ type TemporalNamespaceReconciler struct {
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *TemporalNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
-	logger := log.FromContext(ctx)
-
-	logger.Info("Starting reconciliation")
-
 	namespace := &v1beta1.TemporalNamespace{}
 	err := r.Get(ctx, req.NamespacedName, namespace)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			r.RateLimiter.Forget(req.String())
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
 
+	ctx, logger := logging.WithCluster(ctx, namespace.Spec.ClusterRef.Name, namespace)
+
+	logger.Info("Starting reconciliation")
+
 	patchHelper, err := patch.NewHelper(namespace, r.Client)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -82,8 +96,7 @@ func (r *TemporalNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 	}()
 
-	cluster := &v1beta1.TemporalCluster{}
-	err = r.Get(ctx, namespace.Spec.ClusterRef.NamespacedName(namespace), cluster)
+	cluster, err := temporal.GetEffectiveCluster(ctx, r.Client, namespace.Spec.ClusterRef.NamespacedName(namespace))
 	if err != nil {
 		if apierrors.IsNotFound(err) && !namespace.ObjectMeta.DeletionTimestamp.IsZero() {
 			// Two ways to get here:
@@ -101,6 +114,14 @@ func (r *TemporalNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
 	}
 
+	clusterKey := client.ObjectKeyFromObject(cluster).String()
+	delay := r.RateLimiter.Reserve(clusterKey, req.String(), time.Now())
+	metrics.ObserveNamespaceReconcile(cluster.GetNamespace(), cluster.GetName(), r.RateLimiter.Pending(clusterKey), delay > 0)
+	if delay > 0 {
+		logger.V(1).Info("Delaying namespace reconciliation to respect the cluster rate limit", "delay", delay)
+		return reconcile.Result{RequeueAfter: delay}, nil
+	}
+
 	// Check if the resource has been marked for deletion
 	if !namespace.ObjectMeta.DeletionTimestamp.IsZero() {
 		logger.Info("Deleting namespace")
@@ -115,14 +136,12 @@ func (r *TemporalNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	// Ensure the namespace have a deletion marker if the AllowDeletion is set to true.
 	r.ensureFinalizer(namespace)
 
-	client, err := temporal.GetClusterNamespaceClient(ctx, r.Client, cluster)
-	if err != nil {
-		err = fmt.Errorf("can't create cluster namespace client: %w", err)
-		return r.handleError(namespace, v1beta1.ReconcileErrorReason, err)
+	// Global namespaces are registered on the active cluster, and replicated to standby clusters.
+	if namespace.Spec.IsGlobalNamespace && cluster.Spec.Replication.IsStandby() {
+		return r.reconcileReplicatedNamespace(ctx, namespace, cluster)
 	}
-	defer client.Close()
 
-	err = client.Register(ctx, temporal.NamespaceToRegisterNamespaceRequest(cluster, namespace))
+	err = r.ClusterOperations.RegisterNamespace(ctx, cluster, temporal.NamespaceToRegisterNamespaceRequest(cluster, namespace))
 	if err != nil {
 		var namespaceAlreadyExistsError *serviceerror.NamespaceAlreadyExists
 		ok := errors.As(err, &namespaceAlreadyExistsError)
@@ -130,12 +149,25 @@ func (r *TemporalNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			err = fmt.Errorf("can't create \"%s\" namespace: %w", namespace.GetName(), err)
 			return r.handleError(namespace, v1beta1.ReconcileErrorReason, err)
 		}
-		err = client.Update(ctx, temporal.NamespaceToUpdateNamespaceRequest(cluster, namespace))
+		err = r.ClusterOperations.UpdateNamespace(ctx, cluster, temporal.NamespaceToUpdateNamespaceRequest(cluster, namespace))
 		if err != nil {
 			return r.handleError(namespace, v1beta1.ReconcileErrorReason, err)
 		}
 	}
 
+	err = r.reconcileSearchAttributeAliases(ctx, namespace, cluster)
+	if err != nil {
+		return r.handleError(namespace, v1beta1.ReconcileErrorReason, err)
+	}
+
+	// Rate limits are rendered by the cluster controller in the cluster's dynamic config.
+	if namespace.Spec.RateLimits != nil && cluster.Spec.DynamicConfig == nil {
+		err = errors.New("namespace rate limits require dynamic config to be enabled on the referenced cluster (spec.dynamicConfig)")
+		return r.handleError(namespace, v1beta1.ReconcileErrorReason, err)
+	}
+
+	namespace.Status.ActiveClusterName = namespace.GetActiveClusterName(cluster)
+
 	logger.Info("Successfully reconciled namespace", "namespace", namespace.GetName())
 
 	v1beta1.SetTemporalNamespaceReady(namespace, metav1.ConditionTrue, v1beta1.TemporalNamespaceCreatedReason, "Namespace successfully created")
@@ -157,13 +189,13 @@ func (r *TemporalNamespaceReconciler) ensureNamespaceDeleted(ctx context.Context
 		return nil
 	}
 
-	client, err := temporal.GetClusterClient(ctx, r.Client, cluster)
-	if err != nil {
-		return fmt.Errorf("can't create cluster client: %w", err)
+	// Global namespaces are deleted by the operator managing the active cluster.
+	if namespace.Spec.IsGlobalNamespace && cluster.Spec.Replication.IsStandby() {
+		_ = controllerutil.RemoveFinalizer(namespace, deletionFinalizer)
+		return nil
 	}
-	defer client.Close()
 
-	_, err = client.OperatorService().DeleteNamespace(ctx, temporal.NamespaceToDeleteNamespaceRequest(namespace))
+	err := r.ClusterOperations.DeleteNamespace(ctx, cluster, temporal.NamespaceToDeleteNamespaceRequest(namespace))
 	if err != nil {
 		var namespaceNotFoundError *serviceerror.NamespaceNotFound
 		if errors.As(err, &namespaceNotFoundError) {
@@ -177,6 +209,60 @@ func (r *TemporalNamespaceReconciler) ensureNamespaceDeleted(ctx context.Context
 	return nil
 }
 
+// reconcileSearchAttributeAliases syncs the namespace search attribute aliases with the ones set in the namespace spec.
+// Aliases can't be set when registering a namespace, so they are always applied using namespace updates.
+func (r *TemporalNamespaceReconciler) reconcileSearchAttributeAliases(ctx context.Context, namespace *v1beta1.TemporalNamespace, cluster *v1beta1.TemporalCluster) error {
+	if len(namespace.Spec.SearchAttributeAliases) == 0 && len(namespace.Status.SearchAttributeAliases) == 0 {
+		return nil
+	}
+
+	info, err := r.ClusterOperations.DescribeNamespace(ctx, cluster, namespace.GetName())
+	if err != nil {
+		return fmt.Errorf("can't describe \"%s\" namespace: %w", namespace.GetName(), err)
+	}
+
+	requests, err := temporal.NamespaceToSearchAttributeAliasesUpdateRequests(namespace, info.SearchAttributeAliases)
+	if err != nil {
+		return fmt.Errorf("can't sync \"%s\" namespace search attribute aliases: %w", namespace.GetName(), err)
+	}
+
+	for _, request := range requests {
+		err := r.ClusterOperations.UpdateNamespace(ctx, cluster, request)
+		if err != nil {
+			return fmt.Errorf("can't update \"%s\" namespace search attribute aliases: %w", namespace.GetName(), err)
+		}
+	}
+
+	namespace.Status.SearchAttributeAliases = namespace.Spec.SearchAttributeAliases
+
+	return nil
+}
+
+// reconcileReplicatedNamespace reports whether a global namespace registered on the active cluster
+// has been replicated to the standby cluster, and the cluster it's active on.
+func (r *TemporalNamespaceReconciler) reconcileReplicatedNamespace(ctx context.Context, namespace *v1beta1.TemporalNamespace, cluster *v1beta1.TemporalCluster) (ctrl.Result, error) {
+	info, err := r.ClusterOperations.DescribeNamespace(ctx, cluster, namespace.GetName())
+	if err != nil {
+		var namespaceNotFoundError *serviceerror.NamespaceNotFound
+		if !errors.As(err, &namespaceNotFoundError) {
+			err = fmt.Errorf("can't describe \"%s\" namespace: %w", namespace.GetName(), err)
+			return r.handleError(namespace, v1beta1.ReconcileErrorReason, err)
+		}
+
+		v1beta1.SetTemporalNamespaceReady(namespace, metav1.ConditionFalse, v1beta1.TemporalNamespaceNotReplicatedReason,
+			fmt.Sprintf("Waiting for the namespace to be replicated from the active cluster %s", cluster.Spec.Replication.ActiveCluster))
+		return r.handleSuccessWithRequeue(namespace, namespaceReplicationCheckInterval)
+	}
+
+	namespace.Status.ActiveClusterName = info.ActiveClusterName
+
+	log.FromContext(ctx).Info("Namespace replicated from the active cluster", "namespace", namespace.GetName())
+
+	v1beta1.SetTemporalNamespaceReady(namespace, metav1.ConditionTrue, v1beta1.TemporalNamespaceReplicatedReason, "Namespace replicated from the active cluster")
+
+	return r.handleSuccess(namespace)
+}
+
 func (r *TemporalNamespaceReconciler) handleSuccess(namespace *v1beta1.TemporalNamespace) (ctrl.Result, error) {
 	return r.handleSuccessWithRequeue(namespace, 0)
 }
@@ -251,5 +337,6 @@ func (r *TemporalNamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&v1beta1.TemporalCluster{},
 			handler.EnqueueRequestsFromMapFunc(r.clusterToNamespacesMapfunc),
 		).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
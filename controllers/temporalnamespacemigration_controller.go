@@ -0,0 +1,375 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/patch"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/logging"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporalclient"
+	"go.temporal.io/api/serviceerror"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// namespaceMigrationCheckInterval is the interval at which a migration checks whether its current step is done.
+const namespaceMigrationCheckInterval = 10 * time.Second
+
+// TemporalNamespaceMigrationReconciler reconciles a TemporalNamespaceMigration object.
+type TemporalNamespaceMigrationReconciler struct {
+	Base
+
+	ClusterOperations temporalclient.ClusterOperations
+}
+
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalnamespacemigrations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalnamespacemigrations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalnamespacemigrations/finalizers,verbs=update
+
+// Reconcile moves the migrated namespace through the migration steps. Each step updates the migrated
+// TemporalNamespace, which is applied to the clusters by the namespace controller, then waits for the
+// clusters to report the change before moving to the next step.
+func (r *TemporalNamespaceMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	migration := &v1beta1.TemporalNamespaceMigration{}
+	err := r.Get(ctx, req.NamespacedName, migration)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	ctx, logger := logging.WithCluster(ctx, migration.Spec.TargetClusterRef.Name, migration)
+
+	logger.Info("Starting reconciliation")
+
+	// Check if the resource has been marked for deletion
+	if !migration.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	// Migrations run only once.
+	if migration.IsFinished() {
+		return reconcile.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(migration, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the TemporalNamespaceMigration object and status after each reconciliation.
+		err := patchHelper.Patch(ctx, migration)
+		if err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	if migration.Status.Phase == "" {
+		now := metav1.Now()
+		migration.Status.StartTime = &now
+		migration.SetPhase(v1beta1.NamespaceMigrationPending, "Waiting for the clusters to be ready")
+	}
+
+	// Migrations started by previous operator versions don't record when their phase started.
+	if migration.Status.PhaseStartTime == nil {
+		now := metav1.Now()
+		migration.Status.PhaseStartTime = &now
+	}
+
+	namespace := &v1beta1.TemporalNamespace{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: migration.GetNamespace(), Name: migration.Spec.NamespaceRef.Name}, namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			migration.Status.Message = fmt.Sprintf("Waiting for the TemporalNamespace %s to be created", migration.Spec.NamespaceRef.Name)
+			return reconcile.Result{RequeueAfter: namespaceMigrationCheckInterval}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// The source cluster is recorded when the migration starts, as the namespace references the target cluster once migrated.
+	if migration.Status.SourceClusterRef == nil {
+		key := namespace.Spec.ClusterRef.NamespacedName(namespace)
+		migration.Status.SourceClusterRef = &v1beta1.ObjectReference{Name: key.Name, Namespace: key.Namespace}
+	}
+
+	source, err := temporal.GetEffectiveCluster(ctx, r.Client, migration.Status.SourceClusterRef.NamespacedName(migration))
+	if err != nil {
+		migration.Status.Message = fmt.Sprintf("Can't get source cluster: %s", err)
+		return reconcile.Result{}, err
+	}
+
+	target, err := temporal.GetEffectiveCluster(ctx, r.Client, migration.Spec.TargetClusterRef.NamespacedName(migration))
+	if err != nil {
+		migration.Status.Message = fmt.Sprintf("Can't get target cluster: %s", err)
+		return reconcile.Result{}, err
+	}
+
+	switch migration.Status.Phase { //nolint:exhaustive
+	case v1beta1.NamespaceMigrationPending:
+		return r.startMigration(ctx, migration, namespace, source, target)
+	case v1beta1.NamespaceMigrationReplicating:
+		return r.reconcileNamespaceReplicated(ctx, migration, namespace, target)
+	case v1beta1.NamespaceMigrationSyncing:
+		return r.reconcileNamespaceSynced(ctx, migration, namespace, source, target)
+	case v1beta1.NamespaceMigrationFailingOver:
+		return r.reconcileNamespaceFailedOver(ctx, migration, namespace, source, target)
+	case v1beta1.NamespaceMigrationRemovingOrigin:
+		return r.reconcileOriginRemoved(ctx, migration, namespace, source, target)
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// startMigration makes the namespace global, replicated to both clusters and active on the source cluster.
+func (r *TemporalNamespaceMigrationReconciler) startMigration(ctx context.Context, migration *v1beta1.TemporalNamespaceMigration, namespace *v1beta1.TemporalNamespace, source, target *v1beta1.TemporalCluster) (ctrl.Result, error) {
+	if client.ObjectKeyFromObject(source) == client.ObjectKeyFromObject(target) {
+		return r.failMigration(migration, "The namespace already references the target cluster")
+	}
+
+	err := validateNamespaceMigration(source, target)
+	if err != nil {
+		return r.failMigration(migration, err.Error())
+	}
+
+	if !source.IsReady() || !target.IsReady() {
+		migration.Status.Message = "Waiting for the clusters to be ready"
+		return reconcile.Result{RequeueAfter: namespaceMigrationCheckInterval}, nil
+	}
+
+	namespace.Spec.IsGlobalNamespace = true
+	for _, name := range []string{source.GetName(), target.GetName()} {
+		if !slices.Contains(namespace.Spec.Clusters, name) {
+			namespace.Spec.Clusters = append(namespace.Spec.Clusters, name)
+		}
+	}
+	namespace.Spec.ActiveClusterName = source.GetName()
+
+	err = r.Update(ctx, namespace)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("can't make namespace global: %w", err)
+	}
+
+	r.setPhase(migration, v1beta1.NamespaceMigrationReplicating, fmt.Sprintf("Replicating the namespace to cluster %s", target.GetName()))
+
+	return reconcile.Result{RequeueAfter: namespaceMigrationCheckInterval}, nil
+}
+
+// reconcileNamespaceReplicated waits for the namespace to be replicated to the target cluster.
+func (r *TemporalNamespaceMigrationReconciler) reconcileNamespaceReplicated(ctx context.Context, migration *v1beta1.TemporalNamespaceMigration, namespace *v1beta1.TemporalNamespace, target *v1beta1.TemporalCluster) (ctrl.Result, error) {
+	if !namespaceApplied(namespace) {
+		migration.Status.Message = "Waiting for the namespace to be made global on the source cluster"
+		return reconcile.Result{RequeueAfter: namespaceMigrationCheckInterval}, nil
+	}
+
+	_, err := r.ClusterOperations.DescribeNamespace(ctx, target, namespace.GetName())
+	if err != nil {
+		var namespaceNotFoundError *serviceerror.NamespaceNotFound
+		if !errors.As(err, &namespaceNotFoundError) {
+			return reconcile.Result{}, fmt.Errorf("can't describe namespace on target cluster: %w", err)
+		}
+		migration.Status.Message = fmt.Sprintf("Waiting for the namespace to be replicated to cluster %s", target.GetName())
+		return reconcile.Result{RequeueAfter: namespaceMigrationCheckInterval}, nil
+	}
+
+	r.setPhase(migration, v1beta1.NamespaceMigrationSyncing, fmt.Sprintf("Waiting for the replication to cluster %s to catch up", target.GetName()))
+
+	return reconcile.Result{RequeueAfter: namespaceMigrationCheckInterval}, nil
+}
+
+// reconcileNamespaceSynced fails the namespace over to the target cluster once the replication lag is low enough.
+func (r *TemporalNamespaceMigrationReconciler) reconcileNamespaceSynced(ctx context.Context, migration *v1beta1.TemporalNamespaceMigration, namespace *v1beta1.TemporalNamespace, source, target *v1beta1.TemporalCluster) (ctrl.Result, error) {
+	var replication *v1beta1.RemoteClusterReplicationStatus
+	if source.Status.Replication != nil {
+		for i, remote := range source.Status.Replication.RemoteClusters {
+			if remote.Name == target.GetName() {
+				replication = &source.Status.Replication.RemoteClusters[i]
+			}
+		}
+	}
+
+	if replication == nil || !replication.Connected || replication.Lag == nil {
+		message := fmt.Sprintf("cluster %s to report its replication lag to cluster %s", source.GetName(), target.GetName())
+		if reason := replicationLagUnknownReason(source, replication); reason != "" {
+			message = fmt.Sprintf("%s (%s)", message, reason)
+		}
+
+		// The namespace is still active on the source cluster: failing the migration is safe.
+		timeout := migration.GetSyncTimeout()
+		if time.Since(migration.Status.PhaseStartTime.Time) > timeout {
+			return r.failMigration(migration, fmt.Sprintf("Timed out after %s waiting for %s", timeout, message))
+		}
+
+		migration.Status.Message = "Waiting for " + message
+		return reconcile.Result{RequeueAfter: namespaceMigrationCheckInterval}, nil
+	}
+
+	migration.Status.ReplicationLag = replication.Lag
+
+	maxLag := migration.GetMaxReplicationLag(source)
+	if replication.Lag.Duration > maxLag {
+		migration.Status.Message = fmt.Sprintf("Replication lag to cluster %s is %s, waiting for it to be below %s", target.GetName(), replication.Lag.Duration, maxLag)
+		return reconcile.Result{RequeueAfter: namespaceMigrationCheckInterval}, nil
+	}
+
+	namespace.Spec.ActiveClusterName = target.GetName()
+
+	err := r.Update(ctx, namespace)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("can't fail namespace over: %w", err)
+	}
+
+	r.setPhase(migration, v1beta1.NamespaceMigrationFailingOver, fmt.Sprintf("Failing the namespace over to cluster %s", target.GetName()))
+
+	return reconcile.Result{RequeueAfter: namespaceMigrationCheckInterval}, nil
+}
+
+// reconcileNamespaceFailedOver moves the namespace to the target cluster once it's active on it,
+// removing the source cluster from its clusters.
+func (r *TemporalNamespaceMigrationReconciler) reconcileNamespaceFailedOver(ctx context.Context, migration *v1beta1.TemporalNamespaceMigration, namespace *v1beta1.TemporalNamespace, source, target *v1beta1.TemporalCluster) (ctrl.Result, error) {
+	info, err := r.ClusterOperations.DescribeNamespace(ctx, target, namespace.GetName())
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("can't describe namespace on target cluster: %w", err)
+	}
+
+	if info.ActiveClusterName != target.GetName() {
+		migration.Status.Message = fmt.Sprintf("Waiting for the namespace to be active on cluster %s", target.GetName())
+		return reconcile.Result{RequeueAfter: namespaceMigrationCheckInterval}, nil
+	}
+
+	namespace.Spec.ClusterRef = migration.Spec.TargetClusterRef
+	namespace.Spec.Clusters = slices.DeleteFunc(namespace.Spec.Clusters, func(name string) bool {
+		return name == source.GetName()
+	})
+
+	err = r.Update(ctx, namespace)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("can't move namespace to target cluster: %w", err)
+	}
+
+	r.setPhase(migration, v1beta1.NamespaceMigrationRemovingOrigin, fmt.Sprintf("Removing cluster %s from the namespace clusters", source.GetName()))
+
+	return reconcile.Result{RequeueAfter: namespaceMigrationCheckInterval}, nil
+}
+
+// reconcileOriginRemoved completes the migration once the source cluster is removed from the namespace clusters.
+func (r *TemporalNamespaceMigrationReconciler) reconcileOriginRemoved(ctx context.Context, migration *v1beta1.TemporalNamespaceMigration, namespace *v1beta1.TemporalNamespace, source, target *v1beta1.TemporalCluster) (ctrl.Result, error) {
+	if !namespaceApplied(namespace) {
+		migration.Status.Message = "Waiting for the namespace to be updated on the target cluster"
+		return reconcile.Result{RequeueAfter: namespaceMigrationCheckInterval}, nil
+	}
+
+	info, err := r.ClusterOperations.DescribeNamespace(ctx, target, namespace.GetName())
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("can't describe namespace on target cluster: %w", err)
+	}
+
+	if slices.Contains(info.Clusters, source.GetName()) {
+		migration.Status.Message = fmt.Sprintf("Waiting for cluster %s to be removed from the namespace clusters", source.GetName())
+		return reconcile.Result{RequeueAfter: namespaceMigrationCheckInterval}, nil
+	}
+
+	now := metav1.Now()
+	migration.Status.CompletionTime = &now
+	r.setPhase(migration, v1beta1.NamespaceMigrationCompleted, fmt.Sprintf("Namespace migrated from cluster %s to cluster %s", source.GetName(), target.GetName()))
+
+	return reconcile.Result{}, nil
+}
+
+// setPhase moves the migration to the provided phase and records it in an event.
+func (r *TemporalNamespaceMigrationReconciler) setPhase(migration *v1beta1.TemporalNamespaceMigration, phase v1beta1.TemporalNamespaceMigrationPhase, message string) {
+	migration.SetPhase(phase, message)
+	r.Recorder.Event(migration, corev1.EventTypeNormal, string(phase), message)
+}
+
+// failMigration marks the migration as failed. Failed migrations aren't retried.
+func (r *TemporalNamespaceMigrationReconciler) failMigration(migration *v1beta1.TemporalNamespaceMigration, message string) (ctrl.Result, error) {
+	now := metav1.Now()
+	migration.Status.CompletionTime = &now
+	migration.SetPhase(v1beta1.NamespaceMigrationFailed, message)
+	r.Recorder.Event(migration, corev1.EventTypeWarning, "MigrationFailed", message)
+	return reconcile.Result{}, nil
+}
+
+// validateNamespaceMigration checks that namespaces can be migrated between the provided clusters.
+func validateNamespaceMigration(source, target *v1beta1.TemporalCluster) error {
+	for _, cluster := range []*v1beta1.TemporalCluster{source, target} {
+		if !cluster.Spec.Replication.IsEnabled() {
+			return fmt.Errorf("replication isn't enabled on cluster %s", cluster.GetName())
+		}
+		if cluster.Spec.Replication.IsStandby() {
+			return fmt.Errorf("cluster %s is a standby cluster: global namespaces can only be updated through active clusters", cluster.GetName())
+		}
+	}
+
+	for _, pair := range [][2]*v1beta1.TemporalCluster{{source, target}, {target, source}} {
+		listed := slices.ContainsFunc(pair[0].Spec.Replication.RemoteClusters, func(remote v1beta1.RemoteClusterSpec) bool {
+			return remote.Name == pair[1].GetName()
+		})
+		if !listed {
+			return fmt.Errorf("cluster %s doesn't list cluster %s in its remote clusters", pair[0].GetName(), pair[1].GetName())
+		}
+	}
+
+	return nil
+}
+
+// replicationLagUnknownReason explains why the source cluster doesn't report its replication lag to a remote cluster.
+func replicationLagUnknownReason(source *v1beta1.TemporalCluster, replication *v1beta1.RemoteClusterReplicationStatus) string {
+	if replication != nil && replication.Message != "" {
+		return replication.Message
+	}
+
+	condition := apimeta.FindStatusCondition(source.Status.Conditions, v1beta1.ReplicationHealthyCondition)
+	if condition != nil && condition.Status == metav1.ConditionUnknown {
+		return condition.Message
+	}
+
+	return ""
+}
+
+// namespaceApplied returns true if the namespace controller applied the current namespace spec.
+func namespaceApplied(namespace *v1beta1.TemporalNamespace) bool {
+	for _, condition := range namespace.Status.Conditions {
+		if condition.Type == v1beta1.ReadyCondition {
+			return condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == namespace.GetGeneration()
+		}
+	}
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemporalNamespaceMigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.TemporalNamespaceMigration{}).
+		Complete(r)
+}
@@ -0,0 +1,340 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporalclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// fakeClusterOperations serves the namespaces registered on each cluster, by cluster name.
+type fakeClusterOperations struct {
+	temporalclient.ClusterOperations
+
+	namespaces map[string]*temporalclient.NamespaceInfo
+}
+
+func (f *fakeClusterOperations) DescribeNamespace(_ context.Context, cluster *v1beta1.TemporalCluster, namespace string) (*temporalclient.NamespaceInfo, error) {
+	info, ok := f.namespaces[cluster.GetName()]
+	if !ok {
+		return nil, serviceerror.NewNamespaceNotFound(namespace)
+	}
+	return info, nil
+}
+
+func migrationCluster(name, remote string) *v1beta1.TemporalCluster {
+	return &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Replication: &v1beta1.ReplicationSpec{
+				Enabled:        true,
+				RemoteClusters: []v1beta1.RemoteClusterSpec{{Name: remote}},
+			},
+		},
+		Status: v1beta1.TemporalClusterStatus{
+			Conditions: []metav1.Condition{
+				{Type: v1beta1.ReadyCondition, Status: metav1.ConditionTrue, Reason: v1beta1.ServicesReadyReason},
+			},
+		},
+	}
+}
+
+func TestTemporalNamespaceMigrationReconcile(t *testing.T) {
+	tests := map[string]struct {
+		phase v1beta1.TemporalNamespaceMigrationPhase
+		// phaseAge is the time elapsed since the migration entered its phase.
+		phaseAge         time.Duration
+		target           string
+		namespaceApplied bool
+		mutate           func(source, target *v1beta1.TemporalCluster, namespace *v1beta1.TemporalNamespace)
+		targetNamespace  *temporalclient.NamespaceInfo
+		expectedPhase    v1beta1.TemporalNamespaceMigrationPhase
+		expectedMessage  string
+		expectedRequeue  bool
+		checkNamespace   func(t *testing.T, namespace *v1beta1.TemporalNamespace)
+	}{
+		"new migration waits for the clusters to be ready": {
+			mutate: func(_, target *v1beta1.TemporalCluster, _ *v1beta1.TemporalNamespace) {
+				target.Status.Conditions = nil
+			},
+			expectedPhase:   v1beta1.NamespaceMigrationPending,
+			expectedMessage: "Waiting for the clusters to be ready",
+			expectedRequeue: true,
+		},
+		"pending to replicating": {
+			phase:           v1beta1.NamespaceMigrationPending,
+			expectedPhase:   v1beta1.NamespaceMigrationReplicating,
+			expectedMessage: "Replicating the namespace to cluster prod-dr",
+			expectedRequeue: true,
+			checkNamespace: func(t *testing.T, namespace *v1beta1.TemporalNamespace) {
+				assert.True(t, namespace.Spec.IsGlobalNamespace)
+				assert.Equal(t, []string{"prod", "prod-dr"}, namespace.Spec.Clusters)
+				assert.Equal(t, "prod", namespace.Spec.ActiveClusterName)
+			},
+		},
+		"pending fails when the namespace already references the target cluster": {
+			phase:           v1beta1.NamespaceMigrationPending,
+			target:          "prod",
+			expectedPhase:   v1beta1.NamespaceMigrationFailed,
+			expectedMessage: "The namespace already references the target cluster",
+			checkNamespace: func(t *testing.T, namespace *v1beta1.TemporalNamespace) {
+				assert.False(t, namespace.Spec.IsGlobalNamespace)
+			},
+		},
+		"pending fails when the clusters don't replicate with each other": {
+			phase: v1beta1.NamespaceMigrationPending,
+			mutate: func(_, target *v1beta1.TemporalCluster, _ *v1beta1.TemporalNamespace) {
+				target.Spec.Replication.RemoteClusters = nil
+			},
+			expectedPhase:   v1beta1.NamespaceMigrationFailed,
+			expectedMessage: "cluster prod-dr doesn't list cluster prod in its remote clusters",
+			checkNamespace: func(t *testing.T, namespace *v1beta1.TemporalNamespace) {
+				assert.False(t, namespace.Spec.IsGlobalNamespace)
+			},
+		},
+		"pending fails when the target cluster is a standby cluster": {
+			phase: v1beta1.NamespaceMigrationPending,
+			mutate: func(_, target *v1beta1.TemporalCluster, _ *v1beta1.TemporalNamespace) {
+				target.Spec.Replication.Role = v1beta1.StandbyReplicationRole
+			},
+			expectedPhase:   v1beta1.NamespaceMigrationFailed,
+			expectedMessage: "cluster prod-dr is a standby cluster",
+		},
+		"replicating waits for the namespace to be made global": {
+			phase:           v1beta1.NamespaceMigrationReplicating,
+			expectedPhase:   v1beta1.NamespaceMigrationReplicating,
+			expectedMessage: "Waiting for the namespace to be made global on the source cluster",
+			expectedRequeue: true,
+		},
+		"replicating waits for the namespace to be replicated": {
+			phase:            v1beta1.NamespaceMigrationReplicating,
+			namespaceApplied: true,
+			expectedPhase:    v1beta1.NamespaceMigrationReplicating,
+			expectedMessage:  "Waiting for the namespace to be replicated to cluster prod-dr",
+			expectedRequeue:  true,
+		},
+		"replicating to syncing": {
+			phase:            v1beta1.NamespaceMigrationReplicating,
+			namespaceApplied: true,
+			targetNamespace:  &temporalclient.NamespaceInfo{IsGlobalNamespace: true, ActiveClusterName: "prod", Clusters: []string{"prod", "prod-dr"}},
+			expectedPhase:    v1beta1.NamespaceMigrationSyncing,
+			expectedMessage:  "Waiting for the replication to cluster prod-dr to catch up",
+			expectedRequeue:  true,
+		},
+		"syncing waits for the replication lag": {
+			phase:    v1beta1.NamespaceMigrationSyncing,
+			phaseAge: time.Minute,
+			mutate: func(source, _ *v1beta1.TemporalCluster, _ *v1beta1.TemporalNamespace) {
+				v1beta1.SetTemporalClusterReplicationHealthy(source, metav1.ConditionUnknown, v1beta1.ReplicationStatusUnknownReason, "can't get history hosts")
+			},
+			expectedPhase:   v1beta1.NamespaceMigrationSyncing,
+			expectedMessage: "Waiting for cluster prod to report its replication lag to cluster prod-dr (can't get history hosts)",
+			expectedRequeue: true,
+		},
+		"syncing fails when the replication lag isn't reported in time": {
+			phase:    v1beta1.NamespaceMigrationSyncing,
+			phaseAge: time.Hour,
+			mutate: func(source, _ *v1beta1.TemporalCluster, _ *v1beta1.TemporalNamespace) {
+				source.Status.Replication = &v1beta1.ReplicationStatus{
+					RemoteClusters: []v1beta1.RemoteClusterReplicationStatus{
+						{Name: "prod-dr", Connected: false, Message: "connection refused"},
+					},
+				}
+			},
+			expectedPhase:   v1beta1.NamespaceMigrationFailed,
+			expectedMessage: "Timed out after 15m0s waiting for cluster prod to report its replication lag to cluster prod-dr (connection refused)",
+			checkNamespace: func(t *testing.T, namespace *v1beta1.TemporalNamespace) {
+				assert.Equal(t, "prod", namespace.Spec.ActiveClusterName)
+			},
+		},
+		"syncing waits for the replication lag to be low enough": {
+			phase: v1beta1.NamespaceMigrationSyncing,
+			mutate: func(source, _ *v1beta1.TemporalCluster, _ *v1beta1.TemporalNamespace) {
+				source.Status.Replication = &v1beta1.ReplicationStatus{
+					RemoteClusters: []v1beta1.RemoteClusterReplicationStatus{
+						{Name: "prod-dr", Connected: true, Lag: &metav1.Duration{Duration: 10 * time.Minute}},
+					},
+				}
+			},
+			expectedPhase:   v1beta1.NamespaceMigrationSyncing,
+			expectedMessage: "Replication lag to cluster prod-dr is 10m0s, waiting for it to be below 5m0s",
+			expectedRequeue: true,
+		},
+		"syncing to failing over": {
+			phase: v1beta1.NamespaceMigrationSyncing,
+			mutate: func(source, _ *v1beta1.TemporalCluster, _ *v1beta1.TemporalNamespace) {
+				source.Status.Replication = &v1beta1.ReplicationStatus{
+					RemoteClusters: []v1beta1.RemoteClusterReplicationStatus{
+						{Name: "prod-dr", Connected: true, Lag: &metav1.Duration{Duration: time.Second}},
+					},
+				}
+			},
+			expectedPhase:   v1beta1.NamespaceMigrationFailingOver,
+			expectedMessage: "Failing the namespace over to cluster prod-dr",
+			expectedRequeue: true,
+			checkNamespace: func(t *testing.T, namespace *v1beta1.TemporalNamespace) {
+				assert.Equal(t, "prod-dr", namespace.Spec.ActiveClusterName)
+			},
+		},
+		"failing over waits for the namespace to be active on the target cluster": {
+			phase:           v1beta1.NamespaceMigrationFailingOver,
+			targetNamespace: &temporalclient.NamespaceInfo{IsGlobalNamespace: true, ActiveClusterName: "prod", Clusters: []string{"prod", "prod-dr"}},
+			expectedPhase:   v1beta1.NamespaceMigrationFailingOver,
+			expectedMessage: "Waiting for the namespace to be active on cluster prod-dr",
+			expectedRequeue: true,
+		},
+		"failing over to removing origin": {
+			phase:           v1beta1.NamespaceMigrationFailingOver,
+			targetNamespace: &temporalclient.NamespaceInfo{IsGlobalNamespace: true, ActiveClusterName: "prod-dr", Clusters: []string{"prod", "prod-dr"}},
+			expectedPhase:   v1beta1.NamespaceMigrationRemovingOrigin,
+			expectedMessage: "Removing cluster prod from the namespace clusters",
+			expectedRequeue: true,
+			checkNamespace: func(t *testing.T, namespace *v1beta1.TemporalNamespace) {
+				assert.Equal(t, "prod-dr", namespace.Spec.ClusterRef.Name)
+				assert.Equal(t, []string{"prod-dr"}, namespace.Spec.Clusters)
+			},
+		},
+		"removing origin waits for the target cluster to report the change": {
+			phase:            v1beta1.NamespaceMigrationRemovingOrigin,
+			namespaceApplied: true,
+			targetNamespace:  &temporalclient.NamespaceInfo{IsGlobalNamespace: true, ActiveClusterName: "prod-dr", Clusters: []string{"prod", "prod-dr"}},
+			expectedPhase:    v1beta1.NamespaceMigrationRemovingOrigin,
+			expectedMessage:  "Waiting for cluster prod to be removed from the namespace clusters",
+			expectedRequeue:  true,
+		},
+		"removing origin to completed": {
+			phase:            v1beta1.NamespaceMigrationRemovingOrigin,
+			namespaceApplied: true,
+			targetNamespace:  &temporalclient.NamespaceInfo{IsGlobalNamespace: true, ActiveClusterName: "prod-dr", Clusters: []string{"prod-dr"}},
+			expectedPhase:    v1beta1.NamespaceMigrationCompleted,
+			expectedMessage:  "Namespace migrated from cluster prod to cluster prod-dr",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			ctx := context.Background()
+
+			source := migrationCluster("prod", "prod-dr")
+			target := migrationCluster("prod-dr", "prod")
+
+			namespace := &v1beta1.TemporalNamespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "default", Generation: 1},
+				Spec: v1beta1.TemporalNamespaceSpec{
+					ClusterRef: v1beta1.ObjectReference{Name: "prod"},
+				},
+			}
+			if test.phase != "" && test.phase != v1beta1.NamespaceMigrationPending {
+				namespace.Spec.IsGlobalNamespace = true
+				namespace.Spec.Clusters = []string{"prod", "prod-dr"}
+				namespace.Spec.ActiveClusterName = "prod"
+			}
+			if test.namespaceApplied {
+				namespace.Status.Conditions = []metav1.Condition{
+					{Type: v1beta1.ReadyCondition, Status: metav1.ConditionTrue, ObservedGeneration: 1},
+				}
+			}
+
+			if test.mutate != nil {
+				test.mutate(source, target, namespace)
+			}
+
+			targetName := test.target
+			if targetName == "" {
+				targetName = "prod-dr"
+			}
+
+			migration := &v1beta1.TemporalNamespaceMigration{
+				ObjectMeta: metav1.ObjectMeta{Name: "payments-migration", Namespace: "default"},
+				Spec: v1beta1.TemporalNamespaceMigrationSpec{
+					NamespaceRef:     corev1.LocalObjectReference{Name: "payments"},
+					TargetClusterRef: v1beta1.ObjectReference{Name: targetName},
+				},
+			}
+			if test.phase != "" {
+				migration.Status.Phase = test.phase
+				migration.Status.PhaseStartTime = &metav1.Time{Time: time.Now().Add(-test.phaseAge)}
+				migration.Status.SourceClusterRef = &v1beta1.ObjectReference{Name: "prod", Namespace: "default"}
+			}
+
+			operations := &fakeClusterOperations{namespaces: map[string]*temporalclient.NamespaceInfo{}}
+			if test.targetNamespace != nil {
+				operations.namespaces[targetName] = test.targetNamespace
+			}
+
+			r := &TemporalNamespaceMigrationReconciler{
+				Base:              newFakeBase(tt, source, target, namespace, migration),
+				ClusterOperations: operations,
+			}
+
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(migration)})
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expectedRequeue, result.RequeueAfter > 0)
+
+			updated := &v1beta1.TemporalNamespaceMigration{}
+			require.NoError(tt, r.Get(ctx, client.ObjectKeyFromObject(migration), updated))
+			assert.Equal(tt, test.expectedPhase, updated.Status.Phase)
+			assert.Contains(tt, updated.Status.Message, test.expectedMessage)
+			assert.Equal(tt, "prod", updated.Status.SourceClusterRef.Name)
+			assert.NotNil(tt, updated.Status.PhaseStartTime)
+			assert.Equal(tt, updated.IsFinished(), updated.Status.CompletionTime != nil)
+
+			if test.checkNamespace != nil {
+				updatedNamespace := &v1beta1.TemporalNamespace{}
+				require.NoError(tt, r.Get(ctx, client.ObjectKeyFromObject(namespace), updatedNamespace))
+				test.checkNamespace(tt, updatedNamespace)
+			}
+		})
+	}
+}
+
+func TestTemporalNamespaceMigrationReconcileMissingNamespace(t *testing.T) {
+	migration := &v1beta1.TemporalNamespaceMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments-migration", Namespace: "default"},
+		Spec: v1beta1.TemporalNamespaceMigrationSpec{
+			NamespaceRef:     corev1.LocalObjectReference{Name: "payments"},
+			TargetClusterRef: v1beta1.ObjectReference{Name: "prod-dr"},
+		},
+	}
+
+	r := &TemporalNamespaceMigrationReconciler{
+		Base:              newFakeBase(t, migration),
+		ClusterOperations: &fakeClusterOperations{},
+	}
+
+	result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(migration)})
+	require.NoError(t, err)
+	assert.Equal(t, namespaceMigrationCheckInterval, result.RequeueAfter)
+
+	updated := &v1beta1.TemporalNamespaceMigration{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(migration), updated))
+	assert.Equal(t, v1beta1.NamespaceMigrationPending, updated.Status.Phase)
+	assert.Equal(t, "Waiting for the TemporalNamespace payments to be created", updated.Status.Message)
+	assert.NotNil(t, updated.Status.StartTime)
+}
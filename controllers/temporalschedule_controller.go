@@ -25,7 +25,7 @@ import (
 
 	"github.com/alexandrevilain/controller-tools/pkg/patch"
 	"go.temporal.io/api/serviceerror"
-	temporalclient "go.temporal.io/sdk/client"
+	sdkclient "go.temporal.io/sdk/client"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -41,13 +41,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/logging"
 	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporalclient"
 )
 
 // TemporalScheduleReconciler reconciles a Schedule object.
 type TemporalScheduleReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme        *runtime.Scheme
+	ClientManager *temporalclient.Manager
 }
 
 //+kubebuilder:rbac:groups=temporal.io,resources=temporalschedules,verbs=get;list;watch;create;update;patch;delete
@@ -98,14 +101,15 @@ func (r *TemporalScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return r.handleError(ctx, schedule, v1beta1.ReconcileErrorReason, "Namespace lookup", err)
 	}
 
+	ctx, logger = logging.WithCluster(ctx, namespace.Spec.ClusterRef.Name, schedule)
+
 	if !namespace.IsReady() {
 		logger.Info("Skipping schedule reconciliation until referenced namespace is ready")
 
 		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
 	}
 
-	cluster := &v1beta1.TemporalCluster{}
-	err = r.Get(ctx, namespace.Spec.ClusterRef.NamespacedName(schedule), cluster)
+	cluster, err := temporal.GetEffectiveCluster(ctx, r.Client, namespace.Spec.ClusterRef.NamespacedName(schedule))
 	if err != nil {
 		if apierrors.IsNotFound(err) && !schedule.ObjectMeta.DeletionTimestamp.IsZero() {
 			logger.Info("Cluster not found deleting schedule", "cluster", namespace.Spec.ClusterRef.NamespacedName(schedule))
@@ -125,15 +129,11 @@ func (r *TemporalScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
 	}
 
-	clientOpts := func(opt *temporalclient.Options) {
-		opt.Namespace = schedule.Spec.NamespaceRef.Name
-	}
-	client, err := temporal.GetClusterClient(ctx, r.Client, cluster, clientOpts)
+	client, err := r.ClientManager.Client(ctx, cluster, schedule.Spec.NamespaceRef.Name)
 	if err != nil {
 		err = fmt.Errorf("can't create cluster client: %w", err)
 		return r.handleError(ctx, schedule, v1beta1.ReconcileErrorReason, "Creating cluster client", err)
 	}
-	defer client.Close()
 
 	// Check if the resource has been marked for deletion
 	if !schedule.ObjectMeta.DeletionTimestamp.IsZero() {
@@ -149,7 +149,14 @@ func (r *TemporalScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	// Ensure the schedule have a deletion marker if the AllowDeletion is set to true.
 	r.ensureFinalizer(schedule)
 
-	request, err := temporal.ScheduleToCreateScheduleRequest(schedule)
+	// Schedules not setting their own time zone are interpreted in the cluster time zone.
+	desired := schedule
+	if schedule.Spec.Schedule.Spec.TimeZoneName == "" && cluster.Spec.TimeZone != "" {
+		desired = schedule.DeepCopy()
+		desired.Spec.Schedule.Spec.TimeZoneName = cluster.Spec.TimeZone
+	}
+
+	request, err := temporal.ScheduleToCreateScheduleRequest(desired)
 	if err != nil {
 		return r.handleError(ctx, schedule, v1beta1.ReconcileErrorReason, "Constructing create schedule request", err)
 	}
@@ -163,7 +170,7 @@ func (r *TemporalScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			return r.handleError(ctx, schedule, v1beta1.ReconcileErrorReason, "Creating schedule", err)
 		}
 
-		request, err := temporal.ScheduleToUpdateScheduleRequest(schedule)
+		request, err := temporal.ScheduleToUpdateScheduleRequest(desired)
 		if err != nil {
 			return r.handleError(ctx, schedule, v1beta1.ReconcileErrorReason, "Constructing update schedule request", err)
 		}
@@ -192,7 +199,7 @@ func (r *TemporalScheduleReconciler) ensureFinalizer(schedule *v1beta1.TemporalS
 	}
 }
 
-func (r *TemporalScheduleReconciler) ensureScheduleDeleted(ctx context.Context, schedule *v1beta1.TemporalSchedule, client *temporalclient.Client) error {
+func (r *TemporalScheduleReconciler) ensureScheduleDeleted(ctx context.Context, schedule *v1beta1.TemporalSchedule, client *sdkclient.Client) error {
 	logger := log.FromContext(ctx)
 
 	if !controllerutil.ContainsFinalizer(schedule, deletionFinalizer) {
@@ -0,0 +1,213 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/patch"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/logging"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"go.temporal.io/server/common/primitives"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// serviceScalerProgressInterval is the interval at which a scaler status is refreshed while the service is scaling.
+const serviceScalerProgressInterval = 10 * time.Second
+
+// TemporalServiceScalerReconciler reconciles a TemporalServiceScaler object.
+type TemporalServiceScalerReconciler struct {
+	Base
+}
+
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalservicescalers,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalservicescalers/status,verbs=get;update;patch
+
+// Reconcile writes the scaler replicas to the referenced cluster spec and reports the service pods.
+func (r *TemporalServiceScalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	scaler := &v1beta1.TemporalServiceScaler{}
+	err := r.Get(ctx, req.NamespacedName, scaler)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	ctx, logger := logging.WithCluster(ctx, scaler.Spec.ClusterRef.Name, scaler)
+
+	logger.Info("Starting reconciliation")
+
+	// Check if the resource has been marked for deletion
+	if !scaler.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(scaler, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the TemporalServiceScaler object and status after each reconciliation.
+		err := patchHelper.Patch(ctx, scaler)
+		if err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	cluster := &v1beta1.TemporalCluster{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: scaler.GetNamespace(), Name: scaler.Spec.ClusterRef.Name}, cluster)
+	if err != nil {
+		v1beta1.SetTemporalServiceScalerReady(scaler, metav1.ConditionFalse, v1beta1.ServiceScalerReconcileErrorReason, fmt.Sprintf("Can't get referenced cluster: %s", err))
+		return reconcile.Result{}, err
+	}
+
+	serviceName := primitives.ServiceName(scaler.Spec.Service)
+	if serviceName == primitives.InternalFrontendService && (cluster.Spec.Services == nil || !cluster.Spec.Services.InternalFrontend.IsEnabled()) {
+		err := fmt.Errorf("the %s service is not enabled on the cluster", serviceName)
+		v1beta1.SetTemporalServiceScalerReady(scaler, metav1.ConditionFalse, v1beta1.ServiceScalerReconcileErrorReason, err.Error())
+		return reconcile.Result{}, err
+	}
+
+	err = r.reconcileServiceReplicas(ctx, scaler, cluster, serviceName)
+	if err != nil {
+		v1beta1.SetTemporalServiceScalerReady(scaler, metav1.ConditionFalse, v1beta1.ServiceScalerReconcileErrorReason, err.Error())
+		return reconcile.Result{}, err
+	}
+
+	scaler.Status.Selector = labels.SelectorFromSet(metadata.LabelsSelector(cluster, string(serviceName))).String()
+
+	deployment := &appsv1.Deployment{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: cluster.GetNamespace(), Name: cluster.ChildResourceName(string(serviceName))}, deployment)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+
+	scaler.Status.Replicas = deployment.Status.Replicas
+	scaler.Status.ReadyReplicas = deployment.Status.ReadyReplicas
+
+	desired := *scaler.Spec.Replicas
+	if deployment.Status.ObservedGeneration >= deployment.GetGeneration() &&
+		deployment.Status.Replicas == desired &&
+		deployment.Status.ReadyReplicas == desired {
+		v1beta1.SetTemporalServiceScalerReady(scaler, metav1.ConditionTrue, v1beta1.ServiceScalerReadyReason, "")
+		return reconcile.Result{}, nil
+	}
+
+	v1beta1.SetTemporalServiceScalerReady(scaler, metav1.ConditionFalse, v1beta1.ServiceScalerProgressingReason,
+		fmt.Sprintf("%d/%d %s pods ready", deployment.Status.ReadyReplicas, desired, serviceName))
+
+	return reconcile.Result{RequeueAfter: serviceScalerProgressInterval}, nil
+}
+
+// reconcileServiceReplicas writes the scaler replicas to the cluster spec.
+// If the scaler replicas are not set, they are initialized from the cluster spec, so
+// autoscalers start from the current service replicas.
+func (r *TemporalServiceScalerReconciler) reconcileServiceReplicas(ctx context.Context, scaler *v1beta1.TemporalServiceScaler, cluster *v1beta1.TemporalCluster, serviceName primitives.ServiceName) error {
+	current := int32(1)
+	if cluster.Spec.Services != nil {
+		spec, err := cluster.Spec.Services.GetServiceSpec(serviceName)
+		if err != nil {
+			return err
+		}
+		if spec != nil && spec.Replicas != nil {
+			current = *spec.Replicas
+		}
+	}
+
+	if scaler.Spec.Replicas == nil {
+		scaler.Spec.Replicas = &current
+		return nil
+	}
+
+	desired := *scaler.Spec.Replicas
+	if desired == current {
+		return nil
+	}
+
+	clusterPatch := client.MergeFrom(cluster.DeepCopy())
+	if cluster.Spec.Services == nil {
+		cluster.Spec.Services = &v1beta1.ServicesSpec{}
+	}
+	err := cluster.Spec.Services.SetServiceReplicas(serviceName, desired)
+	if err != nil {
+		return err
+	}
+
+	err = r.Patch(ctx, cluster, clusterPatch)
+	if err != nil {
+		return fmt.Errorf("can't write %s replicas to the cluster: %w", serviceName, err)
+	}
+
+	r.Recorder.Eventf(scaler, corev1.EventTypeNormal, "Scaled", "Scaled %s from %d to %d replicas", serviceName, current, desired)
+
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemporalServiceScalerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1beta1.TemporalServiceScaler{}, clusterRefField, func(rawObj client.Object) []string {
+		scaler := rawObj.(*v1beta1.TemporalServiceScaler)
+		if scaler.Spec.ClusterRef.Name == "" {
+			return nil
+		}
+		return []string{scaler.Spec.ClusterRef.Name}
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.TemporalServiceScaler{}).
+		// Replicas can also be changed by editing the cluster spec.
+		Watches(
+			&v1beta1.TemporalCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToScalersMapfunc),
+		).
+		Complete(r)
+}
+
+func (r *TemporalServiceScalerReconciler) clusterToScalersMapfunc(ctx context.Context, o client.Object) []reconcile.Request {
+	scalers := &v1beta1.TemporalServiceScalerList{}
+	err := r.List(ctx, scalers, client.InNamespace(o.GetNamespace()), client.MatchingFields{clusterRefField: o.GetName()})
+	if err != nil {
+		return nil
+	}
+
+	result := make([]reconcile.Request, 0, len(scalers.Items))
+	for _, scaler := range scalers.Items {
+		result = append(result, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&scaler),
+		})
+	}
+
+	return result
+}
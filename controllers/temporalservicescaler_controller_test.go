@@ -0,0 +1,166 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func testScaledCluster() *v1beta1.TemporalCluster {
+	return &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "temporal"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Services: &v1beta1.ServicesSpec{
+				History: &v1beta1.ServiceSpec{Replicas: ptr.To[int32](3)},
+			},
+		},
+	}
+}
+
+func testServiceScaler(service string, replicas *int32) *v1beta1.TemporalServiceScaler {
+	return &v1beta1.TemporalServiceScaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-history", Namespace: "temporal"},
+		Spec: v1beta1.TemporalServiceScalerSpec{
+			ClusterRef: corev1.LocalObjectReference{Name: "prod"},
+			Service:    service,
+			Replicas:   replicas,
+		},
+	}
+}
+
+func reconcileServiceScaler(t *testing.T, r *TemporalServiceScalerReconciler) (ctrl.Result, *v1beta1.TemporalServiceScaler, error) {
+	t.Helper()
+
+	key := client.ObjectKey{Namespace: "temporal", Name: "prod-history"}
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+
+	scaler := &v1beta1.TemporalServiceScaler{}
+	require.NoError(t, r.Get(context.Background(), key, scaler))
+
+	return result, scaler, err
+}
+
+func TestTemporalServiceScalerReconcileErrors(t *testing.T) {
+	tests := map[string]struct {
+		objects     []client.Object
+		expectedErr string
+	}{
+		"missing cluster": {
+			objects:     []client.Object{testServiceScaler("history", nil)},
+			expectedErr: "not found",
+		},
+		"internal frontend not enabled": {
+			objects:     []client.Object{testScaledCluster(), testServiceScaler("internal-frontend", nil)},
+			expectedErr: "the internal-frontend service is not enabled on the cluster",
+		},
+		"cluster without services": {
+			objects: []client.Object{
+				&v1beta1.TemporalCluster{ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "temporal"}},
+				testServiceScaler("internal-frontend", nil),
+			},
+			expectedErr: "the internal-frontend service is not enabled on the cluster",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			r := &TemporalServiceScalerReconciler{Base: newFakeBase(tt, test.objects...)}
+
+			_, scaler, err := reconcileServiceScaler(tt, r)
+			assert.ErrorContains(tt, err, test.expectedErr)
+
+			condition := apimeta.FindStatusCondition(scaler.Status.Conditions, v1beta1.ReadyCondition)
+			require.NotNil(tt, condition)
+			assert.Equal(tt, metav1.ConditionFalse, condition.Status)
+			assert.Equal(tt, v1beta1.ServiceScalerReconcileErrorReason, condition.Reason)
+		})
+	}
+}
+
+func TestTemporalServiceScalerReconcile(t *testing.T) {
+	r := &TemporalServiceScalerReconciler{Base: newFakeBase(t, testScaledCluster(), testServiceScaler("history", nil))}
+	ctx := context.Background()
+	clusterKey := client.ObjectKey{Namespace: "temporal", Name: "prod"}
+
+	// The scaler replicas are initialized from the cluster spec.
+	result, scaler, err := reconcileServiceScaler(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, serviceScalerProgressInterval, result.RequeueAfter)
+	assert.Equal(t, ptr.To[int32](3), scaler.Spec.Replicas)
+	assert.NotEmpty(t, scaler.Status.Selector)
+
+	condition := apimeta.FindStatusCondition(scaler.Status.Conditions, v1beta1.ReadyCondition)
+	require.NotNil(t, condition)
+	assert.Equal(t, v1beta1.ServiceScalerProgressingReason, condition.Reason)
+	assert.Equal(t, "0/3 history pods ready", condition.Message)
+
+	// The scaler is scaled, its replicas are written to the cluster spec.
+	scaler.Spec.Replicas = ptr.To[int32](5)
+	require.NoError(t, r.Update(ctx, scaler))
+
+	_, _, err = reconcileServiceScaler(t, r)
+	require.NoError(t, err)
+
+	cluster := &v1beta1.TemporalCluster{}
+	require.NoError(t, r.Get(ctx, clusterKey, cluster))
+	assert.Equal(t, ptr.To[int32](5), cluster.Spec.Services.History.Replicas)
+	events := r.Recorder.(*record.FakeRecorder).Events
+	require.Len(t, events, 1)
+	assert.Equal(t, "Normal Scaled Scaled history from 3 to 5 replicas", <-events)
+
+	// The history pods are scaled.
+	require.NoError(t, r.Create(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-history", Namespace: "temporal"},
+		Status: appsv1.DeploymentStatus{
+			Replicas:      5,
+			ReadyReplicas: 5,
+		},
+	}))
+
+	result, scaler, err = reconcileServiceScaler(t, r)
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+	assert.Equal(t, int32(5), scaler.Status.Replicas)
+	assert.Equal(t, int32(5), scaler.Status.ReadyReplicas)
+	assert.True(t, apimeta.IsStatusConditionTrue(scaler.Status.Conditions, v1beta1.ReadyCondition))
+
+	// The scaler owns the service replicas: changes made to the cluster spec are reverted.
+	cluster.Spec.Services.History.Replicas = ptr.To[int32](8)
+	require.NoError(t, r.Update(ctx, cluster))
+
+	_, scaler, err = reconcileServiceScaler(t, r)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, clusterKey, cluster))
+	assert.Equal(t, ptr.To[int32](5), cluster.Spec.Services.History.Replicas)
+	assert.Equal(t, ptr.To[int32](5), scaler.Spec.Replicas)
+	require.Len(t, events, 1)
+	assert.Equal(t, "Normal Scaled Scaled history from 8 to 5 replicas", <-events)
+}
@@ -0,0 +1,245 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/patch"
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/discovery"
+	"github.com/alexandrevilain/temporal-operator/internal/logging"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/workerdeployment"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const clusterClientRefNameField = "spec.clusterClientRef.name"
+
+// TemporalWorkerDeploymentReconciler reconciles a TemporalWorkerDeployment object.
+type TemporalWorkerDeploymentReconciler struct {
+	Base
+
+	AvailableAPIs *discovery.AvailableAPIs
+}
+
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalworkerdeployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalworkerdeployments/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalworkerdeployments/finalizers,verbs=update
+//+kubebuilder:rbac:groups=keda.sh,resources=scaledobjects;triggerauthentications,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *TemporalWorkerDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	workerDeployment := &v1beta1.TemporalWorkerDeployment{}
+	err := r.Get(ctx, req.NamespacedName, workerDeployment)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	ctx, logger := logging.WithCluster(ctx, workerDeployment.Spec.ClusterRef.Name, workerDeployment)
+
+	logger.Info("Starting reconciliation")
+
+	// Check if the resource has been marked for deletion
+	if !workerDeployment.ObjectMeta.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(workerDeployment, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the TemporalWorkerDeployment object and status after each reconciliation.
+		err := patchHelper.Patch(ctx, workerDeployment)
+		if err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	cluster, err := temporal.GetEffectiveCluster(ctx, r.Client, workerDeployment.Spec.ClusterRef.NamespacedName(workerDeployment))
+	if err != nil {
+		v1beta1.SetTemporalWorkerDeploymentReady(workerDeployment, metav1.ConditionFalse, v1beta1.WorkerDeploymentReconcileErrorReason, fmt.Sprintf("Can't get referenced cluster: %s", err))
+		return reconcile.Result{}, err
+	}
+
+	clusterClient, err := r.getClusterClient(ctx, workerDeployment, cluster)
+	if err != nil {
+		v1beta1.SetTemporalWorkerDeploymentReady(workerDeployment, metav1.ConditionFalse, v1beta1.WorkerDeploymentReconcileErrorReason, err.Error())
+		return reconcile.Result{}, err
+	}
+
+	if clusterClient != nil && (clusterClient.Status.SecretRef == nil || clusterClient.Status.SecretRef.Name == "") {
+		logger.Info("Waiting for cluster client secret, requeuing")
+		v1beta1.SetTemporalWorkerDeploymentReady(workerDeployment, metav1.ConditionFalse, v1beta1.WorkerDeploymentNotReadyReason, "Waiting for the cluster client secret")
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	if workerDeployment.Spec.Autoscaling != nil && !r.AvailableAPIs.KEDA {
+		err := errors.New("autoscaling requires KEDA to be installed in the kubernetes cluster")
+		v1beta1.SetTemporalWorkerDeploymentReady(workerDeployment, metav1.ConditionFalse, v1beta1.WorkerDeploymentReconcileErrorReason, err.Error())
+		return reconcile.Result{}, err
+	}
+
+	builders := []resource.Builder{
+		workerdeployment.NewDeploymentBuilder(workerDeployment, cluster, clusterClient, r.Scheme),
+	}
+
+	objects, err := r.Reconciler.ReconcileBuilders(ctx, workerDeployment, builders)
+	if err != nil {
+		v1beta1.SetTemporalWorkerDeploymentReady(workerDeployment, metav1.ConditionFalse, v1beta1.WorkerDeploymentReconcileErrorReason, err.Error())
+		return reconcile.Result{}, err
+	}
+
+	// KEDA objects are unstructured, as the KEDA types aren't registered in the scheme.
+	if r.AvailableAPIs.KEDA {
+		err := r.reconcileUnstructuredBuilders(ctx, []resource.Builder{
+			workerdeployment.NewTriggerAuthenticationBuilder(workerDeployment, clusterClient, r.Scheme),
+			workerdeployment.NewScaledObjectBuilder(workerDeployment, cluster, clusterClient, r.Scheme),
+		})
+		if err != nil {
+			v1beta1.SetTemporalWorkerDeploymentReady(workerDeployment, metav1.ConditionFalse, v1beta1.WorkerDeploymentReconcileErrorReason, err.Error())
+			return reconcile.Result{}, err
+		}
+	}
+
+	for _, object := range objects {
+		deployment, ok := object.(*appsv1.Deployment)
+		if !ok {
+			continue
+		}
+		reportWorkerDeploymentStatus(workerDeployment, deployment)
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// getClusterClient returns the TemporalClusterClient referenced by the worker deployment, if any.
+func (r *TemporalWorkerDeploymentReconciler) getClusterClient(ctx context.Context, workerDeployment *v1beta1.TemporalWorkerDeployment, cluster *v1beta1.TemporalCluster) (*v1beta1.TemporalClusterClient, error) {
+	frontendMTLS := cluster.MTLSWithCertManagerEnabled() && cluster.Spec.MTLS.FrontendEnabled()
+
+	if workerDeployment.Spec.ClusterClientRef == nil {
+		if frontendMTLS {
+			return nil, errors.New("the cluster frontend uses mTLS, spec.clusterClientRef is required")
+		}
+		return nil, nil
+	}
+
+	if !frontendMTLS {
+		return nil, errors.New("spec.clusterClientRef can only be used with clusters using frontend mTLS with cert-manager")
+	}
+
+	clusterClient := &v1beta1.TemporalClusterClient{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: workerDeployment.GetNamespace(), Name: workerDeployment.Spec.ClusterClientRef.Name}, clusterClient)
+	if err != nil {
+		return nil, fmt.Errorf("can't get referenced cluster client: %w", err)
+	}
+
+	if clusterClient.Spec.ClusterRef.NamespacedName(clusterClient) != client.ObjectKeyFromObject(cluster) {
+		return nil, errors.New("the referenced cluster client doesn't reference the worker deployment cluster")
+	}
+
+	return clusterClient, nil
+}
+
+// reportWorkerDeploymentStatus reports the worker pods status from the provided deployment.
+func reportWorkerDeploymentStatus(workerDeployment *v1beta1.TemporalWorkerDeployment, deployment *appsv1.Deployment) {
+	workerDeployment.Status.Replicas = deployment.Status.Replicas
+	workerDeployment.Status.ReadyReplicas = deployment.Status.ReadyReplicas
+
+	var desired int32
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+
+	if deployment.Status.ObservedGeneration >= deployment.GetGeneration() &&
+		deployment.Status.UpdatedReplicas == desired &&
+		deployment.Status.ReadyReplicas == desired {
+		v1beta1.SetTemporalWorkerDeploymentReady(workerDeployment, metav1.ConditionTrue, v1beta1.WorkerDeploymentReadyReason, "")
+		return
+	}
+
+	v1beta1.SetTemporalWorkerDeploymentReady(workerDeployment, metav1.ConditionFalse, v1beta1.WorkerDeploymentNotReadyReason,
+		fmt.Sprintf("%d/%d worker pods ready", deployment.Status.ReadyReplicas, desired))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemporalWorkerDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&v1beta1.TemporalWorkerDeployment{},
+		clusterClientRefNameField,
+		func(rawObj client.Object) []string {
+			workerDeployment := rawObj.(*v1beta1.TemporalWorkerDeployment)
+			if workerDeployment.Spec.ClusterClientRef == nil {
+				return nil
+			}
+			return []string{workerDeployment.Spec.ClusterClientRef.Name}
+		})
+	if err != nil {
+		return err
+	}
+
+	controller := ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.TemporalWorkerDeployment{}).
+		Owns(&appsv1.Deployment{}).
+		// The client secret name is only known once the client is reconciled.
+		Watches(
+			&v1beta1.TemporalClusterClient{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterClientToWorkerDeploymentsMapfunc),
+		)
+
+	if r.AvailableAPIs.KEDA {
+		controller = controller.Owns(workerdeployment.NewScaledObject())
+	}
+
+	return controller.Complete(r)
+}
+
+func (r *TemporalWorkerDeploymentReconciler) clusterClientToWorkerDeploymentsMapfunc(ctx context.Context, o client.Object) []reconcile.Request {
+	workerDeployments := &v1beta1.TemporalWorkerDeploymentList{}
+	err := r.List(ctx, workerDeployments, client.InNamespace(o.GetNamespace()), client.MatchingFields{clusterClientRefNameField: o.GetName()})
+	if err != nil {
+		return nil
+	}
+
+	result := make([]reconcile.Request, 0, len(workerDeployments.Items))
+	for _, workerDeployment := range workerDeployments.Items {
+		result = append(result, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&workerDeployment),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,340 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/discovery"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/workerdeployment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func testWorkerCluster(mTLS bool) *v1beta1.TemporalCluster {
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "temporal"},
+		Spec: v1beta1.TemporalClusterSpec{
+			Services: &v1beta1.ServicesSpec{
+				Frontend: &v1beta1.ServiceSpec{Port: ptr.To(7233)},
+			},
+		},
+	}
+	if mTLS {
+		cluster.Spec.MTLS = &v1beta1.MTLSSpec{
+			Provider: v1beta1.CertManagerMTLSProvider,
+			Frontend: &v1beta1.FrontendMTLSSpec{Enabled: true},
+		}
+	}
+	return cluster
+}
+
+func testClusterClient(clusterName, secretName string) *v1beta1.TemporalClusterClient {
+	clusterClient := &v1beta1.TemporalClusterClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "temporal"},
+		Spec: v1beta1.TemporalClusterClientSpec{
+			ClusterRef: v1beta1.ObjectReference{Name: clusterName},
+		},
+	}
+	if secretName != "" {
+		clusterClient.Status.SecretRef = &corev1.LocalObjectReference{Name: secretName}
+	}
+	return clusterClient
+}
+
+func testWorkerDeployment(clusterClientRef bool, autoscaling *v1beta1.WorkerAutoscalingSpec) *v1beta1.TemporalWorkerDeployment {
+	workerDeployment := &v1beta1.TemporalWorkerDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "temporal"},
+		Spec: v1beta1.TemporalWorkerDeploymentSpec{
+			ClusterRef:  v1beta1.ObjectReference{Name: "prod"},
+			Namespace:   "orders",
+			TaskQueues:  []string{"orders"},
+			Image:       "example.com/orders-worker:v1",
+			Autoscaling: autoscaling,
+		},
+	}
+	if clusterClientRef {
+		workerDeployment.Spec.ClusterClientRef = &corev1.LocalObjectReference{Name: "orders"}
+	}
+	return workerDeployment
+}
+
+func reconcileWorkerDeployment(t *testing.T, r *TemporalWorkerDeploymentReconciler) (ctrl.Result, *v1beta1.TemporalWorkerDeployment, error) {
+	t.Helper()
+
+	key := client.ObjectKey{Namespace: "temporal", Name: "orders"}
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+
+	workerDeployment := &v1beta1.TemporalWorkerDeployment{}
+	require.NoError(t, r.Get(context.Background(), key, workerDeployment))
+
+	return result, workerDeployment, err
+}
+
+func TestTemporalWorkerDeploymentReconcile(t *testing.T) {
+	tests := map[string]struct {
+		objects        []client.Object
+		keda           bool
+		expectedErr    string
+		expectedResult ctrl.Result
+		expectedReason string
+		expectedMsg    string
+	}{
+		"creates the worker deployment": {
+			objects:        []client.Object{testWorkerCluster(false), testWorkerDeployment(false, nil)},
+			expectedReason: v1beta1.WorkerDeploymentNotReadyReason,
+			expectedMsg:    "0/1 worker pods ready",
+		},
+		"missing cluster": {
+			objects:        []client.Object{testWorkerDeployment(false, nil)},
+			expectedErr:    "not found",
+			expectedReason: v1beta1.WorkerDeploymentReconcileErrorReason,
+		},
+		"cluster client required with frontend mTLS": {
+			objects:        []client.Object{testWorkerCluster(true), testWorkerDeployment(false, nil)},
+			expectedErr:    "the cluster frontend uses mTLS, spec.clusterClientRef is required",
+			expectedReason: v1beta1.WorkerDeploymentReconcileErrorReason,
+		},
+		"cluster client without frontend mTLS": {
+			objects:        []client.Object{testWorkerCluster(false), testClusterClient("prod", "orders-tls"), testWorkerDeployment(true, nil)},
+			expectedErr:    "spec.clusterClientRef can only be used with clusters using frontend mTLS with cert-manager",
+			expectedReason: v1beta1.WorkerDeploymentReconcileErrorReason,
+		},
+		"cluster client of another cluster": {
+			objects:        []client.Object{testWorkerCluster(true), testClusterClient("staging", "orders-tls"), testWorkerDeployment(true, nil)},
+			expectedErr:    "the referenced cluster client doesn't reference the worker deployment cluster",
+			expectedReason: v1beta1.WorkerDeploymentReconcileErrorReason,
+		},
+		"waits for the cluster client secret": {
+			objects:        []client.Object{testWorkerCluster(true), testClusterClient("prod", ""), testWorkerDeployment(true, nil)},
+			expectedResult: ctrl.Result{RequeueAfter: 10 * time.Second},
+			expectedReason: v1beta1.WorkerDeploymentNotReadyReason,
+			expectedMsg:    "Waiting for the cluster client secret",
+		},
+		"autoscaling requires KEDA": {
+			objects:        []client.Object{testWorkerCluster(false), testWorkerDeployment(false, &v1beta1.WorkerAutoscalingSpec{MaxReplicas: 5})},
+			expectedErr:    "autoscaling requires KEDA to be installed in the kubernetes cluster",
+			expectedReason: v1beta1.WorkerDeploymentReconcileErrorReason,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			r := &TemporalWorkerDeploymentReconciler{
+				Base:          newFakeBase(tt, test.objects...),
+				AvailableAPIs: &discovery.AvailableAPIs{KEDA: test.keda},
+			}
+
+			result, workerDeployment, err := reconcileWorkerDeployment(tt, r)
+			if test.expectedErr != "" {
+				assert.ErrorContains(tt, err, test.expectedErr)
+			} else {
+				assert.NoError(tt, err)
+			}
+			assert.Equal(tt, test.expectedResult, result)
+
+			condition := apimeta.FindStatusCondition(workerDeployment.Status.Conditions, v1beta1.ReadyCondition)
+			require.NotNil(tt, condition)
+			assert.Equal(tt, metav1.ConditionFalse, condition.Status)
+			assert.Equal(tt, test.expectedReason, condition.Reason)
+			if test.expectedMsg != "" {
+				assert.Equal(tt, test.expectedMsg, condition.Message)
+			}
+		})
+	}
+}
+
+func TestTemporalWorkerDeploymentReconcileRollout(t *testing.T) {
+	r := &TemporalWorkerDeploymentReconciler{
+		Base:          newFakeBase(t, testWorkerCluster(true), testClusterClient("prod", "orders-tls"), testWorkerDeployment(true, nil)),
+		AvailableAPIs: &discovery.AvailableAPIs{},
+	}
+	ctx := context.Background()
+
+	_, workerDeployment, err := reconcileWorkerDeployment(t, r)
+	require.NoError(t, err)
+	assert.False(t, workerDeployment.IsReady())
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "temporal", Name: "orders"}, deployment))
+	assert.Equal(t, "orders-tls", deployment.Spec.Template.Spec.Volumes[0].Secret.SecretName)
+
+	// The worker pods are rolled out.
+	deployment.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: deployment.Generation,
+		Replicas:           1,
+		UpdatedReplicas:    1,
+		ReadyReplicas:      1,
+	}
+	require.NoError(t, r.Update(ctx, deployment))
+
+	_, workerDeployment, err = reconcileWorkerDeployment(t, r)
+	require.NoError(t, err)
+	assert.True(t, workerDeployment.IsReady())
+	assert.Equal(t, int32(1), workerDeployment.Status.Replicas)
+	assert.Equal(t, int32(1), workerDeployment.Status.ReadyReplicas)
+
+	// A new worker image is rolled out.
+	workerDeployment.Spec.Image = "example.com/orders-worker:v2"
+	workerDeployment.Spec.Replicas = ptr.To[int32](2)
+	require.NoError(t, r.Update(ctx, workerDeployment))
+
+	_, workerDeployment, err = reconcileWorkerDeployment(t, r)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(deployment), deployment))
+	assert.Equal(t, "example.com/orders-worker:v2", deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+
+	condition := apimeta.FindStatusCondition(workerDeployment.Status.Conditions, v1beta1.ReadyCondition)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "1/2 worker pods ready", condition.Message)
+}
+
+// TestTemporalWorkerDeploymentReconcileCached ensures the worker Deployment is found in the manager cache
+// once created: otherwise it's created again on every reconcile.
+func TestTemporalWorkerDeploymentReconcileCached(t *testing.T) {
+	r := &TemporalWorkerDeploymentReconciler{
+		Base:          newCachedFakeBase(t, testWorkerCluster(false), testWorkerDeployment(false, nil)),
+		AvailableAPIs: &discovery.AvailableAPIs{},
+	}
+	ctx := context.Background()
+
+	_, _, err := reconcileWorkerDeployment(t, r)
+	require.NoError(t, err)
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "temporal", Name: "orders"}, deployment))
+
+	deployment.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: deployment.Generation,
+		Replicas:           1,
+		UpdatedReplicas:    1,
+		ReadyReplicas:      1,
+	}
+	require.NoError(t, r.Update(ctx, deployment))
+
+	_, workerDeployment, err := reconcileWorkerDeployment(t, r)
+	require.NoError(t, err)
+	assert.True(t, workerDeployment.IsReady())
+}
+
+func TestTemporalWorkerDeploymentReconcileAutoscaling(t *testing.T) {
+	autoscaling := &v1beta1.WorkerAutoscalingSpec{MinReplicas: ptr.To[int32](2), MaxReplicas: 10}
+	r := &TemporalWorkerDeploymentReconciler{
+		Base:          newFakeBase(t, testWorkerCluster(true), testClusterClient("prod", "orders-tls"), testWorkerDeployment(true, autoscaling)),
+		AvailableAPIs: &discovery.AvailableAPIs{KEDA: true},
+	}
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: "temporal", Name: "orders"}
+
+	_, _, err := reconcileWorkerDeployment(t, r)
+	require.NoError(t, err)
+
+	scaledObject := workerdeployment.NewScaledObject()
+	require.NoError(t, r.Get(ctx, key, scaledObject))
+	assert.Equal(t, int64(2), scaledObject.Object["spec"].(map[string]any)["minReplicaCount"])
+	require.NoError(t, r.Get(ctx, key, workerdeployment.NewTriggerAuthentication()))
+
+	// The deployment starts with the autoscaling min replicas.
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, key, deployment))
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+
+	// The replicas ramped up by the autoscaler are kept.
+	deployment.Spec.Replicas = ptr.To[int32](6)
+	require.NoError(t, r.Update(ctx, deployment))
+
+	_, workerDeployment, err := reconcileWorkerDeployment(t, r)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, key, deployment))
+	assert.Equal(t, int32(6), *deployment.Spec.Replicas)
+
+	// Disabling autoscaling removes the KEDA objects and restores the spec replicas.
+	workerDeployment.Spec.Autoscaling = nil
+	require.NoError(t, r.Update(ctx, workerDeployment))
+
+	_, _, err = reconcileWorkerDeployment(t, r)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, key, deployment))
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+	assert.True(t, apierrors.IsNotFound(r.Get(ctx, key, workerdeployment.NewScaledObject())))
+	assert.True(t, apierrors.IsNotFound(r.Get(ctx, key, workerdeployment.NewTriggerAuthentication())))
+}
+
+func TestReportWorkerDeploymentStatus(t *testing.T) {
+	tests := map[string]struct {
+		generation      int64
+		status          appsv1.DeploymentStatus
+		expectedReady   bool
+		expectedMessage string
+	}{
+		"rollout complete": {
+			generation:    2,
+			status:        appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3},
+			expectedReady: true,
+		},
+		"rollout not observed yet": {
+			generation:      3,
+			status:          appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3},
+			expectedMessage: "3/3 worker pods ready",
+		},
+		"rollout in progress": {
+			generation:      2,
+			status:          appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 1, ReadyReplicas: 3},
+			expectedMessage: "3/3 worker pods ready",
+		},
+		"pods not ready": {
+			generation:      2,
+			status:          appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 1},
+			expectedMessage: "1/3 worker pods ready",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			workerDeployment := &v1beta1.TemporalWorkerDeployment{}
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: test.generation},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](3)},
+				Status:     test.status,
+			}
+
+			reportWorkerDeploymentStatus(workerDeployment, deployment)
+
+			assert.Equal(tt, test.expectedReady, workerDeployment.IsReady())
+			assert.Equal(tt, test.status.Replicas, workerDeployment.Status.Replicas)
+			if !test.expectedReady {
+				condition := apimeta.FindStatusCondition(workerDeployment.Status.Conditions, v1beta1.ReadyCondition)
+				require.NotNil(tt, condition)
+				assert.Equal(tt, test.expectedMessage, condition.Message)
+			}
+		})
+	}
+}
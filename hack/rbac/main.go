@@ -0,0 +1,225 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Command rbac generates the ClusterRoles aggregated to the kubernetes default view, edit and admin
+// user-facing roles, granting access to the operator's custom resources.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const header = `# Code generated by hack/rbac. DO NOT EDIT.
+`
+
+var (
+	readVerbs  = []string{"get", "list", "watch"}
+	writeVerbs = []string{"create", "delete", "deletecollection", "patch", "update"}
+	scaleVerbs = []string{"patch", "update"}
+)
+
+// adminResources are only writable by namespace admins, as they run the temporal infrastructure
+// or grant access to it. Other namespaced resources are writable by editors.
+var adminResources = map[string]bool{
+	"temporalaccesspolicies":      true,
+	"temporalclusters":            true,
+	"temporalclusterclients":      true,
+	"temporalclusterclones":       true,
+	"temporalclusteroverrides":    true,
+	"temporalnamespacemigrations": true,
+	"temporalservicescalers":      true,
+}
+
+// resource is a custom resource the roles grant access to.
+type resource struct {
+	group      string
+	plural     string
+	namespaced bool
+	status     bool
+	scale      bool
+}
+
+func main() {
+	crdsDir := flag.String("crds", "config/crd/bases", "Directory containing the custom resource definitions.")
+	output := flag.String("output", "config/rbac/aggregated_roles.yaml", "Path of the generated roles manifest.")
+	flag.Parse()
+
+	resources, err := loadResources(*crdsDir)
+	if err != nil {
+		log.Fatalf("can't load custom resource definitions: %v", err)
+	}
+
+	content, err := render(buildRoles(resources))
+	if err != nil {
+		log.Fatalf("can't render roles: %v", err)
+	}
+
+	if err := os.WriteFile(*output, content, 0o600); err != nil {
+		log.Fatalf("can't write roles: %v", err)
+	}
+}
+
+// loadResources returns the resources defined by the custom resource definitions of the provided directory.
+func loadResources(dir string) ([]resource, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []resource{}
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		err = yaml.NewYAMLOrJSONDecoder(f, 4096).Decode(crd)
+		f.Close()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("can't decode %s: %w", file, err)
+		}
+
+		if crd.Kind != "CustomResourceDefinition" {
+			continue
+		}
+
+		r := resource{
+			group:      crd.Spec.Group,
+			plural:     crd.Spec.Names.Plural,
+			namespaced: crd.Spec.Scope == apiextensionsv1.NamespaceScoped,
+		}
+		for _, version := range crd.Spec.Versions {
+			if version.Subresources != nil && version.Subresources.Status != nil {
+				r.status = true
+			}
+			if version.Subresources != nil && version.Subresources.Scale != nil {
+				r.scale = true
+			}
+		}
+
+		resources = append(resources, r)
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].plural < resources[j].plural
+	})
+
+	return resources, nil
+}
+
+// buildRoles returns the view, edit and admin roles granting access to the provided resources.
+func buildRoles(resources []resource) []*rbacv1.ClusterRole {
+	view := newRole("view")
+	edit := newRole("edit")
+	admin := newRole("admin")
+
+	for _, r := range resources {
+		view.Rules = append(view.Rules, rbacv1.PolicyRule{
+			APIGroups: []string{r.group},
+			Resources: []string{r.plural},
+			Verbs:     readVerbs,
+		})
+		if r.status {
+			view.Rules = append(view.Rules, rbacv1.PolicyRule{
+				APIGroups: []string{r.group},
+				Resources: []string{r.plural + "/status"},
+				Verbs:     readVerbs,
+			})
+		}
+		if r.scale {
+			view.Rules = append(view.Rules, rbacv1.PolicyRule{
+				APIGroups: []string{r.group},
+				Resources: []string{r.plural + "/scale"},
+				Verbs:     readVerbs,
+			})
+		}
+
+		// Cluster-scoped resources are managed by cluster administrators.
+		if !r.namespaced {
+			continue
+		}
+
+		role := edit
+		if adminResources[r.plural] {
+			role = admin
+		}
+
+		role.Rules = append(role.Rules, rbacv1.PolicyRule{
+			APIGroups: []string{r.group},
+			Resources: []string{r.plural},
+			Verbs:     writeVerbs,
+		})
+		if r.scale {
+			role.Rules = append(role.Rules, rbacv1.PolicyRule{
+				APIGroups: []string{r.group},
+				Resources: []string{r.plural + "/scale"},
+				Verbs:     scaleVerbs,
+			})
+		}
+	}
+
+	return []*rbacv1.ClusterRole{view, edit, admin}
+}
+
+// newRole returns an empty ClusterRole aggregated to the provided kubernetes default role.
+func newRole(aggregateTo string) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "ClusterRole",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("aggregate-to-%s", aggregateTo),
+			Labels: map[string]string{
+				fmt.Sprintf("rbac.authorization.k8s.io/aggregate-to-%s", aggregateTo): "true",
+			},
+		},
+	}
+}
+
+// render returns the YAML manifest of the provided roles.
+func render(roles []*rbacv1.ClusterRole) ([]byte, error) {
+	serializer := json.NewSerializerWithOptions(json.DefaultMetaFactory, nil, nil, json.SerializerOptions{Yaml: true})
+
+	buf := bytes.NewBufferString(header)
+	for i, role := range roles {
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		if err := serializer.Encode(runtime.Object(role), buf); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,145 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package buildinfo exposes the version, commit and build date of the operator binary.
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+)
+
+// Build information set at build time using -ldflags "-X".
+var (
+	// Version is the operator version.
+	Version = "dev"
+	// Commit is the git commit the operator was built from.
+	// Defaults to the VCS revision recorded by the go toolchain.
+	Commit = ""
+	// Date is the operator build date.
+	// Defaults to the VCS commit time recorded by the go toolchain.
+	Date = ""
+)
+
+// Info describes an operator build.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+// Get returns the running operator build information.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			if info.Date == "" {
+				info.Date = setting.Value
+			}
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+
+	return info
+}
+
+// String returns a human readable description of the build.
+func (i Info) String() string {
+	commit := i.Commit
+	if commit == "" {
+		commit = "unknown"
+	}
+	if i.Modified {
+		commit += "-dirty"
+	}
+
+	date := i.Date
+	if date == "" {
+		date = "unknown"
+	}
+
+	return fmt.Sprintf("temporal-operator %s (commit: %s, built: %s, %s %s)", i.Version, commit, date, i.GoVersion, i.Platform)
+}
+
+// Flag is a flag printing the build information when set.
+// It can be used as a boolean flag (--version) or set to an output format (--version=json).
+type Flag struct {
+	format string
+}
+
+// IsBoolFlag allows the flag to be set without a value.
+func (f *Flag) IsBoolFlag() bool {
+	return true
+}
+
+// String returns the requested output format.
+func (f *Flag) String() string {
+	return f.format
+}
+
+// Set sets the output format, "true" being an alias for "text".
+func (f *Flag) Set(value string) error {
+	switch value {
+	case "false":
+		f.format = ""
+	case "true", "text":
+		f.format = "text"
+	case "json":
+		f.format = "json"
+	default:
+		return fmt.Errorf("unsupported version format %q, must be one of: text, json", value)
+	}
+	return nil
+}
+
+// Requested returns true if the build information should be printed.
+func (f *Flag) Requested() bool {
+	return f.format != ""
+}
+
+// Print writes the provided build information using the requested format.
+func (f *Flag) Print(w io.Writer, info Info) error {
+	if f.format == "json" {
+		return json.NewEncoder(w).Encode(info)
+	}
+	_, err := fmt.Fprintln(w, info.String())
+	return err
+}
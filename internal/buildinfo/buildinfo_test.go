@@ -0,0 +1,135 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package buildinfo
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfoString(t *testing.T) {
+	tests := map[string]struct {
+		info     Info
+		expected string
+	}{
+		"release build": {
+			info: Info{
+				Version:   "v0.20.0",
+				Commit:    "1a2b3c4",
+				Date:      "2024-06-01T10:00:00Z",
+				GoVersion: "go1.22.2",
+				Platform:  "linux/amd64",
+			},
+			expected: "temporal-operator v0.20.0 (commit: 1a2b3c4, built: 2024-06-01T10:00:00Z, go1.22.2 linux/amd64)",
+		},
+		"local build with uncommitted changes": {
+			info: Info{
+				Version:   "dev",
+				Commit:    "1a2b3c4",
+				Modified:  true,
+				GoVersion: "go1.22.2",
+				Platform:  "darwin/arm64",
+			},
+			expected: "temporal-operator dev (commit: 1a2b3c4-dirty, built: unknown, go1.22.2 darwin/arm64)",
+		},
+		"without vcs information": {
+			info: Info{
+				Version:   "dev",
+				GoVersion: "go1.22.2",
+				Platform:  "linux/arm64",
+			},
+			expected: "temporal-operator dev (commit: unknown, built: unknown, go1.22.2 linux/arm64)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, test.info.String())
+		})
+	}
+}
+
+func TestGetPrefersLinkerFlags(t *testing.T) {
+	previousVersion, previousCommit, previousDate := Version, Commit, Date
+	t.Cleanup(func() {
+		Version, Commit, Date = previousVersion, previousCommit, previousDate
+	})
+
+	Version, Commit, Date = "v1.0.0", "abcdef0", "2024-01-01T00:00:00Z"
+
+	info := Get()
+	assert.Equal(t, "v1.0.0", info.Version)
+	assert.Equal(t, "abcdef0", info.Commit)
+	assert.Equal(t, "2024-01-01T00:00:00Z", info.Date)
+	assert.NotEmpty(t, info.GoVersion)
+	assert.NotEmpty(t, info.Platform)
+}
+
+func TestFlag(t *testing.T) {
+	info := Info{Version: "v0.20.0", Commit: "1a2b3c4", Date: "2024-06-01T10:00:00Z", GoVersion: "go1.22.2", Platform: "linux/amd64"}
+
+	tests := map[string]struct {
+		args              []string
+		expectedRequested bool
+		expectedOutput    string
+		expectedErr       bool
+	}{
+		"not set": {},
+		"boolean": {
+			args:              []string{"--version"},
+			expectedRequested: true,
+			expectedOutput:    "temporal-operator v0.20.0 (commit: 1a2b3c4, built: 2024-06-01T10:00:00Z, go1.22.2 linux/amd64)\n",
+		},
+		"json": {
+			args:              []string{"--version=json"},
+			expectedRequested: true,
+			expectedOutput:    `{"version":"v0.20.0","commit":"1a2b3c4","date":"2024-06-01T10:00:00Z","goVersion":"go1.22.2","platform":"linux/amd64"}` + "\n",
+		},
+		"unknown format": {
+			args:        []string{"--version=yaml"},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			versionFlag := &Flag{}
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(&bytes.Buffer{})
+			fs.Var(versionFlag, "version", "")
+
+			err := fs.Parse(test.args)
+			if test.expectedErr {
+				assert.Error(tt, err)
+				return
+			}
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expectedRequested, versionFlag.Requested())
+
+			if test.expectedRequested {
+				out := &bytes.Buffer{}
+				require.NoError(tt, versionFlag.Print(out, info))
+				assert.Equal(tt, test.expectedOutput, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// ManagedObjectsSelector selects the objects the operator creates for the clusters and the worker deployments it manages.
+// All of them have the "app.kubernetes.io/part-of" label set by metadata.GetLabels, with the value returned
+// by the owner SelectorLabels.
+var ManagedObjectsSelector = func() labels.Selector {
+	requirement, err := labels.NewRequirement("app.kubernetes.io/part-of", selection.In, []string{"temporal", "temporal-worker"})
+	if err != nil {
+		panic(err)
+	}
+	return labels.NewSelector().Add(*requirement)
+}()
+
+// Options returns the manager cache options.
+// Without them, the manager caches every Deployment, Service and ConfigMap of the kubernetes cluster,
+// which uses a lot of memory on large clusters. Only the objects created by the operator are cached
+// and managed fields are removed from all the cached objects as the operator never reads them.
+// If watchNamespaces is not empty, only the namespaced objects of these namespaces are cached.
+func Options(watchNamespaces []string) crcache.Options {
+	opts := crcache.Options{
+		DefaultTransform: crcache.TransformStripManagedFields(),
+		ByObject: map[client.Object]crcache.ByObject{
+			&appsv1.Deployment{}: {Label: ManagedObjectsSelector},
+			&corev1.Service{}:    {Label: ManagedObjectsSelector},
+			&corev1.ConfigMap{}:  {Label: ManagedObjectsSelector},
+		},
+	}
+
+	if len(watchNamespaces) > 0 {
+		opts.DefaultNamespaces = map[string]crcache.Config{}
+		for _, namespace := range watchNamespaces {
+			opts.DefaultNamespaces[namespace] = crcache.Config{}
+		}
+	}
+
+	return opts
+}
+
+// NewMetadataCache returns a cache holding all the objects of the watched namespaces, and adds it to the manager.
+// It must only be used to watch the metadata of the objects the manager cache doesn't hold, like the ConfigMaps
+// provided by users, as watching full objects would cache all of them.
+func NewMetadataCache(mgr manager.Manager, watchNamespaces []string) (crcache.Cache, error) {
+	opts := Options(watchNamespaces)
+	opts.ByObject = nil
+	opts.HTTPClient = mgr.GetHTTPClient()
+	opts.Scheme = mgr.GetScheme()
+	opts.Mapper = mgr.GetRESTMapper()
+
+	metadataCache, err := crcache.New(mgr.GetConfig(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadataCache, mgr.Add(metadataCache)
+}
+
+// ClientOptions returns the manager client options.
+// Secrets are read directly from the API server instead of being cached, as the operator reads
+// user-provided secrets that can't be selected using labels. Controllers watching secrets
+// must only watch their metadata to avoid caching them.
+func ClientOptions() client.Options {
+	return client.Options{
+		Cache: &client.CacheOptions{
+			DisableFor: []client.Object{
+				&corev1.Secret{},
+			},
+		},
+	}
+}
@@ -0,0 +1,174 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/internal/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestOptions(t *testing.T) {
+	opts := cache.Options(nil)
+
+	require.NotNil(t, opts.DefaultTransform)
+
+	for object := range opts.ByObject {
+		switch object.(type) {
+		case *appsv1.Deployment, *corev1.Service, *corev1.ConfigMap:
+		default:
+			t.Errorf("unexpected restricted object %T", object)
+		}
+		assert.Equal(t, cache.ManagedObjectsSelector, opts.ByObject[object].Label)
+	}
+	assert.Len(t, opts.ByObject, 3)
+	assert.Nil(t, opts.DefaultNamespaces)
+
+	managed := labels.Set{
+		"app.kubernetes.io/name":    "prod",
+		"app.kubernetes.io/part-of": "temporal",
+	}
+	assert.True(t, cache.ManagedObjectsSelector.Matches(managed))
+	worker := labels.Set{
+		"app.kubernetes.io/name":    "orders",
+		"app.kubernetes.io/part-of": "temporal-worker",
+	}
+	assert.True(t, cache.ManagedObjectsSelector.Matches(worker))
+	assert.False(t, cache.ManagedObjectsSelector.Matches(labels.Set{"app": "other"}))
+
+	configMap := newConfigMap(0, true)
+	transformed, err := opts.DefaultTransform(configMap)
+	require.NoError(t, err)
+	assert.Nil(t, transformed.(*corev1.ConfigMap).ManagedFields)
+}
+
+func TestOptionsWatchNamespaces(t *testing.T) {
+	opts := cache.Options([]string{"team-a", "team-b"})
+
+	assert.Len(t, opts.DefaultNamespaces, 2)
+	assert.Contains(t, opts.DefaultNamespaces, "team-a")
+	assert.Contains(t, opts.DefaultNamespaces, "team-b")
+}
+
+func TestClientOptions(t *testing.T) {
+	opts := cache.ClientOptions()
+
+	require.NotNil(t, opts.Cache)
+	assert.Equal(t, []client.Object{&corev1.Secret{}}, opts.Cache.DisableFor)
+}
+
+// newConfigMap returns a ConfigMap as stored by the API server.
+// Managed ConfigMaps are labeled as the ones created by the operator.
+func newConfigMap(i int, managed bool) *corev1.ConfigMap {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("configmap-%d", i),
+			Namespace: "default",
+			Labels: map[string]string{
+				"app": "other",
+			},
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:   "kubectl",
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1: &metav1.FieldsV1{
+						Raw: []byte(fmt.Sprintf(`{"f:data":{%s}}`, strings.Repeat(`"f:key":{},`, 64))),
+					},
+				},
+			},
+		},
+		Data: map[string]string{
+			"key": strings.Repeat("x", 2048),
+		},
+	}
+	if managed {
+		configMap.Labels = map[string]string{
+			"app.kubernetes.io/part-of": "temporal",
+		}
+	}
+	return configMap
+}
+
+// storeHeapSize returns the heap size used by a cache store filled with the provided objects.
+func storeHeapSize(b *testing.B, objects []*corev1.ConfigMap, restricted bool) uint64 {
+	b.Helper()
+
+	opts := cache.Options(nil)
+	var before, after runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	store := toolscache.NewStore(toolscache.MetaNamespaceKeyFunc)
+	for _, object := range objects {
+		// Copy objects as informers decode them from the API server responses.
+		var obj any = object.DeepCopy()
+		if restricted {
+			if !cache.ManagedObjectsSelector.Matches(labels.Set(object.Labels)) {
+				continue
+			}
+			var err error
+			obj, err = opts.DefaultTransform(obj)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+		if err := store.Add(obj); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(store)
+
+	if after.HeapAlloc < before.HeapAlloc {
+		return 0
+	}
+	return after.HeapAlloc - before.HeapAlloc
+}
+
+// BenchmarkCacheMemory compares the memory used to cache ConfigMaps of a kubernetes cluster
+// where 10% of them are managed by the operator, with and without the cache options.
+func BenchmarkCacheMemory(b *testing.B) {
+	objects := make([]*corev1.ConfigMap, 0, 5000)
+	for i := 0; i < cap(objects); i++ {
+		objects = append(objects, newConfigMap(i, i%10 == 0))
+	}
+
+	for name, restricted := range map[string]bool{"default": false, "restricted": true} {
+		b.Run(name, func(b *testing.B) {
+			var total uint64
+			for i := 0; i < b.N; i++ {
+				total += storeHeapSize(b, objects, restricted)
+			}
+			b.ReportMetric(float64(total)/float64(b.N)/(1<<20), "MiB/store")
+		})
+	}
+}
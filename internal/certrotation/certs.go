@@ -0,0 +1,267 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certrotation
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+)
+
+const (
+	// CACertName is the secret key and file name holding the PEM encoded CA certificates bundle.
+	// The first certificate is the current CA, the next ones are the previous CAs still valid.
+	CACertName = "ca.crt"
+	// CAKeyName is the secret key holding the PEM encoded current CA private key.
+	CAKeyName = "ca.key"
+	// CertName is the secret key and file name holding the PEM encoded serving certificate.
+	CertName = "tls.crt"
+	// KeyName is the secret key and file name holding the PEM encoded serving certificate private key.
+	KeyName = "tls.key"
+
+	caValidity   = 10 * 365 * 24 * time.Hour
+	certValidity = 365 * 24 * time.Hour
+	// renewBefore is how long before their expiration the CA and the serving certificate are renewed.
+	renewBefore = 30 * 24 * time.Hour
+)
+
+// rotation is the part of the certificates needing to be issued again.
+type rotation int
+
+const (
+	rotateNone rotation = iota
+	rotateCert
+	rotateAll
+)
+
+// keyPair is a parsed certificate and its private key.
+type keyPair struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// needsRotation returns the part of the certificates stored in the provided data to issue again.
+func needsRotation(data map[string][]byte, dnsNames []string, now time.Time) rotation {
+	ca, err := parseKeyPair(data[CACertName], data[CAKeyName])
+	if err != nil || now.Add(renewBefore).After(ca.cert.NotAfter) {
+		return rotateAll
+	}
+
+	cert, err := parseKeyPair(data[CertName], data[KeyName])
+	if err != nil || now.Add(renewBefore).After(cert.cert.NotAfter) {
+		return rotateCert
+	}
+
+	if err := cert.cert.CheckSignatureFrom(ca.cert); err != nil {
+		return rotateCert
+	}
+
+	for _, name := range dnsNames {
+		if !slices.Contains(cert.cert.DNSNames, name) {
+			return rotateCert
+		}
+	}
+
+	return rotateNone
+}
+
+// rotate returns the provided data with the certificates issued again.
+// When the CA is renewed, the previous one is kept in the CA bundle until it expires,
+// so clients trusting it accept the certificates served until all operator replicas reload theirs.
+func rotate(data map[string][]byte, r rotation, dnsNames []string, now time.Time) (map[string][]byte, error) {
+	result := map[string][]byte{}
+	for key, value := range data {
+		result[key] = value
+	}
+
+	if r == rotateAll {
+		ca, err := newCA(now)
+		if err != nil {
+			return nil, err
+		}
+
+		bundle := encodeCert(ca.cert)
+		for _, previous := range validCerts(data[CACertName], now) {
+			bundle = append(bundle, encodeCert(previous)...)
+		}
+
+		key, err := encodeKey(ca.key)
+		if err != nil {
+			return nil, err
+		}
+
+		result[CACertName] = bundle
+		result[CAKeyName] = key
+	}
+
+	ca, err := parseKeyPair(result[CACertName], result[CAKeyName])
+	if err != nil {
+		return nil, fmt.Errorf("can't parse CA: %w", err)
+	}
+
+	cert, err := newCert(ca, dnsNames, now)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := encodeKey(cert.key)
+	if err != nil {
+		return nil, err
+	}
+
+	result[CertName] = encodeCert(cert.cert)
+	result[KeyName] = key
+
+	return result, nil
+}
+
+func newCA(now time.Time) (*keyPair, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "temporal-operator-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return issue(template, nil)
+}
+
+func newCert(ca *keyPair, dnsNames []string, now time.Time) (*keyPair, error) {
+	notAfter := now.Add(certValidity)
+	if notAfter.After(ca.cert.NotAfter) {
+		notAfter = ca.cert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:    dnsNames,
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	return issue(template, ca)
+}
+
+// issue creates a certificate from the provided template, signed by the provided CA or self-signed if nil.
+func issue(template *x509.Certificate, ca *keyPair) (*keyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("can't generate private key: %w", err)
+	}
+
+	template.SerialNumber, err = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("can't generate serial number: %w", err)
+	}
+
+	parent, signer := template, key
+	if ca != nil {
+		parent, signer = ca.cert, ca.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	if err != nil {
+		return nil, fmt.Errorf("can't create certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyPair{cert: cert, key: key}, nil
+}
+
+// parseKeyPair parses the first certificate of the provided PEM data and its private key.
+func parseKeyPair(certPEM, keyPEM []byte) (*keyPair, error) {
+	certs := parseCerts(certPEM)
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate found")
+	}
+
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("no private key found")
+	}
+
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyPair{cert: certs[0], key: key}, nil
+}
+
+// parseCerts returns the certificates of the provided PEM data, ignoring the invalid ones.
+func parseCerts(data []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		certs = append(certs, cert)
+	}
+}
+
+// validCerts returns the certificates of the provided PEM data which are not expired.
+func validCerts(data []byte, now time.Time) []*x509.Certificate {
+	return slices.DeleteFunc(parseCerts(data), func(cert *x509.Certificate) bool {
+		return now.After(cert.NotAfter)
+	})
+}
+
+// caBundle returns the PEM encoded CA certificates which are not expired.
+func caBundle(data []byte, now time.Time) []byte {
+	var buf bytes.Buffer
+	for _, cert := range validCerts(data, now) {
+		buf.Write(encodeCert(cert))
+	}
+	return buf.Bytes()
+}
+
+func encodeCert(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+func encodeKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("can't encode private key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
@@ -0,0 +1,75 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certrotation
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotate(t *testing.T) {
+	now := time.Now()
+	dnsNames := []string{"webhook.system.svc", "webhook.system.svc.cluster.local"}
+
+	assert.Equal(t, rotateAll, needsRotation(nil, dnsNames, now))
+
+	data, err := rotate(nil, rotateAll, dnsNames, now)
+	require.NoError(t, err)
+	assert.Equal(t, rotateNone, needsRotation(data, dnsNames, now))
+
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(caBundle(data[CACertName], now)))
+	cert := parseCerts(data[CertName])[0]
+	_, err = cert.Verify(x509.VerifyOptions{DNSName: "webhook.system.svc", Roots: pool, CurrentTime: now})
+	assert.NoError(t, err)
+
+	// A new service name requires a new certificate signed by the same CA.
+	assert.Equal(t, rotateCert, needsRotation(data, append(dnsNames, "metrics.system.svc"), now))
+
+	// The certificate is renewed before it expires.
+	later := now.Add(certValidity - renewBefore + time.Hour)
+	assert.Equal(t, rotateCert, needsRotation(data, dnsNames, later))
+
+	renewed, err := rotate(data, rotateCert, dnsNames, later)
+	require.NoError(t, err)
+	assert.Equal(t, data[CACertName], renewed[CACertName])
+	assert.Equal(t, rotateNone, needsRotation(renewed, dnsNames, later))
+}
+
+func TestRotateCA(t *testing.T) {
+	now := time.Now()
+	dnsNames := []string{"webhook.system.svc"}
+
+	data, err := rotate(nil, rotateAll, dnsNames, now)
+	require.NoError(t, err)
+
+	later := now.Add(caValidity - renewBefore + time.Hour)
+	assert.Equal(t, rotateAll, needsRotation(data, dnsNames, later))
+
+	renewed, err := rotate(data, rotateAll, dnsNames, later)
+	require.NoError(t, err)
+
+	// The previous CA is kept in the bundle until it expires.
+	assert.Len(t, parseCerts(renewed[CACertName]), 2)
+	assert.Len(t, parseCerts(caBundle(renewed[CACertName], later.Add(renewBefore))), 1)
+	assert.Equal(t, rotateNone, needsRotation(renewed, dnsNames, later))
+}
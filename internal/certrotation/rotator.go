@@ -0,0 +1,275 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package certrotation issues and rotates the serving certificate of the operator webhook and metrics servers,
+// so installing the operator doesn't require cert-manager.
+package certrotation
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// checkInterval is the interval between two checks of the serving certificate.
+const checkInterval = time.Hour
+
+// Options configures the serving certificate rotation.
+type Options struct {
+	// Enabled defines if the operator issues and rotates its serving certificate.
+	Enabled bool
+	// CertDir is the directory the webhook and metrics servers read the serving certificate from.
+	CertDir string
+	// SecretName is the name of the Secret, in the operator namespace, holding the CA and the serving certificate.
+	SecretName string
+	// Services are the names of the Services, in the operator namespace, the certificate is issued for.
+	Services []string
+	// Webhooks are the names of the mutating and validating webhook configurations the CA is injected in.
+	Webhooks []string
+}
+
+// NewOptions returns the default rotation options.
+func NewOptions() *Options {
+	return &Options{
+		CertDir:    filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs"),
+		SecretName: "webhook-server-cert",
+		Services:   []string{"temporal-operator-webhook-service"},
+		Webhooks: []string{
+			"temporal-operator-mutating-webhook-configuration",
+			"temporal-operator-validating-webhook-configuration",
+		},
+	}
+}
+
+// BindFlags binds the rotation flags to the provided flagset.
+func (o *Options) BindFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&o.Enabled, "cert-rotation", o.Enabled,
+		"Issue and rotate the webhook and metrics serving certificate in the operator, instead of relying on cert-manager.")
+	fs.StringVar(&o.CertDir, "cert-dir", o.CertDir,
+		"The directory the webhook and metrics servers read the serving certificate from.")
+	fs.StringVar(&o.SecretName, "cert-rotation-secret", o.SecretName,
+		"The name of the Secret in the operator namespace holding the rotated CA and serving certificate.")
+	fs.Func("cert-rotation-services", "The comma separated names of the Services in the operator namespace the rotated certificate is issued for.", func(value string) error {
+		o.Services = splitList(value)
+		return nil
+	})
+	fs.Func("cert-rotation-webhooks", "The comma separated names of the webhook configurations the rotated CA is injected in.", func(value string) error {
+		o.Webhooks = splitList(value)
+		return nil
+	})
+}
+
+// DNSNames returns the DNS names the serving certificate is issued for.
+func (o *Options) DNSNames(namespace string) []string {
+	var names []string
+	for _, service := range o.Services {
+		names = append(names,
+			fmt.Sprintf("%s.%s.svc", service, namespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace),
+		)
+	}
+	return names
+}
+
+// splitList splits the provided comma separated list, ignoring empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Rotator keeps the serving certificate valid.
+// The certificate is stored in a Secret shared by all operator replicas, written in the local certificate directory
+// and its CA is injected in the webhook configurations.
+type Rotator struct {
+	Options   *Options
+	Client    client.Client
+	Namespace string
+}
+
+var (
+	_ manager.Runnable               = (*Rotator)(nil)
+	_ manager.LeaderElectionRunnable = (*Rotator)(nil)
+)
+
+//+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;update
+
+// NeedLeaderElection returns false as all operator replicas serve the webhooks and need the certificate.
+func (r *Rotator) NeedLeaderElection() bool {
+	return false
+}
+
+// Start checks the serving certificate until the context is done.
+func (r *Rotator) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("cert-rotation")
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := r.Ensure(ctx); err != nil {
+			logger.Error(err, "Can't rotate the serving certificate")
+		}
+	}, checkInterval)
+
+	return nil
+}
+
+// Ensure issues the serving certificate if it is missing or about to expire, writes it in the certificate directory
+// and injects its CA in the webhook configurations.
+// It must be called before the webhook server starts, as it requires the certificate files.
+func (r *Rotator) Ensure(ctx context.Context) error {
+	secret, err := r.ensureSecret(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{CACertName, CertName, KeyName} {
+		if err := writeFile(filepath.Join(r.Options.CertDir, name), secret.Data[name]); err != nil {
+			return fmt.Errorf("can't write %s: %w", name, err)
+		}
+	}
+
+	bundle := caBundle(secret.Data[CACertName], time.Now())
+	for _, name := range r.Options.Webhooks {
+		if err := r.injectCABundle(ctx, name, bundle); err != nil {
+			return fmt.Errorf("can't inject CA in webhook configuration %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ensureSecret returns the Secret holding a valid certificate, issuing it if needed.
+// Replicas racing to issue it retry with the certificate issued by the first one.
+func (r *Rotator) ensureSecret(ctx context.Context) (*corev1.Secret, error) {
+	logger := log.FromContext(ctx).WithName("cert-rotation")
+	dnsNames := r.Options.DNSNames(r.Namespace)
+	key := types.NamespacedName{Name: r.Options.SecretName, Namespace: r.Namespace}
+
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		secret := &corev1.Secret{}
+		err = r.Client.Get(ctx, key, secret)
+		notFound := apierrors.IsNotFound(err)
+		if err != nil && !notFound {
+			return nil, err
+		}
+
+		now := time.Now()
+		rotation := needsRotation(secret.Data, dnsNames, now)
+		if rotation == rotateNone {
+			return secret, nil
+		}
+
+		secret.Data, err = rotate(secret.Data, rotation, dnsNames, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if notFound {
+			secret.ObjectMeta = metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}
+			secret.Type = corev1.SecretTypeOpaque
+			err = r.Client.Create(ctx, secret)
+		} else {
+			err = r.Client.Update(ctx, secret)
+		}
+		if apierrors.IsAlreadyExists(err) || apierrors.IsConflict(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Info("Serving certificate issued", "secret", key, "renewedCA", rotation == rotateAll)
+		return secret, nil
+	}
+
+	return nil, fmt.Errorf("can't update secret %s: %w", key, err)
+}
+
+// injectCABundle sets the CA bundle of the mutating or validating webhook configuration with the provided name.
+func (r *Rotator) injectCABundle(ctx context.Context, name string, bundle []byte) error {
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: name}, mutating)
+	if err == nil {
+		changed := false
+		for i := range mutating.Webhooks {
+			if !bytes.Equal(mutating.Webhooks[i].ClientConfig.CABundle, bundle) {
+				mutating.Webhooks[i].ClientConfig.CABundle = bundle
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		return r.Client.Update(ctx, mutating)
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: name}, validating); err != nil {
+		return err
+	}
+	changed := false
+	for i := range validating.Webhooks {
+		if !bytes.Equal(validating.Webhooks[i].ClientConfig.CABundle, bundle) {
+			validating.Webhooks[i].ClientConfig.CABundle = bundle
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return r.Client.Update(ctx, validating)
+}
+
+// writeFile atomically replaces the file content if it changed, so the servers watching it never read a partial file.
+func writeFile(path string, data []byte) error {
+	current, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(current, data) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
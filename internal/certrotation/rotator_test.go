@@ -0,0 +1,80 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certrotation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsure(t *testing.T) {
+	c := fake.NewClientBuilder().WithObjects(
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "mutating"},
+			Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: "mtemporalc.kb.io"}},
+		},
+		&admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "validating"},
+			Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "vtemporalc.kb.io"}},
+		},
+	).Build()
+
+	opts := NewOptions()
+	opts.CertDir = t.TempDir()
+	opts.Webhooks = []string{"mutating", "validating"}
+	rotator := &Rotator{Options: opts, Client: c, Namespace: "temporal-system"}
+
+	require.NoError(t, rotator.Ensure(context.Background()))
+
+	secret := &corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: opts.SecretName, Namespace: "temporal-system"}, secret))
+
+	for _, name := range []string{CACertName, CertName, KeyName} {
+		content, err := os.ReadFile(filepath.Join(opts.CertDir, name))
+		require.NoError(t, err)
+		assert.Equal(t, secret.Data[name], content)
+	}
+	_, err := os.Stat(filepath.Join(opts.CertDir, CAKeyName))
+	assert.True(t, os.IsNotExist(err), "the CA private key must not be written on disk")
+
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "mutating"}, mutating))
+	assert.Equal(t, secret.Data[CACertName], mutating.Webhooks[0].ClientConfig.CABundle)
+
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "validating"}, validating))
+	assert.Equal(t, secret.Data[CACertName], validating.Webhooks[0].ClientConfig.CABundle)
+
+	// Other replicas reuse the issued certificate.
+	other := &Rotator{Options: opts, Client: c, Namespace: "temporal-system"}
+	require.NoError(t, other.Ensure(context.Background()))
+
+	current := &corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: opts.SecretName, Namespace: "temporal-system"}, current))
+	assert.Equal(t, secret.Data, current.Data)
+}
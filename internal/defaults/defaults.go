@@ -0,0 +1,263 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package defaults holds the operator-level defaults applied to the clusters
+// that don't specify them, allowing multi-team setups to rely on a centralized configuration.
+package defaults
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/strings/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// MTLSProviderKey is the ConfigMap key holding the default mTLS provider.
+	MTLSProviderKey = "mTLSProvider"
+	// ClusterIssuerKey is the ConfigMap key holding the default cert-manager ClusterIssuer.
+	ClusterIssuerKey = "clusterIssuer"
+	// ImageRegistryKey is the ConfigMap key holding the default image registry.
+	ImageRegistryKey = "imageRegistry"
+	// ImagePullSecretsKey is the ConfigMap key holding the comma separated default image pull secrets.
+	ImagePullSecretsKey = "imagePullSecrets"
+
+	// reloadInterval is the interval between two reads of the defaults ConfigMap.
+	reloadInterval = 30 * time.Second
+)
+
+// Defaults are the values applied to clusters that don't specify them.
+type Defaults struct {
+	// MTLSProvider is the mTLS provider used by clusters enabling mTLS without a provider.
+	MTLSProvider v1beta1.MTLSProvider
+	// ClusterIssuer is the cert-manager ClusterIssuer signing the root CA of clusters using cert-manager without issuer.
+	ClusterIssuer string
+	// ImageRegistry is the registry the default images are pulled from.
+	ImageRegistry string
+	// ImagePullSecrets are the image pull secrets of clusters without image pull secrets.
+	ImagePullSecrets []string
+}
+
+// Options holds the operator-level defaults.
+// They are set from flags, environment variables and can be reloaded from a ConfigMap at runtime.
+type Options struct {
+	// ConfigMap is the name of the ConfigMap the defaults are reloaded from, if set.
+	ConfigMap string
+
+	mu       sync.RWMutex
+	defaults Defaults
+}
+
+// NewOptions returns empty defaults.
+func NewOptions() *Options {
+	return &Options{}
+}
+
+// BindFlags binds the defaults flags to the provided flagset.
+// Each flag defaults to the value of its TEMPORAL_OPERATOR_DEFAULT_* environment variable.
+func (o *Options) BindFlags(fs *flag.FlagSet) {
+	o.Set(Defaults{
+		MTLSProvider:     v1beta1.MTLSProvider(os.Getenv("TEMPORAL_OPERATOR_DEFAULT_MTLS_PROVIDER")),
+		ClusterIssuer:    os.Getenv("TEMPORAL_OPERATOR_DEFAULT_CLUSTER_ISSUER"),
+		ImageRegistry:    os.Getenv("TEMPORAL_OPERATOR_DEFAULT_IMAGE_REGISTRY"),
+		ImagePullSecrets: splitList(os.Getenv("TEMPORAL_OPERATOR_DEFAULT_IMAGE_PULL_SECRETS")),
+	})
+
+	fs.Func("default-mtls-provider", "The mTLS provider of clusters enabling mTLS without provider, one of: cert-manager, linkerd, istio.", func(value string) error {
+		return o.update(map[string]string{MTLSProviderKey: value})
+	})
+	fs.Func("default-cluster-issuer", "The cert-manager ClusterIssuer signing the root CA of clusters using cert-manager without issuer.", func(value string) error {
+		return o.update(map[string]string{ClusterIssuerKey: value})
+	})
+	fs.Func("default-image-registry", "The registry the default images of clusters are pulled from.", func(value string) error {
+		return o.update(map[string]string{ImageRegistryKey: value})
+	})
+	fs.Func("default-image-pull-secrets", "The comma separated image pull secrets of clusters without image pull secrets.", func(value string) error {
+		return o.update(map[string]string{ImagePullSecretsKey: value})
+	})
+	fs.StringVar(&o.ConfigMap, "defaults-configmap", "",
+		"The name of a ConfigMap in the operator namespace holding the mTLSProvider, clusterIssuer, imageRegistry and imagePullSecrets keys. When set, the defaults are reloaded from it at runtime.")
+}
+
+// Get returns the current defaults.
+func (o *Options) Get() Defaults {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	d := o.defaults
+	d.ImagePullSecrets = append([]string(nil), o.defaults.ImagePullSecrets...)
+	return d
+}
+
+// Set replaces the current defaults.
+func (o *Options) Set(d Defaults) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.defaults = d
+}
+
+// update applies the provided values on top of the current defaults, returning an error if one of them is invalid.
+// Invalid values are ignored.
+func (o *Options) update(data map[string]string) error {
+	d, err := parse(o.Get(), data)
+	o.Set(d)
+	return err
+}
+
+// parse returns the current defaults overridden by the provided values.
+func parse(current Defaults, data map[string]string) (Defaults, error) {
+	var errs []error
+
+	if value, ok := data[MTLSProviderKey]; ok {
+		switch provider := v1beta1.MTLSProvider(value); provider {
+		case "", v1beta1.CertManagerMTLSProvider, v1beta1.LinkerdMTLSProvider, v1beta1.IstioMTLSProvider:
+			current.MTLSProvider = provider
+		default:
+			errs = append(errs, fmt.Errorf("unsupported mTLS provider %q, must be one of: cert-manager, linkerd, istio", value))
+		}
+	}
+
+	if value, ok := data[ClusterIssuerKey]; ok {
+		current.ClusterIssuer = strings.TrimSpace(value)
+	}
+
+	if value, ok := data[ImageRegistryKey]; ok {
+		current.ImageRegistry = strings.TrimSuffix(strings.TrimSpace(value), "/")
+	}
+
+	if value, ok := data[ImagePullSecretsKey]; ok {
+		current.ImagePullSecrets = splitList(value)
+	}
+
+	return current, errors.Join(errs...)
+}
+
+// splitList splits the provided comma separated list, ignoring empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Apply sets the defaults on the cluster fields the user didn't specify.
+// It must be called before TemporalCluster.Default.
+func (d Defaults) Apply(cluster *v1beta1.TemporalCluster) {
+	if cluster.Spec.MTLS != nil {
+		if cluster.Spec.MTLS.Provider == "" && d.MTLSProvider != "" {
+			cluster.Spec.MTLS.Provider = d.MTLSProvider
+		}
+
+		provider := cluster.Spec.MTLS.Provider
+		if provider == "" {
+			provider = v1beta1.CertManagerMTLSProvider
+		}
+
+		if provider == v1beta1.CertManagerMTLSProvider && cluster.Spec.MTLS.IssuerRef == nil && d.ClusterIssuer != "" {
+			cluster.Spec.MTLS.IssuerRef = &v1beta1.CertificateIssuerReference{
+				Name: d.ClusterIssuer,
+				Kind: v1beta1.ClusterIssuerCertificateIssuerKind,
+			}
+		}
+	}
+
+	cluster.DefaultImagesFromRegistry(d.ImageRegistry)
+
+	if len(cluster.Spec.ImagePullSecrets) == 0 {
+		for _, name := range d.ImagePullSecrets {
+			cluster.Spec.ImagePullSecrets = append(cluster.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
+		}
+	}
+}
+
+// Reloader periodically applies the defaults stored in a ConfigMap.
+// The ConfigMap is read using an uncached reader, as the operator cache only holds operator-managed objects.
+type Reloader struct {
+	Options *Options
+	Reader  client.Reader
+	Key     types.NamespacedName
+}
+
+var (
+	_ manager.Runnable               = (*Reloader)(nil)
+	_ manager.LeaderElectionRunnable = (*Reloader)(nil)
+)
+
+// NeedLeaderElection returns false as all operator replicas serve the defaulting webhook.
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start reloads the defaults until the context is done.
+func (r *Reloader) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("defaults").WithValues("configmap", r.Key)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		changed, err := r.Reload(ctx)
+		if err != nil {
+			logger.Error(err, "Can't reload operator defaults")
+			return
+		}
+		if changed {
+			logger.Info("Operator defaults reloaded")
+		}
+	}, reloadInterval)
+
+	return nil
+}
+
+// Reload applies the defaults stored in the ConfigMap.
+// It returns true if the defaults changed. A missing ConfigMap leaves the defaults unchanged.
+func (r *Reloader) Reload(ctx context.Context) (bool, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Reader.Get(ctx, r.Key, cm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	current := r.Options.Get()
+	d, err := parse(current, cm.Data)
+	r.Options.Set(d)
+
+	return !equal(current, d), err
+}
+
+func equal(a, b Defaults) bool {
+	return a.MTLSProvider == b.MTLSProvider &&
+		a.ClusterIssuer == b.ClusterIssuer &&
+		a.ImageRegistry == b.ImageRegistry &&
+		slices.Equal(a.ImagePullSecrets, b.ImagePullSecrets)
+}
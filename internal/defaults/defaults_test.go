@@ -0,0 +1,164 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package defaults
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestApply(t *testing.T) {
+	defaults := Defaults{
+		MTLSProvider:     v1beta1.CertManagerMTLSProvider,
+		ClusterIssuer:    "corporate-ca",
+		ImageRegistry:    "registry.example.com/mirror",
+		ImagePullSecrets: []string{"mirror-credentials"},
+	}
+
+	tests := map[string]struct {
+		spec     v1beta1.TemporalClusterSpec
+		expected func(*testing.T, *v1beta1.TemporalCluster)
+	}{
+		"unset fields": {
+			spec: v1beta1.TemporalClusterSpec{
+				MTLS: &v1beta1.MTLSSpec{Internode: &v1beta1.InternodeMTLSSpec{Enabled: true}},
+			},
+			expected: func(t *testing.T, c *v1beta1.TemporalCluster) {
+				assert.Equal(t, v1beta1.CertManagerMTLSProvider, c.Spec.MTLS.Provider)
+				assert.Equal(t, &v1beta1.CertificateIssuerReference{Name: "corporate-ca", Kind: v1beta1.ClusterIssuerCertificateIssuerKind}, c.Spec.MTLS.IssuerRef)
+				assert.Equal(t, "registry.example.com/mirror/temporalio/server", c.Spec.Image)
+				assert.Equal(t, "registry.example.com/mirror/temporalio/ui", c.Spec.UI.Image)
+				assert.Equal(t, "registry.example.com/mirror/temporalio/admin-tools", c.Spec.AdminTools.Image)
+				assert.Equal(t, []corev1.LocalObjectReference{{Name: "mirror-credentials"}}, c.Spec.ImagePullSecrets)
+			},
+		},
+		"user provided fields": {
+			spec: v1beta1.TemporalClusterSpec{
+				Image:            "example.com/server",
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "team-credentials"}},
+				MTLS: &v1beta1.MTLSSpec{
+					Provider:  v1beta1.CertManagerMTLSProvider,
+					IssuerRef: &v1beta1.CertificateIssuerReference{Name: "team-ca", Kind: v1beta1.IssuerCertificateIssuerKind},
+				},
+			},
+			expected: func(t *testing.T, c *v1beta1.TemporalCluster) {
+				assert.Equal(t, &v1beta1.CertificateIssuerReference{Name: "team-ca", Kind: v1beta1.IssuerCertificateIssuerKind}, c.Spec.MTLS.IssuerRef)
+				assert.Equal(t, "example.com/server", c.Spec.Image)
+				assert.Equal(t, []corev1.LocalObjectReference{{Name: "team-credentials"}}, c.Spec.ImagePullSecrets)
+			},
+		},
+		"no issuer for other providers": {
+			spec: v1beta1.TemporalClusterSpec{
+				MTLS: &v1beta1.MTLSSpec{Provider: v1beta1.IstioMTLSProvider},
+			},
+			expected: func(t *testing.T, c *v1beta1.TemporalCluster) {
+				assert.Equal(t, v1beta1.IstioMTLSProvider, c.Spec.MTLS.Provider)
+				assert.Nil(t, c.Spec.MTLS.IssuerRef)
+			},
+		},
+		"registry host replaced": {
+			spec: v1beta1.TemporalClusterSpec{
+				UI: &v1beta1.TemporalUISpec{OAuth2Proxy: &v1beta1.TemporalUIOAuth2ProxySpec{}},
+			},
+			expected: func(t *testing.T, c *v1beta1.TemporalCluster) {
+				assert.Equal(t, "registry.example.com/mirror/oauth2-proxy/oauth2-proxy", c.Spec.UI.OAuth2Proxy.Image)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{Spec: test.spec}
+			defaults.Apply(cluster)
+			test.expected(tt, cluster)
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	key := types.NamespacedName{Name: "defaults", Namespace: "temporal-system"}
+
+	tests := map[string]struct {
+		data            map[string]string
+		missing         bool
+		expectedChanged bool
+		expectedErr     bool
+		expected        Defaults
+	}{
+		"missing configmap": {
+			missing:  true,
+			expected: Defaults{ImageRegistry: "docker.io"},
+		},
+		"unchanged": {
+			data:     map[string]string{ImageRegistryKey: "docker.io/"},
+			expected: Defaults{ImageRegistry: "docker.io"},
+		},
+		"changed": {
+			data: map[string]string{
+				MTLSProviderKey:     "cert-manager",
+				ClusterIssuerKey:    "corporate-ca",
+				ImagePullSecretsKey: "a, b,,",
+			},
+			expectedChanged: true,
+			expected: Defaults{
+				MTLSProvider:     v1beta1.CertManagerMTLSProvider,
+				ClusterIssuer:    "corporate-ca",
+				ImageRegistry:    "docker.io",
+				ImagePullSecrets: []string{"a", "b"},
+			},
+		},
+		"invalid provider keeps valid issuer": {
+			data:            map[string]string{MTLSProviderKey: "vault", ClusterIssuerKey: "corporate-ca"},
+			expectedChanged: true,
+			expectedErr:     true,
+			expected:        Defaults{ClusterIssuer: "corporate-ca", ImageRegistry: "docker.io"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			builder := fake.NewClientBuilder()
+			if !test.missing {
+				builder = builder.WithObjects(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+					Data:       test.data,
+				})
+			}
+
+			opts := NewOptions()
+			opts.Set(Defaults{ImageRegistry: "docker.io"})
+			reloader := &Reloader{Options: opts, Reader: builder.Build(), Key: key}
+
+			changed, err := reloader.Reload(context.Background())
+			if test.expectedErr {
+				assert.Error(tt, err)
+			} else {
+				assert.NoError(tt, err)
+			}
+			assert.Equal(tt, test.expectedChanged, changed)
+			assert.Equal(tt, test.expected, opts.Get())
+		})
+	}
+}
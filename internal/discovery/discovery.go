@@ -26,6 +26,28 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	istionetworkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	istiosecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	kdiscovery "k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// routeGroupVersion is the openshift Route API group version.
+	routeGroupVersion = "route.openshift.io/v1"
+	// kedaGroupVersion is the KEDA API group version.
+	kedaGroupVersion = "keda.sh/v1alpha1"
+)
+
+var (
+	// minSupportedVersion is the oldest kubernetes version supported by the operator.
+	minSupportedVersion = utilversion.MustParseGeneric("1.23.0")
+	// minGRPCProbesVersion is the first kubernetes version enabling gRPC probes by default.
+	minGRPCProbesVersion = utilversion.MustParseGeneric("1.24.0")
+	// minCELValidationVersion is the first kubernetes version enforcing the CEL validation rules of the CRDs by default.
+	minCELValidationVersion = utilversion.MustParseGeneric("1.25.0")
+	// minTopologyModeVersion is the first kubernetes version supporting the service.kubernetes.io/topology-mode annotation.
+	minTopologyModeVersion = utilversion.MustParseGeneric("1.27.0")
 )
 
 // AvailableAPIs holds available apis in the cluster.
@@ -33,6 +55,18 @@ type AvailableAPIs struct {
 	Istio              bool
 	CertManager        bool
 	PrometheusOperator bool
+	// KubernetesVersion is the version of the kubernetes cluster, empty if it can't be determined.
+	KubernetesVersion string
+	// GRPCProbes is true if the kubernetes cluster supports gRPC container probes.
+	GRPCProbes bool
+	// CELValidation is true if the kubernetes cluster enforces the CEL validation rules of the CRDs.
+	CELValidation bool
+	// TopologyMode is true if the kubernetes cluster supports the service.kubernetes.io/topology-mode annotation.
+	TopologyMode bool
+	// Routes is true if the openshift Route API is available.
+	Routes bool
+	// KEDA is true if the KEDA ScaledObject API is available.
+	KEDA bool
 }
 
 // FindAvailableAPIs searches for available well-known APIs in the cluster.
@@ -62,6 +96,105 @@ func FindAvailableAPIs(logger logr.Logger, mgr discovery.Manager) (*AvailableAPI
 	return resources, nil
 }
 
+// FindVersionGatedFeatures enables the features requiring a minimum kubernetes version, and logs a report
+// of the features falling back to older APIs. If the kubernetes version can't be determined, these features
+// are disabled and the error is returned.
+func FindVersionGatedFeatures(logger logr.Logger, cfg *rest.Config, resources *AvailableAPIs) error {
+	serverVersion, err := kubernetesVersion(cfg)
+	if err != nil {
+		resources.GRPCProbes = false
+		resources.CELValidation = false
+		resources.TopologyMode = false
+		return err
+	}
+
+	resources.KubernetesVersion = serverVersion.String()
+	resources.GRPCProbes = serverVersion.AtLeast(minGRPCProbesVersion)
+	resources.CELValidation = serverVersion.AtLeast(minCELValidationVersion)
+	resources.TopologyMode = serverVersion.AtLeast(minTopologyModeVersion)
+
+	if !serverVersion.AtLeast(minSupportedVersion) {
+		logger.Info("Kubernetes version is older than the minimum supported version, some resources may fail to be created",
+			"version", resources.KubernetesVersion, "minVersion", minSupportedVersion.String())
+	}
+
+	logVersionGatedFeature(logger, "gRPC probes", resources.GRPCProbes, minGRPCProbesVersion, "services are probed using TCP probes")
+	logVersionGatedFeature(logger, "CRDs CEL validation rules", resources.CELValidation, minCELValidationVersion,
+		"the admission webhook enforces the TemporalCluster rules, the rules of other resources are not enforced")
+	logVersionGatedFeature(logger, "topology-mode annotation", resources.TopologyMode, minTopologyModeVersion,
+		"topology aware routing uses the service.kubernetes.io/topology-aware-hints annotation")
+
+	return nil
+}
+
+// kubernetesVersion returns the version of the kubernetes cluster.
+func kubernetesVersion(cfg *rest.Config) (*utilversion.Version, error) {
+	client, err := kdiscovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := client.ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("can't get kubernetes server version: %w", err)
+	}
+
+	serverVersion, err := utilversion.ParseGeneric(info.GitVersion)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse kubernetes server version: %w", err)
+	}
+
+	return serverVersion, nil
+}
+
+// SupportsRoutes returns true if the openshift Route API is available in the kubernetes cluster.
+func SupportsRoutes(logger logr.Logger, cfg *rest.Config) (bool, error) {
+	return supportsKinds(logger, cfg, "openshift routes", routeGroupVersion, "Route")
+}
+
+// SupportsKEDA returns true if the KEDA ScaledObject and TriggerAuthentication APIs are available in the kubernetes cluster.
+func SupportsKEDA(logger logr.Logger, cfg *rest.Config) (bool, error) {
+	return supportsKinds(logger, cfg, "keda", kedaGroupVersion, "ScaledObject", "TriggerAuthentication")
+}
+
+// supportsKinds returns true if all the provided kinds of the group version are served by the kubernetes cluster.
+// It's used for APIs the operator handles as unstructured objects, as their types are not registered in the scheme.
+func supportsKinds(logger logr.Logger, cfg *rest.Config, apiName, groupVersion string, kinds ...string) (bool, error) {
+	client, err := kdiscovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return false, err
+	}
+
+	served := map[string]bool{}
+	resources, err := client.ServerResourcesForGroupVersion(groupVersion)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return false, fmt.Errorf("can't determine if %s is available: %w", apiName, err)
+	default:
+		for _, resource := range resources.APIResources {
+			served[resource.Kind] = true
+		}
+	}
+
+	found := true
+	for _, kind := range kinds {
+		found = found && served[kind]
+	}
+
+	logResourceAvailability(logger, apiName, found)
+
+	return found, nil
+}
+
+func logVersionGatedFeature(logger logr.Logger, feature string, enabled bool, minVersion *utilversion.Version, fallback string) {
+	if enabled {
+		logger.Info(fmt.Sprintf("Kubernetes supports %s, features requiring it are enabled", feature))
+		return
+	}
+	logger.Info(fmt.Sprintf("Kubernetes doesn't support %s, %s", feature, fallback), "minVersion", minVersion.String())
+}
+
 func logResourceAvailability(logger logr.Logger, apiName string, found bool) {
 	var msg string
 	if found {
@@ -0,0 +1,49 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package logging
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ClusterKey is the log key holding the name of the temporal cluster an object belongs to.
+	ClusterKey = "cluster"
+	// NamespaceKey is the log key holding the kubernetes namespace of the logged object.
+	NamespaceKey = "namespace"
+	// GenerationKey is the log key holding the generation of the logged object.
+	GenerationKey = "generation"
+	// ReconcileIDKey is the log key correlating all log lines of a reconciliation or an admission request.
+	// It matches the key used by controller-runtime.
+	ReconcileIDKey = "reconcileID"
+)
+
+// WithCluster returns a copy of the context holding a logger with the cluster correlation fields.
+// cluster is the name of the temporal cluster the provided object belongs to.
+func WithCluster(ctx context.Context, cluster string, obj client.Object) (context.Context, logr.Logger) {
+	logger := log.FromContext(ctx).WithValues(
+		ClusterKey, cluster,
+		NamespaceKey, obj.GetNamespace(),
+		GenerationKey, obj.GetGeneration(),
+	)
+	return log.IntoContext(ctx, logger), logger
+}
@@ -0,0 +1,187 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package logging configures the operator logger and provides helpers to
+// attach correlation fields to log lines.
+package logging
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	crzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// Format is the log lines encoding.
+type Format string
+
+const (
+	// JSONFormat encodes log lines as JSON objects.
+	JSONFormat Format = "json"
+	// ConsoleFormat encodes log lines in a human readable way.
+	ConsoleFormat Format = "console"
+)
+
+// ParseFormat parses the provided log format.
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case JSONFormat:
+		return JSONFormat, nil
+	case ConsoleFormat:
+		return ConsoleFormat, nil
+	default:
+		return "", fmt.Errorf("unsupported log format %q, must be one of: json, console", value)
+	}
+}
+
+// ParseLevel parses the provided log level.
+// It accepts zap level names (debug, info, error, ...) or a positive integer matching logr verbosity levels.
+func ParseLevel(value string) (zapcore.Level, error) {
+	if v, err := strconv.Atoi(value); err == nil {
+		if v < 0 {
+			return 0, fmt.Errorf("invalid log level %d, verbosity levels must be positive", v)
+		}
+		return zapcore.Level(-v), nil
+	}
+
+	level, err := zapcore.ParseLevel(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid log level %q: %w", value, err)
+	}
+	return level, nil
+}
+
+// Options configures the operator logger.
+// Both the format and the level can be changed at runtime, see Options.SetFormat and Options.SetLevel.
+type Options struct {
+	// ConfigMap is the name of the ConfigMap the logging configuration is reloaded from, if set.
+	ConfigMap string
+
+	level  zap.AtomicLevel
+	format atomic.Value
+}
+
+// NewOptions returns the default logger options: console format at info level.
+func NewOptions() *Options {
+	o := &Options{
+		level: zap.NewAtomicLevelAt(zapcore.InfoLevel),
+	}
+	o.format.Store(ConsoleFormat)
+	return o
+}
+
+// BindFlags binds the logging flags to the provided flagset.
+func (o *Options) BindFlags(fs *flag.FlagSet) {
+	fs.Func("log-format", "The log lines encoding, one of: json, console. Defaults to console.", o.SetFormat)
+	fs.Func("log-level", "The log level, one of: debug, info, error, or a positive integer for verbosity levels. Defaults to info.", o.SetLevel)
+	fs.StringVar(&o.ConfigMap, "log-config-configmap", "",
+		"The name of a ConfigMap in the operator namespace holding the logFormat and logLevel keys. When set, the logging configuration is reloaded from it at runtime.")
+}
+
+// Format returns the current log format.
+func (o *Options) Format() Format {
+	return o.format.Load().(Format)
+}
+
+// SetFormat changes the log format.
+func (o *Options) SetFormat(value string) error {
+	format, err := ParseFormat(value)
+	if err != nil {
+		return err
+	}
+	o.format.Store(format)
+	return nil
+}
+
+// Level returns the current log level.
+func (o *Options) Level() zapcore.Level {
+	return o.level.Level()
+}
+
+// SetLevel changes the log level.
+func (o *Options) SetLevel(value string) error {
+	level, err := ParseLevel(value)
+	if err != nil {
+		return err
+	}
+	o.level.SetLevel(level)
+	return nil
+}
+
+// NewLogger builds a logger writing to stderr honoring the options, even when changed at runtime.
+func (o *Options) NewLogger() logr.Logger {
+	sink := zapcore.Lock(os.Stderr)
+
+	jsonConfig := zap.NewProductionEncoderConfig()
+	jsonConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	consoleConfig := zap.NewDevelopmentEncoderConfig()
+
+	core := &switchableCore{
+		format: &o.format,
+		cores: map[Format]zapcore.Core{
+			JSONFormat:    zapcore.NewCore(&crzap.KubeAwareEncoder{Encoder: zapcore.NewJSONEncoder(jsonConfig)}, sink, o.level),
+			ConsoleFormat: zapcore.NewCore(&crzap.KubeAwareEncoder{Encoder: zapcore.NewConsoleEncoder(consoleConfig)}, sink, o.level),
+		},
+	}
+
+	return zapr.NewLogger(zap.New(core, zap.AddCaller(), zap.ErrorOutput(sink)))
+}
+
+// switchableCore delegates to the core matching the current format.
+type switchableCore struct {
+	format *atomic.Value
+	cores  map[Format]zapcore.Core
+}
+
+var _ zapcore.Core = (*switchableCore)(nil)
+
+func (c *switchableCore) current() zapcore.Core {
+	return c.cores[c.format.Load().(Format)]
+}
+
+func (c *switchableCore) Enabled(level zapcore.Level) bool {
+	return c.current().Enabled(level)
+}
+
+func (c *switchableCore) With(fields []zapcore.Field) zapcore.Core {
+	cores := make(map[Format]zapcore.Core, len(c.cores))
+	for format, core := range c.cores {
+		cores[format] = core.With(fields)
+	}
+	return &switchableCore{format: c.format, cores: cores}
+}
+
+func (c *switchableCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return c.current().Check(entry, checked)
+}
+
+func (c *switchableCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.current().Write(entry, fields)
+}
+
+func (c *switchableCore) Sync() error {
+	return c.current().Sync()
+}
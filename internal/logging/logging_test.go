@@ -0,0 +1,149 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]struct {
+		value       string
+		expected    zapcore.Level
+		expectedErr bool
+	}{
+		"name":            {value: "debug", expected: zapcore.DebugLevel},
+		"upper case name": {value: "ERROR", expected: zapcore.ErrorLevel},
+		"verbosity":       {value: "3", expected: zapcore.Level(-3)},
+		"zero verbosity":  {value: "0", expected: zapcore.InfoLevel},
+		"negative":        {value: "-1", expectedErr: true},
+		"unknown":         {value: "verbose", expectedErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			level, err := ParseLevel(test.value)
+			if test.expectedErr {
+				assert.Error(tt, err)
+				return
+			}
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expected, level)
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	key := types.NamespacedName{Name: "logging", Namespace: "temporal-system"}
+
+	tests := map[string]struct {
+		data            map[string]string
+		missing         bool
+		expectedChanged bool
+		expectedErr     bool
+		expectedFormat  Format
+		expectedLevel   zapcore.Level
+	}{
+		"missing configmap": {
+			missing:        true,
+			expectedFormat: ConsoleFormat,
+			expectedLevel:  zapcore.InfoLevel,
+		},
+		"unchanged": {
+			data:           map[string]string{FormatKey: "console", LevelKey: "info"},
+			expectedFormat: ConsoleFormat,
+			expectedLevel:  zapcore.InfoLevel,
+		},
+		"changed": {
+			data:            map[string]string{FormatKey: "json", LevelKey: "2"},
+			expectedChanged: true,
+			expectedFormat:  JSONFormat,
+			expectedLevel:   zapcore.Level(-2),
+		},
+		"invalid format keeps valid level": {
+			data:            map[string]string{FormatKey: "xml", LevelKey: "debug"},
+			expectedChanged: true,
+			expectedErr:     true,
+			expectedFormat:  ConsoleFormat,
+			expectedLevel:   zapcore.DebugLevel,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			builder := fake.NewClientBuilder()
+			if !test.missing {
+				builder = builder.WithObjects(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+					Data:       test.data,
+				})
+			}
+
+			opts := NewOptions()
+			reloader := &Reloader{Options: opts, Reader: builder.Build(), Key: key}
+
+			changed, err := reloader.Reload(context.Background())
+			if test.expectedErr {
+				assert.Error(tt, err)
+			} else {
+				assert.NoError(tt, err)
+			}
+			assert.Equal(tt, test.expectedChanged, changed)
+			assert.Equal(tt, test.expectedFormat, opts.Format())
+			assert.Equal(tt, test.expectedLevel, opts.Level())
+		})
+	}
+}
+
+func TestSwitchableCoreFollowsFormat(t *testing.T) {
+	jsonCore, jsonLogs := observer.New(zapcore.InfoLevel)
+	consoleCore, consoleLogs := observer.New(zapcore.InfoLevel)
+
+	opts := NewOptions()
+	core := (&switchableCore{
+		format: &opts.format,
+		cores: map[Format]zapcore.Core{
+			JSONFormat:    jsonCore,
+			ConsoleFormat: consoleCore,
+		},
+	}).With([]zapcore.Field{zap.String(ClusterKey, "prod")})
+	logger := zap.New(core)
+
+	logger.Info("first")
+	require.NoError(t, opts.SetFormat("json"))
+	logger.Info("second")
+
+	require.Equal(t, 1, consoleLogs.Len())
+	assert.Equal(t, "first", consoleLogs.All()[0].Message)
+	assert.Equal(t, "prod", consoleLogs.All()[0].ContextMap()[ClusterKey])
+
+	require.Equal(t, 1, jsonLogs.Len())
+	assert.Equal(t, "second", jsonLogs.All()[0].Message)
+	assert.Equal(t, "prod", jsonLogs.All()[0].ContextMap()[ClusterKey])
+}
@@ -0,0 +1,121 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package logging
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// FormatKey is the ConfigMap key holding the log format.
+	FormatKey = "logFormat"
+	// LevelKey is the ConfigMap key holding the log level.
+	LevelKey = "logLevel"
+
+	// reloadInterval is the interval between two reads of the logging ConfigMap.
+	reloadInterval = 30 * time.Second
+)
+
+// Reloader periodically applies the logging configuration stored in a ConfigMap.
+// The ConfigMap is read using an uncached reader, as the operator cache only holds operator-managed objects.
+type Reloader struct {
+	Options *Options
+	Reader  client.Reader
+	Key     types.NamespacedName
+}
+
+var (
+	_ manager.Runnable               = (*Reloader)(nil)
+	_ manager.LeaderElectionRunnable = (*Reloader)(nil)
+)
+
+// NeedLeaderElection returns false as all operator replicas log.
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start reloads the logging configuration until the context is done.
+func (r *Reloader) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("logging").WithValues("configmap", r.Key)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		changed, err := r.Reload(ctx)
+		if err != nil {
+			logger.Error(err, "Can't reload logging configuration")
+			return
+		}
+		if changed {
+			logger.Info("Logging configuration reloaded", "format", r.Options.Format(), "level", r.Options.Level())
+		}
+	}, reloadInterval)
+
+	return nil
+}
+
+// Reload applies the logging configuration stored in the ConfigMap.
+// It returns true if the configuration changed. A missing ConfigMap leaves the configuration unchanged.
+func (r *Reloader) Reload(ctx context.Context) (bool, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Reader.Get(ctx, r.Key, cm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return r.Options.apply(cm.Data)
+}
+
+// apply applies the provided logging configuration, returning true if it changed.
+func (o *Options) apply(data map[string]string) (bool, error) {
+	var errs []error
+	changed := false
+
+	if value, ok := data[FormatKey]; ok {
+		format, err := ParseFormat(value)
+		if err != nil {
+			errs = append(errs, err)
+		} else if format != o.Format() {
+			o.format.Store(format)
+			changed = true
+		}
+	}
+
+	if value, ok := data[LevelKey]; ok {
+		level, err := ParseLevel(value)
+		if err != nil {
+			errs = append(errs, err)
+		} else if level != o.Level() {
+			o.level.SetLevel(level)
+			changed = true
+		}
+	}
+
+	return changed, errors.Join(errs...)
+}
@@ -17,6 +17,11 @@
 
 package metadata
 
+import (
+	"strconv"
+	"strings"
+)
+
 // GetAnnotations returns service annotations.
 func GetAnnotations(_ string, annotations ...map[string]string) map[string]string {
 	return Merge(annotations...)
@@ -33,3 +38,31 @@ func FilterAnnotations(annotations map[string]string, fn func(k, v string) bool)
 	}
 	return result
 }
+
+const (
+	externalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+	externalDNSTTLAnnotation      = "external-dns.alpha.kubernetes.io/ttl"
+)
+
+// GetExternalDNSAnnotations returns the external-dns annotations publishing the provided hostnames.
+// It returns an empty map if no hostnames are provided.
+func GetExternalDNSAnnotations(hostnames []string, ttl *int64) map[string]string {
+	result := make(map[string]string)
+	if len(hostnames) == 0 {
+		return result
+	}
+
+	result[externalDNSHostnameAnnotation] = strings.Join(hostnames, ",")
+	if ttl != nil {
+		result[externalDNSTTLAnnotation] = strconv.FormatInt(*ttl, 10)
+	}
+	return result
+}
+
+// RemoveExternalDNSAnnotations returns a copy of the provided annotations without the external-dns annotations.
+// It allows removing the annotations from existing objects once the hostnames are unset.
+func RemoveExternalDNSAnnotations(annotations map[string]string) map[string]string {
+	return FilterAnnotations(annotations, func(k, _ string) bool {
+		return k != externalDNSHostnameAnnotation && k != externalDNSTTLAnnotation
+	})
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 )
 
 func TestFilterAnnotations(t *testing.T) {
@@ -85,3 +86,72 @@ func TestFilterAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExternalDNSAnnotations(t *testing.T) {
+	tests := map[string]struct {
+		hostnames []string
+		ttl       *int64
+		expected  map[string]string
+	}{
+		"no hostnames": {
+			hostnames: nil,
+			expected:  map[string]string{},
+		},
+		"single hostname": {
+			hostnames: []string{"temporal.example.com"},
+			expected: map[string]string{
+				"external-dns.alpha.kubernetes.io/hostname": "temporal.example.com",
+			},
+		},
+		"multiple hostnames with ttl": {
+			hostnames: []string{"temporal.example.com", "temporal.example.org"},
+			ttl:       ptr.To[int64](60),
+			expected: map[string]string{
+				"external-dns.alpha.kubernetes.io/hostname": "temporal.example.com,temporal.example.org",
+				"external-dns.alpha.kubernetes.io/ttl":      "60",
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			result := metadata.GetExternalDNSAnnotations(test.hostnames, test.ttl)
+			assert.Equal(tt, test.expected, result)
+		})
+	}
+}
+
+func TestRemoveExternalDNSAnnotations(t *testing.T) {
+	tests := map[string]struct {
+		annotations map[string]string
+		expected    map[string]string
+	}{
+		"nil annotations": {
+			annotations: nil,
+			expected:    map[string]string{},
+		},
+		"external-dns annotations are removed": {
+			annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/hostname": "temporal.example.com",
+				"external-dns.alpha.kubernetes.io/ttl":      "60",
+				"a":                                         "b",
+			},
+			expected: map[string]string{
+				"a": "b",
+			},
+		},
+		"other external-dns annotations are kept": {
+			annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/target": "1.2.3.4",
+			},
+			expected: map[string]string{
+				"external-dns.alpha.kubernetes.io/target": "1.2.3.4",
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			result := metadata.RemoveExternalDNSAnnotations(test.annotations)
+			assert.Equal(tt, test.expected, result)
+		})
+	}
+}
@@ -38,23 +38,21 @@ func LabelsSelector(owner OwnerObject, serviceName string) map[string]string {
 }
 
 // GetLabels returns a Labels for a temporal service.
+// The provided labels can't override the selector labels, as they are used to select
+// the objects managed by the operator.
 func GetLabels(owner OwnerObject, service string, version *version.Version, labels map[string]string) map[string]string {
-	l := LabelsSelector(owner, service)
-	l["app.kubernetes.io/version"] = version.String()
-	for k, v := range labels {
-		l[k] = v
-	}
-	return l
+	return GetVersionStringLabels(owner, service, version.String(), labels)
 }
 
 // GetLabels returns a Labels for a temporal service using string Version.
 func GetVersionStringLabels(owner OwnerObject, service string, version string, labels map[string]string) map[string]string {
-	l := LabelsSelector(owner, service)
-	l["app.kubernetes.io/version"] = version
-	for k, v := range labels {
-		l[k] = v
-	}
-	return l
+	return Merge(
+		map[string]string{
+			"app.kubernetes.io/version": version,
+		},
+		labels,
+		LabelsSelector(owner, service),
+	)
 }
 
 // HeadlessLabels returns labels to express that a service is headless.
@@ -0,0 +1,281 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/internal/buildinfo"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// BuildInfo exposes the running operator build, helping to spot version skew between operator replicas.
+	BuildInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "temporal_operator_build_info",
+			Help: "Running operator build version, commit and go version.",
+		},
+		[]string{"version", "commit", "goversion"},
+	)
+
+	// FleetRolloutsInProgress exposes the number of clusters holding a fleet rollout slot.
+	FleetRolloutsInProgress = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "temporal_operator_fleet_rollouts_in_progress",
+			Help: "Number of TemporalClusters rolling out their pods after operator initiated changes.",
+		},
+	)
+
+	// FleetRolloutsWaiting exposes the number of clusters waiting for a fleet rollout slot.
+	FleetRolloutsWaiting = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "temporal_operator_fleet_rollouts_waiting",
+			Help: "Number of TemporalClusters whose operator initiated rollout is held back.",
+		},
+	)
+
+	// SupportedVersionRange exposes the temporal versions range supported by the operator.
+	SupportedVersionRange = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "temporal_operator_supported_version_range_info",
+			Help: "Temporal server versions range supported by the operator.",
+		},
+		[]string{"min_version", "max_version", "deprecated_before"},
+	)
+
+	// ClusterReconcileDuration exposes the duration of each TemporalCluster reconciliation.
+	ClusterReconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "temporal_operator_cluster_reconcile_duration_seconds",
+			Help:    "Duration of TemporalCluster reconciliations.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 12),
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// ClusterReconcileTotal exposes the number of TemporalCluster reconciliations.
+	ClusterReconcileTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "temporal_operator_cluster_reconcile_total",
+			Help: "Total number of TemporalCluster reconciliations.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// DeploymentRollouts exposes the number of deployment rollouts triggered by the operator.
+	// The cause is "spec" when the cluster spec changed, "operator" otherwise (e.g. after an operator upgrade).
+	DeploymentRollouts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "temporal_operator_deployment_rollouts_total",
+			Help: "Total number of deployment rollouts triggered by the operator.",
+		},
+		[]string{"namespace", "name", "deployment", "cause"},
+	)
+
+	// ClusterReconcileErrors exposes the number of failed TemporalCluster reconciliations.
+	ClusterReconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "temporal_operator_cluster_reconcile_errors_total",
+			Help: "Total number of failed TemporalCluster reconciliations.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// CanaryRuns exposes the number of canary workflow runs, by result ("success" or "failure").
+	CanaryRuns = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "temporal_operator_canary_runs_total",
+			Help: "Total number of canary workflow runs.",
+		},
+		[]string{"namespace", "name", "result"},
+	)
+
+	// CanaryDuration exposes the duration of the successful canary workflow runs.
+	CanaryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "temporal_operator_canary_duration_seconds",
+			Help:    "Duration of successful canary workflow runs.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 12),
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// SchemaJobDuration exposes the duration of the cluster schema jobs, still running jobs included.
+	SchemaJobDuration = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "temporal_operator_schema_job_duration_seconds",
+			Help: "Duration of TemporalCluster schema jobs.",
+		},
+		[]string{"namespace", "name", "schema_job"},
+	)
+
+	// SchemaJobFailures exposes the number of failed pods of the cluster schema jobs.
+	SchemaJobFailures = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "temporal_operator_schema_job_failures",
+			Help: "Number of failed pods of TemporalCluster schema jobs.",
+		},
+		[]string{"namespace", "name", "schema_job"},
+	)
+
+	// NamespaceReconcilesPending exposes the number of TemporalNamespaces waiting for the cluster rate limit.
+	NamespaceReconcilesPending = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "temporal_operator_namespace_reconciles_pending",
+			Help: "Number of TemporalNamespaces waiting for the cluster namespaces rate limit.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// NamespaceReconcilesThrottled exposes the number of TemporalNamespace reconciliations delayed by the cluster rate limit.
+	NamespaceReconcilesThrottled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "temporal_operator_namespace_reconciles_throttled_total",
+			Help: "Total number of TemporalNamespace reconciliations delayed by the cluster namespaces rate limit.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// SchemaJobSucceeded exposes whether the cluster schema jobs succeeded (1) or not (0).
+	SchemaJobSucceeded = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "temporal_operator_schema_job_succeeded",
+			Help: "Whether TemporalCluster schema jobs succeeded.",
+		},
+		[]string{"namespace", "name", "schema_job"},
+	)
+)
+
+// ObserveClusterReconcile records the outcome of a TemporalCluster reconciliation.
+func ObserveClusterReconcile(namespace, name string, duration time.Duration, err error) {
+	ClusterReconcileDuration.WithLabelValues(namespace, name).Observe(duration.Seconds())
+	ClusterReconcileTotal.WithLabelValues(namespace, name).Inc()
+	// Always initialize the errors counter so that error ratios can be computed.
+	errCounter := ClusterReconcileErrors.WithLabelValues(namespace, name)
+	if err != nil {
+		errCounter.Inc()
+	}
+}
+
+const (
+	// SpecRolloutCause is the rollout cause when the cluster spec changed.
+	SpecRolloutCause = "spec"
+	// OperatorRolloutCause is the rollout cause when the cluster spec didn't change.
+	OperatorRolloutCause = "operator"
+)
+
+// ObserveDeploymentRollout records a deployment rollout triggered by the operator.
+func ObserveDeploymentRollout(namespace, name, deployment, cause string) {
+	DeploymentRollouts.WithLabelValues(namespace, name, deployment, cause).Inc()
+}
+
+const (
+	// CanarySuccessResult is the result of a successful canary run.
+	CanarySuccessResult = "success"
+	// CanaryFailureResult is the result of a failed canary run.
+	CanaryFailureResult = "failure"
+)
+
+// ObserveCanaryRun records the outcome of a canary workflow run.
+func ObserveCanaryRun(namespace, name string, duration time.Duration, err error) {
+	// Always initialize both results so that success rates can be computed.
+	success := CanaryRuns.WithLabelValues(namespace, name, CanarySuccessResult)
+	failure := CanaryRuns.WithLabelValues(namespace, name, CanaryFailureResult)
+	if err != nil {
+		failure.Inc()
+		return
+	}
+	success.Inc()
+	CanaryDuration.WithLabelValues(namespace, name).Observe(duration.Seconds())
+}
+
+// ObserveSchemaJob records the state of a cluster schema job.
+func ObserveSchemaJob(namespace, name, job string, duration time.Duration, failures int32, succeeded bool) {
+	SchemaJobDuration.WithLabelValues(namespace, name, job).Set(duration.Seconds())
+	SchemaJobFailures.WithLabelValues(namespace, name, job).Set(float64(failures))
+	success := 0.0
+	if succeeded {
+		success = 1
+	}
+	SchemaJobSucceeded.WithLabelValues(namespace, name, job).Set(success)
+}
+
+// ForgetSchemaJob removes the metrics of a deleted cluster schema job.
+func ForgetSchemaJob(namespace, name, job string) {
+	SchemaJobDuration.DeleteLabelValues(namespace, name, job)
+	SchemaJobFailures.DeleteLabelValues(namespace, name, job)
+	SchemaJobSucceeded.DeleteLabelValues(namespace, name, job)
+}
+
+// ObserveNamespaceReconcile records the number of TemporalNamespaces waiting for the cluster rate limit,
+// and whether the current reconciliation was throttled.
+func ObserveNamespaceReconcile(namespace, name string, pending int, throttled bool) {
+	NamespaceReconcilesPending.WithLabelValues(namespace, name).Set(float64(pending))
+	// Always initialize the counter so that throttling rates can be computed.
+	counter := NamespaceReconcilesThrottled.WithLabelValues(namespace, name)
+	if throttled {
+		counter.Inc()
+	}
+}
+
+// ForgetCluster removes the per-cluster metrics of a deleted TemporalCluster.
+func ForgetCluster(namespace, name string) {
+	ClusterReconcileDuration.DeleteLabelValues(namespace, name)
+	ClusterReconcileTotal.DeleteLabelValues(namespace, name)
+	ClusterReconcileErrors.DeleteLabelValues(namespace, name)
+	DeploymentRollouts.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name})
+	CanaryRuns.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name})
+	CanaryDuration.DeleteLabelValues(namespace, name)
+	SchemaJobDuration.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name})
+	SchemaJobFailures.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name})
+	SchemaJobSucceeded.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name})
+	NamespaceReconcilesPending.DeleteLabelValues(namespace, name)
+	NamespaceReconcilesThrottled.DeleteLabelValues(namespace, name)
+}
+
+func init() {
+	metrics.Registry.MustRegister(
+		BuildInfo,
+		SupportedVersionRange,
+		ClusterReconcileDuration,
+		ClusterReconcileTotal,
+		ClusterReconcileErrors,
+		DeploymentRollouts,
+		CanaryRuns,
+		CanaryDuration,
+		SchemaJobDuration,
+		SchemaJobFailures,
+		SchemaJobSucceeded,
+		NamespaceReconcilesPending,
+		NamespaceReconcilesThrottled,
+		FleetRolloutsInProgress,
+		FleetRolloutsWaiting,
+	)
+
+	build := buildinfo.Get()
+	BuildInfo.WithLabelValues(build.Version, build.Commit, build.GoVersion).Set(1)
+
+	SupportedVersionRange.WithLabelValues(
+		version.Compatibility.MinVersion,
+		version.Compatibility.MaxVersion,
+		version.Compatibility.DeprecatedBefore,
+	).Set(1)
+}
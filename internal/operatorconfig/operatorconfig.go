@@ -0,0 +1,255 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package operatorconfig applies the operator runtime settings stored in a TemporalOperatorConfig,
+// allowing the operator to be tuned without being redeployed.
+package operatorconfig
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/defaults"
+	"github.com/alexandrevilain/temporal-operator/pkg/notification"
+	"github.com/alexandrevilain/temporal-operator/pkg/ratelimit"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// reloadInterval is the interval between two reads of the operator config.
+const reloadInterval = 30 * time.Second
+
+// Settings are the operator runtime settings.
+type Settings struct {
+	// WatchNamespaces are the namespaces watched by the operator, all namespaces if empty.
+	// Changes require an operator restart.
+	WatchNamespaces []string
+	// NamespaceWorkers is the number of TemporalNamespaces reconciled in parallel.
+	// Changes require an operator restart.
+	NamespaceWorkers int
+	// NamespaceRateLimit is the maximum number of TemporalNamespaces reconciliations per second and per cluster.
+	NamespaceRateLimit float64
+	// NamespaceRateLimitBurst is the number of TemporalNamespaces reconciliations allowed in a burst per cluster.
+	NamespaceRateLimitBurst int
+	// MaxConcurrentClusterRollouts is the maximum number of TemporalClusters rolling out their pods at the same time.
+	MaxConcurrentClusterRollouts int
+	// Defaults are the values applied to the clusters that don't specify them.
+	Defaults defaults.Defaults
+	// NotificationURL is the webhook URL clusters lifecycle events are sent to.
+	NotificationURL string
+}
+
+// Resolve returns the base settings, set by the operator flags, overridden by the fields set in the config spec.
+func Resolve(base Settings, spec *v1beta1.TemporalOperatorConfigSpec) Settings {
+	settings := base
+	settings.WatchNamespaces = append([]string(nil), base.WatchNamespaces...)
+	settings.Defaults.ImagePullSecrets = append([]string(nil), base.Defaults.ImagePullSecrets...)
+
+	if spec == nil {
+		return settings
+	}
+
+	if len(spec.WatchNamespaces) > 0 {
+		settings.WatchNamespaces = append([]string(nil), spec.WatchNamespaces...)
+	}
+
+	if c := spec.Concurrency; c != nil {
+		if c.NamespaceWorkers != nil {
+			settings.NamespaceWorkers = int(*c.NamespaceWorkers)
+		}
+		if c.NamespaceRateLimit != nil {
+			settings.NamespaceRateLimit = float64(*c.NamespaceRateLimit)
+		}
+		if c.NamespaceRateLimitBurst != nil {
+			settings.NamespaceRateLimitBurst = int(*c.NamespaceRateLimitBurst)
+		}
+		if c.MaxConcurrentClusterRollouts != nil {
+			settings.MaxConcurrentClusterRollouts = int(*c.MaxConcurrentClusterRollouts)
+		}
+	}
+
+	if d := spec.Defaults; d != nil {
+		if d.MTLSProvider != "" {
+			settings.Defaults.MTLSProvider = d.MTLSProvider
+		}
+		if d.ClusterIssuer != "" {
+			settings.Defaults.ClusterIssuer = d.ClusterIssuer
+		}
+		if d.ImageRegistry != "" {
+			settings.Defaults.ImageRegistry = strings.TrimSuffix(d.ImageRegistry, "/")
+		}
+		if len(d.ImagePullSecrets) > 0 {
+			settings.Defaults.ImagePullSecrets = append([]string(nil), d.ImagePullSecrets...)
+		}
+	}
+
+	if n := spec.Notifications; n != nil && n.WebhookURL != "" {
+		settings.NotificationURL = n.WebhookURL
+	}
+
+	return settings
+}
+
+// restartRequired returns the paths of the config fields applied on start that changed since the operator started.
+func restartRequired(started, current Settings) []string {
+	var fields []string
+	if !reflect.DeepEqual(started.WatchNamespaces, current.WatchNamespaces) {
+		fields = append(fields, "spec.watchNamespaces")
+	}
+	if started.NamespaceWorkers != current.NamespaceWorkers {
+		fields = append(fields, "spec.concurrency.namespaceWorkers")
+	}
+	return fields
+}
+
+// Load returns the operator config with the provided name.
+// It returns nil if the config or the TemporalOperatorConfig resource doesn't exist.
+func Load(ctx context.Context, reader client.Reader, name string) (*v1beta1.TemporalOperatorConfig, error) {
+	config := &v1beta1.TemporalOperatorConfig{}
+	err := reader.Get(ctx, types.NamespacedName{Name: name}, config)
+	if err != nil {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("can't get operator config %s: %w", name, err)
+	}
+	return config, nil
+}
+
+// Reloader periodically applies the settings stored in the operator config.
+// The config is read using an uncached reader, as it's read by all the operator replicas.
+// Removing the config, or a field of the config, restores the value set by the operator flags.
+type Reloader struct {
+	// Name is the name of the TemporalOperatorConfig.
+	Name   string
+	Reader client.Reader
+	// Client writes the config status.
+	Client client.Client
+	// Base are the settings set by the operator flags.
+	Base Settings
+	// Started are the settings the operator started with.
+	Started Settings
+
+	Defaults             *defaults.Options
+	Notifier             *notification.Sink
+	NamespaceRateLimiter *ratelimit.KeyedLimiter
+	ClusterRollouts      *ratelimit.Semaphore
+
+	applied *Settings
+}
+
+var (
+	_ manager.Runnable               = (*Reloader)(nil)
+	_ manager.LeaderElectionRunnable = (*Reloader)(nil)
+)
+
+//+kubebuilder:rbac:groups=temporal.io,resources=temporaloperatorconfigs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporaloperatorconfigs/status,verbs=get;update;patch
+
+// NeedLeaderElection returns false as all operator replicas serve the defaulting webhook.
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start reloads the operator config until the context is done.
+func (r *Reloader) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("operatorconfig").WithValues("config", r.Name)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		changed, err := r.Reload(ctx)
+		if err != nil {
+			logger.Error(err, "Can't reload operator config")
+			return
+		}
+		if changed {
+			logger.Info("Operator config reloaded")
+		}
+	}, reloadInterval)
+
+	return nil
+}
+
+// Reload applies the settings stored in the operator config and reports them in the config status.
+// It returns true if the settings changed.
+func (r *Reloader) Reload(ctx context.Context) (bool, error) {
+	config, err := Load(ctx, r.Reader, r.Name)
+	if err != nil {
+		return false, err
+	}
+
+	settings := r.Base
+	if config != nil {
+		settings = Resolve(r.Base, &config.Spec)
+	}
+
+	if r.applied == nil {
+		started := r.Started
+		r.applied = &started
+	}
+	changed := !reflect.DeepEqual(*r.applied, settings)
+	r.apply(settings)
+
+	if config == nil {
+		return changed, nil
+	}
+
+	return changed, r.updateStatus(ctx, config, settings)
+}
+
+func (r *Reloader) apply(settings Settings) {
+	r.Defaults.Set(settings.Defaults)
+	r.Notifier.SetDefaultURL(settings.NotificationURL)
+	r.NamespaceRateLimiter.SetLimit(settings.NamespaceRateLimit, settings.NamespaceRateLimitBurst)
+	r.ClusterRollouts.Resize(settings.MaxConcurrentClusterRollouts)
+	r.applied = &settings
+}
+
+func (r *Reloader) updateStatus(ctx context.Context, config *v1beta1.TemporalOperatorConfig, settings Settings) error {
+	condition := metav1.Condition{
+		Type:               v1beta1.OperatorConfigAppliedCondition,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: config.GetGeneration(),
+		Status:             metav1.ConditionTrue,
+		Reason:             v1beta1.OperatorConfigAppliedReason,
+		Message:            "The operator runs with the config settings",
+	}
+	if fields := restartRequired(r.Started, settings); len(fields) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1beta1.OperatorConfigRestartRequiredReason
+		condition.Message = fmt.Sprintf("%s changed since the operator started, restart the operator to apply them", strings.Join(fields, ", "))
+	}
+
+	original := config.DeepCopy()
+	config.Status.ObservedGeneration = config.GetGeneration()
+	apimeta.SetStatusCondition(&config.Status.Conditions, condition)
+	if equality.Semantic.DeepEqual(original.Status, config.Status) {
+		return nil
+	}
+
+	return r.Client.Status().Patch(ctx, config, client.MergeFrom(original))
+}
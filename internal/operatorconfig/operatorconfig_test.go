@@ -0,0 +1,219 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package operatorconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/defaults"
+	"github.com/alexandrevilain/temporal-operator/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResolve(t *testing.T) {
+	base := Settings{
+		NamespaceWorkers:        4,
+		NamespaceRateLimit:      5,
+		NamespaceRateLimitBurst: 10,
+		Defaults:                defaults.Defaults{ImageRegistry: "docker.io"},
+		NotificationURL:         "https://hooks.example.com/flags",
+	}
+
+	tests := map[string]struct {
+		spec     *v1beta1.TemporalOperatorConfigSpec
+		expected Settings
+	}{
+		"no spec": {
+			expected: base,
+		},
+		"empty spec": {
+			spec:     &v1beta1.TemporalOperatorConfigSpec{},
+			expected: base,
+		},
+		"overridden fields": {
+			spec: &v1beta1.TemporalOperatorConfigSpec{
+				WatchNamespaces: []string{"team-a", "team-b"},
+				Concurrency: &v1beta1.OperatorConcurrencySpec{
+					NamespaceRateLimit:           ptr.To[int32](0),
+					MaxConcurrentClusterRollouts: ptr.To[int32](2),
+				},
+				Defaults: &v1beta1.OperatorDefaultsSpec{
+					ClusterIssuer:    "corporate-ca",
+					ImageRegistry:    "registry.example.com/mirror/",
+					ImagePullSecrets: []string{"mirror-credentials"},
+				},
+				Notifications: &v1beta1.OperatorNotificationsSpec{
+					WebhookURL: "https://hooks.example.com/config",
+				},
+			},
+			expected: Settings{
+				WatchNamespaces:              []string{"team-a", "team-b"},
+				NamespaceWorkers:             4,
+				NamespaceRateLimit:           0,
+				NamespaceRateLimitBurst:      10,
+				MaxConcurrentClusterRollouts: 2,
+				Defaults: defaults.Defaults{
+					ClusterIssuer:    "corporate-ca",
+					ImageRegistry:    "registry.example.com/mirror",
+					ImagePullSecrets: []string{"mirror-credentials"},
+				},
+				NotificationURL: "https://hooks.example.com/config",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, Resolve(base, test.spec))
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	base := Settings{
+		NamespaceWorkers:        4,
+		NamespaceRateLimit:      5,
+		NamespaceRateLimitBurst: 10,
+	}
+
+	tests := map[string]struct {
+		spec            *v1beta1.TemporalOperatorConfigSpec
+		expectedChanged bool
+		expectedApplied *metav1.Condition
+		expectedRollout bool
+	}{
+		"missing config": {
+			expectedRollout: true,
+		},
+		"runtime settings": {
+			spec: &v1beta1.TemporalOperatorConfigSpec{
+				Concurrency: &v1beta1.OperatorConcurrencySpec{
+					MaxConcurrentClusterRollouts: ptr.To[int32](1),
+				},
+				Defaults: &v1beta1.OperatorDefaultsSpec{
+					ImageRegistry: "registry.example.com/mirror",
+				},
+			},
+			expectedChanged: true,
+			expectedApplied: &metav1.Condition{
+				Status: metav1.ConditionTrue,
+				Reason: v1beta1.OperatorConfigAppliedReason,
+			},
+		},
+		"startup settings": {
+			spec: &v1beta1.TemporalOperatorConfigSpec{
+				WatchNamespaces: []string{"team-a"},
+			},
+			expectedChanged: true,
+			expectedApplied: &metav1.Condition{
+				Status:  metav1.ConditionFalse,
+				Reason:  v1beta1.OperatorConfigRestartRequiredReason,
+				Message: "spec.watchNamespaces changed since the operator started, restart the operator to apply them",
+			},
+			expectedRollout: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&v1beta1.TemporalOperatorConfig{})
+			if test.spec != nil {
+				builder = builder.WithObjects(&v1beta1.TemporalOperatorConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "default", Generation: 1},
+					Spec:       *test.spec,
+				})
+			}
+			c := builder.Build()
+
+			opts := defaults.NewOptions()
+			rollouts := ratelimit.NewSemaphore(0)
+			reloader := &Reloader{
+				Name:                 "default",
+				Reader:               c,
+				Client:               c,
+				Base:                 base,
+				Started:              base,
+				Defaults:             opts,
+				NamespaceRateLimiter: ratelimit.NewKeyedLimiter(base.NamespaceRateLimit, base.NamespaceRateLimitBurst),
+				ClusterRollouts:      rollouts,
+			}
+
+			changed, err := reloader.Reload(context.Background())
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expectedChanged, changed)
+
+			// The rollouts semaphore is resized at runtime.
+			assert.True(tt, rollouts.Acquire("demo/prod"))
+			assert.Equal(tt, test.expectedRollout, rollouts.Acquire("demo/staging"))
+
+			// Reloading an unchanged config is a no-op.
+			changed, err = reloader.Reload(context.Background())
+			require.NoError(tt, err)
+			assert.False(tt, changed)
+
+			if test.expectedApplied == nil {
+				return
+			}
+
+			config := &v1beta1.TemporalOperatorConfig{}
+			require.NoError(tt, c.Get(context.Background(), types.NamespacedName{Name: "default"}, config))
+			assert.Equal(tt, int64(1), config.Status.ObservedGeneration)
+
+			applied := apimeta.FindStatusCondition(config.Status.Conditions, v1beta1.OperatorConfigAppliedCondition)
+			require.NotNil(tt, applied)
+			assert.Equal(tt, test.expectedApplied.Status, applied.Status)
+			assert.Equal(tt, test.expectedApplied.Reason, applied.Reason)
+			if test.expectedApplied.Message != "" {
+				assert.Equal(tt, test.expectedApplied.Message, applied.Message)
+			}
+		})
+	}
+}
+
+func TestReloadRestoresFlags(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	base := Settings{Defaults: defaults.Defaults{ImageRegistry: "docker.io"}}
+	opts := defaults.NewOptions()
+	reloader := &Reloader{
+		Name:     "default",
+		Reader:   fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Base:     base,
+		Started:  Settings{Defaults: defaults.Defaults{ImageRegistry: "registry.example.com/mirror"}},
+		Defaults: opts,
+	}
+
+	// The config was deleted since the operator started.
+	changed, err := reloader.Reload(context.Background())
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "docker.io", opts.Get().ImageRegistry)
+}
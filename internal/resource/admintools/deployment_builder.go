@@ -134,8 +134,8 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 			Containers: []corev1.Container{
 				{
 					Name:                     "admintools",
-					Image:                    fmt.Sprintf("%s:%s", b.instance.Spec.AdminTools.Image, b.instance.Spec.Version),
-					ImagePullPolicy:          corev1.PullIfNotPresent,
+					Image:                    b.instance.AdminToolsImage(),
+					ImagePullPolicy:          b.instance.GetImagePullPolicy(),
 					TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 					Env:                      env,
@@ -167,6 +167,8 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		},
 	}
 
+	meta.ApplyPodSecurity(b.instance, &deployment.Spec.Template.Spec)
+
 	if b.instance.Spec.AdminTools.Overrides != nil && b.instance.Spec.AdminTools.Overrides.Deployment != nil {
 		err := kubernetes.ApplyDeploymentOverrides(deployment, b.instance.Spec.AdminTools.Overrides.Deployment)
 		if err != nil {
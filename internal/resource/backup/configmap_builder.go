@@ -0,0 +1,95 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*ConfigmapBuilder)(nil)
+
+// ConfigmapBuilder builds the ConfigMap holding the cluster manifest exported by the backup CronJob.
+type ConfigmapBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewConfigmapBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *ConfigmapBuilder {
+	return &ConfigmapBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *ConfigmapBuilder) Build() client.Object {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(serviceName),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, serviceName, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *ConfigmapBuilder) Enabled() bool {
+	return b.instance.Spec.Backup.IsEnabled()
+}
+
+func (b *ConfigmapBuilder) Update(object client.Object) error {
+	configMap := object.(*corev1.ConfigMap)
+
+	// Only export what's needed to re-create the cluster.
+	cluster := &v1beta1.TemporalCluster{
+		TypeMeta: v1beta1.TemporalClusterTypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      b.instance.Name,
+			Namespace: b.instance.Namespace,
+			Labels:    b.instance.Labels,
+			Annotations: metadata.FilterAnnotations(b.instance.Annotations, func(k, _ string) bool {
+				return k != "kubectl.kubernetes.io/last-applied-configuration"
+			}),
+		},
+		Spec: b.instance.Spec,
+	}
+
+	manifest, err := json.MarshalIndent(cluster, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed marshaling cluster manifest: %w", err)
+	}
+
+	configMap.Data = map[string]string{
+		clusterManifestFileName: string(manifest),
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, configMap, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
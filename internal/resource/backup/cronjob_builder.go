@@ -0,0 +1,345 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*CronJobBuilder)(nil)
+
+const (
+	serviceName = "backup"
+
+	clusterManifestFileName = "temporalcluster.json"
+
+	exportPath     = "/backup"
+	certsMountPath = "/etc/temporal/config/certs/client/backup"
+)
+
+// CronJobBuilder builds the CronJob periodically exporting the cluster spec, rendered configuration,
+// dynamic config and namespace list to the backup bucket.
+type CronJobBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewCronJobBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *CronJobBuilder {
+	return &CronJobBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *CronJobBuilder) Build() client.Object {
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(serviceName),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, serviceName, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *CronJobBuilder) Enabled() bool {
+	return b.instance.Spec.Backup.IsEnabled()
+}
+
+// collectScript copies the mounted configuration files to the export directory.
+func (b *CronJobBuilder) collectScript() string {
+	return strings.Join([]string{
+		"set -e",
+		fmt.Sprintf("mkdir -p %[1]s/config %[1]s/dynamicconfig", exportPath),
+		fmt.Sprintf("cp -L /etc/backup/cluster/* %s/", exportPath),
+		fmt.Sprintf("cp -L /etc/backup/config/* %s/config/", exportPath),
+		fmt.Sprintf("if ls /etc/backup/dynamicconfig/* >/dev/null 2>&1; then cp -L /etc/backup/dynamicconfig/* %s/dynamicconfig/; fi", exportPath),
+		fmt.Sprintf("temporal operator namespace list --output json > %s/namespaces.json", exportPath),
+	}, "\n")
+}
+
+// uploadScript uploads the export directory to the bucket, in a directory named after the export date.
+func (b *CronJobBuilder) uploadScript() string {
+	backup := b.instance.Spec.Backup
+
+	lines := []string{
+		"set -e",
+		`dest="${BACKUP_URL%/}/$(date -u +%Y%m%dT%H%M%SZ)"`,
+	}
+
+	if backup.IsGCS() {
+		lines = append(lines,
+			`if [ -n "$GOOGLE_APPLICATION_CREDENTIALS" ]; then gcloud auth activate-service-account --key-file "$GOOGLE_APPLICATION_CREDENTIALS"; fi`,
+			fmt.Sprintf(`gcloud storage cp --recursive %s/* "$dest/"`, exportPath),
+		)
+	} else {
+		args := ""
+		if backup.S3 != nil && backup.S3.Endpoint != nil {
+			args = fmt.Sprintf(` --endpoint-url "%s"`, *backup.S3.Endpoint)
+		}
+		lines = append(lines, fmt.Sprintf(`aws s3 cp%s --recursive %s "$dest/"`, args, exportPath))
+	}
+
+	lines = append(lines, `echo "Cluster configuration exported to $dest"`)
+
+	return strings.Join(lines, "\n")
+}
+
+// uploadEnv returns the environment variables of the upload container.
+func (b *CronJobBuilder) uploadEnv() []corev1.EnvVar {
+	backup := b.instance.Spec.Backup
+
+	env := []corev1.EnvVar{
+		{
+			Name:  "BACKUP_URL",
+			Value: backup.URL,
+		},
+		// The CLIs write their configuration in the home directory, which may not exist for arbitrary users.
+		{
+			Name:  "HOME",
+			Value: "/tmp",
+		},
+	}
+
+	if backup.IsGCS() {
+		if backup.GCS != nil && backup.GCS.CredentialsRef != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+				Value: backup.GCS.CredentialsFileMountPath(),
+			})
+		}
+		return env
+	}
+
+	if backup.S3 == nil {
+		return env
+	}
+
+	if backup.S3.Region != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "AWS_REGION",
+			Value: backup.S3.Region,
+		})
+	}
+
+	if backup.S3.Credentials != nil {
+		env = append(env,
+			corev1.EnvVar{
+				Name: "AWS_ACCESS_KEY_ID",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: backup.S3.Credentials.AccessKeyIDRef,
+				},
+			},
+			corev1.EnvVar{
+				Name: "AWS_SECRET_ACCESS_KEY",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: backup.S3.Credentials.SecretAccessKeyRef,
+				},
+			},
+		)
+	}
+
+	return env
+}
+
+func (b *CronJobBuilder) Update(object client.Object) error {
+	cronJob := object.(*batchv1.CronJob)
+	backup := b.instance.Spec.Backup
+
+	labels := metadata.GetLabels(b.instance, serviceName, b.instance.Spec.Version, b.instance.Labels)
+
+	collectEnv := []corev1.EnvVar{
+		{
+			Name:  "TEMPORAL_ADDRESS",
+			Value: b.instance.GetPublicClientAddress(),
+		},
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: "export",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: "cluster",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: b.instance.ChildResourceName(serviceName),
+					},
+				},
+			},
+		},
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: b.instance.ChildResourceName(meta.ServiceConfig),
+					},
+				},
+			},
+		},
+		{
+			Name: "dynamicconfig",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: b.instance.ChildResourceName(meta.ServiceDynamicConfig),
+					},
+					Optional: ptr.To(true),
+				},
+			},
+		},
+	}
+
+	collectVolumeMounts := []corev1.VolumeMount{
+		{Name: "export", MountPath: exportPath},
+		{Name: "cluster", MountPath: "/etc/backup/cluster"},
+		{Name: "config", MountPath: "/etc/backup/config"},
+		{Name: "dynamicconfig", MountPath: "/etc/backup/dynamicconfig"},
+	}
+
+	uploadVolumeMounts := []corev1.VolumeMount{
+		{Name: "export", MountPath: exportPath},
+	}
+
+	if b.instance.MTLSWithCertManagerEnabled() && b.instance.Spec.MTLS.FrontendEnabled() {
+		volumes = append(volumes, corev1.Volume{
+			Name: certmanager.WorkerFrontendClientCertificate,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  b.instance.ChildResourceName(certmanager.WorkerFrontendClientCertificate),
+					DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
+				},
+			},
+		})
+		collectVolumeMounts = append(collectVolumeMounts, corev1.VolumeMount{
+			Name:      certmanager.WorkerFrontendClientCertificate,
+			MountPath: certsMountPath,
+		})
+		collectEnv = append(collectEnv, certmanager.GetTLSEnvironmentVariables(b.instance, "TEMPORAL", certsMountPath)...)
+	}
+
+	if backup.IsGCS() && backup.GCS != nil && backup.GCS.CredentialsRef != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: "credentials",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: backup.GCS.CredentialsRef.Name,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  backup.GCS.CredentialsRef.Key,
+							Path: "credentials.json",
+						},
+					},
+				},
+			},
+		})
+		uploadVolumeMounts = append(uploadVolumeMounts, corev1.VolumeMount{
+			Name:      "credentials",
+			MountPath: "/etc/backup/credentials",
+			ReadOnly:  true,
+		})
+	}
+
+	cronJob.Spec = batchv1.CronJobSpec{
+		Schedule:          backup.Schedule,
+		ConcurrencyPolicy: batchv1.ForbidConcurrent,
+		JobTemplate: batchv1.JobTemplateSpec{
+			Spec: batchv1.JobSpec{
+				BackoffLimit: ptr.To[int32](2),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels:      labels,
+						Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+					},
+					Spec: corev1.PodSpec{
+						RestartPolicy:      corev1.RestartPolicyNever,
+						ImagePullSecrets:   b.instance.Spec.ImagePullSecrets,
+						ServiceAccountName: backup.ServiceAccountName,
+						// Configuration files and the namespace list are collected using the admin tools,
+						// then uploaded using the cloud provider CLI.
+						InitContainers: []corev1.Container{
+							{
+								Name:                     "collect",
+								Image:                    b.instance.AdminToolsImage(),
+								ImagePullPolicy:          b.instance.GetImagePullPolicy(),
+								Command:                  []string{"/bin/sh", "-c", b.collectScript()},
+								Env:                      collectEnv,
+								Resources:                backup.Resources,
+								TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+								TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+								SecurityContext: &corev1.SecurityContext{
+									AllowPrivilegeEscalation: ptr.To(false),
+								},
+								VolumeMounts: collectVolumeMounts,
+							},
+						},
+						Containers: []corev1.Container{
+							{
+								Name:                     "upload",
+								Image:                    backup.GetImage(),
+								ImagePullPolicy:          corev1.PullIfNotPresent,
+								Command:                  []string{"/bin/sh", "-c", b.uploadScript()},
+								Env:                      b.uploadEnv(),
+								Resources:                backup.Resources,
+								TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+								TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+								SecurityContext: &corev1.SecurityContext{
+									AllowPrivilegeEscalation: ptr.To(false),
+								},
+								VolumeMounts: uploadVolumeMounts,
+							},
+						},
+						Volumes: volumes,
+					},
+				},
+			},
+		},
+	}
+
+	// Left unset for UTC clusters, as the field is dropped by kubernetes versions not supporting it.
+	if b.instance.Spec.TimeZone != "" {
+		cronJob.Spec.TimeZone = ptr.To(b.instance.GetTimeZone())
+	}
+
+	meta.ApplyPodSecurity(b.instance, &cronJob.Spec.JobTemplate.Spec.Template.Spec)
+
+	if err := controllerutil.SetControllerReference(b.instance, cronJob, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*ArchivalVolumeClaimBuilder)(nil)
+
+// ArchivalVolumeClaimBuilder builds the PersistentVolumeClaim used by the filestore archival provider.
+type ArchivalVolumeClaimBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewArchivalVolumeClaimBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *ArchivalVolumeClaimBuilder {
+	return &ArchivalVolumeClaimBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *ArchivalVolumeClaimBuilder) Build() client.Object {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName("archival"),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, "archival", b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *ArchivalVolumeClaimBuilder) Enabled() bool {
+	volume := b.instance.ArchivalVolume()
+	return volume != nil && volume.ClaimTemplate != nil
+}
+
+func (b *ArchivalVolumeClaimBuilder) Update(object client.Object) error {
+	pvc := object.(*corev1.PersistentVolumeClaim)
+	template := b.instance.ArchivalVolume().ClaimTemplate
+
+	if pvc.CreationTimestamp.IsZero() {
+		pvc.Spec = *template.DeepCopy()
+	} else {
+		// Most of the claim spec is immutable once created, only volume expansion is allowed.
+		pvc.Spec.Resources.Requests = template.Resources.Requests
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, pvc, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
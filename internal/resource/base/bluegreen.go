@@ -15,22 +15,14 @@
 // specific language governing permissions and limitations
 // under the License.
 
-package networking
+package base
 
-import "net"
+import "fmt"
 
-func GetFreePort() (int, error) {
-	addr, err := net.ResolveTCPAddr("tcp", "localhost:0")
-	if err != nil {
-		return 0, err
-	}
+const blueGreenSuffix = "green"
 
-	l, err := net.ListenTCP("tcp", addr)
-	if err != nil {
-		return 0, err
-	}
-	defer func() {
-		_ = l.Close()
-	}()
-	return l.Addr().(*net.TCPAddr).Port, nil
+// BlueGreenComponentName returns the component name of the deployment
+// running the target version of the provided service during a blue/green upgrade.
+func BlueGreenComponentName(serviceName string) string {
+	return fmt.Sprintf("%s-%s", serviceName, blueGreenSuffix)
 }
@@ -20,10 +20,15 @@ package base
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/alexandrevilain/controller-tools/pkg/resource"
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/datadog"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/frontendproxy"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/persistence"
@@ -48,30 +53,225 @@ type DeploymentBuilder struct {
 	scheme      *runtime.Scheme
 	service     *v1beta1.ServiceSpec
 	configHash  string
+	// grpcProbes is true if the kubernetes cluster supports gRPC container probes.
+	grpcProbes bool
+	// blueGreen is true when the builder manages the deployment of
+	// the target version during a blue/green upgrade.
+	blueGreen bool
+	// remoteClusters are the resolved connections to the replication remote clusters.
+	remoteClusters []v1beta1.RemoteClusterConnection
 }
 
-func NewDeploymentBuilder(serviceName string, instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, service *v1beta1.ServiceSpec, configHash string) *DeploymentBuilder {
+func NewDeploymentBuilder(serviceName string, instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, service *v1beta1.ServiceSpec, configHash string, grpcProbes bool, remoteClusters []v1beta1.RemoteClusterConnection) *DeploymentBuilder {
 	return &DeploymentBuilder{
-		serviceName: serviceName,
-		instance:    instance,
-		scheme:      scheme,
-		service:     service,
-		configHash:  configHash,
+		serviceName:    serviceName,
+		instance:       instance,
+		scheme:         scheme,
+		service:        service,
+		configHash:     configHash,
+		grpcProbes:     grpcProbes,
+		remoteClusters: remoteClusters,
 	}
 }
 
+// NewBlueGreenDeploymentBuilder returns a builder for the deployment running
+// the target version of the service during a blue/green upgrade.
+func NewBlueGreenDeploymentBuilder(serviceName string, instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, service *v1beta1.ServiceSpec, configHash string, grpcProbes bool, remoteClusters []v1beta1.RemoteClusterConnection) *DeploymentBuilder {
+	b := NewDeploymentBuilder(serviceName, instance, scheme, service, configHash, grpcProbes, remoteClusters)
+	b.blueGreen = true
+	return b
+}
+
+// component returns the name of the component managed by the builder.
+func (b *DeploymentBuilder) component() string {
+	if b.blueGreen {
+		return BlueGreenComponentName(b.serviceName)
+	}
+	return b.serviceName
+}
+
+// grpcHealthServices are the gRPC health check service names registered by each temporal service.
+// History and matching register under the workflowservice package, see service/history/handler.go in temporal.
+var grpcHealthServices = map[string]string{
+	string(primitives.FrontendService):         "temporal.api.workflowservice.v1.WorkflowService",
+	string(primitives.InternalFrontendService): "temporal.api.workflowservice.v1.WorkflowService",
+	string(primitives.HistoryService):          "temporal.api.workflowservice.v1.HistoryService",
+	string(primitives.MatchingService):         "temporal.api.workflowservice.v1.MatchingService",
+}
+
+// grpcTLSEnabled returns true if the service gRPC port requires TLS.
+func (b *DeploymentBuilder) grpcTLSEnabled() bool {
+	if b.serviceName == string(primitives.FrontendService) {
+		return b.instance.Spec.MTLS.FrontendEnabled()
+	}
+	return b.instance.Spec.MTLS.InternodeEnabled()
+}
+
+// lifecycle returns the service container lifecycle hooks: the preStop hook, and the user provided postStart hook.
+func (b *DeploymentBuilder) lifecycle() *corev1.Lifecycle {
+	lifecycle := b.preStopLifecycle()
+	if b.service.PostStart == nil {
+		return lifecycle
+	}
+
+	if lifecycle == nil {
+		lifecycle = &corev1.Lifecycle{}
+	}
+	lifecycle.PostStart = b.service.PostStart
+
+	return lifecycle
+}
+
+// preStopLifecycle returns the lifecycle hooks shared by the service container and its sidecars.
+// The preStop hook delays the termination signal so that the pod is removed from
+// the service endpoints before the service evicts itself from the membership ring.
+func (b *DeploymentBuilder) preStopLifecycle() *corev1.Lifecycle {
+	gracefulShutdown := b.service.GracefulShutdown
+	if gracefulShutdown == nil || gracefulShutdown.PreStopDelay == nil {
+		return nil
+	}
+
+	return &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"sleep", strconv.Itoa(int(gracefulShutdown.PreStopDelay.Seconds()))},
+			},
+		},
+	}
+}
+
+// replicas returns the deployment replicas.
+// When a coordinated scale-down is configured, pods are removed one at a time: the next pod
+// is removed once the previously removed one had time to drain its task queues.
+func (b *DeploymentBuilder) replicas(deployment *appsv1.Deployment) *int32 {
+	desired := b.service.Replicas
+	current := deployment.Spec.Replicas
+
+	if !b.service.GracefulShutdown.CoordinatedScaleDownEnabled() ||
+		desired == nil || current == nil || *current <= *desired {
+		delete(deployment.Annotations, v1beta1.ScaleDownNextStepAnnotation)
+		return desired
+	}
+
+	now := time.Now()
+	nextStep, err := time.Parse(time.RFC3339, deployment.Annotations[v1beta1.ScaleDownNextStepAnnotation])
+	if err == nil && now.Before(nextStep) {
+		return current
+	}
+
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[v1beta1.ScaleDownNextStepAnnotation] = now.Add(b.service.GracefulShutdown.GetTerminationGracePeriod(0)).Format(time.RFC3339)
+
+	return ptr.To(*current - 1)
+}
+
+// ScaleDownRequeueAfter returns when the next step of the coordinated scale-downs
+// of the provided deployments is due, or 0 if no scale-down is in progress.
+func ScaleDownRequeueAfter(objects []client.Object) time.Duration {
+	var requeueAfter time.Duration
+	for _, object := range objects {
+		deployment, ok := object.(*appsv1.Deployment)
+		if !ok {
+			continue
+		}
+
+		value, ok := deployment.Annotations[v1beta1.ScaleDownNextStepAnnotation]
+		if !ok {
+			continue
+		}
+
+		// Requeue once more after the last step, so the annotation gets removed.
+		nextStep, err := time.Parse(time.RFC3339, value)
+		wait := time.Until(nextStep)
+		if err != nil || wait < time.Second {
+			wait = time.Second
+		}
+
+		if requeueAfter == 0 || wait < requeueAfter {
+			requeueAfter = wait
+		}
+	}
+
+	return requeueAfter
+}
+
+// strategy returns the service deployment strategy, defaulting to RollingUpdate.
+// Rolling update parameters left unset keep the values defaulted by the API server.
+func (b *DeploymentBuilder) strategy(current appsv1.DeploymentStrategy) appsv1.DeploymentStrategy {
+	strategy := appsv1.DeploymentStrategy{}
+	if b.service.DeploymentStrategy != nil {
+		strategy = *b.service.DeploymentStrategy.DeepCopy()
+	}
+
+	if strategy.Type == "" {
+		strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+	}
+
+	if strategy.Type == appsv1.RollingUpdateDeploymentStrategyType && strategy.RollingUpdate == nil && current.Type == strategy.Type {
+		strategy.RollingUpdate = current.RollingUpdate
+	}
+
+	return strategy
+}
+
+// frontendProxyEnabled returns true if the service is the frontend exposed through the proxy sidecar.
+func (b *DeploymentBuilder) frontendProxyEnabled() bool {
+	return b.serviceName == string(primitives.FrontendService) && b.instance.FrontendProxyEnabled()
+}
+
+// readinessProbe returns the service readiness probe.
+// It uses the temporal gRPC health check, so pods failing to reach their datastores are marked unready.
+// As the kubelet can't run gRPC probes using TLS, it falls back to a TCP probe
+// when the gRPC port requires TLS or the kubernetes cluster doesn't support gRPC probes.
+func (b *DeploymentBuilder) readinessProbe() *corev1.Probe {
+	healthService, ok := grpcHealthServices[b.serviceName]
+	if !ok {
+		// worker has no grpc endpoint.
+		return nil
+	}
+
+	probe := &corev1.Probe{
+		InitialDelaySeconds: 10,
+		TimeoutSeconds:      1,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromString("rpc"),
+			},
+		},
+	}
+
+	if b.grpcProbes && !b.grpcTLSEnabled() && b.service.Port != nil {
+		probe.ProbeHandler = corev1.ProbeHandler{
+			GRPC: &corev1.GRPCAction{
+				Port:    int32(*b.service.Port),
+				Service: ptr.To(healthService),
+			},
+		}
+	}
+
+	return probe
+}
+
 func (b *DeploymentBuilder) Build() client.Object {
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        b.instance.ChildResourceName(b.serviceName),
+			Name:        b.instance.ChildResourceName(b.component()),
 			Namespace:   b.instance.Namespace,
-			Labels:      metadata.GetLabels(b.instance, b.serviceName, b.instance.Spec.Version, b.instance.Labels),
+			Labels:      metadata.GetLabels(b.instance, b.component(), b.instance.Spec.Version, b.instance.Labels),
 			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
 		},
 	}
 }
 
 func (b *DeploymentBuilder) Enabled() bool {
+	if b.blueGreen && !b.instance.IsBlueGreenUpgradeInProgress() {
+		return false
+	}
 	return isBuilderEnabled(b.instance, b.serviceName)
 }
 
@@ -79,7 +279,7 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 	deployment := object.(*appsv1.Deployment)
 	deployment.Labels = metadata.Merge(
 		object.GetLabels(),
-		metadata.GetLabels(b.instance, b.serviceName, b.instance.Spec.Version, b.instance.Labels),
+		metadata.GetLabels(b.instance, b.component(), b.instance.Spec.Version, b.instance.Labels),
 	)
 	deployment.Annotations = metadata.Merge(
 		object.GetAnnotations(),
@@ -119,13 +319,37 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		},
 	}
 
+	resources := b.service.MemoryProtection.GetResources(b.service.Resources)
+
+	if goMemoryLimit := b.service.MemoryProtection.GoMemoryLimit(resources); goMemoryLimit > 0 {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "GOMEMLIMIT",
+			Value: strconv.FormatInt(goMemoryLimit, 10),
+		})
+	}
+
+	// Make panics dump all goroutines in the logs collected by the crash dumps.
+	if b.instance.Spec.CrashDump.IsEnabled() {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "GOTRACEBACK",
+			Value: "all",
+		})
+	}
+
 	datastores := b.instance.Spec.Persistence.GetDatastores()
 
-	envVars = append(envVars, persistence.GetDatastoresEnvironmentVariables(datastores)...)
+	envVars = append(envVars, persistence.GetDatastoresEnvironmentVariables(b.instance, datastores)...)
+
+	// When using an external config, the operator's ConfigMap is still mounted for the claim mapper rules.
+	configVolumeName := "config"
+	externalConfigMapName := b.instance.Spec.ExternalConfig.ConfigMapName(primitives.ServiceName(b.serviceName))
+	if externalConfigMapName != "" {
+		configVolumeName = "external-config"
+	}
 
 	volumeMounts := []corev1.VolumeMount{
 		{
-			Name:      "config",
+			Name:      configVolumeName,
 			MountPath: "/etc/temporal/config/config_template.yaml",
 			SubPath:   "config_template.yaml",
 		},
@@ -133,6 +357,15 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 
 	volumeMounts = append(volumeMounts, persistence.GetDatastoresVolumeMounts(datastores)...)
 
+	if b.instance.Spec.Authorization.CertificateClaimMapperEnabled() &&
+		(b.serviceName == string(primitives.FrontendService) || b.serviceName == string(primitives.InternalFrontendService)) {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "config",
+			MountPath: v1beta1.CertificateClaimMapperRulesPath,
+			SubPath:   v1beta1.CertificateClaimMapperRulesFileName,
+		})
+	}
+
 	volumes := []corev1.Volume{
 		{
 			Name: "config",
@@ -149,6 +382,20 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 
 	volumes = append(volumes, persistence.GetDatastoresVolumes(datastores)...)
 
+	if externalConfigMapName != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "external-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: externalConfigMapName,
+					},
+					DefaultMode: ptr.To[int32](corev1.ConfigMapVolumeSourceDefaultMode),
+				},
+			},
+		})
+	}
+
 	if b.instance.Spec.DynamicConfig != nil {
 		volumes = append(volumes, corev1.Volume{
 			Name: "dynamicconfig",
@@ -162,47 +409,58 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 			},
 		})
 
+		// Mount the whole configmap (without subPath) so updates are propagated to running pods.
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      "dynamicconfig",
-			MountPath: "/etc/temporal/config/dynamic_config.yaml",
-			SubPath:   "dynamic_config.yaml",
+			MountPath: filepath.Dir(b.instance.Spec.DynamicConfig.GetFilePath()),
 		})
 	}
 
 	if b.instance.Spec.Archival.IsEnabled() {
-		if b.instance.Spec.Archival.Provider.Kind() == v1beta1.S3ArchivalProviderKind &&
-			b.instance.Spec.Archival.Provider.S3.Credentials != nil {
+		// S3 credentials are process-wide: all s3 providers share the same credentials.
+		if s3 := b.instance.Spec.Archival.S3Provider(); s3 != nil && s3.Credentials != nil {
 			envVars = append(envVars,
 				corev1.EnvVar{
 					Name: "AWS_ACCESS_KEY_ID",
 					ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: b.instance.Spec.Archival.Provider.S3.Credentials.AccessKeyIDRef,
+						SecretKeyRef: s3.Credentials.AccessKeyIDRef,
 					},
 				},
 				corev1.EnvVar{
 					Name: "AWS_SECRET_ACCESS_KEY",
 					ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: b.instance.Spec.Archival.Provider.S3.Credentials.SecretAccessKeyRef,
+						SecretKeyRef: s3.Credentials.SecretAccessKeyRef,
 					},
 				},
 			)
 		}
 
-		if b.instance.Spec.Archival.Provider.Kind() == v1beta1.GCSArchivalProviderKind &&
-			b.instance.Spec.Archival.Provider.GCS.CredentialsRef != nil {
-			key := b.instance.Spec.Archival.Provider.GCS.CredentialsRef.Key
+		gcsCredentials := []struct {
+			volumeName string
+			provider   *v1beta1.ArchivalProvider
+			mountPath  string
+		}{
+			{"archival", b.instance.Spec.Archival.Provider, v1beta1.GCSArchiver{}.CredentialsFileMountPath()},
+			{"archival-visibility", b.instance.Spec.Archival.VisibilityProvider, v1beta1.GCSArchiver{}.VisibilityCredentialsFileMountPath()},
+		}
+		for _, credentials := range gcsCredentials {
+			if credentials.provider.Kind() != v1beta1.GCSArchivalProviderKind || credentials.provider.GCS.CredentialsRef == nil {
+				continue
+			}
+
+			key := credentials.provider.GCS.CredentialsRef.Key
 			if key == "" {
 				key = "credentials.json"
 			}
 			volumes = append(volumes, corev1.Volume{
-				Name: "archival",
+				Name: credentials.volumeName,
 				VolumeSource: corev1.VolumeSource{
 					Secret: &corev1.SecretVolumeSource{
-						SecretName: b.instance.Spec.Archival.Provider.GCS.CredentialsRef.Name,
+						SecretName: credentials.provider.GCS.CredentialsRef.Name,
 						Items: []corev1.KeyToPath{
 							{
 								Key:  key,
-								Path: filepath.Base(b.instance.Spec.Archival.Provider.GCS.CredentialsFileMountPath()),
+								Path: filepath.Base(credentials.mountPath),
 							},
 						},
 						DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
@@ -211,12 +469,50 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 			})
 
 			volumeMounts = append(volumeMounts, corev1.VolumeMount{
-				Name:      "archival",
-				MountPath: filepath.Dir(b.instance.Spec.Archival.Provider.GCS.CredentialsFileMountPath()),
+				Name:      credentials.volumeName,
+				MountPath: filepath.Dir(credentials.mountPath),
+			})
+		}
+
+		if volume := b.instance.ArchivalVolume(); volume != nil && b.serviceName != string(primitives.MatchingService) {
+			volumes = append(volumes, corev1.Volume{
+				Name: "archival-data",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: b.instance.ArchivalVolumeClaimName(),
+					},
+				},
+			})
+
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      "archival-data",
+				MountPath: volume.MountPath,
 			})
 		}
 	}
 
+	for _, remote := range b.remoteClusters {
+		if remote.TLS == nil {
+			continue
+		}
+
+		name := fmt.Sprintf("remote-%s", remote.Name)
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  remote.TLS.SecretRef.Name,
+					DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
+				},
+			},
+		})
+
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: remote.TLSMountPath(),
+		})
+	}
+
 	if b.instance.MTLSWithCertManagerEnabled() {
 		if b.instance.Spec.MTLS.InternodeEnabled() {
 			volumeMounts = append(volumeMounts,
@@ -334,41 +630,67 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		})
 	}
 
-	deployment.Spec.Replicas = b.service.Replicas
+	readinessProbe := b.readinessProbe()
+	var sidecars []corev1.Container
+
+	// The frontend listens on localhost only: the proxy sidecar declares its ports and checks its health,
+	// as neither the kubelet nor the Services can reach it.
+	if b.frontendProxyEnabled() {
+		// Only the current version frontends mirror their requests to the target version ones.
+		proxy, err := frontendproxy.Container(b.instance, b.preStopLifecycle(), !b.blueGreen)
+		if err != nil {
+			return fmt.Errorf("can't build frontend proxy container: %w", err)
+		}
+		sidecars = append(sidecars, proxy)
+		volumes = append(volumes, frontendproxy.Volumes(b.instance)...)
+
+		containerPorts = slices.DeleteFunc(containerPorts, func(port corev1.ContainerPort) bool {
+			return port.Name == "rpc" || port.Name == "membership" || port.Name == "http"
+		})
+		livenessProbe = nil
+		readinessProbe = nil
+	}
+
+	deployment.Spec.Replicas = b.replicas(deployment)
+	deployment.Spec.Strategy = b.strategy(deployment.Spec.Strategy)
 
 	deployment.Spec.Selector = &metav1.LabelSelector{
-		MatchLabels: metadata.LabelsSelector(b.instance, b.serviceName),
+		MatchLabels: metadata.LabelsSelector(b.instance, b.component()),
 	}
 
 	deployment.Spec.Template = corev1.PodTemplateSpec{
-		ObjectMeta: meta.BuildPodObjectMeta(b.instance, b.serviceName, b.configHash),
+		ObjectMeta: meta.BuildPodObjectMeta(b.instance, b.component(), b.configHash),
 		Spec: corev1.PodSpec{
 			ServiceAccountName:       b.instance.ChildResourceName(b.serviceName),
 			DeprecatedServiceAccount: b.instance.ChildResourceName(b.serviceName),
 			ImagePullSecrets:         b.instance.Spec.ImagePullSecrets,
 			Containers: []corev1.Container{
 				{
-					Name:                     "service", // name "service" is here to simplify overrides
-					Image:                    fmt.Sprintf("%s:%s", b.instance.Spec.Image, b.instance.Spec.Version),
-					ImagePullPolicy:          corev1.PullIfNotPresent,
-					Resources:                b.service.Resources,
-					TerminationMessagePath:   corev1.TerminationMessagePathDefault,
-					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+					Name:                   "service", // name "service" is here to simplify overrides
+					Image:                  b.instance.ServerImage(),
+					ImagePullPolicy:        b.instance.GetImagePullPolicy(),
+					Resources:              resources,
+					TerminationMessagePath: corev1.TerminationMessagePathDefault,
+					// Temporal doesn't write a termination message, the end of the logs is reported in the ServiceDegraded condition.
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 					SecurityContext: &corev1.SecurityContext{
 						AllowPrivilegeEscalation: ptr.To(false),
 						Capabilities: &corev1.Capabilities{
 							Drop: []corev1.Capability{"ALL"},
 						},
 					},
-					Ports:         containerPorts,
-					LivenessProbe: livenessProbe,
-					Env:           envVars,
-					VolumeMounts:  volumeMounts,
+					Args:           b.service.ExtraArgs,
+					Ports:          containerPorts,
+					LivenessProbe:  livenessProbe,
+					ReadinessProbe: readinessProbe,
+					Lifecycle:      b.lifecycle(),
+					Env:            envVars,
+					VolumeMounts:   volumeMounts,
 				},
 			},
 			InitContainers:                b.service.InitContainers,
 			RestartPolicy:                 corev1.RestartPolicyAlways,
-			TerminationGracePeriodSeconds: ptr.To[int64](30),
+			TerminationGracePeriodSeconds: ptr.To(int64(b.service.GracefulShutdown.GetTerminationGracePeriod(30 * time.Second).Seconds())),
 			DNSPolicy:                     corev1.DNSClusterFirst,
 			SchedulerName:                 corev1.DefaultSchedulerName,
 			SecurityContext: &corev1.PodSecurityContext{
@@ -381,6 +703,62 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		},
 	}
 
+	deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers, sidecars...)
+
+	if b.instance.Spec.HighAvailability {
+		selector := &metav1.LabelSelector{
+			MatchLabels: metadata.LabelsSelector(b.instance, b.component()),
+		}
+
+		deployment.Spec.Template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       corev1.LabelTopologyZone,
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+				LabelSelector:     selector,
+			},
+		}
+
+		deployment.Spec.Template.Spec.Affinity = &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+					{
+						Weight: 100,
+						PodAffinityTerm: corev1.PodAffinityTerm{
+							TopologyKey:   corev1.LabelHostname,
+							LabelSelector: selector,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	datadogAnnotations, err := datadog.GetAnnotations(b.instance, b.serviceName)
+	if err != nil {
+		return fmt.Errorf("can't build datadog annotations: %w", err)
+	}
+
+	deployment.Spec.Template.Annotations = metadata.Merge(
+		deployment.Spec.Template.Annotations,
+		b.service.Autoscaler.GetPodAnnotations(),
+		datadogAnnotations,
+	)
+
+	if b.serviceName == string(primitives.FrontendService) && b.service.Traffic.ReadinessGateEnabled() {
+		deployment.Spec.Template.Spec.ReadinessGates = []corev1.PodReadinessGate{
+			{ConditionType: v1beta1.TrafficReadyConditionType},
+		}
+	}
+
+	if b.serviceName == string(primitives.HistoryService) && b.service.Warmup.IsEnabled() {
+		deployment.Spec.Template.Spec.ReadinessGates = []corev1.PodReadinessGate{
+			{ConditionType: v1beta1.ShardsAcquiredConditionType},
+		}
+	}
+
+	meta.ApplyPodSecurity(b.instance, &deployment.Spec.Template.Spec)
+
 	if b.instance.Spec.Services.Overrides != nil && b.instance.Spec.Services.Overrides.Deployment != nil {
 		err := kubernetes.ApplyDeploymentOverrides(deployment, b.instance.Spec.Services.Overrides.Deployment)
 		if err != nil {
@@ -39,12 +39,15 @@ var _ resource.Builder = (*DynamicConfigmapBuilder)(nil)
 type DynamicConfigmapBuilder struct {
 	instance *v1beta1.TemporalCluster
 	scheme   *runtime.Scheme
+	// namespaces are the TemporalNamespaces referencing the cluster.
+	namespaces []v1beta1.TemporalNamespace
 }
 
-func NewDynamicConfigmapBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *DynamicConfigmapBuilder {
+func NewDynamicConfigmapBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, namespaces []v1beta1.TemporalNamespace) *DynamicConfigmapBuilder {
 	return &DynamicConfigmapBuilder{
-		instance: instance,
-		scheme:   scheme,
+		instance:   instance,
+		scheme:     scheme,
+		namespaces: namespaces,
 	}
 }
 
@@ -67,7 +70,7 @@ func (b *DynamicConfigmapBuilder) Update(object client.Object) error {
 	configMap := object.(*corev1.ConfigMap)
 
 	currentValues := config.YamlDynamicConfig{}
-	expectedValues, err := config.DynamicConfigToYamlDynamicConfig(b.instance.Spec.DynamicConfig)
+	expectedValues, _, err := config.RenderDynamicConfig(b.instance, b.namespaces)
 	if err != nil {
 		return fmt.Errorf("failed computing expected dynamic config: %w", err)
 	}
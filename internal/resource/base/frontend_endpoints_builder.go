@@ -0,0 +1,112 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*FrontendEndpointsBuilder)(nil)
+
+// FrontendEndpointsBuilder builds the Endpoints of the selector-less frontend Service
+// when the frontend is mapped to externally-managed addresses.
+type FrontendEndpointsBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewFrontendEndpointsBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *FrontendEndpointsBuilder {
+	return &FrontendEndpointsBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *FrontendEndpointsBuilder) Build() client.Object {
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			// Endpoints are bound to the Service with the same name.
+			Name:        b.instance.ChildResourceName(meta.FrontendService),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, meta.FrontendService, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *FrontendEndpointsBuilder) Enabled() bool {
+	external := b.instance.Spec.Expose.GetExternalFrontend()
+	return external != nil && len(external.Addresses) > 0
+}
+
+func (b *FrontendEndpointsBuilder) Update(object client.Object) error {
+	endpoints := object.(*corev1.Endpoints)
+	endpoints.Labels = metadata.Merge(
+		object.GetLabels(),
+		metadata.GetLabels(b.instance, meta.FrontendService, b.instance.Spec.Version, b.instance.Labels),
+	)
+	endpoints.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+	)
+
+	addresses := []corev1.EndpointAddress{}
+	for _, ip := range b.instance.Spec.Expose.GetExternalFrontend().Addresses {
+		addresses = append(addresses, corev1.EndpointAddress{IP: ip})
+	}
+
+	// Port names must match the frontend Service ports names.
+	ports := []corev1.EndpointPort{
+		{
+			Name:     "grpc-rpc",
+			Protocol: corev1.ProtocolTCP,
+			Port:     int32(*b.instance.Spec.Services.Frontend.Port),
+		},
+	}
+
+	if b.instance.Spec.Services.Frontend.HTTPPort != nil {
+		ports = append(ports, corev1.EndpointPort{
+			Name:     "http",
+			Protocol: corev1.ProtocolTCP,
+			Port:     int32(*b.instance.Spec.Services.Frontend.HTTPPort),
+		})
+	}
+
+	endpoints.Subsets = []corev1.EndpointSubset{
+		{
+			Addresses: addresses,
+			Ports:     ports,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, endpoints, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package base
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*FrontendLoadBalancingServiceBuilder)(nil)
+
+// FrontendLoadBalancingServiceBuilder builds the headless frontend Service used by SDK clients
+// doing client-side load balancing.
+type FrontendLoadBalancingServiceBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewFrontendLoadBalancingServiceBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *FrontendLoadBalancingServiceBuilder {
+	return &FrontendLoadBalancingServiceBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *FrontendLoadBalancingServiceBuilder) Build() client.Object {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName("frontend-lb"),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, meta.FrontendService, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *FrontendLoadBalancingServiceBuilder) Enabled() bool {
+	return b.instance.Spec.Expose.ClientLoadBalancingEnabled()
+}
+
+func (b *FrontendLoadBalancingServiceBuilder) Update(object client.Object) error {
+	service := object.(*corev1.Service)
+	service.Labels = metadata.Merge(
+		object.GetLabels(),
+		metadata.GetLabels(b.instance, meta.FrontendService, b.instance.Spec.Version, b.instance.Labels),
+	)
+	service.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+	)
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	service.Spec.ClusterIP = corev1.ClusterIPNone
+	service.Spec.Selector = frontendSelector(b.instance)
+	// Clients should only resolve pods able to serve requests.
+	service.Spec.PublishNotReadyAddresses = false
+	service.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "grpc-rpc",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       int32(*b.instance.Spec.Services.Frontend.Port),
+			TargetPort: intstr.FromString("rpc"),
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, service, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
@@ -38,12 +38,15 @@ var _ resource.Builder = (*FrontendServiceBuilder)(nil)
 type FrontendServiceBuilder struct {
 	instance *v1beta1.TemporalCluster
 	scheme   *runtime.Scheme
+	// topologyMode is true if the kubernetes cluster supports the topology-mode annotation.
+	topologyMode bool
 }
 
-func NewFrontendServiceBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *FrontendServiceBuilder {
+func NewFrontendServiceBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, topologyMode bool) *FrontendServiceBuilder {
 	return &FrontendServiceBuilder{
-		instance: instance,
-		scheme:   scheme,
+		instance:     instance,
+		scheme:       scheme,
+		topologyMode: topologyMode,
 	}
 }
 
@@ -68,12 +71,32 @@ func (b *FrontendServiceBuilder) Update(object client.Object) error {
 		object.GetLabels(),
 		metadata.GetLabels(b.instance, meta.FrontendService, b.instance.Spec.Version, b.instance.Labels),
 	)
+	traffic := b.instance.Spec.Services.Frontend.Traffic
+	annotations := metadata.RemoveExternalDNSAnnotations(object.GetAnnotations())
+	delete(annotations, v1beta1.TopologyModeAnnotation)
+	delete(annotations, v1beta1.TopologyAwareHintsAnnotation)
 	service.Annotations = metadata.Merge(
-		object.GetAnnotations(),
+		annotations,
 		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		metadata.GetExternalDNSAnnotations(b.instance.Spec.Expose.GetFrontendHostnames(), b.instance.Spec.Expose.GetTTL()),
+		traffic.GetServiceAnnotations(b.topologyMode),
 	)
 	service.Spec.Type = corev1.ServiceTypeClusterIP
-	service.Spec.Selector = metadata.LabelsSelector(b.instance, string(primitives.FrontendService))
+	service.Spec.Selector = frontendSelector(b.instance)
+	service.Spec.ExternalName = ""
+	service.Spec.PublishNotReadyAddresses = traffic != nil && traffic.PublishNotReadyAddresses
+
+	if external := b.instance.Spec.Expose.GetExternalFrontend(); external != nil {
+		// Endpoints of selector-less Services are managed by the FrontendEndpointsBuilder.
+		service.Spec.Selector = nil
+		if external.ExternalName != "" {
+			service.Spec.Type = corev1.ServiceTypeExternalName
+			service.Spec.ExternalName = external.ExternalName
+			service.Spec.ClusterIP = ""
+			service.Spec.ClusterIPs = nil
+		}
+	}
+
 	service.Spec.Ports = []corev1.ServicePort{
 		{
 			Name:       "grpc-rpc",
@@ -98,3 +121,12 @@ func (b *FrontendServiceBuilder) Update(object client.Object) error {
 
 	return nil
 }
+
+// frontendSelector returns the selector of the pods serving the frontend traffic.
+func frontendSelector(instance *v1beta1.TemporalCluster) map[string]string {
+	if instance.IsBlueGreenSwitched() {
+		// Route traffic to the target version frontend while the current one is upgraded.
+		return metadata.LabelsSelector(instance, BlueGreenComponentName(string(primitives.FrontendService)))
+	}
+	return metadata.LabelsSelector(instance, string(primitives.FrontendService))
+}
@@ -68,10 +68,8 @@ func (b *ServiceAccountBuilder) Enabled() bool {
 
 func (b *ServiceAccountBuilder) getIAMAnnotations() map[string]string {
 	annotations := make(map[string]string)
-	if b.instance.Spec.Archival.IsEnabled() &&
-		b.instance.Spec.Archival.Provider.S3 != nil &&
-		b.instance.Spec.Archival.Provider.S3.RoleName != nil {
-		annotations[awsRoleArnAnnotation] = *b.instance.Spec.Archival.Provider.S3.RoleName
+	if s3 := b.instance.Spec.Archival.S3Provider(); b.instance.Spec.Archival.IsEnabled() && s3 != nil && s3.RoleName != nil {
+		annotations[awsRoleArnAnnotation] = *s3.RoleName
 	}
 	if b.instance.Spec.Persistence.DefaultStore.SQL != nil &&
 		b.instance.Spec.Persistence.DefaultStore.SQL.GCPServiceAccount != nil {
@@ -0,0 +1,134 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package benchmark
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const serviceName = "benchmark"
+
+// JobBuilder builds the Job running an omes scenario, along with its worker, against the cluster.
+type JobBuilder struct {
+	instance *v1beta1.TemporalBenchmark
+	cluster  *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewJobBuilder(instance *v1beta1.TemporalBenchmark, cluster *v1beta1.TemporalCluster, scheme *runtime.Scheme) *JobBuilder {
+	return &JobBuilder{
+		instance: instance,
+		cluster:  cluster,
+		scheme:   scheme,
+	}
+}
+
+// JobName returns the name of the benchmark Job.
+func JobName(instance *v1beta1.TemporalBenchmark) string {
+	return fmt.Sprintf("%s-omes", instance.GetName())
+}
+
+func (b *JobBuilder) Build() client.Object {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        JobName(b.instance),
+			Namespace:   b.instance.GetNamespace(),
+			Labels:      metadata.GetLabels(b.cluster, serviceName, b.cluster.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+// args returns the omes command line running the benchmark scenario.
+func (b *JobBuilder) args() []string {
+	spec := b.instance.Spec
+	args := []string{
+		"run-scenario-with-worker",
+		"--language", "go",
+		"--scenario", spec.GetScenario(),
+		"--run-id", b.instance.Status.RunID,
+		"--server-address", b.cluster.GetPublicClientAddress(),
+		"--namespace", spec.GetNamespace(),
+	}
+
+	if spec.Iterations != nil {
+		args = append(args, "--iterations", strconv.Itoa(int(*spec.Iterations)))
+	}
+
+	if spec.Duration != nil {
+		args = append(args, "--duration", spec.Duration.Duration.String())
+	}
+
+	if spec.MaxConcurrent != nil {
+		args = append(args, "--max-concurrent", strconv.Itoa(int(*spec.MaxConcurrent)))
+	}
+
+	return args
+}
+
+func (b *JobBuilder) Update(object client.Object) error {
+	job := object.(*batchv1.Job)
+
+	labels := metadata.GetLabels(b.cluster, serviceName, b.cluster.Spec.Version, b.instance.Labels)
+
+	job.Spec = batchv1.JobSpec{
+		// The benchmark results are only meaningful for a single run.
+		BackoffLimit: ptr.To[int32](0),
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      labels,
+				Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+			},
+			Spec: corev1.PodSpec{
+				RestartPolicy:    corev1.RestartPolicyNever,
+				ImagePullSecrets: b.instance.Spec.ImagePullSecrets,
+				Containers: []corev1.Container{
+					{
+						Name:                     "omes",
+						Image:                    b.instance.Spec.GetImage(),
+						ImagePullPolicy:          corev1.PullIfNotPresent,
+						Args:                     b.args(),
+						Resources:                b.instance.Spec.Resources,
+						TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr.To(false),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, job, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
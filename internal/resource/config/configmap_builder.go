@@ -18,8 +18,10 @@
 package config
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"path"
 	"strconv"
 	"time"
@@ -47,17 +49,27 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// podIP is rendered as the pod IP when the server starts.
+const podIP = "{{ default .Env.POD_IP \"0.0.0.0\" }}"
+
 var _ resource.Builder = (*ConfigmapBuilder)(nil)
 
 type ConfigmapBuilder struct {
 	instance *v1beta1.TemporalCluster
 	scheme   *runtime.Scheme
+	// clientRules are the claim mapper rules granting the permissions requested by the cluster clients
+	// and the access policies.
+	clientRules []v1beta1.CertificateClaimMapperRule
+	// remoteClusters are the resolved connections to the replication remote clusters.
+	remoteClusters []v1beta1.RemoteClusterConnection
 }
 
-func NewConfigmapBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *ConfigmapBuilder {
+func NewConfigmapBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, clientRules []v1beta1.CertificateClaimMapperRule, remoteClusters []v1beta1.RemoteClusterConnection) *ConfigmapBuilder {
 	return &ConfigmapBuilder{
-		instance: instance,
-		scheme:   scheme,
+		instance:       instance,
+		scheme:         scheme,
+		clientRules:    clientRules,
+		remoteClusters: remoteClusters,
 	}
 }
 
@@ -84,6 +96,7 @@ func (b *ConfigmapBuilder) buildDatastoreConfig(store *v1beta1.DatastoreSpec) (*
 		v1beta1.MySQLDatastore,
 		v1beta1.MySQL8Datastore:
 		cfg.SQL = persistence.NewSQLConfigFromDatastoreSpec(store)
+		cfg.SQL.ConnectAddr = b.instance.GetDatastoreConnectAddr(store)
 		cfg.SQL.Password = fmt.Sprintf("{{ .Env.%s }}", store.GetPasswordEnvVarName())
 	case v1beta1.CassandraDatastore:
 		cfg.Cassandra = persistence.NewCassandraConfigFromDatastoreSpec(store)
@@ -149,14 +162,22 @@ func (b *ConfigmapBuilder) buildArchivalConfig() (*config.Archival, *config.Arch
 	if archival.Provider != nil {
 		cfg.History.Provider = &config.HistoryArchiverProvider{
 			Filestore: archivalutil.FilestoreArchiverToTemporalFilestoreArchiver(archival.Provider.Filestore),
-			Gstorage:  archivalutil.GCSArchiverToTemporalGstorageArchiver(archival.Provider.GCS),
+			Gstorage:  archivalutil.GCSArchiverToTemporalGstorageArchiver(archival.Provider.GCS, v1beta1.GCSArchiver{}.CredentialsFileMountPath()),
 			S3store:   archivalutil.S3ArchiverToTemporalS3Archiver(archival.Provider.S3),
 		}
+	}
+
+	// Visibility records may be archived using a dedicated provider.
+	if provider := archival.GetVisibilityProvider(); provider != nil {
+		credentialsPath := v1beta1.GCSArchiver{}.CredentialsFileMountPath()
+		if archival.VisibilityProvider != nil {
+			credentialsPath = v1beta1.GCSArchiver{}.VisibilityCredentialsFileMountPath()
+		}
 
 		cfg.Visibility.Provider = &config.VisibilityArchiverProvider{
-			Filestore: archivalutil.FilestoreArchiverToTemporalFilestoreArchiver(archival.Provider.Filestore),
-			Gstorage:  archivalutil.GCSArchiverToTemporalGstorageArchiver(archival.Provider.GCS),
-			S3store:   archivalutil.S3ArchiverToTemporalS3Archiver(archival.Provider.S3),
+			Filestore: archivalutil.FilestoreArchiverToTemporalFilestoreArchiver(provider.Filestore),
+			Gstorage:  archivalutil.GCSArchiverToTemporalGstorageArchiver(provider.GCS, credentialsPath),
+			S3store:   archivalutil.S3ArchiverToTemporalS3Archiver(provider.S3),
 		}
 	}
 
@@ -192,13 +213,22 @@ func (b *ConfigmapBuilder) buildArchivalConfig() (*config.Archival, *config.Arch
 
 		namespaceDefaults.Visibility = config.VisibilityArchivalNamespaceDefaults{
 			State: state,
-			URI:   archivalutil.URI(archival.Provider, archival.Visibility),
+			URI:   archivalutil.URI(archival.GetVisibilityProvider(), archival.Visibility),
 		}
 	}
 
 	return cfg, namespaceDefaults
 }
 
+// bindOnIP returns the address the services listen on.
+// When the frontend is exposed through the proxy sidecar, services only listen on their pod IP.
+func (b *ConfigmapBuilder) bindOnIP() string {
+	if b.instance.FrontendProxyEnabled() {
+		return podIP
+	}
+	return "0.0.0.0"
+}
+
 func (b *ConfigmapBuilder) Update(object client.Object) error {
 	configMap := object.(*corev1.ConfigMap)
 
@@ -213,7 +243,7 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 		Global: config.Global{
 			Membership: config.Membership{
 				MaxJoinDuration:  30 * time.Second,
-				BroadcastAddress: "{{ default .Env.POD_IP \"0.0.0.0\" }}",
+				BroadcastAddress: podIP,
 			},
 			Authorization: authorization.ToTemporalAuthorization(b.instance.Spec.Authorization),
 		},
@@ -224,14 +254,15 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 			Archival: *archivalNamespaceDefaults,
 		},
 		ClusterMetadata: &cluster.Config{
-			EnableGlobalNamespace:    false,
-			FailoverVersionIncrement: 10,
+			EnableGlobalNamespace:    b.instance.Spec.Replication.IsEnabled(),
+			FailoverVersionIncrement: b.instance.Spec.Replication.GetFailoverVersionIncrement(),
 			MasterClusterName:        b.instance.Name,
 			CurrentClusterName:       b.instance.Name,
+			Tags:                     b.instance.Spec.ClusterMetadata.GetTags(),
 			ClusterInformation: map[string]cluster.ClusterInformation{
 				b.instance.Name: {
 					Enabled:                true,
-					InitialFailoverVersion: 1,
+					InitialFailoverVersion: b.instance.Spec.Replication.GetInitialFailoverVersion(),
 					RPCAddress:             "127.0.0.1:7233",
 				},
 			},
@@ -242,7 +273,7 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 					GRPCPort:        *b.instance.Spec.Services.Frontend.Port,
 					MembershipPort:  *b.instance.Spec.Services.Frontend.MembershipPort,
 					BindOnLocalHost: false,
-					BindOnIP:        "0.0.0.0",
+					BindOnIP:        b.bindOnIP(),
 				},
 			},
 			string(primitives.HistoryService): {
@@ -250,7 +281,7 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 					GRPCPort:        *b.instance.Spec.Services.History.Port,
 					MembershipPort:  *b.instance.Spec.Services.History.MembershipPort,
 					BindOnLocalHost: false,
-					BindOnIP:        "0.0.0.0",
+					BindOnIP:        b.bindOnIP(),
 				},
 			},
 			string(primitives.MatchingService): {
@@ -258,7 +289,7 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 					GRPCPort:        *b.instance.Spec.Services.Matching.Port,
 					MembershipPort:  *b.instance.Spec.Services.Matching.MembershipPort,
 					BindOnLocalHost: false,
-					BindOnIP:        "0.0.0.0",
+					BindOnIP:        b.bindOnIP(),
 				},
 			},
 			string(primitives.WorkerService): {
@@ -266,7 +297,7 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 					GRPCPort:        *b.instance.Spec.Services.Worker.Port,
 					MembershipPort:  *b.instance.Spec.Services.Worker.MembershipPort,
 					BindOnLocalHost: false,
-					BindOnIP:        "0.0.0.0",
+					BindOnIP:        b.bindOnIP(),
 				},
 			},
 		},
@@ -280,7 +311,7 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 					MembershipPort:  *b.instance.Spec.Services.InternalFrontend.MembershipPort,
 					HTTPPort:        *b.instance.Spec.Services.InternalFrontend.HTTPPort,
 					BindOnLocalHost: false,
-					BindOnIP:        "0.0.0.0",
+					BindOnIP:        b.bindOnIP(),
 				},
 			}
 		}
@@ -288,7 +319,7 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 
 	if !b.instance.Spec.Version.GreaterOrEqual(version.V1_18_0) {
 		temporalCfg.PublicClient = config.PublicClient{
-			HostPort: b.instance.GetPublicClientAddress(),
+			HostPort: b.instance.GetLocalFrontendAddress(),
 		}
 	}
 
@@ -302,9 +333,17 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 		}
 	}
 
+	// The frontend is only reachable through the proxy sidecar, which forwards the connections to localhost.
+	if b.instance.FrontendProxyEnabled() {
+		frontend := temporalCfg.Services[string(primitives.FrontendService)]
+		frontend.RPC.BindOnLocalHost = true
+		frontend.RPC.BindOnIP = ""
+		temporalCfg.Services[string(primitives.FrontendService)] = frontend
+	}
+
 	if b.instance.Spec.DynamicConfig != nil {
 		temporalCfg.DynamicConfigClient = &dynamicconfig.FileBasedClientConfig{
-			Filepath:     "/etc/temporal/config/dynamic_config.yaml",
+			Filepath:     b.instance.Spec.DynamicConfig.GetFilePath(),
 			PollInterval: b.instance.Spec.DynamicConfig.PollInterval.Duration,
 		}
 	}
@@ -434,6 +473,36 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 		}
 	}
 
+	for _, remote := range b.remoteClusters {
+		if remote.TLS == nil {
+			continue
+		}
+
+		// Temporal looks up the remote cluster TLS configuration using the host of its frontend address.
+		host, _, err := net.SplitHostPort(remote.Address)
+		if err != nil {
+			return fmt.Errorf("invalid remote cluster %s address: %w", remote.Name, err)
+		}
+
+		if temporalCfg.Global.TLS.RemoteClusters == nil {
+			temporalCfg.Global.TLS.RemoteClusters = map[string]config.GroupTLS{}
+		}
+
+		mountPath := remote.TLSMountPath()
+		temporalCfg.Global.TLS.RemoteClusters[host] = config.GroupTLS{
+			Client: config.ClientTLS{
+				ServerName:  remote.TLS.ServerName,
+				RootCAFiles: []string{path.Join(mountPath, certmanager.TLSCA)},
+				ForceTLS:    true,
+			},
+			// The server certificate is presented as the client certificate to the remote frontend.
+			Server: config.ServerTLS{
+				CertFile: path.Join(mountPath, certmanager.TLSCert),
+				KeyFile:  path.Join(mountPath, certmanager.TLSKey),
+			},
+		}
+	}
+
 	result, err := yaml.Marshal(temporalCfg)
 	if err != nil {
 		return fmt.Errorf("failed marshaling temporal config: %w", err)
@@ -443,6 +512,16 @@ func (b *ConfigmapBuilder) Update(object client.Object) error {
 		"config_template.yaml": string(result),
 	}
 
+	if b.instance.Spec.Authorization.CertificateClaimMapperEnabled() {
+		mapper := b.instance.Spec.Authorization.CertificateClaimMapper.DeepCopy()
+		mapper.Rules = append(mapper.Rules, b.clientRules...)
+		rules, err := json.Marshal(mapper)
+		if err != nil {
+			return fmt.Errorf("failed marshaling certificate claim mapper rules: %w", err)
+		}
+		configMap.Data[v1beta1.CertificateClaimMapperRulesFileName] = string(rules)
+	}
+
 	if err := controllerutil.SetControllerReference(b.instance, configMap, b.scheme); err != nil {
 		return fmt.Errorf("failed setting controller reference: %w", err)
 	}
@@ -0,0 +1,104 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/primitives"
+	"gopkg.in/yaml.v3"
+)
+
+// externalConfigFuncs mirrors the template functions available to dockerize, which renders
+// the configuration template when temporal containers start.
+var externalConfigFuncs = template.FuncMap{
+	"contains": func(values map[string]string, key string) bool {
+		_, ok := values[key]
+		return ok
+	},
+	"exists": func(string) bool { return true },
+	"split":  strings.Split,
+	"replace": func(s, old, replacement string, n int) string {
+		return strings.Replace(s, old, replacement, n)
+	},
+	"default": func(args ...any) any {
+		for _, arg := range args {
+			if s, ok := arg.(string); ok && s == "" {
+				continue
+			}
+			if arg != nil {
+				return arg
+			}
+		}
+		return ""
+	},
+	"atoi": func(s string) int {
+		i, _ := strconv.Atoi(s)
+		return i
+	},
+	"add": func(a, b int) int { return a + b },
+	"isTrue": func(s string) bool {
+		b, _ := strconv.ParseBool(s)
+		return b
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// ValidateExternalConfig ensures the provided temporal server configuration template
+// can be used to run the provided service.
+// Environment variables referenced by the template are rendered as empty values.
+func ValidateExternalConfig(service primitives.ServiceName, data string) error {
+	tmpl, err := template.New("config").Funcs(externalConfigFuncs).Option("missingkey=zero").Parse(data)
+	if err != nil {
+		return fmt.Errorf("can't parse config template: %w", err)
+	}
+
+	rendered := &bytes.Buffer{}
+	err = tmpl.Execute(rendered, map[string]any{"Env": map[string]string{}})
+	if err != nil {
+		return fmt.Errorf("can't render config template: %w", err)
+	}
+
+	cfg := &config.Config{}
+	decoder := yaml.NewDecoder(rendered)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil {
+		return fmt.Errorf("invalid temporal configuration: %w", err)
+	}
+
+	if cfg.Persistence.DefaultStore == "" {
+		return errors.New("invalid temporal configuration: persistence.defaultStore is required")
+	}
+
+	if _, ok := cfg.Persistence.DataStores[cfg.Persistence.DefaultStore]; !ok {
+		return fmt.Errorf("invalid temporal configuration: persistence default store %q is not defined in persistence.datastores", cfg.Persistence.DefaultStore)
+	}
+
+	if _, ok := cfg.Services[string(service)]; !ok {
+		return fmt.Errorf("invalid temporal configuration: services.%s is not defined", service)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package datadog
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+)
+
+// ContainerName is the name of the temporal service container the check is attached to.
+const ContainerName = "service"
+
+// DefaultMetrics are the metric filters collected when the user doesn't provide any.
+// They cover the requests, persistence, tasks and workflows metrics emitted by the temporal server.
+var DefaultMetrics = []string{
+	"service_.*",
+	"persistence_.*",
+	"visibility_persistence_.*",
+	"task_.*",
+	"workflow_.*",
+	"poll_.*",
+	"schedule_.*",
+	"sharditem_.*",
+	"numshards_gauge",
+	"membership_changed_count",
+	"restarts",
+}
+
+type checks struct {
+	OpenMetrics check `json:"openmetrics"`
+}
+
+type check struct {
+	InitConfig struct{}        `json:"init_config"`
+	Instances  []checkInstance `json:"instances"`
+}
+
+type checkInstance struct {
+	OpenMetricsEndpoint string   `json:"openmetrics_endpoint"`
+	Namespace           string   `json:"namespace"`
+	Metrics             []string `json:"metrics"`
+	Tags                []string `json:"tags"`
+}
+
+// GetAnnotations returns the Datadog autodiscovery annotations for the provided temporal service.
+func GetAnnotations(instance *v1beta1.TemporalCluster, service string) (map[string]string, error) {
+	if !instance.Spec.Metrics.DatadogEnabled() ||
+		instance.Spec.Metrics.Prometheus == nil ||
+		instance.Spec.Metrics.Prometheus.ListenPort == nil {
+		return map[string]string{}, nil
+	}
+
+	spec := instance.Spec.Metrics.Datadog
+
+	metrics := spec.Metrics
+	if len(metrics) == 0 {
+		prefix := ""
+		if instance.Spec.Metrics.Prefix != nil {
+			prefix = *instance.Spec.Metrics.Prefix
+		}
+		metrics = make([]string, 0, len(DefaultMetrics))
+		for _, m := range DefaultMetrics {
+			metrics = append(metrics, prefix+m)
+		}
+	}
+
+	tags := []string{
+		fmt.Sprintf("temporal_cluster:%s", instance.Name),
+		fmt.Sprintf("temporal_service:%s", service),
+	}
+	tags = append(tags, spec.Tags...)
+
+	value, err := json.Marshal(checks{
+		OpenMetrics: check{
+			Instances: []checkInstance{
+				{
+					OpenMetricsEndpoint: fmt.Sprintf("http://%%%%host%%%%:%d/metrics", *instance.Spec.Metrics.Prometheus.ListenPort),
+					Namespace:           spec.GetNamespace(),
+					Metrics:             metrics,
+					Tags:                tags,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal datadog checks: %w", err)
+	}
+
+	return map[string]string{
+		fmt.Sprintf("ad.datadoghq.com/%s.checks", ContainerName): string(value),
+	}, nil
+}
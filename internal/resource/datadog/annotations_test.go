@@ -0,0 +1,112 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package datadog_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/datadog"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetAnnotations(t *testing.T) {
+	tests := map[string]struct {
+		metrics  *v1beta1.MetricsSpec
+		expected map[string]string
+	}{
+		"metrics disabled": {
+			metrics:  nil,
+			expected: map[string]string{},
+		},
+		"datadog disabled": {
+			metrics: &v1beta1.MetricsSpec{
+				Enabled: true,
+				Prometheus: &v1beta1.PrometheusSpec{
+					ListenPort: ptr.To[int32](9090),
+				},
+			},
+			expected: map[string]string{},
+		},
+		"default filters": {
+			metrics: &v1beta1.MetricsSpec{
+				Enabled: true,
+				Prometheus: &v1beta1.PrometheusSpec{
+					ListenPort: ptr.To[int32](9090),
+				},
+				Datadog: &v1beta1.DatadogSpec{
+					Enabled: true,
+				},
+			},
+			expected: map[string]string{
+				"ad.datadoghq.com/service.checks": `{"openmetrics":{"init_config":{},"instances":[{"openmetrics_endpoint":"http://%%host%%:9090/metrics","namespace":"temporal","metrics":["service_.*","persistence_.*","visibility_persistence_.*","task_.*","workflow_.*","poll_.*","schedule_.*","sharditem_.*","numshards_gauge","membership_changed_count","restarts"],"tags":["temporal_cluster:prod","temporal_service:history"]}]}}`,
+			},
+		},
+		"default filters with prefix": {
+			metrics: &v1beta1.MetricsSpec{
+				Enabled: true,
+				Prefix:  ptr.To("prod_"),
+				Prometheus: &v1beta1.PrometheusSpec{
+					ListenPort: ptr.To[int32](9090),
+				},
+				Datadog: &v1beta1.DatadogSpec{
+					Enabled: true,
+				},
+			},
+			expected: map[string]string{
+				"ad.datadoghq.com/service.checks": `{"openmetrics":{"init_config":{},"instances":[{"openmetrics_endpoint":"http://%%host%%:9090/metrics","namespace":"temporal","metrics":["prod_service_.*","prod_persistence_.*","prod_visibility_persistence_.*","prod_task_.*","prod_workflow_.*","prod_poll_.*","prod_schedule_.*","prod_sharditem_.*","prod_numshards_gauge","prod_membership_changed_count","prod_restarts"],"tags":["temporal_cluster:prod","temporal_service:history"]}]}}`,
+			},
+		},
+		"custom namespace, filters and tags": {
+			metrics: &v1beta1.MetricsSpec{
+				Enabled: true,
+				Prometheus: &v1beta1.PrometheusSpec{
+					ListenPort: ptr.To[int32](9000),
+				},
+				Datadog: &v1beta1.DatadogSpec{
+					Enabled:   true,
+					Namespace: "temporal_server",
+					Metrics:   []string{"service_requests"},
+					Tags:      []string{"env:prod"},
+				},
+			},
+			expected: map[string]string{
+				"ad.datadoghq.com/service.checks": `{"openmetrics":{"init_config":{},"instances":[{"openmetrics_endpoint":"http://%%host%%:9000/metrics","namespace":"temporal_server","metrics":["service_requests"],"tags":["temporal_cluster:prod","temporal_service:history","env:prod"]}]}}`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "prod",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Metrics: test.metrics,
+				},
+			}
+
+			annotations, err := datadog.GetAnnotations(cluster, "history")
+			assert.NoError(tt, err)
+			assert.Equal(tt, test.expected, annotations)
+		})
+	}
+}
@@ -0,0 +1,486 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package frontendproxy
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+)
+
+const (
+	// ContainerName is the name of the proxy sidecar container.
+	ContainerName = "frontend-proxy"
+	// ClientName is the name of the frontend client certificate issued to the proxy.
+	ClientName = "frontend-proxy"
+
+	// HealthPort is the port the proxy reports the frontend health on.
+	HealthPort = 9902
+	// HealthPath is the path of the proxy health endpoint.
+	HealthPath = "/ready"
+
+	// MetricsPort is the port the proxy exposes its prometheus metrics on when mirroring is enabled.
+	MetricsPort = 9903
+	// MetricsPath is the path of the proxy prometheus metrics.
+	MetricsPath = "/stats/prometheus"
+	// MirrorClusterName is the envoy cluster the requests are mirrored to,
+	// reported by the envoy_cluster_name label of the proxy metrics.
+	MirrorClusterName = "mirror"
+
+	// workflowService is the gRPC service served by the frontend.
+	workflowService = "temporal.api.workflowservice.v1.WorkflowService"
+	// healthService is the gRPC health check service name registered by the frontend.
+	healthService = workflowService
+
+	serverCertsMountPath = "/etc/envoy/server"
+	clientCertsMountPath = "/etc/envoy/client"
+)
+
+// podIP is expanded by the kubelet, as the proxy listens on the pod IP next to the frontend bound on localhost.
+const podIP = "$(POD_IP)"
+
+// readOnlyMethods are the WorkflowService methods mirrored to the target frontend.
+// They don't change the cluster state, so serving them twice is harmless.
+// QueryWorkflow is left out, as it dispatches a workflow task to the workers.
+var readOnlyMethods = []string{
+	"CountWorkflowExecutions",
+	"DescribeBatchOperation",
+	"DescribeNamespace",
+	"DescribeSchedule",
+	"DescribeTaskQueue",
+	"DescribeWorkflowExecution",
+	"GetClusterInfo",
+	"GetSearchAttributes",
+	"GetSystemInfo",
+	"GetWorkerBuildIdCompatibility",
+	"GetWorkerTaskReachability",
+	"GetWorkflowExecutionHistory",
+	"GetWorkflowExecutionHistoryReverse",
+	"ListArchivedWorkflowExecutions",
+	"ListBatchOperations",
+	"ListClosedWorkflowExecutions",
+	"ListNamespaces",
+	"ListOpenWorkflowExecutions",
+	"ListScheduleMatchingTimes",
+	"ListSchedules",
+	"ListTaskQueuePartitions",
+	"ListWorkflowExecutions",
+	"ScanWorkflowExecutions",
+}
+
+// startMethods are the WorkflowService methods rejected in drain mode, as they start new workflow executions.
+var startMethods = []string{
+	"CreateSchedule",
+	"ExecuteMultiOperation",
+	"SignalWithStartWorkflowExecution",
+	"StartWorkflowExecution",
+}
+
+// httpStartPaths matches the HTTP API paths of the startMethods, optionally prefixed by /api/v1.
+const httpStartPaths = "^(/api/v1)?/namespaces/[^/]+/(workflows/[^/]+(/signal-with-start/[^/]+)?|schedules/[^/]+)$"
+
+// drainMessage is returned to the clients starting workflow executions in drain mode.
+// Envoy maps the 503 status to the UNAVAILABLE status for gRPC requests.
+const drainMessage = "the cluster is in drain mode and doesn't accept new workflow executions"
+
+var configTemplate = template.Must(template.New("envoy").Parse(`static_resources:
+  listeners:
+{{- range .Listeners }}
+  - name: {{ .Name }}
+    address:
+      socket_address:
+        address: {{ $.Address }}
+        port_value: {{ .Port }}
+    filter_chains:
+    - filters:
+      - name: envoy.filters.network.http_connection_manager
+        typed_config:
+          "@type": type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager
+          codec_type: AUTO
+          stat_prefix: {{ .Name }}
+          stream_idle_timeout: 0s
+          route_config:
+            name: {{ .Name }}
+            virtual_hosts:
+            - name: {{ .Name }}
+              domains: ["*"]
+              routes:
+{{- if .Drain }}
+              - match:
+                  safe_regex:
+                    regex: {{ .Drain }}
+                  headers:
+                  - name: ":method"
+                    string_match:
+                      exact: POST
+                direct_response:
+                  status: 503
+                  body:
+                    inline_string: {{ $.DrainMessage }}
+{{- end }}
+{{- if and .Mirror $.Mirror }}
+              - match:
+                  safe_regex:
+                    regex: {{ $.Mirror.Methods }}
+                route:
+                  cluster: {{ .Name }}
+                  timeout: 0s
+                  max_stream_duration:
+                    grpc_timeout_header_max: 0s
+                  request_mirror_policies:
+                  - cluster: {{ $.Mirror.Cluster }}
+                    runtime_fraction:
+                      default_value:
+                        numerator: {{ $.Mirror.Percentage }}
+                        denominator: HUNDRED
+{{- end }}
+              - match:
+                  prefix: "/"
+                route:
+                  cluster: {{ .Name }}
+                  timeout: 0s
+                  max_stream_duration:
+                    grpc_timeout_header_max: 0s
+          http_filters:
+          - name: envoy.filters.http.router
+            typed_config:
+              "@type": type.googleapis.com/envoy.extensions.filters.http.router.v3.Router
+      transport_socket:
+        name: envoy.transport_sockets.tls
+        typed_config:
+          "@type": type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.DownstreamTlsContext
+          require_client_certificate: true
+          common_tls_context:
+            alpn_protocols: ["h2", "http/1.1"]
+            tls_certificates:
+            - certificate_chain:
+                filename: {{ $.ServerCert }}
+              private_key:
+                filename: {{ $.ServerKey }}
+            validation_context:
+              trusted_ca:
+                filename: {{ $.CA }}
+{{- if $.AllowedClientNames }}
+              match_typed_subject_alt_names:
+{{- range $.AllowedClientNames }}
+              - san_type: DNS
+                matcher:
+                  exact: {{ . }}
+{{- end }}
+{{- end }}
+{{- end }}
+  - name: membership
+    address:
+      socket_address:
+        address: {{ .Address }}
+        port_value: {{ .MembershipPort }}
+    filter_chains:
+    - filters:
+      - name: envoy.filters.network.tcp_proxy
+        typed_config:
+          "@type": type.googleapis.com/envoy.extensions.filters.network.tcp_proxy.v3.TcpProxy
+          stat_prefix: membership
+          cluster: membership
+  - name: health
+    address:
+      socket_address:
+        address: 0.0.0.0
+        port_value: {{ .HealthPort }}
+    filter_chains:
+    - filters:
+      - name: envoy.filters.network.http_connection_manager
+        typed_config:
+          "@type": type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager
+          stat_prefix: health
+          route_config:
+            name: health
+          http_filters:
+          - name: envoy.filters.http.health_check
+            typed_config:
+              "@type": type.googleapis.com/envoy.extensions.filters.http.health_check.v3.HealthCheck
+              pass_through_mode: false
+              headers:
+              - name: ":path"
+                string_match:
+                  exact: {{ .HealthPath }}
+              cluster_min_healthy_percentages:
+                rpc:
+                  value: 100
+          - name: envoy.filters.http.router
+            typed_config:
+              "@type": type.googleapis.com/envoy.extensions.filters.http.router.v3.Router
+{{- if .Mirror }}
+  - name: metrics
+    address:
+      socket_address:
+        address: 0.0.0.0
+        port_value: {{ .Mirror.MetricsPort }}
+    filter_chains:
+    - filters:
+      - name: envoy.filters.network.http_connection_manager
+        typed_config:
+          "@type": type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager
+          stat_prefix: metrics
+          route_config:
+            name: metrics
+            virtual_hosts:
+            - name: metrics
+              domains: ["*"]
+              routes:
+              - match:
+                  path: {{ .Mirror.MetricsPath }}
+                route:
+                  cluster: admin
+          http_filters:
+          - name: envoy.filters.http.router
+            typed_config:
+              "@type": type.googleapis.com/envoy.extensions.filters.http.router.v3.Router
+{{- end }}
+  clusters:
+{{- range .Listeners }}
+  - name: {{ .Name }}
+    connect_timeout: 5s
+    type: STATIC
+{{- if .HTTP2 }}
+    typed_extension_protocol_options:
+      envoy.extensions.upstreams.http.v3.HttpProtocolOptions:
+        "@type": type.googleapis.com/envoy.extensions.upstreams.http.v3.HttpProtocolOptions
+        explicit_http_config:
+          http2_protocol_options: {}
+{{- end }}
+{{- if .HealthCheck }}
+    health_checks:
+    - timeout: 1s
+      interval: 10s
+      unhealthy_threshold: 3
+      healthy_threshold: 1
+      grpc_health_check:
+        service_name: {{ $.HealthService }}
+{{- end }}
+    load_assignment:
+      cluster_name: {{ .Name }}
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+            address:
+              socket_address:
+                address: 127.0.0.1
+                port_value: {{ .Port }}
+{{- template "upstreamTLS" $ }}
+{{- end }}
+{{- if .Mirror }}
+  - name: {{ .Mirror.Cluster }}
+    connect_timeout: 5s
+    type: STRICT_DNS
+    typed_extension_protocol_options:
+      envoy.extensions.upstreams.http.v3.HttpProtocolOptions:
+        "@type": type.googleapis.com/envoy.extensions.upstreams.http.v3.HttpProtocolOptions
+        explicit_http_config:
+          http2_protocol_options: {}
+    load_assignment:
+      cluster_name: {{ .Mirror.Cluster }}
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+            address:
+              socket_address:
+                address: {{ .Mirror.Address }}
+                port_value: {{ .Mirror.Port }}
+{{- template "upstreamTLS" $ }}
+  - name: admin
+    connect_timeout: 5s
+    type: STATIC
+    load_assignment:
+      cluster_name: admin
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+            address:
+              socket_address:
+                address: 127.0.0.1
+                port_value: 9901
+{{- end }}
+  - name: membership
+    connect_timeout: 5s
+    type: STATIC
+    load_assignment:
+      cluster_name: membership
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+            address:
+              socket_address:
+                address: 127.0.0.1
+                port_value: {{ .MembershipPort }}
+admin:
+  address:
+    socket_address:
+      address: 127.0.0.1
+      port_value: 9901
+{{- define "upstreamTLS" }}
+    transport_socket:
+      name: envoy.transport_sockets.tls
+      typed_config:
+        "@type": type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext
+        sni: {{ .ServerName }}
+        common_tls_context:
+          tls_certificates:
+          - certificate_chain:
+              filename: {{ .ClientCert }}
+            private_key:
+              filename: {{ .ClientKey }}
+          validation_context:
+            trusted_ca:
+              filename: {{ .CA }}
+            match_typed_subject_alt_names:
+            - san_type: DNS
+              matcher:
+                exact: {{ .ServerName }}
+{{- end }}
+`))
+
+// listener is a frontend port served by the proxy.
+type listener struct {
+	Name        string
+	Port        int
+	HTTP2       bool
+	HealthCheck bool
+	// Mirror is true if the listener read-only requests are mirrored.
+	Mirror bool
+	// Drain is the quoted regex matching the paths rejected in drain mode, if any.
+	Drain string
+}
+
+// mirror configures the mirroring of the read-only requests to the blue/green target frontend.
+type mirror struct {
+	Cluster     string
+	Address     string
+	Port        int
+	Percentage  int32
+	Methods     string
+	MetricsPort int
+	MetricsPath string
+}
+
+type config struct {
+	Address            string
+	Listeners          []listener
+	MembershipPort     int
+	HealthPort         int
+	HealthPath         string
+	HealthService      string
+	ServerName         string
+	ServerCert         string
+	ServerKey          string
+	ClientCert         string
+	ClientKey          string
+	CA                 string
+	AllowedClientNames []string
+	Mirror             *mirror
+	DrainMessage       string
+}
+
+// RenderConfig returns the envoy configuration of the cluster frontend proxy.
+// If mirrored is true, the proxy mirrors the read-only requests to the blue/green target frontend.
+// In drain mode, the proxy rejects the requests starting new workflow executions.
+func RenderConfig(instance *v1beta1.TemporalCluster, mirrored bool) (string, error) {
+	frontend := instance.Spec.Services.Frontend
+
+	var rpcDrain, httpDrain string
+	if instance.IsDraining() {
+		rpcDrain = strconv.Quote(fmt.Sprintf("^/%s/(%s)$", regexp.QuoteMeta(workflowService), strings.Join(startMethods, "|")))
+		httpDrain = strconv.Quote(httpStartPaths)
+	}
+
+	cfg := config{
+		Address: podIP,
+		Listeners: []listener{
+			{
+				Name:        "rpc",
+				Port:        *frontend.Port,
+				HTTP2:       true,
+				HealthCheck: true,
+				Mirror:      true,
+				Drain:       rpcDrain,
+			},
+		},
+		MembershipPort: *frontend.MembershipPort,
+		HealthPort:     HealthPort,
+		HealthPath:     HealthPath,
+		HealthService:  healthService,
+		ServerName:     instance.Spec.MTLS.Frontend.ServerName(instance),
+		ServerCert:     path.Join(serverCertsMountPath, certmanager.TLSCert),
+		ServerKey:      path.Join(serverCertsMountPath, certmanager.TLSKey),
+		ClientCert:     path.Join(clientCertsMountPath, certmanager.TLSCert),
+		ClientKey:      path.Join(clientCertsMountPath, certmanager.TLSKey),
+		CA:             path.Join(clientCertsMountPath, certmanager.TLSCA),
+		DrainMessage:   strconv.Quote(drainMessage),
+	}
+
+	allowedClientNames := frontend.Proxy.AllowedClientNames
+	// The target frontend proxy must accept the requests mirrored by the current frontend proxies.
+	if instance.FrontendMirroringEnabled() && len(allowedClientNames) > 0 {
+		allowedClientNames = append(slices.Clone(allowedClientNames), certmanager.GenericFrontendClientDNSName(instance, ClientName))
+	}
+	for _, name := range allowedClientNames {
+		cfg.AllowedClientNames = append(cfg.AllowedClientNames, strconv.Quote(name))
+	}
+
+	if mirrored && instance.FrontendMirroringEnabled() {
+		cfg.Mirror = &mirror{
+			Cluster:     MirrorClusterName,
+			Address:     MirrorAddress(instance),
+			Port:        *frontend.Port,
+			Percentage:  frontend.Proxy.Mirroring.GetPercentage(),
+			Methods:     strconv.Quote(fmt.Sprintf("^/%s/(%s)$", regexp.QuoteMeta(workflowService), strings.Join(readOnlyMethods, "|"))),
+			MetricsPort: MetricsPort,
+			MetricsPath: MetricsPath,
+		}
+	}
+
+	// Temporal >= 1.22 provides HTTP endpoint for the frontend
+	if instance.Spec.Version.GreaterOrEqual(version.V1_22_0) && frontend.HTTPPort != nil {
+		cfg.Listeners = append(cfg.Listeners, listener{
+			Name:  "http",
+			Port:  *frontend.HTTPPort,
+			Drain: httpDrain,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := configTemplate.Execute(&buf, cfg); err != nil {
+		return "", fmt.Errorf("can't render envoy configuration: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// MirrorAddress returns the DNS name resolving the blue/green target frontend pods.
+func MirrorAddress(instance *v1beta1.TemporalCluster) string {
+	return fmt.Sprintf("%s.%s", instance.ChildResourceName(meta.FrontendMirrorService), instance.FQDNSuffix())
+}
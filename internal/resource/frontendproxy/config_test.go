@@ -0,0 +1,141 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package frontendproxy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newCluster(v string, allowedClientNames ...string) *v1beta1.TemporalCluster {
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prod",
+			Namespace: "temporal",
+		},
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString(v),
+			Services: &v1beta1.ServicesSpec{
+				Frontend: &v1beta1.ServiceSpec{
+					Proxy: &v1beta1.FrontendProxySpec{
+						Enabled:            true,
+						AllowedClientNames: allowedClientNames,
+					},
+				},
+			},
+			MTLS: &v1beta1.MTLSSpec{
+				Provider: v1beta1.CertManagerMTLSProvider,
+				Frontend: &v1beta1.FrontendMTLSSpec{
+					Enabled: true,
+				},
+			},
+		},
+	}
+	cluster.Default()
+	return cluster
+}
+
+func TestRenderConfig(t *testing.T) {
+	cluster := newCluster("1.22.0", "ui.prod.temporal.svc.cluster.local")
+
+	cfg, err := RenderConfig(cluster, true)
+	assert.NoError(t, err)
+
+	out := map[string]any{}
+	assert.NoError(t, yaml.Unmarshal([]byte(cfg), &out))
+	assert.Contains(t, cfg, "address: $(POD_IP)\n        port_value: 7233")
+	assert.Contains(t, cfg, "address: $(POD_IP)\n        port_value: 6933")
+	assert.Contains(t, cfg, "address: $(POD_IP)\n        port_value: 7243")
+	assert.Contains(t, cfg, "require_client_certificate: true")
+	assert.Contains(t, cfg, `exact: "ui.prod.temporal.svc.cluster.local"`)
+	assert.Contains(t, cfg, "service_name: temporal.api.workflowservice.v1.WorkflowService")
+	// Quoting the allowed names for the template must not change the cluster spec.
+	assert.Equal(t, []string{"ui.prod.temporal.svc.cluster.local"}, cluster.Spec.Services.Frontend.Proxy.AllowedClientNames)
+}
+
+func TestRenderConfigWithoutHTTPPort(t *testing.T) {
+	cfg, err := RenderConfig(newCluster("1.21.0"), true)
+	assert.NoError(t, err)
+
+	assert.NotContains(t, cfg, "port_value: 7243")
+	assert.NotContains(t, cfg, "match_typed_subject_alt_names:\n              - san_type")
+	assert.Equal(t, 1, strings.Count(cfg, "grpc_health_check"))
+	assert.NotContains(t, cfg, "request_mirror_policies")
+}
+
+func TestRenderConfigWithMirroring(t *testing.T) {
+	cluster := newCluster("1.22.0", "ui.prod.temporal.svc.cluster.local")
+	cluster.Spec.Services.Frontend.Proxy.Mirroring = &v1beta1.FrontendMirroringSpec{
+		Enabled:    true,
+		Percentage: ptr.To[int32](25),
+	}
+
+	cfg, err := RenderConfig(cluster, true)
+	assert.NoError(t, err)
+
+	out := map[string]any{}
+	assert.NoError(t, yaml.Unmarshal([]byte(cfg), &out))
+	assert.Contains(t, cfg, "- cluster: mirror\n")
+	assert.Contains(t, cfg, "numerator: 25")
+	assert.Contains(t, cfg, "address: prod-frontend-mirror.temporal.svc.cluster.local\n                port_value: 7233")
+	assert.Contains(t, cfg, `DescribeWorkflowExecution|`)
+	assert.NotContains(t, cfg, "QueryWorkflow")
+	assert.Contains(t, cfg, "path: /stats/prometheus")
+	// The mirroring route is only set on the gRPC listener.
+	assert.Equal(t, 1, strings.Count(cfg, "request_mirror_policies"))
+	// The target proxies accept the requests mirrored by the current proxies.
+	assert.Contains(t, cfg, `exact: "frontend-proxy.prod.temporal.svc.cluster.local"`)
+	assert.Equal(t, []string{"ui.prod.temporal.svc.cluster.local"}, cluster.Spec.Services.Frontend.Proxy.AllowedClientNames)
+
+	// The target version proxies don't mirror the requests.
+	cfg, err = RenderConfig(cluster, false)
+	assert.NoError(t, err)
+	assert.NotContains(t, cfg, "request_mirror_policies")
+	assert.NotContains(t, cfg, "name: mirror")
+	assert.Contains(t, cfg, `exact: "frontend-proxy.prod.temporal.svc.cluster.local"`)
+}
+
+func TestRenderConfigWithDrain(t *testing.T) {
+	cluster := newCluster("1.22.0")
+
+	cfg, err := RenderConfig(cluster, true)
+	assert.NoError(t, err)
+	assert.NotContains(t, cfg, "direct_response")
+
+	cluster.Spec.Services.Frontend.Drain = true
+	cfg, err = RenderConfig(cluster, true)
+	assert.NoError(t, err)
+
+	out := map[string]any{}
+	assert.NoError(t, yaml.Unmarshal([]byte(cfg), &out))
+	// Both the gRPC and the HTTP listeners reject the new workflow executions.
+	assert.Equal(t, 2, strings.Count(cfg, "direct_response"))
+	assert.Equal(t, 2, strings.Count(cfg, "status: 503"))
+	assert.Contains(t, cfg, `regex: "^/temporal\\.api\\.workflowservice\\.v1\\.WorkflowService/(CreateSchedule|ExecuteMultiOperation|SignalWithStartWorkflowExecution|StartWorkflowExecution)$"`)
+	assert.Contains(t, cfg, `regex: "^(/api/v1)?/namespaces/[^/]+/(workflows/[^/]+(/signal-with-start/[^/]+)?|schedules/[^/]+)$"`)
+	assert.Contains(t, cfg, `inline_string: "the cluster is in drain mode and doesn't accept new workflow executions"`)
+	// The drain routes come before the routes forwarding the requests to the frontend.
+	assert.Less(t, strings.Index(cfg, "direct_response"), strings.Index(cfg, `prefix: "/"`))
+}
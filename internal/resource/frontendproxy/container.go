@@ -0,0 +1,146 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package frontendproxy
+
+import (
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	serverCertsVolumeName = certmanager.FrontendCertificate
+	clientCertsVolumeName = "frontend-proxy-certificate"
+
+	// MetricsPortName is the name of the proxy metrics container port.
+	MetricsPortName = "proxy-metrics"
+)
+
+// Container returns the proxy sidecar container of the frontend pods.
+// It declares the frontend named ports, so the frontend Services target the proxy.
+// If mirrored is true, the proxy mirrors the read-only requests to the blue/green target frontend.
+func Container(instance *v1beta1.TemporalCluster, lifecycle *corev1.Lifecycle, mirrored bool) (corev1.Container, error) {
+	frontend := instance.Spec.Services.Frontend
+
+	cfg, err := RenderConfig(instance, mirrored)
+	if err != nil {
+		return corev1.Container{}, err
+	}
+
+	ports := []corev1.ContainerPort{
+		{
+			Name:          "rpc",
+			ContainerPort: int32(*frontend.Port),
+			Protocol:      corev1.ProtocolTCP,
+		},
+		{
+			Name:          "membership",
+			ContainerPort: int32(*frontend.MembershipPort),
+			Protocol:      corev1.ProtocolTCP,
+		},
+	}
+
+	if instance.Spec.Version.GreaterOrEqual(version.V1_22_0) && frontend.HTTPPort != nil {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          "http",
+			ContainerPort: int32(*frontend.HTTPPort),
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+
+	if mirrored && instance.FrontendMirroringEnabled() {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          MetricsPortName,
+			ContainerPort: MetricsPort,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+
+	return corev1.Container{
+		Name:            ContainerName,
+		Image:           instance.FrontendProxyImage(),
+		ImagePullPolicy: instance.GetImagePullPolicy(),
+		// The configuration is passed inline: the kubelet expands the pod IP the listeners bind on.
+		Args: []string{"--config-yaml", cfg},
+		Env: []corev1.EnvVar{
+			{
+				Name: "POD_IP",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						APIVersion: "v1",
+						FieldPath:  "status.podIP",
+					},
+				},
+			},
+		},
+		Ports:                    ports,
+		Resources:                frontend.Proxy.Resources,
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		// The proxy is ready once the frontend gRPC health check succeeds.
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: HealthPath,
+					Port: intstr.FromInt32(HealthPort),
+				},
+			},
+			InitialDelaySeconds: 10,
+			TimeoutSeconds:      1,
+			PeriodSeconds:       10,
+			SuccessThreshold:    1,
+			FailureThreshold:    3,
+		},
+		// Keep serving the connections while the frontend drains.
+		Lifecycle: lifecycle,
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      serverCertsVolumeName,
+				MountPath: serverCertsMountPath,
+			},
+			{
+				Name:      clientCertsVolumeName,
+				MountPath: clientCertsMountPath,
+			},
+		},
+	}, nil
+}
+
+// Volumes returns the volumes the proxy sidecar needs, which aren't already mounted by the frontend container.
+func Volumes(instance *v1beta1.TemporalCluster) []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: clientCertsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  instance.ChildResourceName(certmanager.FrontendProxyFrontendClientCertificate),
+					DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
+				},
+			},
+		},
+	}
+}
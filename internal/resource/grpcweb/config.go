@@ -0,0 +1,233 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpcweb
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+)
+
+const (
+	// ComponentName is the name of the gRPC-web proxy component.
+	ComponentName = "grpc-web"
+
+	configFileName  = "envoy.yaml"
+	configMountPath = "/etc/envoy"
+	certsMountPath  = "/etc/envoy/certs"
+)
+
+// defaultAllowedHeaders are the request headers sent by the gRPC-web clients.
+var defaultAllowedHeaders = []string{
+	"keep-alive",
+	"user-agent",
+	"cache-control",
+	"content-type",
+	"content-transfer-encoding",
+	"x-accept-content-transfer-encoding",
+	"x-accept-response-streaming",
+	"x-user-agent",
+	"x-grpc-web",
+	"grpc-timeout",
+	"authorization",
+}
+
+// defaultExposedHeaders are the response headers read by the gRPC-web clients.
+var defaultExposedHeaders = []string{
+	"grpc-status",
+	"grpc-message",
+}
+
+var configTemplate = template.Must(template.New("envoy").Parse(`static_resources:
+  listeners:
+  - name: grpc-web
+    address:
+      socket_address:
+        address: 0.0.0.0
+        port_value: {{ .Port }}
+    filter_chains:
+    - filters:
+      - name: envoy.filters.network.http_connection_manager
+        typed_config:
+          "@type": type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager
+          codec_type: AUTO
+          stat_prefix: grpc_web
+          route_config:
+            name: frontend
+            virtual_hosts:
+            - name: frontend
+              domains: ["*"]
+              routes:
+              - match:
+                  prefix: "/"
+                route:
+                  cluster: frontend
+                  timeout: 0s
+                  max_stream_duration:
+                    grpc_timeout_header_max: 0s
+{{- if .CORS }}
+              typed_per_filter_config:
+                envoy.filters.http.cors:
+                  "@type": type.googleapis.com/envoy.extensions.filters.http.cors.v3.CorsPolicy
+                  allow_origin_string_match:
+{{- range .CORS.Origins }}
+                  - {{ . }}
+{{- end }}
+                  allow_methods: GET, PUT, DELETE, POST, OPTIONS
+                  allow_headers: {{ .CORS.AllowedHeaders }}
+                  expose_headers: {{ .CORS.ExposedHeaders }}
+                  allow_credentials: {{ .CORS.AllowCredentials }}
+{{- if .CORS.MaxAge }}
+                  max_age: "{{ .CORS.MaxAge }}"
+{{- end }}
+{{- end }}
+          http_filters:
+          - name: envoy.filters.http.grpc_web
+            typed_config:
+              "@type": type.googleapis.com/envoy.extensions.filters.http.grpc_web.v3.GrpcWeb
+          - name: envoy.filters.http.cors
+            typed_config:
+              "@type": type.googleapis.com/envoy.extensions.filters.http.cors.v3.Cors
+          - name: envoy.filters.http.router
+            typed_config:
+              "@type": type.googleapis.com/envoy.extensions.filters.http.router.v3.Router
+  clusters:
+  - name: frontend
+    connect_timeout: 5s
+    type: STRICT_DNS
+    lb_policy: ROUND_ROBIN
+    typed_extension_protocol_options:
+      envoy.extensions.upstreams.http.v3.HttpProtocolOptions:
+        "@type": type.googleapis.com/envoy.extensions.upstreams.http.v3.HttpProtocolOptions
+        explicit_http_config:
+          http2_protocol_options: {}
+    load_assignment:
+      cluster_name: frontend
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+            address:
+              socket_address:
+                address: {{ .FrontendHost }}
+                port_value: {{ .FrontendPort }}
+{{- if .TLS }}
+    transport_socket:
+      name: envoy.transport_sockets.tls
+      typed_config:
+        "@type": type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext
+        sni: {{ .TLS.ServerName }}
+        common_tls_context:
+          tls_certificates:
+          - certificate_chain:
+              filename: {{ .TLS.Cert }}
+            private_key:
+              filename: {{ .TLS.Key }}
+          validation_context:
+            trusted_ca:
+              filename: {{ .TLS.CA }}
+            match_typed_subject_alt_names:
+            - san_type: DNS
+              matcher:
+                exact: {{ .TLS.ServerName }}
+{{- end }}
+admin:
+  address:
+    socket_address:
+      address: 127.0.0.1
+      port_value: 9901
+`))
+
+type corsConfig struct {
+	Origins          []string
+	AllowedHeaders   string
+	ExposedHeaders   string
+	AllowCredentials bool
+	MaxAge           string
+}
+
+type tlsConfig struct {
+	ServerName string
+	CA         string
+	Cert       string
+	Key        string
+}
+
+type config struct {
+	Port         int32
+	FrontendHost string
+	FrontendPort int32
+	CORS         *corsConfig
+	TLS          *tlsConfig
+}
+
+// originMatcher returns the envoy string matcher matching the provided allowed origin.
+func originMatcher(origin string) string {
+	if origin == "*" {
+		return `{safe_regex: {regex: ".*"}}`
+	}
+	return fmt.Sprintf("{exact: %s}", strconv.Quote(origin))
+}
+
+// RenderConfig returns the envoy configuration of the cluster gRPC-web proxy.
+func RenderConfig(instance *v1beta1.TemporalCluster) (string, error) {
+	spec := instance.Spec.GRPCWeb
+
+	cfg := config{
+		Port:         *spec.Port,
+		FrontendHost: instance.ChildResourceName(meta.FrontendService),
+		FrontendPort: int32(*instance.Spec.Services.Frontend.Port),
+	}
+
+	if spec.CORS != nil {
+		cors := &corsConfig{
+			AllowedHeaders:   strings.Join(append(append([]string{}, defaultAllowedHeaders...), spec.CORS.AllowedHeaders...), ","),
+			ExposedHeaders:   strings.Join(append(append([]string{}, defaultExposedHeaders...), spec.CORS.ExposedHeaders...), ","),
+			AllowCredentials: spec.CORS.AllowCredentials,
+		}
+		for _, origin := range spec.CORS.AllowedOrigins {
+			cors.Origins = append(cors.Origins, originMatcher(origin))
+		}
+		if spec.CORS.MaxAge != nil {
+			cors.MaxAge = strconv.Itoa(int(spec.CORS.MaxAge.Seconds()))
+		}
+		cfg.CORS = cors
+	}
+
+	if instance.MTLSWithCertManagerEnabled() && instance.Spec.MTLS.FrontendEnabled() {
+		cfg.TLS = &tlsConfig{
+			ServerName: instance.Spec.MTLS.Frontend.ServerName(instance),
+			CA:         path.Join(certsMountPath, certmanager.TLSCA),
+			Cert:       path.Join(certsMountPath, certmanager.TLSCert),
+			Key:        path.Join(certsMountPath, certmanager.TLSKey),
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := configTemplate.Execute(&buf, cfg); err != nil {
+		return "", fmt.Errorf("can't render envoy configuration: %w", err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,69 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpcweb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConfigTemplate(t *testing.T) {
+	var s strings.Builder
+	assert.NoError(t, configTemplate.Execute(&s, config{
+		Port:         8080,
+		FrontendHost: "prod-frontend",
+		FrontendPort: 7233,
+		CORS: &corsConfig{
+			Origins:          []string{originMatcher("https://app.example.com"), originMatcher("*")},
+			AllowedHeaders:   "content-type,x-grpc-web",
+			ExposedHeaders:   "grpc-status,grpc-message",
+			AllowCredentials: true,
+			MaxAge:           "600",
+		},
+		TLS: &tlsConfig{
+			ServerName: "frontend.prod.svc.cluster.local",
+			CA:         "/etc/envoy/certs/ca.crt",
+			Cert:       "/etc/envoy/certs/tls.crt",
+			Key:        "/etc/envoy/certs/tls.key",
+		},
+	}))
+
+	out := map[string]any{}
+	assert.NoError(t, yaml.Unmarshal([]byte(s.String()), &out))
+	assert.Contains(t, s.String(), `- {exact: "https://app.example.com"}`)
+	assert.Contains(t, s.String(), `- {safe_regex: {regex: ".*"}}`)
+	assert.Contains(t, s.String(), "sni: frontend.prod.svc.cluster.local")
+	assert.Contains(t, s.String(), "address: prod-frontend\n                port_value: 7233")
+}
+
+func TestConfigTemplateWithoutCORS(t *testing.T) {
+	var s strings.Builder
+	assert.NoError(t, configTemplate.Execute(&s, config{
+		Port:         8080,
+		FrontendHost: "prod-frontend",
+		FrontendPort: 7233,
+	}))
+
+	out := map[string]any{}
+	assert.NoError(t, yaml.Unmarshal([]byte(s.String()), &out))
+	assert.NotContains(t, s.String(), "CorsPolicy")
+	assert.NotContains(t, s.String(), "transport_socket")
+}
@@ -0,0 +1,190 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpcweb
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*DeploymentBuilder)(nil)
+
+type DeploymentBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewDeploymentBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *DeploymentBuilder {
+	return &DeploymentBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *DeploymentBuilder) Build() client.Object {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(ComponentName),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, ComponentName, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *DeploymentBuilder) Enabled() bool {
+	return b.instance.GRPCWebEnabled()
+}
+
+func (b *DeploymentBuilder) Update(object client.Object) error {
+	deployment := object.(*appsv1.Deployment)
+	deployment.Labels = metadata.Merge(
+		object.GetLabels(),
+		metadata.GetLabels(b.instance, ComponentName, b.instance.Spec.Version, b.instance.Labels),
+	)
+	deployment.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+	)
+
+	// The proxy pods are rolled when the envoy configuration changes.
+	config, err := RenderConfig(b.instance)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256([]byte(config))
+	configHash := hex.EncodeToString(sum[:])
+
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "config",
+			MountPath: configMountPath,
+		},
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: b.instance.ChildResourceName(ComponentName),
+					},
+					DefaultMode: ptr.To[int32](corev1.ConfigMapVolumeSourceDefaultMode),
+				},
+			},
+		},
+	}
+
+	if b.instance.MTLSWithCertManagerEnabled() && b.instance.Spec.MTLS.FrontendEnabled() {
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{
+				Name:      certmanager.GRPCWebFrontendClientCertificate,
+				MountPath: certsMountPath,
+			},
+		)
+
+		volumes = append(volumes,
+			corev1.Volume{
+				Name: certmanager.GRPCWebFrontendClientCertificate,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName:  b.instance.ChildResourceName(certmanager.GRPCWebFrontendClientCertificate),
+						DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
+					},
+				},
+			},
+		)
+	}
+
+	deployment.Spec.Replicas = b.instance.Spec.GRPCWeb.Replicas
+
+	deployment.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: metadata.LabelsSelector(b.instance, ComponentName),
+	}
+
+	deployment.Spec.Template = corev1.PodTemplateSpec{
+		ObjectMeta: meta.BuildPodObjectMeta(b.instance, ComponentName, configHash),
+		Spec: corev1.PodSpec{
+			ImagePullSecrets: b.instance.Spec.ImagePullSecrets,
+			Containers: []corev1.Container{
+				{
+					Name:                     "envoy",
+					Image:                    b.instance.GRPCWebImage(),
+					ImagePullPolicy:          b.instance.GetImagePullPolicy(),
+					Args:                     []string{"--config-path", path.Join(configMountPath, configFileName)},
+					TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+					Resources:                b.instance.Spec.GRPCWeb.Resources,
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          "http",
+							ContainerPort: *b.instance.Spec.GRPCWeb.Port,
+							Protocol:      corev1.ProtocolTCP,
+						},
+					},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							TCPSocket: &corev1.TCPSocketAction{
+								Port: intstr.FromString("http"),
+							},
+						},
+						TimeoutSeconds:   1,
+						PeriodSeconds:    10,
+						SuccessThreshold: 1,
+						FailureThreshold: 3,
+					},
+					SecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: ptr.To(false),
+					},
+					VolumeMounts: volumeMounts,
+				},
+			},
+			RestartPolicy:                 corev1.RestartPolicyAlways,
+			TerminationGracePeriodSeconds: ptr.To[int64](30),
+			DNSPolicy:                     corev1.DNSClusterFirst,
+			SecurityContext:               &corev1.PodSecurityContext{},
+			SchedulerName:                 corev1.DefaultSchedulerName,
+			Volumes:                       volumes,
+		},
+	}
+
+	meta.ApplyPodSecurity(b.instance, &deployment.Spec.Template.Spec)
+
+	if err := controllerutil.SetControllerReference(b.instance, deployment, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
@@ -19,9 +19,10 @@ package meta
 
 // Service components.
 const (
-	FrontendService      = "frontend"
-	ServiceConfig        = "config"
-	ServiceDynamicConfig = "dynamicconfig"
+	FrontendService       = "frontend"
+	FrontendMirrorService = "frontend-mirror"
+	ServiceConfig         = "config"
+	ServiceDynamicConfig  = "dynamicconfig"
 )
 
 // Additionals services.
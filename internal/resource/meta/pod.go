@@ -18,24 +18,37 @@
 package meta
 
 import (
+	"time"
+
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/istio"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/linkerd"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/prometheus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/strings/slices"
 )
 
 const (
-	configHashKey = "operator.temporal.io/config"
+	configHashKey  = "operator.temporal.io/config"
+	restartedAtKey = "operator.temporal.io/restarted-at"
 )
 
 // BuildPodObjectMeta return ObjectMeta for the service (frontend, ui, admintools) of the provided Cluster.
 func BuildPodObjectMeta(instance *v1beta1.TemporalCluster, service, configHash string) metav1.ObjectMeta {
 	instanceAnnotations := metadata.FilterAnnotations(instance.Annotations, func(k, _ string) bool {
-		return k != "kubectl.kubernetes.io/last-applied-configuration"
+		return k != "kubectl.kubernetes.io/last-applied-configuration" && !slices.Contains(v1beta1.ActionAnnotations, k)
 	})
 
+	annotations := map[string]string{
+		configHashKey: configHash,
+	}
+
+	// Changing the restart time rolls the service pods.
+	if restartedAt := instance.Status.ServiceRestartedAt(service); restartedAt != nil {
+		annotations[restartedAtKey] = restartedAt.UTC().Format(time.RFC3339)
+	}
+
 	return metav1.ObjectMeta{
 		Labels: metadata.Merge(
 			istio.GetLabels(instance),
@@ -46,9 +59,7 @@ func BuildPodObjectMeta(instance *v1beta1.TemporalCluster, service, configHash s
 			istio.GetAnnotations(instance),
 			prometheus.GetAnnotations(instance),
 			metadata.GetAnnotations(instance.Name, instanceAnnotations),
-			map[string]string{
-				configHashKey: configHash,
-			},
+			annotations,
 		),
 	}
 }
@@ -0,0 +1,91 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package meta
+
+import (
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// restrictedPodUser is the user and group running restricted pods which image doesn't set a non-root user.
+const restrictedPodUser = 1000
+
+// ApplyPodSecurity applies the cluster pod security settings to the provided pod spec.
+// Settings already set on the pod spec are only tightened by the restricted profile.
+// On openshift, explicit users and groups are removed so that security context constraints assign them.
+func ApplyPodSecurity(instance *v1beta1.TemporalCluster, spec *corev1.PodSpec) {
+	if podSecurity := instance.Spec.PodSecurity; podSecurity != nil {
+		applyPodSecuritySpec(podSecurity, spec)
+	}
+
+	if instance.IsOpenShift() && spec.SecurityContext != nil {
+		spec.SecurityContext.RunAsUser = nil
+		spec.SecurityContext.RunAsGroup = nil
+		spec.SecurityContext.FSGroup = nil
+	}
+}
+
+func applyPodSecuritySpec(podSecurity *v1beta1.PodSecuritySpec, spec *corev1.PodSpec) {
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+
+	if podSecurity.IsRestricted() {
+		spec.SecurityContext.RunAsNonRoot = ptr.To(true)
+		if spec.SecurityContext.RunAsUser == nil {
+			spec.SecurityContext.RunAsUser = ptr.To[int64](restrictedPodUser)
+			spec.SecurityContext.RunAsGroup = ptr.To[int64](restrictedPodUser)
+		}
+		spec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		}
+
+		for i := range spec.InitContainers {
+			restrictContainer(&spec.InitContainers[i])
+		}
+		for i := range spec.Containers {
+			restrictContainer(&spec.Containers[i])
+		}
+	}
+
+	if podSecurity.SeccompProfile != nil {
+		spec.SecurityContext.SeccompProfile = podSecurity.SeccompProfile.DeepCopy()
+	}
+
+	if podSecurity.AppArmorProfile != nil {
+		spec.SecurityContext.AppArmorProfile = podSecurity.AppArmorProfile.DeepCopy()
+	}
+
+	if podSecurity.HostUsers != nil {
+		spec.HostUsers = ptr.To(*podSecurity.HostUsers)
+	}
+}
+
+// restrictContainer prevents the container from escalating privileges and drops all its capabilities.
+func restrictContainer(container *corev1.Container) {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+
+	container.SecurityContext.AllowPrivilegeEscalation = ptr.To(false)
+	container.SecurityContext.Privileged = nil
+	container.SecurityContext.Capabilities = &corev1.Capabilities{
+		Drop: []corev1.Capability{"ALL"},
+	}
+}
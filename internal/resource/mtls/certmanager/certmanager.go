@@ -56,6 +56,12 @@ var (
 	// UIFrontendClientCertificate is the name of the client certificate
 	// used for by UI for authenticating against the frontend.
 	UIFrontendClientCertificate = GetCertificateSecretName("ui")
+	// GRPCWebFrontendClientCertificate is the name of the client certificate
+	// used for by the gRPC-web proxy for authenticating against the frontend.
+	GRPCWebFrontendClientCertificate = GetCertificateSecretName("grpc-web")
+	// FrontendProxyFrontendClientCertificate is the name of the client certificate
+	// used for by the frontend proxy sidecar for authenticating against the frontend.
+	FrontendProxyFrontendClientCertificate = GetCertificateSecretName("frontend-proxy")
 )
 
 const (
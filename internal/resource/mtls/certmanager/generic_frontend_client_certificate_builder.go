@@ -45,6 +45,11 @@ func NewGenericFrontendClientCertificateBuilder(instance *v1beta1.TemporalCluste
 	}
 }
 
+// GenericFrontendClientDNSName returns the DNS name of the certificate issued to the provided client.
+func GenericFrontendClientDNSName(instance *v1beta1.TemporalCluster, clientName string) string {
+	return fmt.Sprintf("%s.%s", clientName, instance.ServerName())
+}
+
 func (b *GenericFrontendClientCertificateBuilder) Build() client.Object {
 	return &certmanagerv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
@@ -64,7 +69,7 @@ func (b *GenericFrontendClientCertificateBuilder) Update(object client.Object) e
 		SecretName:  b.instance.ChildResourceName(GetCertificateSecretName(b.name)),
 		CommonName:  fmt.Sprintf("%s client certificate", b.name),
 		Duration:    b.instance.Spec.MTLS.CertificatesDuration.ClientCertificates,
-		RenewBefore: b.instance.Spec.MTLS.RenewBefore,
+		RenewBefore: b.instance.CertificateRenewBefore(b.instance.Spec.MTLS.CertificatesDuration.ClientCertificates),
 		PrivateKey: &certmanagerv1.CertificatePrivateKey{
 			RotationPolicy: certmanagerv1.RotationPolicyAlways,
 			Encoding:       certmanagerv1.PKCS8,
@@ -72,7 +77,7 @@ func (b *GenericFrontendClientCertificateBuilder) Update(object client.Object) e
 			Size:           4096,
 		},
 		DNSNames: []string{
-			fmt.Sprintf("%s.%s", b.name, b.instance.ServerName()),
+			GenericFrontendClientDNSName(b.instance, b.name),
 		},
 		IssuerRef: certmanagermeta.ObjectReference{
 			Name: b.instance.ChildResourceName(frontendIntermediateCAIssuer),
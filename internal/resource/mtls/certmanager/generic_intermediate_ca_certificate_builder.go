@@ -60,7 +60,7 @@ func (b *GenericItermediateCACertificateBuilder) Update(object client.Object) er
 		SecretName:  b.instance.ChildResourceName(b.secretName),
 		CommonName:  b.commonName,
 		Duration:    b.instance.Spec.MTLS.CertificatesDuration.IntermediateCAsCertificates,
-		RenewBefore: b.instance.Spec.MTLS.RenewBefore,
+		RenewBefore: b.instance.CertificateRenewBefore(b.instance.Spec.MTLS.CertificatesDuration.IntermediateCAsCertificates),
 		PrivateKey:  caCertificatePrivateKey,
 		DNSNames: []string{
 			b.instance.ServerName(),
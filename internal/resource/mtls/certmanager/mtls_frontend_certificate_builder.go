@@ -65,7 +65,7 @@ func (b *MTLSFrontendCertificateBuilder) Update(object client.Object) error {
 		SecretName:  b.instance.ChildResourceName(FrontendCertificate),
 		CommonName:  "Frontend Certificate",
 		Duration:    b.instance.Spec.MTLS.CertificatesDuration.FrontendCertificate,
-		RenewBefore: b.instance.Spec.MTLS.RenewBefore,
+		RenewBefore: b.instance.CertificateRenewBefore(b.instance.Spec.MTLS.CertificatesDuration.FrontendCertificate),
 		PrivateKey: &certmanagerv1.CertificatePrivateKey{
 			RotationPolicy: certmanagerv1.RotationPolicyAlways,
 			Encoding:       certmanagerv1.PKCS8,
@@ -65,7 +65,7 @@ func (b *MTLSInternodeCertificateBuilder) Update(object client.Object) error {
 		SecretName:  b.instance.ChildResourceName(InternodeCertificate),
 		CommonName:  "Internode Certificate",
 		Duration:    b.instance.Spec.MTLS.CertificatesDuration.InternodeCertificate,
-		RenewBefore: b.instance.Spec.MTLS.RenewBefore,
+		RenewBefore: b.instance.CertificateRenewBefore(b.instance.Spec.MTLS.CertificatesDuration.InternodeCertificate),
 		PrivateKey: &certmanagerv1.CertificatePrivateKey{
 			RotationPolicy: certmanagerv1.RotationPolicyAlways,
 			Encoding:       certmanagerv1.PKCS8,
@@ -64,7 +64,7 @@ func (b *MTLSRootCACertificateBuilder) Update(object client.Object) error {
 	certificate.Spec = certmanagerv1.CertificateSpec{
 		IsCA:        true,
 		Duration:    b.instance.Spec.MTLS.CertificatesDuration.RootCACertificate,
-		RenewBefore: b.instance.Spec.MTLS.RenewBefore,
+		RenewBefore: b.instance.CertificateRenewBefore(b.instance.Spec.MTLS.CertificatesDuration.RootCACertificate),
 		SecretName:  b.instance.ChildResourceName(rootCaCertificate),
 		CommonName:  "Root CA certificate",
 		PrivateKey:  caCertificatePrivateKey,
@@ -78,6 +78,14 @@ func (b *MTLSRootCACertificateBuilder) Update(object client.Object) error {
 		Usages: caCertificatesUsages,
 	}
 
+	// Let the user provided issuer sign the root CA, chaining the cluster certificates to the centralized PKI.
+	if b.instance.Spec.MTLS.ExternalIssuerEnabled() {
+		certificate.Spec.IssuerRef = certmanagermeta.ObjectReference{
+			Name: b.instance.Spec.MTLS.IssuerRef.Name,
+			Kind: string(b.instance.Spec.MTLS.IssuerRef.Kind),
+		}
+	}
+
 	if err := controllerutil.SetControllerReference(b.instance, certificate, b.scheme); err != nil {
 		return fmt.Errorf("failed setting controller reference: %w", err)
 	}
@@ -69,7 +69,7 @@ func (b *DestinationRuleBuilder) Enabled() bool {
 func (b *DestinationRuleBuilder) Update(object client.Object) error {
 	pa := object.(*istionetworkingv1beta1.DestinationRule)
 	pa.Spec = istioapinetworkingv1beta1.DestinationRule{
-		Host: fmt.Sprintf("%s.%s.svc.cluster.local", b.instance.ChildResourceName(fmt.Sprintf("%s-headless", b.serviceName)), b.instance.Namespace),
+		Host: fmt.Sprintf("%s.%s", b.instance.ChildResourceName(fmt.Sprintf("%s-headless", b.serviceName)), b.instance.FQDNSuffix()),
 		TrafficPolicy: &istioapinetworkingv1beta1.TrafficPolicy{
 			Tls: &istioapinetworkingv1beta1.ClientTLSSettings{
 				Mode: istioapinetworkingv1beta1.ClientTLSSettings_ISTIO_MUTUAL,
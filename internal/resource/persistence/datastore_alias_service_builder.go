@@ -0,0 +1,95 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package persistence
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*DatastoreAliasServiceBuilder)(nil)
+
+// DatastoreAliasServiceBuilder builds the ExternalName service aliasing a datastore host in the cluster namespace.
+type DatastoreAliasServiceBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+	store    *v1beta1.DatastoreSpec
+}
+
+func NewDatastoreAliasServiceBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, store *v1beta1.DatastoreSpec) *DatastoreAliasServiceBuilder {
+	return &DatastoreAliasServiceBuilder{
+		instance: instance,
+		scheme:   scheme,
+		store:    store,
+	}
+}
+
+func (b *DatastoreAliasServiceBuilder) component() string {
+	return fmt.Sprintf("%s-datastore", b.store.LowerCaseName())
+}
+
+func (b *DatastoreAliasServiceBuilder) Build() client.Object {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.DatastoreAliasServiceName(b.store),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, b.component(), b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *DatastoreAliasServiceBuilder) Enabled() bool {
+	return b.store.ServiceAliasEnabled()
+}
+
+func (b *DatastoreAliasServiceBuilder) Update(object client.Object) error {
+	service := object.(*corev1.Service)
+	service.Labels = metadata.Merge(
+		object.GetLabels(),
+		metadata.GetLabels(b.instance, b.component(), b.instance.Spec.Version, b.instance.Labels),
+	)
+	service.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+	)
+
+	host, _, err := net.SplitHostPort(b.store.SQL.ConnectAddr)
+	if err != nil {
+		return fmt.Errorf("can't parse host port: %w", err)
+	}
+
+	service.Spec.Type = corev1.ServiceTypeExternalName
+	service.Spec.ExternalName = host
+	service.Spec.Selector = nil
+
+	if err := controllerutil.SetControllerReference(b.instance, service, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const managedElasticsearchDataPath = "/usr/share/elasticsearch/data"
+
+// elasticsearchUserID is the uid running Elasticsearch in the official images.
+const elasticsearchUserID = 1000
+
+var (
+	_ resource.Builder = (*ManagedElasticsearchVolumeClaimBuilder)(nil)
+	_ resource.Builder = (*ManagedElasticsearchDeploymentBuilder)(nil)
+	_ resource.Builder = (*ManagedElasticsearchServiceBuilder)(nil)
+)
+
+func managedElasticsearchComponent(store *v1beta1.DatastoreSpec) string {
+	return fmt.Sprintf("%s-elasticsearch", store.LowerCaseName())
+}
+
+// ManagedElasticsearchVolumeClaimBuilder builds the PersistentVolumeClaim holding the managed Elasticsearch data.
+type ManagedElasticsearchVolumeClaimBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+	store    *v1beta1.DatastoreSpec
+}
+
+func NewManagedElasticsearchVolumeClaimBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, store *v1beta1.DatastoreSpec) *ManagedElasticsearchVolumeClaimBuilder {
+	return &ManagedElasticsearchVolumeClaimBuilder{
+		instance: instance,
+		scheme:   scheme,
+		store:    store,
+	}
+}
+
+func (b *ManagedElasticsearchVolumeClaimBuilder) Build() client.Object {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ManagedElasticsearchName(b.store),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, managedElasticsearchComponent(b.store), b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *ManagedElasticsearchVolumeClaimBuilder) Enabled() bool {
+	return b.store.ManagedElasticsearchEnabled()
+}
+
+func (b *ManagedElasticsearchVolumeClaimBuilder) Update(object client.Object) error {
+	pvc := object.(*corev1.PersistentVolumeClaim)
+	managed := b.store.Elasticsearch.Managed
+
+	if pvc.CreationTimestamp.IsZero() {
+		pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+		pvc.Spec.StorageClassName = managed.StorageClassName
+	}
+	// Most of the claim spec is immutable once created, only volume expansion is allowed.
+	if managed.Storage != nil {
+		pvc.Spec.Resources.Requests = corev1.ResourceList{
+			corev1.ResourceStorage: *managed.Storage,
+		}
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, pvc, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
+
+// ManagedElasticsearchDeploymentBuilder builds the Deployment running the managed single-node Elasticsearch instance.
+type ManagedElasticsearchDeploymentBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+	store    *v1beta1.DatastoreSpec
+}
+
+func NewManagedElasticsearchDeploymentBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, store *v1beta1.DatastoreSpec) *ManagedElasticsearchDeploymentBuilder {
+	return &ManagedElasticsearchDeploymentBuilder{
+		instance: instance,
+		scheme:   scheme,
+		store:    store,
+	}
+}
+
+func (b *ManagedElasticsearchDeploymentBuilder) Build() client.Object {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ManagedElasticsearchName(b.store),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, managedElasticsearchComponent(b.store), b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *ManagedElasticsearchDeploymentBuilder) Enabled() bool {
+	return b.store.ManagedElasticsearchEnabled()
+}
+
+func (b *ManagedElasticsearchDeploymentBuilder) Update(object client.Object) error {
+	deployment := object.(*appsv1.Deployment)
+	deployment.Labels = metadata.Merge(
+		object.GetLabels(),
+		metadata.GetLabels(b.instance, managedElasticsearchComponent(b.store), b.instance.Spec.Version, b.instance.Labels),
+	)
+	deployment.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+	)
+
+	managed := b.store.Elasticsearch.Managed
+	component := managedElasticsearchComponent(b.store)
+
+	deployment.Spec.Replicas = ptr.To[int32](1)
+	// The data volume can only be mounted by a single pod.
+	deployment.Spec.Strategy = appsv1.DeploymentStrategy{
+		Type: appsv1.RecreateDeploymentStrategyType,
+	}
+	deployment.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: metadata.LabelsSelector(b.instance, component),
+	}
+
+	deployment.Spec.Template = corev1.PodTemplateSpec{
+		// The temporal pods metadata isn't used, as it holds the metrics scraping and service mesh settings.
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      metadata.GetLabels(b.instance, component, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:                     "elasticsearch",
+					Image:                    fmt.Sprintf("%s:%s", managed.Image, managed.ImageVersion),
+					ImagePullPolicy:          b.instance.GetImagePullPolicy(),
+					TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+					Resources:                managed.Resources,
+					Env: []corev1.EnvVar{
+						{Name: "discovery.type", Value: "single-node"},
+						{Name: "xpack.security.enabled", Value: "false"},
+						{Name: "ES_JAVA_OPTS", Value: "-Xms512m -Xmx512m"},
+					},
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          "http",
+							ContainerPort: v1beta1.ManagedElasticsearchPort,
+							Protocol:      corev1.ProtocolTCP,
+						},
+					},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Path:   "/_cluster/health?wait_for_status=yellow&timeout=1s",
+								Port:   intstr.FromString("http"),
+								Scheme: corev1.URISchemeHTTP,
+							},
+						},
+						TimeoutSeconds:   5,
+						PeriodSeconds:    10,
+						SuccessThreshold: 1,
+						FailureThreshold: 3,
+					},
+					SecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: ptr.To(false),
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "data",
+							MountPath: managedElasticsearchDataPath,
+						},
+					},
+				},
+			},
+			RestartPolicy:                 corev1.RestartPolicyAlways,
+			TerminationGracePeriodSeconds: ptr.To[int64](30),
+			DNSPolicy:                     corev1.DNSClusterFirst,
+			SecurityContext: &corev1.PodSecurityContext{
+				FSGroup: ptr.To[int64](elasticsearchUserID),
+			},
+			SchedulerName: corev1.DefaultSchedulerName,
+			Volumes: []corev1.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: b.instance.ManagedElasticsearchName(b.store),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	meta.ApplyPodSecurity(b.instance, &deployment.Spec.Template.Spec)
+
+	if err := controllerutil.SetControllerReference(b.instance, deployment, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
+
+// ManagedElasticsearchServiceBuilder builds the Service exposing the managed Elasticsearch instance to the cluster.
+type ManagedElasticsearchServiceBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+	store    *v1beta1.DatastoreSpec
+}
+
+func NewManagedElasticsearchServiceBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, store *v1beta1.DatastoreSpec) *ManagedElasticsearchServiceBuilder {
+	return &ManagedElasticsearchServiceBuilder{
+		instance: instance,
+		scheme:   scheme,
+		store:    store,
+	}
+}
+
+func (b *ManagedElasticsearchServiceBuilder) Build() client.Object {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      b.instance.ManagedElasticsearchName(b.store),
+			Namespace: b.instance.Namespace,
+		},
+	}
+}
+
+func (b *ManagedElasticsearchServiceBuilder) Enabled() bool {
+	return b.store.ManagedElasticsearchEnabled()
+}
+
+func (b *ManagedElasticsearchServiceBuilder) Update(object client.Object) error {
+	service := object.(*corev1.Service)
+	component := managedElasticsearchComponent(b.store)
+	service.Labels = metadata.Merge(
+		object.GetLabels(),
+		metadata.GetLabels(b.instance, component, b.instance.Spec.Version, b.instance.Labels),
+	)
+	service.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+	)
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	service.Spec.Selector = metadata.LabelsSelector(b.instance, component)
+	service.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "http",
+			TargetPort: intstr.FromString("http"),
+			Protocol:   corev1.ProtocolTCP,
+			Port:       v1beta1.ManagedElasticsearchPort,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, service, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
@@ -56,6 +56,8 @@ const (
 	CreateAdvancedVisibilityDatabaseScript  = "create-advanced-visibility-database.sh"
 	SetupAdvancedVisibilitySchemaScript     = "setup-advanced-visibility-schema.sh"
 	UpdateAdvancedVisibilitySchemaScript    = "update-advanced-visibility-schema.sh"
+	MigrateDefaultDatabaseScript            = "migrate-default-database.sh"
+	MigrateVisibilityDatabaseScript         = "migrate-visibility-database.sh"
 
 	defaultSchemaPath    = "temporal"
 	visibilitySchemaPath = "visibility"
@@ -160,7 +162,7 @@ func (b *SchemaScriptsConfigmapBuilder) argsMapToString(m *orderedmap.OrderedMap
 }
 
 func (b *SchemaScriptsConfigmapBuilder) getSQLArgs(spec *v1beta1.DatastoreSpec) (*orderedmap.OrderedMap[string, string], error) {
-	host, port, err := net.SplitHostPort(spec.SQL.ConnectAddr)
+	host, port, err := net.SplitHostPort(b.instance.GetDatastoreConnectAddr(spec))
 	if err != nil {
 		return nil, fmt.Errorf("can't parse host port: %w", err)
 	}
@@ -258,6 +260,16 @@ func (b *SchemaScriptsConfigmapBuilder) getStoreArgs(spec *v1beta1.DatastoreSpec
 	return args, nil
 }
 
+// getConnectionArgs returns the schema tool arguments for the provided datastore, including the user-provided extra arguments.
+func (b *SchemaScriptsConfigmapBuilder) getConnectionArgs(spec *v1beta1.DatastoreSpec) (string, error) {
+	args, err := b.getStoreArgs(spec)
+	if err != nil {
+		return "", fmt.Errorf("can't get store args: %w", err)
+	}
+
+	return strings.Join(append([]string{b.argsMapToString(args)}, spec.SchemaToolExtraArgs...), " "), nil
+}
+
 func (b *SchemaScriptsConfigmapBuilder) getStoreTool(storeType v1beta1.DatastoreType) string {
 	var tool string
 	switch storeType {
@@ -276,6 +288,49 @@ func (b *SchemaScriptsConfigmapBuilder) getStoreTool(storeType v1beta1.Datastore
 	return tool
 }
 
+// getHooksCommands returns the commands running the provided datastore hooks scripts of the provided phase.
+// Scripts are run by the datastore schema tool, without schema versioning.
+func (b *SchemaScriptsConfigmapBuilder) getHooksCommands(spec *v1beta1.DatastoreSpec, connectionArgs string, phase v1beta1.PersistenceHookPhase) []string {
+	commands := []string{}
+	for _, hook := range b.instance.Spec.Persistence.Hooks.Get(phase) {
+		if hook.Store != spec.Name {
+			continue
+		}
+
+		commands = append(commands,
+			fmt.Sprintf("%s %s setup-schema --schema-file %s --disable-versioning", b.getStoreTool(spec.GetType()), connectionArgs, hook.MountPath(phase)),
+		)
+	}
+	return commands
+}
+
+// getESCurlTLSArgs returns the curl arguments matching the datastore TLS configuration.
+func (b *SchemaScriptsConfigmapBuilder) getESCurlTLSArgs(spec *v1beta1.DatastoreSpec) string {
+	if spec.TLS == nil || !spec.TLS.Enabled {
+		return ""
+	}
+
+	args := []string{}
+	if caFile := spec.GetTLSCaFileMountPath(); caFile != "" {
+		args = append(args, "--cacert", caFile)
+	}
+	if certFile := spec.GetTLSCertFileMountPath(); certFile != "" {
+		args = append(args, "--cert", certFile)
+	}
+	if keyFile := spec.GetTLSKeyFileMountPath(); keyFile != "" {
+		args = append(args, "--key", keyFile)
+	}
+	if !spec.TLS.EnableHostVerification {
+		args = append(args, "--insecure")
+	}
+
+	if len(args) == 0 {
+		return ""
+	}
+
+	return strings.Join(args, " ") + " "
+}
+
 func (b *SchemaScriptsConfigmapBuilder) getESVersion(es *v1beta1.ElasticsearchSpec) string {
 	version := es.Version
 	if version == "v8" {
@@ -303,16 +358,16 @@ func (b *SchemaScriptsConfigmapBuilder) GetStoreCreateTemplate(spec *v1beta1.Dat
 		return b.renderTemplate(noOpTemplate, b.baseData())
 	}
 
-	args, err := b.getStoreArgs(spec)
+	connectionArgs, err := b.getConnectionArgs(spec)
 	if err != nil {
-		return "", fmt.Errorf("can't get store args: %w", err)
+		return "", err
 	}
 
 	if storeType == v1beta1.CassandraDatastore {
 		data := createKeyspace{
 			baseData:       b.baseData(),
 			Tool:           b.getStoreTool(storeType),
-			ConnectionArgs: b.argsMapToString(args),
+			ConnectionArgs: connectionArgs,
 			KeyspaceName:   spec.Cassandra.Keyspace,
 		}
 
@@ -327,7 +382,7 @@ func (b *SchemaScriptsConfigmapBuilder) GetStoreCreateTemplate(spec *v1beta1.Dat
 	data := createDatabase{
 		baseData:       b.baseData(),
 		Tool:           b.getStoreTool(storeType),
-		ConnectionArgs: b.argsMapToString(args),
+		ConnectionArgs: connectionArgs,
 		DatabaseName:   spec.SQL.DatabaseName,
 	}
 
@@ -344,20 +399,23 @@ func (b *SchemaScriptsConfigmapBuilder) GetStoreSetupTemplate(spec *v1beta1.Data
 			Username:       spec.Elasticsearch.Username,
 			PasswordEnvVar: spec.GetPasswordEnvVarName(),
 			Indices:        spec.Elasticsearch.Indices,
+			CurlTLSArgs:    b.getESCurlTLSArgs(spec),
 		}
 		return b.renderTemplate(setupESVisibility, data)
 	}
 
-	args, err := b.getStoreArgs(spec)
+	connectionArgs, err := b.getConnectionArgs(spec)
 	if err != nil {
-		return "", fmt.Errorf("can't get store args: %w", err)
+		return "", err
 	}
 
 	data := setupSchemaData{
 		baseData:       b.baseData(),
 		Tool:           b.getStoreTool(storeType),
-		ConnectionArgs: b.argsMapToString(args),
+		ConnectionArgs: connectionArgs,
 		InitialVersion: "0.0",
+		PreHooks:       b.getHooksCommands(spec, connectionArgs, v1beta1.PreSetupPersistenceHookPhase),
+		PostHooks:      b.getHooksCommands(spec, connectionArgs, v1beta1.PostSetupPersistenceHookPhase),
 	}
 
 	return b.renderTemplate(setupSchemaTemplate, data)
@@ -373,25 +431,114 @@ func (b *SchemaScriptsConfigmapBuilder) GetStoreUpdateTemplate(spec *v1beta1.Dat
 			Username:       spec.Elasticsearch.Username,
 			PasswordEnvVar: spec.GetPasswordEnvVarName(),
 			Indices:        spec.Elasticsearch.Indices,
+			CurlTLSArgs:    b.getESCurlTLSArgs(spec),
 		}
 		return b.renderTemplate(updateESVisibility, data)
 	}
 
-	args, err := b.getStoreArgs(spec)
+	connectionArgs, err := b.getConnectionArgs(spec)
 	if err != nil {
-		return "", fmt.Errorf("can't get store args: %w", err)
+		return "", err
 	}
 
 	data := updateSchemaData{
 		baseData:       b.baseData(),
 		Tool:           b.getStoreTool(storeType),
-		ConnectionArgs: b.argsMapToString(args),
+		ConnectionArgs: connectionArgs,
 		SchemaDir:      b.computeSchemaDir(storeType, targetSchema),
+		PreHooks:       b.getHooksCommands(spec, connectionArgs, v1beta1.PreUpdatePersistenceHookPhase),
+		PostHooks:      b.getHooksCommands(spec, connectionArgs, v1beta1.PostUpdatePersistenceHookPhase),
 	}
 
 	return b.renderTemplate(updateSchemaTemplate, data)
 }
 
+// GetStoreMigrateTemplate returns the script copying the database the datastore has been set up in to the database set in its spec.
+// It returns a no-op script if no migration is pending.
+func (b *SchemaScriptsConfigmapBuilder) GetStoreMigrateTemplate(spec *v1beta1.DatastoreSpec, status *v1beta1.DatastoreStatus) (string, error) {
+	if !status.MigrationPending(spec) || !spec.IsSQL() {
+		return b.renderTemplate(noOpTemplate, b.baseData())
+	}
+
+	host, port, err := net.SplitHostPort(b.instance.GetDatastoreConnectAddr(spec))
+	if err != nil {
+		return "", fmt.Errorf("can't parse host port: %w", err)
+	}
+
+	data := migrateDatabaseData{
+		baseData: b.baseData(),
+		Host:     host,
+		Port:     port,
+		User:     spec.SQL.User,
+		Source:   status.DatabaseName,
+		Target:   spec.SQL.DatabaseName,
+	}
+
+	if spec.PasswordSecretRef != nil {
+		data.PasswordEnvVar = spec.GetPasswordEnvVarName()
+	}
+
+	switch spec.GetType() {
+	case v1beta1.PostgresSQLDatastore, v1beta1.PostgresSQL12Datastore:
+		data.Postgres = true
+		data.TLSEnv = b.getPostgresTLSEnv(spec)
+	default:
+		data.TLSArgs = b.getMySQLTLSArgs(spec)
+	}
+
+	return b.renderTemplate(migrateDatabaseTemplate, data)
+}
+
+// getPostgresTLSEnv returns the libpq environment variables matching the datastore TLS configuration.
+func (b *SchemaScriptsConfigmapBuilder) getPostgresTLSEnv(spec *v1beta1.DatastoreSpec) []string {
+	if spec.TLS == nil || !spec.TLS.Enabled {
+		return nil
+	}
+
+	mode := "require"
+	if spec.TLS.EnableHostVerification {
+		mode = "verify-full"
+	}
+
+	env := []string{fmt.Sprintf("PGSSLMODE=%s", mode)}
+	if caFile := spec.GetTLSCaFileMountPath(); caFile != "" {
+		env = append(env, fmt.Sprintf("PGSSLROOTCERT=%s", caFile))
+	}
+	if certFile := spec.GetTLSCertFileMountPath(); certFile != "" {
+		env = append(env, fmt.Sprintf("PGSSLCERT=%s", certFile))
+	}
+	if keyFile := spec.GetTLSKeyFileMountPath(); keyFile != "" {
+		env = append(env, fmt.Sprintf("PGSSLKEY=%s", keyFile))
+	}
+
+	return env
+}
+
+// getMySQLTLSArgs returns the mysql client arguments matching the datastore TLS configuration.
+func (b *SchemaScriptsConfigmapBuilder) getMySQLTLSArgs(spec *v1beta1.DatastoreSpec) []string {
+	if spec.TLS == nil || !spec.TLS.Enabled {
+		return nil
+	}
+
+	mode := "REQUIRED"
+	if spec.TLS.EnableHostVerification {
+		mode = "VERIFY_IDENTITY"
+	}
+
+	args := []string{fmt.Sprintf("--ssl-mode=%s", mode)}
+	if caFile := spec.GetTLSCaFileMountPath(); caFile != "" {
+		args = append(args, fmt.Sprintf("--ssl-ca=%s", caFile))
+	}
+	if certFile := spec.GetTLSCertFileMountPath(); certFile != "" {
+		args = append(args, fmt.Sprintf("--ssl-cert=%s", certFile))
+	}
+	if keyFile := spec.GetTLSKeyFileMountPath(); keyFile != "" {
+		args = append(args, fmt.Sprintf("--ssl-key=%s", keyFile))
+	}
+
+	return args
+}
+
 func (b *SchemaScriptsConfigmapBuilder) Update(object client.Object) error {
 	configMap := object.(*corev1.ConfigMap)
 	configMap.Data = map[string]string{}
@@ -402,6 +549,22 @@ func (b *SchemaScriptsConfigmapBuilder) Update(object client.Object) error {
 		return err
 	}
 
+	var defaultStatus, visibilityStatus *v1beta1.DatastoreStatus
+	if b.instance.Status.Persistence != nil {
+		defaultStatus = b.instance.Status.Persistence.DefaultStore
+		visibilityStatus = b.instance.Status.Persistence.VisibilityStore
+	}
+
+	configMap.Data[MigrateDefaultDatabaseScript], err = b.GetStoreMigrateTemplate(b.instance.Spec.Persistence.DefaultStore, defaultStatus)
+	if err != nil {
+		return err
+	}
+
+	configMap.Data[MigrateVisibilityDatabaseScript], err = b.GetStoreMigrateTemplate(b.instance.Spec.Persistence.VisibilityStore, visibilityStatus)
+	if err != nil {
+		return err
+	}
+
 	configMap.Data[SetupDefaultSchemaScript], err = b.GetStoreSetupTemplate(b.instance.Spec.Persistence.DefaultStore)
 	if err != nil {
 		return err
@@ -22,6 +22,7 @@ import (
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/meta"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/istio"
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/linkerd"
 	batchv1 "k8s.io/api/batch/v1"
@@ -67,7 +68,7 @@ func (b *SchemaJobBuilder) Build() client.Object {
 			Value: fmt.Sprintf("%s:%d", b.instance.ChildResourceName("frontend"), *b.instance.Spec.Services.Frontend.Port),
 		},
 	}
-	envVars = append(envVars, GetDatastoresEnvironmentVariables(datastores)...)
+	envVars = append(envVars, GetDatastoresEnvironmentVariables(b.instance, datastores)...)
 
 	volumeMounts := []corev1.VolumeMount{
 		{
@@ -77,6 +78,7 @@ func (b *SchemaJobBuilder) Build() client.Object {
 	}
 
 	volumeMounts = append(volumeMounts, GetDatastoresVolumeMounts(datastores)...)
+	volumeMounts = append(volumeMounts, GetHooksVolumeMounts(b.instance)...)
 
 	volumes := []corev1.Volume{
 		{
@@ -93,8 +95,9 @@ func (b *SchemaJobBuilder) Build() client.Object {
 	}
 
 	volumes = append(volumes, GetDatastoresVolumes(datastores)...)
+	volumes = append(volumes, GetHooksVolumes(b.instance)...)
 
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        b.instance.ChildResourceName(b.name),
 			Namespace:   b.instance.Namespace,
@@ -103,6 +106,8 @@ func (b *SchemaJobBuilder) Build() client.Object {
 		},
 		Spec: batchv1.JobSpec{
 			TTLSecondsAfterFinished: b.instance.Spec.JobTTLSecondsAfterFinished,
+			ActiveDeadlineSeconds:   b.instance.Spec.JobActiveDeadlineSeconds,
+			BackoffLimit:            b.instance.Spec.JobBackoffLimit,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: metadata.Merge(
@@ -123,8 +128,8 @@ func (b *SchemaJobBuilder) Build() client.Object {
 					Containers: []corev1.Container{
 						{
 							Name:                     "schema-script-runner",
-							Image:                    fmt.Sprintf("%s:%s", b.instance.Spec.AdminTools.Image, b.instance.Spec.Version),
-							ImagePullPolicy:          corev1.PullIfNotPresent,
+							Image:                    b.instance.AdminToolsImage(),
+							ImagePullPolicy:          b.instance.GetImagePullPolicy(),
 							Resources:                b.instance.Spec.JobResources,
 							TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 							TerminationMessagePolicy: corev1.TerminationMessageReadFile,
@@ -141,11 +146,19 @@ func (b *SchemaJobBuilder) Build() client.Object {
 					DNSPolicy:                     corev1.DNSClusterFirst,
 					SecurityContext:               &corev1.PodSecurityContext{},
 					SchedulerName:                 corev1.DefaultSchedulerName,
+					NodeSelector:                  b.instance.Spec.JobNodeSelector,
+					Tolerations:                   b.instance.Spec.JobTolerations,
+					Affinity:                      b.instance.Spec.JobAffinity,
+					PriorityClassName:             b.instance.Spec.JobPriorityClassName,
 					Volumes:                       volumes,
 				},
 			},
 		},
 	}
+
+	meta.ApplyPodSecurity(b.instance, &job.Spec.Template.Spec)
+
+	return job
 }
 
 func (b *SchemaJobBuilder) Update(object client.Object) error {
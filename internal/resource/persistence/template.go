@@ -39,6 +39,9 @@ const (
 	updateSchemaTemplate = "update-schema.sh"
 	updateESVisibility   = "update-es-visibility.sh"
 
+	// Migrate datastores templates.
+	migrateDatabaseTemplate = "migrate-database.sh"
+
 	// noOpTemplate does nothing.
 	noOpTemplate = "no-op.sh"
 )
@@ -69,12 +72,80 @@ var (
 		`),
 		setupSchemaTemplate: dedent.Dedent(`
 			#!/bin/bash
+			(
+			set -e
+			{{ range .PreHooks }}{{ . }}
+			{{ end -}}
 			{{ .Tool }} {{ .ConnectionArgs }} setup-schema -v {{ .InitialVersion }}
+			{{ range .PostHooks }}{{ . }}
+			{{ end -}}
+			)
 			{{ template "scripts" . }}
 		`),
 		updateSchemaTemplate: dedent.Dedent(`
 			#!/bin/bash
+			(
+			set -e
+			{{ range .PreHooks }}{{ . }}
+			{{ end -}}
 			{{ .Tool }} {{ .ConnectionArgs }} update-schema -d {{ .SchemaDir }}
+			{{ range .PostHooks }}{{ . }}
+			{{ end -}}
+			)
+			{{ template "scripts" . }}
+		`),
+		migrateDatabaseTemplate: dedent.Dedent(`
+			#!/bin/bash
+			(
+			set -eo pipefail
+
+			{{- if .Postgres }}
+			{{ if .PasswordEnvVar }}export PGPASSWORD="${{ .PasswordEnvVar }}"{{ end }}
+			{{ range .TLSEnv }}export {{ . }}
+			{{ end }}
+			run_sql() { psql -v ON_ERROR_STOP=1 -h "{{ .Host }}" -p "{{ .Port }}" -U "{{ .User }}" -At -d "$1" -c "$2"; }
+			list_tables() { run_sql "$1" "SELECT tablename FROM pg_tables WHERE schemaname = 'public' ORDER BY tablename"; }
+			count_rows() { run_sql "$1" "SELECT count(*) FROM \"$2\""; }
+
+			if [ "$(run_sql postgres "SELECT count(*) FROM pg_database WHERE datname = '{{ .Target }}'")" = "0" ]; then
+				run_sql postgres "CREATE DATABASE \"{{ .Target }}\""
+			fi
+			if [ -n "$(list_tables "{{ .Target }}")" ]; then
+				echo "Target database {{ .Target }} is not empty, drop it before retrying the migration"
+				exit 1
+			fi
+
+			echo "Copying database {{ .Source }} to {{ .Target }}"
+			pg_dump -h "{{ .Host }}" -p "{{ .Port }}" -U "{{ .User }}" --no-owner --no-privileges "{{ .Source }}" \
+				| psql -v ON_ERROR_STOP=1 -q -h "{{ .Host }}" -p "{{ .Port }}" -U "{{ .User }}" -d "{{ .Target }}"
+			{{- else }}
+			mysql_args=(-h "{{ .Host }}" -P "{{ .Port }}" -u "{{ .User }}"{{ if .PasswordEnvVar }} -p"${{ .PasswordEnvVar }}"{{ end }}{{ range .TLSArgs }} {{ . }}{{ end }})
+			run_sql() { mysql "${mysql_args[@]}" -N -B -e "$2" "$1"; }
+			list_tables() { run_sql "$1" "SELECT table_name FROM information_schema.tables WHERE table_schema = '$1' ORDER BY table_name"; }
+			count_rows() { run_sql "$1" "SELECT COUNT(*) FROM ` + "\\`$2\\`" + `"; }
+
+			run_sql information_schema "CREATE DATABASE IF NOT EXISTS ` + "\\`{{ .Target }}\\`" + `"
+			if [ -n "$(list_tables "{{ .Target }}")" ]; then
+				echo "Target database {{ .Target }} is not empty, drop it before retrying the migration"
+				exit 1
+			fi
+
+			echo "Copying database {{ .Source }} to {{ .Target }}"
+			mysqldump "${mysql_args[@]}" --single-transaction --routines --triggers "{{ .Source }}" \
+				| mysql "${mysql_args[@]}" "{{ .Target }}"
+			{{- end }}
+
+			echo "Validating database {{ .Target }}"
+			for table in $(list_tables "{{ .Source }}"); do
+				source_rows=$(count_rows "{{ .Source }}" "$table")
+				target_rows=$(count_rows "{{ .Target }}" "$table")
+				if [ "$source_rows" != "$target_rows" ]; then
+					echo "Table $table has $source_rows rows in {{ .Source }} but $target_rows rows in {{ .Target }}"
+					exit 1
+				fi
+				echo "Table $table: $source_rows rows copied"
+			done
+			)
 			{{ template "scripts" . }}
 		`),
 		setupESVisibility: dedent.Dedent(`
@@ -82,12 +153,12 @@ var (
 			# Change index_patterns from temporal_visibility_v1* to {{ .Indices.Visibility }}* at index_template_{{ .Version }}.json before apply
 			sed 's/temporal_visibility_v1./{{ .Indices.Visibility }}*/g' /etc/temporal/schema/elasticsearch/visibility/index_template_{{ .Version }}.json > /tmp/index_template_{{ .Version }}.json
 
-			curl --fail --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/_cluster/settings" -H "Content-Type: application/json" --data-binary @/etc/temporal/schema/elasticsearch/visibility/cluster_settings_{{ .Version }}.json --write-out "\n"
-			curl --fail --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/_template/{{ .Indices.Visibility }}_template" -H "Content-Type: application/json" --data-binary @/tmp/index_template_{{ .Version }}.json --write-out "\n"
+			curl --fail {{ .CurlTLSArgs }}--user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/_cluster/settings" -H "Content-Type: application/json" --data-binary @/etc/temporal/schema/elasticsearch/visibility/cluster_settings_{{ .Version }}.json --write-out "\n"
+			curl --fail {{ .CurlTLSArgs }}--user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/_template/{{ .Indices.Visibility }}_template" -H "Content-Type: application/json" --data-binary @/tmp/index_template_{{ .Version }}.json --write-out "\n"
 			# No --fail here because create index is not idempotent operaton.
-			curl --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}" --write-out "\n"
+			curl {{ .CurlTLSArgs }}--user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}" --write-out "\n"
 			{{ if .Indices.SecondaryVisibility }}
-			curl --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.SecondaryVisibility }}" --write-out "\n"
+			curl {{ .CurlTLSArgs }}--user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.SecondaryVisibility }}" --write-out "\n"
 			{{ end }}
 			{{ template "scripts" . }}
 		`),
@@ -126,7 +197,7 @@ var (
 						}
 						'
 
-						curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}${doc_type}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
+						curl --silent {{ .CurlTLSArgs }}--user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}${doc_type}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
 						;;
 					v3)
 						echo "Upgrading to schema v3"
@@ -141,7 +212,7 @@ var (
 						}
 						'
 
-						curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
+						curl --silent {{ .CurlTLSArgs }}--user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
 						;;
 					v4)
 						echo "Upgrading to schema v4"
@@ -156,7 +227,7 @@ var (
 						}
 						'
 
-						curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
+						curl --silent {{ .CurlTLSArgs }}--user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
 						;;
 					v5)
 						echo "Upgrading to schema v5"
@@ -171,7 +242,7 @@ var (
 						}
 						'
 
-						curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
+						curl --silent {{ .CurlTLSArgs }}--user "{{ .Username }}":"${{ .PasswordEnvVar }}" -X PUT "{{ .URL }}/{{ .Indices.Visibility }}/_mapping" -H "Content-Type: application/json" --data-binary "$new_mapping" | jq
 					;;
 				esac
 			}
@@ -182,7 +253,7 @@ var (
 			current_version_found=false
 
 			# Get the current_mapping value in elasticsearch.
-			current_mapping=$(curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}" {{ .URL }}/{{ .Indices.Visibility }})
+			current_mapping=$(curl --silent {{ .CurlTLSArgs }}--user "{{ .Username }}":"${{ .PasswordEnvVar }}" {{ .URL }}/{{ .Indices.Visibility }})
 
 			# Guess current mapping version
 			# v0 does not have the "ExecutionDuration" property
@@ -268,7 +339,7 @@ var (
 
 			do_upgrade $expected_version
 
-			until curl --silent --user "{{ .Username }}":"${{ .PasswordEnvVar }}" "{{ .URL }}/_cluster/health/{{ .Indices.Visibility }}" | jq --exit-status '.status=="green" | .'; do
+			until curl --silent {{ .CurlTLSArgs }}--user "{{ .Username }}":"${{ .PasswordEnvVar }}" "{{ .URL }}/_cluster/health/{{ .Indices.Visibility }}" | jq --exit-status '.status=="green" | .'; do
 				echo "Waiting for Elasticsearch index {{ .Indices.Visibility }} become green."
 				sleep 1
 			done
@@ -301,6 +372,9 @@ type (
 		Tool           string
 		ConnectionArgs string
 		InitialVersion string
+		// PreHooks and PostHooks are the commands running the persistence hooks scripts.
+		PreHooks  []string
+		PostHooks []string
 	}
 
 	updateSchemaData struct {
@@ -308,6 +382,25 @@ type (
 		Tool           string
 		ConnectionArgs string
 		SchemaDir      string
+		// PreHooks and PostHooks are the commands running the persistence hooks scripts.
+		PreHooks  []string
+		PostHooks []string
+	}
+
+	migrateDatabaseData struct {
+		baseData
+		// Postgres is true for postgres datastores, mysql is used otherwise.
+		Postgres       bool
+		Host           string
+		Port           string
+		User           string
+		PasswordEnvVar string
+		// TLSEnv are the libpq TLS environment variables, for postgres datastores.
+		TLSEnv []string
+		// TLSArgs are the mysql client TLS arguments, for mysql datastores.
+		TLSArgs []string
+		Source  string
+		Target  string
 	}
 
 	esSchemaData struct {
@@ -317,6 +410,8 @@ type (
 		Username       string
 		PasswordEnvVar string
 		Indices        v1beta1.ElasticsearchIndices
+		// CurlTLSArgs holds curl TLS arguments, with a trailing space if not empty.
+		CurlTLSArgs string
 	}
 )
 
@@ -36,3 +36,29 @@ func TestTemplates(t *testing.T) {
 	}))
 	assert.Contains(t, s.String(), "curl -X POST http://localhost:4191/shutdown")
 }
+
+func TestMigrateDatabaseTemplate(t *testing.T) {
+	var s strings.Builder
+	assert.NoError(t, templates[migrateDatabaseTemplate].Execute(&s, migrateDatabaseData{
+		Postgres:       true,
+		Host:           "postgres",
+		Port:           "5432",
+		User:           "temporal",
+		PasswordEnvVar: "TEMPORAL_DEFAULT_DATASTORE_PASSWORD",
+		Source:         "temporal",
+		Target:         "temporal_v2",
+	}))
+	assert.Contains(t, s.String(), `pg_dump -h "postgres" -p "5432" -U "temporal" --no-owner --no-privileges "temporal"`)
+	assert.Contains(t, s.String(), "temporal_v2")
+}
+
+func TestSetupSchemaTemplateWithHooks(t *testing.T) {
+	var s strings.Builder
+	assert.NoError(t, templates[setupSchemaTemplate].Execute(&s, setupSchemaData{
+		Tool:           "temporal-sql-tool",
+		ConnectionArgs: "--ep postgres",
+		InitialVersion: "0.0",
+		PreHooks:       []string{"temporal-sql-tool --ep postgres setup-schema --schema-file /etc/temporal/hooks/pre-setup/extensions --disable-versioning"},
+	}))
+	assert.Contains(t, s.String(), "set -e\ntemporal-sql-tool --ep postgres setup-schema --schema-file /etc/temporal/hooks/pre-setup/extensions --disable-versioning\ntemporal-sql-tool --ep postgres setup-schema -v 0.0\n)")
+}
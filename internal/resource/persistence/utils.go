@@ -19,6 +19,7 @@ package persistence
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
@@ -27,14 +28,17 @@ import (
 
 const (
 	defaultPasswordSecretKey = "password"
+
+	hooksVolumeName = "persistence-hooks"
 )
 
-// GetDatastoresEnvironmentVariables returns needed env vars for the provided datastores list.
-func GetDatastoresEnvironmentVariables(datastores []*v1beta1.DatastoreSpec) []corev1.EnvVar {
+// GetDatastoresEnvironmentVariables returns needed env vars for the provided cluster's datastores list.
+func GetDatastoresEnvironmentVariables(cluster *v1beta1.TemporalCluster, datastores []*v1beta1.DatastoreSpec) []corev1.EnvVar {
 	vars := []corev1.EnvVar{}
 	for _, datastore := range datastores {
-		if datastore.PasswordSecretRef != nil {
-			key := datastore.PasswordSecretRef.Key
+		passwordSecretRef := cluster.GetDatastorePasswordSecretRef(datastore)
+		if passwordSecretRef != nil {
+			key := passwordSecretRef.Key
 			if key == "" {
 				key = defaultPasswordSecretKey
 			}
@@ -44,7 +48,7 @@ func GetDatastoresEnvironmentVariables(datastores []*v1beta1.DatastoreSpec) []co
 					ValueFrom: &corev1.EnvVarSource{
 						SecretKeyRef: &corev1.SecretKeySelector{
 							LocalObjectReference: corev1.LocalObjectReference{
-								Name: datastore.PasswordSecretRef.Name,
+								Name: passwordSecretRef.Name,
 							},
 							Key: key,
 						},
@@ -162,3 +166,55 @@ func GetDatastoresVolumeMounts(datastores []*v1beta1.DatastoreSpec) []corev1.Vol
 	}
 	return volumeMounts
 }
+
+// GetHooksVolumes returns the volume holding the cluster's persistence hooks scripts, if any.
+func GetHooksVolumes(cluster *v1beta1.TemporalCluster) []corev1.Volume {
+	sources := []corev1.VolumeProjection{}
+	for _, phase := range v1beta1.PersistenceHookPhases {
+		for _, hook := range cluster.Spec.Persistence.Hooks.Get(phase) {
+			sources = append(sources, corev1.VolumeProjection{
+				ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: hook.ConfigMapRef.Name,
+					},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  hook.ConfigMapRef.Key,
+							Path: path.Join(string(phase), hook.Name),
+						},
+					},
+				},
+			})
+		}
+	}
+
+	if len(sources) == 0 {
+		return []corev1.Volume{}
+	}
+
+	return []corev1.Volume{
+		{
+			Name: hooksVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: sources,
+				},
+			},
+		},
+	}
+}
+
+// GetHooksVolumeMounts returns the volume mount of the cluster's persistence hooks scripts, if any.
+func GetHooksVolumeMounts(cluster *v1beta1.TemporalCluster) []corev1.VolumeMount {
+	if len(GetHooksVolumes(cluster)) == 0 {
+		return []corev1.VolumeMount{}
+	}
+
+	return []corev1.VolumeMount{
+		{
+			Name:      hooksVolumeName,
+			MountPath: v1beta1.PersistenceHooksMountPath,
+			ReadOnly:  true,
+		},
+	}
+}
@@ -24,10 +24,12 @@ import (
 	"github.com/alexandrevilain/temporal-operator/internal/resource/persistence"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetDatastoresEnvironmentVariables(t *testing.T) {
 	tests := map[string]struct {
+		cluster         *v1beta1.TemporalCluster
 		datastores      []*v1beta1.DatastoreSpec
 		expectedEnvVars []corev1.EnvVar
 	}{
@@ -82,6 +84,45 @@ func TestGetDatastoresEnvironmentVariables(t *testing.T) {
 				},
 			},
 		},
+		"one datastore with secret decryption": {
+			cluster: &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "prod",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						SecretDecryption: &v1beta1.SecretDecryptionSpec{
+							Provider: v1beta1.AESGCMSecretDecryptionProvider,
+							KeyRef: &v1beta1.SecretKeyReference{
+								Name: "key",
+							},
+						},
+					},
+				},
+			},
+			datastores: []*v1beta1.DatastoreSpec{
+				{
+					Name: "default",
+					PasswordSecretRef: &v1beta1.SecretKeyReference{
+						Name: "testSecret",
+						Key:  "my-password",
+					},
+				},
+			},
+			expectedEnvVars: []corev1.EnvVar{
+				{
+					Name: "TEMPORAL_DEFAULT_DATASTORE_PASSWORD",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "prod-default-datastore-password",
+							},
+							Key: "password",
+						},
+					},
+				},
+			},
+		},
 		"two datastores": {
 			datastores: []*v1beta1.DatastoreSpec{
 				{
@@ -128,7 +169,11 @@ func TestGetDatastoresEnvironmentVariables(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(tt *testing.T) {
-			result := persistence.GetDatastoresEnvironmentVariables(test.datastores)
+			cluster := test.cluster
+			if cluster == nil {
+				cluster = &v1beta1.TemporalCluster{}
+			}
+			result := persistence.GetDatastoresEnvironmentVariables(cluster, test.datastores)
 			assert.EqualValues(tt, test.expectedEnvVars, result)
 		})
 	}
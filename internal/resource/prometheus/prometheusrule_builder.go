@@ -0,0 +1,132 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*PrometheusRuleBuilder)(nil)
+
+const prometheusRuleName = "alerts"
+
+type PrometheusRuleBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+}
+
+func NewPrometheusRuleBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme) *PrometheusRuleBuilder {
+	return &PrometheusRuleBuilder{
+		instance: instance,
+		scheme:   scheme,
+	}
+}
+
+func (b *PrometheusRuleBuilder) Build() client.Object {
+	return &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.ChildResourceName(prometheusRuleName),
+			Namespace:   b.instance.Namespace,
+			Labels:      metadata.GetLabels(b.instance, prometheusRuleName, b.instance.Spec.Version, b.instance.Labels),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *PrometheusRuleBuilder) Enabled() bool {
+	return b.instance.Spec.Metrics != nil &&
+		b.instance.Spec.Metrics.Prometheus != nil &&
+		b.instance.Spec.Metrics.Prometheus.PrometheusRule != nil &&
+		b.instance.Spec.Metrics.Prometheus.PrometheusRule.Enabled
+}
+
+func (b *PrometheusRuleBuilder) Update(object client.Object) error {
+	rule := object.(*monitoringv1.PrometheusRule)
+	spec := b.instance.Spec.Metrics.Prometheus.PrometheusRule
+
+	extraLabels := spec.Labels
+	if extraLabels == nil {
+		extraLabels = map[string]string{}
+	}
+
+	rule.Labels = metadata.Merge(
+		rule.GetLabels(),
+		extraLabels,
+	)
+
+	rule.Annotations = metadata.Merge(
+		rule.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+	)
+
+	threshold := spec.GetSchemaJobAlertThreshold()
+	selector := fmt.Sprintf(`namespace=%q, name=%q`, b.instance.Namespace, b.instance.Name)
+	pending := fmt.Sprintf(`temporal_operator_schema_job_succeeded{%s} == 0`, selector)
+	seconds := int64(threshold / time.Second)
+	forDuration := monitoringv1.Duration(fmt.Sprintf("%ds", seconds))
+
+	rule.Spec = monitoringv1.PrometheusRuleSpec{
+		Groups: []monitoringv1.RuleGroup{
+			{
+				Name: "temporal-schema-jobs",
+				Rules: []monitoringv1.Rule{
+					{
+						Alert: "TemporalSchemaJobFailing",
+						Expr:  intstr.FromString(fmt.Sprintf(`temporal_operator_schema_job_failures{%s} > 0 and on(namespace, name, schema_job) %s`, selector, pending)),
+						For:   &forDuration,
+						Labels: map[string]string{
+							"severity": "warning",
+						},
+						Annotations: map[string]string{
+							"summary":     "Temporal schema job is failing",
+							"description": "Schema job {{ $labels.schema_job }} of TemporalCluster {{ $labels.namespace }}/{{ $labels.name }} has been failing for more than " + threshold.String() + ".",
+						},
+					},
+					{
+						Alert: "TemporalSchemaJobHanging",
+						Expr:  intstr.FromString(fmt.Sprintf(`temporal_operator_schema_job_duration_seconds{%s} > %d and on(namespace, name, schema_job) %s`, selector, seconds, pending)),
+						Labels: map[string]string{
+							"severity": "warning",
+						},
+						Annotations: map[string]string{
+							"summary":     "Temporal schema job is not completing",
+							"description": "Schema job {{ $labels.schema_job }} of TemporalCluster {{ $labels.namespace }}/{{ $labels.name }} has been running for more than " + threshold.String() + ".",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, rule, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
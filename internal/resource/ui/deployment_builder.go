@@ -19,6 +19,8 @@ package ui
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/metadata"
@@ -29,6 +31,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -36,6 +39,9 @@ import (
 
 const (
 	uiCertsMountPath = "/etc/temporal/config/certs/client/ui"
+
+	uiPort          = 8080
+	oauth2ProxyPort = 4180
 )
 
 type DeploymentBuilder struct {
@@ -88,10 +94,12 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		},
 		{
 			Name:  "TEMPORAL_UI_PORT",
-			Value: "8080",
+			Value: strconv.Itoa(uiPort),
 		},
 	}
 
+	env = append(env, b.settingsEnv()...)
+
 	if b.instance.MTLSWithCertManagerEnabled() && b.instance.Spec.MTLS.FrontendEnabled() {
 		volumeMounts = append(volumeMounts,
 			corev1.VolumeMount{
@@ -115,6 +123,15 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		env = append(env, certmanager.GetTLSEnvironmentVariables(b.instance, "TEMPORAL", uiCertsMountPath)...)
 	}
 
+	env = mergeEnv(env, b.instance.Spec.UI.Env)
+
+	// When oauth2-proxy is enabled, it receives the traffic on the "http" port
+	// and forwards authenticated requests to the UI.
+	uiPortName := "http"
+	if b.instance.Spec.UI.OAuth2Proxy != nil {
+		uiPortName = "ui"
+	}
+
 	deployment.Spec.Replicas = b.instance.Spec.UI.Replicas
 
 	deployment.Spec.Selector = &metav1.LabelSelector{
@@ -127,15 +144,15 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 			Containers: []corev1.Container{
 				{
 					Name:                     "ui",
-					Image:                    fmt.Sprintf("%s:%s", b.instance.Spec.UI.Image, b.instance.Spec.UI.Version),
-					ImagePullPolicy:          corev1.PullIfNotPresent,
+					Image:                    b.instance.UIImage(),
+					ImagePullPolicy:          b.instance.GetImagePullPolicy(),
 					Resources:                b.instance.Spec.UI.Resources,
 					TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 					TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 					Ports: []corev1.ContainerPort{
 						{
-							Name:          "http",
-							ContainerPort: int32(8080),
+							Name:          uiPortName,
+							ContainerPort: int32(uiPort),
 							Protocol:      corev1.ProtocolTCP,
 						},
 					},
@@ -156,6 +173,12 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 		},
 	}
 
+	if b.instance.Spec.UI.OAuth2Proxy != nil {
+		deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers, b.oauth2ProxyContainer())
+	}
+
+	meta.ApplyPodSecurity(b.instance, &deployment.Spec.Template.Spec)
+
 	if b.instance.Spec.UI.Overrides != nil && b.instance.Spec.UI.Overrides.Deployment != nil {
 		err := kubernetes.ApplyDeploymentOverrides(deployment, b.instance.Spec.UI.Overrides.Deployment)
 		if err != nil {
@@ -169,3 +192,138 @@ func (b *DeploymentBuilder) Update(object client.Object) error {
 
 	return nil
 }
+
+// settingsEnv returns the environment variables configuring the UI server from the UI spec.
+func (b *DeploymentBuilder) settingsEnv() []corev1.EnvVar {
+	spec := b.instance.Spec.UI
+	env := []corev1.EnvVar{}
+
+	if spec.PublicPath != "" {
+		env = append(env, corev1.EnvVar{Name: "TEMPORAL_UI_PUBLIC_PATH", Value: spec.PublicPath})
+	}
+
+	if len(spec.ForwardHeaders) > 0 {
+		env = append(env, corev1.EnvVar{Name: "TEMPORAL_FORWARD_HEADERS", Value: strings.Join(spec.ForwardHeaders, ",")})
+	}
+
+	if spec.BannerText != "" {
+		env = append(env, corev1.EnvVar{Name: "TEMPORAL_BANNER_TEXT", Value: spec.BannerText})
+	}
+
+	if spec.DefaultNamespace != "" {
+		env = append(env, corev1.EnvVar{Name: "TEMPORAL_DEFAULT_NAMESPACE", Value: spec.DefaultNamespace})
+	}
+
+	if spec.ShowTemporalSystemNamespace {
+		env = append(env, corev1.EnvVar{Name: "TEMPORAL_SHOW_TEMPORAL_SYSTEM_NAMESPACE", Value: "true"})
+	}
+
+	if spec.DisableWriteActions {
+		env = append(env, corev1.EnvVar{Name: "TEMPORAL_DISABLE_WRITE_ACTIONS", Value: "true"})
+	}
+
+	if spec.Codec != nil {
+		env = append(env,
+			corev1.EnvVar{Name: "TEMPORAL_CODEC_ENDPOINT", Value: spec.Codec.Endpoint},
+			corev1.EnvVar{Name: "TEMPORAL_CODEC_PASS_ACCESS_TOKEN", Value: strconv.FormatBool(spec.Codec.PassAccessToken)},
+			corev1.EnvVar{Name: "TEMPORAL_CODEC_INCLUDE_CREDENTIALS", Value: strconv.FormatBool(spec.Codec.IncludeCredentials)},
+		)
+	}
+
+	return env
+}
+
+// mergeEnv returns the base environment variables, replaced or completed by the provided overrides.
+func mergeEnv(base, overrides []corev1.EnvVar) []corev1.EnvVar {
+	result := make([]corev1.EnvVar, 0, len(base)+len(overrides))
+	overridden := map[string]bool{}
+	for _, v := range overrides {
+		overridden[v.Name] = true
+	}
+
+	for _, v := range base {
+		if !overridden[v.Name] {
+			result = append(result, v)
+		}
+	}
+
+	return append(result, overrides...)
+}
+
+// oauth2ProxyContainer returns the oauth2-proxy container authenticating requests to the UI.
+func (b *DeploymentBuilder) oauth2ProxyContainer() corev1.Container {
+	spec := b.instance.Spec.UI.OAuth2Proxy
+
+	proxyPrefix := "/oauth2"
+	if b.instance.Spec.UI.PublicPath != "" {
+		proxyPrefix = b.instance.Spec.UI.PublicPath + proxyPrefix
+	}
+
+	args := []string{
+		fmt.Sprintf("--http-address=0.0.0.0:%d", oauth2ProxyPort),
+		fmt.Sprintf("--upstream=http://127.0.0.1:%d/", uiPort),
+		"--provider=oidc",
+		fmt.Sprintf("--oidc-issuer-url=%s", spec.IssuerURL),
+		fmt.Sprintf("--client-id=%s", spec.ClientID),
+		fmt.Sprintf("--proxy-prefix=%s", proxyPrefix),
+		"--reverse-proxy=true",
+		"--pass-access-token=true",
+		"--set-xauthrequest=true",
+		"--skip-provider-button=true",
+	}
+
+	for _, domain := range spec.EmailDomains {
+		args = append(args, fmt.Sprintf("--email-domain=%s", domain))
+	}
+
+	for _, group := range spec.AllowedGroups {
+		args = append(args, fmt.Sprintf("--allowed-group=%s", group))
+	}
+
+	args = append(args, spec.ExtraArgs...)
+
+	return corev1.Container{
+		Name:                     "oauth2-proxy",
+		Image:                    b.instance.OAuth2ProxyImage(),
+		ImagePullPolicy:          b.instance.GetImagePullPolicy(),
+		Args:                     args,
+		Resources:                spec.Resources,
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "http",
+				ContainerPort: int32(oauth2ProxyPort),
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		Env: []corev1.EnvVar{
+			{
+				Name: "OAUTH2_PROXY_CLIENT_SECRET",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: spec.ClientSecretRef.Name},
+						Key:                  spec.ClientSecretRef.Key,
+					},
+				},
+			},
+			{
+				Name: "OAUTH2_PROXY_COOKIE_SECRET",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: spec.CookieSecretRef.Name},
+						Key:                  spec.CookieSecretRef.Key,
+					},
+				},
+			},
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/ping",
+					Port: intstr.FromString("http"),
+				},
+			},
+		},
+	}
+}
@@ -55,14 +55,16 @@ func (b *IngressBuilder) Build() client.Object {
 }
 
 func (b *IngressBuilder) Enabled() bool {
-	return b.instance.Spec.UI != nil &&
+	// On openshift, the UI is exposed using routes instead.
+	return !b.instance.IsOpenShift() &&
+		b.instance.Spec.UI != nil &&
 		b.instance.Spec.UI.Enabled &&
 		b.instance.Spec.UI.Ingress != nil
 }
 
 // parseHost parses the provided ingress host.
 // It parses the path, but it's useless for now has the UI does not support another path than "/".
-func (b *IngressBuilder) parseHost(host string) *url.URL {
+func parseHost(host string) *url.URL {
 	result := &url.URL{}
 	parts := strings.Split(host, "/")
 	if len(parts) == 0 {
@@ -78,12 +80,22 @@ func (b *IngressBuilder) parseHost(host string) *url.URL {
 func (b *IngressBuilder) Update(object client.Object) error {
 	ingress := object.(*networkingv1.Ingress)
 	ingress.Labels = object.GetLabels()
-	ingress.Annotations = metadata.Merge(object.GetAnnotations(), b.instance.Spec.UI.Ingress.Annotations)
+	ingress.Annotations = metadata.Merge(
+		metadata.RemoveExternalDNSAnnotations(object.GetAnnotations()),
+		b.instance.Spec.UI.Ingress.Annotations,
+		metadata.GetExternalDNSAnnotations(b.instance.Spec.Expose.GetUIHostnames(), b.instance.Spec.Expose.GetTTL()),
+	)
 
 	rules := make([]networkingv1.IngressRule, 0, len(b.instance.Spec.UI.Ingress.Hosts))
 
+	// Note that the generated source code for the UI uses hardcoded "/" unless a public path is set.
+	path := "/"
+	if b.instance.Spec.UI.PublicPath != "" {
+		path = b.instance.Spec.UI.PublicPath
+	}
+
 	for _, host := range b.instance.Spec.UI.Ingress.Hosts {
-		parsedURL := b.parseHost(host)
+		parsedURL := parseHost(host)
 		pathType := networkingv1.PathTypePrefix
 		rules = append(rules, networkingv1.IngressRule{
 			Host: parsedURL.Host,
@@ -91,7 +103,7 @@ func (b *IngressBuilder) Update(object client.Object) error {
 				HTTP: &networkingv1.HTTPIngressRuleValue{
 					Paths: []networkingv1.HTTPIngressPath{
 						{
-							Path:     "/", // Note that the generated source code for the UI uses hardcoded "/".
+							Path:     path,
 							PathType: &pathType,
 							Backend: networkingv1.IngressBackend{
 								Service: &networkingv1.IngressServiceBackend{
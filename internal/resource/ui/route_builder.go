@@ -0,0 +1,143 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ui
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// RouteGroupVersionKind is the GroupVersionKind of openshift routes.
+var RouteGroupVersionKind = schema.GroupVersionKind{
+	Group:   "route.openshift.io",
+	Version: "v1",
+	Kind:    "Route",
+}
+
+// NewRoute returns an empty openshift route.
+// Routes are handled as unstructured objects to avoid depending on the openshift api module.
+func NewRoute() *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(RouteGroupVersionKind)
+	return route
+}
+
+// RouteBuilder builds the openshift route exposing the UI for the ingress host at the provided index.
+// Routes only support a single host, so one route is created per ingress host.
+type RouteBuilder struct {
+	instance *v1beta1.TemporalCluster
+	scheme   *runtime.Scheme
+	index    int
+}
+
+func NewRouteBuilder(instance *v1beta1.TemporalCluster, scheme *runtime.Scheme, index int) *RouteBuilder {
+	return &RouteBuilder{
+		instance: instance,
+		scheme:   scheme,
+		index:    index,
+	}
+}
+
+func (b *RouteBuilder) name() string {
+	if b.index == 0 {
+		return b.instance.ChildResourceName("ui")
+	}
+	return b.instance.ChildResourceName(fmt.Sprintf("ui-%d", b.index))
+}
+
+func (b *RouteBuilder) Build() client.Object {
+	route := NewRoute()
+	route.SetName(b.name())
+	route.SetNamespace(b.instance.Namespace)
+	route.SetLabels(metadata.GetLabels(b.instance, "ui", b.instance.Spec.Version, b.instance.Labels))
+	route.SetAnnotations(metadata.GetAnnotations(b.instance.Name, b.instance.Annotations))
+	return route
+}
+
+func (b *RouteBuilder) Enabled() bool {
+	return b.instance.IsOpenShift() &&
+		b.instance.Spec.UI != nil &&
+		b.instance.Spec.UI.Enabled &&
+		b.instance.Spec.UI.Ingress != nil &&
+		b.index < len(b.instance.Spec.UI.Ingress.Hosts)
+}
+
+// tlsEnabled returns true if one of the ingress TLS entries covers the provided host.
+func (b *RouteBuilder) tlsEnabled(host string) bool {
+	for _, tls := range b.instance.Spec.UI.Ingress.TLS {
+		for _, tlsHost := range tls.Hosts {
+			if tlsHost == host {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (b *RouteBuilder) Update(object client.Object) error {
+	route := object.(*unstructured.Unstructured)
+	route.SetAnnotations(metadata.Merge(
+		metadata.RemoveExternalDNSAnnotations(route.GetAnnotations()),
+		b.instance.Spec.UI.Ingress.Annotations,
+		metadata.GetExternalDNSAnnotations(b.instance.Spec.Expose.GetUIHostnames(), b.instance.Spec.Expose.GetTTL()),
+	))
+
+	host := parseHost(b.instance.Spec.UI.Ingress.Hosts[b.index]).Host
+
+	// Note that the generated source code for the UI uses hardcoded "/" unless a public path is set.
+	path := "/"
+	if b.instance.Spec.UI.PublicPath != "" {
+		path = b.instance.Spec.UI.PublicPath
+	}
+
+	spec := map[string]any{
+		"host": host,
+		"path": path,
+		"to": map[string]any{
+			"kind":   "Service",
+			"name":   b.instance.ChildResourceName("ui"),
+			"weight": int64(100),
+		},
+		"port": map[string]any{
+			"targetPort": "http",
+		},
+	}
+
+	if b.tlsEnabled(host) {
+		spec["tls"] = map[string]any{
+			"termination":                   "edge",
+			"insecureEdgeTerminationPolicy": "Redirect",
+		}
+	}
+
+	if err := unstructured.SetNestedMap(route.Object, spec, "spec"); err != nil {
+		return fmt.Errorf("can't set route spec: %w", err)
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, route, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+	return nil
+}
@@ -50,6 +50,7 @@ func (b *ServiceBuilder) Build() client.Object {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      b.instance.ChildResourceName("ui"),
 			Namespace: b.instance.Namespace,
+			Labels:    metadata.GetLabels(b.instance, "ui", b.instance.Spec.Version, b.instance.Labels),
 		},
 	}
 }
@@ -60,8 +61,14 @@ func (b *ServiceBuilder) Enabled() bool {
 
 func (b *ServiceBuilder) Update(object client.Object) error {
 	service := object.(*corev1.Service)
-	service.Labels = object.GetLabels()
-	service.Annotations = object.GetAnnotations()
+	service.Labels = metadata.Merge(
+		object.GetLabels(),
+		metadata.GetLabels(b.instance, "ui", b.instance.Spec.Version, b.instance.Labels),
+	)
+	service.Annotations = metadata.Merge(
+		metadata.RemoveExternalDNSAnnotations(object.GetAnnotations()),
+		metadata.GetExternalDNSAnnotations(b.instance.Spec.Expose.GetUIHostnames(), b.instance.Spec.Expose.GetTTL()),
+	)
 	service.Spec.Type = corev1.ServiceTypeClusterIP
 	service.Spec.Selector = metadata.LabelsSelector(b.instance, "ui")
 	service.Spec.Ports = []corev1.ServicePort{
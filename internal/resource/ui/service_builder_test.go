@@ -0,0 +1,104 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestServiceBuilderUpdateExternalDNSAnnotations(t *testing.T) {
+	tests := map[string]struct {
+		expose              *v1beta1.ExposeSpec
+		existingAnnotations map[string]string
+		expected            map[string]string
+	}{
+		"hostnames are published": {
+			expose: &v1beta1.ExposeSpec{
+				Hostnames: &v1beta1.ExposeHostnamesSpec{
+					UI: []string{"ui.example.com"},
+				},
+			},
+			existingAnnotations: map[string]string{
+				"a": "b",
+			},
+			expected: map[string]string{
+				"a": "b",
+				"external-dns.alpha.kubernetes.io/hostname": "ui.example.com",
+			},
+		},
+		"hostnames are updated": {
+			expose: &v1beta1.ExposeSpec{
+				Hostnames: &v1beta1.ExposeHostnamesSpec{
+					UI: []string{"ui.example.org"},
+				},
+			},
+			existingAnnotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/hostname": "ui.example.com",
+				"external-dns.alpha.kubernetes.io/ttl":      "60",
+			},
+			expected: map[string]string{
+				"external-dns.alpha.kubernetes.io/hostname": "ui.example.org",
+			},
+		},
+		"unset hostnames are removed": {
+			expose: nil,
+			existingAnnotations: map[string]string{
+				"a": "b",
+				"external-dns.alpha.kubernetes.io/hostname": "ui.example.com",
+				"external-dns.alpha.kubernetes.io/ttl":      "60",
+			},
+			expected: map[string]string{
+				"a": "b",
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					UI: &v1beta1.TemporalUISpec{
+						Enabled: true,
+					},
+					Expose: test.expose,
+				},
+			}
+
+			builder := ui.NewServiceBuilder(cluster, scheme)
+			service := builder.Build().(*corev1.Service)
+			service.Annotations = test.existingAnnotations
+
+			require.NoError(tt, builder.Update(service))
+			assert.Equal(tt, test.expected, service.Annotations)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package workerdeployment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ resource.Builder = (*DeploymentBuilder)(nil)
+
+const (
+	// component is the component label of the worker deployment resources.
+	component = "worker"
+	// certsMountPath is the path the cluster client secret is mounted at in the worker pods.
+	certsMountPath = "/etc/temporal/tls"
+	// certsVolumeName is the name of the volume holding the cluster client secret.
+	certsVolumeName = "temporal-tls"
+)
+
+// DeploymentBuilder builds the Deployment running the application workers.
+type DeploymentBuilder struct {
+	instance *v1beta1.TemporalWorkerDeployment
+	cluster  *v1beta1.TemporalCluster
+	// clusterClient is the referenced TemporalClusterClient, if any.
+	clusterClient *v1beta1.TemporalClusterClient
+	scheme        *runtime.Scheme
+}
+
+func NewDeploymentBuilder(instance *v1beta1.TemporalWorkerDeployment, cluster *v1beta1.TemporalCluster, clusterClient *v1beta1.TemporalClusterClient, scheme *runtime.Scheme) *DeploymentBuilder {
+	return &DeploymentBuilder{
+		instance:      instance,
+		cluster:       cluster,
+		clusterClient: clusterClient,
+		scheme:        scheme,
+	}
+}
+
+// labels returns the labels of the worker deployment resources.
+func labels(instance *v1beta1.TemporalWorkerDeployment) map[string]string {
+	return metadata.Merge(instance.Labels, metadata.LabelsSelector(instance, component))
+}
+
+func (b *DeploymentBuilder) Build() client.Object {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        b.instance.GetName(),
+			Namespace:   b.instance.GetNamespace(),
+			Labels:      labels(b.instance),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+	}
+}
+
+func (b *DeploymentBuilder) Enabled() bool {
+	return true
+}
+
+// env returns the environment variables of the worker container.
+// Variables set in the spec come last, so they take precedence.
+func (b *DeploymentBuilder) env() []corev1.EnvVar {
+	env := []corev1.EnvVar{
+		{
+			Name:  "TEMPORAL_ADDRESS",
+			Value: b.cluster.GetPublicClientAddress(),
+		},
+		{
+			Name:  "TEMPORAL_NAMESPACE",
+			Value: b.instance.Spec.Namespace,
+		},
+		{
+			Name:  "TEMPORAL_TASK_QUEUES",
+			Value: strings.Join(b.instance.Spec.TaskQueues, ","),
+		},
+	}
+
+	if b.clusterClient != nil {
+		env = append(env, certmanager.GetTLSEnvironmentVariables(b.cluster, "TEMPORAL", certsMountPath)...)
+	}
+
+	return append(env, b.instance.Spec.Env...)
+}
+
+func (b *DeploymentBuilder) Update(object client.Object) error {
+	deployment := object.(*appsv1.Deployment)
+	deployment.Labels = metadata.Merge(object.GetLabels(), labels(b.instance))
+	deployment.Annotations = metadata.Merge(
+		object.GetAnnotations(),
+		metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+	)
+
+	switch {
+	case b.instance.Spec.Autoscaling == nil:
+		deployment.Spec.Replicas = ptr.To(b.instance.Spec.GetReplicas())
+	case deployment.Spec.Replicas == nil:
+		// Replicas are managed by the autoscaler once the deployment exists.
+		deployment.Spec.Replicas = ptr.To(max(b.instance.Spec.Autoscaling.GetMinReplicas(), 1))
+	}
+
+	deployment.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: metadata.LabelsSelector(b.instance, component),
+	}
+
+	volumes := []corev1.Volume{}
+	volumeMounts := []corev1.VolumeMount{}
+
+	if b.clusterClient != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: certsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  b.clusterClient.Status.SecretRef.Name,
+					DefaultMode: ptr.To[int32](corev1.SecretVolumeSourceDefaultMode),
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      certsVolumeName,
+			MountPath: certsMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	imagePullPolicy := b.instance.Spec.ImagePullPolicy
+	if imagePullPolicy == "" {
+		imagePullPolicy = corev1.PullIfNotPresent
+	}
+
+	deployment.Spec.Template = corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      labels(b.instance),
+			Annotations: metadata.GetAnnotations(b.instance.Name, b.instance.Annotations),
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: b.instance.Spec.ServiceAccountName,
+			ImagePullSecrets:   b.instance.Spec.ImagePullSecrets,
+			Containers: []corev1.Container{
+				{
+					Name:                     component,
+					Image:                    b.instance.Spec.Image,
+					ImagePullPolicy:          imagePullPolicy,
+					Command:                  b.instance.Spec.Command,
+					Args:                     b.instance.Spec.Args,
+					Env:                      b.env(),
+					Resources:                b.instance.Spec.Resources,
+					TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+					SecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: ptr.To(false),
+					},
+					VolumeMounts: volumeMounts,
+				},
+			},
+			RestartPolicy:                 corev1.RestartPolicyAlways,
+			TerminationGracePeriodSeconds: ptr.To[int64](30),
+			DNSPolicy:                     corev1.DNSClusterFirst,
+			SecurityContext:               &corev1.PodSecurityContext{},
+			SchedulerName:                 corev1.DefaultSchedulerName,
+			Volumes:                       volumes,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, deployment, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package workerdeployment_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/workerdeployment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	return scheme
+}
+
+func newCluster(mTLS bool) *v1beta1.TemporalCluster {
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prod",
+			Namespace: "temporal",
+		},
+		Spec: v1beta1.TemporalClusterSpec{
+			Services: &v1beta1.ServicesSpec{
+				Frontend: &v1beta1.ServiceSpec{
+					Port: ptr.To(7233),
+				},
+			},
+		},
+	}
+	if mTLS {
+		cluster.Spec.MTLS = &v1beta1.MTLSSpec{
+			Provider: v1beta1.CertManagerMTLSProvider,
+			Frontend: &v1beta1.FrontendMTLSSpec{
+				Enabled: true,
+			},
+		}
+	}
+	return cluster
+}
+
+func newWorkerDeployment() *v1beta1.TemporalWorkerDeployment {
+	return &v1beta1.TemporalWorkerDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "orders",
+			Namespace: "temporal",
+			UID:       "uid",
+		},
+		Spec: v1beta1.TemporalWorkerDeploymentSpec{
+			ClusterRef: v1beta1.ObjectReference{Name: "prod"},
+			Namespace:  "orders",
+			TaskQueues: []string{"orders", "payments"},
+			Image:      "example.com/orders-worker:v1",
+			Env: []corev1.EnvVar{
+				{Name: "TEMPORAL_NAMESPACE", Value: "overridden"},
+			},
+		},
+	}
+}
+
+func newClusterClient() *v1beta1.TemporalClusterClient {
+	return &v1beta1.TemporalClusterClient{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "orders",
+			Namespace: "temporal",
+		},
+		Status: v1beta1.TemporalClusterClientStatus{
+			SecretRef: &corev1.LocalObjectReference{Name: "orders-client-certificate"},
+		},
+	}
+}
+
+func TestDeploymentBuilder(t *testing.T) {
+	scheme := newScheme(t)
+
+	builder := workerdeployment.NewDeploymentBuilder(newWorkerDeployment(), newCluster(false), nil, scheme)
+	deployment := builder.Build().(*appsv1.Deployment)
+	require.NoError(t, builder.Update(deployment))
+
+	assert.Equal(t, "orders", deployment.Name)
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+	for key, value := range deployment.Spec.Selector.MatchLabels {
+		assert.Equal(t, value, deployment.Spec.Template.Labels[key])
+	}
+	if assert.Len(t, deployment.OwnerReferences, 1) {
+		assert.Equal(t, "TemporalWorkerDeployment", deployment.OwnerReferences[0].Kind)
+	}
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "example.com/orders-worker:v1", container.Image)
+	assert.Equal(t, corev1.PullIfNotPresent, container.ImagePullPolicy)
+	assert.Empty(t, container.VolumeMounts)
+	assert.Empty(t, deployment.Spec.Template.Spec.Volumes)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "TEMPORAL_ADDRESS", Value: "prod-frontend.temporal:7233"},
+		{Name: "TEMPORAL_NAMESPACE", Value: "orders"},
+		{Name: "TEMPORAL_TASK_QUEUES", Value: "orders,payments"},
+		// Variables set in the spec come last, so they take precedence.
+		{Name: "TEMPORAL_NAMESPACE", Value: "overridden"},
+	}, container.Env)
+}
+
+func TestDeploymentBuilderWithClusterClient(t *testing.T) {
+	builder := workerdeployment.NewDeploymentBuilder(newWorkerDeployment(), newCluster(true), newClusterClient(), newScheme(t))
+	deployment := builder.Build().(*appsv1.Deployment)
+	require.NoError(t, builder.Update(deployment))
+
+	podSpec := deployment.Spec.Template.Spec
+	if assert.Len(t, podSpec.Volumes, 1) {
+		assert.Equal(t, "orders-client-certificate", podSpec.Volumes[0].Secret.SecretName)
+	}
+	if assert.Len(t, podSpec.Containers[0].VolumeMounts, 1) {
+		assert.Equal(t, "/etc/temporal/tls", podSpec.Containers[0].VolumeMounts[0].MountPath)
+		assert.True(t, podSpec.Containers[0].VolumeMounts[0].ReadOnly)
+	}
+
+	env := map[string]string{}
+	for _, v := range podSpec.Containers[0].Env {
+		env[v.Name] = v.Value
+	}
+	assert.Equal(t, "/etc/temporal/tls/ca.crt", env["TEMPORAL_TLS_CA"])
+	assert.Equal(t, "/etc/temporal/tls/tls.crt", env["TEMPORAL_TLS_CERT"])
+	assert.Equal(t, "/etc/temporal/tls/tls.key", env["TEMPORAL_TLS_KEY"])
+	assert.NotEmpty(t, env["TEMPORAL_TLS_SERVER_NAME"])
+}
+
+func TestDeploymentBuilderReplicas(t *testing.T) {
+	tests := map[string]struct {
+		replicas    *int32
+		autoscaling *v1beta1.WorkerAutoscalingSpec
+		existing    *int32
+		expected    int32
+	}{
+		"defaults to one replica": {
+			expected: 1,
+		},
+		"uses the spec replicas": {
+			replicas: ptr.To[int32](3),
+			existing: ptr.To[int32](5),
+			expected: 3,
+		},
+		"scales to the autoscaling min replicas on creation": {
+			autoscaling: &v1beta1.WorkerAutoscalingSpec{MinReplicas: ptr.To[int32](2), MaxReplicas: 10},
+			expected:    2,
+		},
+		"starts a worker when autoscaling scales to zero": {
+			autoscaling: &v1beta1.WorkerAutoscalingSpec{MinReplicas: ptr.To[int32](0), MaxReplicas: 10},
+			expected:    1,
+		},
+		"keeps the replicas set by the autoscaler": {
+			replicas:    ptr.To[int32](3),
+			autoscaling: &v1beta1.WorkerAutoscalingSpec{MaxReplicas: 10},
+			existing:    ptr.To[int32](7),
+			expected:    7,
+		},
+	}
+
+	scheme := newScheme(t)
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			instance := newWorkerDeployment()
+			instance.Spec.Replicas = test.replicas
+			instance.Spec.Autoscaling = test.autoscaling
+
+			builder := workerdeployment.NewDeploymentBuilder(instance, newCluster(false), nil, scheme)
+			deployment := builder.Build().(*appsv1.Deployment)
+			deployment.Spec.Replicas = test.existing
+
+			require.NoError(tt, builder.Update(deployment))
+			assert.Equal(tt, test.expected, *deployment.Spec.Replicas)
+		})
+	}
+}
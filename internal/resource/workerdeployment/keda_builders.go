@@ -0,0 +1,194 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package workerdeployment
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/metadata"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var (
+	_ resource.Builder = (*ScaledObjectBuilder)(nil)
+	_ resource.Builder = (*TriggerAuthenticationBuilder)(nil)
+)
+
+var (
+	// ScaledObjectGroupVersionKind is the GroupVersionKind of KEDA scaled objects.
+	ScaledObjectGroupVersionKind = schema.GroupVersionKind{
+		Group:   "keda.sh",
+		Version: "v1alpha1",
+		Kind:    "ScaledObject",
+	}
+	// TriggerAuthenticationGroupVersionKind is the GroupVersionKind of KEDA trigger authentications.
+	TriggerAuthenticationGroupVersionKind = schema.GroupVersionKind{
+		Group:   "keda.sh",
+		Version: "v1alpha1",
+		Kind:    "TriggerAuthentication",
+	}
+)
+
+// NewScaledObject returns an empty KEDA scaled object.
+// KEDA objects are handled as unstructured objects to avoid depending on the KEDA api module.
+func NewScaledObject() *unstructured.Unstructured {
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetGroupVersionKind(ScaledObjectGroupVersionKind)
+	return scaledObject
+}
+
+// NewTriggerAuthentication returns an empty KEDA trigger authentication.
+func NewTriggerAuthentication() *unstructured.Unstructured {
+	triggerAuthentication := &unstructured.Unstructured{}
+	triggerAuthentication.SetGroupVersionKind(TriggerAuthenticationGroupVersionKind)
+	return triggerAuthentication
+}
+
+// ScaledObjectBuilder builds the KEDA scaled object scaling the workers on their task queues backlog.
+type ScaledObjectBuilder struct {
+	instance      *v1beta1.TemporalWorkerDeployment
+	cluster       *v1beta1.TemporalCluster
+	clusterClient *v1beta1.TemporalClusterClient
+	scheme        *runtime.Scheme
+}
+
+func NewScaledObjectBuilder(instance *v1beta1.TemporalWorkerDeployment, cluster *v1beta1.TemporalCluster, clusterClient *v1beta1.TemporalClusterClient, scheme *runtime.Scheme) *ScaledObjectBuilder {
+	return &ScaledObjectBuilder{
+		instance:      instance,
+		cluster:       cluster,
+		clusterClient: clusterClient,
+		scheme:        scheme,
+	}
+}
+
+func (b *ScaledObjectBuilder) Build() client.Object {
+	scaledObject := NewScaledObject()
+	scaledObject.SetName(b.instance.GetName())
+	scaledObject.SetNamespace(b.instance.GetNamespace())
+	scaledObject.SetLabels(labels(b.instance))
+	scaledObject.SetAnnotations(metadata.GetAnnotations(b.instance.Name, b.instance.Annotations))
+	return scaledObject
+}
+
+func (b *ScaledObjectBuilder) Enabled() bool {
+	return b.instance.Spec.Autoscaling != nil
+}
+
+func (b *ScaledObjectBuilder) Update(object client.Object) error {
+	scaledObject := object.(*unstructured.Unstructured)
+	autoscaling := b.instance.Spec.Autoscaling
+
+	// The temporal scaler handles a single task queue: add one trigger per task queue.
+	triggers := []any{}
+	for _, taskQueue := range b.instance.Spec.TaskQueues {
+		trigger := map[string]any{
+			"type": "temporal",
+			"metadata": map[string]any{
+				"endpoint":        b.cluster.GetPublicClientAddress(),
+				"namespace":       b.instance.Spec.Namespace,
+				"taskQueue":       taskQueue,
+				"targetQueueSize": strconv.Itoa(int(autoscaling.GetTargetQueueSize())),
+			},
+		}
+		if b.clusterClient != nil {
+			trigger["authenticationRef"] = map[string]any{
+				"name": b.instance.GetName(),
+			}
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	scaledObject.Object["spec"] = map[string]any{
+		"scaleTargetRef": map[string]any{
+			"name": b.instance.GetName(),
+		},
+		"minReplicaCount": int64(autoscaling.GetMinReplicas()),
+		"maxReplicaCount": int64(autoscaling.MaxReplicas),
+		"triggers":        triggers,
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, scaledObject, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
+
+// TriggerAuthenticationBuilder builds the KEDA trigger authentication using the cluster client certificate.
+type TriggerAuthenticationBuilder struct {
+	instance      *v1beta1.TemporalWorkerDeployment
+	clusterClient *v1beta1.TemporalClusterClient
+	scheme        *runtime.Scheme
+}
+
+func NewTriggerAuthenticationBuilder(instance *v1beta1.TemporalWorkerDeployment, clusterClient *v1beta1.TemporalClusterClient, scheme *runtime.Scheme) *TriggerAuthenticationBuilder {
+	return &TriggerAuthenticationBuilder{
+		instance:      instance,
+		clusterClient: clusterClient,
+		scheme:        scheme,
+	}
+}
+
+func (b *TriggerAuthenticationBuilder) Build() client.Object {
+	triggerAuthentication := NewTriggerAuthentication()
+	triggerAuthentication.SetName(b.instance.GetName())
+	triggerAuthentication.SetNamespace(b.instance.GetNamespace())
+	triggerAuthentication.SetLabels(labels(b.instance))
+	triggerAuthentication.SetAnnotations(metadata.GetAnnotations(b.instance.Name, b.instance.Annotations))
+	return triggerAuthentication
+}
+
+func (b *TriggerAuthenticationBuilder) Enabled() bool {
+	return b.instance.Spec.Autoscaling != nil && b.clusterClient != nil
+}
+
+func (b *TriggerAuthenticationBuilder) Update(object client.Object) error {
+	triggerAuthentication := object.(*unstructured.Unstructured)
+
+	secretName := b.clusterClient.Status.SecretRef.Name
+	secretTargetRefs := []any{}
+	for _, ref := range []struct{ parameter, key string }{
+		{"ca", certmanager.TLSCA},
+		{"cert", certmanager.TLSCert},
+		{"key", certmanager.TLSKey},
+	} {
+		secretTargetRefs = append(secretTargetRefs, map[string]any{
+			"parameter": ref.parameter,
+			"name":      secretName,
+			"key":       ref.key,
+		})
+	}
+
+	triggerAuthentication.Object["spec"] = map[string]any{
+		"secretTargetRef": secretTargetRefs,
+	}
+
+	if err := controllerutil.SetControllerReference(b.instance, triggerAuthentication, b.scheme); err != nil {
+		return fmt.Errorf("failed setting controller reference: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package workerdeployment_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/workerdeployment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+)
+
+func TestScaledObjectBuilder(t *testing.T) {
+	tests := map[string]struct {
+		clusterClient *v1beta1.TemporalClusterClient
+	}{
+		"without cluster client": {},
+		"with cluster client": {
+			clusterClient: newClusterClient(),
+		},
+	}
+
+	scheme := newScheme(t)
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			instance := newWorkerDeployment()
+			instance.Spec.Autoscaling = &v1beta1.WorkerAutoscalingSpec{
+				MinReplicas:     ptr.To[int32](0),
+				MaxReplicas:     10,
+				TargetQueueSize: ptr.To[int32](20),
+			}
+
+			builder := workerdeployment.NewScaledObjectBuilder(instance, newCluster(test.clusterClient != nil), test.clusterClient, scheme)
+			assert.True(tt, builder.Enabled())
+
+			scaledObject := builder.Build().(*unstructured.Unstructured)
+			require.NoError(tt, builder.Update(scaledObject))
+
+			assert.Equal(tt, workerdeployment.ScaledObjectGroupVersionKind, scaledObject.GroupVersionKind())
+			assert.Len(tt, scaledObject.GetOwnerReferences(), 1)
+
+			spec := scaledObject.Object["spec"].(map[string]any)
+			assert.Equal(tt, map[string]any{"name": "orders"}, spec["scaleTargetRef"])
+			assert.Equal(tt, int64(0), spec["minReplicaCount"])
+			assert.Equal(tt, int64(10), spec["maxReplicaCount"])
+
+			// The temporal scaler handles a single task queue: there's one trigger per task queue.
+			triggers := spec["triggers"].([]any)
+			require.Len(tt, triggers, 2)
+			for i, taskQueue := range []string{"orders", "payments"} {
+				trigger := triggers[i].(map[string]any)
+				assert.Equal(tt, "temporal", trigger["type"])
+				assert.Equal(tt, map[string]any{
+					"endpoint":        "prod-frontend.temporal:7233",
+					"namespace":       "orders",
+					"taskQueue":       taskQueue,
+					"targetQueueSize": "20",
+				}, trigger["metadata"])
+
+				if test.clusterClient != nil {
+					assert.Equal(tt, map[string]any{"name": "orders"}, trigger["authenticationRef"])
+				} else {
+					assert.NotContains(tt, trigger, "authenticationRef")
+				}
+			}
+		})
+	}
+}
+
+func TestScaledObjectBuilderDisabledWithoutAutoscaling(t *testing.T) {
+	builder := workerdeployment.NewScaledObjectBuilder(newWorkerDeployment(), newCluster(false), nil, newScheme(t))
+	assert.False(t, builder.Enabled())
+}
+
+func TestTriggerAuthenticationBuilder(t *testing.T) {
+	scheme := newScheme(t)
+
+	instance := newWorkerDeployment()
+	assert.False(t, workerdeployment.NewTriggerAuthenticationBuilder(instance, newClusterClient(), scheme).Enabled())
+
+	instance.Spec.Autoscaling = &v1beta1.WorkerAutoscalingSpec{MaxReplicas: 10}
+	assert.False(t, workerdeployment.NewTriggerAuthenticationBuilder(instance, nil, scheme).Enabled())
+
+	builder := workerdeployment.NewTriggerAuthenticationBuilder(instance, newClusterClient(), scheme)
+	assert.True(t, builder.Enabled())
+
+	triggerAuthentication := builder.Build().(*unstructured.Unstructured)
+	require.NoError(t, builder.Update(triggerAuthentication))
+
+	assert.Equal(t, workerdeployment.TriggerAuthenticationGroupVersionKind, triggerAuthentication.GroupVersionKind())
+	assert.Equal(t, map[string]any{
+		"secretTargetRef": []any{
+			map[string]any{"parameter": "ca", "name": "orders-client-certificate", "key": "ca.crt"},
+			map[string]any{"parameter": "cert", "name": "orders-client-certificate", "key": "tls.crt"},
+			map[string]any{"parameter": "key", "name": "orders-client-certificate", "key": "tls.key"},
+		},
+	}, triggerAuthentication.Object["spec"])
+}
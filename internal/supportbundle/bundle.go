@@ -0,0 +1,86 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package supportbundle writes the support bundles attached to bug reports:
+// gzipped tarballs of the cluster resources, with sensitive values redacted.
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+)
+
+// Writer writes files to a gzipped tarball.
+type Writer struct {
+	root string
+	now  time.Time
+	gz   *gzip.Writer
+	tar  *tar.Writer
+}
+
+// NewWriter returns a Writer writing files under the provided root directory of the tarball written to w.
+func NewWriter(w io.Writer, root string) *Writer {
+	gz := gzip.NewWriter(w)
+	return &Writer{
+		root: root,
+		now:  time.Now(),
+		gz:   gz,
+		tar:  tar.NewWriter(gz),
+	}
+}
+
+// Add writes a file with the provided content.
+func (w *Writer) Add(name string, content []byte) error {
+	err := w.tar.WriteHeader(&tar.Header{
+		Name:    path.Join(w.root, name),
+		Mode:    0o644,
+		Size:    int64(len(content)),
+		ModTime: w.now,
+	})
+	if err != nil {
+		return fmt.Errorf("can't write %s header: %w", name, err)
+	}
+
+	_, err = w.tar.Write(content)
+	if err != nil {
+		return fmt.Errorf("can't write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// AddJSON writes a file holding the indented JSON encoding of the provided value.
+func (w *Writer) AddJSON(name string, v any) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("can't encode %s: %w", name, err)
+	}
+	return w.Add(name, append(content, '\n'))
+}
+
+// Close flushes the tarball. It doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.tar.Close(); err != nil {
+		return err
+	}
+	return w.gz.Close()
+}
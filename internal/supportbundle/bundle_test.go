@@ -0,0 +1,94 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, "bundle")
+	require.NoError(t, w.Add("cluster.txt", []byte("hello")))
+	require.NoError(t, w.AddJSON("conditions.json", map[string]string{"Ready": "True"}))
+	require.NoError(t, w.Close())
+
+	gz, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	r := tar.NewReader(gz)
+
+	files := map[string]string{}
+	for {
+		header, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+
+	assert.Equal(t, map[string]string{
+		"bundle/cluster.txt":     "hello",
+		"bundle/conditions.json": "{\n  \"Ready\": \"True\"\n}\n",
+	}, files)
+}
+
+func TestRedactYAML(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected string
+	}{
+		"nothing to redact": {
+			input:    "persistence:\n  defaultStore: default\n",
+			expected: "persistence:\n  defaultStore: default\n",
+		},
+		"sensitive scalar": {
+			input:    "sql:\n  user: temporal\n  password: hunter2\n",
+			expected: "sql:\n  user: temporal\n  password: REDACTED\n",
+		},
+		"sensitive key holding a mapping is walked": {
+			input:    "passwordSecretRef:\n  name: postgres\n  key: PASSWORD\n",
+			expected: "passwordSecretRef:\n  name: postgres\n  key: PASSWORD\n",
+		},
+		"sequence": {
+			input:    "stores:\n  - name: es\n    apiToken: abc\n",
+			expected: "stores:\n  - name: es\n    apiToken: REDACTED\n",
+		},
+		"empty value is kept": {
+			input:    "password: \"\"\n",
+			expected: "password: \"\"\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			result, err := RedactYAML([]byte(test.input))
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expected, string(result))
+		})
+	}
+}
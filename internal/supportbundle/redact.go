@@ -0,0 +1,80 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package supportbundle
+
+import (
+	"bytes"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Redacted replaces the sensitive values in the bundle files.
+const Redacted = "REDACTED"
+
+// sensitiveKey matches the configuration keys holding credentials.
+var sensitiveKey = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|privatekey|apikey)`)
+
+// IsSensitiveKey returns true if the provided configuration key holds a credential.
+func IsSensitiveKey(key string) bool {
+	return sensitiveKey.MatchString(key)
+}
+
+// RedactYAML replaces the scalar values of the sensitive keys of the provided YAML document.
+// Keys holding a mapping or a sequence are walked instead, so their non sensitive values are kept.
+func RedactYAML(content []byte) ([]byte, error) {
+	var doc yaml.Node
+	err := yaml.Unmarshal(content, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	redactNode(&doc)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func redactNode(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if value.Kind == yaml.ScalarNode && value.Value != "" && IsSensitiveKey(key.Value) {
+				value.Value = Redacted
+				value.Tag = "!!str"
+				value.Style = 0
+				continue
+			}
+			redactNode(value)
+		}
+		return
+	}
+
+	for _, child := range node.Content {
+		redactNode(child)
+	}
+}
@@ -18,19 +18,27 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"os"
 
+	// Embed the time zone database, as the distroless image doesn't ship it.
+	_ "time/tzdata"
+
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -40,7 +48,18 @@ import (
 	"github.com/alexandrevilain/controller-tools/pkg/discovery"
 	temporaliov1beta1 "github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/controllers"
+	"github.com/alexandrevilain/temporal-operator/internal/buildinfo"
+	"github.com/alexandrevilain/temporal-operator/internal/cache"
+	"github.com/alexandrevilain/temporal-operator/internal/certrotation"
+	"github.com/alexandrevilain/temporal-operator/internal/defaults"
 	internaldiscovery "github.com/alexandrevilain/temporal-operator/internal/discovery"
+	"github.com/alexandrevilain/temporal-operator/internal/logging"
+	_ "github.com/alexandrevilain/temporal-operator/internal/metrics"
+	"github.com/alexandrevilain/temporal-operator/internal/operatorconfig"
+	"github.com/alexandrevilain/temporal-operator/pkg/circuitbreaker"
+	"github.com/alexandrevilain/temporal-operator/pkg/notification"
+	"github.com/alexandrevilain/temporal-operator/pkg/ratelimit"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporalclient"
 	"github.com/alexandrevilain/temporal-operator/webhooks"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	//+kubebuilder:scaffold:imports
@@ -64,28 +83,127 @@ func init() {
 func main() {
 	var (
 		metricsAddr          string
+		metricsSecure        bool
 		enableLeaderElection bool
 		probeAddr            string
+		notificationURL      string
+		datastoreBackoff     = circuitbreaker.DefaultConfig()
+		datastoreThreshold   int
+		namespaceWorkers     int
+		namespaceRate        float64
+		namespaceBurst       int
+		maxClusterRollouts   int
+		operatorConfig       string
+		versionFlag          = &buildinfo.Flag{}
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	flag.BoolVar(&metricsSecure, "metrics-secure", false,
+		"Serve the metric endpoint over HTTPS, using the serving certificate from the --cert-dir directory.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 
-	opts := zap.Options{
-		Development: true,
-	}
-	opts.BindFlags(flag.CommandLine)
+	flag.StringVar(&notificationURL, "notification-webhook-url", "",
+		"The webhook URL clusters lifecycle events are sent to. Can be overridden per cluster using annotations.")
+
+	flag.DurationVar(&datastoreBackoff.InitialDelay, "datastore-backoff-initial-delay", datastoreBackoff.InitialDelay,
+		"The delay before retrying a failed persistence reconciliation. It doubles on each consecutive failure.")
+	flag.DurationVar(&datastoreBackoff.MaxDelay, "datastore-backoff-max-delay", datastoreBackoff.MaxDelay,
+		"The maximum delay between two persistence reconciliation attempts.")
+	flag.IntVar(&datastoreThreshold, "datastore-circuit-breaker-threshold", int(datastoreBackoff.FailureThreshold),
+		"The number of consecutive persistence reconciliation failures pausing the cluster reconciliation until the backoff delay elapsed. Set to 0 to disable.")
+
+	flag.IntVar(&namespaceWorkers, "namespace-workers", 4,
+		"The number of TemporalNamespaces reconciled in parallel.")
+	flag.Float64Var(&namespaceRate, "namespace-rate-limit", 5,
+		"The maximum number of TemporalNamespaces reconciliations per second and per cluster. Set to 0 to disable.")
+	flag.IntVar(&namespaceBurst, "namespace-rate-limit-burst", 10,
+		"The number of TemporalNamespaces reconciliations allowed in a burst per cluster.")
+
+	flag.IntVar(&maxClusterRollouts, "max-concurrent-cluster-rollouts", 0,
+		"The maximum number of TemporalClusters rolling out their pods at the same time after operator initiated changes, like an operator upgrade. Set to 0 to disable.")
+
+	flag.StringVar(&operatorConfig, "operator-config", "",
+		"The name of the TemporalOperatorConfig holding the operator runtime settings. When set, the settings are reloaded from it at runtime and take precedence over the flags.")
+
+	flag.Var(versionFlag, "version",
+		"Print the operator build version, commit and date, then exit. Use --version=json for a JSON output.")
+
+	logOpts := logging.NewOptions()
+	logOpts.BindFlags(flag.CommandLine)
+	defaultsOpts := defaults.NewOptions()
+	defaultsOpts.BindFlags(flag.CommandLine)
+	certOpts := certrotation.NewOptions()
+	certOpts.BindFlags(flag.CommandLine)
+	callOpts := temporalclient.NewCallOptions()
+	callOpts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	build := buildinfo.Get()
+	if versionFlag.Requested() {
+		if err := versionFlag.Print(os.Stdout, build); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	datastoreBackoff.FailureThreshold = int32(datastoreThreshold)
+
+	ctrl.SetLogger(logOpts.NewLogger())
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: scheme,
-		Metrics: metricsserver.Options{
-			BindAddress: metricsAddr,
-		},
+	restConfig := ctrl.GetConfigOrDie()
+
+	// Settings set by the flags, overridden by the operator config if any.
+	flagSettings := operatorconfig.Settings{
+		NamespaceWorkers:             namespaceWorkers,
+		NamespaceRateLimit:           namespaceRate,
+		NamespaceRateLimitBurst:      namespaceBurst,
+		MaxConcurrentClusterRollouts: maxClusterRollouts,
+		Defaults:                     defaultsOpts.Get(),
+		NotificationURL:              notificationURL,
+	}
+	settings := flagSettings
+
+	if operatorConfig != "" {
+		if defaultsOpts.ConfigMap != "" {
+			setupLog.Error(errors.New("--operator-config and --defaults-configmap are mutually exclusive"), "invalid operator configuration")
+			os.Exit(1)
+		}
+
+		configClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "unable to create operator config client")
+			os.Exit(1)
+		}
+
+		config, err := operatorconfig.Load(context.Background(), configClient, operatorConfig)
+		if err != nil {
+			setupLog.Error(err, "unable to load operator config")
+			os.Exit(1)
+		}
+		if config != nil {
+			settings = operatorconfig.Resolve(flagSettings, &config.Spec)
+		}
+		defaultsOpts.Set(settings.Defaults)
+	}
+
+	metricsOpts := metricsserver.Options{
+		BindAddress: metricsAddr,
+	}
+	if metricsSecure {
+		metricsOpts.SecureServing = true
+		metricsOpts.CertDir = certOpts.CertDir
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
+		Scheme:  scheme,
+		Metrics: metricsOpts,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			CertDir: certOpts.CertDir,
+		}),
+		Cache:                  cache.Options(settings.WatchNamespaces),
+		Client:                 cache.ClientOptions(),
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "0cfcfa11.temporal.io",
@@ -95,6 +213,29 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The serving certificate must be written before the webhook and metrics servers start.
+	if certOpts.Enabled {
+		certClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "unable to create certificate rotation client")
+			os.Exit(1)
+		}
+
+		rotator := &certrotation.Rotator{
+			Options:   certOpts,
+			Client:    certClient,
+			Namespace: os.Getenv("POD_NAMESPACE"),
+		}
+		if err := rotator.Ensure(context.Background()); err != nil {
+			setupLog.Error(err, "unable to issue the serving certificate")
+			os.Exit(1)
+		}
+		if err := mgr.Add(rotator); err != nil {
+			setupLog.Error(err, "unable to set up serving certificate rotation")
+			os.Exit(1)
+		}
+	}
+
 	discoveryManager, err := discovery.NewManager(mgr.GetConfig(), scheme)
 	if err != nil {
 		setupLog.Error(err, "unable to discover available apis")
@@ -107,21 +248,77 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Features requiring a newer kubernetes version fall back to older APIs instead of failing at reconcile time.
+	err = internaldiscovery.FindVersionGatedFeatures(setupLog, mgr.GetConfig(), availableAPIs)
+	if err != nil {
+		setupLog.Error(err, "unable to determine the kubernetes version, features requiring a minimum version are disabled")
+	}
+
+	availableAPIs.Routes, err = internaldiscovery.SupportsRoutes(setupLog, mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to determine if openshift routes are supported")
+		os.Exit(1)
+	}
+
+	availableAPIs.KEDA, err = internaldiscovery.SupportsKEDA(setupLog, mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to determine if keda is supported")
+		os.Exit(1)
+	}
+
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create kubernetes clientset")
+		os.Exit(1)
+	}
+
+	// Temporal clients are shared by all controllers.
+	clientManager := temporalclient.NewManager(mgr.GetClient(), callOpts)
+
+	notifier := notification.NewSink(mgr.GetClient(), settings.NotificationURL)
+	namespaceRateLimiter := ratelimit.NewKeyedLimiter(settings.NamespaceRateLimit, settings.NamespaceRateLimitBurst)
+	clusterRollouts := ratelimit.NewSemaphore(settings.MaxConcurrentClusterRollouts)
+
+	// User-provided objects, like external config ConfigMaps, aren't held by the manager cache.
+	metadataCache, err := cache.NewMetadataCache(mgr, settings.WatchNamespaces)
+	if err != nil {
+		setupLog.Error(err, "unable to create metadata cache")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.TemporalClusterReconciler{
-		Base:          controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("cluster-controller"), discoveryManager),
-		AvailableAPIs: availableAPIs,
+		Base:             controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("cluster-controller"), discoveryManager),
+		AvailableAPIs:    availableAPIs,
+		ClientManager:    clientManager,
+		Notifier:         notifier,
+		Clientset:        clientset,
+		DatastoreBackoff: datastoreBackoff,
+		FleetRollouts:    clusterRollouts,
+		APIReader:        mgr.GetAPIReader(),
+		MetadataCache:    metadataCache,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Cluster")
 		os.Exit(1)
 	}
 
-	if err = (&webhooks.TemporalClusterWebhook{
+	clusterWebhook := &webhooks.TemporalClusterWebhook{
 		AvailableAPIs: availableAPIs,
-	}).SetupWebhookWithManager(mgr); err != nil {
+		Client:        mgr.GetAPIReader(),
+		Defaults:      defaultsOpts,
+	}
+	if err = clusterWebhook.SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "TemporalCluster")
 		os.Exit(1)
 	}
 
+	if err = (&webhooks.TemporalClusterOverrideWebhook{
+		Client:         mgr.GetAPIReader(),
+		ClusterWebhook: clusterWebhook,
+	}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "TemporalClusterOverride")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.TemporalClusterClientReconciler{
 		Base:          controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("clusterclient-controller"), discoveryManager),
 		AvailableAPIs: availableAPIs,
@@ -131,22 +328,111 @@ func main() {
 	}
 
 	if err = (&controllers.TemporalNamespaceReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		ClusterOperations:       temporalclient.NewClusterOperations(clientManager),
+		RateLimiter:             namespaceRateLimiter,
+		MaxConcurrentReconciles: settings.NamespaceWorkers,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Namespace")
 		os.Exit(1)
 	}
 
 	if err = (&controllers.TemporalScheduleReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		ClientManager: clientManager,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Schedule")
 		os.Exit(1)
 	}
+
+	if err = (&controllers.TemporalBenchmarkReconciler{
+		Base:          controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("benchmark-controller"), discoveryManager),
+		ClientManager: clientManager,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Benchmark")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.TemporalWorkerDeploymentReconciler{
+		Base:          controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("workerdeployment-controller"), discoveryManager),
+		AvailableAPIs: availableAPIs,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "WorkerDeployment")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.TemporalFleetReportReconciler{
+		Base:          controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("fleetreport-controller"), discoveryManager),
+		AvailableAPIs: availableAPIs,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FleetReport")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.TemporalClusterCloneReconciler{
+		Base: controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("clusterclone-controller"), discoveryManager),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterClone")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.TemporalServiceScalerReconciler{
+		Base: controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("servicescaler-controller"), discoveryManager),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ServiceScaler")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.TemporalNamespaceMigrationReconciler{
+		Base:              controllers.New(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("namespacemigration-controller"), discoveryManager),
+		ClusterOperations: temporalclient.NewClusterOperations(clientManager),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NamespaceMigration")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
+	if logOpts.ConfigMap != "" {
+		if err := mgr.Add(&logging.Reloader{
+			Options: logOpts,
+			Reader:  mgr.GetAPIReader(),
+			Key:     types.NamespacedName{Name: logOpts.ConfigMap, Namespace: os.Getenv("POD_NAMESPACE")},
+		}); err != nil {
+			setupLog.Error(err, "unable to set up logging configuration reloader")
+			os.Exit(1)
+		}
+	}
+
+	if defaultsOpts.ConfigMap != "" {
+		if err := mgr.Add(&defaults.Reloader{
+			Options: defaultsOpts,
+			Reader:  mgr.GetAPIReader(),
+			Key:     types.NamespacedName{Name: defaultsOpts.ConfigMap, Namespace: os.Getenv("POD_NAMESPACE")},
+		}); err != nil {
+			setupLog.Error(err, "unable to set up operator defaults reloader")
+			os.Exit(1)
+		}
+	}
+
+	if operatorConfig != "" {
+		if err := mgr.Add(&operatorconfig.Reloader{
+			Name:                 operatorConfig,
+			Reader:               mgr.GetAPIReader(),
+			Client:               mgr.GetClient(),
+			Base:                 flagSettings,
+			Started:              settings,
+			Defaults:             defaultsOpts,
+			Notifier:             notifier,
+			NamespaceRateLimiter: namespaceRateLimiter,
+			ClusterRollouts:      clusterRollouts,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up operator config reloader")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -156,8 +442,10 @@ func main() {
 		os.Exit(1)
 	}
 
-	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	setupLog.Info("starting manager", "version", build.Version, "commit", build.Commit, "date", build.Date)
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	clientManager.Close()
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
@@ -0,0 +1,147 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package alertmanager provides a minimal alertmanager client used to silence alerts during rollouts.
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Matcher matches alerts by label value.
+type Matcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+// Silence is an alertmanager silence.
+type Silence struct {
+	Matchers  []Matcher `json:"matchers"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy string    `json:"createdBy"`
+	Comment   string    `json:"comment"`
+}
+
+// NewSilence returns a silence matching the provided labels, starting now for the provided duration.
+func NewSilence(labels map[string]string, duration time.Duration, createdBy, comment string) *Silence {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	matchers := make([]Matcher, 0, len(names))
+	for _, name := range names {
+		matchers = append(matchers, Matcher{
+			Name:    name,
+			Value:   labels[name],
+			IsEqual: true,
+		})
+	}
+
+	now := time.Now()
+	return &Silence{
+		Matchers:  matchers,
+		StartsAt:  now,
+		EndsAt:    now.Add(duration),
+		CreatedBy: createdBy,
+		Comment:   comment,
+	}
+}
+
+type createSilenceResponse struct {
+	SilenceID string `json:"silenceID"`
+}
+
+// Client is a minimal alertmanager v2 API client.
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient returns a new alertmanager client.
+func NewClient(url string) *Client {
+	return &Client{
+		url: strings.TrimSuffix(url, "/"),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// CreateSilence creates the provided silence and returns its id.
+func (c *Client) CreateSilence(ctx context.Context, silence *Silence) (string, error) {
+	body, err := json.Marshal(silence)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"/api/v2/silences", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("can't create alertmanager silence: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("can't create alertmanager silence: unexpected status code %d", resp.StatusCode)
+	}
+
+	result := &createSilenceResponse{}
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if err != nil {
+		return "", fmt.Errorf("can't decode alertmanager silence: %w", err)
+	}
+
+	return result.SilenceID, nil
+}
+
+// ExpireSilence expires the silence with the provided id.
+func (c *Client) ExpireSilence(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.url+"/api/v2/silence/"+id, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't expire alertmanager silence: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Alertmanager garbage collects expired silences: a missing silence is already expired.
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("can't expire alertmanager silence: unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
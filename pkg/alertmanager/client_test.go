@@ -0,0 +1,109 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package alertmanager_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/alertmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSilence(t *testing.T) {
+	tests := map[string]struct {
+		handler     http.HandlerFunc
+		expectedID  string
+		expectedErr bool
+	}{
+		"silence created": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				silence := &alertmanager.Silence{}
+				if r.URL.Path != "/api/v2/silences" || json.NewDecoder(r.Body).Decode(silence) != nil || len(silence.Matchers) != 2 {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				_, _ = w.Write([]byte(`{"silenceID":"1234"}`))
+			},
+			expectedID: "1234",
+		},
+		"alertmanager error": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			server := httptest.NewServer(test.handler)
+			defer server.Close()
+
+			silence := alertmanager.NewSilence(map[string]string{"namespace": "demo", "service": "temporal"}, time.Hour, "temporal-operator", "rollout")
+			id, err := alertmanager.NewClient(server.URL).CreateSilence(context.Background(), silence)
+			if test.expectedErr {
+				assert.Error(tt, err)
+				return
+			}
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expectedID, id)
+		})
+	}
+}
+
+func TestExpireSilence(t *testing.T) {
+	tests := map[string]struct {
+		status      int
+		expectedErr bool
+	}{
+		"silence expired": {
+			status: http.StatusOK,
+		},
+		"silence not found": {
+			status: http.StatusNotFound,
+		},
+		"alertmanager error": {
+			status:      http.StatusInternalServerError,
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(tt, "/api/v2/silence/1234", r.URL.Path)
+				assert.Equal(tt, http.MethodDelete, r.Method)
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+
+			err := alertmanager.NewClient(server.URL).ExpireSilence(context.Background(), "1234")
+			if test.expectedErr {
+				assert.Error(tt, err)
+			} else {
+				assert.NoError(tt, err)
+			}
+		})
+	}
+}
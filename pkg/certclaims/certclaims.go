@@ -0,0 +1,180 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package certclaims maps clients certificates and JWT token subjects to Temporal roles, using the rules of
+// the TemporalCluster spec.authorization.certificateClaimMapper and of the TemporalAccessPolicies.
+// It is meant to be wrapped by a Temporal claim mapper registered in a custom server build.
+package certclaims
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+)
+
+// Role is a bitmask of Temporal roles. Its values match the go.temporal.io/server/common/authorization roles.
+type Role int32
+
+const (
+	RoleWorker = Role(1 << iota)
+	RoleReader
+	RoleWriter
+	RoleAdmin
+	RoleUndefined = Role(0)
+)
+
+var roles = map[v1beta1.TemporalRole]Role{
+	v1beta1.ReadTemporalRole:   RoleReader,
+	v1beta1.WriteTemporalRole:  RoleWriter,
+	v1beta1.WorkerTemporalRole: RoleWorker,
+	v1beta1.AdminTemporalRole:  RoleAdmin,
+}
+
+// Claims are the roles granted to a client certificate.
+type Claims struct {
+	// Subject is the certificate subject common name.
+	Subject string
+	// System is the role granted on the whole cluster.
+	System Role
+	// Namespaces are the roles granted per namespace.
+	Namespaces map[string]Role
+}
+
+// Mapper grants roles to clients certificates matching its rules.
+type Mapper struct {
+	rules []v1beta1.CertificateClaimMapperRule
+}
+
+// NewMapper returns a new mapper using the provided rules.
+func NewMapper(spec *v1beta1.CertificateClaimMapperSpec) *Mapper {
+	m := &Mapper{}
+	if spec != nil {
+		m.rules = spec.Rules
+	}
+	return m
+}
+
+// LoadMapper returns a new mapper using the rules rendered by the operator in the provided file.
+// The rules are rendered at v1beta1.CertificateClaimMapperRulesPath in the frontend pods.
+func LoadMapper(file string) (*Mapper, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("can't read claim mapper rules: %w", err)
+	}
+
+	spec := &v1beta1.CertificateClaimMapperSpec{}
+	err = json.Unmarshal(content, spec)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse claim mapper rules: %w", err)
+	}
+
+	return NewMapper(spec), nil
+}
+
+// GetClaims returns the roles granted to the provided client certificate by all matching rules.
+func (m *Mapper) GetClaims(cert *x509.Certificate) *Claims {
+	claims := &Claims{
+		Namespaces: map[string]Role{},
+	}
+	if cert == nil {
+		return claims
+	}
+
+	claims.Subject = cert.Subject.CommonName
+
+	for _, rule := range m.rules {
+		if !ruleMatches(rule, cert) {
+			continue
+		}
+
+		claims.System |= toRole(rule.SystemRoles)
+		for _, namespace := range rule.Namespaces {
+			claims.Namespaces[namespace.Namespace] |= toRole(namespace.Roles)
+		}
+	}
+
+	return claims
+}
+
+// GetTokenClaims returns the roles granted to the provided JWT token subject by all matching rules.
+// It is meant to complement the roles granted by the token permissions claim.
+func (m *Mapper) GetTokenClaims(subject string) *Claims {
+	claims := &Claims{
+		Subject:    subject,
+		Namespaces: map[string]Role{},
+	}
+	if subject == "" {
+		return claims
+	}
+
+	for _, rule := range m.rules {
+		if rule.JWTSubject == "" || !globMatches(rule.JWTSubject, subject) {
+			continue
+		}
+
+		claims.System |= toRole(rule.SystemRoles)
+		for _, namespace := range rule.Namespaces {
+			claims.Namespaces[namespace.Namespace] |= toRole(namespace.Roles)
+		}
+	}
+
+	return claims
+}
+
+// ruleMatches returns true if the certificate matches all the patterns set in the rule.
+func ruleMatches(rule v1beta1.CertificateClaimMapperRule, cert *x509.Certificate) bool {
+	if rule.CommonName == "" && rule.DNSName == "" {
+		return false
+	}
+
+	if rule.CommonName != "" && !globMatches(rule.CommonName, cert.Subject.CommonName) {
+		return false
+	}
+
+	if rule.DNSName != "" {
+		found := false
+		for _, name := range cert.DNSNames {
+			if globMatches(rule.DNSName, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// globMatches returns true if the value matches the pattern. Invalid patterns never match.
+func globMatches(pattern, value string) bool {
+	matched, err := path.Match(pattern, value)
+	return err == nil && matched
+}
+
+func toRole(names []v1beta1.TemporalRole) Role {
+	result := RoleUndefined
+	for _, name := range names {
+		result |= roles[name]
+	}
+	return result
+}
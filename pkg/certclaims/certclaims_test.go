@@ -0,0 +1,167 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certclaims_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/certclaims"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapperGetClaims(t *testing.T) {
+	spec := &v1beta1.CertificateClaimMapperSpec{
+		Rules: []v1beta1.CertificateClaimMapperRule{
+			{
+				CommonName:  "admin.temporal.example.com",
+				SystemRoles: []v1beta1.TemporalRole{v1beta1.AdminTemporalRole},
+			},
+			{
+				CommonName: "*.payments.example.com",
+				Namespaces: []v1beta1.CertificateClaimMapperNamespaceRoles{
+					{
+						Namespace: "payments",
+						Roles:     []v1beta1.TemporalRole{v1beta1.WorkerTemporalRole},
+					},
+				},
+			},
+			{
+				DNSName: "ci.example.com",
+				Namespaces: []v1beta1.CertificateClaimMapperNamespaceRoles{
+					{
+						Namespace: "payments",
+						Roles:     []v1beta1.TemporalRole{v1beta1.ReadTemporalRole, v1beta1.WriteTemporalRole},
+					},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		cert     *x509.Certificate
+		expected *certclaims.Claims
+	}{
+		"no certificate": {
+			cert: nil,
+			expected: &certclaims.Claims{
+				Namespaces: map[string]certclaims.Role{},
+			},
+		},
+		"no matching rule": {
+			cert: &x509.Certificate{
+				Subject: pkix.Name{CommonName: "unknown.example.com"},
+			},
+			expected: &certclaims.Claims{
+				Subject:    "unknown.example.com",
+				Namespaces: map[string]certclaims.Role{},
+			},
+		},
+		"system role": {
+			cert: &x509.Certificate{
+				Subject: pkix.Name{CommonName: "admin.temporal.example.com"},
+			},
+			expected: &certclaims.Claims{
+				Subject:    "admin.temporal.example.com",
+				System:     certclaims.RoleAdmin,
+				Namespaces: map[string]certclaims.Role{},
+			},
+		},
+		"roles of matching rules are merged": {
+			cert: &x509.Certificate{
+				Subject:  pkix.Name{CommonName: "worker.payments.example.com"},
+				DNSNames: []string{"worker.example.com", "ci.example.com"},
+			},
+			expected: &certclaims.Claims{
+				Subject: "worker.payments.example.com",
+				Namespaces: map[string]certclaims.Role{
+					"payments": certclaims.RoleWorker | certclaims.RoleReader | certclaims.RoleWriter,
+				},
+			},
+		},
+	}
+
+	mapper := certclaims.NewMapper(spec)
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, mapper.GetClaims(test.cert))
+		})
+	}
+}
+
+func TestMapperGetTokenClaims(t *testing.T) {
+	mapper := certclaims.NewMapper(&v1beta1.CertificateClaimMapperSpec{
+		Rules: []v1beta1.CertificateClaimMapperRule{
+			{
+				CommonName:  "*",
+				SystemRoles: []v1beta1.TemporalRole{v1beta1.AdminTemporalRole},
+			},
+			{
+				JWTSubject: "payments-*",
+				Namespaces: []v1beta1.CertificateClaimMapperNamespaceRoles{
+					{
+						Namespace: "payments",
+						Roles:     []v1beta1.TemporalRole{v1beta1.WriteTemporalRole},
+					},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, &certclaims.Claims{
+		Subject: "payments-api",
+		Namespaces: map[string]certclaims.Role{
+			"payments": certclaims.RoleWriter,
+		},
+	}, mapper.GetTokenClaims("payments-api"))
+
+	assert.Equal(t, &certclaims.Claims{
+		Subject:    "orders-api",
+		Namespaces: map[string]certclaims.Role{},
+	}, mapper.GetTokenClaims("orders-api"))
+
+	// Certificate rules never match tokens, and token rules never match certificates.
+	claims := mapper.GetClaims(&x509.Certificate{
+		Subject: pkix.Name{CommonName: "payments-api"},
+	})
+	assert.Equal(t, certclaims.RoleAdmin, claims.System)
+	assert.Empty(t, claims.Namespaces)
+}
+
+func TestLoadMapper(t *testing.T) {
+	file := filepath.Join(t.TempDir(), v1beta1.CertificateClaimMapperRulesFileName)
+	err := os.WriteFile(file, []byte(`{"rules":[{"dnsName":"*.example.com","systemRoles":["read"]}]}`), 0o600)
+	require.NoError(t, err)
+
+	mapper, err := certclaims.LoadMapper(file)
+	require.NoError(t, err)
+
+	claims := mapper.GetClaims(&x509.Certificate{
+		DNSNames: []string{"worker.example.com"},
+	})
+	assert.Equal(t, certclaims.RoleReader, claims.System)
+
+	_, err = certclaims.LoadMapper(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package circuitbreaker
+
+import (
+	"time"
+)
+
+// Config configures an exponential backoff guarded by a circuit breaker.
+// Each consecutive failure doubles the delay before the next attempt, up to MaxDelay.
+// Once FailureThreshold consecutive failures are reached, the circuit opens:
+// no attempt is made until the delay elapsed.
+type Config struct {
+	// InitialDelay is the delay after the first failure.
+	InitialDelay time.Duration
+	// MaxDelay is the maximum delay between two attempts.
+	MaxDelay time.Duration
+	// FailureThreshold is the number of consecutive failures opening the circuit.
+	FailureThreshold int32
+}
+
+// DefaultConfig returns the default circuit breaker configuration.
+func DefaultConfig() Config {
+	return Config{
+		InitialDelay:     2 * time.Second,
+		MaxDelay:         10 * time.Minute,
+		FailureThreshold: 5,
+	}
+}
+
+// Delay returns the delay before the next attempt after the provided number of consecutive failures.
+func (c Config) Delay(failures int32) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+
+	delay := c.InitialDelay
+	for i := int32(1); i < failures && delay < c.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, c.MaxDelay)
+}
+
+// IsOpen returns true if the provided number of consecutive failures opens the circuit.
+func (c Config) IsOpen(failures int32) bool {
+	return c.FailureThreshold > 0 && failures >= c.FailureThreshold
+}
+
+// RetryIn returns how long to wait before the next attempt is allowed, given the number of
+// consecutive failures and the time of the last one. It returns 0 if the circuit is closed
+// or if the delay already elapsed (half-open circuit).
+func (c Config) RetryIn(failures int32, lastFailure, now time.Time) time.Duration {
+	if !c.IsOpen(failures) {
+		return 0
+	}
+
+	remaining := lastFailure.Add(c.Delay(failures)).Sub(now)
+	return max(remaining, 0)
+}
@@ -0,0 +1,95 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package circuitbreaker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/circuitbreaker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelay(t *testing.T) {
+	c := circuitbreaker.Config{
+		InitialDelay:     time.Second,
+		MaxDelay:         time.Minute,
+		FailureThreshold: 3,
+	}
+
+	tests := map[int32]time.Duration{
+		0:    0,
+		1:    time.Second,
+		2:    2 * time.Second,
+		3:    4 * time.Second,
+		6:    32 * time.Second,
+		7:    time.Minute,
+		1000: time.Minute,
+	}
+
+	for failures, expected := range tests {
+		assert.Equal(t, expected, c.Delay(failures), "failures: %d", failures)
+	}
+}
+
+func TestRetryIn(t *testing.T) {
+	c := circuitbreaker.Config{
+		InitialDelay:     time.Second,
+		MaxDelay:         time.Minute,
+		FailureThreshold: 3,
+	}
+	now := time.Now()
+
+	tests := map[string]struct {
+		failures    int32
+		lastFailure time.Time
+		expected    time.Duration
+	}{
+		"closed circuit": {
+			failures:    2,
+			lastFailure: now,
+			expected:    0,
+		},
+		"open circuit": {
+			failures:    3,
+			lastFailure: now.Add(-time.Second),
+			expected:    3 * time.Second,
+		},
+		"half-open circuit": {
+			failures:    3,
+			lastFailure: now.Add(-10 * time.Second),
+			expected:    0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, c.RetryIn(test.failures, test.lastFailure, now))
+		})
+	}
+}
+
+func TestDisabledCircuit(t *testing.T) {
+	c := circuitbreaker.Config{
+		InitialDelay: time.Second,
+		MaxDelay:     time.Minute,
+	}
+
+	assert.False(t, c.IsOpen(1000))
+	assert.Equal(t, time.Duration(0), c.RetryIn(1000, time.Now(), time.Now()))
+}
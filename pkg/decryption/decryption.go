@@ -0,0 +1,122 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package decryption decrypts secret material provided encrypted by users, using pluggable providers.
+package decryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+)
+
+// Decrypter decrypts secret values.
+type Decrypter interface {
+	// Decrypt returns the plain text of the provided encrypted value.
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// Provider returns a Decrypter using the provided key.
+// The key format depends on the provider: it can be the key itself or credentials to reach a KMS.
+type Provider func(key []byte) (Decrypter, error)
+
+var (
+	mu        sync.RWMutex
+	providers = map[v1beta1.SecretDecryptionProvider]Provider{
+		v1beta1.AESGCMSecretDecryptionProvider: NewAESGCMDecrypter,
+	}
+)
+
+// Register makes a decryption provider available under the provided name.
+// It allows custom operator builds to support other encryption formats, like SOPS or cloud KMS.
+// It replaces any provider previously registered with the same name.
+func Register(name v1beta1.SecretDecryptionProvider, provider Provider) {
+	mu.Lock()
+	defer mu.Unlock()
+	providers[name] = provider
+}
+
+// NewDecrypter returns a Decrypter from the provider registered with the provided name.
+func NewDecrypter(name v1beta1.SecretDecryptionProvider, key []byte) (Decrypter, error) {
+	mu.RLock()
+	provider, ok := providers[name]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown decryption provider %q, available providers: %s", name, strings.Join(providerNames(), ", "))
+	}
+
+	return provider(key)
+}
+
+func providerNames() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+type aesGCMDecrypter struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMDecrypter returns a Decrypter for base64 encoded AES-GCM sealed values, prefixed by their nonce.
+// The key must be 16, 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+func NewAESGCMDecrypter(key []byte) (Decrypter, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aes key: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &aesGCMDecrypter{aead: aead}, nil
+}
+
+func (d *aesGCMDecrypter) Decrypt(_ context.Context, ciphertext []byte) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(ciphertext)))
+	if err != nil {
+		return nil, fmt.Errorf("can't decode value: %w", err)
+	}
+
+	nonceSize := d.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, errors.New("value is too short")
+	}
+
+	plaintext, err := d.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't decrypt value: %w", err)
+	}
+
+	return plaintext, nil
+}
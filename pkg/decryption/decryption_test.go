@@ -0,0 +1,126 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decryption_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/decryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seal(t *testing.T, key, plaintext []byte) []byte {
+	t.Helper()
+
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	require.NoError(t, err)
+
+	return []byte(base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, plaintext, nil)) + "\n")
+}
+
+func TestAESGCMDecrypter(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	otherKey := []byte("fedcba9876543210fedcba9876543210")
+
+	tests := map[string]struct {
+		key           []byte
+		ciphertext    []byte
+		expected      []byte
+		expectedError bool
+	}{
+		"decrypts value": {
+			key:        key,
+			ciphertext: seal(t, key, []byte("s3cr3t")),
+			expected:   []byte("s3cr3t"),
+		},
+		"wrong key": {
+			key:           otherKey,
+			ciphertext:    seal(t, key, []byte("s3cr3t")),
+			expectedError: true,
+		},
+		"not base64": {
+			key:           key,
+			ciphertext:    []byte("s3cr3t!"),
+			expectedError: true,
+		},
+		"too short": {
+			key:           key,
+			ciphertext:    []byte(base64.StdEncoding.EncodeToString([]byte("short"))),
+			expectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			decrypter, err := decryption.NewDecrypter(v1beta1.AESGCMSecretDecryptionProvider, test.key)
+			require.NoError(tt, err)
+
+			result, err := decrypter.Decrypt(context.Background(), test.ciphertext)
+			if test.expectedError {
+				assert.Error(tt, err)
+				return
+			}
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expected, result)
+		})
+	}
+}
+
+func TestNewDecrypter(t *testing.T) {
+	_, err := decryption.NewDecrypter(v1beta1.AESGCMSecretDecryptionProvider, []byte("invalid"))
+	assert.Error(t, err)
+
+	_, err = decryption.NewDecrypter("unknown", []byte("key"))
+	assert.ErrorContains(t, err, "available providers: aesgcm")
+}
+
+type reverseDecrypter struct{}
+
+func (reverseDecrypter) Decrypt(_ context.Context, ciphertext []byte) ([]byte, error) {
+	result := make([]byte, len(ciphertext))
+	for i, b := range ciphertext {
+		result[len(ciphertext)-1-i] = b
+	}
+	return result, nil
+}
+
+func TestRegister(t *testing.T) {
+	decryption.Register("reverse", func(_ []byte) (decryption.Decrypter, error) {
+		return reverseDecrypter{}, nil
+	})
+
+	decrypter, err := decryption.NewDecrypter("reverse", nil)
+	require.NoError(t, err)
+
+	result, err := decrypter.Decrypt(context.Background(), []byte("terces"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), result)
+}
@@ -0,0 +1,112 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package edition gates the cluster config blocks on the temporal server build edition.
+package edition
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// capability describes how an edition capability is used in the cluster spec.
+type capability struct {
+	name v1beta1.EditionCapability
+	path *field.Path
+	used func(cluster *v1beta1.TemporalCluster) bool
+}
+
+var capabilities = []capability{
+	{
+		name: v1beta1.ArchivalEditionCapability,
+		path: field.NewPath("spec", "archival"),
+		used: func(cluster *v1beta1.TemporalCluster) bool {
+			return cluster.Spec.Archival.IsEnabled()
+		},
+	},
+	{
+		name: v1beta1.AdvancedVisibilityEditionCapability,
+		path: field.NewPath("spec", "persistence", "advancedVisibilityStore"),
+		used: func(cluster *v1beta1.TemporalCluster) bool {
+			return cluster.Spec.Persistence.AdvancedVisibilityStore != nil
+		},
+	},
+	{
+		name: v1beta1.ReplicationEditionCapability,
+		path: field.NewPath("spec", "replication"),
+		used: func(cluster *v1beta1.TemporalCluster) bool {
+			return cluster.Spec.Replication.IsEnabled()
+		},
+	},
+	{
+		name: v1beta1.AuthorizationEditionCapability,
+		path: field.NewPath("spec", "authorization"),
+		used: func(cluster *v1beta1.TemporalCluster) bool {
+			return cluster.Spec.Authorization.IsEnabled()
+		},
+	},
+	{
+		name: v1beta1.InternalFrontendEditionCapability,
+		path: field.NewPath("spec", "services", "internalFrontend"),
+		used: func(cluster *v1beta1.TemporalCluster) bool {
+			return cluster.Spec.Services != nil && cluster.Spec.Services.InternalFrontend.IsEnabled()
+		},
+	},
+	{
+		name: v1beta1.DynamicConfigEditionCapability,
+		path: field.NewPath("spec", "dynamicConfig"),
+		used: func(cluster *v1beta1.TemporalCluster) bool {
+			return cluster.Spec.DynamicConfig != nil
+		},
+	},
+	{
+		name: v1beta1.MTLSEditionCapability,
+		path: field.NewPath("spec", "mTLS"),
+		used: func(cluster *v1beta1.TemporalCluster) bool {
+			return cluster.Spec.MTLS != nil && (cluster.Spec.MTLS.InternodeEnabled() || cluster.Spec.MTLS.FrontendEnabled())
+		},
+	},
+}
+
+// Supports returns true if the provided edition supports the capability.
+// The OSS edition supports all capabilities, custom editions only the ones they declare.
+func Supports(spec *v1beta1.EditionSpec, name v1beta1.EditionCapability) bool {
+	if spec.GetName() == v1beta1.OSSEdition {
+		return true
+	}
+	return slices.Contains(spec.Capabilities, name)
+}
+
+// Validate returns an error for each config block used by the cluster and not supported by its edition.
+func Validate(cluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+
+	for _, c := range capabilities {
+		if !c.used(cluster) || Supports(cluster.Spec.Edition, c.name) {
+			continue
+		}
+
+		errs = append(errs, field.Forbidden(c.path,
+			fmt.Sprintf("%s is not supported by the %s edition, add it to spec.edition.capabilities if the temporal server build supports it", c.name, cluster.Spec.Edition.GetName()),
+		))
+	}
+
+	return errs
+}
@@ -0,0 +1,89 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package edition_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/edition"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	archival := &v1beta1.ClusterArchivalSpec{Enabled: true}
+	replication := &v1beta1.ReplicationSpec{Enabled: true}
+
+	tests := map[string]struct {
+		spec         v1beta1.TemporalClusterSpec
+		expectedErrs []string
+	}{
+		"oss edition supports everything": {
+			spec: v1beta1.TemporalClusterSpec{
+				Archival:    archival,
+				Replication: replication,
+			},
+		},
+		"custom edition without capabilities": {
+			spec: v1beta1.TemporalClusterSpec{
+				Edition:     &v1beta1.EditionSpec{Name: v1beta1.CustomEdition},
+				Archival:    archival,
+				Replication: replication,
+			},
+			expectedErrs: []string{
+				"spec.archival: Forbidden: Archival is not supported by the Custom edition, add it to spec.edition.capabilities if the temporal server build supports it",
+				"spec.replication: Forbidden: Replication is not supported by the Custom edition, add it to spec.edition.capabilities if the temporal server build supports it",
+			},
+		},
+		"custom edition with capabilities": {
+			spec: v1beta1.TemporalClusterSpec{
+				Edition: &v1beta1.EditionSpec{
+					Name:         v1beta1.CustomEdition,
+					Capabilities: []v1beta1.EditionCapability{v1beta1.ArchivalEditionCapability},
+				},
+				Archival:    archival,
+				Replication: replication,
+			},
+			expectedErrs: []string{
+				"spec.replication: Forbidden: Replication is not supported by the Custom edition, add it to spec.edition.capabilities if the temporal server build supports it",
+			},
+		},
+		"custom edition without optional config": {
+			spec: v1beta1.TemporalClusterSpec{
+				Edition: &v1beta1.EditionSpec{Name: v1beta1.CustomEdition},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{Spec: test.spec}
+
+			errs := []string{}
+			for _, err := range edition.Validate(cluster) {
+				errs = append(errs, err.Error())
+			}
+
+			if len(test.expectedErrs) == 0 {
+				assert.Empty(tt, errs)
+				return
+			}
+			assert.Equal(tt, test.expectedErrs, errs)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// HealthGreen means all shards are allocated.
+	HealthGreen = "green"
+	// HealthYellow means all primary shards are allocated, but some replicas are not.
+	HealthYellow = "yellow"
+	// HealthRed means some primary shards are not allocated.
+	HealthRed = "red"
+)
+
+// ClusterHealth is the subset of the elasticsearch cluster health API response used by the operator.
+type ClusterHealth struct {
+	ClusterName string `json:"cluster_name"`
+	Status      string `json:"status"`
+}
+
+// IsHealthy returns true if all primary shards are allocated.
+func (h *ClusterHealth) IsHealthy() bool {
+	return h.Status == HealthGreen || h.Status == HealthYellow
+}
+
+// Client is a minimal elasticsearch client used to check the cluster health and the visibility indices mappings.
+type Client struct {
+	url        string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewClient returns a new elasticsearch client.
+// The provided tlsConfig can be nil.
+func NewClient(url, username, password string, tlsConfig *tls.Config) *Client {
+	return &Client{
+		url:      strings.TrimSuffix(url, "/"),
+		username: username,
+		password: password,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+		},
+	}
+}
+
+// ClusterHealth returns the elasticsearch cluster health.
+func (c *Client) ClusterHealth(ctx context.Context) (*ClusterHealth, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/_cluster/health", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("can't get elasticsearch cluster health: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("can't get elasticsearch cluster health: unexpected status code %d", resp.StatusCode)
+	}
+
+	health := &ClusterHealth{}
+	err = json.NewDecoder(resp.Body).Decode(health)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode elasticsearch cluster health: %w", err)
+	}
+
+	return health, nil
+}
@@ -0,0 +1,83 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/elasticsearch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterHealth(t *testing.T) {
+	tests := map[string]struct {
+		handler         http.HandlerFunc
+		expectedHealthy bool
+		expectedErr     bool
+	}{
+		"green cluster": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"cluster_name":"es","status":"green"}`))
+			},
+			expectedHealthy: true,
+		},
+		"yellow cluster": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"cluster_name":"es","status":"yellow"}`))
+			},
+			expectedHealthy: true,
+		},
+		"red cluster": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"cluster_name":"es","status":"red"}`))
+			},
+			expectedHealthy: false,
+		},
+		"wrong credentials": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				user, password, ok := r.BasicAuth()
+				if !ok || user != "temporal" || password != "secret" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				_, _ = w.Write([]byte(`{"cluster_name":"es","status":"green"}`))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			server := httptest.NewServer(test.handler)
+			defer server.Close()
+
+			client := elasticsearch.NewClient(server.URL, "temporal", "wrong", nil)
+			health, err := client.ClusterHealth(context.Background())
+			if test.expectedErr {
+				assert.Error(tt, err)
+				return
+			}
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expectedHealthy, health.IsHealthy())
+		})
+	}
+}
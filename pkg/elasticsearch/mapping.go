@@ -0,0 +1,252 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+)
+
+const (
+	// FieldTypeKeyword is the elasticsearch keyword field type.
+	FieldTypeKeyword = "keyword"
+	// FieldTypeText is the elasticsearch text field type.
+	FieldTypeText = "text"
+	// FieldTypeLong is the elasticsearch long field type.
+	FieldTypeLong = "long"
+	// FieldTypeScaledFloat is the elasticsearch scaled_float field type.
+	FieldTypeScaledFloat = "scaled_float"
+	// FieldTypeBoolean is the elasticsearch boolean field type.
+	FieldTypeBoolean = "boolean"
+	// FieldTypeDateNanos is the elasticsearch date_nanos field type.
+	FieldTypeDateNanos = "date_nanos"
+
+	// scaledFloatScalingFactor is the scaling factor temporal uses for Double search attributes.
+	scaledFloatScalingFactor = 10000
+)
+
+// searchAttributeFieldTypes maps temporal search attribute types to elasticsearch field types.
+var searchAttributeFieldTypes = map[string]string{
+	"Keyword":     FieldTypeKeyword,
+	"Text":        FieldTypeText,
+	"Int":         FieldTypeLong,
+	"Double":      FieldTypeScaledFloat,
+	"Bool":        FieldTypeBoolean,
+	"Datetime":    FieldTypeDateNanos,
+	"KeywordList": FieldTypeKeyword,
+}
+
+// FieldType returns the elasticsearch field type of the provided temporal search attribute type.
+func FieldType(searchAttributeType string) (string, error) {
+	fieldType, ok := searchAttributeFieldTypes[searchAttributeType]
+	if !ok {
+		return "", fmt.Errorf("unknown search attribute type %q", searchAttributeType)
+	}
+	return fieldType, nil
+}
+
+// SystemSearchAttributes returns the system search attributes fields, with their elasticsearch field type,
+// temporal expects in the visibility index of the provided version.
+func SystemSearchAttributes(v *version.Version) map[string]string {
+	fields := map[string]string{
+		"NamespaceId":           FieldTypeKeyword,
+		"WorkflowId":            FieldTypeKeyword,
+		"RunId":                 FieldTypeKeyword,
+		"WorkflowType":          FieldTypeKeyword,
+		"StartTime":             FieldTypeDateNanos,
+		"ExecutionTime":         FieldTypeDateNanos,
+		"CloseTime":             FieldTypeDateNanos,
+		"ExecutionDuration":     FieldTypeLong,
+		"ExecutionStatus":       FieldTypeKeyword,
+		"TaskQueue":             FieldTypeKeyword,
+		"TemporalChangeVersion": FieldTypeKeyword,
+		"BatcherNamespace":      FieldTypeKeyword,
+		"BatcherUser":           FieldTypeKeyword,
+		"BinaryChecksums":       FieldTypeKeyword,
+		"HistoryLength":         FieldTypeLong,
+		"StateTransitionCount":  FieldTypeLong,
+	}
+
+	if v.GreaterOrEqual(version.V1_18_0) {
+		fields["TemporalNamespaceDivision"] = FieldTypeKeyword
+		fields["TemporalScheduledStartTime"] = FieldTypeDateNanos
+		fields["TemporalScheduledById"] = FieldTypeKeyword
+		fields["TemporalSchedulePaused"] = FieldTypeBoolean
+	}
+
+	if v.GreaterOrEqual(version.V1_20_0) {
+		fields["HistorySizeBytes"] = FieldTypeLong
+	}
+
+	if v.GreaterOrEqual(version.V1_21_0) {
+		fields["BuildIds"] = FieldTypeKeyword
+	}
+
+	return fields
+}
+
+// FieldConflict describes a field mapped with an unexpected type.
+type FieldConflict struct {
+	Field        string
+	ExpectedType string
+	ActualType   string
+}
+
+// MappingDrift is the difference between an index mapping and the expected fields.
+type MappingDrift struct {
+	// Missing lists the expected fields missing from the index mapping.
+	Missing []string
+	// Conflicts lists the fields mapped with an unexpected type.
+	Conflicts []FieldConflict
+}
+
+// CompareMapping compares the actual index mapping fields with the expected ones.
+// Fields of the index mapping which are not expected are ignored.
+func CompareMapping(expected, actual map[string]string) MappingDrift {
+	drift := MappingDrift{}
+	for field, expectedType := range expected {
+		actualType, ok := actual[field]
+		switch {
+		case !ok:
+			drift.Missing = append(drift.Missing, field)
+		case actualType != expectedType:
+			drift.Conflicts = append(drift.Conflicts, FieldConflict{
+				Field:        field,
+				ExpectedType: expectedType,
+				ActualType:   actualType,
+			})
+		}
+	}
+
+	sort.Strings(drift.Missing)
+	sort.Slice(drift.Conflicts, func(i, j int) bool {
+		return drift.Conflicts[i].Field < drift.Conflicts[j].Field
+	})
+
+	return drift
+}
+
+type fieldMapping struct {
+	Type          string `json:"type"`
+	ScalingFactor int    `json:"scaling_factor,omitempty"`
+}
+
+type indexMapping struct {
+	Mappings struct {
+		Properties map[string]fieldMapping `json:"properties"`
+	} `json:"mappings"`
+}
+
+// IndexMapping returns the fields of the provided index mapping, with their type.
+// When index is an alias, fields of all its indices are returned.
+func (c *Client) IndexMapping(ctx context.Context, index string) (map[string]string, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/"+url.PathEscape(index)+"/_mapping", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("can't get elasticsearch index mapping: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("can't get elasticsearch index mapping: unexpected status code %d", resp.StatusCode)
+	}
+
+	mappings := map[string]indexMapping{}
+	err = json.NewDecoder(resp.Body).Decode(&mappings)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode elasticsearch index mapping: %w", err)
+	}
+
+	fields := map[string]string{}
+	for _, mapping := range mappings {
+		for field, property := range mapping.Mappings.Properties {
+			fields[field] = property.Type
+		}
+	}
+
+	return fields, nil
+}
+
+// PutMapping adds the provided fields, with their type, to the index mapping.
+// Existing fields can't be changed by elasticsearch without reindexing.
+func (c *Client) PutMapping(ctx context.Context, index string, fields map[string]string) error {
+	properties := map[string]fieldMapping{}
+	for field, fieldType := range fields {
+		property := fieldMapping{Type: fieldType}
+		if fieldType == FieldTypeScaledFloat {
+			property.ScalingFactor = scaledFloatScalingFactor
+		}
+		properties[field] = property
+	}
+
+	body, err := json.Marshal(map[string]any{"properties": properties})
+	if err != nil {
+		return err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPut, "/"+url.PathEscape(index)+"/_mapping", body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't update elasticsearch index mapping: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("can't update elasticsearch index mapping: unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body []byte) (*http.Request, error) {
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,112 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/elasticsearch"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemSearchAttributes(t *testing.T) {
+	fields := elasticsearch.SystemSearchAttributes(version.MustNewVersionFromString("1.20.3"))
+	assert.Equal(t, elasticsearch.FieldTypeLong, fields["HistorySizeBytes"])
+	assert.Equal(t, elasticsearch.FieldTypeBoolean, fields["TemporalSchedulePaused"])
+	assert.NotContains(t, fields, "BuildIds")
+
+	fields = elasticsearch.SystemSearchAttributes(version.MustNewVersionFromString("1.21.0"))
+	assert.Equal(t, elasticsearch.FieldTypeKeyword, fields["BuildIds"])
+}
+
+func TestCompareMapping(t *testing.T) {
+	expected := map[string]string{
+		"WorkflowId":    elasticsearch.FieldTypeKeyword,
+		"StartTime":     elasticsearch.FieldTypeDateNanos,
+		"CustomInt":     elasticsearch.FieldTypeLong,
+		"CustomKeyword": elasticsearch.FieldTypeKeyword,
+	}
+	actual := map[string]string{
+		"WorkflowId": elasticsearch.FieldTypeKeyword,
+		"StartTime":  "date",
+		"Memo":       "binary",
+	}
+
+	drift := elasticsearch.CompareMapping(expected, actual)
+	assert.Equal(t, []string{"CustomInt", "CustomKeyword"}, drift.Missing)
+	assert.Equal(t, []elasticsearch.FieldConflict{
+		{Field: "StartTime", ExpectedType: elasticsearch.FieldTypeDateNanos, ActualType: "date"},
+	}, drift.Conflicts)
+
+	drift = elasticsearch.CompareMapping(expected, expected)
+	assert.Empty(t, drift.Missing)
+	assert.Empty(t, drift.Conflicts)
+}
+
+func TestIndexMapping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/temporal_visibility_v1/_mapping" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"temporal_visibility_v1_000001": {"mappings": {"properties": {"WorkflowId": {"type": "keyword"}}}},
+			"temporal_visibility_v1_000002": {"mappings": {"properties": {"CustomDouble": {"type": "scaled_float", "scaling_factor": 10000}}}}
+		}`))
+	}))
+	defer server.Close()
+
+	client := elasticsearch.NewClient(server.URL, "", "", nil)
+	fields, err := client.IndexMapping(context.Background(), "temporal_visibility_v1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"WorkflowId":   elasticsearch.FieldTypeKeyword,
+		"CustomDouble": elasticsearch.FieldTypeScaledFloat,
+	}, fields)
+
+	_, err = client.IndexMapping(context.Background(), "unknown")
+	assert.Error(t, err)
+}
+
+func TestPutMapping(t *testing.T) {
+	var received map[string]map[string]map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/temporal_visibility_v1/_mapping" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}))
+	defer server.Close()
+
+	client := elasticsearch.NewClient(server.URL, "", "", nil)
+	err := client.PutMapping(context.Background(), "temporal_visibility_v1", map[string]string{
+		"CustomKeyword": elasticsearch.FieldTypeKeyword,
+		"CustomDouble":  elasticsearch.FieldTypeScaledFloat,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"type": "keyword"}, received["properties"]["CustomKeyword"])
+	assert.Equal(t, map[string]any{"type": "scaled_float", "scaling_factor": float64(10000)}, received["properties"]["CustomDouble"])
+}
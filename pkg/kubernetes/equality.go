@@ -0,0 +1,136 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kubernetes
+
+import (
+	"reflect"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// serverDefaultedLists are the list fields the API server fills when they are left empty.
+var serverDefaultedLists = map[string]bool{
+	"ClusterIPs": true,
+	"IPFamilies": true,
+}
+
+// serverDefaultedNumbers are the number fields the API server fills when they are left to zero,
+// such as the probes timeout, period and thresholds.
+var serverDefaultedNumbers = map[string]bool{
+	"TimeoutSeconds":   true,
+	"PeriodSeconds":    true,
+	"SuccessThreshold": true,
+	"FailureThreshold": true,
+}
+
+var (
+	quantityType = reflect.TypeOf(resource.Quantity{})
+	timeType     = reflect.TypeOf(metav1.Time{})
+)
+
+// SemanticallyEqual returns true if the desired object matches the live one, ignoring the fields
+// left unset in the desired object as they are defaulted by the API server.
+// Unlike equality.Semantic.DeepDerivative, emptied lists and maps are reported as changes
+// (e.g. removed init containers), except for the lists known to be defaulted by the API server.
+// Likewise, zero numbers are only ignored for the fields known to be defaulted by the API server.
+func SemanticallyEqual(desired, live runtime.Object) bool {
+	return derivative(reflect.ValueOf(desired), reflect.ValueOf(live), "")
+}
+
+// PodTemplateChanged returns true if updating the live deployment with the desired one
+// would roll its pods out.
+func PodTemplateChanged(desired, live *appsv1.Deployment) bool {
+	return !derivative(reflect.ValueOf(desired.Spec.Template), reflect.ValueOf(live.Spec.Template), "")
+}
+
+// derivative returns true if desired is a derivative of live: all the fields set in desired
+// have the same value in live. field is the name of the struct field holding the values.
+func derivative(desired, live reflect.Value, field string) bool {
+	if !desired.IsValid() || !live.IsValid() {
+		return desired.IsValid() == live.IsValid()
+	}
+
+	if desired.Type() != live.Type() {
+		return false
+	}
+
+	switch desired.Type() {
+	case quantityType:
+		desiredQuantity, liveQuantity := desired.Interface().(resource.Quantity), live.Interface().(resource.Quantity)
+		return desiredQuantity.Cmp(liveQuantity) == 0
+	case timeType:
+		desiredTime, liveTime := desired.Interface().(metav1.Time), live.Interface().(metav1.Time)
+		return desiredTime.Equal(&liveTime)
+	}
+
+	switch desired.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if desired.IsNil() {
+			return true
+		}
+		if live.IsNil() {
+			return false
+		}
+		return derivative(desired.Elem(), live.Elem(), field)
+	case reflect.Slice:
+		if desired.Len() == 0 && serverDefaultedLists[field] {
+			return true
+		}
+		if desired.Len() != live.Len() {
+			return false
+		}
+		for i := 0; i < desired.Len(); i++ {
+			if !derivative(desired.Index(i), live.Index(i), "") {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if desired.Len() != live.Len() {
+			return false
+		}
+		for _, key := range desired.MapKeys() {
+			if !derivative(desired.MapIndex(key), live.MapIndex(key), "") {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < desired.NumField(); i++ {
+			if !desired.Type().Field(i).IsExported() {
+				continue
+			}
+			if !derivative(desired.Field(i), live.Field(i), desired.Type().Field(i).Name) {
+				return false
+			}
+		}
+		return true
+	case reflect.String:
+		return desired.Len() == 0 || desired.String() == live.String()
+	case reflect.Int32:
+		if desired.Int() == 0 && serverDefaultedNumbers[field] {
+			return true
+		}
+		return desired.Int() == live.Int()
+	default:
+		return desired.Interface() == live.Interface()
+	}
+}
@@ -0,0 +1,360 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kubernetes_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/kubernetes"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// desiredDeployment returns a deployment as rendered by the operator builders.
+func desiredDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-history",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app.kubernetes.io/name": "test",
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name": "test",
+				},
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app.kubernetes.io/name": "test",
+					},
+					Annotations: map[string]string{
+						"operator.temporal.io/config": "hash",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "service",
+							Image: "temporalio/server:1.23.0",
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "rpc",
+									ContainerPort: 7234,
+								},
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU: resource.MustParse("1"),
+								},
+							},
+							LivenessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.FromString("rpc"),
+									},
+								},
+								TimeoutSeconds:   1,
+								PeriodSeconds:    10,
+								SuccessThreshold: 1,
+								FailureThreshold: 3,
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "test-config",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// liveDeployment returns the desired deployment as stored by the API server, with its defaulted fields.
+func liveDeployment() *appsv1.Deployment {
+	deployment := desiredDeployment()
+	deployment.ResourceVersion = "42"
+	deployment.Generation = 3
+	deployment.UID = "uid"
+	deployment.Annotations = map[string]string{
+		"deployment.kubernetes.io/revision": "3",
+	}
+	deployment.Spec.RevisionHistoryLimit = ptr.To[int32](10)
+	deployment.Spec.ProgressDeadlineSeconds = ptr.To[int32](600)
+	deployment.Spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
+		MaxUnavailable: ptr.To(intstr.FromString("25%")),
+		MaxSurge:       ptr.To(intstr.FromString("25%")),
+	}
+
+	spec := &deployment.Spec.Template.Spec
+	spec.RestartPolicy = corev1.RestartPolicyAlways
+	spec.DNSPolicy = corev1.DNSClusterFirst
+	spec.SchedulerName = corev1.DefaultSchedulerName
+	spec.TerminationGracePeriodSeconds = ptr.To[int64](30)
+	spec.SecurityContext = &corev1.PodSecurityContext{}
+	spec.Volumes[0].ConfigMap.DefaultMode = ptr.To[int32](corev1.ConfigMapVolumeSourceDefaultMode)
+
+	container := &spec.Containers[0]
+	container.ImagePullPolicy = corev1.PullIfNotPresent
+	container.TerminationMessagePath = corev1.TerminationMessagePathDefault
+	container.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	container.Ports[0].Protocol = corev1.ProtocolTCP
+	container.Resources.Requests[corev1.ResourceCPU] = resource.MustParse("1000m")
+
+	deployment.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: 3,
+		Replicas:           1,
+		ReadyReplicas:      1,
+	}
+
+	return deployment
+}
+
+// renderDeployment updates the live deployment with the desired one, as the operator builders do.
+func renderDeployment(live, desired *appsv1.Deployment) *appsv1.Deployment {
+	result := live.DeepCopy()
+	result.Labels = desired.Labels
+	result.Spec.Replicas = desired.Spec.Replicas
+	result.Spec.Selector = desired.Spec.Selector
+	result.Spec.Strategy.Type = desired.Spec.Strategy.Type
+	result.Spec.Template = desired.Spec.Template
+	return result
+}
+
+// withReadinessProbe adds a readiness probe leaving its server defaulted fields unset to the deployment.
+func withReadinessProbe(deployment *appsv1.Deployment) *appsv1.Deployment {
+	deployment.Spec.Template.Spec.Containers[0].ReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/ping",
+				Port: intstr.FromString("rpc"),
+			},
+		},
+	}
+	return deployment
+}
+
+// withDefaultedReadinessProbe adds the readiness probe of withReadinessProbe as stored by the API server.
+func withDefaultedReadinessProbe(deployment *appsv1.Deployment) *appsv1.Deployment {
+	deployment = withReadinessProbe(deployment)
+	probe := deployment.Spec.Template.Spec.Containers[0].ReadinessProbe
+	probe.HTTPGet.Scheme = corev1.URISchemeHTTP
+	probe.TimeoutSeconds = 1
+	probe.PeriodSeconds = 10
+	probe.SuccessThreshold = 1
+	probe.FailureThreshold = 3
+	return deployment
+}
+
+func TestSemanticallyEqual(t *testing.T) {
+	tests := map[string]struct {
+		desired runtime.Object
+		live    runtime.Object
+		equal   bool
+	}{
+		"deployment with server defaulted fields": {
+			desired: renderDeployment(liveDeployment(), desiredDeployment()),
+			live:    liveDeployment(),
+			equal:   true,
+		},
+		"deployment with server defaulted probe fields": {
+			desired: renderDeployment(liveDeployment(), withReadinessProbe(desiredDeployment())),
+			live:    withDefaultedReadinessProbe(liveDeployment()),
+			equal:   true,
+		},
+		"deployment with new probe threshold": {
+			desired: func() *appsv1.Deployment {
+				deployment := withReadinessProbe(desiredDeployment())
+				deployment.Spec.Template.Spec.Containers[0].ReadinessProbe.FailureThreshold = 5
+				return renderDeployment(liveDeployment(), deployment)
+			}(),
+			live:  withDefaultedReadinessProbe(liveDeployment()),
+			equal: false,
+		},
+		"deployment with new image": {
+			desired: func() *appsv1.Deployment {
+				deployment := desiredDeployment()
+				deployment.Spec.Template.Spec.Containers[0].Image = "temporalio/server:1.23.1"
+				return renderDeployment(liveDeployment(), deployment)
+			}(),
+			live:  liveDeployment(),
+			equal: false,
+		},
+		"deployment with new replicas count": {
+			desired: func() *appsv1.Deployment {
+				deployment := desiredDeployment()
+				deployment.Spec.Replicas = ptr.To[int32](3)
+				return renderDeployment(liveDeployment(), deployment)
+			}(),
+			live:  liveDeployment(),
+			equal: false,
+		},
+		"deployment with removed init container": {
+			desired: renderDeployment(liveDeployment(), desiredDeployment()),
+			live: func() *appsv1.Deployment {
+				deployment := liveDeployment()
+				deployment.Spec.Template.Spec.InitContainers = []corev1.Container{
+					{
+						Name:  "init",
+						Image: "busybox",
+					},
+				}
+				return deployment
+			}(),
+			equal: false,
+		},
+		"deployment with removed node selector": {
+			desired: renderDeployment(liveDeployment(), desiredDeployment()),
+			live: func() *appsv1.Deployment {
+				deployment := liveDeployment()
+				deployment.Spec.Template.Spec.NodeSelector = map[string]string{
+					"pool": "temporal",
+				}
+				return deployment
+			}(),
+			equal: false,
+		},
+		"deployment with new pod annotation": {
+			desired: func() *appsv1.Deployment {
+				deployment := desiredDeployment()
+				deployment.Spec.Template.Annotations["operator.temporal.io/config"] = "new-hash"
+				return renderDeployment(liveDeployment(), deployment)
+			}(),
+			live:  liveDeployment(),
+			equal: false,
+		},
+		"service with server defaulted fields": {
+			desired: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-frontend",
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "rpc",
+							Port:       7233,
+							TargetPort: intstr.FromString("rpc"),
+						},
+					},
+				},
+			},
+			live: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test-frontend",
+					ResourceVersion: "12",
+				},
+				Spec: corev1.ServiceSpec{
+					Type:            corev1.ServiceTypeClusterIP,
+					ClusterIP:       "10.0.0.12",
+					ClusterIPs:      []string{"10.0.0.12"},
+					IPFamilies:      []corev1.IPFamily{corev1.IPv4Protocol},
+					SessionAffinity: corev1.ServiceAffinityNone,
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "rpc",
+							Protocol:   corev1.ProtocolTCP,
+							Port:       7233,
+							TargetPort: intstr.FromString("rpc"),
+						},
+					},
+				},
+			},
+			equal: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.equal, kubernetes.SemanticallyEqual(test.desired, test.live))
+		})
+	}
+}
+
+func TestPodTemplateChanged(t *testing.T) {
+	tests := map[string]struct {
+		desired *appsv1.Deployment
+		live    *appsv1.Deployment
+		changed bool
+	}{
+		"no-op reconcile": {
+			desired: desiredDeployment(),
+			changed: false,
+		},
+		"no-op reconcile with server defaulted probe fields": {
+			desired: withReadinessProbe(desiredDeployment()),
+			live:    withDefaultedReadinessProbe(liveDeployment()),
+			changed: false,
+		},
+		"replicas change": {
+			desired: func() *appsv1.Deployment {
+				deployment := desiredDeployment()
+				deployment.Spec.Replicas = ptr.To[int32](3)
+				return deployment
+			}(),
+			changed: false,
+		},
+		"image change": {
+			desired: func() *appsv1.Deployment {
+				deployment := desiredDeployment()
+				deployment.Spec.Template.Spec.Containers[0].Image = "temporalio/server:1.23.1"
+				return deployment
+			}(),
+			changed: true,
+		},
+		"config change": {
+			desired: func() *appsv1.Deployment {
+				deployment := desiredDeployment()
+				deployment.Spec.Template.Annotations["operator.temporal.io/config"] = "new-hash"
+				return deployment
+			}(),
+			changed: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			live := test.live
+			if live == nil {
+				live = liveDeployment()
+			}
+			assert.Equal(tt, test.changed, kubernetes.PodTemplateChanged(test.desired, live))
+		})
+	}
+}
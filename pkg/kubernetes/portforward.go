@@ -0,0 +1,277 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// reconnectInitialDelay is the delay before the first reconnection attempt, doubled after each failed attempt.
+	reconnectInitialDelay = 500 * time.Millisecond
+	// reconnectMaxDelay is the maximum delay between two reconnection attempts.
+	reconnectMaxDelay = 10 * time.Second
+)
+
+// ErrNoReadyPod is returned when no ready pod matches the port forward selector.
+var ErrNoReadyPod = errors.New("no ready pod found")
+
+// PodPortForwardError is returned when a port can't be forwarded to a pod.
+type PodPortForwardError struct {
+	// Pod is the name of the pod the port couldn't be forwarded to.
+	Pod string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *PodPortForwardError) Error() string {
+	return fmt.Sprintf("can't forward port to pod %s: %s", e.Pod, e.Err)
+}
+
+func (e *PodPortForwardError) Unwrap() error {
+	return e.Err
+}
+
+// ForwardPortToPod forwards a random local port to the provided port of the pod, until stopCh is closed.
+// It blocks until the forward is ready and returns the local port.
+func ForwardPortToPod(cfg *rest.Config, pod *corev1.Pod, podPort int32, out io.Writer, stopCh <-chan struct{}) (int, error) {
+	localPort, _, err := forwardPort(cfg, pod, 0, podPort, out, stopCh)
+	return localPort, err
+}
+
+// forwardPort forwards the local port to the provided port of the pod, until stopCh is closed
+// or the connection to the pod is lost. A zero local port forwards a random local port.
+// It blocks until the forward is ready and returns the local port and a channel receiving the forward end error.
+func forwardPort(cfg *rest.Config, pod *corev1.Pod, localPort int, podPort int32, out io.Writer, stopCh <-chan struct{}) (int, <-chan error, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return 0, nil, fmt.Errorf("can't create port forward round tripper: %w", err)
+	}
+
+	serverURL, _, err := rest.DefaultServerUrlFor(cfg)
+	if err != nil {
+		return 0, nil, fmt.Errorf("can't get kubernetes api server url: %w", err)
+	}
+	serverURL.Path = path.Join(serverURL.Path, "api", "v1", "namespaces", pod.GetNamespace(), "pods", pod.GetName(), "portforward")
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, serverURL)
+
+	readyCh := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"localhost"}, []string{fmt.Sprintf("%d:%d", localPort, podPort)}, stopCh, readyCh, out, out)
+	if err != nil {
+		return 0, nil, &PodPortForwardError{Pod: pod.GetName(), Err: err}
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-readyCh:
+	case err := <-errCh:
+		if err == nil {
+			err = errors.New("port forward stopped before being ready")
+		}
+		return 0, nil, &PodPortForwardError{Pod: pod.GetName(), Err: err}
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		return 0, nil, &PodPortForwardError{Pod: pod.GetName(), Err: err}
+	}
+	if len(ports) == 0 {
+		return 0, nil, &PodPortForwardError{Pod: pod.GetName(), Err: errors.New("no port forwarded")}
+	}
+
+	return int(ports[0].Local), errCh, nil
+}
+
+// PortForward forwards a local port to one of the ready pods matching a selector.
+// When the connection to the pod is lost, e.g. because the pod was deleted, it forwards the same local port
+// to another ready pod, retrying until a pod is ready or the port forward is closed.
+type PortForward struct {
+	// LocalPort is the forwarded local port.
+	LocalPort int
+
+	cfg       *rest.Config
+	reader    client.Reader
+	namespace string
+	selector  labels.Selector
+	podPort   int32
+	out       io.Writer
+
+	stopCh    chan struct{}
+	closeOnce sync.Once
+}
+
+// ForwardPortToReadyPods forwards a random local port to the provided port of one of the ready pods matching the selector.
+// Pods are tried in turn until the port is forwarded to one of them.
+// The forward lasts until the context is done or the returned PortForward is closed.
+func ForwardPortToReadyPods(ctx context.Context, cfg *rest.Config, reader client.Reader, namespace string, selector labels.Selector, podPort int32, out io.Writer) (*PortForward, error) {
+	pf := &PortForward{
+		cfg:       cfg,
+		reader:    reader,
+		namespace: namespace,
+		selector:  selector,
+		podPort:   podPort,
+		out:       out,
+		stopCh:    make(chan struct{}),
+	}
+
+	pod, doneCh, err := pf.connect(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	go pf.reconnect(ctx, pod, doneCh)
+
+	return pf, nil
+}
+
+// Address returns the local address of the port forward.
+func (pf *PortForward) Address() string {
+	return fmt.Sprintf("localhost:%d", pf.LocalPort)
+}
+
+// Close stops the port forward.
+func (pf *PortForward) Close() {
+	pf.closeOnce.Do(func() {
+		close(pf.stopCh)
+	})
+}
+
+// connect forwards the port to a ready pod, trying the previous pod last.
+// It returns the pod name and a channel receiving the forward end error.
+func (pf *PortForward) connect(ctx context.Context, previous string) (string, <-chan error, error) {
+	pods, err := pf.readyPods(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Fall back to the previous pod only if no other pod is ready.
+	for i := range pods {
+		if pods[i].GetName() == previous {
+			pods = append(append(pods[:i:i], pods[i+1:]...), pods[i])
+			break
+		}
+	}
+
+	var errs []error
+	for i := range pods {
+		localPort, doneCh, err := forwardPort(pf.cfg, &pods[i], pf.LocalPort, pf.podPort, pf.out, pf.stopCh)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		// The local port is only chosen on the first connection, reconnections reuse it.
+		if pf.LocalPort == 0 {
+			pf.LocalPort = localPort
+		}
+		return pods[i].GetName(), doneCh, nil
+	}
+
+	return "", nil, errors.Join(errs...)
+}
+
+// reconnect forwards the port to another pod each time the current forward ends, until the port forward is closed.
+func (pf *PortForward) reconnect(ctx context.Context, pod string, doneCh <-chan error) {
+	for {
+		select {
+		case <-ctx.Done():
+			pf.Close()
+			return
+		case <-pf.stopCh:
+			return
+		case err := <-doneCh:
+			if err != nil {
+				fmt.Fprintf(pf.out, "Port forward to pod %s ended: %s, reconnecting\n", pod, err)
+			}
+		}
+
+		delay := reconnectInitialDelay
+		for {
+			var err error
+			pod, doneCh, err = pf.connect(ctx, pod)
+			if err == nil {
+				fmt.Fprintf(pf.out, "Port forward reconnected to pod %s\n", pod)
+				break
+			}
+
+			fmt.Fprintf(pf.out, "Can't reconnect port forward: %s, retrying in %s\n", err, delay)
+			select {
+			case <-ctx.Done():
+				pf.Close()
+				return
+			case <-pf.stopCh:
+				return
+			case <-time.After(delay):
+			}
+			delay = min(2*delay, reconnectMaxDelay)
+		}
+	}
+}
+
+// readyPods returns the running and ready pods matching the port forward selector.
+func (pf *PortForward) readyPods(ctx context.Context) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	err := pf.reader.List(ctx, podList, client.InNamespace(pf.namespace), client.MatchingLabelsSelector{Selector: pf.selector})
+	if err != nil {
+		return nil, fmt.Errorf("can't list pods: %w", err)
+	}
+
+	pods := []corev1.Pod{}
+	for _, pod := range podList.Items {
+		if IsPodReady(&pod) {
+			pods = append(pods, pod)
+		}
+	}
+
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("%w in namespace %s matching %s", ErrNoReadyPod, pf.namespace, pf.selector)
+	}
+
+	return pods, nil
+}
+
+// IsPodReady returns true if the pod is running, not being deleted, and ready.
+func IsPodReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning || !pod.DeletionTimestamp.IsZero() {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package networking provides helpers to allocate local ports and to probe TCP and gRPC endpoints.
+// They are shared by the operator connectivity checks and the end-to-end tests.
+package networking
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// LoopbackHost returns the loopback host of the provided network: "127.0.0.1" for tcp4, "::1" for tcp6,
+// and "localhost" for tcp, letting the resolver pick the address family.
+func LoopbackHost(network string) (string, error) {
+	switch network {
+	case "tcp":
+		return "localhost", nil
+	case "tcp4":
+		return "127.0.0.1", nil
+	case "tcp6":
+		return "::1", nil
+	default:
+		return "", fmt.Errorf("unsupported network %q, must be one of: tcp, tcp4, tcp6", network)
+	}
+}
+
+// FreePort returns a free port on the loopback interface of the provided network (tcp, tcp4 or tcp6).
+func FreePort(network string) (int, error) {
+	return FreePortInRange(network, 0, 0)
+}
+
+// FreePortInRange returns a free port between from and to, both included, on the loopback interface
+// of the provided network (tcp, tcp4 or tcp6). A zero range lets the kernel pick any free port.
+func FreePortInRange(network string, from, to int) (int, error) {
+	host, err := LoopbackHost(network)
+	if err != nil {
+		return 0, err
+	}
+
+	if from == 0 && to == 0 {
+		return listenPort(network, host, 0)
+	}
+
+	if from < 1 || to > 65535 || from > to {
+		return 0, fmt.Errorf("invalid port range %d-%d", from, to)
+	}
+
+	for port := from; port <= to; port++ {
+		if _, err := listenPort(network, host, port); err == nil {
+			return port, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no free port in range %d-%d", from, to)
+}
+
+// listenPort listens on the provided port and returns the bound port once the listener is closed.
+func listenPort(network, host string, port int) (int, error) {
+	l, err := net.Listen(network, net.JoinHostPort(host, fmt.Sprint(port)))
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = l.Close()
+	}()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// ProbeTCP returns an error if a TCP connection can't be opened to the provided address before the timeout.
+// The address is a "host:port" pair, IPv6 hosts must be enclosed in brackets, see net.JoinHostPort.
+func ProbeTCP(ctx context.Context, addr string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("can't reach %s: %w", addr, err)
+	}
+	return conn.Close()
+}
+
+// ProbeGRPCHealth returns an error if the gRPC health service of the provided address doesn't report
+// the service as serving before the timeout. An empty service checks the server overall health.
+// A nil tlsConfig connects in plaintext.
+func ProbeGRPCHealth(ctx context.Context, addr string, tlsConfig *tls.Config, service string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return fmt.Errorf("can't create gRPC client for %s: %w", addr, err)
+	}
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return fmt.Errorf("can't check %s health: %w", addr, err)
+	}
+
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%s is not serving: %s", addr, resp.GetStatus())
+	}
+
+	return nil
+}
+
+// WaitFor calls probe every interval until it succeeds or the context is done.
+// It returns the last probe error if the context is done first.
+func WaitFor(ctx context.Context, interval time.Duration, probe func(ctx context.Context) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := probe(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForTCP waits until a TCP connection can be opened to the provided address, or the context is done.
+func WaitForTCP(ctx context.Context, addr string, interval time.Duration) error {
+	return WaitFor(ctx, interval, func(ctx context.Context) error {
+		return ProbeTCP(ctx, addr, interval)
+	})
+}
+
+// WaitForGRPCHealth waits until the gRPC health service of the provided address reports the service as serving,
+// or the context is done.
+func WaitForGRPCHealth(ctx context.Context, addr string, tlsConfig *tls.Config, service string, interval time.Duration) error {
+	return WaitFor(ctx, interval, func(ctx context.Context) error {
+		return ProbeGRPCHealth(ctx, addr, tlsConfig, service, interval)
+	})
+}
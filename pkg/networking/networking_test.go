@@ -0,0 +1,143 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package networking
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func listen(t *testing.T, network string) net.Listener {
+	host, err := LoopbackHost(network)
+	require.NoError(t, err)
+
+	l, err := net.Listen(network, net.JoinHostPort(host, "0"))
+	if err != nil {
+		t.Skipf("can't listen on %s: %s", network, err)
+	}
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+	return l
+}
+
+func TestFreePort(t *testing.T) {
+	for _, network := range []string{"tcp", "tcp4", "tcp6"} {
+		t.Run(network, func(tt *testing.T) {
+			// Skip networks unavailable on the host, e.g. IPv6.
+			listen(tt, network)
+
+			port, err := FreePort(network)
+			require.NoError(tt, err)
+			assert.Positive(tt, port)
+		})
+	}
+
+	_, err := FreePort("udp")
+	assert.Error(t, err)
+}
+
+func TestFreePortInRange(t *testing.T) {
+	l := listen(t, "tcp4")
+	used := l.Addr().(*net.TCPAddr).Port
+
+	_, err := FreePortInRange("tcp4", used, used)
+	assert.Error(t, err)
+
+	if used < 65535 {
+		port, err := FreePortInRange("tcp4", used, used+1)
+		if err == nil {
+			assert.Equal(t, used+1, port)
+		}
+	}
+
+	_, err = FreePortInRange("tcp4", 10, 5)
+	assert.Error(t, err)
+}
+
+func TestProbeTCP(t *testing.T) {
+	for _, network := range []string{"tcp4", "tcp6"} {
+		t.Run(network, func(tt *testing.T) {
+			l := listen(tt, network)
+
+			err := ProbeTCP(context.Background(), l.Addr().String(), time.Second)
+			assert.NoError(tt, err)
+		})
+	}
+
+	l := listen(t, "tcp4")
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	err := ProbeTCP(context.Background(), addr, time.Second)
+	assert.Error(t, err)
+}
+
+func TestWaitForTCP(t *testing.T) {
+	port, err := FreePort("tcp4")
+	require.NoError(t, err)
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+
+	listeners := make(chan net.Listener, 1)
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		l, err := net.Listen("tcp4", addr)
+		if err == nil {
+			listeners <- l
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, WaitForTCP(ctx, addr, 50*time.Millisecond))
+	_ = (<-listeners).Close()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	assert.Error(t, WaitForTCP(ctx, "127.0.0.1:1", 50*time.Millisecond))
+}
+
+func TestProbeGRPCHealth(t *testing.T) {
+	l := listen(t, "tcp4")
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("temporal.api.workflowservice.v1.WorkflowService", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("temporal.server.api.historyservice.v1.HistoryService", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() {
+		_ = server.Serve(l)
+	}()
+	t.Cleanup(server.Stop)
+
+	err := ProbeGRPCHealth(context.Background(), l.Addr().String(), nil, "temporal.api.workflowservice.v1.WorkflowService", time.Second)
+	assert.NoError(t, err)
+
+	err = ProbeGRPCHealth(context.Background(), l.Addr().String(), nil, "temporal.server.api.historyservice.v1.HistoryService", time.Second)
+	assert.Error(t, err)
+}
@@ -0,0 +1,164 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// DisabledAnnotation disables lifecycle notifications for the annotated cluster when set to "true".
+	DisabledAnnotation = "notifications.temporal.io/disabled"
+	// WebhookSecretAnnotation is the name of a secret, in the cluster's namespace, holding the webhook URL
+	// lifecycle notifications of the annotated cluster are sent to, under the "url" key.
+	// It overrides the operator-level webhook URL.
+	WebhookSecretAnnotation = "notifications.temporal.io/webhook-secret"
+	// EventsAnnotation is a comma separated list of the event types sent for the annotated cluster.
+	// All event types are sent if not set.
+	EventsAnnotation = "notifications.temporal.io/events"
+
+	webhookSecretURLKey = "url"
+)
+
+// Sink sends clusters lifecycle events to a generic webhook.
+// The webhook URL is configured at the operator level and can be overridden per cluster using annotations.
+// Each event is sent at most once per operator run.
+type Sink struct {
+	client client.Client
+
+	mu         sync.Mutex
+	defaultURL string
+	sent       map[string]struct{}
+}
+
+// NewSink returns a new notification sink sending events to the provided default webhook URL.
+// The default URL can be empty: only clusters annotated with a webhook secret are then notified.
+func NewSink(c client.Client, defaultURL string) *Sink {
+	return &Sink{
+		client:     c,
+		defaultURL: defaultURL,
+		sent:       map[string]struct{}{},
+	}
+}
+
+// SetDefaultURL replaces the default webhook URL, for instance when the operator config is reloaded.
+func (s *Sink) SetDefaultURL(url string) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaultURL = url
+}
+
+// Notify sends the provided event for the cluster, unless an event with the same type and key
+// was already sent for this cluster. Errors are logged and never returned.
+func (s *Sink) Notify(ctx context.Context, cluster *v1beta1.TemporalCluster, eventType EventType, key, message string) {
+	if s == nil || !eventEnabled(cluster, eventType) {
+		return
+	}
+
+	id := fmt.Sprintf("%s/%s/%s", cluster.GetUID(), eventType, key)
+
+	s.mu.Lock()
+	_, alreadySent := s.sent[id]
+	s.mu.Unlock()
+	if alreadySent {
+		return
+	}
+
+	logger := log.FromContext(ctx)
+
+	url, err := s.webhookURL(ctx, cluster)
+	if err != nil {
+		logger.Error(err, "Can't get notification webhook url")
+		return
+	}
+	if url == "" {
+		return
+	}
+
+	err = Send(ctx, url, &Event{
+		Type:      eventType,
+		Cluster:   cluster.GetName(),
+		Namespace: cluster.GetNamespace(),
+		Version:   cluster.Spec.Version.String(),
+		Time:      time.Now(),
+		Message:   message,
+	})
+	if err != nil {
+		logger.Error(err, "Can't send lifecycle notification", "event", eventType)
+		return
+	}
+
+	s.mu.Lock()
+	s.sent[id] = struct{}{}
+	s.mu.Unlock()
+}
+
+func (s *Sink) webhookURL(ctx context.Context, cluster *v1beta1.TemporalCluster) (string, error) {
+	secretName, ok := cluster.GetAnnotations()[WebhookSecretAnnotation]
+	if !ok {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.defaultURL, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := s.client.Get(ctx, types.NamespacedName{Namespace: cluster.GetNamespace(), Name: secretName}, secret)
+	if err != nil {
+		return "", err
+	}
+
+	url, ok := secret.Data[webhookSecretURLKey]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in secret %q", webhookSecretURLKey, secretName)
+	}
+
+	return string(url), nil
+}
+
+func eventEnabled(cluster *v1beta1.TemporalCluster, eventType EventType) bool {
+	annotations := cluster.GetAnnotations()
+	if annotations[DisabledAnnotation] == "true" {
+		return false
+	}
+
+	events, ok := annotations[EventsAnnotation]
+	if !ok {
+		return true
+	}
+
+	for _, event := range strings.Split(events, ",") {
+		if EventType(strings.TrimSpace(event)) == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/notification"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSinkNotify(t *testing.T) {
+	tests := map[string]struct {
+		annotations    map[string]string
+		events         []notification.EventType
+		expectedEvents []notification.EventType
+	}{
+		"sends all events": {
+			events:         []notification.EventType{notification.UpgradeStartedEvent, notification.SchemaJobFailedEvent},
+			expectedEvents: []notification.EventType{notification.UpgradeStartedEvent, notification.SchemaJobFailedEvent},
+		},
+		"sends each event once": {
+			events:         []notification.EventType{notification.UpgradeStartedEvent, notification.UpgradeStartedEvent},
+			expectedEvents: []notification.EventType{notification.UpgradeStartedEvent},
+		},
+		"filters events": {
+			annotations: map[string]string{
+				notification.EventsAnnotation: "UpgradeCompleted, SchemaJobFailed",
+			},
+			events:         []notification.EventType{notification.UpgradeStartedEvent, notification.SchemaJobFailedEvent},
+			expectedEvents: []notification.EventType{notification.SchemaJobFailedEvent},
+		},
+		"disabled": {
+			annotations: map[string]string{
+				notification.DisabledAnnotation: "true",
+			},
+			events:         []notification.EventType{notification.UpgradeStartedEvent},
+			expectedEvents: []notification.EventType{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			received := []notification.EventType{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				event := &notification.Event{}
+				assert.NoError(tt, json.NewDecoder(r.Body).Decode(event))
+				received = append(received, event.Type)
+			}))
+			defer server.Close()
+
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "prod",
+					Namespace:   "demo",
+					UID:         "1234",
+					Annotations: test.annotations,
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.23.0"),
+				},
+			}
+
+			sink := notification.NewSink(fake.NewClientBuilder().Build(), server.URL)
+			for _, event := range test.events {
+				sink.Notify(context.Background(), cluster, event, "1.23.0", "")
+			}
+
+			assert.Equal(tt, test.expectedEvents, received)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package notification sends clusters lifecycle events to webhooks.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// EventType is the type of a notification event.
+type EventType string
+
+const (
+	// RolloutStartedEvent is sent when the operator starts rolling out a cluster's services.
+	RolloutStartedEvent EventType = "RolloutStarted"
+	// RolloutCompletedEvent is sent when all the cluster's services are ready again.
+	RolloutCompletedEvent EventType = "RolloutCompleted"
+	// UpgradeStartedEvent is sent when the cluster starts being upgraded to a new version.
+	UpgradeStartedEvent EventType = "UpgradeStarted"
+	// UpgradeCompletedEvent is sent when all the cluster's services run the new version.
+	UpgradeCompletedEvent EventType = "UpgradeCompleted"
+	// SchemaJobFailedEvent is sent when a persistence schema job failed.
+	SchemaJobFailedEvent EventType = "SchemaJobFailed"
+	// CertificateExpiringEvent is sent when a cluster certificate is about to expire.
+	CertificateExpiringEvent EventType = "CertificateExpiring"
+	// ClusterUnhealthyEvent is sent when the cluster has not been ready for a while.
+	ClusterUnhealthyEvent EventType = "ClusterUnhealthy"
+)
+
+// Event is the payload sent to webhooks.
+type Event struct {
+	Type      EventType `json:"type"`
+	Cluster   string    `json:"cluster"`
+	Namespace string    `json:"namespace"`
+	Version   string    `json:"version"`
+	Time      time.Time `json:"time"`
+	// Message is a human readable description of the event.
+	// It is named "text" to be compatible with Slack incoming webhooks.
+	Message string `json:"text,omitempty"`
+}
+
+// Send posts the provided event as JSON to the provided webhook url.
+func Send(ctx context.Context, url string, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("can't send notification: unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package priorityqueue provides a controller workqueue handing out the items with the highest priority first,
+// so the most critical objects are reconciled first when a controller has a backlog.
+package priorityqueue
+
+import (
+	"container/heap"
+	"sync"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// PriorityFunc returns the priority of an item. It's called each time the item is added to the queue.
+type PriorityFunc func(item any) int32
+
+// Queue is a workqueue handing out the items with the highest priority first.
+// Items with the same priority are handed out in the order they were added.
+// Like the default workqueue, an item is never processed concurrently: an item added while being processed
+// is queued again once it's done.
+type Queue struct {
+	priority PriorityFunc
+
+	cond *sync.Cond
+	// queue holds the items waiting to be processed.
+	queue entries
+	// dirty holds the items to process, being queued or added while being processed.
+	dirty map[any]*entry
+	// processing holds the items being processed.
+	processing map[any]struct{}
+	// seq orders the items with the same priority.
+	seq uint64
+
+	shuttingDown bool
+	drain        bool
+}
+
+var _ workqueue.Interface = (*Queue)(nil)
+
+// New returns a queue ordering the items using the provided priority function.
+func New(priority PriorityFunc) *Queue {
+	return &Queue{
+		priority:   priority,
+		cond:       sync.NewCond(&sync.Mutex{}),
+		dirty:      map[any]*entry{},
+		processing: map[any]struct{}{},
+	}
+}
+
+// NewRateLimitingQueue returns a rate limiting queue handing out the items with the highest priority first.
+// Unlike the default controller workqueue, it doesn't report the queue depth and latency metrics.
+func NewRateLimitingQueue(name string, rateLimiter workqueue.RateLimiter, priority PriorityFunc) workqueue.RateLimitingInterface {
+	return workqueue.NewRateLimitingQueueWithConfig(rateLimiter, workqueue.RateLimitingQueueConfig{
+		Name: name,
+		DelayingQueue: workqueue.NewDelayingQueueWithConfig(workqueue.DelayingQueueConfig{
+			Name:  name,
+			Queue: New(priority),
+		}),
+	})
+}
+
+// Add marks the item as needing processing. If the item is already queued, its priority is updated.
+func (q *Queue) Add(item any) {
+	// The priority function may read from a cache, don't hold the lock.
+	priority := q.priority(item)
+
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.shuttingDown {
+		return
+	}
+
+	if e, ok := q.dirty[item]; ok {
+		e.priority = priority
+		if e.index >= 0 {
+			heap.Fix(&q.queue, e.index)
+		}
+		return
+	}
+
+	q.seq++
+	e := &entry{item: item, priority: priority, seq: q.seq, index: -1}
+	q.dirty[item] = e
+
+	// The item is queued again once it's done.
+	if _, ok := q.processing[item]; ok {
+		return
+	}
+
+	heap.Push(&q.queue, e)
+	q.cond.Signal()
+}
+
+// Len returns the number of items waiting to be processed.
+func (q *Queue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	return len(q.queue)
+}
+
+// Get blocks until it can return the item with the highest priority.
+// If shutdown is true, the caller should end its goroutine.
+func (q *Queue) Get() (item any, shutdown bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for len(q.queue) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if len(q.queue) == 0 {
+		return nil, true
+	}
+
+	e := heap.Pop(&q.queue).(*entry)
+	q.processing[e.item] = struct{}{}
+	delete(q.dirty, e.item)
+
+	return e.item, false
+}
+
+// Done marks the item as done processing. If it has been added while being processed, it's queued again.
+func (q *Queue) Done(item any) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	delete(q.processing, item)
+	if e, ok := q.dirty[item]; ok {
+		heap.Push(&q.queue, e)
+		q.cond.Signal()
+	} else if len(q.processing) == 0 {
+		// Wake up ShutDownWithDrain.
+		q.cond.Broadcast()
+	}
+}
+
+// ShutDown makes the queue ignore the added items and Get return once the queued items are handed out.
+func (q *Queue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	q.drain = false
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
+
+// ShutDownWithDrain is like ShutDown, but waits for the items being processed to be done.
+func (q *Queue) ShutDownWithDrain() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	q.drain = true
+	q.shuttingDown = true
+	q.cond.Broadcast()
+
+	for len(q.processing) != 0 && q.drain {
+		q.cond.Wait()
+	}
+}
+
+// ShuttingDown returns true if the queue is shutting down.
+func (q *Queue) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	return q.shuttingDown
+}
+
+// entry is an item waiting to be processed.
+type entry struct {
+	item     any
+	priority int32
+	seq      uint64
+	// index is the position of the entry in the queue, -1 if it's not queued.
+	index int
+}
+
+// entries implements heap.Interface, ordering the entries by decreasing priority then by addition order.
+type entries []*entry
+
+func (e entries) Len() int { return len(e) }
+
+func (e entries) Less(i, j int) bool {
+	if e[i].priority != e[j].priority {
+		return e[i].priority > e[j].priority
+	}
+	return e[i].seq < e[j].seq
+}
+
+func (e entries) Swap(i, j int) {
+	e[i], e[j] = e[j], e[i]
+	e[i].index = i
+	e[j].index = j
+}
+
+func (e *entries) Push(x any) {
+	item := x.(*entry)
+	item.index = len(*e)
+	*e = append(*e, item)
+}
+
+func (e *entries) Pop() any {
+	old := *e
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*e = old[:n-1]
+	return item
+}
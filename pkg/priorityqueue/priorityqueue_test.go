@@ -0,0 +1,155 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package priorityqueue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/priorityqueue"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestQueue(t *testing.T) {
+	priorities := map[string]int32{
+		"prod":    100,
+		"staging": 10,
+		"dev":     -10,
+	}
+	q := priorityqueue.New(func(item any) int32 {
+		return priorities[item.(string)]
+	})
+
+	for _, item := range []string{"dev", "default-a", "prod", "default-b", "staging"} {
+		q.Add(item)
+	}
+	// Adding a queued item again is a no-op.
+	q.Add("dev")
+	assert.Equal(t, 5, q.Len())
+
+	expected := []string{"prod", "staging", "default-a", "default-b", "dev"}
+	for _, item := range expected {
+		got, shutdown := q.Get()
+		assert.False(t, shutdown)
+		assert.Equal(t, item, got)
+		q.Done(got)
+	}
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestQueuePriorityUpdate(t *testing.T) {
+	priorities := map[string]int32{}
+	q := priorityqueue.New(func(item any) int32 {
+		return priorities[item.(string)]
+	})
+
+	q.Add("a")
+	q.Add("b")
+
+	// The priority is updated when a queued item is added again.
+	priorities["b"] = 1
+	q.Add("b")
+
+	got, _ := q.Get()
+	assert.Equal(t, "b", got)
+}
+
+func TestQueueProcessing(t *testing.T) {
+	q := priorityqueue.New(func(any) int32 { return 0 })
+
+	q.Add("a")
+	got, _ := q.Get()
+	assert.Equal(t, "a", got)
+
+	// An item added while being processed is queued again once it's done.
+	q.Add("a")
+	assert.Equal(t, 0, q.Len())
+	q.Done("a")
+	assert.Equal(t, 1, q.Len())
+
+	got, _ = q.Get()
+	assert.Equal(t, "a", got)
+	q.Done("a")
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestQueueShutDown(t *testing.T) {
+	q := priorityqueue.New(func(any) int32 { return 0 })
+
+	q.Add("a")
+	q.ShutDown()
+	assert.True(t, q.ShuttingDown())
+
+	// Added items are ignored, queued items are still handed out.
+	q.Add("b")
+	got, shutdown := q.Get()
+	assert.False(t, shutdown)
+	assert.Equal(t, "a", got)
+
+	_, shutdown = q.Get()
+	assert.True(t, shutdown)
+}
+
+func TestQueueShutDownWithDrain(t *testing.T) {
+	q := priorityqueue.New(func(any) int32 { return 0 })
+
+	q.Add("a")
+	got, _ := q.Get()
+
+	drained := make(chan struct{})
+	go func() {
+		q.ShutDownWithDrain()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		t.Fatal("queue drained while an item is being processed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	q.Done(got)
+	select {
+	case <-drained:
+	case <-time.After(time.Second):
+		t.Fatal("queue not drained once the items are done")
+	}
+}
+
+func TestNewRateLimitingQueue(t *testing.T) {
+	q := priorityqueue.NewRateLimitingQueue("", workqueue.DefaultControllerRateLimiter(), func(item any) int32 {
+		if item == "prod" {
+			return 1
+		}
+		return 0
+	})
+	defer q.ShutDown()
+
+	q.Add("dev")
+	q.AddAfter("prod", 0)
+
+	got, _ := q.Get()
+	assert.Equal(t, "prod", got)
+	q.Done(got)
+
+	q.AddRateLimited("dev")
+	assert.Equal(t, 1, q.NumRequeues("dev"))
+	q.Forget("dev")
+	assert.Equal(t, 0, q.NumRequeues("dev"))
+}
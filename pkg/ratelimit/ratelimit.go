@@ -0,0 +1,136 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// KeyedLimiter rate limits operations per key, like the namespaces registrations of each cluster,
+// and tracks the items waiting for their turn.
+// A nil KeyedLimiter or a limiter with a non-positive limit doesn't limit anything.
+type KeyedLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	pending  map[string]map[string]struct{}
+}
+
+// NewKeyedLimiter returns a limiter allowing perSecond operations per key, with bursts of up to burst operations.
+func NewKeyedLimiter(perSecond float64, burst int) *KeyedLimiter {
+	return &KeyedLimiter{
+		limit:    rate.Limit(perSecond),
+		burst:    max(burst, 1),
+		limiters: map[string]*rate.Limiter{},
+		pending:  map[string]map[string]struct{}{},
+	}
+}
+
+// SetLimit updates the limit and burst of all the keys, for instance when the operator config is reloaded.
+func (l *KeyedLimiter) SetLimit(perSecond float64, burst int) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limit = rate.Limit(perSecond)
+	l.burst = max(burst, 1)
+	for _, limiter := range l.limiters {
+		limiter.SetLimit(l.limit)
+		limiter.SetBurst(l.burst)
+	}
+}
+
+// Reserve takes a token of the key limiter for the item. It returns 0 when the item can proceed,
+// or the delay after which the item should try again. Items asked to wait are reported as pending
+// until they proceed or are forgotten.
+func (l *KeyedLimiter) Reserve(key, item string, now time.Time) time.Duration {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit <= 0 {
+		return 0
+	}
+
+	limiter, ok := l.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[key] = limiter
+	}
+
+	reservation := limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		l.forget(key, item)
+		return 0
+	}
+
+	// Give the token back, the item takes a new one when retrying.
+	reservation.CancelAt(now)
+
+	if l.pending[key] == nil {
+		l.pending[key] = map[string]struct{}{}
+	}
+	l.pending[key][item] = struct{}{}
+
+	return delay
+}
+
+// Forget removes the item from the pending items of every key, for instance when it was deleted while waiting.
+func (l *KeyedLimiter) Forget(item string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key := range l.pending {
+		l.forget(key, item)
+	}
+}
+
+func (l *KeyedLimiter) forget(key, item string) {
+	delete(l.pending[key], item)
+	if len(l.pending[key]) == 0 {
+		delete(l.pending, key)
+	}
+}
+
+// Pending returns the number of items waiting for their turn for the key.
+func (l *KeyedLimiter) Pending(key string) int {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.pending[key])
+}
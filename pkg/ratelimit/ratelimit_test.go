@@ -0,0 +1,77 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := ratelimit.NewKeyedLimiter(2, 2)
+
+	// The burst is allowed.
+	assert.Zero(t, limiter.Reserve("prod", "a", now))
+	assert.Zero(t, limiter.Reserve("prod", "b", now))
+
+	// Next items wait for a token, without consuming one.
+	assert.Equal(t, 500*time.Millisecond, limiter.Reserve("prod", "c", now))
+	assert.Equal(t, 500*time.Millisecond, limiter.Reserve("prod", "d", now))
+	assert.Equal(t, 2, limiter.Pending("prod"))
+
+	// Keys are limited independently.
+	assert.Zero(t, limiter.Reserve("staging", "a", now))
+	assert.Equal(t, 0, limiter.Pending("staging"))
+
+	// Once the delay elapsed, the item proceeds and is no longer pending.
+	assert.Zero(t, limiter.Reserve("prod", "c", now.Add(500*time.Millisecond)))
+	assert.Equal(t, 1, limiter.Pending("prod"))
+
+	limiter.Forget("d")
+	assert.Equal(t, 0, limiter.Pending("prod"))
+}
+
+func TestKeyedLimiterDisabled(t *testing.T) {
+	var limiter *ratelimit.KeyedLimiter
+	assert.Zero(t, limiter.Reserve("prod", "a", time.Now()))
+	assert.Equal(t, 0, limiter.Pending("prod"))
+
+	limiter = ratelimit.NewKeyedLimiter(0, 1)
+	for i := 0; i < 10; i++ {
+		assert.Zero(t, limiter.Reserve("prod", "a", time.Now()))
+	}
+}
+
+func TestKeyedLimiterSetLimit(t *testing.T) {
+	now := time.Now()
+	limiter := ratelimit.NewKeyedLimiter(0, 1)
+
+	assert.Zero(t, limiter.Reserve("prod", "a", now))
+	assert.Zero(t, limiter.Reserve("prod", "b", now))
+
+	limiter.SetLimit(1, 1)
+	assert.Zero(t, limiter.Reserve("prod", "c", now))
+	assert.NotZero(t, limiter.Reserve("prod", "d", now))
+
+	limiter.SetLimit(0, 1)
+	assert.Zero(t, limiter.Reserve("prod", "d", now))
+}
@@ -0,0 +1,121 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit
+
+import (
+	"sync"
+)
+
+// Semaphore caps the number of keys holding a slot at the same time, like the clusters rolling out their pods,
+// and tracks the keys waiting for a slot.
+// A key keeps its slot until it's released: acquiring a slot again is a no-op.
+// A nil Semaphore or a semaphore with a non-positive size doesn't limit anything.
+type Semaphore struct {
+	size int
+
+	mu      sync.Mutex
+	holders map[string]struct{}
+	waiting map[string]struct{}
+}
+
+// NewSemaphore returns a semaphore allowing size keys to hold a slot at the same time.
+func NewSemaphore(size int) *Semaphore {
+	return &Semaphore{
+		size:    size,
+		holders: map[string]struct{}{},
+		waiting: map[string]struct{}{},
+	}
+}
+
+// Resize updates the number of keys allowed to hold a slot at the same time, for instance when the operator config
+// is reloaded. Keys holding a slot keep it when the semaphore shrinks.
+func (s *Semaphore) Resize(size int) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.size = size
+}
+
+// Acquire takes a slot for the key. It returns false if all the slots are held by other keys,
+// the key is then reported as waiting until it gets a slot or is released.
+func (s *Semaphore) Acquire(key string) bool {
+	if s == nil {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size <= 0 {
+		return true
+	}
+
+	if _, ok := s.holders[key]; ok {
+		return true
+	}
+
+	if len(s.holders) >= s.size {
+		s.waiting[key] = struct{}{}
+		return false
+	}
+
+	delete(s.waiting, key)
+	s.holders[key] = struct{}{}
+	return true
+}
+
+// Release gives the key slot back, and stops reporting the key as waiting.
+func (s *Semaphore) Release(key string) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.holders, key)
+	delete(s.waiting, key)
+}
+
+// Holding returns the number of keys holding a slot.
+func (s *Semaphore) Holding() int {
+	if s == nil {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.holders)
+}
+
+// Waiting returns the number of keys waiting for a slot.
+func (s *Semaphore) Waiting() int {
+	if s == nil {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.waiting)
+}
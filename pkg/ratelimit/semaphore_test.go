@@ -0,0 +1,80 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemaphore(t *testing.T) {
+	semaphore := ratelimit.NewSemaphore(2)
+
+	assert.True(t, semaphore.Acquire("demo/prod"))
+	assert.True(t, semaphore.Acquire("demo/staging"))
+	// Acquiring a held slot again is a no-op.
+	assert.True(t, semaphore.Acquire("demo/prod"))
+	assert.Equal(t, 2, semaphore.Holding())
+
+	// Other keys wait for a slot.
+	assert.False(t, semaphore.Acquire("demo/dev"))
+	assert.False(t, semaphore.Acquire("demo/qa"))
+	assert.Equal(t, 2, semaphore.Waiting())
+
+	semaphore.Release("demo/prod")
+	assert.True(t, semaphore.Acquire("demo/dev"))
+	assert.Equal(t, 2, semaphore.Holding())
+	assert.Equal(t, 1, semaphore.Waiting())
+
+	// Releasing a waiting key stops reporting it.
+	semaphore.Release("demo/qa")
+	assert.Equal(t, 0, semaphore.Waiting())
+}
+
+func TestSemaphoreDisabled(t *testing.T) {
+	var semaphore *ratelimit.Semaphore
+	assert.True(t, semaphore.Acquire("demo/prod"))
+	assert.Equal(t, 0, semaphore.Holding())
+	semaphore.Release("demo/prod")
+
+	semaphore = ratelimit.NewSemaphore(0)
+	for _, key := range []string{"demo/prod", "demo/staging", "demo/dev"} {
+		assert.True(t, semaphore.Acquire(key))
+	}
+	assert.Equal(t, 0, semaphore.Waiting())
+}
+
+func TestSemaphoreResize(t *testing.T) {
+	semaphore := ratelimit.NewSemaphore(1)
+
+	assert.True(t, semaphore.Acquire("demo/prod"))
+	assert.False(t, semaphore.Acquire("demo/staging"))
+
+	semaphore.Resize(2)
+	assert.True(t, semaphore.Acquire("demo/staging"))
+
+	// Keys holding a slot keep it when the semaphore shrinks.
+	semaphore.Resize(1)
+	assert.Equal(t, 2, semaphore.Holding())
+	assert.False(t, semaphore.Acquire("demo/dev"))
+
+	semaphore.Resize(0)
+	assert.True(t, semaphore.Acquire("demo/dev"))
+}
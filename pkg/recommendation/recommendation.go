@@ -0,0 +1,146 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package recommendation computes non-binding resources recommendations from the temporal services usage.
+package recommendation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	serviceLabel = "app.kubernetes.io/component"
+
+	// cpuStep and memoryStep are the granularity of the recommended requests.
+	cpuStep    = 10          // millicores
+	memoryStep = 1024 * 1024 // bytes
+)
+
+// podMetricsList is the subset of the metrics.k8s.io/v1beta1 PodMetricsList used to compute usages.
+type podMetricsList struct {
+	Items []podMetrics `json:"items"`
+}
+
+type podMetrics struct {
+	Metadata   metav1.ObjectMeta  `json:"metadata"`
+	Containers []containerMetrics `json:"containers"`
+}
+
+type containerMetrics struct {
+	Name  string              `json:"name"`
+	Usage corev1.ResourceList `json:"usage"`
+}
+
+// Usage is the resources usage of a pod.
+type Usage struct {
+	CPU    resource.Quantity
+	Memory resource.Quantity
+}
+
+// ServicesPeakUsage returns the highest usage of a single pod of each temporal service
+// from the provided metrics.k8s.io/v1beta1 PodMetricsList.
+func ServicesPeakUsage(data []byte) (map[string]Usage, error) {
+	list := &podMetricsList{}
+	if err := json.Unmarshal(data, list); err != nil {
+		return nil, fmt.Errorf("can't decode pod metrics: %w", err)
+	}
+
+	result := map[string]Usage{}
+	for _, pod := range list.Items {
+		service, ok := pod.Metadata.Labels[serviceLabel]
+		if !ok {
+			continue
+		}
+
+		usage := Usage{}
+		for _, container := range pod.Containers {
+			usage.CPU.Add(container.Usage[corev1.ResourceCPU])
+			usage.Memory.Add(container.Usage[corev1.ResourceMemory])
+		}
+
+		peak := result[service]
+		if usage.CPU.Cmp(peak.CPU) > 0 {
+			peak.CPU = usage.CPU
+		}
+		if usage.Memory.Cmp(peak.Memory) > 0 {
+			peak.Memory = usage.Memory
+		}
+		result[service] = peak
+	}
+
+	return result, nil
+}
+
+// Update merges the provided services usage sample in the previous recommendations.
+// Peaks are reset when the window of a service recommendation is over.
+func Update(previous *v1beta1.RecommendationsStatus, usage map[string]Usage, spec *v1beta1.ResourceAdvisorSpec, now time.Time) *v1beta1.RecommendationsStatus {
+	existing := map[string]v1beta1.ResourceRecommendation{}
+	if previous != nil {
+		for _, recommendation := range previous.Services {
+			existing[recommendation.Service] = recommendation
+		}
+	}
+
+	result := &v1beta1.RecommendationsStatus{
+		LastSampleTime: metav1.NewTime(now),
+	}
+
+	for service, sample := range usage {
+		recommendation, ok := existing[service]
+		if !ok || now.Sub(recommendation.WindowStart.Time) > spec.GetWindow() {
+			recommendation = v1beta1.ResourceRecommendation{
+				Service:     service,
+				WindowStart: metav1.NewTime(now),
+			}
+		}
+
+		if sample.CPU.Cmp(recommendation.PeakCPU) > 0 {
+			recommendation.PeakCPU = sample.CPU.DeepCopy()
+		}
+		if sample.Memory.Cmp(recommendation.PeakMemory) > 0 {
+			recommendation.PeakMemory = sample.Memory.DeepCopy()
+		}
+		recommendation.Samples++
+
+		margin := int64(spec.GetMargin())
+		recommendation.CPU = *resource.NewMilliQuantity(roundUp(recommendation.PeakCPU.MilliValue()*(100+margin)/100, cpuStep), resource.DecimalSI)
+		recommendation.Memory = *resource.NewQuantity(roundUp(recommendation.PeakMemory.Value()*(100+margin)/100, memoryStep), resource.BinarySI)
+
+		result.Services = append(result.Services, recommendation)
+	}
+
+	sort.Slice(result.Services, func(i, j int) bool {
+		return result.Services[i].Service < result.Services[j].Service
+	})
+
+	return result
+}
+
+func roundUp(value, step int64) int64 {
+	if value%step == 0 {
+		return value
+	}
+	return (value/step + 1) * step
+}
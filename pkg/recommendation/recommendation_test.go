@@ -0,0 +1,151 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package recommendation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const podMetricsListJSON = `{
+  "kind": "PodMetricsList",
+  "apiVersion": "metrics.k8s.io/v1beta1",
+  "items": [
+    {
+      "metadata": {"name": "prod-history-0", "labels": {"app.kubernetes.io/component": "history"}},
+      "containers": [
+        {"name": "service", "usage": {"cpu": "250m", "memory": "300Mi"}},
+        {"name": "istio-proxy", "usage": {"cpu": "10m", "memory": "40Mi"}}
+      ]
+    },
+    {
+      "metadata": {"name": "prod-history-1", "labels": {"app.kubernetes.io/component": "history"}},
+      "containers": [
+        {"name": "service", "usage": {"cpu": "400m", "memory": "200Mi"}}
+      ]
+    },
+    {
+      "metadata": {"name": "unrelated"},
+      "containers": [
+        {"name": "app", "usage": {"cpu": "4", "memory": "4Gi"}}
+      ]
+    }
+  ]
+}`
+
+func TestServicesPeakUsage(t *testing.T) {
+	usage, err := ServicesPeakUsage([]byte(podMetricsListJSON))
+	require.NoError(t, err)
+
+	require.Len(t, usage, 1)
+	history := usage["history"]
+	assert.Equal(t, int64(400), history.CPU.MilliValue())
+	assert.Zero(t, history.Memory.Cmp(resource.MustParse("340Mi")))
+
+	_, err = ServicesPeakUsage([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestUpdate(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	spec := &v1beta1.ResourceAdvisorSpec{}
+
+	sample := map[string]Usage{
+		"history": {CPU: resource.MustParse("401m"), Memory: resource.MustParse("100Mi")},
+	}
+
+	tests := map[string]struct {
+		previous            *v1beta1.RecommendationsStatus
+		expectedPeakCPU     string
+		expectedPeakMemory  string
+		expectedCPU         string
+		expectedMemory      string
+		expectedSamples     int32
+		expectedWindowStart time.Time
+	}{
+		"first sample": {
+			expectedPeakCPU:     "401m",
+			expectedPeakMemory:  "100Mi",
+			expectedCPU:         "490m",
+			expectedMemory:      "120Mi",
+			expectedSamples:     1,
+			expectedWindowStart: now,
+		},
+		"keeps previous peaks": {
+			previous: &v1beta1.RecommendationsStatus{
+				Services: []v1beta1.ResourceRecommendation{
+					{
+						Service:     "history",
+						PeakCPU:     resource.MustParse("1"),
+						PeakMemory:  resource.MustParse("50Mi"),
+						Samples:     3,
+						WindowStart: metav1.NewTime(now.Add(-time.Hour)),
+					},
+				},
+			},
+			expectedPeakCPU:     "1",
+			expectedPeakMemory:  "100Mi",
+			expectedCPU:         "1200m",
+			expectedMemory:      "120Mi",
+			expectedSamples:     4,
+			expectedWindowStart: now.Add(-time.Hour),
+		},
+		"resets peaks after the window": {
+			previous: &v1beta1.RecommendationsStatus{
+				Services: []v1beta1.ResourceRecommendation{
+					{
+						Service:     "history",
+						PeakCPU:     resource.MustParse("1"),
+						PeakMemory:  resource.MustParse("50Mi"),
+						Samples:     3,
+						WindowStart: metav1.NewTime(now.Add(-8 * 24 * time.Hour)),
+					},
+				},
+			},
+			expectedPeakCPU:     "401m",
+			expectedPeakMemory:  "100Mi",
+			expectedCPU:         "490m",
+			expectedMemory:      "120Mi",
+			expectedSamples:     1,
+			expectedWindowStart: now,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			result := Update(test.previous, sample, spec, now)
+
+			require.Len(tt, result.Services, 1)
+			recommendation := result.Services[0]
+			assert.Equal(tt, "history", recommendation.Service)
+			assert.Zero(tt, recommendation.PeakCPU.Cmp(resource.MustParse(test.expectedPeakCPU)))
+			assert.Zero(tt, recommendation.PeakMemory.Cmp(resource.MustParse(test.expectedPeakMemory)))
+			assert.Zero(tt, recommendation.CPU.Cmp(resource.MustParse(test.expectedCPU)), recommendation.CPU.String())
+			assert.Zero(tt, recommendation.Memory.Cmp(resource.MustParse(test.expectedMemory)), recommendation.Memory.String())
+			assert.Equal(tt, test.expectedSamples, recommendation.Samples)
+			assert.True(tt, recommendation.WindowStart.Time.Equal(test.expectedWindowStart))
+			assert.True(tt, result.LastSampleTime.Time.Equal(now))
+		})
+	}
+}
@@ -0,0 +1,358 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	dockerHubRegistry         = "docker.io"
+	dockerHubRegistryEndpoint = "registry-1.docker.io"
+)
+
+// manifestMediaTypes are the manifest media types accepted when resolving digests.
+// Image indexes are listed first so multi-arch images resolve to their index digest.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// Reference is a parsed image reference.
+type Reference struct {
+	// Name is the image name as provided, without its tag or digest.
+	Name string
+	// Registry is the registry host.
+	Registry string
+	// Repository is the repository path in the registry.
+	Repository string
+	// Tag is the image tag.
+	Tag string
+	// Digest is the image digest.
+	Digest string
+}
+
+// ParseReference parses the provided image reference.
+// Images without registry are pulled from Docker Hub and images without tag or digest use the "latest" tag.
+func ParseReference(image string) (Reference, error) {
+	if image == "" {
+		return Reference{}, errors.New("empty image reference")
+	}
+
+	ref := Reference{Name: image}
+
+	if name, digest, ok := strings.Cut(image, "@"); ok {
+		ref.Name = name
+		ref.Digest = digest
+	}
+
+	// A colon after the last slash separates the tag, a colon before is a registry port.
+	if i := strings.LastIndex(ref.Name, ":"); i > strings.LastIndex(ref.Name, "/") {
+		ref.Tag = ref.Name[i+1:]
+		ref.Name = ref.Name[:i]
+	}
+
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	ref.Registry = dockerHubRegistry
+	ref.Repository = ref.Name
+	if first, rest, ok := strings.Cut(ref.Name, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry = first
+		ref.Repository = rest
+	}
+
+	if ref.Registry == dockerHubRegistry && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+
+	return ref, nil
+}
+
+// Credentials are the credentials used to authenticate against a registry.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+type dockerConfig struct {
+	Auths map[string]struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	} `json:"auths"`
+}
+
+// CredentialsFromDockerConfig returns the registries credentials from the content of
+// a kubernetes.io/dockerconfigjson secret.
+func CredentialsFromDockerConfig(data []byte) (map[string]Credentials, error) {
+	config := &dockerConfig{}
+	err := json.Unmarshal(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode docker config: %w", err)
+	}
+
+	result := map[string]Credentials{}
+	for server, auth := range config.Auths {
+		credentials := Credentials{
+			Username: auth.Username,
+			Password: auth.Password,
+		}
+
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("can't decode docker config auth for %s: %w", server, err)
+			}
+			username, password, _ := strings.Cut(string(decoded), ":")
+			credentials = Credentials{
+				Username: username,
+				Password: password,
+			}
+		}
+
+		result[registryHost(server)] = credentials
+	}
+
+	return result, nil
+}
+
+// registryHost returns the registry host of a docker config server entry.
+func registryHost(server string) string {
+	if u, err := url.Parse(server); err == nil && u.Host != "" {
+		server = u.Host
+	}
+	server = strings.TrimSuffix(server, "/")
+
+	switch server {
+	case "index.docker.io", dockerHubRegistryEndpoint:
+		return dockerHubRegistry
+	}
+	return server
+}
+
+// Client resolves image tags to digests using the registries HTTP API.
+type Client struct {
+	credentials map[string]Credentials
+	httpClient  *http.Client
+}
+
+// NewClient returns a new registry client using the provided credentials, indexed by registry host.
+func NewClient(credentials map[string]Credentials) *Client {
+	return &Client{
+		credentials: credentials,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// ResolveDigest returns the provided image reference pinned to the digest of its tag.
+// References already pinned to a digest are returned as is.
+func (c *Client) ResolveDigest(ctx context.Context, image string) (string, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+
+	if ref.Digest != "" {
+		return image, nil
+	}
+
+	resp, err := c.get(ctx, ref, http.MethodHead, "manifests/"+ref.Tag, manifestMediaTypes)
+	if err != nil {
+		return "", fmt.Errorf("can't resolve %s digest: %w", image, err)
+	}
+	resp.Body.Close()
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("can't resolve %s digest: registry didn't return the manifest digest", image)
+	}
+
+	return fmt.Sprintf("%s@%s", ref.Name, digest), nil
+}
+
+// endpoint returns the registry API endpoint.
+// Local registries are reached over plain HTTP.
+func (c *Client) endpoint(registry string) string {
+	if registry == dockerHubRegistry {
+		return "https://" + dockerHubRegistryEndpoint
+	}
+
+	host := registry
+	if h, _, err := net.SplitHostPort(registry); err == nil {
+		host = h
+	}
+	if host == "localhost" || net.ParseIP(host).IsLoopback() {
+		return "http://" + registry
+	}
+
+	return "https://" + registry
+}
+
+// get sends a request for the provided path of the reference repository, authenticating if the registry requires it.
+// It returns an error if the registry doesn't answer with a 200 status code.
+func (c *Client) get(ctx context.Context, ref Reference, method, path string, accept []string) (*http.Response, error) {
+	target := fmt.Sprintf("%s/v2/%s/%s", c.endpoint(ref.Registry), ref.Repository, path)
+
+	resp, err := c.do(ctx, method, target, accept, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+
+		authorization, err := c.authorize(ctx, ref, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return nil, fmt.Errorf("can't authenticate to %s: %w", ref.Registry, err)
+		}
+
+		resp, err = c.do(ctx, method, target, accept, authorization)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	return resp, nil
+}
+
+func (c *Client) do(ctx context.Context, method, target string, accept []string, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	if len(accept) > 0 {
+		req.Header.Set("Accept", strings.Join(accept, ","))
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// StatusError is returned when the registry answers with an unexpected status code.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d", e.StatusCode)
+}
+
+type tokenResponse struct {
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
+}
+
+// authorize returns the Authorization header answering the provided registry challenge.
+func (c *Client) authorize(ctx context.Context, ref Reference, challenge string) (string, error) {
+	credentials, hasCredentials := c.credentials[ref.Registry]
+
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "basic":
+		if !hasCredentials {
+			return "", errors.New("registry requires credentials")
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials.Username+":"+credentials.Password)), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("invalid authentication realm %q", params["realm"])
+	}
+
+	query := realm.Query()
+	if service, ok := params["service"]; ok {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", ref.Repository))
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	if hasCredentials {
+		req.SetBasicAuth(credentials.Username, credentials.Password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected token status code %d", resp.StatusCode)
+	}
+
+	token := &tokenResponse{}
+	err = json.NewDecoder(resp.Body).Decode(token)
+	if err != nil {
+		return "", fmt.Errorf("can't decode token: %w", err)
+	}
+
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+
+	return "Bearer " + token.Token, nil
+}
+
+// parseChallenge parses a WWW-Authenticate header, returning its lower-cased scheme and parameters.
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+
+	params := map[string]string{}
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, ", "), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+
+	return strings.ToLower(scheme), params
+}
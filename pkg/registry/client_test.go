@@ -0,0 +1,138 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registry_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := map[string]struct {
+		image    string
+		expected registry.Reference
+	}{
+		"docker hub official image": {
+			image: "busybox",
+			expected: registry.Reference{
+				Name:       "busybox",
+				Registry:   "docker.io",
+				Repository: "library/busybox",
+				Tag:        "latest",
+			},
+		},
+		"docker hub image with tag": {
+			image: "temporalio/server:1.23.0",
+			expected: registry.Reference{
+				Name:       "temporalio/server",
+				Registry:   "docker.io",
+				Repository: "temporalio/server",
+				Tag:        "1.23.0",
+			},
+		},
+		"registry with port": {
+			image: "registry.example.com:5000/temporal/server:1.23.0",
+			expected: registry.Reference{
+				Name:       "registry.example.com:5000/temporal/server",
+				Registry:   "registry.example.com:5000",
+				Repository: "temporal/server",
+				Tag:        "1.23.0",
+			},
+		},
+		"digest": {
+			image: "ghcr.io/temporal/server@sha256:abcd",
+			expected: registry.Reference{
+				Name:       "ghcr.io/temporal/server",
+				Registry:   "ghcr.io",
+				Repository: "temporal/server",
+				Digest:     "sha256:abcd",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			ref, err := registry.ParseReference(test.image)
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expected, ref)
+		})
+	}
+}
+
+func TestCredentialsFromDockerConfig(t *testing.T) {
+	credentials, err := registry.CredentialsFromDockerConfig([]byte(`{"auths":{
+		"https://index.docker.io/v1/":{"auth":"dXNlcjpwYXNz"},
+		"ghcr.io":{"username":"bot","password":"token"}
+	}}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]registry.Credentials{
+		"docker.io": {Username: "user", Password: "pass"},
+		"ghcr.io":   {Username: "bot", Password: "token"},
+	}, credentials)
+}
+
+func TestResolveDigest(t *testing.T) {
+	const digest = "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			username, password, ok := r.BasicAuth()
+			if !ok || username != "user" || password != "pass" || r.URL.Query().Get("scope") != "repository:temporal/server:pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token":"secret"}`))
+		case "/v2/temporal/server/manifests/1.23.0":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="`+server.URL+`/token",service="registry"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", digest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	client := registry.NewClient(map[string]registry.Credentials{
+		host: {Username: "user", Password: "pass"},
+	})
+
+	resolved, err := client.ResolveDigest(context.Background(), host+"/temporal/server:1.23.0")
+	require.NoError(t, err)
+	assert.Equal(t, host+"/temporal/server@"+digest, resolved)
+
+	pinned, err := client.ResolveDigest(context.Background(), resolved)
+	require.NoError(t, err)
+	assert.Equal(t, resolved, pinned)
+
+	_, err = client.ResolveDigest(context.Background(), host+"/temporal/server:unknown")
+	assert.Error(t, err)
+}
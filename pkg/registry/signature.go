@@ -0,0 +1,192 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registry
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// cosignSignatureAnnotation is the signature layer annotation holding the base64 encoded signature of its payload.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// maxSignaturePayloadSize is the maximum size of a signature payload read from the registry.
+	maxSignaturePayloadSize = 1 << 20
+)
+
+// ParsePublicKey parses a PEM encoded ECDSA, RSA or Ed25519 public key, as generated by cosign.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse public key: %w", err)
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+type signatureManifest struct {
+	Layers []struct {
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"layers"`
+}
+
+type signaturePayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// VerifySignature verifies the provided digest pinned image has a cosign signature made with the provided key.
+// Signatures are looked up using the cosign tag convention: "<algorithm>-<hex>.sig" in the image repository.
+func (c *Client) VerifySignature(ctx context.Context, image string, key crypto.PublicKey) error {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return err
+	}
+
+	if ref.Digest == "" {
+		return fmt.Errorf("can't verify %s: image is not pinned to a digest", image)
+	}
+
+	resp, err := c.get(ctx, ref, http.MethodGet, "manifests/"+strings.Replace(ref.Digest, ":", "-", 1)+".sig", manifestMediaTypes)
+	if err != nil {
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("no signature found for %s", image)
+		}
+		return fmt.Errorf("can't get %s signatures: %w", image, err)
+	}
+	defer resp.Body.Close()
+
+	manifest := &signatureManifest{}
+	err = json.NewDecoder(resp.Body).Decode(manifest)
+	if err != nil {
+		return fmt.Errorf("can't decode %s signatures: %w", image, err)
+	}
+
+	for _, layer := range manifest.Layers {
+		signature, ok := layer.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+
+		payload, err := c.getBlob(ctx, ref, layer.Digest)
+		if err != nil {
+			return fmt.Errorf("can't get %s signature payload: %w", image, err)
+		}
+
+		if verifyPayload(payload, signature, key, ref.Digest) == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no signature of %s matches the provided public key", image)
+}
+
+// getBlob returns the content of the provided blob, checking it matches its digest.
+func (c *Client) getBlob(ctx context.Context, ref Reference, digest string) ([]byte, error) {
+	algorithm, expected, ok := strings.Cut(digest, ":")
+	if !ok || algorithm != "sha256" {
+		return nil, fmt.Errorf("unsupported blob digest %q", digest)
+	}
+
+	resp, err := c.get(ctx, ref, http.MethodGet, "blobs/"+digest, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxSignaturePayloadSize))
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != expected {
+		return nil, fmt.Errorf("blob content doesn't match digest %s", digest)
+	}
+
+	return content, nil
+}
+
+// verifyPayload verifies the base64 encoded signature of the payload and that the payload signs the expected digest.
+func verifyPayload(payload []byte, signature string, key crypto.PublicKey, digest string) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("can't decode signature: %w", err)
+	}
+
+	sum := sha256.Sum256(payload)
+
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, sum[:], sig) {
+			return errors.New("invalid signature")
+		}
+	case *rsa.PublicKey:
+		err := rsa.VerifyPKCS1v15(k, crypto.SHA256, sum[:], sig)
+		if err != nil {
+			return fmt.Errorf("invalid signature: %w", err)
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, payload, sig) {
+			return errors.New("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+
+	result := &signaturePayload{}
+	err = json.Unmarshal(payload, result)
+	if err != nil {
+		return fmt.Errorf("can't decode signature payload: %w", err)
+	}
+
+	if result.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature payload is for digest %s", result.Critical.Image.DockerManifestDigest)
+	}
+
+	return nil
+}
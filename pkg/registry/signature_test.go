@@ -0,0 +1,112 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registry_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	const digest = "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+	signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"temporal/server"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, digest))
+	payloadSum := sha256.Sum256(payload)
+	payloadDigest := "sha256:" + hex.EncodeToString(payloadSum[:])
+	signature, err := ecdsa.SignASN1(rand.Reader, signingKey, payloadSum[:])
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/temporal/server/manifests/sha256-2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae.sig":
+			_, _ = fmt.Fprintf(w, `{"layers":[{"digest":%q,"annotations":{"dev.cosignproject.cosign/signature":%q}}]}`,
+				payloadDigest, base64.StdEncoding.EncodeToString(signature))
+		case "/v2/temporal/server/blobs/" + payloadDigest:
+			_, _ = w.Write(payload)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	client := registry.NewClient(nil)
+
+	tests := map[string]struct {
+		image       string
+		key         *ecdsa.PublicKey
+		expectedErr string
+	}{
+		"valid signature": {
+			image: host + "/temporal/server@" + digest,
+			key:   &signingKey.PublicKey,
+		},
+		"signed with another key": {
+			image:       host + "/temporal/server@" + digest,
+			key:         &otherKey.PublicKey,
+			expectedErr: "no signature of",
+		},
+		"unsigned image": {
+			image:       host + "/temporal/server@sha256:fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9",
+			key:         &signingKey.PublicKey,
+			expectedErr: "no signature found",
+		},
+		"image not pinned": {
+			image:       host + "/temporal/server:1.23.0",
+			key:         &signingKey.PublicKey,
+			expectedErr: "not pinned to a digest",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			der, err := x509.MarshalPKIXPublicKey(test.key)
+			require.NoError(tt, err)
+			key, err := registry.ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+			require.NoError(tt, err)
+
+			err = client.VerifySignature(context.Background(), test.image, key)
+			if test.expectedErr != "" {
+				assert.ErrorContains(tt, err, test.expectedErr)
+				return
+			}
+			assert.NoError(tt, err)
+		})
+	}
+}
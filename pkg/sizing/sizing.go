@@ -0,0 +1,108 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package sizing checks that the cluster history shards, replicas and resources are mutually sensible.
+// The thresholds are derived from the temporal server benchmarks: a history pod handles about
+// 512 shards per CPU core and keeps a few MiB of caches per owned shard.
+package sizing
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// MaxShardsPerHistoryPod is the number of shards above which a single history pod
+	// spends most of its time acquiring and renewing shards.
+	MaxShardsPerHistoryPod = 2048
+	// MilliCPUPerShard is the CPU needed by a history pod for each shard it owns.
+	MilliCPUPerShard = 2
+	// MemoryPerShard is the memory needed by a history pod for each shard it owns.
+	MemoryPerShard = 2 * 1024 * 1024
+)
+
+// Check returns the sizing issues of the provided cluster.
+// Resources are only checked when the history service sets them.
+func Check(cluster *v1beta1.TemporalCluster) []string {
+	var issues []string
+
+	shards := int64(cluster.Spec.NumHistoryShards)
+	if shards == 0 || cluster.Spec.Services == nil || cluster.Spec.Services.History == nil {
+		return issues
+	}
+
+	history := cluster.Spec.Services.History
+	replicas := int64(1)
+	if history.Replicas != nil {
+		replicas = int64(*history.Replicas)
+	}
+	if replicas == 0 {
+		return issues
+	}
+
+	if replicas > shards {
+		issues = append(issues,
+			fmt.Sprintf("spec.services.history.replicas is %d but the cluster has %d history shards: %d history pods won't own any shard.", replicas, shards, replicas-shards),
+		)
+	}
+
+	// A pod owns at most the ceiling of shards/replicas shards.
+	shardsPerPod := (shards + replicas - 1) / replicas
+
+	if shardsPerPod > MaxShardsPerHistoryPod {
+		issues = append(issues,
+			fmt.Sprintf("each history pod owns %d shards (%d shards for %d replicas), more than the recommended %d: increase spec.services.history.replicas to at least %d.", shardsPerPod, shards, replicas, MaxShardsPerHistoryPod, (shards+MaxShardsPerHistoryPod-1)/MaxShardsPerHistoryPod),
+		)
+	}
+
+	resources := history.MemoryProtection.GetResources(history.Resources)
+
+	// The CPU request is what the pod is guaranteed to get, while the memory limit is what it can use before being OOM killed.
+	if cpu := quantity(corev1.ResourceCPU, resources.Requests, resources.Limits); cpu != nil {
+		required := resource.NewMilliQuantity(shardsPerPod*MilliCPUPerShard, resource.DecimalSI)
+		if cpu.Cmp(*required) < 0 {
+			issues = append(issues,
+				fmt.Sprintf("history pods have %s CPU for %d shards each: at least %s CPU is recommended, increase spec.services.history.resources or spec.services.history.replicas.", cpu.String(), shardsPerPod, required.String()),
+			)
+		}
+	}
+
+	if memory := quantity(corev1.ResourceMemory, resources.Limits, resources.Requests); memory != nil {
+		required := resource.NewQuantity(shardsPerPod*MemoryPerShard, resource.BinarySI)
+		if memory.Cmp(*required) < 0 {
+			issues = append(issues,
+				fmt.Sprintf("history pods have %s memory for %d shards each: at least %s memory is recommended, increase spec.services.history.resources or spec.services.history.replicas.", memory.String(), shardsPerPod, required.String()),
+			)
+		}
+	}
+
+	return issues
+}
+
+// quantity returns the amount of the provided resource from the first list setting it.
+// It returns nil when none of the lists sets it.
+func quantity(name corev1.ResourceName, lists ...corev1.ResourceList) *resource.Quantity {
+	for _, list := range lists {
+		if q, ok := list[name]; ok {
+			return &q
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sizing_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/sizing"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func TestCheck(t *testing.T) {
+	tests := map[string]struct {
+		shards    int32
+		replicas  int32
+		resources corev1.ResourceRequirements
+		expected  []string
+	}{
+		"sensible sizing": {
+			shards:   512,
+			replicas: 2,
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+			expected: nil,
+		},
+		"no resources": {
+			shards:   4096,
+			replicas: 2,
+			expected: nil,
+		},
+		"single small history pod with many shards": {
+			shards:   4096,
+			replicas: 1,
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+			expected: []string{
+				"each history pod owns 4096 shards (4096 shards for 1 replicas), more than the recommended 2048: increase spec.services.history.replicas to at least 2.",
+				"history pods have 500m CPU for 4096 shards each: at least 8192m CPU is recommended, increase spec.services.history.resources or spec.services.history.replicas.",
+				"history pods have 1Gi memory for 4096 shards each: at least 8Gi memory is recommended, increase spec.services.history.resources or spec.services.history.replicas.",
+			},
+		},
+		"memory limit preferred over request": {
+			shards:   1024,
+			replicas: 1,
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+			expected: nil,
+		},
+		"more replicas than shards": {
+			shards:   4,
+			replicas: 6,
+			expected: []string{
+				"spec.services.history.replicas is 6 but the cluster has 4 history shards: 2 history pods won't own any shard.",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				Spec: v1beta1.TemporalClusterSpec{
+					NumHistoryShards: test.shards,
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							Replicas:  ptr.To(test.replicas),
+							Resources: test.resources,
+						},
+					},
+				},
+			}
+
+			assert.Equal(tt, test.expected, sizing.Check(cluster))
+		})
+	}
+}
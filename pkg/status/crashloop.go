@@ -0,0 +1,126 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.temporal.io/server/common/primitives"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// RestartStormThreshold is the number of restarts from which a not ready container is considered crash looping.
+	RestartStormThreshold = 3
+
+	// maxCrashMessageLength is the maximum length of a container termination message reported in conditions.
+	maxCrashMessageLength = 512
+
+	serviceLabel = "app.kubernetes.io/component"
+)
+
+// ContainerCrash describes a crash looping container of a temporal service pod.
+type ContainerCrash struct {
+	Service      string
+	Pod          string
+	Container    string
+	RestartCount int32
+	// Reason and Message are the reason and message of the container last termination.
+	Reason  string
+	Message string
+}
+
+// String returns a human readable description of the crash.
+func (c ContainerCrash) String() string {
+	description := fmt.Sprintf("%s: container %s of pod %s restarted %d times", c.Service, c.Container, c.Pod, c.RestartCount)
+	if c.Reason != "" {
+		description += fmt.Sprintf(", last terminated with reason %s", c.Reason)
+	}
+	if c.Message != "" {
+		description += fmt.Sprintf(": %s", c.Message)
+	}
+	return description
+}
+
+// CrashLoopingContainers returns the crash looping containers of the provided temporal services pods.
+// A container is crash looping when it's waiting in CrashLoopBackOff, or when it's not ready and has restarted
+// at least RestartStormThreshold times. Only the first crash looping container of each service is returned.
+func CrashLoopingContainers(pods []corev1.Pod) []ContainerCrash {
+	services := map[string]bool{
+		string(primitives.FrontendService):         true,
+		string(primitives.InternalFrontendService): true,
+		string(primitives.HistoryService):          true,
+		string(primitives.MatchingService):         true,
+		string(primitives.WorkerService):           true,
+	}
+
+	sorted := append([]corev1.Pod{}, pods...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	result := []ContainerCrash{}
+	for _, pod := range sorted {
+		service := pod.Labels[serviceLabel]
+		if !services[service] {
+			continue
+		}
+
+		for _, container := range pod.Status.ContainerStatuses {
+			if !isCrashLooping(container) {
+				continue
+			}
+
+			crash := ContainerCrash{
+				Service:      service,
+				Pod:          pod.Name,
+				Container:    container.Name,
+				RestartCount: container.RestartCount,
+			}
+			if terminated := container.LastTerminationState.Terminated; terminated != nil {
+				crash.Reason = terminated.Reason
+				crash.Message = truncateCrashMessage(terminated.Message)
+			}
+
+			result = append(result, crash)
+			// Report a single container per service to keep the condition message short.
+			services[service] = false
+			break
+		}
+	}
+
+	return result
+}
+
+func isCrashLooping(container corev1.ContainerStatus) bool {
+	if container.State.Waiting != nil && container.State.Waiting.Reason == "CrashLoopBackOff" {
+		return true
+	}
+	return !container.Ready && container.RestartCount >= RestartStormThreshold
+}
+
+// truncateCrashMessage keeps the end of the provided message, where the fatal error is usually logged.
+func truncateCrashMessage(message string) string {
+	message = strings.TrimSpace(message)
+	if len(message) <= maxCrashMessageLength {
+		return message
+	}
+	return "..." + strings.ToValidUTF8(message[len(message)-maxCrashMessageLength:], "")
+}
@@ -0,0 +1,129 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status_test
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/status"
+	"github.com/stretchr/testify/assert"
+)
+
+func servicePod(name, service string, containers ...corev1.ContainerStatus) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"app.kubernetes.io/component": service},
+		},
+		Status: corev1.PodStatus{ContainerStatuses: containers},
+	}
+}
+
+func TestCrashLoopingContainers(t *testing.T) {
+	crashLooping := corev1.ContainerStatus{
+		Name:         "service",
+		RestartCount: 5,
+		State: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+		},
+		LastTerminationState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{
+				Reason:  "Error",
+				Message: "unable to connect to the database: authentication failed\n",
+			},
+		},
+	}
+	restarting := corev1.ContainerStatus{Name: "service", RestartCount: 3}
+	healthy := corev1.ContainerStatus{Name: "service", Ready: true, RestartCount: 4}
+
+	tests := map[string]struct {
+		pods     []corev1.Pod
+		expected []status.ContainerCrash
+	}{
+		"no pods": {
+			expected: []status.ContainerCrash{},
+		},
+		"healthy pods": {
+			pods: []corev1.Pod{
+				servicePod("history-0", "history", healthy),
+			},
+			expected: []status.ContainerCrash{},
+		},
+		"crash loop back off": {
+			pods: []corev1.Pod{
+				servicePod("history-0", "history", crashLooping),
+			},
+			expected: []status.ContainerCrash{
+				{
+					Service:      "history",
+					Pod:          "history-0",
+					Container:    "service",
+					RestartCount: 5,
+					Reason:       "Error",
+					Message:      "unable to connect to the database: authentication failed",
+				},
+			},
+		},
+		"restart storm": {
+			pods: []corev1.Pod{
+				servicePod("matching-0", "matching", restarting),
+			},
+			expected: []status.ContainerCrash{
+				{Service: "matching", Pod: "matching-0", Container: "service", RestartCount: 3},
+			},
+		},
+		"one container per service": {
+			pods: []corev1.Pod{
+				servicePod("history-1", "history", crashLooping),
+				servicePod("history-0", "history", restarting),
+				servicePod("ui-0", "ui", crashLooping),
+			},
+			expected: []status.ContainerCrash{
+				{Service: "history", Pod: "history-0", Container: "service", RestartCount: 3},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, status.CrashLoopingContainers(test.pods))
+		})
+	}
+}
+
+func TestCrashLoopingContainersTruncatesMessage(t *testing.T) {
+	pod := servicePod("frontend-0", "frontend", corev1.ContainerStatus{
+		Name:         "service",
+		RestartCount: 10,
+		LastTerminationState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{
+				Message: strings.Repeat("a", 1000) + "fatal error",
+			},
+		},
+	})
+
+	crashes := status.CrashLoopingContainers([]corev1.Pod{pod})
+	assert.Len(t, crashes, 1)
+	assert.True(t, strings.HasPrefix(crashes[0].Message, "..."))
+	assert.True(t, strings.HasSuffix(crashes[0].Message, "fatal error"))
+	assert.Len(t, crashes[0].Message, 515)
+}
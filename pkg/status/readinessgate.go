@@ -0,0 +1,64 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReadinessGateDue reports whether the operator should set the provided readiness gate condition on the pod.
+// The condition is due once the pod containers have been ready for the provided delay. When the pod
+// declares the gate but it isn't due yet, the minimum remaining wait is returned: the delay itself
+// while the containers aren't ready.
+func ReadinessGateDue(pod *corev1.Pod, conditionType corev1.PodConditionType, delay time.Duration, now time.Time) (bool, time.Duration) {
+	if pod.DeletionTimestamp != nil || !hasReadinessGate(pod, conditionType) {
+		return false, 0
+	}
+
+	var containersReady *corev1.PodCondition
+	for i, condition := range pod.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return false, 0
+		}
+		if condition.Type == corev1.ContainersReady {
+			containersReady = &pod.Status.Conditions[i]
+		}
+	}
+
+	if containersReady == nil || containersReady.Status != corev1.ConditionTrue {
+		return false, delay
+	}
+
+	wait := containersReady.LastTransitionTime.Add(delay).Sub(now)
+	if wait > 0 {
+		return false, wait
+	}
+
+	return true, 0
+}
+
+func hasReadinessGate(pod *corev1.Pod, conditionType corev1.PodConditionType) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == conditionType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status_test
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/status"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadinessGateDue(t *testing.T) {
+	const gate corev1.PodConditionType = "temporal.io/traffic-ready"
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	gatedPod := func(conditions ...corev1.PodCondition) *corev1.Pod {
+		return &corev1.Pod{
+			Spec:   corev1.PodSpec{ReadinessGates: []corev1.PodReadinessGate{{ConditionType: gate}}},
+			Status: corev1.PodStatus{Conditions: conditions},
+		}
+	}
+	containersReady := func(status corev1.ConditionStatus, since time.Duration) corev1.PodCondition {
+		return corev1.PodCondition{
+			Type:               corev1.ContainersReady,
+			Status:             status,
+			LastTransitionTime: metav1.NewTime(now.Add(-since)),
+		}
+	}
+
+	tests := map[string]struct {
+		pod          *corev1.Pod
+		expectedDue  bool
+		expectedWait time.Duration
+	}{
+		"no readiness gate": {
+			pod: &corev1.Pod{Status: corev1.PodStatus{Conditions: []corev1.PodCondition{containersReady(corev1.ConditionTrue, time.Hour)}}},
+		},
+		"containers not ready": {
+			pod:          gatedPod(containersReady(corev1.ConditionFalse, time.Hour)),
+			expectedWait: 30 * time.Second,
+		},
+		"containers ready for less than the delay": {
+			pod:          gatedPod(containersReady(corev1.ConditionTrue, 10*time.Second)),
+			expectedWait: 20 * time.Second,
+		},
+		"containers ready for the delay": {
+			pod:         gatedPod(containersReady(corev1.ConditionTrue, 30*time.Second)),
+			expectedDue: true,
+		},
+		"gate already set": {
+			pod: gatedPod(
+				containersReady(corev1.ConditionTrue, time.Hour),
+				corev1.PodCondition{Type: gate, Status: corev1.ConditionTrue},
+			),
+		},
+		"terminating pod": {
+			pod: func() *corev1.Pod {
+				pod := gatedPod(containersReady(corev1.ConditionTrue, time.Hour))
+				pod.DeletionTimestamp = &metav1.Time{Time: now}
+				return pod
+			}(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			due, wait := status.ReadinessGateDue(test.pod, gate, 30*time.Second, now)
+			assert.Equal(tt, test.expectedDue, due)
+			assert.Equal(tt, test.expectedWait, wait)
+		})
+	}
+}
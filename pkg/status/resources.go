@@ -0,0 +1,96 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/alexandrevilain/controller-tools/pkg/resource"
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ManagedResources returns the status of the provided reconciled objects, sorted by kind and name.
+// Objects are healthy when their kstatus is current, like ready deployments or issued certificates.
+func ManagedResources(scheme *runtime.Scheme, objects []client.Object) ([]v1beta1.ManagedResourceStatus, error) {
+	result := make([]v1beta1.ManagedResourceStatus, 0, len(objects))
+	for _, object := range objects {
+		gvk, err := apiutil.GVKForObject(object, scheme)
+		if err != nil {
+			return nil, err
+		}
+
+		status, err := resource.GetStatus(object)
+		if err != nil {
+			return nil, err
+		}
+
+		hash, err := AppliedHash(object)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, v1beta1.ManagedResourceStatus{
+			APIVersion:      gvk.GroupVersion().String(),
+			Kind:            gvk.Kind,
+			Name:            object.GetName(),
+			Namespace:       object.GetNamespace(),
+			Healthy:         status.Ready,
+			LastAppliedHash: hash,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// AppliedHash returns the hash of the desired state of the provided object: its labels, annotations and content,
+// without its status and the metadata managed by the apiserver.
+func AppliedHash(object client.Object) (string, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return "", err
+	}
+
+	delete(content, "status")
+	delete(content, "apiVersion")
+	delete(content, "kind")
+	content["metadata"] = map[string]interface{}{
+		"labels":      object.GetLabels(),
+		"annotations": object.GetAnnotations(),
+	}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
@@ -0,0 +1,98 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status_test
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestManagedResources(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	ready := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-history", Namespace: "temporal", Generation: 1},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](1)},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           1,
+			UpdatedReplicas:    1,
+			ReadyReplicas:      1,
+			AvailableReplicas:  1,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+				{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "NewReplicaSetAvailable"},
+			},
+		},
+	}
+	progressing := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-frontend", Namespace: "temporal", Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](1)},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: 1},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-config", Namespace: "temporal"},
+		Data:       map[string]string{"config_template.yaml": "log: {}"},
+	}
+
+	resources, err := status.ManagedResources(scheme, []client.Object{ready, progressing, configMap})
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+
+	assert.Equal(t, v1beta1.ManagedResourceStatus{APIVersion: "v1", Kind: "ConfigMap", Name: "prod-config", Namespace: "temporal", Healthy: true, LastAppliedHash: resources[0].LastAppliedHash}, resources[0])
+	assert.Equal(t, "apps/v1", resources[1].APIVersion)
+	assert.Equal(t, "prod-frontend", resources[1].Name)
+	assert.False(t, resources[1].Healthy)
+	assert.Equal(t, "prod-history", resources[2].Name)
+	assert.True(t, resources[2].Healthy)
+}
+
+func TestAppliedHash(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-config", Namespace: "temporal", ResourceVersion: "1"},
+		Data:       map[string]string{"key": "value"},
+	}
+
+	hash, err := status.AppliedHash(configMap)
+	require.NoError(t, err)
+	assert.Len(t, hash, 16)
+
+	// Metadata managed by the apiserver doesn't change the hash.
+	configMap.ResourceVersion = "2"
+	unchanged, err := status.AppliedHash(configMap)
+	require.NoError(t, err)
+	assert.Equal(t, hash, unchanged)
+
+	configMap.Data["key"] = "other"
+	changed, err := status.AppliedHash(configMap)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+}
@@ -103,3 +103,37 @@ func ReconciledObjectsToServiceStatuses(c *v1beta1.TemporalCluster, objects []cl
 
 	return result, nil
 }
+
+// DeploymentsReady returns true if all the provided deployments are found in the list
+// of reconciled objects and are ready.
+func DeploymentsReady(c *v1beta1.TemporalCluster, objects []client.Object, names []string) (bool, error) {
+	for _, name := range names {
+		found := false
+		for _, object := range objects {
+			if object.GetObjectKind().GroupVersionKind() != deployGVK {
+				continue
+			}
+
+			if object.GetName() != name || object.GetNamespace() != c.GetNamespace() {
+				continue
+			}
+
+			status, err := resource.GetStatus(object)
+			if err != nil {
+				return false, err
+			}
+
+			if !status.Ready {
+				return false, nil
+			}
+
+			found = true
+		}
+
+		if !found {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -317,3 +317,89 @@ func TestReconciledObjectsToServiceStatuses(t *testing.T) {
 		})
 	}
 }
+
+func TestDeploymentsReady(t *testing.T) {
+	cluster := &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+	}
+
+	readyDeployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-frontend-green",
+			Namespace: "default",
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			UpdatedReplicas:    1,
+			ReadyReplicas:      1,
+			AvailableReplicas:  1,
+			Replicas:           1,
+			Conditions: []appsv1.DeploymentCondition{
+				{
+					Type:   appsv1.DeploymentAvailable,
+					Status: corev1.ConditionTrue,
+				},
+				{
+					Type:   appsv1.DeploymentProgressing,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	notReadyDeployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-history-green",
+			Namespace: "default",
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			UpdatedReplicas:    1,
+			ReadyReplicas:      0,
+			AvailableReplicas:  0,
+			Replicas:           1,
+		},
+	}
+
+	tests := map[string]struct {
+		objects  []client.Object
+		names    []string
+		expected bool
+	}{
+		"all deployments ready": {
+			objects:  []client.Object{readyDeployment, notReadyDeployment},
+			names:    []string{"test-frontend-green"},
+			expected: true,
+		},
+		"one deployment not ready": {
+			objects:  []client.Object{readyDeployment, notReadyDeployment},
+			names:    []string{"test-frontend-green", "test-history-green"},
+			expected: false,
+		},
+		"deployment not found": {
+			objects:  []client.Object{readyDeployment},
+			names:    []string{"test-frontend-green", "test-matching-green"},
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			ready, err := status.DeploymentsReady(cluster, test.objects, test.names)
+			assert.NoError(tt, err)
+
+			assert.Equal(tt, test.expected, ready)
+		})
+	}
+}
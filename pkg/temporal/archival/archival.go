@@ -71,12 +71,14 @@ func S3ArchiverToTemporalS3Archiver(a *v1beta1.S3Archiver) *config.S3Archiver {
 	}
 }
 
-func GCSArchiverToTemporalGstorageArchiver(a *v1beta1.GCSArchiver) *config.GstorageArchiver {
+// GCSArchiverToTemporalGstorageArchiver returns the temporal config of the provided archiver,
+// reading its credentials from the provided path.
+func GCSArchiverToTemporalGstorageArchiver(a *v1beta1.GCSArchiver, credentialsPath string) *config.GstorageArchiver {
 	if a == nil {
 		return nil
 	}
 
 	return &config.GstorageArchiver{
-		CredentialsPath: a.CredentialsFileMountPath(),
+		CredentialsPath: credentialsPath,
 	}
 }
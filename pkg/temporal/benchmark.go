@@ -0,0 +1,73 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"go.temporal.io/api/workflowservice/v1"
+	temporalclient "go.temporal.io/sdk/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// GetBenchmarkSummary counts the workflows started by the omes benchmark run and computes its throughput.
+// Workflow counts rely on the cluster visibility store supporting CountWorkflowExecutions:
+// if counting fails, the summary only holds the run duration and the error message.
+func GetBenchmarkSummary(ctx context.Context, c temporalclient.Client, namespace, runID string, duration time.Duration) *v1beta1.TemporalBenchmarkSummary {
+	summary := &v1beta1.TemporalBenchmarkSummary{
+		Duration: metav1.Duration{Duration: duration},
+	}
+
+	// omes runs all the scenario workflows on the "omes-<run-id>" task queue.
+	taskQueue := fmt.Sprintf("omes-%s", runID)
+
+	completed, err := countWorkflows(ctx, c, namespace, fmt.Sprintf("TaskQueue = '%s' AND ExecutionStatus = 'Completed'", taskQueue))
+	if err != nil {
+		summary.Message = fmt.Sprintf("can't count completed workflows: %s", err)
+		return summary
+	}
+
+	failed, err := countWorkflows(ctx, c, namespace, fmt.Sprintf("TaskQueue = '%s' AND ExecutionStatus IN ('Failed', 'TimedOut')", taskQueue))
+	if err != nil {
+		summary.Message = fmt.Sprintf("can't count failed workflows: %s", err)
+		return summary
+	}
+
+	summary.CompletedWorkflows = ptr.To(completed)
+	summary.FailedWorkflows = ptr.To(failed)
+	if duration > 0 {
+		summary.WorkflowsPerSecond = fmt.Sprintf("%.2f", float64(completed)/duration.Seconds())
+	}
+
+	return summary
+}
+
+func countWorkflows(ctx context.Context, c temporalclient.Client, namespace, query string) (int64, error) {
+	resp, err := c.WorkflowService().CountWorkflowExecutions(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: namespace,
+		Query:     query,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.GetCount(), nil
+}
@@ -28,6 +28,11 @@ import (
 	"github.com/alexandrevilain/temporal-operator/internal/resource/mtls/certmanager"
 	temporallog "github.com/alexandrevilain/temporal-operator/pkg/temporal/log"
 	temporalclient "go.temporal.io/sdk/client"
+	"go.temporal.io/server/api/adminservice/v1"
+	"go.temporal.io/server/api/historyservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -90,12 +95,46 @@ func GetClusterClientTLSConfig(ctx context.Context, client client.Client, cluste
 	return tlsConfig, nil
 }
 
-func buildClusterClientOptions(ctx context.Context, client client.Client, cluster *v1beta1.TemporalCluster, overrides ...ClientOption) (temporalclient.Options, error) {
+// GetClusterInternalClientTLSConfig returns the tls configuration used to reach the internal frontend
+// of the provided temporal cluster, using the internode certificate as identity.
+func GetClusterInternalClientTLSConfig(ctx context.Context, client client.Client, cluster *v1beta1.TemporalCluster) (*tls.Config, error) {
+	secret := &corev1.Secret{}
+
+	err := client.Get(ctx, types.NamespacedName{
+		Name:      cluster.ChildResourceName(certmanager.InternodeCertificate),
+		Namespace: cluster.GetNamespace(),
+	}, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := GetTlSConfigFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig.ServerName = cluster.Spec.MTLS.Internode.ServerName(cluster)
+	return tlsConfig, nil
+}
+
+// BuildClusterClientOptions returns the temporal sdk client options to connect to the provided temporal cluster.
+func BuildClusterClientOptions(ctx context.Context, client client.Client, cluster *v1beta1.TemporalCluster, overrides ...ClientOption) (temporalclient.Options, error) {
 	opts := temporalclient.Options{
-		HostPort: cluster.GetPublicClientAddress(),
+		HostPort: cluster.GetLocalFrontendAddress(),
 		Logger:   temporallog.NewTemporalSDKLogFromContext(ctx),
 	}
-	if cluster.MTLSWithCertManagerEnabled() && cluster.Spec.MTLS.FrontendEnabled() {
+
+	// With authorization enabled, the operator acts as a system caller and uses the internal frontend.
+	if cluster.UseInternalFrontendForSystemCalls() {
+		opts.HostPort = cluster.GetInternalClientAddress()
+		if cluster.MTLSWithCertManagerEnabled() && cluster.Spec.MTLS.InternodeEnabled() {
+			tlsConfig, err := GetClusterInternalClientTLSConfig(ctx, client, cluster)
+			if err != nil {
+				return opts, fmt.Errorf("can't get cluster internal TLS config: %w", err)
+			}
+			opts.ConnectionOptions.TLS = tlsConfig
+		}
+	} else if cluster.MTLSWithCertManagerEnabled() && cluster.Spec.MTLS.FrontendEnabled() {
 		tlsConfig, err := GetClusterClientTLSConfig(ctx, client, cluster)
 		if err != nil {
 			return opts, fmt.Errorf("can't get cluster TLS config: %w", err)
@@ -127,9 +166,22 @@ func WithHostPort(hostPort string) ClientOption {
 	}
 }
 
+// WithNamespace is overriding the client namespace.
+func WithNamespace(namespace string) ClientOption {
+	return func(opts *temporalclient.Options) {
+		opts.Namespace = namespace
+	}
+}
+
 // GetClusterClient returns a temporal sdk client for the provider temporal cluster.
+// The provided cluster is the stored cluster: the TemporalClusterOverrides selecting it are applied before connecting.
 func GetClusterClient(ctx context.Context, client client.Client, cluster *v1beta1.TemporalCluster, overrides ...ClientOption) (temporalclient.Client, error) {
-	opts, err := buildClusterClientOptions(ctx, client, cluster, overrides...)
+	effective := cluster.DeepCopy()
+	if _, err := ApplyClusterOverrides(ctx, client, effective); err != nil {
+		return nil, err
+	}
+
+	opts, err := BuildClusterClientOptions(ctx, client, effective, overrides...)
 	if err != nil {
 		return nil, err
 	}
@@ -144,8 +196,14 @@ func GetClusterClient(ctx context.Context, client client.Client, cluster *v1beta
 }
 
 // GetClusterNamespaceClient returns a temporal sdk namespace client for the provider temporal cluster.
+// The provided cluster is the stored cluster: the TemporalClusterOverrides selecting it are applied before connecting.
 func GetClusterNamespaceClient(ctx context.Context, client client.Client, cluster *v1beta1.TemporalCluster, overrides ...ClientOption) (temporalclient.NamespaceClient, error) {
-	opts, err := buildClusterClientOptions(ctx, client, cluster, overrides...)
+	effective := cluster.DeepCopy()
+	if _, err := ApplyClusterOverrides(ctx, client, effective); err != nil {
+		return nil, err
+	}
+
+	opts, err := BuildClusterClientOptions(ctx, client, effective, overrides...)
 	if err != nil {
 		return nil, err
 	}
@@ -154,3 +212,53 @@ func GetClusterNamespaceClient(ctx context.Context, client client.Client, cluste
 
 	return temporalclient.NewNamespaceClient(opts)
 }
+
+// GetClusterAdminClient returns a temporal admin service client for the provided temporal cluster.
+// It reaches the same frontend as the sdk client, using the same credentials. The returned connection must be closed by the caller.
+// The provided dial options are added to the connection ones.
+func GetClusterAdminClient(ctx context.Context, client client.Client, cluster *v1beta1.TemporalCluster, dialOptions ...grpc.DialOption) (adminservice.AdminServiceClient, *grpc.ClientConn, error) {
+	opts, err := BuildClusterClientOptions(ctx, client, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transportCredentials := insecure.NewCredentials()
+	if opts.ConnectionOptions.TLS != nil {
+		transportCredentials = credentials.NewTLS(opts.ConnectionOptions.TLS)
+	}
+
+	dialOptions = append([]grpc.DialOption{grpc.WithTransportCredentials(transportCredentials)}, dialOptions...)
+
+	conn, err := grpc.NewClient(opts.HostPort, dialOptions...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't create temporal admin client: %w", err)
+	}
+
+	return adminservice.NewAdminServiceClient(conn), conn, nil
+}
+
+// GetHistoryHostClient returns a temporal history service client for the provided history host address of the provided cluster.
+// History hosts only serve the shards they own. The returned connection must be closed by the caller.
+// When internode mTLS is enabled, the connection uses the internode certificate, as history hosts only accept internode clients.
+// The provided dial options are added to the connection ones.
+func GetHistoryHostClient(ctx context.Context, client client.Client, cluster *v1beta1.TemporalCluster, address string, dialOptions ...grpc.DialOption) (historyservice.HistoryServiceClient, *grpc.ClientConn, error) {
+	transportCredentials := insecure.NewCredentials()
+	if cluster.MTLSWithCertManagerEnabled() && cluster.Spec.MTLS.InternodeEnabled() {
+		tlsConfig, err := GetClusterInternalClientTLSConfig(ctx, client, cluster)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't get cluster internode TLS config: %w", err)
+		}
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
+
+	log.FromContext(ctx).V(1).Info("Connecting to temporal history host", "address", address)
+
+	dialOptions = append([]grpc.DialOption{grpc.WithTransportCredentials(transportCredentials)}, dialOptions...)
+
+	conn, err := grpc.NewClient(address, dialOptions...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't create temporal history client: %w", err)
+	}
+
+	return historyservice.NewHistoryServiceClient(conn), conn, nil
+}
@@ -0,0 +1,86 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// clientValidationTimeout is the maximum duration of a client credentials validation.
+const clientValidationTimeout = 10 * time.Second
+
+// ValidateClientCredentials opens a gRPC connection to the provided frontend address using the provided
+// TLS configuration and calls GetSystemInfo. It returns the server information if the credentials are accepted.
+func ValidateClientCredentials(ctx context.Context, address string, tlsConfig *tls.Config) (*v1beta1.ClusterClientServerStatus, error) {
+	log.FromContext(ctx).V(1).Info("Validating client credentials", "address", address)
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return nil, fmt.Errorf("can't create temporal client connection: %w", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, clientValidationTimeout)
+	defer cancel()
+
+	info, err := workflowservice.NewWorkflowServiceClient(conn).GetSystemInfo(ctx, &workflowservice.GetSystemInfoRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("can't get system info from %s: %w", address, err)
+	}
+
+	return &v1beta1.ClusterClientServerStatus{
+		Version:      info.GetServerVersion(),
+		Capabilities: capabilitiesNames(info.GetCapabilities()),
+	}, nil
+}
+
+// capabilitiesNames returns the names of the enabled server capabilities.
+func capabilitiesNames(capabilities *workflowservice.GetSystemInfoResponse_Capabilities) []string {
+	all := []struct {
+		name    string
+		enabled bool
+	}{
+		{"SignalAndQueryHeader", capabilities.GetSignalAndQueryHeader()},
+		{"InternalErrorDifferentiation", capabilities.GetInternalErrorDifferentiation()},
+		{"ActivityFailureIncludeHeartbeat", capabilities.GetActivityFailureIncludeHeartbeat()},
+		{"SupportsSchedules", capabilities.GetSupportsSchedules()},
+		{"EncodedFailureAttributes", capabilities.GetEncodedFailureAttributes()},
+		{"BuildIdBasedVersioning", capabilities.GetBuildIdBasedVersioning()},
+		{"UpsertMemo", capabilities.GetUpsertMemo()},
+		{"EagerWorkflowStart", capabilities.GetEagerWorkflowStart()},
+		{"SdkMetadata", capabilities.GetSdkMetadata()},
+		{"CountGroupByExecutionStatus", capabilities.GetCountGroupByExecutionStatus()},
+	}
+
+	names := []string{}
+	for _, capability := range all {
+		if capability.enabled {
+			names = append(names, capability.name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,100 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/api/adminservice/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetClusterInfo returns the cluster metadata reported by the cluster frontend.
+func GetClusterInfo(ctx context.Context, workflowService workflowservice.WorkflowServiceClient, operator operatorservice.OperatorServiceClient) (*v1beta1.ClusterInfoStatus, error) {
+	info, err := workflowService.GetClusterInfo(ctx, &workflowservice.GetClusterInfoRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("can't get cluster info: %w", err)
+	}
+
+	status := &v1beta1.ClusterInfoStatus{
+		ClusterID:         info.GetClusterId(),
+		ClusterName:       info.GetClusterName(),
+		ServerVersion:     info.GetServerVersion(),
+		HistoryShardCount: info.GetHistoryShardCount(),
+		LastCheckTime:     metav1.Now(),
+	}
+
+	// The initial failover version is only exposed by the cluster metadata list.
+	var nextPageToken []byte
+	for {
+		res, err := operator.ListClusters(ctx, &operatorservice.ListClustersRequest{
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("can't list clusters: %w", err)
+		}
+		for _, cluster := range res.GetClusters() {
+			if cluster.GetClusterId() == status.ClusterID {
+				status.InitialFailoverVersion = cluster.GetInitialFailoverVersion()
+				return status, nil
+			}
+		}
+		nextPageToken = res.GetNextPageToken()
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+
+	return status, nil
+}
+
+// GetClusterTags returns the cluster metadata tags reported by the admin DescribeCluster API.
+func GetClusterTags(ctx context.Context, admin adminservice.AdminServiceClient) (map[string]string, error) {
+	res, err := admin.DescribeCluster(ctx, &adminservice.DescribeClusterRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("can't describe cluster: %w", err)
+	}
+	return res.GetTags(), nil
+}
+
+// GetMembership returns a human readable dump of the cluster membership rings reported by the admin DescribeCluster API.
+func GetMembership(ctx context.Context, admin adminservice.AdminServiceClient) (string, error) {
+	res, err := admin.DescribeCluster(ctx, &adminservice.DescribeClusterRequest{})
+	if err != nil {
+		return "", fmt.Errorf("can't describe cluster: %w", err)
+	}
+
+	membership := res.GetMembershipInfo()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "current host: %s\n", membership.GetCurrentHost().GetIdentity())
+	fmt.Fprintf(&b, "reachable members: %s\n", strings.Join(membership.GetReachableMembers(), ", "))
+	for _, ring := range membership.GetRings() {
+		fmt.Fprintf(&b, "%s ring (%d members):\n", ring.GetRole(), ring.GetMemberCount())
+		for _, member := range ring.GetMembers() {
+			fmt.Fprintf(&b, "  - %s\n", member.GetIdentity())
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,129 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// autoTuneMutableStateEntrySize is the estimated memory footprint of a cached workflow mutable state.
+	autoTuneMutableStateEntrySize = 32 * 1024
+	// autoTuneMutableStateCacheRatio is the share of the history memory dedicated to mutable state caches.
+	autoTuneMutableStateCacheRatio = 0.3
+	// autoTuneEventsCacheRatio is the share of the history memory dedicated to events caches.
+	autoTuneEventsCacheRatio = 0.1
+	// autoTuneWorkersPerCPU is the number of queue processor scheduler workers per history CPU core.
+	autoTuneWorkersPerCPU = 128
+)
+
+// AutoTuneRecommendation is a dynamic config value derived by the auto-tuner.
+type AutoTuneRecommendation struct {
+	Key       string
+	Value     any
+	Rationale string
+}
+
+// AutoTuneRecommendations derives history cache sizes and queue processor worker counts
+// from the number of history shards and the history service resources.
+// Limits are preferred over requests; values depending on an undeclared resource are not recommended.
+func AutoTuneRecommendations(numHistoryShards int32, history *v1beta1.ServiceSpec) []AutoTuneRecommendation {
+	if history == nil || numHistoryShards <= 0 {
+		return nil
+	}
+
+	replicas := int32(1)
+	if history.Replicas != nil && *history.Replicas > 0 {
+		replicas = *history.Replicas
+	}
+	shardsPerHost := int64((numHistoryShards + replicas - 1) / replicas)
+
+	recommendations := []AutoTuneRecommendation{}
+
+	if memory := autoTuneResource(history.Resources, corev1.ResourceMemory); memory != nil {
+		bytes := memory.Value()
+
+		cacheSize := clamp(int64(float64(bytes)*autoTuneMutableStateCacheRatio)/shardsPerHost/autoTuneMutableStateEntrySize, 64, 4096)
+		recommendations = append(recommendations,
+			AutoTuneRecommendation{
+				Key:   "history.cacheMaxSize",
+				Value: int(cacheSize),
+				Rationale: fmt.Sprintf("%d%% of %s history memory shared by %d shards per host, assuming %dKiB per mutable state",
+					int(autoTuneMutableStateCacheRatio*100), memory.String(), shardsPerHost, autoTuneMutableStateEntrySize/1024),
+			},
+			AutoTuneRecommendation{
+				Key:       "history.cacheInitialSize",
+				Value:     int(min(cacheSize, 128)),
+				Rationale: "initial size can't exceed history.cacheMaxSize",
+			},
+		)
+
+		eventsCacheBytes := clamp(int64(float64(bytes)*autoTuneEventsCacheRatio)/shardsPerHost, 256*1024, 16*1024*1024)
+		recommendations = append(recommendations, AutoTuneRecommendation{
+			Key:   "history.eventsCacheMaxSizeBytes",
+			Value: int(eventsCacheBytes),
+			Rationale: fmt.Sprintf("%d%% of %s history memory shared by %d shards per host",
+				int(autoTuneEventsCacheRatio*100), memory.String(), shardsPerHost),
+		})
+	}
+
+	if cpu := autoTuneResource(history.Resources, corev1.ResourceCPU); cpu != nil {
+		workers := clamp(cpu.MilliValue()*autoTuneWorkersPerCPU/1000, 32, 1024)
+		rationale := fmt.Sprintf("%d workers per core for %s history CPU", autoTuneWorkersPerCPU, cpu.String())
+		for _, key := range []string{
+			"history.transferProcessorSchedulerWorkerCount",
+			"history.timerProcessorSchedulerWorkerCount",
+			"history.visibilityProcessorSchedulerWorkerCount",
+		} {
+			recommendations = append(recommendations, AutoTuneRecommendation{
+				Key:       key,
+				Value:     int(workers),
+				Rationale: rationale,
+			})
+		}
+	}
+
+	return recommendations
+}
+
+// AddAutoTunedValues adds the provided auto-tuner recommendations to the dynamic config.
+// Values already set for the same key take precedence.
+func AddAutoTunedValues(cfg YamlDynamicConfig, recommendations []AutoTuneRecommendation) {
+	for _, recommendation := range recommendations {
+		addConstrainedValue(cfg, recommendation.Key, map[string]any{}, recommendation.Value)
+	}
+}
+
+// autoTuneResource returns the resource limit, or its request if no limit is set.
+func autoTuneResource(resources corev1.ResourceRequirements, name corev1.ResourceName) *resource.Quantity {
+	if q, ok := resources.Limits[name]; ok && !q.IsZero() {
+		return &q
+	}
+	if q, ok := resources.Requests[name]; ok && !q.IsZero() {
+		return &q
+	}
+	return nil
+}
+
+func clamp(value, lower, upper int64) int64 {
+	return max(lower, min(value, upper))
+}
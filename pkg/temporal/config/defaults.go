@@ -0,0 +1,82 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+)
+
+// versionDefaults are dynamic config values applied to the temporal versions in a range.
+type versionDefaults struct {
+	// minVersion is the first version the values apply to.
+	minVersion *version.Version
+	// maxVersion, if set, is the first version the values no longer apply to.
+	maxVersion *version.Version
+	values     map[string]any
+}
+
+// defaultsBundles holds the operator built-in dynamic config defaults, applied to clusters enabling
+// spec.dynamicConfig.versionDefaults. Values must be safe for any workload: they harden the cluster or
+// backport the upstream default of a later version, they never change the behavior seen by clients.
+var defaultsBundles = []versionDefaults{
+	{
+		// The operator manages the server version: servers don't need to call temporal's version check service.
+		minVersion: version.V1_18_0,
+		values: map[string]any{
+			"frontend.enableServerVersionCheck": false,
+		},
+	},
+	{
+		// Reject task completions whose token was issued for another namespace, the default starting with 1.23.
+		minVersion: version.V1_20_0,
+		maxVersion: version.V1_23_0,
+		values: map[string]any{
+			"frontend.enableTokenNamespaceEnforcement": true,
+		},
+	},
+}
+
+// VersionDefaults returns the built-in dynamic config defaults for the provided temporal version.
+func VersionDefaults(v *version.Version) map[string]any {
+	result := map[string]any{}
+	if v == nil {
+		return result
+	}
+
+	for _, bundle := range defaultsBundles {
+		if !v.GreaterOrEqual(bundle.minVersion) {
+			continue
+		}
+		if bundle.maxVersion != nil && v.GreaterOrEqual(bundle.maxVersion) {
+			continue
+		}
+		for key, value := range bundle.values {
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+// AddVersionDefaults adds the built-in dynamic config defaults for the provided temporal version.
+// Values already set for the same key and constraints take precedence.
+func AddVersionDefaults(cfg YamlDynamicConfig, v *version.Version) {
+	for key, value := range VersionDefaults(v) {
+		addConstrainedValue(cfg, key, map[string]any{}, value)
+	}
+}
@@ -0,0 +1,215 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal/config"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestVersionDefaults(t *testing.T) {
+	tests := map[string]struct {
+		version  *version.Version
+		expected map[string]any
+	}{
+		"no version": {
+			expected: map[string]any{},
+		},
+		"1.19": {
+			version: version.MustNewVersionFromString("1.19.1"),
+			expected: map[string]any{
+				"frontend.enableServerVersionCheck": false,
+			},
+		},
+		"1.22": {
+			version: version.MustNewVersionFromString("1.22.4"),
+			expected: map[string]any{
+				"frontend.enableServerVersionCheck":        false,
+				"frontend.enableTokenNamespaceEnforcement": true,
+			},
+		},
+		"1.23": {
+			version: version.V1_23_0,
+			expected: map[string]any{
+				"frontend.enableServerVersionCheck": false,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, config.VersionDefaults(test.version))
+		})
+	}
+}
+
+func TestRenderDynamicConfigLayers(t *testing.T) {
+	unconstrained := func(value any) []config.YamlConstrainedValue {
+		return []config.YamlConstrainedValue{{Constraints: map[string]any{}, Value: value}}
+	}
+
+	userValues := map[string][]v1beta1.ConstrainedValue{
+		"frontend.enableServerVersionCheck": {
+			{Value: &apiextensionsv1.JSON{Raw: []byte(`true`)}},
+		},
+		"history.transferProcessorMaxPollRPS": {
+			{Value: &apiextensionsv1.JSON{Raw: []byte(`80`)}},
+		},
+	}
+
+	tests := map[string]struct {
+		defaults bool
+		profile  v1beta1.QueueProcessorProfile
+		values   map[string][]v1beta1.ConstrainedValue
+		expected config.YamlDynamicConfig
+	}{
+		"no layer": {
+			expected: config.YamlDynamicConfig{},
+		},
+		"defaults": {
+			defaults: true,
+			expected: config.YamlDynamicConfig{
+				"frontend.enableServerVersionCheck":        unconstrained(false),
+				"frontend.enableTokenNamespaceEnforcement": unconstrained(true),
+			},
+		},
+		"profile": {
+			profile: v1beta1.LowLatencyQueueProcessorProfile,
+			expected: config.YamlDynamicConfig{
+				"history.transferProcessorMaxPollRPS":   unconstrained(float64(50)),
+				"history.timerProcessorMaxPollInterval": unconstrained("1m"),
+			},
+		},
+		"values": {
+			values: userValues,
+			expected: config.YamlDynamicConfig{
+				"frontend.enableServerVersionCheck":   unconstrained(true),
+				"history.transferProcessorMaxPollRPS": unconstrained(float64(80)),
+			},
+		},
+		"defaults and profile": {
+			defaults: true,
+			profile:  v1beta1.LowLatencyQueueProcessorProfile,
+			expected: config.YamlDynamicConfig{
+				"frontend.enableServerVersionCheck":        unconstrained(false),
+				"frontend.enableTokenNamespaceEnforcement": unconstrained(true),
+				"history.transferProcessorMaxPollRPS":      unconstrained(float64(50)),
+				"history.timerProcessorMaxPollInterval":    unconstrained("1m"),
+			},
+		},
+		"defaults and values": {
+			defaults: true,
+			values:   userValues,
+			expected: config.YamlDynamicConfig{
+				"frontend.enableServerVersionCheck":        unconstrained(true),
+				"frontend.enableTokenNamespaceEnforcement": unconstrained(true),
+				"history.transferProcessorMaxPollRPS":      unconstrained(float64(80)),
+			},
+		},
+		"profile and values": {
+			profile: v1beta1.LowLatencyQueueProcessorProfile,
+			values:  userValues,
+			expected: config.YamlDynamicConfig{
+				"frontend.enableServerVersionCheck":     unconstrained(true),
+				"history.transferProcessorMaxPollRPS":   unconstrained(float64(80)),
+				"history.timerProcessorMaxPollInterval": unconstrained("1m"),
+			},
+		},
+		"all layers": {
+			defaults: true,
+			profile:  v1beta1.LowLatencyQueueProcessorProfile,
+			values:   userValues,
+			expected: config.YamlDynamicConfig{
+				"frontend.enableServerVersionCheck":        unconstrained(true),
+				"frontend.enableTokenNamespaceEnforcement": unconstrained(true),
+				"history.transferProcessorMaxPollRPS":      unconstrained(float64(80)),
+				"history.timerProcessorMaxPollInterval":    unconstrained("1m"),
+			},
+		},
+	}
+
+	observedKeys := []string{
+		"frontend.enableServerVersionCheck",
+		"frontend.enableTokenNamespaceEnforcement",
+		"history.transferProcessorMaxPollRPS",
+		"history.timerProcessorMaxPollInterval",
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			cluster := &v1beta1.TemporalCluster{
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.4"),
+					DynamicConfig: &v1beta1.DynamicConfigSpec{
+						VersionDefaults:       test.defaults,
+						QueueProcessorProfile: test.profile,
+						Values:                test.values,
+					},
+				},
+			}
+
+			cfg, _, err := config.RenderDynamicConfig(cluster, nil)
+			require.NoError(tt, err)
+
+			result := config.YamlDynamicConfig{}
+			for _, key := range observedKeys {
+				if values, ok := cfg[key]; ok {
+					result[key] = values
+				}
+			}
+			assert.Equal(tt, test.expected, result)
+		})
+	}
+}
+
+func TestRenderDynamicConfigVersionDefaultsSources(t *testing.T) {
+	cluster := &v1beta1.TemporalCluster{
+		Spec: v1beta1.TemporalClusterSpec{
+			Version: version.MustNewVersionFromString("1.22.4"),
+			DynamicConfig: &v1beta1.DynamicConfigSpec{
+				VersionDefaults: true,
+				Values: map[string][]v1beta1.ConstrainedValue{
+					"frontend.enableServerVersionCheck": {
+						{Value: &apiextensionsv1.JSON{Raw: []byte(`true`)}},
+					},
+				},
+			},
+		},
+	}
+
+	_, keys, err := config.RenderDynamicConfig(cluster, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []v1beta1.DynamicConfigKeyStatus{
+		{
+			Key:               "frontend.enableServerVersionCheck",
+			Sources:           []v1beta1.DynamicConfigSource{v1beta1.ValuesDynamicConfigSource},
+			OverriddenSources: []v1beta1.DynamicConfigSource{v1beta1.VersionDefaultsDynamicConfigSource},
+		},
+		{
+			Key:     "frontend.enableTokenNamespaceEnforcement",
+			Sources: []v1beta1.DynamicConfigSource{v1beta1.VersionDefaultsDynamicConfigSource},
+		},
+	}, keys)
+}
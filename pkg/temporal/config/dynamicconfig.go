@@ -19,6 +19,7 @@ package config
 
 import (
 	"encoding/json"
+	"reflect"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 )
@@ -33,6 +34,15 @@ type YamlConstrainedValue struct {
 func DynamicConfigToYamlDynamicConfig(dc *v1beta1.DynamicConfigSpec) (YamlDynamicConfig, error) {
 	result := map[string][]YamlConstrainedValue{}
 
+	for k, v := range queueProcessorProfileValues(dc.QueueProcessorProfile) {
+		result[k] = []YamlConstrainedValue{
+			{
+				Constraints: map[string]any{},
+				Value:       v,
+			},
+		}
+	}
+
 	for k, v := range dc.Values {
 		yamlConstrainedValues := []YamlConstrainedValue{}
 		for _, constrainedValue := range v {
@@ -92,3 +102,239 @@ func constrainedValueToYamlConstrainedValue(cv *v1beta1.ConstrainedValue) (YamlC
 		Value:       value,
 	}, nil
 }
+
+// AddNamespacesRateLimits adds the provided namespaces rate limits to the dynamic config.
+// Values explicitly set in the cluster dynamic config for the same key and namespace take precedence.
+func AddNamespacesRateLimits(cfg YamlDynamicConfig, namespaces []v1beta1.TemporalNamespace) {
+	for _, namespace := range namespaces {
+		if namespace.Spec.RateLimits == nil {
+			continue
+		}
+
+		limits := map[string]*int32{
+			"frontend.namespaceRPS":            namespace.Spec.RateLimits.FrontendRPS,
+			"frontend.namespaceBurst":          namespace.Spec.RateLimits.FrontendBurst,
+			"frontend.globalNamespaceRPS":      namespace.Spec.RateLimits.GlobalFrontendRPS,
+			"frontend.namespaceRPS.visibility": namespace.Spec.RateLimits.VisibilityRPS,
+		}
+
+		for key, limit := range limits {
+			if limit == nil || hasNamespaceConstrainedValue(cfg[key], namespace.GetName()) {
+				continue
+			}
+
+			cfg[key] = append(cfg[key], YamlConstrainedValue{
+				Constraints: map[string]any{
+					"namespace": namespace.GetName(),
+				},
+				Value: int(*limit),
+			})
+		}
+	}
+}
+
+// AddNamespacesTaskQueues adds the provided namespaces task queues configuration to the dynamic config.
+// Values explicitly set in the cluster dynamic config for the same key and constraints take precedence.
+func AddNamespacesTaskQueues(cfg YamlDynamicConfig, namespaces []v1beta1.TemporalNamespace) {
+	for _, namespace := range namespaces {
+		taskQueues := namespace.Spec.TaskQueues
+		if taskQueues == nil {
+			continue
+		}
+
+		if taskQueues.StickyTTL != nil {
+			addConstrainedValue(cfg, "history.stickyTTL", map[string]any{
+				"namespace": namespace.GetName(),
+			}, taskQueues.StickyTTL.Duration.String())
+		}
+
+		for _, queue := range taskQueues.Queues {
+			constraints := map[string]any{
+				"namespace":     namespace.GetName(),
+				"taskqueuename": queue.Name,
+			}
+			if queue.Type != "" {
+				constraints["tasktype"] = string(queue.Type)
+			}
+
+			values := map[string]any{}
+			if queue.ReadPartitions != nil {
+				values["matching.numTaskqueueReadPartitions"] = int(*queue.ReadPartitions)
+			}
+			if queue.WritePartitions != nil {
+				values["matching.numTaskqueueWritePartitions"] = int(*queue.WritePartitions)
+			}
+			if queue.ForwarderMaxRatePerSecond != nil {
+				values["matching.forwarderMaxRatePerSecond"] = int(*queue.ForwarderMaxRatePerSecond)
+			}
+			if queue.LongPollExpirationInterval != nil {
+				values["matching.longPollExpirationInterval"] = queue.LongPollExpirationInterval.Duration.String()
+			}
+
+			for key, value := range values {
+				addConstrainedValue(cfg, key, constraints, value)
+			}
+		}
+	}
+}
+
+// AddNamespacesLimits adds the provided namespaces limits to the dynamic config.
+// Values explicitly set in the cluster dynamic config for the same key and namespace take precedence.
+func AddNamespacesLimits(cfg YamlDynamicConfig, namespaces []v1beta1.TemporalNamespace) {
+	for _, namespace := range namespaces {
+		limits := namespace.Spec.Limits
+		if limits == nil {
+			continue
+		}
+
+		values := map[string]any{}
+
+		if limits.DefaultWorkflowTaskTimeout != nil {
+			values["system.defaultWorkflowTaskTimeout"] = limits.DefaultWorkflowTaskTimeout.Duration.String()
+		}
+
+		sizes := map[string]*v1beta1.SizeLimitSpec{
+			"limit.blobSize":    limits.BlobSize,
+			"limit.memoSize":    limits.MemoSize,
+			"limit.historySize": limits.HistorySize,
+		}
+		for key, size := range sizes {
+			if size == nil {
+				continue
+			}
+			if size.Warn != nil {
+				values[key+".warn"] = int(size.Warn.Value())
+			}
+			if size.Error != nil {
+				values[key+".error"] = int(size.Error.Value())
+			}
+		}
+
+		counts := map[string]*int32{
+			"limit.numPendingActivities.error":      limits.MaxPendingActivities,
+			"limit.numPendingChildExecutions.error": limits.MaxPendingChildExecutions,
+			"limit.numPendingSignals.error":         limits.MaxPendingSignals,
+		}
+		if limits.HistoryCount != nil {
+			counts["limit.historyCount.warn"] = limits.HistoryCount.Warn
+			counts["limit.historyCount.error"] = limits.HistoryCount.Error
+		}
+		for key, count := range counts {
+			if count != nil {
+				values[key] = int(*count)
+			}
+		}
+
+		for key, value := range values {
+			addConstrainedValue(cfg, key, map[string]any{
+				"namespace": namespace.GetName(),
+			}, value)
+		}
+	}
+}
+
+// AddNamespacesVisibilityStores routes the provided namespaces visibility queries to their visibility store.
+// Values explicitly set in the cluster dynamic config for the same key and namespace take precedence.
+func AddNamespacesVisibilityStores(cfg YamlDynamicConfig, namespaces []v1beta1.TemporalNamespace) {
+	for _, namespace := range namespaces {
+		if namespace.Spec.VisibilityStore == "" {
+			continue
+		}
+
+		addConstrainedValue(cfg, "system.enableReadFromSecondaryVisibility", map[string]any{
+			"namespace": namespace.GetName(),
+		}, namespace.Spec.VisibilityStore == v1beta1.SecondaryNamespaceVisibilityStore)
+	}
+}
+
+// AddServicesShutdownDrain adds the services shutdown drain durations to the dynamic config.
+// Values explicitly set in the cluster dynamic config for the same key take precedence.
+func AddServicesShutdownDrain(cfg YamlDynamicConfig, services *v1beta1.ServicesSpec) {
+	if services == nil {
+		return
+	}
+
+	keys := []struct {
+		key  string
+		spec *v1beta1.ServiceSpec
+	}{
+		{"frontend.shutdownDrainDuration", services.Frontend},
+		{"history.shutdownDrainDuration", services.History},
+		{"matching.shutdownDrainDuration", services.Matching},
+	}
+
+	for _, k := range keys {
+		if k.spec == nil || k.spec.GracefulShutdown == nil || k.spec.GracefulShutdown.DrainDuration == nil {
+			continue
+		}
+
+		addConstrainedValue(cfg, k.key, map[string]any{}, k.spec.GracefulShutdown.DrainDuration.Duration.String())
+	}
+}
+
+// AddPersistenceRateLimits adds the datastores rate limits to the dynamic config.
+// Values explicitly set in the cluster dynamic config for the same key take precedence.
+func AddPersistenceRateLimits(cfg YamlDynamicConfig, limits *v1beta1.PersistenceRateLimitsSpec) {
+	if limits == nil {
+		return
+	}
+
+	values := map[string]*int32{}
+
+	services := map[string]*v1beta1.ServicePersistenceRateLimitsSpec{
+		"frontend": limits.Frontend,
+		"history":  limits.History,
+		"matching": limits.Matching,
+		"worker":   limits.Worker,
+	}
+	for service, serviceLimits := range services {
+		if serviceLimits == nil {
+			continue
+		}
+		values[service+".persistenceMaxQPS"] = serviceLimits.MaxQPS
+		values[service+".persistenceGlobalMaxQPS"] = serviceLimits.GlobalMaxQPS
+	}
+
+	if limits.TaskScheduler != nil {
+		values["history.taskSchedulerMaxQPS"] = limits.TaskScheduler.MaxQPS
+		values["history.taskSchedulerGlobalMaxQPS"] = limits.TaskScheduler.GlobalMaxQPS
+		values["history.taskSchedulerNamespaceMaxQPS"] = limits.TaskScheduler.NamespaceMaxQPS
+		values["history.taskSchedulerGlobalNamespaceMaxQPS"] = limits.TaskScheduler.GlobalNamespaceMaxQPS
+	}
+
+	if limits.VisibilityStore != nil {
+		values["system.visibilityPersistenceMaxReadQPS"] = limits.VisibilityStore.MaxReadQPS
+		values["system.visibilityPersistenceMaxWriteQPS"] = limits.VisibilityStore.MaxWriteQPS
+	}
+
+	for key, value := range values {
+		if value == nil {
+			continue
+		}
+		addConstrainedValue(cfg, key, map[string]any{}, int(*value))
+	}
+}
+
+// addConstrainedValue adds the value for the provided key and constraints,
+// unless a value is already set for the same key and constraints.
+func addConstrainedValue(cfg YamlDynamicConfig, key string, constraints map[string]any, value any) {
+	for _, existing := range cfg[key] {
+		if reflect.DeepEqual(existing.Constraints, constraints) {
+			return
+		}
+	}
+
+	cfg[key] = append(cfg[key], YamlConstrainedValue{
+		Constraints: constraints,
+		Value:       value,
+	})
+}
+
+func hasNamespaceConstrainedValue(values []YamlConstrainedValue, namespace string) bool {
+	for _, value := range values {
+		if len(value.Constraints) == 1 && value.Constraints["namespace"] == namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -19,12 +19,17 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/pkg/temporal/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestDynamicConfigToYamlDynamicConfig(t *testing.T) {
@@ -151,6 +156,56 @@ func TestDynamicConfigToYamlDynamicConfig(t *testing.T) {
 				},
 			},
 		},
+		"queue processor profile overridden by values": {
+			dyanmicConfig: &v1beta1.DynamicConfigSpec{
+				QueueProcessorProfile: v1beta1.LowLatencyQueueProcessorProfile,
+				Values: map[string][]v1beta1.ConstrainedValue{
+					"history.transferProcessorMaxPollInterval": {
+						{
+							Value: &apiextensionsv1.JSON{Raw: []byte(`"5s"`)},
+						},
+					},
+				},
+			},
+			expectedYamlDynamicConfig: config.YamlDynamicConfig{
+				"history.transferProcessorMaxPollInterval": {
+					{
+						Constraints: map[string]any{},
+						Value:       "5s",
+					},
+				},
+				"history.timerProcessorMaxPollInterval": {
+					{
+						Constraints: map[string]any{},
+						Value:       "1m",
+					},
+				},
+				"history.visibilityProcessorMaxPollInterval": {
+					{
+						Constraints: map[string]any{},
+						Value:       "10s",
+					},
+				},
+				"history.transferProcessorMaxPollRPS": {
+					{
+						Constraints: map[string]any{},
+						Value:       float64(50),
+					},
+				},
+				"history.timerProcessorMaxPollRPS": {
+					{
+						Constraints: map[string]any{},
+						Value:       float64(50),
+					},
+				},
+				"history.visibilityProcessorMaxPollRPS": {
+					{
+						Constraints: map[string]any{},
+						Value:       float64(50),
+					},
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -161,3 +216,497 @@ func TestDynamicConfigToYamlDynamicConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestAddNamespacesRateLimits(t *testing.T) {
+	cfg := config.YamlDynamicConfig{
+		"frontend.namespaceRPS": {
+			{
+				Constraints: map[string]any{
+					"namespace": "accounting",
+				},
+				Value: float64(100),
+			},
+		},
+	}
+
+	namespaces := []v1beta1.TemporalNamespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "accounting"},
+			Spec: v1beta1.TemporalNamespaceSpec{
+				RateLimits: &v1beta1.TemporalNamespaceRateLimitsSpec{
+					FrontendRPS:   ptr.To[int32](50),
+					VisibilityRPS: ptr.To[int32](10),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing"},
+			Spec: v1beta1.TemporalNamespaceSpec{
+				RateLimits: &v1beta1.TemporalNamespaceRateLimitsSpec{
+					FrontendRPS: ptr.To[int32](20),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-limits"},
+		},
+	}
+
+	config.AddNamespacesRateLimits(cfg, namespaces)
+
+	expected := config.YamlDynamicConfig{
+		"frontend.namespaceRPS": {
+			{
+				Constraints: map[string]any{
+					"namespace": "accounting",
+				},
+				Value: float64(100),
+			},
+			{
+				Constraints: map[string]any{
+					"namespace": "billing",
+				},
+				Value: 20,
+			},
+		},
+		"frontend.namespaceRPS.visibility": {
+			{
+				Constraints: map[string]any{
+					"namespace": "accounting",
+				},
+				Value: 10,
+			},
+		},
+	}
+
+	assert.EqualValues(t, expected, cfg)
+}
+
+func TestAddNamespacesTaskQueues(t *testing.T) {
+	cfg := config.YamlDynamicConfig{
+		"matching.numTaskqueueReadPartitions": {
+			{
+				Constraints: map[string]any{
+					"namespace":     "accounting",
+					"taskqueuename": "invoices",
+				},
+				Value: float64(8),
+			},
+		},
+	}
+
+	namespaces := []v1beta1.TemporalNamespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "accounting"},
+			Spec: v1beta1.TemporalNamespaceSpec{
+				TaskQueues: &v1beta1.TemporalNamespaceTaskQueuesSpec{
+					StickyTTL: &metav1.Duration{Duration: 30 * time.Second},
+					Queues: []v1beta1.TaskQueueConfigSpec{
+						{
+							Name:            "invoices",
+							ReadPartitions:  ptr.To[int32](4),
+							WritePartitions: ptr.To[int32](4),
+						},
+						{
+							Name:                       "reports",
+							Type:                       v1beta1.ActivityTaskQueueType,
+							LongPollExpirationInterval: &metav1.Duration{Duration: time.Minute},
+						},
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-task-queues"},
+		},
+	}
+
+	config.AddNamespacesTaskQueues(cfg, namespaces)
+
+	expected := config.YamlDynamicConfig{
+		"history.stickyTTL": {
+			{
+				Constraints: map[string]any{
+					"namespace": "accounting",
+				},
+				Value: "30s",
+			},
+		},
+		"matching.numTaskqueueReadPartitions": {
+			{
+				Constraints: map[string]any{
+					"namespace":     "accounting",
+					"taskqueuename": "invoices",
+				},
+				Value: float64(8),
+			},
+		},
+		"matching.numTaskqueueWritePartitions": {
+			{
+				Constraints: map[string]any{
+					"namespace":     "accounting",
+					"taskqueuename": "invoices",
+				},
+				Value: 4,
+			},
+		},
+		"matching.longPollExpirationInterval": {
+			{
+				Constraints: map[string]any{
+					"namespace":     "accounting",
+					"taskqueuename": "reports",
+					"tasktype":      "Activity",
+				},
+				Value: "1m0s",
+			},
+		},
+	}
+
+	assert.EqualValues(t, expected, cfg)
+}
+
+func TestAddNamespacesVisibilityStores(t *testing.T) {
+	cfg := config.YamlDynamicConfig{
+		"system.enableReadFromSecondaryVisibility": {
+			{
+				Constraints: map[string]any{
+					"namespace": "accounting",
+				},
+				Value: false,
+			},
+		},
+	}
+
+	namespaces := []v1beta1.TemporalNamespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "accounting"},
+			Spec: v1beta1.TemporalNamespaceSpec{
+				VisibilityStore: v1beta1.SecondaryNamespaceVisibilityStore,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "heavy-tenant"},
+			Spec: v1beta1.TemporalNamespaceSpec{
+				VisibilityStore: v1beta1.SecondaryNamespaceVisibilityStore,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing"},
+			Spec: v1beta1.TemporalNamespaceSpec{
+				VisibilityStore: v1beta1.PrimaryNamespaceVisibilityStore,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-store"},
+		},
+	}
+
+	config.AddNamespacesVisibilityStores(cfg, namespaces)
+
+	expected := config.YamlDynamicConfig{
+		"system.enableReadFromSecondaryVisibility": {
+			{
+				Constraints: map[string]any{
+					"namespace": "accounting",
+				},
+				Value: false,
+			},
+			{
+				Constraints: map[string]any{
+					"namespace": "heavy-tenant",
+				},
+				Value: true,
+			},
+			{
+				Constraints: map[string]any{
+					"namespace": "billing",
+				},
+				Value: false,
+			},
+		},
+	}
+
+	assert.EqualValues(t, expected, cfg)
+}
+
+func TestAddNamespacesLimits(t *testing.T) {
+	cfg := config.YamlDynamicConfig{
+		"limit.blobSize.error": {
+			{
+				Constraints: map[string]any{
+					"namespace": "accounting",
+				},
+				Value: 4194304,
+			},
+		},
+	}
+
+	namespaces := []v1beta1.TemporalNamespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "accounting"},
+			Spec: v1beta1.TemporalNamespaceSpec{
+				Limits: &v1beta1.TemporalNamespaceLimitsSpec{
+					DefaultWorkflowTaskTimeout: &metav1.Duration{Duration: 20 * time.Second},
+					BlobSize: &v1beta1.SizeLimitSpec{
+						Warn:  ptr.To(resource.MustParse("512Ki")),
+						Error: ptr.To(resource.MustParse("1Mi")),
+					},
+					HistoryCount: &v1beta1.CountLimitSpec{
+						Error: ptr.To[int32](20000),
+					},
+					MaxPendingActivities: ptr.To[int32](500),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unlimited"},
+		},
+	}
+
+	config.AddNamespacesLimits(cfg, namespaces)
+
+	constraints := map[string]any{
+		"namespace": "accounting",
+	}
+	expected := config.YamlDynamicConfig{
+		"system.defaultWorkflowTaskTimeout": {
+			{Constraints: constraints, Value: "20s"},
+		},
+		"limit.blobSize.warn": {
+			{Constraints: constraints, Value: 524288},
+		},
+		"limit.blobSize.error": {
+			{Constraints: constraints, Value: 4194304},
+		},
+		"limit.historyCount.error": {
+			{Constraints: constraints, Value: 20000},
+		},
+		"limit.numPendingActivities.error": {
+			{Constraints: constraints, Value: 500},
+		},
+	}
+
+	assert.EqualValues(t, expected, cfg)
+}
+
+func TestAddServicesShutdownDrain(t *testing.T) {
+	cfg := config.YamlDynamicConfig{
+		"history.shutdownDrainDuration": {
+			{
+				Constraints: map[string]any{},
+				Value:       "1m0s",
+			},
+		},
+	}
+
+	drain := &v1beta1.GracefulShutdownSpec{
+		DrainDuration: &metav1.Duration{Duration: 30 * time.Second},
+	}
+
+	services := &v1beta1.ServicesSpec{
+		Frontend: &v1beta1.ServiceSpec{},
+		History:  &v1beta1.ServiceSpec{GracefulShutdown: drain},
+		Matching: &v1beta1.ServiceSpec{GracefulShutdown: drain},
+		Worker:   &v1beta1.ServiceSpec{GracefulShutdown: drain},
+	}
+
+	config.AddServicesShutdownDrain(cfg, services)
+
+	expected := config.YamlDynamicConfig{
+		"history.shutdownDrainDuration": {
+			{
+				Constraints: map[string]any{},
+				Value:       "1m0s",
+			},
+		},
+		"matching.shutdownDrainDuration": {
+			{
+				Constraints: map[string]any{},
+				Value:       "30s",
+			},
+		},
+	}
+
+	assert.EqualValues(t, expected, cfg)
+}
+
+func TestAddPersistenceRateLimits(t *testing.T) {
+	cfg := config.YamlDynamicConfig{
+		"history.persistenceMaxQPS": {
+			{
+				Constraints: map[string]any{},
+				Value:       float64(5000),
+			},
+		},
+	}
+
+	limits := &v1beta1.PersistenceRateLimitsSpec{
+		History: &v1beta1.ServicePersistenceRateLimitsSpec{
+			MaxQPS:       ptr.To[int32](3000),
+			GlobalMaxQPS: ptr.To[int32](9000),
+		},
+		TaskScheduler: &v1beta1.TaskSchedulerRateLimitsSpec{
+			NamespaceMaxQPS: ptr.To[int32](500),
+		},
+		VisibilityStore: &v1beta1.VisibilityStoreRateLimitsSpec{
+			MaxWriteQPS: ptr.To[int32](100),
+		},
+	}
+
+	config.AddPersistenceRateLimits(cfg, limits)
+
+	expected := config.YamlDynamicConfig{
+		"history.persistenceMaxQPS": {
+			{
+				Constraints: map[string]any{},
+				Value:       float64(5000),
+			},
+		},
+		"history.persistenceGlobalMaxQPS": {
+			{
+				Constraints: map[string]any{},
+				Value:       9000,
+			},
+		},
+		"history.taskSchedulerNamespaceMaxQPS": {
+			{
+				Constraints: map[string]any{},
+				Value:       500,
+			},
+		},
+		"system.visibilityPersistenceMaxWriteQPS": {
+			{
+				Constraints: map[string]any{},
+				Value:       100,
+			},
+		},
+	}
+
+	assert.EqualValues(t, expected, cfg)
+}
+
+func TestAutoTuneRecommendations(t *testing.T) {
+	history := &v1beta1.ServiceSpec{
+		Replicas: ptr.To[int32](2),
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+	}
+
+	cfg := config.YamlDynamicConfig{
+		"history.timerProcessorSchedulerWorkerCount": {
+			{
+				Constraints: map[string]any{},
+				Value:       float64(64),
+			},
+		},
+	}
+
+	recommendations := config.AutoTuneRecommendations(512, history)
+	for _, recommendation := range recommendations {
+		assert.NotEmpty(t, recommendation.Rationale)
+	}
+
+	config.AddAutoTunedValues(cfg, recommendations)
+
+	values := map[string]any{}
+	for key, constrainedValues := range cfg {
+		require.Len(t, constrainedValues, 1)
+		values[key] = constrainedValues[0].Value
+	}
+
+	expected := map[string]any{
+		"history.cacheMaxSize":                            153,
+		"history.cacheInitialSize":                        128,
+		"history.eventsCacheMaxSizeBytes":                 1677721,
+		"history.transferProcessorSchedulerWorkerCount":   256,
+		"history.timerProcessorSchedulerWorkerCount":      float64(64),
+		"history.visibilityProcessorSchedulerWorkerCount": 256,
+	}
+
+	assert.EqualValues(t, expected, values)
+}
+
+func TestAutoTuneRecommendationsWithoutResources(t *testing.T) {
+	recommendations := config.AutoTuneRecommendations(512, &v1beta1.ServiceSpec{})
+	assert.Empty(t, recommendations)
+}
+
+func TestRenderDynamicConfig(t *testing.T) {
+	cluster := &v1beta1.TemporalCluster{
+		Spec: v1beta1.TemporalClusterSpec{
+			Persistence: v1beta1.TemporalPersistenceSpec{
+				RateLimits: &v1beta1.PersistenceRateLimitsSpec{
+					History: &v1beta1.ServicePersistenceRateLimitsSpec{
+						MaxQPS: ptr.To[int32](3000),
+					},
+				},
+			},
+			DynamicConfig: &v1beta1.DynamicConfigSpec{
+				QueueProcessorProfile: v1beta1.LowLatencyQueueProcessorProfile,
+				Values: map[string][]v1beta1.ConstrainedValue{
+					"history.persistenceMaxQPS": {
+						{
+							Value: &apiextensionsv1.JSON{Raw: []byte(`5000`)},
+						},
+					},
+					"history.transferProcessorMaxPollInterval": {
+						{
+							Constraints: v1beta1.Constraints{Namespace: "payments"},
+							Value:       &apiextensionsv1.JSON{Raw: []byte(`"1s"`)},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	namespaces := []v1beta1.TemporalNamespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "payments"},
+			Spec: v1beta1.TemporalNamespaceSpec{
+				RateLimits: &v1beta1.TemporalNamespaceRateLimitsSpec{
+					FrontendRPS: ptr.To[int32](100),
+				},
+			},
+		},
+	}
+
+	cfg, keys, err := config.RenderDynamicConfig(cluster, namespaces)
+	require.NoError(t, err)
+
+	assert.Equal(t, []config.YamlConstrainedValue{{Constraints: map[string]any{}, Value: float64(5000)}}, cfg["history.persistenceMaxQPS"])
+	assert.Equal(t, []config.YamlConstrainedValue{{Constraints: map[string]any{"namespace": "payments"}, Value: "1s"}}, cfg["history.transferProcessorMaxPollInterval"])
+	assert.Equal(t, []config.YamlConstrainedValue{{Constraints: map[string]any{}, Value: "1m"}}, cfg["history.timerProcessorMaxPollInterval"])
+	assert.Equal(t, []config.YamlConstrainedValue{{Constraints: map[string]any{"namespace": "payments"}, Value: 100}}, cfg["frontend.namespaceRPS"])
+
+	statuses := map[string]v1beta1.DynamicConfigKeyStatus{}
+	for _, key := range keys {
+		statuses[key.Key] = key
+	}
+
+	assert.Equal(t, v1beta1.DynamicConfigKeyStatus{
+		Key:               "history.persistenceMaxQPS",
+		Sources:           []v1beta1.DynamicConfigSource{v1beta1.ValuesDynamicConfigSource},
+		OverriddenSources: []v1beta1.DynamicConfigSource{v1beta1.PersistenceRateLimitsDynamicConfigSource},
+	}, statuses["history.persistenceMaxQPS"])
+	assert.Equal(t, v1beta1.DynamicConfigKeyStatus{
+		Key:               "history.transferProcessorMaxPollInterval",
+		Sources:           []v1beta1.DynamicConfigSource{v1beta1.ValuesDynamicConfigSource},
+		OverriddenSources: []v1beta1.DynamicConfigSource{v1beta1.QueueProcessorProfileDynamicConfigSource},
+	}, statuses["history.transferProcessorMaxPollInterval"])
+	assert.Equal(t, v1beta1.DynamicConfigKeyStatus{
+		Key:     "history.timerProcessorMaxPollInterval",
+		Sources: []v1beta1.DynamicConfigSource{v1beta1.QueueProcessorProfileDynamicConfigSource},
+	}, statuses["history.timerProcessorMaxPollInterval"])
+	assert.Equal(t, v1beta1.DynamicConfigKeyStatus{
+		Key:     "frontend.namespaceRPS",
+		Sources: []v1beta1.DynamicConfigSource{v1beta1.NamespaceRateLimitsDynamicConfigSource},
+	}, statuses["frontend.namespaceRPS"])
+}
@@ -0,0 +1,102 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+)
+
+// featureKeys are the dynamic config keys enabling a server feature, starting from a temporal version.
+type featureKeys struct {
+	minVersion *version.Version
+	keys       []string
+}
+
+// serverFeatures holds, for each server feature, the dynamic config keys enabling it.
+// Entries are sorted by ascending version: the keys of the latest entry the cluster version satisfies are used.
+var serverFeatures = map[v1beta1.ServerFeature][]featureKeys{
+	v1beta1.UpdateWorkflowExecutionServerFeature: {
+		{
+			minVersion: version.V1_20_0,
+			keys:       []string{"frontend.enableUpdateWorkflowExecution"},
+		},
+		{
+			minVersion: version.V1_21_0,
+			keys:       []string{"frontend.enableUpdateWorkflowExecution", "frontend.enableUpdateWorkflowExecutionAsyncAccepted"},
+		},
+	},
+	v1beta1.EagerActivitiesServerFeature: {
+		{
+			minVersion: version.MustNewVersionFromString("1.17.0"),
+			keys:       []string{"system.enableActivityEagerExecution"},
+		},
+	},
+	v1beta1.EagerWorkflowStartServerFeature: {
+		{
+			minVersion: version.V1_22_0,
+			keys:       []string{"system.enableEagerWorkflowStart"},
+		},
+	},
+	v1beta1.NexusServerFeature: {
+		{
+			minVersion: version.MustNewVersionFromString("1.24.0"),
+			keys:       []string{"system.enableNexus"},
+		},
+	},
+}
+
+// FeatureDynamicConfigKeys returns the dynamic config keys enabling the provided feature for the provided temporal version.
+// It returns an error if the feature is unknown or not available in this version.
+func FeatureDynamicConfigKeys(feature v1beta1.ServerFeature, v *version.Version) ([]string, error) {
+	entries, ok := serverFeatures[feature]
+	if !ok {
+		return nil, fmt.Errorf("unknown feature %q", feature)
+	}
+
+	var keys []string
+	for _, entry := range entries {
+		if v.GreaterOrEqual(entry.minVersion) {
+			keys = entry.keys
+		}
+	}
+
+	if keys == nil {
+		return nil, fmt.Errorf("feature %s requires temporal version %s or later", feature, entries[0].minVersion.String())
+	}
+
+	return keys, nil
+}
+
+// AddFeatures adds the dynamic config values enabling the provided features for the provided temporal version.
+// Values explicitly set in the cluster dynamic config for the same key take precedence.
+// Features not available in this version are ignored, they are rejected by the cluster validation.
+func AddFeatures(cfg YamlDynamicConfig, features []v1beta1.ServerFeature, v *version.Version) {
+	for _, feature := range features {
+		keys, err := FeatureDynamicConfigKeys(feature, v)
+		if err != nil {
+			continue
+		}
+
+		for _, key := range keys {
+			addConstrainedValue(cfg, key, map[string]any{}, true)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal/config"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureDynamicConfigKeys(t *testing.T) {
+	tests := map[string]struct {
+		feature      v1beta1.ServerFeature
+		version      string
+		expectedKeys []string
+		expectedErr  string
+	}{
+		"update workflow on 1.20": {
+			feature:      v1beta1.UpdateWorkflowExecutionServerFeature,
+			version:      "1.20.3",
+			expectedKeys: []string{"frontend.enableUpdateWorkflowExecution"},
+		},
+		"update workflow on 1.23": {
+			feature:      v1beta1.UpdateWorkflowExecutionServerFeature,
+			version:      "1.23.0",
+			expectedKeys: []string{"frontend.enableUpdateWorkflowExecution", "frontend.enableUpdateWorkflowExecutionAsyncAccepted"},
+		},
+		"eager workflow start not available": {
+			feature:     v1beta1.EagerWorkflowStartServerFeature,
+			version:     "1.21.0",
+			expectedErr: "feature EagerWorkflowStart requires temporal version 1.22.0 or later",
+		},
+		"unknown feature": {
+			feature:     v1beta1.ServerFeature("Unknown"),
+			version:     "1.23.0",
+			expectedErr: `unknown feature "Unknown"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			keys, err := config.FeatureDynamicConfigKeys(test.feature, version.MustNewVersionFromString(test.version))
+			if test.expectedErr != "" {
+				assert.EqualError(tt, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expectedKeys, keys)
+		})
+	}
+}
+
+func TestAddFeatures(t *testing.T) {
+	cfg := config.YamlDynamicConfig{
+		"system.enableActivityEagerExecution": {
+			{
+				Constraints: map[string]any{},
+				Value:       false,
+			},
+		},
+	}
+
+	features := []v1beta1.ServerFeature{
+		v1beta1.EagerActivitiesServerFeature,
+		v1beta1.EagerWorkflowStartServerFeature,
+		v1beta1.NexusServerFeature,
+	}
+	config.AddFeatures(cfg, features, version.MustNewVersionFromString("1.22.0"))
+
+	expected := config.YamlDynamicConfig{
+		// Explicit values take precedence.
+		"system.enableActivityEagerExecution": {
+			{
+				Constraints: map[string]any{},
+				Value:       false,
+			},
+		},
+		"system.enableEagerWorkflowStart": {
+			{
+				Constraints: map[string]any{},
+				Value:       true,
+			},
+		},
+	}
+
+	assert.Equal(t, expected, cfg)
+}
@@ -0,0 +1,62 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import "github.com/alexandrevilain/temporal-operator/api/v1beta1"
+
+// queueProcessorProfiles holds the dynamic config values applied by each queue processor profile.
+// Values are vetted against temporal's defaults (poll interval: 1m for transfer & visibility, 5m for timer,
+// batch size: 100, poll RPS: 20, scheduler workers: 512).
+var queueProcessorProfiles = map[v1beta1.QueueProcessorProfile]map[string]any{
+	v1beta1.LowLatencyQueueProcessorProfile: {
+		"history.transferProcessorMaxPollInterval":   "10s",
+		"history.timerProcessorMaxPollInterval":      "1m",
+		"history.visibilityProcessorMaxPollInterval": "10s",
+		"history.transferProcessorMaxPollRPS":        float64(50),
+		"history.timerProcessorMaxPollRPS":           float64(50),
+		"history.visibilityProcessorMaxPollRPS":      float64(50),
+	},
+	v1beta1.HighThroughputQueueProcessorProfile: {
+		"history.transferTaskBatchSize":                   float64(500),
+		"history.timerTaskBatchSize":                      float64(500),
+		"history.visibilityTaskBatchSize":                 float64(500),
+		"history.transferProcessorMaxPollRPS":             float64(50),
+		"history.timerProcessorMaxPollRPS":                float64(50),
+		"history.visibilityProcessorMaxPollRPS":           float64(50),
+		"history.transferProcessorSchedulerWorkerCount":   float64(1024),
+		"history.timerProcessorSchedulerWorkerCount":      float64(1024),
+		"history.visibilityProcessorSchedulerWorkerCount": float64(1024),
+	},
+	v1beta1.ResourceConstrainedQueueProcessorProfile: {
+		"history.transferTaskBatchSize":                   float64(50),
+		"history.timerTaskBatchSize":                      float64(50),
+		"history.visibilityTaskBatchSize":                 float64(50),
+		"history.transferProcessorMaxPollRPS":             float64(10),
+		"history.timerProcessorMaxPollRPS":                float64(10),
+		"history.visibilityProcessorMaxPollRPS":           float64(10),
+		"history.transferProcessorSchedulerWorkerCount":   float64(64),
+		"history.timerProcessorSchedulerWorkerCount":      float64(64),
+		"history.visibilityProcessorSchedulerWorkerCount": float64(64),
+	},
+}
+
+// queueProcessorProfileValues returns the dynamic config values for the provided profile.
+// It returns nil for an empty or unknown profile.
+func queueProcessorProfileValues(profile v1beta1.QueueProcessorProfile) map[string]any {
+	return queueProcessorProfiles[profile]
+}
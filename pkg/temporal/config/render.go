@@ -0,0 +1,230 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+)
+
+// dynamicConfigLayer adds the values rendered from a spec field to the dynamic config.
+type dynamicConfigLayer struct {
+	source v1beta1.DynamicConfigSource
+	add    func(cfg YamlDynamicConfig) error
+}
+
+// dynamicConfigLayers returns the layers of the cluster dynamic config, by decreasing precedence.
+func dynamicConfigLayers(cluster *v1beta1.TemporalCluster, namespaces []v1beta1.TemporalNamespace) []dynamicConfigLayer {
+	dc := cluster.Spec.DynamicConfig
+
+	layers := []dynamicConfigLayer{
+		{
+			source: v1beta1.ValuesDynamicConfigSource,
+			add: func(cfg YamlDynamicConfig) error {
+				values, err := DynamicConfigToYamlDynamicConfig(&v1beta1.DynamicConfigSpec{Values: dc.Values})
+				if err != nil {
+					return err
+				}
+				for key, value := range values {
+					cfg[key] = value
+				}
+				return nil
+			},
+		},
+		{
+			// Keys set in values replace the whole profile value, whatever their constraints.
+			source: v1beta1.QueueProcessorProfileDynamicConfigSource,
+			add: func(cfg YamlDynamicConfig) error {
+				for key, value := range queueProcessorProfileValues(dc.QueueProcessorProfile) {
+					if _, ok := cfg[key]; ok {
+						continue
+					}
+					cfg[key] = []YamlConstrainedValue{
+						{
+							Constraints: map[string]any{},
+							Value:       value,
+						},
+					}
+				}
+				return nil
+			},
+		},
+	}
+
+	if dc.AutoTune && cluster.Spec.Services != nil {
+		layers = append(layers, dynamicConfigLayer{
+			source: v1beta1.AutoTuneDynamicConfigSource,
+			add: func(cfg YamlDynamicConfig) error {
+				AddAutoTunedValues(cfg, AutoTuneRecommendations(cluster.Spec.NumHistoryShards, cluster.Spec.Services.History))
+				return nil
+			},
+		})
+	}
+
+	layers = append(layers,
+		dynamicConfigLayer{
+			source: v1beta1.FeaturesDynamicConfigSource,
+			add: func(cfg YamlDynamicConfig) error {
+				AddFeatures(cfg, cluster.Spec.Features, cluster.Spec.Version)
+				return nil
+			},
+		},
+		dynamicConfigLayer{
+			source: v1beta1.GracefulShutdownDynamicConfigSource,
+			add: func(cfg YamlDynamicConfig) error {
+				AddServicesShutdownDrain(cfg, cluster.Spec.Services)
+				return nil
+			},
+		},
+		dynamicConfigLayer{
+			source: v1beta1.PersistenceRateLimitsDynamicConfigSource,
+			add: func(cfg YamlDynamicConfig) error {
+				AddPersistenceRateLimits(cfg, cluster.Spec.Persistence.RateLimits)
+				return nil
+			},
+		},
+		dynamicConfigLayer{
+			source: v1beta1.NamespaceRateLimitsDynamicConfigSource,
+			add: func(cfg YamlDynamicConfig) error {
+				AddNamespacesRateLimits(cfg, namespaces)
+				return nil
+			},
+		},
+		dynamicConfigLayer{
+			source: v1beta1.NamespaceTaskQueuesDynamicConfigSource,
+			add: func(cfg YamlDynamicConfig) error {
+				AddNamespacesTaskQueues(cfg, namespaces)
+				return nil
+			},
+		},
+		dynamicConfigLayer{
+			source: v1beta1.NamespaceLimitsDynamicConfigSource,
+			add: func(cfg YamlDynamicConfig) error {
+				AddNamespacesLimits(cfg, namespaces)
+				return nil
+			},
+		},
+	)
+
+	if cluster.Spec.Persistence.SecondaryVisibilityStore != nil {
+		layers = append(layers, dynamicConfigLayer{
+			source: v1beta1.NamespaceVisibilityStoreDynamicConfigSource,
+			add: func(cfg YamlDynamicConfig) error {
+				AddNamespacesVisibilityStores(cfg, namespaces)
+				return nil
+			},
+		})
+	}
+
+	if dc.VersionDefaults {
+		// The built-in defaults have the lowest precedence: any value set by the user,
+		// a profile or derived from the cluster spec overrides them.
+		layers = append(layers, dynamicConfigLayer{
+			source: v1beta1.VersionDefaultsDynamicConfigSource,
+			add: func(cfg YamlDynamicConfig) error {
+				AddVersionDefaults(cfg, cluster.Spec.Version)
+				return nil
+			},
+		})
+	}
+
+	return layers
+}
+
+// RenderDynamicConfig renders the effective dynamic config of the provided cluster: the values set in
+// spec.dynamicConfig merged with the values the operator derives from the other cluster and namespaces spec fields.
+// It also reports, for each key, the spec fields its values are rendered from, and the ones overridden by
+// a value with the same constraints set by a source taking precedence.
+func RenderDynamicConfig(cluster *v1beta1.TemporalCluster, namespaces []v1beta1.TemporalNamespace) (YamlDynamicConfig, []v1beta1.DynamicConfigKeyStatus, error) {
+	cfg := YamlDynamicConfig{}
+	owners := map[string]v1beta1.DynamicConfigSource{}
+	keys := map[string]*v1beta1.DynamicConfigKeyStatus{}
+
+	for _, layer := range dynamicConfigLayers(cluster, namespaces) {
+		// Render the layer alone to know which values it sets.
+		layerValues := YamlDynamicConfig{}
+		err := layer.add(layerValues)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't render %s dynamic config values: %w", layer.source, err)
+		}
+
+		err = layer.add(cfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't render %s dynamic config values: %w", layer.source, err)
+		}
+
+		for key, values := range layerValues {
+			status, ok := keys[key]
+			if !ok {
+				status = &v1beta1.DynamicConfigKeyStatus{Key: key}
+				keys[key] = status
+			}
+
+			for _, value := range values {
+				id := constrainedValueID(key, value.Constraints)
+				owner, owned := owners[id]
+				switch {
+				case !owned && hasConstraints(cfg[key], value.Constraints):
+					owners[id] = layer.source
+					status.Sources = appendSource(status.Sources, layer.source)
+				case !owned || owner != layer.source:
+					status.OverriddenSources = appendSource(status.OverriddenSources, layer.source)
+				}
+			}
+		}
+	}
+
+	result := make([]v1beta1.DynamicConfigKeyStatus, 0, len(keys))
+	for _, status := range keys {
+		result = append(result, *status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return cfg, result, nil
+}
+
+// constrainedValueID identifies the value of a key for the provided constraints.
+func constrainedValueID(key string, constraints map[string]any) string {
+	// Maps are marshaled with sorted keys, making the id stable.
+	b, _ := json.Marshal(constraints)
+	return key + string(b)
+}
+
+func hasConstraints(values []YamlConstrainedValue, constraints map[string]any) bool {
+	for _, value := range values {
+		if reflect.DeepEqual(value.Constraints, constraints) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendSource(sources []v1beta1.DynamicConfigSource, source v1beta1.DynamicConfigSource) []v1beta1.DynamicConfigSource {
+	for _, s := range sources {
+		if s == source {
+			return sources
+		}
+	}
+	return append(sources, source)
+}
@@ -18,6 +18,9 @@
 package temporal
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/pkg/temporal/archival"
 	"go.temporal.io/api/enums/v1"
@@ -58,7 +61,7 @@ func NamespaceToRegisterNamespaceRequest(cluster *v1beta1.TemporalCluster, names
 			}
 
 			re.VisibilityArchivalState = state
-			re.VisibilityArchivalUri = archival.URI(cluster.Spec.Archival.Provider, namespace.Spec.Archival.Visibility)
+			re.VisibilityArchivalUri = archival.URI(cluster.Spec.Archival.GetVisibilityProvider(), namespace.Spec.Archival.Visibility)
 		}
 	}
 
@@ -78,7 +81,7 @@ func NamespaceToRegisterNamespaceRequest(cluster *v1beta1.TemporalCluster, names
 			}
 		}
 
-		re.ActiveClusterName = namespace.Spec.ActiveClusterName
+		re.ActiveClusterName = namespace.GetActiveClusterName(cluster)
 	}
 
 	return re
@@ -123,7 +126,7 @@ func NamespaceToUpdateNamespaceRequest(cluster *v1beta1.TemporalCluster, namespa
 			}
 
 			re.Config.VisibilityArchivalState = state
-			re.Config.VisibilityArchivalUri = archival.URI(cluster.Spec.Archival.Provider, namespace.Spec.Archival.Visibility)
+			re.Config.VisibilityArchivalUri = archival.URI(cluster.Spec.Archival.GetVisibilityProvider(), namespace.Spec.Archival.Visibility)
 		}
 	}
 
@@ -143,8 +146,74 @@ func NamespaceToUpdateNamespaceRequest(cluster *v1beta1.TemporalCluster, namespa
 			}
 		}
 
-		re.ReplicationConfig.ActiveClusterName = namespace.Spec.ActiveClusterName
+		// Changing the active cluster name fails over the namespace.
+		re.ReplicationConfig.ActiveClusterName = namespace.GetActiveClusterName(cluster)
 	}
 
 	return re
 }
+
+// NamespaceToSearchAttributeAliasesUpdateRequests returns the requests syncing the namespace search attribute aliases
+// with the desired ones. current is the field name to alias mapping currently set on the namespace.
+// Aliases previously applied by the operator (found in the namespace status) and no longer desired are removed.
+// Temporal refuses to allocate an already aliased field, so changed aliases are removed in a first request
+// before being set in a second one.
+func NamespaceToSearchAttributeAliasesUpdateRequests(namespace *v1beta1.TemporalNamespace, current map[string]string) ([]*workflowservice.UpdateNamespaceRequest, error) {
+	desired := map[string]string{}
+	aliases := make([]string, 0, len(namespace.Spec.SearchAttributeAliases))
+	for alias := range namespace.Spec.SearchAttributeAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		field := namespace.Spec.SearchAttributeAliases[alias]
+		if other, ok := desired[field]; ok {
+			return nil, fmt.Errorf("search attribute field %q can't be aliased by both %q and %q", field, other, alias)
+		}
+		desired[field] = alias
+	}
+
+	removals := map[string]string{}
+	for field, alias := range current {
+		if desiredAlias, ok := desired[field]; ok {
+			if desiredAlias != alias {
+				removals[field] = ""
+			}
+			continue
+		}
+		if namespace.Status.SearchAttributeAliases[alias] == field {
+			removals[field] = ""
+		}
+	}
+
+	additions := map[string]string{}
+	for field, alias := range desired {
+		if current[field] == alias {
+			continue
+		}
+		for currentField, currentAlias := range current {
+			if currentAlias != alias {
+				continue
+			}
+			if _, removed := removals[currentField]; !removed {
+				return nil, fmt.Errorf("search attribute alias %q is already used by field %q", alias, currentField)
+			}
+		}
+		additions[field] = alias
+	}
+
+	requests := []*workflowservice.UpdateNamespaceRequest{}
+	for _, changes := range []map[string]string{removals, additions} {
+		if len(changes) == 0 {
+			continue
+		}
+		requests = append(requests, &workflowservice.UpdateNamespaceRequest{
+			Namespace: namespace.GetName(),
+			Config: &namespacev1.NamespaceConfig{
+				CustomSearchAttributeAliases: changes,
+			},
+		})
+	}
+
+	return requests, nil
+}
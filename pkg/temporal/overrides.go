@@ -0,0 +1,68 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyClusterOverrides applies the TemporalClusterOverrides selecting the provided cluster to its spec,
+// and returns the names of the applied overrides. The resulting spec is the effective spec the operator uses
+// to render the cluster resources, and to reach the cluster. It must only be applied to the stored cluster spec.
+func ApplyClusterOverrides(ctx context.Context, c client.Reader, cluster *v1beta1.TemporalCluster) ([]string, error) {
+	overrides := &v1beta1.TemporalClusterOverrideList{}
+	err := c.List(ctx, overrides, client.InNamespace(cluster.GetNamespace()))
+	if err != nil {
+		return nil, fmt.Errorf("can't list cluster overrides: %w", err)
+	}
+
+	applied, err := v1beta1.ApplyClusterOverrides(cluster, overrides.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(applied) > 0 {
+		// Overrides can add fields relying on default values, like new services or datastores.
+		cluster.Default()
+	}
+
+	return applied, nil
+}
+
+// GetEffectiveCluster returns the cluster with the provided key, with its overrides applied.
+// Resources reaching a cluster must use it instead of the stored cluster, as overrides can change
+// the settings used to connect to the cluster, like its mTLS settings or ports.
+func GetEffectiveCluster(ctx context.Context, c client.Reader, key types.NamespacedName) (*v1beta1.TemporalCluster, error) {
+	cluster := &v1beta1.TemporalCluster{}
+	err := c.Get(ctx, key, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = ApplyClusterOverrides(ctx, c, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("can't apply cluster %s overrides: %w", key.Name, err)
+	}
+
+	return cluster, nil
+}
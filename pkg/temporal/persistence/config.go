@@ -62,6 +62,7 @@ func NewElasticsearchConfigFromDatastoreSpec(spec *v1beta1.DatastoreSpec) (*escl
 		CloseIdleConnectionsInterval: spec.Elasticsearch.CloseIdleConnectionsInterval.Duration,
 		EnableSniff:                  spec.Elasticsearch.EnableSniff,
 		EnableHealthcheck:            spec.Elasticsearch.EnableSniff,
+		TLS:                          tlsConfigConfigFromDatastoreSpec(spec),
 	}, nil
 }
 
@@ -105,7 +106,7 @@ func NewCassandraConfigFromDatastoreSpec(spec *v1beta1.DatastoreSpec) *config.Ca
 		}
 
 		if spec.Cassandra.Consistency.SerialConsistency != nil {
-			cfg.Consistency.Default.Consistency = spec.Cassandra.Consistency.SerialConsistency.String()
+			cfg.Consistency.Default.SerialConsistency = spec.Cassandra.Consistency.SerialConsistency.String()
 		}
 	}
 	return cfg
@@ -0,0 +1,78 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package persistence_test
+
+import (
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal/persistence"
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/server/common/config"
+	"k8s.io/utils/ptr"
+)
+
+func TestNewCassandraConfigFromDatastoreSpecConsistency(t *testing.T) {
+	tests := map[string]struct {
+		consistency *v1beta1.CassandraConsistencySpec
+		expected    *config.CassandraStoreConsistency
+	}{
+		"no consistency": {
+			consistency: nil,
+			expected:    &config.CassandraStoreConsistency{},
+		},
+		"consistency and serial consistency": {
+			consistency: &v1beta1.CassandraConsistencySpec{
+				Consistency:       ptr.To(gocql.LocalOne),
+				SerialConsistency: ptr.To(gocql.Serial),
+			},
+			expected: &config.CassandraStoreConsistency{
+				Default: &config.CassandraConsistencySettings{
+					Consistency:       "LOCAL_ONE",
+					SerialConsistency: "SERIAL",
+				},
+			},
+		},
+		"serial consistency only": {
+			consistency: &v1beta1.CassandraConsistencySpec{
+				SerialConsistency: ptr.To(gocql.LocalSerial),
+			},
+			expected: &config.CassandraStoreConsistency{
+				Default: &config.CassandraConsistencySettings{
+					SerialConsistency: "LOCAL_SERIAL",
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			spec := &v1beta1.DatastoreSpec{
+				Cassandra: &v1beta1.CassandraSpec{
+					Hosts:       []string{"cassandra"},
+					Keyspace:    "temporal",
+					Consistency: test.consistency,
+				},
+			}
+
+			cfg := persistence.NewCassandraConfigFromDatastoreSpec(spec)
+			assert.Equal(tt, test.expected, cfg.Consistency)
+		})
+	}
+}
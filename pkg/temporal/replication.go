@@ -0,0 +1,143 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/server/api/historyservice/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetRemoteClustersReplicationStatus returns the connection health and the replication lag to each provided remote cluster.
+// The lag is the maximum, across all history shards, of the time elapsed since the oldest replication task
+// not yet acknowledged by the remote cluster was created.
+// All the cluster history hosts must be provided, as each of them only reports the status of the shards it owns.
+func GetRemoteClustersReplicationStatus(ctx context.Context, operator operatorservice.OperatorServiceClient, histories []historyservice.HistoryServiceClient, remoteClusters []v1beta1.RemoteClusterSpec) ([]v1beta1.RemoteClusterReplicationStatus, error) {
+	clusters, err := listClusters(ctx, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	connected := map[string]bool{}
+	for _, cluster := range clusters {
+		connected[cluster.GetClusterName()] = cluster.GetIsConnectionEnabled()
+	}
+
+	names := make([]string, 0, len(remoteClusters))
+	for _, remote := range remoteClusters {
+		names = append(names, remote.Name)
+	}
+
+	lags := map[string]time.Duration{}
+	for _, history := range histories {
+		status, err := history.GetReplicationStatus(ctx, &historyservice.GetReplicationStatusRequest{
+			RemoteClusters: names,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("can't get replication status: %w", err)
+		}
+
+		for _, shard := range status.GetShards() {
+			for name, remote := range shard.GetRemoteClusters() {
+				if remote.GetAckedTaskId() >= shard.GetMaxReplicationTaskId() || remote.GetAckedTaskVisibilityTime() == nil {
+					continue
+				}
+				lag := shard.GetShardLocalTime().AsTime().Sub(remote.GetAckedTaskVisibilityTime().AsTime())
+				if lag > lags[name] {
+					lags[name] = lag
+				}
+			}
+		}
+	}
+
+	result := make([]v1beta1.RemoteClusterReplicationStatus, 0, len(remoteClusters))
+	for _, remote := range remoteClusters {
+		isConnected, registered := connected[remote.Name]
+		remoteStatus := v1beta1.RemoteClusterReplicationStatus{
+			Name:      remote.Name,
+			Connected: registered && isConnected,
+			Lag:       &metav1.Duration{Duration: lags[remote.Name]},
+		}
+		switch {
+		case !registered:
+			remoteStatus.Message = "remote cluster is not registered"
+		case !isConnected:
+			remoteStatus.Message = "remote cluster connection is disabled"
+		}
+		result = append(result, remoteStatus)
+	}
+
+	return result, nil
+}
+
+// RegisterRemoteClusters registers the provided remote clusters, or updates their frontend address when it changed.
+// It returns the registration error of each remote cluster that couldn't be registered, by remote cluster name.
+func RegisterRemoteClusters(ctx context.Context, operator operatorservice.OperatorServiceClient, remoteClusters []v1beta1.RemoteClusterConnection) (map[string]error, error) {
+	clusters, err := listClusters(ctx, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	registered := map[string]*operatorservice.ClusterMetadata{}
+	for _, cluster := range clusters {
+		registered[cluster.GetClusterName()] = cluster
+	}
+
+	errs := map[string]error{}
+	for _, remote := range remoteClusters {
+		cluster, ok := registered[remote.Name]
+		if ok && cluster.GetAddress() == remote.Address && cluster.GetIsConnectionEnabled() {
+			continue
+		}
+
+		_, err := operator.AddOrUpdateRemoteCluster(ctx, &operatorservice.AddOrUpdateRemoteClusterRequest{
+			FrontendAddress:               remote.Address,
+			EnableRemoteClusterConnection: true,
+		})
+		if err != nil {
+			errs[remote.Name] = err
+		}
+	}
+
+	return errs, nil
+}
+
+func listClusters(ctx context.Context, operator operatorservice.OperatorServiceClient) ([]*operatorservice.ClusterMetadata, error) {
+	clusters := []*operatorservice.ClusterMetadata{}
+	var nextPageToken []byte
+	for {
+		res, err := operator.ListClusters(ctx, &operatorservice.ListClustersRequest{
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("can't list clusters: %w", err)
+		}
+		clusters = append(clusters, res.GetClusters()...)
+		nextPageToken = res.GetNextPageToken()
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+
+	return clusters, nil
+}
@@ -0,0 +1,100 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+var (
+	// Log lines of the temporal schema tools update task.
+	// See: https://github.com/temporalio/temporal/blob/v1.23.0/tools/common/schema/updatetask.go
+	runningUpdatesRegex   = regexp.MustCompile(`running (\d+) updates for current version (\S+)`)
+	zeroUpdatesRegex      = regexp.MustCompile(`found zero updates from current version (\S+)`)
+	executingUpdatesRegex = regexp.MustCompile(`---- Executing updates for version (\S+) ----`)
+	schemaUpdatedRegex    = regexp.MustCompile(`Schema updated from \S+ to (\S+)`)
+)
+
+// UpdateProgress is the progress of a schema update, as reported by the schema tools.
+type UpdateProgress struct {
+	// CurrentVersion is the last schema version applied to the datastore.
+	CurrentVersion string
+	// ApplyingVersion is the schema version being applied, if any.
+	ApplyingVersion string
+	// AppliedSteps is the number of schema versions applied so far.
+	AppliedSteps int32
+	// TotalSteps is the number of schema versions to apply, zero until known.
+	TotalSteps int32
+}
+
+// ParseUpdateProgress reads the schema tools update-schema output and returns the update progress.
+// It returns nil if the output doesn't contain any progress information.
+func ParseUpdateProgress(r io.Reader) (*UpdateProgress, error) {
+	var progress *UpdateProgress
+
+	scanner := bufio.NewScanner(r)
+	// Schema statements can be long, allow lines up to 1MiB.
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if matches := runningUpdatesRegex.FindStringSubmatch(line); matches != nil {
+			total, err := strconv.ParseInt(matches[1], 10, 32)
+			if err != nil {
+				return nil, err
+			}
+			progress = &UpdateProgress{
+				CurrentVersion: matches[2],
+				TotalSteps:     int32(total),
+			}
+			continue
+		}
+
+		if matches := zeroUpdatesRegex.FindStringSubmatch(line); matches != nil {
+			progress = &UpdateProgress{
+				CurrentVersion: matches[1],
+			}
+			continue
+		}
+
+		if progress == nil {
+			continue
+		}
+
+		if matches := executingUpdatesRegex.FindStringSubmatch(line); matches != nil {
+			progress.ApplyingVersion = matches[1]
+			continue
+		}
+
+		if matches := schemaUpdatedRegex.FindStringSubmatch(line); matches != nil {
+			progress.CurrentVersion = matches[1]
+			progress.ApplyingVersion = ""
+			progress.AppliedSteps++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
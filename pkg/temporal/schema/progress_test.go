@@ -0,0 +1,74 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUpdateProgress(t *testing.T) {
+	tests := map[string]struct {
+		output   string
+		expected *schema.UpdateProgress
+	}{
+		"no progress": {
+			output:   "2024-04-01T10:00:00.000Z\tINFO\tUpdateSchemaTask started\n",
+			expected: nil,
+		},
+		"no updates": {
+			output: strings.Join([]string{
+				"2024-04-01T10:00:00.000Z\tINFO\tUpdateSchemaTask started",
+				"2024-04-01T10:00:00.100Z\tDEBUG\tfound zero updates from current version 1.9",
+				"2024-04-01T10:00:00.200Z\tINFO\tUpdateSchemaTask done",
+			}, "\n"),
+			expected: &schema.UpdateProgress{
+				CurrentVersion: "1.9",
+			},
+		},
+		"update in progress": {
+			output: strings.Join([]string{
+				"2024-04-01T10:00:00.000Z\tINFO\tUpdateSchemaTask started",
+				"2024-04-01T10:00:00.100Z\tDEBUG\trunning 3 updates for current version 1.6",
+				"2024-04-01T10:00:00.200Z\tDEBUG\t---- Executing updates for version 1.7 ----",
+				"2024-04-01T10:00:00.300Z\tDEBUG\tALTER TABLE executions ADD COLUMN foo blob;",
+				"2024-04-01T10:00:00.400Z\tDEBUG\t---- Done ----",
+				"2024-04-01T10:00:00.500Z\tDEBUG\tSchema updated from 1.6 to 1.7",
+				"2024-04-01T10:00:00.600Z\tDEBUG\t---- Executing updates for version 1.8 ----",
+			}, "\n"),
+			expected: &schema.UpdateProgress{
+				CurrentVersion:  "1.7",
+				ApplyingVersion: "1.8",
+				AppliedSteps:    1,
+				TotalSteps:      3,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			progress, err := schema.ParseUpdateProgress(strings.NewReader(test.output))
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expected, progress)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.temporal.io/server/api/historyservice/v1"
+)
+
+// staleShardThreshold is the age after which the persisted info of an owned shard is reported as stale.
+// History hosts persist their shards info at least every 5 minutes by default (history.shardUpdateMinInterval).
+const staleShardThreshold = 15 * time.Minute
+
+// ShardState is the state of an inspected history shard.
+type ShardState string
+
+const (
+	// ShardHealthy is the state of shards owned by a ready history host and persisted recently.
+	ShardHealthy ShardState = "Healthy"
+	// ShardUnowned is the state of shards not owned by any ready history host.
+	ShardUnowned ShardState = "Unowned"
+	// ShardUnreachable is the state of shards whose info can't be retrieved.
+	ShardUnreachable ShardState = "Unreachable"
+	// ShardStolen is the state of shards whose ownership changed since their last renewal.
+	ShardStolen ShardState = "Stolen"
+	// ShardStale is the state of shards whose info wasn't persisted recently by their owner.
+	ShardStale ShardState = "Stale"
+)
+
+// ShardReport is the inspection result of a history shard.
+type ShardReport struct {
+	ShardID int32      `json:"shardId"`
+	State   ShardState `json:"state"`
+	// Host is the address of the history host owning the shard, according to the cluster membership.
+	Host string `json:"host,omitempty"`
+	// Owner is the owner identity persisted in the shard info.
+	Owner            string     `json:"owner,omitempty"`
+	RangeID          int64      `json:"rangeId,omitempty"`
+	StolenSinceRenew int32      `json:"stolenSinceRenew,omitempty"`
+	UpdateTime       *time.Time `json:"updateTime,omitempty"`
+	Error            string     `json:"error,omitempty"`
+}
+
+// ShardDiagnostics is the inspection result of a range of history shards.
+type ShardDiagnostics struct {
+	// HostShards is the number of shards owned by each ready history host, across the whole cluster.
+	HostShards map[string]int32 `json:"hostShards"`
+	// Shards are the inspected shards reports, ordered by shard id.
+	Shards []ShardReport `json:"shards"`
+}
+
+// Count returns the number of inspected shards in the provided state.
+func (d *ShardDiagnostics) Count(state ShardState) int32 {
+	count := int32(0)
+	for _, shard := range d.Shards {
+		if shard.State == state {
+			count++
+		}
+	}
+	return count
+}
+
+// ParseShardRange parses a shard range as accepted by the temporal.io/diagnose-shards annotation:
+// "all", a shard id or a range of shard ids like "1-128". Shard ids start at 1.
+func ParseShardRange(value string, shardCount int32) (int32, int32, error) {
+	value = strings.TrimSpace(value)
+	if value == "all" {
+		return 1, shardCount, nil
+	}
+
+	firstValue, lastValue, isRange := strings.Cut(value, "-")
+	first, err := strconv.ParseInt(strings.TrimSpace(firstValue), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid shard range %q", value)
+	}
+	last := first
+	if isRange {
+		last, err = strconv.ParseInt(strings.TrimSpace(lastValue), 10, 32)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid shard range %q", value)
+		}
+	}
+
+	if first < 1 || last < first || last > int64(shardCount) {
+		return 0, 0, fmt.Errorf("invalid shard range %q: shard ids must be between 1 and %d", value, shardCount)
+	}
+
+	return int32(first), int32(last), nil
+}
+
+// DiagnoseShards inspects the history shards from first to last, both included.
+// All the cluster ready history hosts must be provided, by address, as each of them only reports the shards it owns.
+func DiagnoseShards(ctx context.Context, histories map[string]historyservice.HistoryServiceClient, first, last int32, now time.Time) (*ShardDiagnostics, error) {
+	if len(histories) == 0 {
+		return nil, errors.New("no history host to inspect")
+	}
+
+	addresses := make([]string, 0, len(histories))
+	for address := range histories {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	diagnostics := &ShardDiagnostics{
+		HostShards: map[string]int32{},
+		Shards:     make([]ShardReport, 0, last-first+1),
+	}
+
+	owners := map[int32]string{}
+	for _, address := range addresses {
+		host, err := histories[address].DescribeHistoryHost(ctx, &historyservice.DescribeHistoryHostRequest{})
+		if err != nil {
+			return nil, fmt.Errorf("can't describe history host %s: %w", address, err)
+		}
+		diagnostics.HostShards[address] = host.GetShardsNumber()
+		for _, shardID := range host.GetShardIds() {
+			owners[shardID] = address
+		}
+	}
+
+	for shardID := first; shardID <= last; shardID++ {
+		report := ShardReport{
+			ShardID: shardID,
+			Host:    owners[shardID],
+		}
+
+		// Shards info are read from the persistence: any host can return the info of a shard it doesn't own.
+		history := histories[addresses[0]]
+		if report.Host != "" {
+			history = histories[report.Host]
+		}
+
+		res, err := history.GetShard(ctx, &historyservice.GetShardRequest{ShardId: shardID})
+		if err != nil {
+			report.Error = err.Error()
+		} else {
+			info := res.GetShardInfo()
+			report.Owner = info.GetOwner()
+			report.RangeID = info.GetRangeId()
+			report.StolenSinceRenew = info.GetStolenSinceRenew()
+			if info.GetUpdateTime() != nil {
+				updateTime := info.GetUpdateTime().AsTime()
+				report.UpdateTime = &updateTime
+			}
+		}
+
+		report.State = shardState(report, now)
+		diagnostics.Shards = append(diagnostics.Shards, report)
+	}
+
+	return diagnostics, nil
+}
+
+func shardState(report ShardReport, now time.Time) ShardState {
+	switch {
+	case report.Host == "":
+		return ShardUnowned
+	case report.Error != "":
+		return ShardUnreachable
+	case report.StolenSinceRenew > 0:
+		return ShardStolen
+	case report.UpdateTime == nil || now.Sub(*report.UpdateTime) > staleShardThreshold:
+		return ShardStale
+	default:
+		return ShardHealthy
+	}
+}
+
+// ShardsWarmupTarget returns the number of shards a history host must own to be considered warm:
+// half of its even share of the shards, as the membership ring doesn't spread them evenly.
+func ShardsWarmupTarget(shardCount, hosts int32) int32 {
+	if hosts <= 0 {
+		hosts = 1
+	}
+	return max(1, shardCount/hosts/2)
+}
+
+// OwnedShardsCount returns the number of shards owned by the provided history host.
+func OwnedShardsCount(ctx context.Context, history historyservice.HistoryServiceClient) (int32, error) {
+	host, err := history.DescribeHistoryHost(ctx, &historyservice.DescribeHistoryHostRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("can't describe history host: %w", err)
+	}
+	return host.GetShardsNumber(), nil
+}
@@ -0,0 +1,134 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/server/api/historyservice/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeHistoryHost struct {
+	historyservice.HistoryServiceClient
+
+	shardIDs []int32
+	shards   map[int32]*persistencespb.ShardInfo
+}
+
+func (h *fakeHistoryHost) DescribeHistoryHost(_ context.Context, _ *historyservice.DescribeHistoryHostRequest, _ ...grpc.CallOption) (*historyservice.DescribeHistoryHostResponse, error) {
+	return &historyservice.DescribeHistoryHostResponse{
+		ShardsNumber: int32(len(h.shardIDs)),
+		ShardIds:     h.shardIDs,
+	}, nil
+}
+
+func (h *fakeHistoryHost) GetShard(_ context.Context, request *historyservice.GetShardRequest, _ ...grpc.CallOption) (*historyservice.GetShardResponse, error) {
+	info, ok := h.shards[request.GetShardId()]
+	if !ok {
+		return nil, errors.New("unavailable")
+	}
+	return &historyservice.GetShardResponse{ShardInfo: info}, nil
+}
+
+func TestParseShardRange(t *testing.T) {
+	tests := map[string]struct {
+		value       string
+		first, last int32
+		expectedErr string
+	}{
+		"all":          {value: "all", first: 1, last: 512},
+		"single shard": {value: "42", first: 42, last: 42},
+		"range":        {value: "1-128", first: 1, last: 128},
+		"shard zero":   {value: "0-10", expectedErr: "invalid shard range \"0-10\": shard ids must be between 1 and 512"},
+		"out of range": {value: "500-513", expectedErr: "invalid shard range \"500-513\": shard ids must be between 1 and 512"},
+		"reversed":     {value: "10-1", expectedErr: "invalid shard range \"10-1\": shard ids must be between 1 and 512"},
+		"invalid":      {value: "some", expectedErr: "invalid shard range \"some\""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			first, last, err := ParseShardRange(test.value, 512)
+			if test.expectedErr != "" {
+				assert.EqualError(tt, err, test.expectedErr)
+				return
+			}
+			require.NoError(tt, err)
+			assert.Equal(tt, test.first, first)
+			assert.Equal(tt, test.last, last)
+		})
+	}
+}
+
+func TestDiagnoseShards(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	recent := timestamppb.New(now.Add(-time.Minute))
+	old := timestamppb.New(now.Add(-time.Hour))
+
+	histories := map[string]historyservice.HistoryServiceClient{
+		"10.0.0.1:7234": &fakeHistoryHost{
+			shardIDs: []int32{1, 2, 3},
+			shards: map[int32]*persistencespb.ShardInfo{
+				1: {ShardId: 1, Owner: "10.0.0.1:7234", RangeId: 10, UpdateTime: recent},
+				2: {ShardId: 2, Owner: "10.0.0.1:7234", RangeId: 11, UpdateTime: old},
+				3: {ShardId: 3, Owner: "10.0.0.1:7234", RangeId: 12, StolenSinceRenew: 2, UpdateTime: recent},
+				5: {ShardId: 5, Owner: "10.0.0.3:7234", RangeId: 13, UpdateTime: old},
+			},
+		},
+		"10.0.0.2:7234": &fakeHistoryHost{
+			shardIDs: []int32{4},
+		},
+	}
+
+	diagnostics, err := DiagnoseShards(context.Background(), histories, 1, 5, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int32{"10.0.0.1:7234": 3, "10.0.0.2:7234": 1}, diagnostics.HostShards)
+
+	states := []ShardState{}
+	for _, shard := range diagnostics.Shards {
+		states = append(states, shard.State)
+	}
+	assert.Equal(t, []ShardState{ShardHealthy, ShardStale, ShardStolen, ShardUnreachable, ShardUnowned}, states)
+	assert.Equal(t, "10.0.0.3:7234", diagnostics.Shards[4].Owner)
+	assert.Equal(t, int32(1), diagnostics.Count(ShardStale))
+}
+
+func TestShardsWarmupTarget(t *testing.T) {
+	tests := map[string]struct {
+		shardCount, hosts, expected int32
+	}{
+		"even share":        {shardCount: 512, hosts: 4, expected: 64},
+		"single host":       {shardCount: 512, hosts: 1, expected: 256},
+		"more hosts":        {shardCount: 4, hosts: 8, expected: 1},
+		"no hosts reported": {shardCount: 512, hosts: 0, expected: 256},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, ShardsWarmupTarget(test.shardCount, test.hosts))
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	commandpb "go.temporal.io/api/command/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	temporalclient "go.temporal.io/sdk/client"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	syntheticWorkflowIdentity           = "temporal-operator"
+	syntheticWorkflowNamespaceRetention = 24 * time.Hour
+	syntheticWorkflowTimeout            = 5 * time.Minute
+)
+
+// syntheticWorkflow describes a trivial workflow run by the operator to check the cluster health.
+type syntheticWorkflow struct {
+	// name is used as workflow type and task queue name.
+	name string
+	// namespaceDescription is the description of the namespace registered for the workflow.
+	namespaceDescription string
+}
+
+var (
+	smokeTestWorkflow = syntheticWorkflow{
+		name:                 "temporal-operator-smoke-test",
+		namespaceDescription: "Reserved namespace for the temporal-operator smoke tests",
+	}
+	canaryWorkflow = syntheticWorkflow{
+		name:                 "temporal-operator-canary",
+		namespaceDescription: "Reserved namespace for the temporal-operator canary workflows",
+	}
+)
+
+// RunSmokeTest starts a trivial workflow in the provided namespace and completes it by acting
+// as a worker, ensuring the whole workflow lifecycle works on the cluster.
+// The provided client must be bound to the namespace, which is registered if it doesn't exist.
+func RunSmokeTest(ctx context.Context, c temporalclient.Client, namespace string) error {
+	return runSyntheticWorkflow(ctx, c, namespace, smokeTestWorkflow)
+}
+
+// RunCanary runs the canary workflow in the provided namespace, the same way as the smoke test.
+// The provided client must be bound to the namespace, which is registered if it doesn't exist.
+func RunCanary(ctx context.Context, c temporalclient.Client, namespace string) error {
+	return runSyntheticWorkflow(ctx, c, namespace, canaryWorkflow)
+}
+
+func runSyntheticWorkflow(ctx context.Context, c temporalclient.Client, namespace string, workflow syntheticWorkflow) error {
+	svc := c.WorkflowService()
+
+	_, err := svc.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: namespace,
+	})
+	if err != nil {
+		var namespaceNotFoundError *serviceerror.NamespaceNotFound
+		if !errors.As(err, &namespaceNotFoundError) {
+			return fmt.Errorf("can't describe %s namespace: %w", workflow.name, err)
+		}
+
+		_, err = svc.RegisterNamespace(ctx, &workflowservice.RegisterNamespaceRequest{
+			Namespace:                        namespace,
+			Description:                      workflow.namespaceDescription,
+			WorkflowExecutionRetentionPeriod: durationpb.New(syntheticWorkflowNamespaceRetention),
+		})
+		if err != nil {
+			return fmt.Errorf("can't register %s namespace: %w", workflow.name, err)
+		}
+	}
+
+	run, err := c.ExecuteWorkflow(ctx, temporalclient.StartWorkflowOptions{
+		ID:                       fmt.Sprintf("%s-%d", workflow.name, time.Now().UnixNano()),
+		TaskQueue:                workflow.name,
+		WorkflowExecutionTimeout: syntheticWorkflowTimeout,
+	}, workflow.name)
+	if err != nil {
+		return fmt.Errorf("can't start %s workflow: %w", workflow.name, err)
+	}
+
+	// Complete workflow tasks until the one of the started workflow is received.
+	// Tasks of workflows left over by previous runs are completed as well.
+	for {
+		task, err := svc.PollWorkflowTaskQueue(ctx, &workflowservice.PollWorkflowTaskQueueRequest{
+			Namespace: namespace,
+			TaskQueue: &taskqueuepb.TaskQueue{
+				Name: workflow.name,
+				Kind: enumspb.TASK_QUEUE_KIND_NORMAL,
+			},
+			Identity: syntheticWorkflowIdentity,
+		})
+		if err != nil {
+			return fmt.Errorf("can't poll %s workflow task: %w", workflow.name, err)
+		}
+
+		// An empty response is returned when the long poll timed out.
+		if len(task.GetTaskToken()) == 0 {
+			continue
+		}
+
+		ownTask := task.GetWorkflowExecution().GetWorkflowId() == run.GetID()
+
+		_, err = svc.RespondWorkflowTaskCompleted(ctx, &workflowservice.RespondWorkflowTaskCompletedRequest{
+			Namespace: namespace,
+			TaskToken: task.GetTaskToken(),
+			Identity:  syntheticWorkflowIdentity,
+			Commands: []*commandpb.Command{
+				{
+					CommandType: enumspb.COMMAND_TYPE_COMPLETE_WORKFLOW_EXECUTION,
+					Attributes: &commandpb.Command_CompleteWorkflowExecutionCommandAttributes{
+						CompleteWorkflowExecutionCommandAttributes: &commandpb.CompleteWorkflowExecutionCommandAttributes{},
+					},
+				},
+			},
+		})
+		if !ownTask {
+			// Failing to complete a left over workflow is not an issue.
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("can't complete %s workflow task: %w", workflow.name, err)
+		}
+
+		break
+	}
+
+	err = run.Get(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s workflow failed: %w", workflow.name, err)
+	}
+
+	return nil
+}
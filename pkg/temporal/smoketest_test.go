@@ -0,0 +1,197 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package temporal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	temporalclient "go.temporal.io/sdk/client"
+	"google.golang.org/grpc"
+)
+
+type fakeWorkflowService struct {
+	workflowservice.WorkflowServiceClient
+
+	describeErr error
+	registered  []string
+	// polls are the responses returned by successive PollWorkflowTaskQueue calls.
+	polls     []*workflowservice.PollWorkflowTaskQueueResponse
+	completed [][]byte
+}
+
+func (s *fakeWorkflowService) DescribeNamespace(_ context.Context, _ *workflowservice.DescribeNamespaceRequest, _ ...grpc.CallOption) (*workflowservice.DescribeNamespaceResponse, error) {
+	if s.describeErr != nil {
+		return nil, s.describeErr
+	}
+	return &workflowservice.DescribeNamespaceResponse{}, nil
+}
+
+func (s *fakeWorkflowService) RegisterNamespace(_ context.Context, request *workflowservice.RegisterNamespaceRequest, _ ...grpc.CallOption) (*workflowservice.RegisterNamespaceResponse, error) {
+	s.registered = append(s.registered, request.GetNamespace())
+	return &workflowservice.RegisterNamespaceResponse{}, nil
+}
+
+func (s *fakeWorkflowService) PollWorkflowTaskQueue(_ context.Context, _ *workflowservice.PollWorkflowTaskQueueRequest, _ ...grpc.CallOption) (*workflowservice.PollWorkflowTaskQueueResponse, error) {
+	if len(s.polls) == 0 {
+		return nil, errors.New("no task")
+	}
+	response := s.polls[0]
+	s.polls = s.polls[1:]
+	return response, nil
+}
+
+func (s *fakeWorkflowService) RespondWorkflowTaskCompleted(_ context.Context, request *workflowservice.RespondWorkflowTaskCompletedRequest, _ ...grpc.CallOption) (*workflowservice.RespondWorkflowTaskCompletedResponse, error) {
+	if request.GetCommands()[0].GetCommandType() != enumspb.COMMAND_TYPE_COMPLETE_WORKFLOW_EXECUTION {
+		return nil, errors.New("unexpected command")
+	}
+	s.completed = append(s.completed, request.GetTaskToken())
+	return &workflowservice.RespondWorkflowTaskCompletedResponse{}, nil
+}
+
+type fakeWorkflowRun struct {
+	temporalclient.WorkflowRun
+
+	id     string
+	getErr error
+}
+
+func (r *fakeWorkflowRun) GetID() string {
+	return r.id
+}
+
+func (r *fakeWorkflowRun) Get(_ context.Context, _ interface{}) error {
+	return r.getErr
+}
+
+type fakeTemporalClient struct {
+	temporalclient.Client
+
+	service    *fakeWorkflowService
+	run        *fakeWorkflowRun
+	executeErr error
+}
+
+func (c *fakeTemporalClient) WorkflowService() workflowservice.WorkflowServiceClient {
+	return c.service
+}
+
+func (c *fakeTemporalClient) ExecuteWorkflow(_ context.Context, _ temporalclient.StartWorkflowOptions, _ interface{}, _ ...interface{}) (temporalclient.WorkflowRun, error) {
+	if c.executeErr != nil {
+		return nil, c.executeErr
+	}
+	return c.run, nil
+}
+
+func workflowTask(token, workflowID string) *workflowservice.PollWorkflowTaskQueueResponse {
+	return &workflowservice.PollWorkflowTaskQueueResponse{
+		TaskToken: []byte(token),
+		WorkflowExecution: &commonpb.WorkflowExecution{
+			WorkflowId: workflowID,
+		},
+	}
+}
+
+func TestRunSmokeTest(t *testing.T) {
+	tests := map[string]struct {
+		describeErr        error
+		polls              []*workflowservice.PollWorkflowTaskQueueResponse
+		executeErr         error
+		getErr             error
+		expectedErr        string
+		expectedRegistered []string
+		expectedCompleted  []string
+	}{
+		"workflow completed": {
+			polls: []*workflowservice.PollWorkflowTaskQueueResponse{
+				workflowTask("own", "smoke-test"),
+			},
+			expectedCompleted: []string{"own"},
+		},
+		"namespace registered": {
+			describeErr: serviceerror.NewNamespaceNotFound("temporal-operator-smoke-test"),
+			polls: []*workflowservice.PollWorkflowTaskQueueResponse{
+				workflowTask("own", "smoke-test"),
+			},
+			expectedRegistered: []string{"temporal-operator-smoke-test"},
+			expectedCompleted:  []string{"own"},
+		},
+		"empty polls and left over workflows are skipped": {
+			polls: []*workflowservice.PollWorkflowTaskQueueResponse{
+				{},
+				workflowTask("left-over", "previous-smoke-test"),
+				workflowTask("own", "smoke-test"),
+			},
+			expectedCompleted: []string{"left-over", "own"},
+		},
+		"namespace describe failure": {
+			describeErr: serviceerror.NewUnavailable("frontend unavailable"),
+			expectedErr: "can't describe temporal-operator-smoke-test namespace: frontend unavailable",
+		},
+		"workflow start failure": {
+			executeErr:  errors.New("start failed"),
+			expectedErr: "can't start temporal-operator-smoke-test workflow: start failed",
+		},
+		"workflow task poll failure": {
+			expectedErr: "can't poll temporal-operator-smoke-test workflow task: no task",
+		},
+		"workflow failure": {
+			polls: []*workflowservice.PollWorkflowTaskQueueResponse{
+				workflowTask("own", "smoke-test"),
+			},
+			getErr:            errors.New("timeout"),
+			expectedErr:       "temporal-operator-smoke-test workflow failed: timeout",
+			expectedCompleted: []string{"own"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			service := &fakeWorkflowService{
+				describeErr: test.describeErr,
+				polls:       test.polls,
+			}
+			c := &fakeTemporalClient{
+				service:    service,
+				run:        &fakeWorkflowRun{id: "smoke-test", getErr: test.getErr},
+				executeErr: test.executeErr,
+			}
+
+			err := RunSmokeTest(context.Background(), c, "temporal-operator-smoke-test")
+			if test.expectedErr != "" {
+				assert.EqualError(tt, err, test.expectedErr)
+			} else {
+				require.NoError(tt, err)
+			}
+
+			assert.Equal(tt, test.expectedRegistered, service.registered)
+
+			var completed []string
+			for _, token := range service.completed {
+				completed = append(completed, string(token))
+			}
+			assert.Equal(tt, test.expectedCompleted, completed)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	enumspb "go.temporal.io/api/enums/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// GetTaskQueuesWorkload returns the backlog and the pollers count of each provided task queue.
+// Errors are reported per task queue in the returned status message.
+func GetTaskQueuesWorkload(ctx context.Context, workflowService workflowservice.WorkflowServiceClient, taskQueues []v1beta1.MonitoredTaskQueueSpec) []v1beta1.TaskQueueWorkloadStatus {
+	result := make([]v1beta1.TaskQueueWorkloadStatus, 0, len(taskQueues))
+	for _, taskQueue := range taskQueues {
+		taskQueueType := taskQueue.Type
+		if taskQueueType == "" {
+			taskQueueType = v1beta1.WorkflowTaskQueueType
+		}
+
+		status := v1beta1.TaskQueueWorkloadStatus{
+			Namespace: taskQueue.Namespace,
+			Name:      taskQueue.Name,
+			Type:      taskQueueType,
+		}
+
+		res, err := workflowService.DescribeTaskQueue(ctx, &workflowservice.DescribeTaskQueueRequest{
+			Namespace: taskQueue.Namespace,
+			TaskQueue: &taskqueuepb.TaskQueue{
+				Name: taskQueue.Name,
+				Kind: enumspb.TASK_QUEUE_KIND_NORMAL,
+			},
+			TaskQueueType:          toTemporalTaskQueueType(taskQueueType),
+			IncludeTaskQueueStatus: true,
+		})
+		if err != nil {
+			status.Message = fmt.Sprintf("can't describe task queue: %s", err)
+		} else {
+			status.BacklogCountHint = res.GetTaskQueueStatus().GetBacklogCountHint()
+			status.Pollers = int32(len(res.GetPollers()))
+		}
+
+		result = append(result, status)
+	}
+
+	return result
+}
+
+func toTemporalTaskQueueType(taskQueueType v1beta1.TaskQueueType) enumspb.TaskQueueType {
+	if taskQueueType == v1beta1.ActivityTaskQueueType {
+		return enumspb.TASK_QUEUE_TYPE_ACTIVITY
+	}
+	return enumspb.TASK_QUEUE_TYPE_WORKFLOW
+}
@@ -0,0 +1,334 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package temporalclient provides a shared, mTLS-aware, temporal clients manager
+// used by the operator's controllers to talk to managed temporal clusters.
+package temporalclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/networking"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	temporalclient "go.temporal.io/sdk/client"
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// connectivityProbeTimeout is the time given to the cluster frontend to accept a TCP connection
+// before connecting the temporal client.
+const connectivityProbeTimeout = 5 * time.Second
+
+// retiredClientsCloseDelay is the delay after which the clients replaced by a new connection are closed.
+// Returned clients aren't reference counted: the delay is longer than the operations using them,
+// so calls in progress complete on the previous connection.
+const retiredClientsCloseDelay = 5 * time.Minute
+
+// Manager maintains a pool of temporal clients shared by all the controllers.
+// A single gRPC connection is opened per cluster, clients bound to other temporal
+// namespaces are derived from it.
+// Connections are re-created when the cluster address or its TLS material changes,
+// so renewed certificates are picked up automatically.
+// Calls made to a cluster are bounded by the manager call options and guarded by a per cluster circuit breaker.
+type Manager struct {
+	client  client.Client
+	options *CallOptions
+
+	mu       sync.Mutex
+	clusters map[types.NamespacedName]*clusterClients
+	retired  map[*clusterClients]*time.Timer
+
+	breakersMu sync.Mutex
+	breakers   map[types.NamespacedName]*clusterBreaker
+}
+
+type clusterClients struct {
+	settings connectionSettings
+	root     temporalclient.Client
+
+	mu         sync.Mutex
+	namespaces map[string]temporalclient.Client
+}
+
+// namespace returns the client bound to the provided temporal namespace, derived from the root client.
+func (c *clusterClients) namespace(namespace string, opts temporalclient.Options) (temporalclient.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	namespaceClient, ok := c.namespaces[namespace]
+	if ok {
+		return namespaceClient, nil
+	}
+
+	opts.Namespace = namespace
+	namespaceClient, err := temporalclient.NewClientFromExisting(c.root, opts)
+	if err != nil {
+		return nil, fmt.Errorf("can't create temporal client for namespace %s: %w", namespace, err)
+	}
+	c.namespaces[namespace] = namespaceClient
+
+	return namespaceClient, nil
+}
+
+func (c *clusterClients) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, namespaceClient := range c.namespaces {
+		namespaceClient.Close()
+	}
+	c.root.Close()
+}
+
+// NewManager returns a new temporal clients manager using the provided kubernetes
+// client to get clusters TLS material, and the provided options for the calls made to the clusters.
+func NewManager(c client.Client, options *CallOptions) *Manager {
+	return &Manager{
+		client:   c,
+		options:  options,
+		clusters: map[types.NamespacedName]*clusterClients{},
+		retired:  map[*clusterClients]*time.Timer{},
+		breakers: map[types.NamespacedName]*clusterBreaker{},
+	}
+}
+
+// DialOptions returns the gRPC dial options applying the manager call options to the calls made to the provided cluster.
+// They're used by the connections to the cluster that aren't managed by the manager, like admin or history hosts connections.
+func (m *Manager) DialOptions(cluster *v1beta1.TemporalCluster) []grpc.DialOption {
+	breaker := m.breaker(client.ObjectKeyFromObject(cluster))
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(m.options.unaryInterceptor(breaker)),
+	}
+}
+
+// HostDialOptions returns the gRPC dial options applying the manager call timeouts and retries to the calls made
+// to a single temporal host, like a history host. They don't use the cluster circuit breaker, as a single
+// unavailable host doesn't make the cluster unavailable.
+func (m *Manager) HostDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(m.options.unaryInterceptor(nil)),
+	}
+}
+
+// breaker returns the circuit breaker of the provided cluster.
+func (m *Manager) breaker(key types.NamespacedName) *clusterBreaker {
+	m.breakersMu.Lock()
+	defer m.breakersMu.Unlock()
+
+	breaker, ok := m.breakers[key]
+	if !ok {
+		breaker = &clusterBreaker{}
+		m.breakers[key] = breaker
+	}
+	return breaker
+}
+
+// Client returns a temporal client for the provided cluster, bound to the provided temporal namespace.
+// If namespace is empty, the client is bound to the default namespace.
+// Returned clients are shared: callers must not close them.
+func (m *Manager) Client(ctx context.Context, cluster *v1beta1.TemporalCluster, namespace string) (temporalclient.Client, error) {
+	opts, err := temporal.BuildClusterClientOptions(ctx, m.client, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := newConnectionSettings(cluster, opts)
+	key := client.ObjectKeyFromObject(cluster)
+
+	breaker := m.breaker(key)
+	if retryIn := breaker.retryIn(m.options.CircuitBreaker, time.Now()); retryIn > 0 {
+		return nil, fmt.Errorf("%w, next attempt in %s", ErrCircuitOpen, retryIn.Round(time.Second))
+	}
+	opts.ConnectionOptions.DialOptions = append(opts.ConnectionOptions.DialOptions, m.DialOptions(cluster)...)
+
+	clients, err := m.clusterClients(ctx, key, settings, opts, breaker)
+	if err != nil {
+		return nil, err
+	}
+
+	if namespace == "" {
+		return clients.root, nil
+	}
+
+	return clients.namespace(namespace, opts)
+}
+
+// clusterClients returns the clients of the provided cluster, connecting to it if they don't exist
+// or if the connection settings changed.
+// The connection is made without holding the manager lock, so a slow cluster doesn't block the calls to the other clusters.
+func (m *Manager) clusterClients(ctx context.Context, key types.NamespacedName, settings connectionSettings, opts temporalclient.Options, breaker *clusterBreaker) (*clusterClients, error) {
+	m.mu.Lock()
+	current, ok := m.clusters[key]
+	m.mu.Unlock()
+
+	if ok {
+		if current.settings.equal(settings) {
+			return current, nil
+		}
+		log.FromContext(ctx).Info("Temporal cluster connection settings changed, reconnecting", "address", opts.HostPort)
+	}
+
+	log.FromContext(ctx).V(1).Info("Connecting to temporal cluster", "address", opts.HostPort)
+
+	// Fail fast on unreachable frontends, instead of waiting for the client health check to time out.
+	err := networking.ProbeTCP(ctx, opts.HostPort, connectivityProbeTimeout)
+	if err != nil {
+		breaker.record(true, time.Now())
+		return nil, fmt.Errorf("temporal cluster frontend is unreachable: %w", err)
+	}
+
+	root, err := temporalclient.Dial(opts)
+	if err != nil {
+		return nil, fmt.Errorf("can't create temporal client: %w", err)
+	}
+
+	clients := &clusterClients{
+		settings:   settings,
+		root:       root,
+		namespaces: map[string]temporalclient.Client{},
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok = m.clusters[key]
+	if ok && current.settings.equal(settings) {
+		// Another caller connected to the cluster in the meantime, use its clients.
+		go clients.close()
+		return current, nil
+	}
+
+	if ok {
+		m.retire(current)
+	}
+	m.clusters[key] = clients
+
+	return clients, nil
+}
+
+// retire closes the provided clients once retiredClientsCloseDelay elapsed.
+// It must be called with the manager lock held.
+func (m *Manager) retire(clients *clusterClients) {
+	m.retired[clients] = time.AfterFunc(retiredClientsCloseDelay, func() {
+		m.mu.Lock()
+		_, ok := m.retired[clients]
+		delete(m.retired, clients)
+		m.mu.Unlock()
+
+		if ok {
+			clients.close()
+		}
+	})
+}
+
+// Forget closes and removes the clients and the circuit breaker of the provided cluster.
+func (m *Manager) Forget(cluster types.NamespacedName) {
+	m.breakersMu.Lock()
+	delete(m.breakers, cluster)
+	m.breakersMu.Unlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clients, ok := m.clusters[cluster]
+	if !ok {
+		return
+	}
+
+	clients.close()
+	delete(m.clusters, cluster)
+}
+
+// Close closes all the managed clients, including the ones waiting for their calls in progress to complete.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, clients := range m.clusters {
+		clients.close()
+		delete(m.clusters, key)
+	}
+
+	for clients, timer := range m.retired {
+		timer.Stop()
+		clients.close()
+		delete(m.retired, clients)
+	}
+}
+
+// connectionSettings are the settings of a connection to a cluster:
+// any change requires a new connection to the cluster.
+type connectionSettings struct {
+	fingerprint string
+	rootCAs     *x509.CertPool
+}
+
+func newConnectionSettings(cluster *v1beta1.TemporalCluster, opts temporalclient.Options) connectionSettings {
+	settings := connectionSettings{
+		fingerprint: optionsFingerprint(cluster, opts),
+	}
+	if opts.ConnectionOptions.TLS != nil {
+		settings.rootCAs = opts.ConnectionOptions.TLS.RootCAs
+	}
+	return settings
+}
+
+// equal returns true if both settings share the same fingerprint and the same CA certificates.
+func (s connectionSettings) equal(other connectionSettings) bool {
+	if s.fingerprint != other.fingerprint {
+		return false
+	}
+	if s.rootCAs == nil || other.rootCAs == nil {
+		return s.rootCAs == other.rootCAs
+	}
+	return s.rootCAs.Equal(other.rootCAs)
+}
+
+// optionsFingerprint returns a fingerprint of the connection settings.
+// As CA pools can't be enumerated, it only holds the subjects of the CA certificates:
+// renewed CA certificates keeping their subject are detected by connectionSettings.equal.
+func optionsFingerprint(cluster *v1beta1.TemporalCluster, opts temporalclient.Options) string {
+	h := sha256.New()
+	h.Write([]byte(cluster.GetUID()))
+	h.Write([]byte(opts.HostPort))
+
+	if tlsConfig := opts.ConnectionOptions.TLS; tlsConfig != nil {
+		h.Write([]byte(tlsConfig.ServerName))
+		for _, certificate := range tlsConfig.Certificates {
+			for _, der := range certificate.Certificate {
+				h.Write(der)
+			}
+		}
+		if tlsConfig.RootCAs != nil {
+			//nolint:staticcheck // The pools are built from the cluster secrets, not from the system pool.
+			for _, subject := range tlsConfig.RootCAs.Subjects() {
+				h.Write(subject)
+			}
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
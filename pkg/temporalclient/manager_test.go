@@ -0,0 +1,186 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package temporalclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	temporalclient "go.temporal.io/sdk/client"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type fakeTemporalClient struct {
+	temporalclient.Client
+
+	workflowService workflowservice.WorkflowServiceClient
+	operatorService operatorservice.OperatorServiceClient
+	closed          int
+}
+
+func (c *fakeTemporalClient) WorkflowService() workflowservice.WorkflowServiceClient {
+	return c.workflowService
+}
+
+func (c *fakeTemporalClient) OperatorService() operatorservice.OperatorServiceClient {
+	return c.operatorService
+}
+
+func (c *fakeTemporalClient) Close() {
+	c.closed++
+}
+
+// testCAPool returns a pool holding a new self-signed CA certificate with the provided common name.
+func testCAPool(t *testing.T, commonName string) *x509.CertPool {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate)
+	return pool
+}
+
+func TestConnectionSettingsEqual(t *testing.T) {
+	cluster := &v1beta1.TemporalCluster{}
+	ca := testCAPool(t, "ca")
+
+	optionsWithCA := func(pool *x509.CertPool) temporalclient.Options {
+		return temporalclient.Options{
+			HostPort: "prod-frontend.demo:7233",
+			ConnectionOptions: temporalclient.ConnectionOptions{
+				TLS: &tls.Config{
+					ServerName: "prod-frontend.demo",
+					RootCAs:    pool,
+				},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		a, b  temporalclient.Options
+		equal bool
+	}{
+		"same settings": {
+			a:     optionsWithCA(ca),
+			b:     optionsWithCA(ca),
+			equal: true,
+		},
+		"same settings without TLS": {
+			a:     temporalclient.Options{HostPort: "prod-frontend.demo:7233"},
+			b:     temporalclient.Options{HostPort: "prod-frontend.demo:7233"},
+			equal: true,
+		},
+		"address changed": {
+			a:     temporalclient.Options{HostPort: "prod-frontend.demo:7233"},
+			b:     temporalclient.Options{HostPort: "prod-internal-frontend.demo:7236"},
+			equal: false,
+		},
+		"TLS enabled": {
+			a:     temporalclient.Options{HostPort: "prod-frontend.demo:7233"},
+			b:     optionsWithCA(ca),
+			equal: false,
+		},
+		"CA changed": {
+			a:     optionsWithCA(ca),
+			b:     optionsWithCA(testCAPool(t, "other-ca")),
+			equal: false,
+		},
+		"CA renewed with the same subject": {
+			a:     optionsWithCA(ca),
+			b:     optionsWithCA(testCAPool(t, "ca")),
+			equal: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			a := newConnectionSettings(cluster, test.a)
+			b := newConnectionSettings(cluster, test.b)
+			assert.Equal(tt, test.equal, a.equal(b))
+		})
+	}
+}
+
+func TestManagerReusesClusterClients(t *testing.T) {
+	m := NewManager(nil, testCallOptions())
+	key := types.NamespacedName{Namespace: "demo", Name: "prod"}
+	opts := temporalclient.Options{HostPort: "prod-frontend.demo:7233"}
+	settings := newConnectionSettings(&v1beta1.TemporalCluster{}, opts)
+
+	current := &clusterClients{
+		settings:   settings,
+		root:       &fakeTemporalClient{},
+		namespaces: map[string]temporalclient.Client{},
+	}
+	m.clusters[key] = current
+
+	clients, err := m.clusterClients(context.Background(), key, settings, opts, m.breaker(key))
+	require.NoError(t, err)
+	assert.Same(t, current, clients)
+}
+
+func TestManagerCloseRetiredClients(t *testing.T) {
+	m := NewManager(nil, testCallOptions())
+
+	root := &fakeTemporalClient{}
+	namespaceClient := &fakeTemporalClient{}
+	retired := &clusterClients{
+		root: root,
+		namespaces: map[string]temporalclient.Client{
+			"default": namespaceClient,
+		},
+	}
+
+	m.mu.Lock()
+	m.retire(retired)
+	m.mu.Unlock()
+
+	// Retired clients are kept open for the calls in progress.
+	assert.Equal(t, 0, root.closed)
+
+	m.Close()
+	assert.Equal(t, 1, root.closed)
+	assert.Equal(t, 1, namespaceClient.closed)
+	assert.Empty(t, m.retired)
+}
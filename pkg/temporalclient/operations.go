@@ -0,0 +1,180 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporalclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// ClusterInfo holds information about a temporal cluster.
+type ClusterInfo struct {
+	// ClusterID is the unique identifier of the cluster.
+	ClusterID string
+	// ClusterName is the name of the cluster.
+	ClusterName string
+	// ServerVersion is the version of the temporal server.
+	ServerVersion string
+	// HistoryShardCount is the number of history shards of the cluster.
+	HistoryShardCount int32
+	// PersistenceStore is the name of the persistence store.
+	PersistenceStore string
+	// VisibilityStore is the name of the visibility store.
+	VisibilityStore string
+}
+
+// NamespaceInfo holds information about a temporal namespace.
+type NamespaceInfo struct {
+	// IsGlobalNamespace is true for namespaces replicated across clusters.
+	IsGlobalNamespace bool
+	// ActiveClusterName is the name of the cluster the namespace is active on.
+	ActiveClusterName string
+	// Clusters are the names of the clusters the namespace is replicated to.
+	Clusters []string
+	// SearchAttributeAliases maps the custom search attribute field names to their namespace aliases.
+	SearchAttributeAliases map[string]string
+}
+
+// ClusterOperations performs administrative operations against temporal clusters managed by the operator.
+// It allows controllers built on top of the operator to manage clusters resources without
+// re-implementing the connection and mTLS plumbing.
+type ClusterOperations interface {
+	// RegisterNamespace registers a new namespace on the cluster.
+	// It returns a *serviceerror.NamespaceAlreadyExists error if the namespace already exists.
+	RegisterNamespace(ctx context.Context, cluster *v1beta1.TemporalCluster, request *workflowservice.RegisterNamespaceRequest) error
+	// UpdateNamespace updates an existing namespace of the cluster.
+	UpdateNamespace(ctx context.Context, cluster *v1beta1.TemporalCluster, request *workflowservice.UpdateNamespaceRequest) error
+	// DeleteNamespace deletes a namespace of the cluster.
+	// It returns a *serviceerror.NamespaceNotFound error if the namespace doesn't exist.
+	DeleteNamespace(ctx context.Context, cluster *v1beta1.TemporalCluster, request *operatorservice.DeleteNamespaceRequest) error
+	// AddSearchAttributes adds custom search attributes to the provided namespace of the cluster.
+	AddSearchAttributes(ctx context.Context, cluster *v1beta1.TemporalCluster, namespace string, attributes map[string]enumspb.IndexedValueType) error
+	// DescribeCluster returns information about the cluster.
+	DescribeCluster(ctx context.Context, cluster *v1beta1.TemporalCluster) (*ClusterInfo, error)
+	// DescribeNamespace returns the replication config and the search attribute aliases of the provided namespace of the cluster.
+	// It returns a *serviceerror.NamespaceNotFound error if the namespace doesn't exist.
+	DescribeNamespace(ctx context.Context, cluster *v1beta1.TemporalCluster, namespace string) (*NamespaceInfo, error)
+}
+
+var _ ClusterOperations = (*clusterOperations)(nil)
+
+type clusterOperations struct {
+	manager *Manager
+}
+
+// NewClusterOperations returns ClusterOperations using clients from the provided manager.
+func NewClusterOperations(manager *Manager) ClusterOperations {
+	return &clusterOperations{
+		manager: manager,
+	}
+}
+
+func (o *clusterOperations) RegisterNamespace(ctx context.Context, cluster *v1beta1.TemporalCluster, request *workflowservice.RegisterNamespaceRequest) error {
+	client, err := o.manager.Client(ctx, cluster, "")
+	if err != nil {
+		return fmt.Errorf("can't create cluster client: %w", err)
+	}
+
+	_, err = client.WorkflowService().RegisterNamespace(ctx, request)
+	return err
+}
+
+func (o *clusterOperations) UpdateNamespace(ctx context.Context, cluster *v1beta1.TemporalCluster, request *workflowservice.UpdateNamespaceRequest) error {
+	client, err := o.manager.Client(ctx, cluster, "")
+	if err != nil {
+		return fmt.Errorf("can't create cluster client: %w", err)
+	}
+
+	_, err = client.WorkflowService().UpdateNamespace(ctx, request)
+	return err
+}
+
+func (o *clusterOperations) DeleteNamespace(ctx context.Context, cluster *v1beta1.TemporalCluster, request *operatorservice.DeleteNamespaceRequest) error {
+	client, err := o.manager.Client(ctx, cluster, "")
+	if err != nil {
+		return fmt.Errorf("can't create cluster client: %w", err)
+	}
+
+	_, err = client.OperatorService().DeleteNamespace(ctx, request)
+	return err
+}
+
+func (o *clusterOperations) AddSearchAttributes(ctx context.Context, cluster *v1beta1.TemporalCluster, namespace string, attributes map[string]enumspb.IndexedValueType) error {
+	client, err := o.manager.Client(ctx, cluster, "")
+	if err != nil {
+		return fmt.Errorf("can't create cluster client: %w", err)
+	}
+
+	_, err = client.OperatorService().AddSearchAttributes(ctx, &operatorservice.AddSearchAttributesRequest{
+		SearchAttributes: attributes,
+		Namespace:        namespace,
+	})
+	return err
+}
+
+func (o *clusterOperations) DescribeCluster(ctx context.Context, cluster *v1beta1.TemporalCluster) (*ClusterInfo, error) {
+	client, err := o.manager.Client(ctx, cluster, "")
+	if err != nil {
+		return nil, fmt.Errorf("can't create cluster client: %w", err)
+	}
+
+	info, err := client.WorkflowService().GetClusterInfo(ctx, &workflowservice.GetClusterInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClusterInfo{
+		ClusterID:         info.GetClusterId(),
+		ClusterName:       info.GetClusterName(),
+		ServerVersion:     info.GetServerVersion(),
+		HistoryShardCount: info.GetHistoryShardCount(),
+		PersistenceStore:  info.GetPersistenceStore(),
+		VisibilityStore:   info.GetVisibilityStore(),
+	}, nil
+}
+
+func (o *clusterOperations) DescribeNamespace(ctx context.Context, cluster *v1beta1.TemporalCluster, namespace string) (*NamespaceInfo, error) {
+	client, err := o.manager.Client(ctx, cluster, "")
+	if err != nil {
+		return nil, fmt.Errorf("can't create cluster client: %w", err)
+	}
+
+	response, err := client.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]string, 0, len(response.GetReplicationConfig().GetClusters()))
+	for _, cluster := range response.GetReplicationConfig().GetClusters() {
+		clusters = append(clusters, cluster.GetClusterName())
+	}
+
+	return &NamespaceInfo{
+		IsGlobalNamespace:      response.GetIsGlobalNamespace(),
+		ActiveClusterName:      response.GetReplicationConfig().GetActiveClusterName(),
+		Clusters:               clusters,
+		SearchAttributeAliases: response.GetConfig().GetCustomSearchAttributeAliases(),
+	}, nil
+}
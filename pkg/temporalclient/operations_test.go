@@ -0,0 +1,226 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package temporalclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	replicationpb "go.temporal.io/api/replication/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	temporalclient "go.temporal.io/sdk/client"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+type fakeWorkflowService struct {
+	workflowservice.WorkflowServiceClient
+
+	err        error
+	registered *workflowservice.RegisterNamespaceRequest
+	updated    *workflowservice.UpdateNamespaceRequest
+}
+
+func (s *fakeWorkflowService) RegisterNamespace(_ context.Context, request *workflowservice.RegisterNamespaceRequest, _ ...grpc.CallOption) (*workflowservice.RegisterNamespaceResponse, error) {
+	s.registered = request
+	return &workflowservice.RegisterNamespaceResponse{}, s.err
+}
+
+func (s *fakeWorkflowService) UpdateNamespace(_ context.Context, request *workflowservice.UpdateNamespaceRequest, _ ...grpc.CallOption) (*workflowservice.UpdateNamespaceResponse, error) {
+	s.updated = request
+	return &workflowservice.UpdateNamespaceResponse{}, s.err
+}
+
+func (s *fakeWorkflowService) GetClusterInfo(_ context.Context, _ *workflowservice.GetClusterInfoRequest, _ ...grpc.CallOption) (*workflowservice.GetClusterInfoResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &workflowservice.GetClusterInfoResponse{
+		ClusterId:         "cluster-id",
+		ClusterName:       "prod",
+		ServerVersion:     "1.23.0",
+		HistoryShardCount: 512,
+		PersistenceStore:  "postgres12",
+		VisibilityStore:   "elasticsearch",
+	}, nil
+}
+
+func (s *fakeWorkflowService) DescribeNamespace(_ context.Context, request *workflowservice.DescribeNamespaceRequest, _ ...grpc.CallOption) (*workflowservice.DescribeNamespaceResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &workflowservice.DescribeNamespaceResponse{
+		IsGlobalNamespace: true,
+		Config: &namespacepb.NamespaceConfig{
+			CustomSearchAttributeAliases: map[string]string{"Keyword01": "CustomerId"},
+		},
+		ReplicationConfig: &replicationpb.NamespaceReplicationConfig{
+			ActiveClusterName: "prod",
+			Clusters: []*replicationpb.ClusterReplicationConfig{
+				{ClusterName: "prod"},
+				{ClusterName: "dr"},
+			},
+		},
+	}, nil
+}
+
+type fakeOperatorService struct {
+	operatorservice.OperatorServiceClient
+
+	err              error
+	deleted          *operatorservice.DeleteNamespaceRequest
+	searchAttributes *operatorservice.AddSearchAttributesRequest
+}
+
+func (s *fakeOperatorService) DeleteNamespace(_ context.Context, request *operatorservice.DeleteNamespaceRequest, _ ...grpc.CallOption) (*operatorservice.DeleteNamespaceResponse, error) {
+	s.deleted = request
+	return &operatorservice.DeleteNamespaceResponse{}, s.err
+}
+
+func (s *fakeOperatorService) AddSearchAttributes(_ context.Context, request *operatorservice.AddSearchAttributesRequest, _ ...grpc.CallOption) (*operatorservice.AddSearchAttributesResponse, error) {
+	s.searchAttributes = request
+	return &operatorservice.AddSearchAttributesResponse{}, s.err
+}
+
+// testClusterOperations returns cluster operations whose manager is connected to the provided cluster using fake services.
+func testClusterOperations(t *testing.T, cluster *v1beta1.TemporalCluster, workflowService *fakeWorkflowService, operatorService *fakeOperatorService) ClusterOperations {
+	m := NewManager(nil, testCallOptions())
+
+	opts, err := temporal.BuildClusterClientOptions(context.Background(), nil, cluster)
+	require.NoError(t, err)
+
+	m.clusters[types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}] = &clusterClients{
+		settings: newConnectionSettings(cluster, opts),
+		root: &fakeTemporalClient{
+			workflowService: workflowService,
+			operatorService: operatorService,
+		},
+		namespaces: map[string]temporalclient.Client{},
+	}
+
+	return NewClusterOperations(m)
+}
+
+func testCluster() *v1beta1.TemporalCluster {
+	return &v1beta1.TemporalCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prod",
+			Namespace: "demo",
+		},
+		Spec: v1beta1.TemporalClusterSpec{
+			Services: &v1beta1.ServicesSpec{
+				Frontend: &v1beta1.ServiceSpec{
+					Port: ptr.To(7233),
+				},
+			},
+		},
+	}
+}
+
+func TestClusterOperationsNamespaces(t *testing.T) {
+	cluster := testCluster()
+	workflowService := &fakeWorkflowService{}
+	operatorService := &fakeOperatorService{}
+	operations := testClusterOperations(t, cluster, workflowService, operatorService)
+	ctx := context.Background()
+
+	err := operations.RegisterNamespace(ctx, cluster, &workflowservice.RegisterNamespaceRequest{Namespace: "default"})
+	require.NoError(t, err)
+	assert.Equal(t, "default", workflowService.registered.GetNamespace())
+
+	err = operations.UpdateNamespace(ctx, cluster, &workflowservice.UpdateNamespaceRequest{Namespace: "default"})
+	require.NoError(t, err)
+	assert.Equal(t, "default", workflowService.updated.GetNamespace())
+
+	err = operations.AddSearchAttributes(ctx, cluster, "default", map[string]enumspb.IndexedValueType{
+		"CustomerId": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "default", operatorService.searchAttributes.GetNamespace())
+	assert.Equal(t, map[string]enumspb.IndexedValueType{"CustomerId": enumspb.INDEXED_VALUE_TYPE_KEYWORD}, operatorService.searchAttributes.GetSearchAttributes())
+
+	err = operations.DeleteNamespace(ctx, cluster, &operatorservice.DeleteNamespaceRequest{Namespace: "default"})
+	require.NoError(t, err)
+	assert.Equal(t, "default", operatorService.deleted.GetNamespace())
+
+	info, err := operations.DescribeNamespace(ctx, cluster, "default")
+	require.NoError(t, err)
+	assert.Equal(t, &NamespaceInfo{
+		IsGlobalNamespace:      true,
+		ActiveClusterName:      "prod",
+		Clusters:               []string{"prod", "dr"},
+		SearchAttributeAliases: map[string]string{"Keyword01": "CustomerId"},
+	}, info)
+}
+
+func TestClusterOperationsDescribeCluster(t *testing.T) {
+	cluster := testCluster()
+	operations := testClusterOperations(t, cluster, &fakeWorkflowService{}, &fakeOperatorService{})
+
+	info, err := operations.DescribeCluster(context.Background(), cluster)
+	require.NoError(t, err)
+	assert.Equal(t, &ClusterInfo{
+		ClusterID:         "cluster-id",
+		ClusterName:       "prod",
+		ServerVersion:     "1.23.0",
+		HistoryShardCount: 512,
+		PersistenceStore:  "postgres12",
+		VisibilityStore:   "elasticsearch",
+	}, info)
+}
+
+func TestClusterOperationsErrors(t *testing.T) {
+	cluster := testCluster()
+	workflowService := &fakeWorkflowService{err: serviceerror.NewNamespaceNotFound("default")}
+	operatorService := &fakeOperatorService{err: errors.New("unavailable")}
+	operations := testClusterOperations(t, cluster, workflowService, operatorService)
+	ctx := context.Background()
+
+	_, err := operations.DescribeNamespace(ctx, cluster, "default")
+	var namespaceNotFound *serviceerror.NamespaceNotFound
+	assert.ErrorAs(t, err, &namespaceNotFound)
+
+	_, err = operations.DescribeCluster(ctx, cluster)
+	assert.ErrorAs(t, err, &namespaceNotFound)
+
+	err = operations.DeleteNamespace(ctx, cluster, &operatorservice.DeleteNamespaceRequest{Namespace: "default"})
+	assert.EqualError(t, err, "unavailable")
+}
+
+func TestClusterOperationsCircuitOpen(t *testing.T) {
+	cluster := testCluster()
+	m := NewManager(nil, testCallOptions())
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+	for i := int32(0); i < m.options.CircuitBreaker.FailureThreshold; i++ {
+		m.breaker(key).record(true, time.Now())
+	}
+
+	err := NewClusterOperations(m).RegisterNamespace(context.Background(), cluster, &workflowservice.RegisterNamespaceRequest{Namespace: "default"})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+}
@@ -0,0 +1,171 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporalclient
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/circuitbreaker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrCircuitOpen is returned when too many consecutive calls to a cluster failed and calls are paused for a while.
+var ErrCircuitOpen = errors.New("temporal cluster circuit breaker is open")
+
+// CallOptions configures the timeouts, retries and circuit breaking of the operator calls to the managed clusters,
+// so a hung frontend doesn't stall the reconciliation of other clusters.
+type CallOptions struct {
+	// Timeout is the timeout of each call attempt. Calls whose context already has a deadline keep it.
+	Timeout time.Duration
+	// MaxRetries is the number of times a call failing with a connectivity error is retried.
+	MaxRetries int
+	// RetryDelay is the delay before the first retry. It doubles on each retry.
+	RetryDelay time.Duration
+	// CircuitBreaker configures the per cluster circuit breaker, opened after consecutive connectivity errors.
+	CircuitBreaker circuitbreaker.Config
+}
+
+// NewCallOptions returns the default call options.
+func NewCallOptions() *CallOptions {
+	return &CallOptions{
+		Timeout:    10 * time.Second,
+		MaxRetries: 2,
+		RetryDelay: 500 * time.Millisecond,
+		CircuitBreaker: circuitbreaker.Config{
+			InitialDelay:     5 * time.Second,
+			MaxDelay:         5 * time.Minute,
+			FailureThreshold: 5,
+		},
+	}
+}
+
+// BindFlags binds the call options flags to the provided flagset.
+func (o *CallOptions) BindFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&o.Timeout, "temporal-call-timeout", o.Timeout,
+		"The timeout of each call to the managed temporal clusters, unless the caller sets its own deadline. Set to 0 to disable.")
+	fs.IntVar(&o.MaxRetries, "temporal-call-max-retries", o.MaxRetries,
+		"The number of times a call to a managed temporal cluster failing with a connectivity error is retried.")
+	fs.DurationVar(&o.RetryDelay, "temporal-call-retry-delay", o.RetryDelay,
+		"The delay before retrying a call to a managed temporal cluster. It doubles on each retry.")
+	fs.DurationVar(&o.CircuitBreaker.InitialDelay, "temporal-circuit-breaker-initial-delay", o.CircuitBreaker.InitialDelay,
+		"The delay calls to a temporal cluster are paused for once its circuit breaker opens. It doubles on each consecutive failure.")
+	fs.DurationVar(&o.CircuitBreaker.MaxDelay, "temporal-circuit-breaker-max-delay", o.CircuitBreaker.MaxDelay,
+		"The maximum delay calls to a temporal cluster are paused for.")
+	fs.Func("temporal-circuit-breaker-threshold", "The number of consecutive connectivity errors opening the circuit breaker of a temporal cluster. Set to 0 to disable. (default 5)", func(value string) error {
+		threshold, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		o.CircuitBreaker.FailureThreshold = int32(threshold)
+		return nil
+	})
+}
+
+// unaryInterceptor returns a gRPC interceptor applying the call options to the calls made to a cluster.
+func (o *CallOptions) unaryInterceptor(breaker *clusterBreaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if retryIn := breaker.retryIn(o.CircuitBreaker, time.Now()); retryIn > 0 {
+			return status.Errorf(codes.Unavailable, "%s, next attempt in %s", ErrCircuitOpen, retryIn.Round(time.Second))
+		}
+
+		delay := o.RetryDelay
+		for attempt := 0; ; attempt++ {
+			err := o.invoke(ctx, method, req, reply, cc, invoker, opts...)
+			failed := isConnectivityError(err)
+			breaker.record(failed, time.Now())
+
+			if !failed || attempt >= o.MaxRetries || ctx.Err() != nil || breaker.retryIn(o.CircuitBreaker, time.Now()) > 0 {
+				return err
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+			delay *= 2
+		}
+	}
+}
+
+// invoke makes a single call attempt, bounded by the call timeout unless the context already has a deadline.
+func (o *CallOptions) invoke(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok && o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// isConnectivityError returns true if the call failed without getting an answer from the cluster.
+func isConnectivityError(err error) bool {
+	switch status.Code(err) { //nolint:exhaustive
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// clusterBreaker tracks the consecutive connectivity errors of the calls made to a cluster.
+type clusterBreaker struct {
+	mu          sync.Mutex
+	failures    int32
+	lastFailure time.Time
+}
+
+// retryIn returns how long calls to the cluster are paused for, or 0 if the circuit is closed.
+// A nil breaker never opens.
+func (b *clusterBreaker) retryIn(config circuitbreaker.Config, now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return config.RetryIn(b.failures, b.lastFailure, now)
+}
+
+// record records the outcome of a call to the cluster. Any answer from the cluster closes the circuit.
+func (b *clusterBreaker) record(failed bool, now time.Time) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	b.lastFailure = now
+}
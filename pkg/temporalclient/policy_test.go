@@ -0,0 +1,141 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporalclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/circuitbreaker"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testCallOptions() *CallOptions {
+	return &CallOptions{
+		Timeout:    time.Second,
+		MaxRetries: 2,
+		RetryDelay: time.Millisecond,
+		CircuitBreaker: circuitbreaker.Config{
+			InitialDelay:     time.Minute,
+			MaxDelay:         time.Minute,
+			FailureThreshold: 4,
+		},
+	}
+}
+
+// failingInvoker returns an invoker failing with the provided codes, then succeeding.
+func failingInvoker(calls *int, failures ...codes.Code) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls <= len(failures) {
+			return status.Error(failures[*calls-1], "failed")
+		}
+		return nil
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	tests := map[string]struct {
+		failures      []codes.Code
+		expectedCode  codes.Code
+		expectedCalls int
+	}{
+		"success": {
+			expectedCode:  codes.OK,
+			expectedCalls: 1,
+		},
+		"retried connectivity errors": {
+			failures:      []codes.Code{codes.Unavailable, codes.DeadlineExceeded},
+			expectedCode:  codes.OK,
+			expectedCalls: 3,
+		},
+		"retries exhausted": {
+			failures:      []codes.Code{codes.Unavailable, codes.Unavailable, codes.Unavailable},
+			expectedCode:  codes.Unavailable,
+			expectedCalls: 3,
+		},
+		"application errors are not retried": {
+			failures:      []codes.Code{codes.NotFound},
+			expectedCode:  codes.NotFound,
+			expectedCalls: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			calls := 0
+			interceptor := testCallOptions().unaryInterceptor(&clusterBreaker{})
+
+			err := interceptor(context.Background(), "/test", nil, nil, nil, failingInvoker(&calls, test.failures...))
+			assert.Equal(tt, test.expectedCode, status.Code(err))
+			assert.Equal(tt, test.expectedCalls, calls)
+		})
+	}
+}
+
+func TestUnaryInterceptorCircuitBreaker(t *testing.T) {
+	breaker := &clusterBreaker{}
+	interceptor := testCallOptions().unaryInterceptor(breaker)
+
+	calls := 0
+	invoker := failingInvoker(&calls, codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable)
+
+	// The first call is retried twice, the second one opens the circuit on its first failure.
+	err := interceptor(context.Background(), "/test", nil, nil, nil, invoker)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	err = interceptor(context.Background(), "/test", nil, nil, nil, invoker)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 4, calls)
+
+	// Calls are not made while the circuit is open.
+	err = interceptor(context.Background(), "/test", nil, nil, nil, invoker)
+	assert.ErrorContains(t, err, ErrCircuitOpen.Error())
+	assert.Equal(t, 4, calls)
+
+	// The circuit closes once a call gets an answer from the cluster.
+	breaker.record(false, time.Now())
+	err = interceptor(context.Background(), "/test", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+}
+
+func TestUnaryInterceptorTimeout(t *testing.T) {
+	interceptor := testCallOptions().unaryInterceptor(nil)
+
+	var deadline time.Time
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		deadline, _ = ctx.Deadline()
+		return nil
+	}
+
+	err := interceptor(context.Background(), "/test", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+
+	// Callers deadlines are kept.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	expected, _ := ctx.Deadline()
+
+	err = interceptor(ctx, "/test", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, deadline)
+}
@@ -0,0 +1,87 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package version
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed compatibility.json
+var compatibilityMatrixData []byte
+
+// CompatibilityMatrix describes the temporal server versions supported by the operator.
+// +kubebuilder:object:generate=false
+type CompatibilityMatrix struct {
+	// MinVersion is the oldest supported temporal version (inclusive).
+	MinVersion string `json:"minVersion"`
+	// MaxVersion is the first temporal version not supported anymore (exclusive).
+	MaxVersion string `json:"maxVersion"`
+	// DeprecatedBefore marks versions older than this one as deprecated.
+	// Deprecated versions are still supported but will be removed in a future operator release.
+	DeprecatedBefore string `json:"deprecatedBefore"`
+	// BrokenReleases lists versions in the supported range known to be broken.
+	BrokenReleases []BrokenRelease `json:"brokenReleases"`
+}
+
+// BrokenRelease is a temporal release the operator refuses to deploy.
+// +kubebuilder:object:generate=false
+type BrokenRelease struct {
+	Version   string `json:"version"`
+	Reference string `json:"reference"`
+}
+
+// Compatibility is the compatibility matrix embedded in the operator.
+var Compatibility = mustLoadCompatibilityMatrix(compatibilityMatrixData)
+
+func mustLoadCompatibilityMatrix(data []byte) *CompatibilityMatrix {
+	matrix := &CompatibilityMatrix{}
+	err := json.Unmarshal(data, matrix)
+	if err != nil {
+		panic(fmt.Errorf("can't parse compatibility matrix: %w", err))
+	}
+	return matrix
+}
+
+// SupportedVersionRange returns the supported versions range as a semver constraint string.
+func (m *CompatibilityMatrix) SupportedVersionRange() string {
+	return fmt.Sprintf(">= %s < %s", m.MinVersion, m.MaxVersion)
+}
+
+// Check returns an error with an actionable message if the provided version is outside the supported range.
+// It returns a non-empty warning if the provided version is supported but deprecated.
+// Broken releases are not checked, see ForbiddenBrokenReleases.
+func (m *CompatibilityMatrix) Check(v *Version) (string, error) {
+	minVersion := MustNewVersionFromString(m.MinVersion)
+	maxVersion := MustNewVersionFromString(m.MaxVersion)
+
+	if v.LessThan(minVersion.Version) {
+		return "", fmt.Errorf("temporal version %s is older than the oldest version supported by this operator (%s), use a previous operator release to upgrade it to %s or later first", v.String(), m.SupportedVersionRange(), minVersion.String())
+	}
+
+	if !v.LessThan(maxVersion.Version) {
+		return "", fmt.Errorf("temporal version %s is newer than the latest version supported by this operator (%s), upgrade the operator first", v.String(), m.SupportedVersionRange())
+	}
+
+	if m.DeprecatedBefore != "" && v.LessThan(MustNewVersionFromString(m.DeprecatedBefore).Version) {
+		return fmt.Sprintf("temporal version %s is deprecated and will not be supported by a future operator release, please upgrade to %s or later", v.String(), m.DeprecatedBefore), nil
+	}
+
+	return "", nil
+}
@@ -28,13 +28,10 @@ import (
 
 var (
 	// SupportedVersionsRange holds all supported temporal versions.
-	SupportedVersionsRange  = mustNewConstraint(">= 1.14.0 < 1.24.0")
-	ForbiddenBrokenReleases = []*Version{
-		// v1.21.0 is reported as broken, see: https://github.com/temporalio/temporal/releases/tag/v1.21.0
-		MustNewVersionFromString("1.21.0"),
-		// v1.21.1 is reported as broken, see: https://github.com/temporalio/temporal/releases/tag/v1.21.1
-		MustNewVersionFromString("1.21.1"),
-	}
+	SupportedVersionsRange = mustNewConstraint(Compatibility.SupportedVersionRange())
+	// ForbiddenBrokenReleases holds all temporal versions reported as broken.
+	ForbiddenBrokenReleases = brokenReleasesVersions(Compatibility)
+
 	V1_18_0 = MustNewVersionFromString("1.18.0") //nolint:stylecheck,revive
 	V1_20_0 = MustNewVersionFromString("1.20.0") //nolint:stylecheck,revive
 	V1_21_0 = MustNewVersionFromString("1.21.0") //nolint:stylecheck,revive
@@ -137,3 +134,11 @@ func mustNewConstraint(constraint string) *semver.Constraints {
 	}
 	return c
 }
+
+func brokenReleasesVersions(matrix *CompatibilityMatrix) []*Version {
+	result := make([]*Version, 0, len(matrix.BrokenReleases))
+	for _, release := range matrix.BrokenReleases {
+		result = append(result, MustNewVersionFromString(release.Version))
+	}
+	return result
+}
@@ -67,3 +67,39 @@ func TestUpgradeConstraint(t *testing.T) {
 		})
 	}
 }
+
+func TestCompatibilityCheck(t *testing.T) {
+	tests := map[string]struct {
+		version         *version.Version
+		expectedWarning string
+		expectedErr     string
+	}{
+		"supported version": {
+			version: version.MustNewVersionFromString("1.23.0"),
+		},
+		"deprecated version": {
+			version:         version.MustNewVersionFromString("1.19.1"),
+			expectedWarning: "temporal version 1.19.1 is deprecated and will not be supported by a future operator release, please upgrade to 1.20.0 or later",
+		},
+		"too old version": {
+			version:     version.MustNewVersionFromString("1.13.0"),
+			expectedErr: "temporal version 1.13.0 is older than the oldest version supported by this operator (>= 1.14.0 < 1.24.0), use a previous operator release to upgrade it to 1.14.0 or later first",
+		},
+		"too recent version": {
+			version:     version.MustNewVersionFromString("1.24.0"),
+			expectedErr: "temporal version 1.24.0 is newer than the latest version supported by this operator (>= 1.14.0 < 1.24.0), upgrade the operator first",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			warning, err := version.Compatibility.Check(test.version)
+			if test.expectedErr != "" {
+				assert.EqualError(tt, err, test.expectedErr)
+				return
+			}
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expectedWarning, warning)
+		})
+	}
+}
@@ -19,14 +19,14 @@ package e2e
 
 import (
 	"context"
+	"crypto/tls"
 	"testing"
 	"time"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
-	"github.com/alexandrevilain/temporal-operator/tests/e2e/temporal/teststarter"
-	"github.com/alexandrevilain/temporal-operator/tests/e2e/temporal/testworker"
+	temporalutil "github.com/alexandrevilain/temporal-operator/tests/e2e/util/temporal"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
@@ -88,28 +88,16 @@ func AssertClusterCanHandleWorkflows() features.Func {
 
 		t.Logf("Temporal frontend addr: %s", connectAddr)
 
-		client := cfg.Client().Resources().GetControllerRuntimeClient()
-
-		clusterClient, err := temporal.GetClusterClient(ctx, client, cluster, temporal.WithHostPort(connectAddr))
-		if err != nil {
-			t.Fatal(err)
-		}
-
-		w, err := testworker.NewWorker(clusterClient)
-		if err != nil {
-			t.Fatal(err)
-		}
-
-		t.Log("Starting test worker")
-		err = w.Start()
-		if err != nil {
-			t.Fatal(err)
+		var tlsConfig *tls.Config
+		if cluster.MTLSWithCertManagerEnabled() && cluster.Spec.MTLS.FrontendEnabled() {
+			tlsConfig, err = temporal.GetClusterClientTLSConfig(ctx, cfg.Client().Resources().GetControllerRuntimeClient(), cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
 		}
 
-		defer w.Stop()
-
-		t.Logf("Starting workflow")
-		err = teststarter.NewStarter(clusterClient).StartGreetingWorkflow()
+		t.Logf("Running sample workflow")
+		err = temporalutil.RunSampleWorkflow(ctx, connectAddr, tlsConfig)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -132,8 +120,6 @@ func AssertTemporalClusterWithMTLSCanHandleWorkflows() features.Func {
 
 		t.Logf("Temporal frontend addr: %s", connectAddr)
 
-		client := cfg.Client().Resources().GetControllerRuntimeClient()
-
 		clientSecret := &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      clusterClient.Status.SecretRef.Name,
@@ -159,26 +145,8 @@ func AssertTemporalClusterWithMTLSCanHandleWorkflows() features.Func {
 		}
 		tlsCfg.ServerName = clusterClient.Status.ServerName
 
-		temporalClient, err := temporal.GetClusterClient(ctx, client, cluster, temporal.WithHostPort(connectAddr), temporal.WithTLSConfig(tlsCfg))
-		if err != nil {
-			t.Fatal(err)
-		}
-
-		w, err := testworker.NewWorker(temporalClient)
-		if err != nil {
-			t.Fatal(err)
-		}
-
-		t.Log("Starting test worker")
-		err = w.Start()
-		if err != nil {
-			t.Fatal(err)
-		}
-
-		defer w.Stop()
-
-		t.Logf("Starting workflow")
-		err = teststarter.NewStarter(temporalClient).StartGreetingWorkflow()
+		t.Logf("Running sample workflow")
+		err = temporalutil.RunSampleWorkflow(ctx, connectAddr, tlsCfg)
 		if err != nil {
 			t.Fatal(err)
 		}
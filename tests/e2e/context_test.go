@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	temporalutil "github.com/alexandrevilain/temporal-operator/tests/e2e/util/temporal"
 )
 
 type (
@@ -28,6 +29,8 @@ type (
 	temporalClusterClientContextKey string
 	temporalNamespaceContextKey     string
 
+	workflowLoadContextKey string
+
 	namespaceContextKey string
 )
 
@@ -37,9 +40,17 @@ var (
 	temporalNamespaceKey     temporalNamespaceContextKey     = "temporalNamespace"
 	temporalScheduleKey      temporalNamespaceContextKey     = "temporalSchedule"
 
+	workflowLoadKey workflowLoadContextKey = "workflowLoad"
+
 	namespaceKey namespaceContextKey = "namespace"
 )
 
+// runningWorkflowLoad is a workflow load running through a port forward.
+type runningWorkflowLoad struct {
+	load             *temporalutil.WorkflowLoad
+	closePortForward func()
+}
+
 func GetNamespaceForFeature(ctx context.Context) string {
 	return ctx.Value(namespaceKey).(string)
 }
@@ -79,3 +90,11 @@ func GetTemporalScheduleForFeature(ctx context.Context) *v1beta1.TemporalSchedul
 func SetTemporalScheduleForFeature(ctx context.Context, schedule *v1beta1.TemporalSchedule) context.Context {
 	return context.WithValue(ctx, temporalScheduleKey, schedule)
 }
+
+func GetWorkflowLoadForFeature(ctx context.Context) *runningWorkflowLoad {
+	return ctx.Value(workflowLoadKey).(*runningWorkflowLoad)
+}
+
+func SetWorkflowLoadForFeature(ctx context.Context, load *runningWorkflowLoad) context.Context {
+	return context.WithValue(ctx, workflowLoadKey, load)
+}
@@ -0,0 +1,97 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	kubernetesutil "github.com/alexandrevilain/temporal-operator/tests/e2e/util/kubernetes"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/features"
+)
+
+const (
+	// operatorNamespace is the namespace the operator is deployed in.
+	operatorNamespace = "temporal-system"
+	// operatorPodsSelector selects the operator pods.
+	operatorPodsSelector = "control-plane=controller-manager"
+	// podLogsTailLines is the number of log lines captured per container.
+	podLogsTailLines = 200
+)
+
+// podLogsCapture defines which pod logs are written to the test output when a feature fails.
+type podLogsCapture string
+
+const (
+	// failingPodLogsCapture captures the logs of the failing pods of the feature namespace, and of the operator.
+	failingPodLogsCapture podLogsCapture = "failing"
+	// allPodLogsCapture captures the logs of all the pods of the feature namespace, and of the operator.
+	allPodLogsCapture podLogsCapture = "all"
+	// noPodLogsCapture disables the capture.
+	noPodLogsCapture podLogsCapture = "none"
+)
+
+// parsePodLogsCapture parses the E2E_POD_LOGS value, defaulting to failing.
+func parsePodLogsCapture(value string) (podLogsCapture, error) {
+	switch podLogsCapture(value) {
+	case "":
+		return failingPodLogsCapture, nil
+	case failingPodLogsCapture, allPodLogsCapture, noPodLogsCapture:
+		return podLogsCapture(value), nil
+	default:
+		return "", fmt.Errorf("unsupported E2E_POD_LOGS value %q, must be one of: failing, all, none", value)
+	}
+}
+
+// capturePodLogsOnFailure writes the pod logs of failed features to the test output.
+func capturePodLogsOnFailure(capture podLogsCapture) func(context.Context, *envconf.Config, *testing.T, features.Feature) (context.Context, error) {
+	return func(ctx context.Context, cfg *envconf.Config, t *testing.T, f features.Feature) (context.Context, error) {
+		if capture == noPodLogsCapture || !t.Failed() {
+			return ctx, nil
+		}
+
+		t.Logf("Feature \"%s\" failed, capturing pod logs (set E2E_POD_LOGS=none to disable)", f.Name())
+		writePodLogs(ctx, cfg, t, GetNamespaceForFeature(ctx), capture == failingPodLogsCapture)
+
+		return ctx, nil
+	}
+}
+
+// writePodLogs writes the logs of the pods of the provided namespace, and the operator logs, to the test output.
+// It can be called from any assessment to debug it.
+func writePodLogs(ctx context.Context, cfg *envconf.Config, t *testing.T, namespace string, onlyFailing bool) {
+	out := &testLogWriter{t}
+
+	err := kubernetesutil.WritePodsLogs(ctx, cfg.Client().RESTConfig(), namespace, kubernetesutil.PodLogsOptions{
+		OnlyFailing: onlyFailing,
+		TailLines:   podLogsTailLines,
+	}, out)
+	if err != nil {
+		t.Logf("Can't capture all pod logs of namespace %s: %s", namespace, err)
+	}
+
+	err = kubernetesutil.WritePodsLogs(ctx, cfg.Client().RESTConfig(), operatorNamespace, kubernetesutil.PodLogsOptions{
+		Selector:  operatorPodsSelector,
+		TailLines: podLogsTailLines,
+	}, out)
+	if err != nil {
+		t.Logf("Can't capture operator logs: %s", err)
+	}
+}
@@ -77,6 +77,19 @@ func testMainRun(m *testing.M) int {
 
 	operatorImagePath := os.Getenv("OPERATOR_IMAGE_PATH")
 
+	// if set to "openshift", run the suite against the cluster from the current kubeconfig
+	// (e.g. OpenShift Local) instead of creating a kind cluster.
+	platform := v1beta1.Platform(os.Getenv("E2E_PLATFORM"))
+
+	// operator image to deploy when not running on kind, it must be pullable by the cluster.
+	operatorImage := os.Getenv("OPERATOR_IMAGE")
+
+	// pod logs captured into the test output when a feature fails.
+	logsCapture, err := parsePodLogsCapture(os.Getenv("E2E_POD_LOGS"))
+	if err != nil {
+		panic(err)
+	}
+
 	kindClusterName := envconf.RandomName("temporal", 16)
 	runID := envconf.RandomName("ns", 4)
 
@@ -95,14 +108,36 @@ func testMainRun(m *testing.M) int {
 		image:   kindImage,
 	}
 
+	clusterSetup := []env.Func{
+		envfuncs.CreateCluster(kindCluster, kindClusterName),
+		envfuncs.LoadImageArchiveToCluster(kindClusterName, operatorImagePath),
+		envfuncs.SetupCRDs("../../out/release/artifacts", "*.crds.yaml"),
+	}
+	clusterFinish := []env.Func{
+		envfuncs.ExportClusterLogs(kindClusterName, clusterLogsOutPath),
+		envfuncs.TeardownCRDs("../../out/release/artifacts", "*.crds.yaml"),
+		envfuncs.DestroyCluster(kindClusterName),
+	}
+	if platform == v1beta1.OpenShiftPlatform {
+		clusterSetup = []env.Func{
+			envfuncs.SetupCRDs("../../out/release/artifacts", "*.crds.yaml"),
+		}
+		clusterFinish = []env.Func{
+			envfuncs.TeardownCRDs("../../out/release/artifacts", "*.crds.yaml"),
+		}
+	}
+
+	operatorImagePullPolicy := corev1.PullIfNotPresent
+	if operatorImage == "" {
+		operatorImage = "temporal-operator"
+	} else {
+		operatorImagePullPolicy = corev1.PullAlways
+	}
+
 	testenv = env.
 		NewWithConfig(cfg).
 		// Create the cluster
-		Setup(
-			envfuncs.CreateCluster(kindCluster, kindClusterName),
-			envfuncs.LoadImageArchiveToCluster(kindClusterName, operatorImagePath),
-			envfuncs.SetupCRDs("../../out/release/artifacts", "*.crds.yaml"),
-		).
+		Setup(clusterSetup...).
 		// Make sure the cluster version is what we expect
 		Setup(func(ctx context.Context, c *envconf.Config) (context.Context, error) {
 			if kubernetesVersion == "" {
@@ -200,8 +235,8 @@ func testMainRun(m *testing.M) int {
 					operatorDeploy = deploy
 					for i, container := range deploy.Spec.Template.Spec.Containers {
 						if strings.Contains(container.Image, "ghcr.io/alexandrevilain/temporal-operator") {
-							deploy.Spec.Template.Spec.Containers[i].Image = "temporal-operator"
-							deploy.Spec.Template.Spec.Containers[i].ImagePullPolicy = "IfNotPresent"
+							deploy.Spec.Template.Spec.Containers[i].Image = operatorImage
+							deploy.Spec.Template.Spec.Containers[i].ImagePullPolicy = operatorImagePullPolicy
 						}
 					}
 				}
@@ -214,15 +249,11 @@ func testMainRun(m *testing.M) int {
 			err = wait.For(conditions.New(c.Client().Resources()).DeploymentConditionMatch(operatorDeploy, appsv1.DeploymentAvailable, corev1.ConditionTrue), wait.WithTimeout(time.Minute*1))
 			return ctx, err
 		}).
-		Finish(
-			envfuncs.ExportClusterLogs(kindClusterName, clusterLogsOutPath),
-			envfuncs.TeardownCRDs("../../out/release/artifacts", "*.crds.yaml"),
-			envfuncs.DestroyCluster(kindClusterName),
-		).
+		Finish(clusterFinish...).
 		BeforeEachFeature(func(ctx context.Context, cfg *envconf.Config, t *testing.T, f features.Feature) (context.Context, error) {
 			return createNSForTest(ctx, cfg, t, f, runID)
 		}).
-		AfterEachFeature(deleteNSForTest)
+		AfterEachFeature(capturePodLogsOnFailure(logsCapture), deleteNSForTest)
 
 	return testenv.Run(m)
 }
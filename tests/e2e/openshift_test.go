@@ -0,0 +1,168 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/resource/ui"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/features"
+)
+
+func TestOpenShiftPlatform(t *testing.T) {
+	if v1beta1.Platform(os.Getenv("E2E_PLATFORM")) != v1beta1.OpenShiftPlatform {
+		t.Skip("E2E_PLATFORM is not openshift")
+	}
+
+	uiHost := "temporal-ui.apps-crc.testing"
+
+	feature := features.New("cluster on openshift").
+		Setup(func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+			namespace := GetNamespaceForFeature(ctx)
+
+			err := deployAndWaitForPostgres(ctx, cfg, namespace)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			connectAddr := fmt.Sprintf("postgres.%s:5432", namespace)
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: namespace,
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					NumHistoryShards:           1,
+					JobTTLSecondsAfterFinished: &jobTTL,
+					Version:                    version.MustNewVersionFromString("1.23.0"),
+					Platform:                   v1beta1.OpenShiftPlatform,
+					PodSecurity: &v1beta1.PodSecuritySpec{
+						Profile: v1beta1.RestrictedPodSecurityProfile,
+					},
+					UI: &v1beta1.TemporalUISpec{
+						Enabled: true,
+						Ingress: &v1beta1.TemporalUIIngressSpec{
+							Hosts: []string{uiHost},
+						},
+					},
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								User:            "temporal",
+								PluginName:      "postgres",
+								DatabaseName:    "temporal",
+								ConnectAddr:     connectAddr,
+								ConnectProtocol: "tcp",
+							},
+							PasswordSecretRef: &v1beta1.SecretKeyReference{
+								Name: "postgres-password",
+								Key:  "PASSWORD",
+							},
+						},
+						VisibilityStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								User:            "temporal",
+								PluginName:      "postgres",
+								DatabaseName:    "temporal_visibility",
+								ConnectAddr:     connectAddr,
+								ConnectProtocol: "tcp",
+							},
+							PasswordSecretRef: &v1beta1.SecretKeyReference{
+								Name: "postgres-password",
+								Key:  "PASSWORD",
+							},
+						},
+					},
+				},
+			}
+
+			err = cfg.Client().Resources(namespace).Create(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return SetTemporalClusterForFeature(ctx, cluster)
+		}).
+		Assess("Temporal cluster created", AssertTemporalClusterReady()).
+		Assess("Pods are admitted by an SCC", AssertClusterPodsAdmittedBySCC()).
+		Assess("UI is exposed using a route", AssertUIRouteExists(uiHost)).
+		Assess("Temporal cluster can handle workflows", AssertClusterCanHandleWorkflows()).
+		Feature()
+
+	testenv.Test(t, feature)
+}
+
+// AssertClusterPodsAdmittedBySCC checks all the cluster pods were admitted by an openshift SCC.
+// Pods setting an explicit UID out of the namespace range would be rejected by the restricted SCC.
+func AssertClusterPodsAdmittedBySCC() features.Func {
+	return func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+		cluster := GetTemporalClusterForFeature(ctx)
+
+		pods := &corev1.PodList{}
+		err := cfg.Client().Resources(cluster.GetNamespace()).List(ctx, pods, resources.WithLabelSelector("app.kubernetes.io/name="+cluster.GetName()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(pods.Items) == 0 {
+			t.Fatal("no cluster pod found")
+		}
+
+		for _, pod := range pods.Items {
+			if pod.Annotations["openshift.io/scc"] == "" {
+				t.Errorf("pod %s wasn't admitted by an SCC", pod.GetName())
+			}
+		}
+
+		return ctx
+	}
+}
+
+// AssertUIRouteExists checks the UI is exposed using an openshift route for the provided host.
+func AssertUIRouteExists(host string) features.Func {
+	return func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+		cluster := GetTemporalClusterForFeature(ctx)
+
+		route := ui.NewRoute()
+		err := cfg.Client().Resources(cluster.GetNamespace()).Get(ctx, cluster.ChildResourceName("ui"), cluster.GetNamespace(), route)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		routeHost, _, err := unstructured.NestedString(route.Object, "spec", "host")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if routeHost != host {
+			t.Errorf("expected route host %s, got %s", host, routeHost)
+		}
+
+		return ctx
+	}
+}
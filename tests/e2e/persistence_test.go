@@ -336,6 +336,53 @@ func TestPersistence(t *testing.T) {
 				}
 			},
 		},
+		"scylla persistence": {
+			upgradePath:        defaultUpgradePath,
+			deployDependencies: []deployDependencyFunc{deployAndWaitForScylla},
+			cluster: func(_ context.Context, _ *envconf.Config, namespace string) *v1beta1.TemporalCluster {
+				connectAddr := fmt.Sprintf("scylla.%s", namespace)
+
+				return &v1beta1.TemporalCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: namespace,
+					},
+					Spec: v1beta1.TemporalClusterSpec{
+						NumHistoryShards:           1,
+						JobTTLSecondsAfterFinished: &jobTTL,
+						Version:                    version.MustNewVersionFromString(initialClusterVersion),
+						Persistence: v1beta1.TemporalPersistenceSpec{
+							DefaultStore: &v1beta1.DatastoreSpec{
+								Cassandra: &v1beta1.CassandraSpec{
+									Hosts:      []string{connectAddr},
+									User:       "temporal",
+									Keyspace:   "temporal",
+									Datacenter: "datacenter1",
+									Variant:    v1beta1.ScyllaCassandraVariant,
+								},
+								PasswordSecretRef: &v1beta1.SecretKeyReference{
+									Name: "scylla-password",
+									Key:  "PASSWORD",
+								},
+							},
+							VisibilityStore: &v1beta1.DatastoreSpec{
+								Cassandra: &v1beta1.CassandraSpec{
+									Hosts:      []string{connectAddr},
+									User:       "temporal",
+									Keyspace:   "temporal_visibility",
+									Datacenter: "datacenter1",
+									Variant:    v1beta1.ScyllaCassandraVariant,
+								},
+								PasswordSecretRef: &v1beta1.SecretKeyReference{
+									Name: "scylla-password",
+									Key:  "PASSWORD",
+								},
+							},
+						},
+					},
+				}
+			},
+		},
 	}
 
 	featureTable := []features.Feature{}
@@ -0,0 +1,157 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/features"
+)
+
+// userNamespacesMinVersion is the first kubernetes version enabling user namespaces by default.
+var userNamespacesMinVersion = version.MustNewVersionFromString("1.33.0")
+
+func TestRootlessWithUserNamespaces(t *testing.T) {
+	feature := features.New("rootless cluster in user namespaces").
+		Setup(func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+			client, err := discovery.NewDiscoveryClientForConfig(cfg.Client().RESTConfig())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			serverVersion, err := client.ServerVersion()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			v, err := version.NewVersionFromString(serverVersion.GitVersion)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !v.GreaterOrEqual(userNamespacesMinVersion) {
+				t.Skipf("user namespaces are not enabled by default on kubernetes %s", serverVersion.GitVersion)
+			}
+
+			namespace := GetNamespaceForFeature(ctx)
+
+			err = deployAndWaitForPostgres(ctx, cfg, namespace)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			connectAddr := fmt.Sprintf("postgres.%s:5432", namespace)
+			cluster := &v1beta1.TemporalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: namespace,
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					NumHistoryShards:           1,
+					JobTTLSecondsAfterFinished: &jobTTL,
+					Version:                    version.MustNewVersionFromString("1.23.0"),
+					PodSecurity: &v1beta1.PodSecuritySpec{
+						Profile:   v1beta1.RestrictedPodSecurityProfile,
+						HostUsers: ptr.To(false),
+					},
+					AdminTools: &v1beta1.TemporalAdminToolsSpec{
+						Enabled: true,
+					},
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								User:            "temporal",
+								PluginName:      "postgres",
+								DatabaseName:    "temporal",
+								ConnectAddr:     connectAddr,
+								ConnectProtocol: "tcp",
+							},
+							PasswordSecretRef: &v1beta1.SecretKeyReference{
+								Name: "postgres-password",
+								Key:  "PASSWORD",
+							},
+						},
+						VisibilityStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								User:            "temporal",
+								PluginName:      "postgres",
+								DatabaseName:    "temporal_visibility",
+								ConnectAddr:     connectAddr,
+								ConnectProtocol: "tcp",
+							},
+							PasswordSecretRef: &v1beta1.SecretKeyReference{
+								Name: "postgres-password",
+								Key:  "PASSWORD",
+							},
+						},
+					},
+				},
+			}
+
+			err = cfg.Client().Resources(namespace).Create(ctx, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return SetTemporalClusterForFeature(ctx, cluster)
+		}).
+		Assess("Temporal cluster created", AssertTemporalClusterReady()).
+		Assess("Pods run rootless in user namespaces", AssertClusterPodsRunInUserNamespaces()).
+		Assess("Temporal cluster can handle workflows", AssertClusterCanHandleWorkflows()).
+		Feature()
+
+	testenv.Test(t, feature)
+}
+
+// AssertClusterPodsRunInUserNamespaces checks all the cluster pods run as non-root users in their own user namespace.
+func AssertClusterPodsRunInUserNamespaces() features.Func {
+	return func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+		cluster := GetTemporalClusterForFeature(ctx)
+
+		pods := &corev1.PodList{}
+		err := cfg.Client().Resources(cluster.GetNamespace()).List(ctx, pods, resources.WithLabelSelector("app.kubernetes.io/name="+cluster.GetName()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(pods.Items) == 0 {
+			t.Fatal("no cluster pod found")
+		}
+
+		for _, pod := range pods.Items {
+			if pod.Spec.HostUsers == nil || *pod.Spec.HostUsers {
+				t.Errorf("pod %s runs in the host user namespace", pod.GetName())
+			}
+			if pod.Spec.SecurityContext == nil || !ptr.Deref(pod.Spec.SecurityContext.RunAsNonRoot, false) {
+				t.Errorf("pod %s may run as root", pod.GetName())
+			}
+		}
+
+		return ctx
+	}
+}
@@ -35,16 +35,18 @@ func NewStarter(client client.Client) *Starter {
 	}
 }
 
-func (s *Starter) StartGreetingWorkflow() error {
+// RunGreetingWorkflow executes the greeting workflow on the provided task queue and waits for its result.
+func (s *Starter) RunGreetingWorkflow(ctx context.Context, taskQueue string) (string, error) {
 	workflowOptions := client.StartWorkflowOptions{
 		ID:        "greetings_" + uuid.NewString(),
-		TaskQueue: testworker.Taskqueue,
+		TaskQueue: taskQueue,
 	}
 
-	we, err := s.client.ExecuteWorkflow(context.Background(), workflowOptions, testworker.GreetingSample)
+	we, err := s.client.ExecuteWorkflow(ctx, workflowOptions, testworker.GreetingSample)
 	if err != nil {
-		return err
+		return "", err
 	}
 	var result string
-	return we.Get(context.Background(), &result)
+	err = we.Get(ctx, &result)
+	return result, err
 }
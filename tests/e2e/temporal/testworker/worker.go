@@ -30,11 +30,16 @@ type Worker struct {
 }
 
 func NewWorker(client client.Client) (*Worker, error) {
+	return NewWorkerOnTaskQueue(client, Taskqueue)
+}
+
+// NewWorkerOnTaskQueue returns a worker polling the provided task queue.
+func NewWorkerOnTaskQueue(client client.Client, taskQueue string) (*Worker, error) {
 	w := &Worker{
 		client: client,
 	}
 
-	w.worker = worker.New(w.client, Taskqueue, worker.Options{})
+	w.worker = worker.New(w.client, taskQueue, worker.Options{})
 	w.worker.RegisterWorkflow(GreetingSample)
 	w.worker.RegisterActivity(&Activities{
 		Name:     "Temporal",
@@ -0,0 +1,184 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/temporal"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	temporalutil "github.com/alexandrevilain/temporal-operator/tests/e2e/util/temporal"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/features"
+)
+
+const (
+	// upgradeMatrixEnv holds the upgrade paths to test, as semicolon separated lists of comma separated versions,
+	// e.g. "1.21.2,1.22.6,1.23.0;1.22.6,1.23.0". Set it to "default" to test the default path.
+	upgradeMatrixEnv = "E2E_UPGRADE_MATRIX"
+
+	// workflowLoadInterval is the interval between two workflows started during upgrades.
+	workflowLoadInterval = 2 * time.Second
+	// workflowLoadDrainTimeout is the time given to the workflows started during upgrades to complete.
+	workflowLoadDrainTimeout = 5 * time.Minute
+)
+
+// defaultUpgradeMatrix upgrades from N-2 to N through N-1, N being the latest supported version.
+var defaultUpgradeMatrix = [][]string{defaultUpgradePath[len(defaultUpgradePath)-3:]}
+
+// upgradeMatrix parses the upgrade paths to test.
+func upgradeMatrix(value string) ([][]string, error) {
+	if value == "default" {
+		return defaultUpgradeMatrix, nil
+	}
+
+	matrix := [][]string{}
+	for _, rawPath := range strings.Split(value, ";") {
+		path := []string{}
+		var previous *version.Version
+		for _, raw := range strings.Split(rawPath, ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			v, err := version.NewVersionFromString(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version %q in upgrade path %q: %w", raw, rawPath, err)
+			}
+			if previous != nil && !v.GreaterOrEqual(previous) {
+				return nil, fmt.Errorf("upgrade path %q must be in ascending order", rawPath)
+			}
+			previous = v
+			path = append(path, raw)
+		}
+		if len(path) < 2 {
+			return nil, fmt.Errorf("upgrade path %q must contain at least two versions", rawPath)
+		}
+		matrix = append(matrix, path)
+	}
+
+	return matrix, nil
+}
+
+// TestUpgradeMatrix upgrades clusters through each upgrade path of the matrix while running workflows continuously,
+// and asserts that no workflow accepted by the cluster is lost.
+// It is skipped unless E2E_UPGRADE_MATRIX is set, see "make test-e2e-upgrade-matrix".
+func TestUpgradeMatrix(t *testing.T) {
+	value := os.Getenv(upgradeMatrixEnv)
+	if value == "" {
+		t.Skipf("%s is not set", upgradeMatrixEnv)
+	}
+
+	matrix, err := upgradeMatrix(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	featureTable := []features.Feature{}
+
+	for _, upgradePath := range matrix {
+		path := upgradePath
+		feature := features.New(fmt.Sprintf("upgrade %s", strings.Join(path, " -> "))).
+			Setup(func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+				namespace := GetNamespaceForFeature(ctx)
+
+				cluster, err := deployAndWaitForTemporalWithPostgres(ctx, cfg, namespace, path[0])
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return SetTemporalClusterForFeature(ctx, cluster)
+			}).
+			Assess("Temporal cluster created", AssertTemporalClusterReady()).
+			Assess("Can create a TemporalNamespace", AssertCanCreateTemporalNamespace("default")).
+			Assess("TemporalNamespace ready", AssertTemporalNamespaceReady()).
+			Assess("Start running workflows continuously", AssertCanStartWorkflowLoad())
+
+		for _, v := range path[1:] {
+			feature.
+				Assess(fmt.Sprintf("Upgrade cluster to %s", v), AssertTemporalClusterCanBeUpgraded(v)).
+				Assess(fmt.Sprintf("Temporal cluster ready after upgrade to %s", v), AssertTemporalClusterReady())
+		}
+
+		feature.Assess("No workflow completion lost", AssertNoWorkflowLost())
+
+		featureTable = append(featureTable, feature.Feature())
+	}
+
+	testenv.TestInParallel(t, featureTable...)
+}
+
+// AssertCanStartWorkflowLoad starts running workflows continuously against the cluster frontend.
+func AssertCanStartWorkflowLoad() features.Func {
+	return func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+		cluster := GetTemporalClusterForFeature(ctx)
+
+		connectAddr, closePortForward, err := forwardPortToTemporalFrontendContinuously(ctx, cfg, t, cluster)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tlsConfig, err := temporal.GetClusterClientTLSConfig(ctx, cfg.Client().Resources().GetControllerRuntimeClient(), cluster)
+		if err != nil {
+			closePortForward()
+			t.Fatal(err)
+		}
+
+		load := temporalutil.NewWorkflowLoad(connectAddr, tlsConfig, workflowLoadInterval)
+		err = load.Start(ctx)
+		if err != nil {
+			closePortForward()
+			t.Fatal(err)
+		}
+
+		return SetWorkflowLoadForFeature(ctx, &runningWorkflowLoad{
+			load:             load,
+			closePortForward: closePortForward,
+		})
+	}
+}
+
+// AssertNoWorkflowLost stops the workflow load and asserts every accepted workflow completed.
+func AssertNoWorkflowLost() features.Func {
+	return func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+		running := GetWorkflowLoadForFeature(ctx)
+		defer running.closePortForward()
+
+		report, err := running.load.Stop(ctx, workflowLoadDrainTimeout)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Logf("Workflows started: %d, completed: %d, rejected while starting: %d", report.Started, report.Completed, report.StartErrors)
+
+		if report.Started == 0 {
+			t.Fatal("No workflow was accepted by the cluster during the upgrades")
+		}
+
+		for id, err := range report.Lost {
+			t.Errorf("Workflow %s didn't complete: %s", id, err)
+		}
+
+		return ctx
+	}
+}
@@ -0,0 +1,135 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kubernetes
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// PodLogsOptions configures the pod logs capture.
+type PodLogsOptions struct {
+	// Selector is the label selector of the pods to capture logs of.
+	Selector string
+	// OnlyFailing restricts the capture to failing pods, see IsPodFailing.
+	OnlyFailing bool
+	// TailLines is the number of lines captured from the end of each container logs.
+	// If zero, all lines are captured.
+	TailLines int64
+}
+
+// WritePodsLogs writes the logs of the containers of the pods matching the options to out.
+// Logs of the previous container instance are written too for restarted containers, as they usually hold the crash cause.
+func WritePodsLogs(ctx context.Context, cfg *rest.Config, namespace string, opts PodLogsOptions, out io.Writer) error {
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: opts.Selector})
+	if err != nil {
+		return fmt.Errorf("can't list pods: %w", err)
+	}
+
+	var errs []error
+	for _, pod := range pods.Items {
+		if opts.OnlyFailing && !IsPodFailing(&pod) {
+			continue
+		}
+
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			if status.RestartCount > 0 {
+				errs = append(errs, writeContainerLogs(ctx, clientset, &pod, status.Name, true, opts.TailLines, out))
+			}
+			errs = append(errs, writeContainerLogs(ctx, clientset, &pod, status.Name, false, opts.TailLines, out))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func writeContainerLogs(ctx context.Context, clientset kubernetes.Interface, pod *corev1.Pod, container string, previous bool, tailLines int64, out io.Writer) error {
+	logOpts := &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+	}
+	if tailLines > 0 {
+		logOpts.TailLines = &tailLines
+	}
+
+	stream, err := clientset.CoreV1().Pods(pod.GetNamespace()).GetLogs(pod.GetName(), logOpts).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("can't get logs of %s/%s container %s: %w", pod.GetNamespace(), pod.GetName(), container, err)
+	}
+	defer stream.Close()
+
+	instance := "current"
+	if previous {
+		instance = "previous"
+	}
+	fmt.Fprintf(out, "===== logs of %s/%s container %s (%s) =====\n", pod.GetNamespace(), pod.GetName(), container, instance)
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fmt.Fprintln(out, scanner.Text())
+	}
+	return scanner.Err()
+}
+
+// IsPodFailing returns true if the pod failed, isn't ready, or one of its containers restarted or is waiting on an error.
+func IsPodFailing(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodFailed {
+		return true
+	}
+
+	if pod.Status.Phase == corev1.PodSucceeded {
+		return false
+	}
+
+	ready := false
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+			ready = true
+		}
+	}
+	if !ready {
+		return true
+	}
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.RestartCount > 0 {
+			return true
+		}
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" && status.State.Waiting.Reason != "ContainerCreating" && status.State.Waiting.Reason != "PodInitializing" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,181 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/tests/e2e/temporal/testworker"
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+)
+
+// WorkflowLoad continuously starts sample workflows against a frontend, to check that
+// no accepted workflow is lost while the cluster is disrupted (e.g. upgraded).
+type WorkflowLoad struct {
+	addr      string
+	tlsConfig *tls.Config
+	interval  time.Duration
+
+	client    client.Client
+	worker    worker.Worker
+	taskQueue string
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu          sync.Mutex
+	executions  []client.WorkflowRun
+	startErrors int
+}
+
+// WorkflowLoadReport summarizes the outcome of a WorkflowLoad.
+type WorkflowLoadReport struct {
+	// Started is the number of workflows accepted by the cluster.
+	Started int
+	// Completed is the number of accepted workflows which completed successfully.
+	Completed int
+	// StartErrors is the number of workflows the cluster couldn't accept, e.g. while the frontend was restarting.
+	// These workflows were never started, so they are not lost.
+	StartErrors int
+	// Lost lists the accepted workflows which didn't complete, with their error.
+	Lost map[string]error
+}
+
+// NewWorkflowLoad returns a load starting a sample workflow every interval against the frontend listening on addr.
+// Set tlsConfig to reach mTLS-enabled frontends, or leave it nil for plaintext frontends.
+func NewWorkflowLoad(addr string, tlsConfig *tls.Config, interval time.Duration) *WorkflowLoad {
+	return &WorkflowLoad{
+		addr:      addr,
+		tlsConfig: tlsConfig,
+		interval:  interval,
+		taskQueue: fmt.Sprintf("%s-%s", testworker.Taskqueue, uuid.NewString()),
+	}
+}
+
+// Start starts the worker and the workflow generator.
+func (l *WorkflowLoad) Start(ctx context.Context) error {
+	c, err := client.DialContext(ctx, client.Options{
+		HostPort:  l.addr,
+		Namespace: "default",
+		ConnectionOptions: client.ConnectionOptions{
+			TLS: l.tlsConfig,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("can't connect to temporal frontend %s: %w", l.addr, err)
+	}
+	l.client = c
+
+	l.worker = worker.New(c, l.taskQueue, worker.Options{})
+	l.worker.RegisterWorkflow(testworker.GreetingSample)
+	l.worker.RegisterActivity(&testworker.Activities{
+		Name:     "Temporal",
+		Greeting: "Hello",
+	})
+	err = l.worker.Start()
+	if err != nil {
+		c.Close()
+		return fmt.Errorf("can't start test worker: %w", err)
+	}
+
+	ctx, l.cancel = context.WithCancel(context.Background())
+	l.done = make(chan struct{})
+
+	go l.run(ctx)
+
+	return nil
+}
+
+func (l *WorkflowLoad) run(ctx context.Context) {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.startWorkflow(ctx)
+		}
+	}
+}
+
+func (l *WorkflowLoad) startWorkflow(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	run, err := l.client.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:        "load_" + uuid.NewString(),
+		TaskQueue: l.taskQueue,
+	}, testworker.GreetingSample)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err != nil {
+		l.startErrors++
+		return
+	}
+	l.executions = append(l.executions, run)
+}
+
+// Stop stops starting workflows, waits up to timeout for the accepted ones to complete,
+// then stops the worker and returns the load report.
+func (l *WorkflowLoad) Stop(ctx context.Context, timeout time.Duration) (WorkflowLoadReport, error) {
+	if l.cancel == nil {
+		return WorkflowLoadReport{}, errors.New("workflow load is not started")
+	}
+	l.cancel()
+	<-l.done
+
+	defer l.client.Close()
+	defer l.worker.Stop()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	report := WorkflowLoadReport{
+		Started:     len(l.executions),
+		StartErrors: l.startErrors,
+		Lost:        map[string]error{},
+	}
+
+	for _, run := range l.executions {
+		var result string
+		// Results are only read once the load is stopped, so workflows started before a disruption are checked too.
+		err := l.client.GetWorkflow(ctx, run.GetID(), run.GetRunID()).Get(ctx, &result)
+		if err != nil {
+			report.Lost[run.GetID()] = err
+			continue
+		}
+		report.Completed++
+	}
+
+	return report, nil
+}
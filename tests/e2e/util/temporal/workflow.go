@@ -0,0 +1,89 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package temporal
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexandrevilain/temporal-operator/pkg/networking"
+	"github.com/alexandrevilain/temporal-operator/tests/e2e/temporal/teststarter"
+	"github.com/alexandrevilain/temporal-operator/tests/e2e/temporal/testworker"
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/client"
+)
+
+// frontendHealthService is the gRPC health service name of the temporal frontend.
+const frontendHealthService = "temporal.api.workflowservice.v1.WorkflowService"
+
+// sampleWorkflowTimeout bounds the sample workflow execution, including the worker startup.
+const sampleWorkflowTimeout = 2 * time.Minute
+
+// RunSampleWorkflow runs the greeting sample workflow against the frontend listening on addr,
+// in the "default" namespace, and checks its result.
+// A worker is started on a dedicated task queue for the run, so concurrent runs don't steal each other's tasks.
+// Set tlsConfig to reach mTLS-enabled frontends, or leave it nil for plaintext frontends.
+func RunSampleWorkflow(ctx context.Context, addr string, tlsConfig *tls.Config) error {
+	ctx, cancel := context.WithTimeout(ctx, sampleWorkflowTimeout)
+	defer cancel()
+
+	err := networking.WaitForGRPCHealth(ctx, addr, tlsConfig, frontendHealthService, time.Second)
+	if err != nil {
+		return fmt.Errorf("temporal frontend %s is not healthy: %w", addr, err)
+	}
+
+	c, err := client.DialContext(ctx, client.Options{
+		HostPort:  addr,
+		Namespace: "default",
+		ConnectionOptions: client.ConnectionOptions{
+			TLS: tlsConfig,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("can't connect to temporal frontend %s: %w", addr, err)
+	}
+
+	taskQueue := fmt.Sprintf("%s-%s", testworker.Taskqueue, uuid.NewString())
+
+	w, err := testworker.NewWorkerOnTaskQueue(c, taskQueue)
+	if err != nil {
+		c.Close()
+		return fmt.Errorf("can't create test worker: %w", err)
+	}
+
+	err = w.Start()
+	if err != nil {
+		c.Close()
+		return fmt.Errorf("can't start test worker: %w", err)
+	}
+	defer w.Stop()
+
+	result, err := teststarter.NewStarter(c).RunGreetingWorkflow(ctx, taskQueue)
+	if err != nil {
+		return fmt.Errorf("sample workflow failed: %w", err)
+	}
+
+	if !strings.HasPrefix(result, "Greeting: Hello Temporal!") {
+		return fmt.Errorf("unexpected sample workflow result: %q", result)
+	}
+
+	return nil
+}
@@ -28,9 +28,8 @@ import (
 	"time"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/kubernetes"
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
-	kubernetesutil "github.com/alexandrevilain/temporal-operator/tests/e2e/util/kubernetes"
-	"github.com/alexandrevilain/temporal-operator/tests/e2e/util/networking"
 	"go.temporal.io/server/common/primitives"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -235,6 +234,20 @@ func deployAndWaitForCassandra(ctx context.Context, cfg *envconf.Config, namespa
 	return wait.For(conditions.New(cfg.Client().Resources()).PodReady(&pod), wait.WithTimeout(10*time.Minute))
 }
 
+func deployAndWaitForScylla(ctx context.Context, cfg *envconf.Config, namespace string) error {
+	name := "scylla"
+	err := deployTestManifest(ctx, cfg, name, namespace)
+	if err != nil {
+		return err
+	}
+
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-0", name), Namespace: namespace},
+	}
+
+	return wait.For(conditions.New(cfg.Client().Resources()).PodReady(&pod), wait.WithTimeout(10*time.Minute))
+}
+
 func deployAndWaitFor(ctx context.Context, cfg *envconf.Config, name, namespace string) error {
 	err := deployTestManifest(ctx, cfg, name, namespace)
 	if err != nil {
@@ -318,42 +331,27 @@ func forwardPortToTemporalFrontend(ctx context.Context, cfg *envconf.Config, t *
 	return forwardPortToPod(ctx, cfg, t, cluster.GetNamespace(), selector, 7233)
 }
 
+// forwardPortToPod forwards a local port to the provided port of one of the ready pods matching the selector.
+// The port is forwarded to another ready pod if the connection to the current one is lost.
 func forwardPortToPod(ctx context.Context, cfg *envconf.Config, t *testing.T, namespace string, selector labels.Selector, port int) (string, func(), error) {
-	podList := &corev1.PodList{}
-	err := cfg.Client().Resources(namespace).List(ctx, podList, resources.WithLabelSelector(selector.String()))
+	portForward, err := kubernetes.ForwardPortToReadyPods(ctx, cfg.Client().RESTConfig(), cfg.Client().Resources().GetControllerRuntimeClient(), namespace, selector, int32(port), &testLogWriter{t})
 	if err != nil {
 		return "", nil, err
 	}
 
-	if len(podList.Items) == 0 {
-		return "", nil, errors.New("no frontend port found")
-	}
-
-	selectedPod := podList.Items[0]
-
-	localPort, err := networking.GetFreePort()
-	if err != nil {
-		return "", nil, err
-	}
-
-	// stopCh control the port forwarding lifecycle. When it gets closed the
-	// port forward will terminate
-	stopCh := make(chan struct{}, 1)
-	// readyCh communicate when the port forward is ready to get traffic
-	readyCh := make(chan struct{})
-
-	out := &testLogWriter{t}
+	t.Log("Port forwarding is ready to get traffic.")
 
-	go func() {
-		err := kubernetesutil.ForwardPortToPod(cfg.Client().RESTConfig(), &selectedPod, localPort, port, out, stopCh, readyCh)
-		if err != nil {
-			panic(err)
-		}
-	}()
+	return portForward.Address(), portForward.Close, nil
+}
 
-	<-readyCh
-	t.Log("Port forwarding is ready to get traffic.")
+// forwardPortToTemporalFrontendContinuously forwards a local port to a ready frontend pod of the cluster.
+// Unlike forwardPortToTemporalFrontend, the frontend pods are not filtered by version,
+// so that the port is forwarded to the new frontend pods when the cluster is upgraded.
+func forwardPortToTemporalFrontendContinuously(ctx context.Context, cfg *envconf.Config, t *testing.T, cluster *v1beta1.TemporalCluster) (string, func(), error) {
+	selector := labels.SelectorFromSet(labels.Set{
+		"app.kubernetes.io/name":      cluster.GetName(),
+		"app.kubernetes.io/component": string(primitives.FrontendService),
+	})
 
-	connectAddr := fmt.Sprintf("localhost:%d", localPort)
-	return connectAddr, func() { close(stopCh) }, nil
+	return forwardPortToPod(ctx, cfg, t, cluster.GetNamespace(), selector, 7233)
 }
@@ -0,0 +1,231 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package webhooks
+
+import (
+	"fmt"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/pkg/sizing"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// minProductionHistoryShards is the number of history shards under which a highly available cluster
+// may not be able to scale, as the number of shards can't be changed once the cluster is created.
+const minProductionHistoryShards = 512
+
+// deprecatedField is a TemporalCluster field planned for removal in the next API version.
+type deprecatedField struct {
+	path *field.Path
+	// isSet returns true if the cluster uses the deprecated field.
+	isSet func(cluster *v1beta1.TemporalCluster) bool
+	// replacement explains what to use instead.
+	replacement string
+}
+
+var deprecatedFields = []deprecatedField{
+	{
+		path: field.NewPath("spec", "metrics", "prometheus", "listenAddress"),
+		isSet: func(cluster *v1beta1.TemporalCluster) bool {
+			return cluster.Spec.Metrics != nil &&
+				cluster.Spec.Metrics.Prometheus != nil &&
+				cluster.Spec.Metrics.Prometheus.ListenAddress != ""
+		},
+		replacement: "use spec.metrics.prometheus.listenPort instead",
+	},
+	{
+		path: field.NewPath("spec", "persistence", "advancedVisibilityStore"),
+		isSet: func(cluster *v1beta1.TemporalCluster) bool {
+			// Clusters >= 1.21 already get a dedicated warning.
+			return cluster.Spec.Persistence.AdvancedVisibilityStore != nil &&
+				cluster.Spec.Version != nil &&
+				!cluster.Spec.Version.GreaterOrEqual(version.V1_21_0)
+		},
+		replacement: "upgrade to temporal >= 1.21 and configure Elasticsearch as spec.persistence.visibilityStore instead",
+	},
+}
+
+// deprecationWarnings warns about deprecated fields used by the cluster.
+func deprecationWarnings(cluster *v1beta1.TemporalCluster) admission.Warnings {
+	var warns admission.Warnings
+
+	for _, deprecated := range deprecatedFields {
+		if deprecated.isSet(cluster) {
+			warns = append(warns,
+				fmt.Sprintf("%s is deprecated and will be removed in v1beta2: %s", deprecated.path, deprecated.replacement),
+			)
+		}
+	}
+
+	return warns
+}
+
+// riskyConfigurationWarnings warns about valid but risky settings combinations.
+// Highly available clusters are considered to run in production.
+func riskyConfigurationWarnings(cluster *v1beta1.TemporalCluster) admission.Warnings {
+	var warns admission.Warnings
+
+	if !cluster.Spec.HighAvailability {
+		return warns
+	}
+
+	mTLS := cluster.Spec.MTLS
+	if mTLS == nil || !mTLS.InternodeEnabled() {
+		warns = append(warns,
+			"spec.highAvailability is enabled but internode mTLS is disabled: temporal services communicate in clear text. Enable spec.mTLS.internode for production clusters.",
+		)
+	}
+
+	if (mTLS == nil || !mTLS.FrontendEnabled()) && !cluster.Spec.Authorization.IsEnabled() {
+		warns = append(warns,
+			"spec.highAvailability is enabled but both frontend mTLS and authorization are disabled: any client reaching the frontend can manage the cluster. Enable spec.mTLS.frontend or spec.authorization for production clusters.",
+		)
+	}
+
+	if cluster.Spec.UI != nil && cluster.Spec.UI.Enabled && cluster.Spec.UI.Ingress != nil && len(cluster.Spec.UI.Ingress.TLS) == 0 {
+		warns = append(warns,
+			"spec.highAvailability is enabled but the UI ingress has no TLS configuration: the UI is exposed over plain HTTP. Set spec.ui.ingress.tls for production clusters.",
+		)
+	}
+
+	if cluster.Spec.NumHistoryShards < minProductionHistoryShards {
+		warns = append(warns,
+			fmt.Sprintf("spec.highAvailability is enabled but spec.numHistoryShards is %d: the number of history shards can't be changed once the cluster is created and limits how far the cluster can scale. Production clusters usually run at least %d shards.", cluster.Spec.NumHistoryShards, minProductionHistoryShards),
+		)
+	}
+
+	return warns
+}
+
+// exposeWarnings warns about hostnames external-dns may not publish.
+func exposeWarnings(cluster *v1beta1.TemporalCluster) admission.Warnings {
+	var warns admission.Warnings
+
+	if len(cluster.Spec.Expose.GetFrontendHostnames()) == 0 {
+		return warns
+	}
+
+	// ExternalName Services are published by external-dns as CNAME records.
+	if external := cluster.Spec.Expose.GetExternalFrontend(); external != nil && external.ExternalName != "" {
+		return warns
+	}
+
+	warns = append(warns,
+		"spec.expose.hostnames.frontend is set but the frontend Service is a ClusterIP Service: external-dns only publishes it when started with --publish-internal-services, and the records resolve to an in-cluster IP. Expose the frontend through a load balancer and map it using spec.expose.externalFrontend.externalName to reach it from outside kubernetes.",
+	)
+	return warns
+}
+
+// managedElasticsearchWarnings warns about Elasticsearch instances run by the operator, as they are not fit for production.
+func managedElasticsearchWarnings(cluster *v1beta1.TemporalCluster) admission.Warnings {
+	var warns admission.Warnings
+
+	stores := []struct {
+		path  string
+		store *v1beta1.DatastoreSpec
+	}{
+		{"visibilityStore", cluster.Spec.Persistence.VisibilityStore},
+		{"secondaryVisibilityStore", cluster.Spec.Persistence.SecondaryVisibilityStore},
+		{"advancedVisibilityStore", cluster.Spec.Persistence.AdvancedVisibilityStore},
+	}
+
+	for _, s := range stores {
+		if s.store == nil || !s.store.ManagedElasticsearchEnabled() {
+			continue
+		}
+		warns = append(warns,
+			fmt.Sprintf("spec.persistence.%s.elasticsearch.managed is enabled: the operator runs a single-node Elasticsearch instance without security, replication nor backups. Only use it for development and test clusters.", s.path),
+		)
+	}
+
+	return warns
+}
+
+// resourcesWarnings warns about services likely to be OOM killed.
+// The history service caches workflow executions and events: it's the most memory hungry service.
+func resourcesWarnings(cluster *v1beta1.TemporalCluster) admission.Warnings {
+	var warns admission.Warnings
+
+	if cluster.Spec.Services == nil || cluster.Spec.Services.History == nil {
+		return warns
+	}
+
+	history := cluster.Spec.Services.History
+	resources := history.MemoryProtection.GetResources(history.Resources)
+	if resources.Limits.Memory().IsZero() {
+		warns = append(warns,
+			"spec.services.history.resources has no memory limit: history pods can use all the node memory and get OOM killed along with their neighbors. Set a memory limit and spec.services.history.memoryProtection.headroomPercent.",
+		)
+	}
+
+	return warns
+}
+
+// sizingWarnings warns when the history shards, replicas and resources of the cluster aren't mutually sensible.
+func sizingWarnings(cluster *v1beta1.TemporalCluster) admission.Warnings {
+	var warns admission.Warnings
+	for _, issue := range sizing.Check(cluster) {
+		warns = append(warns, "sizing: "+issue)
+	}
+	return warns
+}
+
+// certificatesRenewalWarnings warns when the cert-manager certificates renewal window doesn't leave enough time
+// for the services to reload the renewed certificates and for all the cluster pods to be rolled.
+func certificatesRenewalWarnings(cluster *v1beta1.TemporalCluster) admission.Warnings {
+	var warns admission.Warnings
+
+	if !cluster.MTLSWithCertManagerEnabled() {
+		return warns
+	}
+
+	mTLS := cluster.Spec.MTLS
+	window := cluster.CertificateRenewalWindow()
+	rollout := cluster.EstimatedRolloutDuration()
+
+	if mTLS.RenewBefore != nil && mTLS.RenewBefore.Duration < window {
+		warns = append(warns,
+			fmt.Sprintf("spec.mTLS.renewBefore is %s but reloading the certificates and rolling all the cluster pods may take %s (%s rollout): the operator renews the certificates %s before they expire when their duration allows it.", mTLS.RenewBefore.Duration, window, rollout, window),
+		)
+	}
+
+	if mTLS.CertificatesDuration == nil {
+		return warns
+	}
+
+	durations := []struct {
+		name     string
+		duration *metav1.Duration
+	}{
+		{"clientCertificates", mTLS.CertificatesDuration.ClientCertificates},
+		{"frontendCertificate", mTLS.CertificatesDuration.FrontendCertificate},
+		{"internodeCertificate", mTLS.CertificatesDuration.InternodeCertificate},
+	}
+	for _, d := range durations {
+		if d.duration != nil && d.duration.Duration <= window {
+			warns = append(warns,
+				fmt.Sprintf("spec.mTLS.certificatesDuration.%s is %s but reloading the certificates and rolling all the cluster pods may take %s (%s rollout): pods may keep serving expired certificates.", d.name, d.duration.Duration, window, rollout),
+			)
+		}
+	}
+
+	return warns
+}
@@ -21,26 +21,51 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"path"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/defaults"
 	"github.com/alexandrevilain/temporal-operator/internal/discovery"
+	"github.com/alexandrevilain/temporal-operator/internal/logging"
+	"github.com/alexandrevilain/temporal-operator/pkg/edition"
+	temporalconfig "github.com/alexandrevilain/temporal-operator/pkg/temporal/config"
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/go-logr/logr"
 	enumspb "go.temporal.io/api/enums/v1"
 	enumsspb "go.temporal.io/server/api/enums/v1"
+	"go.temporal.io/server/common/primitives"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	"k8s.io/utils/strings/slices"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=list
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalclustertemplates,verbs=get;list;watch
+//+kubebuilder:rbac:groups=temporal.io,resources=temporalclusteroverrides,verbs=get;list;watch
+
 // TemporalClusterWebhook provides endpoints to validate
 // and set default fields values for TemporalCluster objects.
 type TemporalClusterWebhook struct {
 	AvailableAPIs *discovery.AvailableAPIs
+	// Client is used to inspect the kubernetes nodes topology.
+	// If nil, node topology checks are skipped.
+	Client client.Reader
+	// Defaults are the operator-level defaults applied to clusters that don't specify them.
+	// If nil, no operator-level defaults are applied.
+	Defaults *defaults.Options
 }
 
 func (w *TemporalClusterWebhook) getClusterFromRequest(obj runtime.Object) (*v1beta1.TemporalCluster, error) {
@@ -51,25 +76,49 @@ func (w *TemporalClusterWebhook) getClusterFromRequest(obj runtime.Object) (*v1b
 	return cluster, nil
 }
 
-func (w *TemporalClusterWebhook) aggregateClusterErrors(cluster *v1beta1.TemporalCluster, errs field.ErrorList) error {
+func (w *TemporalClusterWebhook) aggregateClusterErrors(ctx context.Context, cluster *v1beta1.TemporalCluster, errs field.ErrorList) error {
 	if len(errs) == 0 {
 		return nil
 	}
 
-	return apierrors.NewInvalid(
+	err := apierrors.NewInvalid(
 		cluster.GroupVersionKind().GroupKind(),
 		cluster.GetName(),
 		errs,
 	)
+	w.logger(ctx, cluster).Info("Rejecting invalid cluster", "reason", err.Error())
+
+	return err
+}
+
+// logger returns the admission request logger with the cluster correlation fields.
+// The admission request UID is used as reconcileID.
+func (w *TemporalClusterWebhook) logger(ctx context.Context, cluster *v1beta1.TemporalCluster) logr.Logger {
+	_, logger := logging.WithCluster(ctx, cluster.GetName(), cluster)
+	if req, err := admission.RequestFromContext(ctx); err == nil {
+		logger = logger.WithValues(logging.ReconcileIDKey, req.UID)
+	}
+	return logger
 }
 
 // Default ensures empty fields have their default value.
-func (w *TemporalClusterWebhook) Default(_ context.Context, obj runtime.Object) error {
+func (w *TemporalClusterWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	cluster, err := w.getClusterFromRequest(obj)
 	if err != nil {
 		return err
 	}
 
+	w.logger(ctx, cluster).V(1).Info("Setting cluster default values")
+
+	if err := w.applyTemplate(ctx, cluster); err != nil {
+		return err
+	}
+
+	// Operator-level defaults apply to the fields neither the user nor the template set.
+	if w.Defaults != nil {
+		w.Defaults.Get().Apply(cluster)
+	}
+
 	if cluster.Spec.Metrics.IsEnabled() {
 		if cluster.Spec.Metrics.Prometheus != nil {
 			// If the user has set the deprecated ListenAddress field and not the new ListenPort,
@@ -111,20 +160,42 @@ func (w *TemporalClusterWebhook) validateCluster(cluster *v1beta1.TemporalCluste
 		)
 	}
 
+	// On openshift the UI is exposed using routes, warn the user if they can't be created.
+	if cluster.IsOpenShift() && cluster.Spec.UI != nil && cluster.Spec.UI.Ingress != nil && !w.AvailableAPIs.Routes {
+		warns = append(warns, "Platform is openshift but openshift routes are not available in the cluster, the UI won't be exposed")
+	}
+
+	warns = append(warns, deprecationWarnings(cluster)...)
+	warns = append(warns, riskyConfigurationWarnings(cluster)...)
+	warns = append(warns, resourcesWarnings(cluster)...)
+	warns = append(warns, sizingWarnings(cluster)...)
+	warns = append(warns, certificatesRenewalWarnings(cluster)...)
+	warns = append(warns, managedElasticsearchWarnings(cluster)...)
+	warns = append(warns, exposeWarnings(cluster)...)
+
 	mTLSWarnings, mTLSErrors := cluster.Spec.MTLS.Validate()
 	warns = append(warns, mTLSWarnings...)
 	errs = append(errs, mTLSErrors...)
 
+	// Ensure the config blocks are supported by the temporal server build.
+	errs = append(errs, edition.Validate(cluster)...)
+
+	// Ensure every deployed service can find its external configuration.
+	errs = append(errs, cluster.Spec.ExternalConfig.Validate(cluster.DeployedServices())...)
+
 	// Validate that the cluster version is a supported one.
-	err := cluster.Spec.Version.Validate()
+	compatibilityWarning, err := version.Compatibility.Check(cluster.Spec.Version)
 	if err != nil {
 		errs = append(errs,
 			field.Forbidden(
 				field.NewPath("spec", "version"),
-				fmt.Sprintf("Unsupported temporal version (supported: %s)", version.SupportedVersionsRange.String()),
+				fmt.Sprintf("Unsupported temporal version: %s", err.Error()),
 			),
 		)
 	}
+	if compatibilityWarning != "" {
+		warns = append(warns, compatibilityWarning)
+	}
 
 	// Ensure ElasticSearch v6 is not used with cluster >= 1.18.0
 	if cluster.Spec.Version.GreaterOrEqual(version.V1_18_0) &&
@@ -139,6 +210,31 @@ func (w *TemporalClusterWebhook) validateCluster(cluster *v1beta1.TemporalCluste
 		)
 	}
 
+	// Ensure managed Elasticsearch instances can be run.
+	for _, s := range []struct {
+		path  string
+		store *v1beta1.DatastoreSpec
+	}{
+		{"visibilityStore", cluster.Spec.Persistence.VisibilityStore},
+		{"secondaryVisibilityStore", cluster.Spec.Persistence.SecondaryVisibilityStore},
+		{"advancedVisibilityStore", cluster.Spec.Persistence.AdvancedVisibilityStore},
+	} {
+		if s.store == nil || !s.store.ManagedElasticsearchEnabled() {
+			continue
+		}
+		path := field.NewPath("spec", "persistence", s.path, "elasticsearch", "managed")
+		if s.store.Elasticsearch.Version == "v6" {
+			errs = append(errs,
+				field.Forbidden(path, "managed Elasticsearch requires Elasticsearch v7 or v8"),
+			)
+		}
+		if s.store.TLS != nil && s.store.TLS.Enabled {
+			errs = append(errs,
+				field.Forbidden(path, "managed Elasticsearch doesn't support TLS, disable the datastore tls"),
+			)
+		}
+	}
+
 	// Ensure dynamicconfig is valid.
 	if cluster.Spec.DynamicConfig != nil {
 		for key, constrainedValues := range cluster.Spec.DynamicConfig.Values {
@@ -185,26 +281,195 @@ func (w *TemporalClusterWebhook) validateCluster(cluster *v1beta1.TemporalCluste
 		}
 	}
 
+	// Ensure features can be enabled.
+	for i, feature := range cluster.Spec.Features {
+		path := field.NewPath("spec", "features").Index(i)
+		if cluster.Spec.DynamicConfig == nil {
+			errs = append(errs, field.Forbidden(path, "features require spec.dynamicConfig to be set"))
+			break
+		}
+
+		if _, err := temporalconfig.FeatureDynamicConfigKeys(feature, cluster.Spec.Version); err != nil {
+			errs = append(errs, field.Invalid(path, feature, err.Error()))
+		}
+	}
+
+	// Ensure services drain durations and traffic settings can be applied.
+	if cluster.Spec.Services != nil {
+		drainServices := []struct {
+			name string
+			spec *v1beta1.ServiceSpec
+		}{
+			{"frontend", cluster.Spec.Services.Frontend},
+			{"history", cluster.Spec.Services.History},
+			{"matching", cluster.Spec.Services.Matching},
+			{"worker", cluster.Spec.Services.Worker},
+		}
+		for _, service := range drainServices {
+			if service.spec == nil || service.spec.GracefulShutdown == nil {
+				continue
+			}
+
+			if service.spec.GracefulShutdown.CoordinatedScaleDown {
+				path := field.NewPath("spec", "services", service.name, "gracefulShutdown", "coordinatedScaleDown")
+				if service.name != "matching" {
+					errs = append(errs, field.Forbidden(path, "coordinated scale-down is only supported by the matching service"))
+				} else if service.spec.GracefulShutdown.DrainDuration == nil {
+					errs = append(errs, field.Forbidden(path, "coordinated scale-down requires gracefulShutdown.drainDuration to be set"))
+				}
+			}
+
+			if service.spec.GracefulShutdown.DrainDuration == nil {
+				continue
+			}
+
+			path := field.NewPath("spec", "services", service.name, "gracefulShutdown", "drainDuration")
+			if service.name == "worker" {
+				errs = append(errs, field.Forbidden(path, "worker service doesn't support shutdown drain duration"))
+				continue
+			}
+
+			if cluster.Spec.DynamicConfig == nil {
+				errs = append(errs, field.Forbidden(path, "shutdown drain duration requires spec.dynamicConfig to be set"))
+			}
+		}
+
+		for _, service := range drainServices {
+			if service.name == "frontend" || service.spec == nil || service.spec.Traffic == nil {
+				continue
+			}
+			path := field.NewPath("spec", "services", service.name, "traffic")
+			errs = append(errs, field.Forbidden(path, "traffic is only supported by the frontend service"))
+		}
+
+		for _, service := range drainServices {
+			if service.name == "frontend" || service.spec == nil || service.spec.Proxy == nil {
+				continue
+			}
+			path := field.NewPath("spec", "services", service.name, "proxy")
+			errs = append(errs, field.Forbidden(path, "proxy is only supported by the frontend service"))
+		}
+	}
+
+	// Ensure the frontend can be served by the proxy sidecar only.
+	if cluster.FrontendProxyEnabled() {
+		path := field.NewPath("spec", "services", "frontend", "proxy", "enabled")
+		if !cluster.Spec.Services.InternalFrontend.IsEnabled() {
+			errs = append(errs, field.Forbidden(path, "frontend proxy requires the internal frontend to be enabled, as system workers can't reach the frontend"))
+		}
+		if !cluster.MTLSWithCertManagerEnabled() || !cluster.Spec.MTLS.FrontendEnabled() {
+			errs = append(errs, field.Forbidden(path, "frontend proxy requires mTLS using cert-manager to be enabled for the frontend"))
+		}
+		if cluster.Spec.Authorization.CertificateClaimMapperEnabled() {
+			errs = append(errs, field.Forbidden(path, "frontend proxy can't be used with the certificate claim mapper, as the frontend only sees the proxy certificate"))
+		}
+		if cluster.FrontendMirroringEnabled() && !cluster.Spec.UpgradeStrategy.IsBlueGreen() {
+			path := field.NewPath("spec", "services", "frontend", "proxy", "mirroring", "enabled")
+			errs = append(errs, field.Forbidden(path, "frontend requests mirroring requires the BlueGreen upgrade strategy, as requests are mirrored to the target version frontend"))
+		}
+	}
+
+	// Drain mode is applied by the frontend proxies.
+	if cluster.IsDraining() && !cluster.FrontendProxyEnabled() {
+		path := field.NewPath("spec", "services", "frontend", "drain")
+		errs = append(errs, field.Forbidden(path, "drain mode requires the frontend proxy to be enabled, as the proxies reject the new workflow executions"))
+	}
+
+	// Ensure services deployment strategies and memory protections are consistent.
+	if cluster.Spec.Services != nil {
+		var internalFrontend *v1beta1.ServiceSpec
+		if cluster.Spec.Services.InternalFrontend != nil {
+			internalFrontend = &cluster.Spec.Services.InternalFrontend.ServiceSpec
+		}
+
+		services := []struct {
+			name string
+			spec *v1beta1.ServiceSpec
+		}{
+			{"frontend", cluster.Spec.Services.Frontend},
+			{"internalFrontend", internalFrontend},
+			{"history", cluster.Spec.Services.History},
+			{"matching", cluster.Spec.Services.Matching},
+			{"worker", cluster.Spec.Services.Worker},
+		}
+		for _, service := range services {
+			if service.spec == nil {
+				continue
+			}
+
+			errs = append(errs, validateMemoryProtection(field.NewPath("spec", "services", service.name), service.spec)...)
+
+			if service.spec.Drain && service.name != "frontend" {
+				errs = append(errs, field.Forbidden(field.NewPath("spec", "services", service.name, "drain"), "drain is only supported by the frontend service"))
+			}
+
+			if service.spec.Warmup != nil {
+				path := field.NewPath("spec", "services", service.name, "warmup")
+				if service.name != "history" {
+					errs = append(errs, field.Forbidden(path, "warmup is only supported by the history service"))
+				} else if service.spec.Warmup.Enabled && !cluster.HistoryHostsReachable() {
+					errs = append(errs, field.Forbidden(path, "warmup requires internode mTLS to be disabled or provided by cert-manager, as the operator can't reach the history pods"))
+				}
+			}
+
+			if service.spec.DeploymentStrategy == nil {
+				continue
+			}
+
+			strategy := service.spec.DeploymentStrategy
+			if strategy.Type == appsv1.RecreateDeploymentStrategyType && strategy.RollingUpdate != nil {
+				errs = append(errs, field.Forbidden(
+					field.NewPath("spec", "services", service.name, "deploymentStrategy", "rollingUpdate"),
+					"rollingUpdate can't be set when the deployment strategy type is Recreate",
+				))
+			}
+		}
+	}
+
+	if cluster.Spec.ImageVerification != nil && !cluster.Spec.ResolveImageDigests {
+		errs = append(errs, field.Forbidden(
+			field.NewPath("spec", "imageVerification"),
+			"image verification requires spec.resolveImageDigests to be enabled",
+		))
+	}
+
+	errs = append(errs, validatePersistenceRateLimits(cluster)...)
+	errs = append(errs, validatePersistenceHooks(cluster)...)
+	if tz := cluster.Spec.TimeZone; tz != "" {
+		// time.LoadLocation resolves "Local" to the operator time zone, which isn't meaningful for the cluster.
+		if _, err := time.LoadLocation(tz); err != nil || tz == "Local" {
+			errs = append(errs,
+				field.Invalid(field.NewPath("spec", "timeZone"), tz, "unknown time zone, it must be an IANA time zone name like Europe/Paris"),
+			)
+		}
+	}
+
+	errs = append(errs, validateActionAnnotations(cluster)...)
+	errs = append(errs, validatePodSecurity(cluster)...)
+
 	// validate archival
 	if cluster.Spec.Archival.IsEnabled() {
-		if cluster.Spec.Archival.Provider == nil || cluster.Spec.Archival.Provider.Kind() == v1beta1.UnknownArchivalProviderKind {
+		errs = append(errs, validateArchivalProviders(cluster.Spec.Archival)...)
+		errs = append(errs, validateArchivalVolume(cluster)...)
+	}
+
+	// Ensure the backup bucket access matches the bucket provider.
+	if backup := cluster.Spec.Backup; backup.IsEnabled() {
+		if backup.IsGCS() && backup.S3 != nil {
 			errs = append(errs,
 				field.Forbidden(
-					field.NewPath("spec", "archival", "provider"),
-					"Please provide an archival provider or disable cluster archival",
+					field.NewPath("spec", "backup", "s3"),
+					"s3 can't be set for gs:// backup URLs",
 				),
 			)
 		}
-
-		if cluster.Spec.Archival.Provider.Kind() == v1beta1.S3ArchivalProviderKind {
-			if cluster.Spec.Archival.Provider.S3.RoleName == nil && cluster.Spec.Archival.Provider.S3.Credentials == nil {
-				errs = append(errs,
-					field.Forbidden(
-						field.NewPath("spec", "archival", "provider", "s3"),
-						"Please provide s3 role name if using EKS or s3 credentials for s3 provider (spec.archival.provider.s3.roleName or spec.archival.provider.s3.credentials)",
-					),
-				)
-			}
+		if !backup.IsGCS() && backup.GCS != nil {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "backup", "gcs"),
+					"gcs can only be set for gs:// backup URLs",
+				),
+			)
 		}
 	}
 
@@ -256,6 +521,15 @@ func (w *TemporalClusterWebhook) validateCluster(cluster *v1beta1.TemporalCluste
 				),
 			)
 		}
+
+		if len(cluster.Spec.ClusterMetadata.GetTags()) > 0 {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "clusterMetadata", "tags"),
+					"temporal cluster version < 1.21.0 doesn't support cluster metadata tags",
+				),
+			)
+		}
 	}
 
 	// Check for visibility store depreciations introduced in >= 1.21, that will be removed in >=1.23
@@ -282,6 +556,159 @@ func (w *TemporalClusterWebhook) validateCluster(cluster *v1beta1.TemporalCluste
 		}
 	}
 
+	// ScyllaDB >= 6.0 creates tablets-enabled keyspaces by default, which don't support lightweight transactions.
+	for name, store := range cluster.Spec.Persistence.GetDatastoresMap() {
+		if store != nil && store.Cassandra.IsScylla() && !store.SkipCreate {
+			warns = append(warns,
+				fmt.Sprintf("spec.persistence.%s runs on ScyllaDB: keyspaces created by the operator use the ScyllaDB defaults. ScyllaDB >= 6.0 enables tablets by default, which don't support the lightweight transactions temporal relies on. Create the keyspace with tablets disabled and set skipCreate.", name),
+			)
+		}
+	}
+
+	// Ensure datastores alias services can be created.
+	for name, store := range cluster.Spec.Persistence.GetDatastoresMap() {
+		if store == nil || store.ServiceAlias == nil || !store.ServiceAlias.Enabled {
+			continue
+		}
+
+		path := field.NewPath("spec", "persistence", name, "serviceAlias")
+		if !store.IsSQL() {
+			errs = append(errs, field.Forbidden(path, "service alias is only supported for SQL datastores"))
+			continue
+		}
+
+		host, _, err := net.SplitHostPort(store.SQL.ConnectAddr)
+		if err == nil && net.ParseIP(host) != nil {
+			errs = append(errs, field.Forbidden(path, "service alias requires the datastore connectAddr to be a DNS name, not an IP address"))
+		}
+	}
+
+	// Ensure each service runs enough replicas in high availability mode.
+	if cluster.Spec.HighAvailability && cluster.Spec.Services != nil {
+		services := []struct {
+			name      primitives.ServiceName
+			fieldName string
+		}{
+			{primitives.FrontendService, "frontend"},
+			{primitives.InternalFrontendService, "internalFrontend"},
+			{primitives.HistoryService, "history"},
+			{primitives.MatchingService, "matching"},
+			{primitives.WorkerService, "worker"},
+		}
+		for _, service := range services {
+			if service.name == primitives.InternalFrontendService && !cluster.Spec.Services.InternalFrontend.IsEnabled() {
+				continue
+			}
+			spec, err := cluster.Spec.Services.GetServiceSpec(service.name)
+			if err != nil || spec == nil || spec.Replicas == nil {
+				continue
+			}
+			if *spec.Replicas < v1beta1.MinHighAvailabilityReplicas {
+				errs = append(errs,
+					field.Invalid(
+						field.NewPath("spec", "services", service.fieldName, "replicas"),
+						*spec.Replicas,
+						fmt.Sprintf("high availability mode requires at least %d replicas", v1beta1.MinHighAvailabilityReplicas),
+					),
+				)
+			}
+		}
+	}
+
+	if external := cluster.Spec.Expose.GetExternalFrontend(); external != nil {
+		if (external.ExternalName == "") == (len(external.Addresses) == 0) {
+			errs = append(errs,
+				field.Invalid(
+					field.NewPath("spec", "expose", "externalFrontend"),
+					external,
+					"exactly one of externalName or addresses must be set",
+				),
+			)
+		}
+		for i, address := range external.Addresses {
+			if net.ParseIP(address) == nil {
+				errs = append(errs,
+					field.Invalid(
+						field.NewPath("spec", "expose", "externalFrontend", "addresses").Index(i),
+						address,
+						"must be a valid IP address",
+					),
+				)
+			}
+		}
+	}
+
+	// Ensure replication settings are consistent.
+	if cluster.Spec.Replication != nil {
+		if cluster.Spec.Replication.ActiveCluster != "" && !cluster.Spec.Replication.Enabled {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "replication", "activeCluster"),
+					"active cluster can only be set when replication is enabled",
+				),
+			)
+		}
+		if cluster.Spec.Replication.Role == v1beta1.StandbyReplicationRole {
+			switch {
+			case !cluster.Spec.Replication.Enabled:
+				errs = append(errs,
+					field.Forbidden(
+						field.NewPath("spec", "replication", "role"),
+						"standby role can only be set when replication is enabled",
+					),
+				)
+			case cluster.Spec.Replication.ActiveCluster == "" || cluster.Spec.Replication.ActiveCluster == cluster.GetName():
+				errs = append(errs,
+					field.Invalid(
+						field.NewPath("spec", "replication", "activeCluster"),
+						cluster.Spec.Replication.ActiveCluster,
+						"standby clusters must set the name of the active cluster",
+					),
+				)
+			}
+		}
+		remoteClusters := map[string]bool{}
+		for i, remote := range cluster.Spec.Replication.RemoteClusters {
+			if remote.Name == cluster.GetName() || remoteClusters[remote.Name] {
+				errs = append(errs,
+					field.Duplicate(
+						field.NewPath("spec", "replication", "remoteClusters").Index(i).Child("name"),
+						remote.Name,
+					),
+				)
+			}
+			remoteClusters[remote.Name] = true
+
+			remotePath := field.NewPath("spec", "replication", "remoteClusters").Index(i)
+			if remote.Address != "" {
+				if _, _, err := net.SplitHostPort(remote.Address); err != nil {
+					errs = append(errs,
+						field.Invalid(remotePath.Child("address"), remote.Address, "address must be in the host:port format"),
+					)
+				}
+			}
+			if remote.ClusterRef != nil && remote.ClusterRef.NamespacedName(cluster) == client.ObjectKeyFromObject(cluster) {
+				errs = append(errs,
+					field.Invalid(remotePath.Child("clusterRef"), remote.ClusterRef.Name, "remote cluster can't reference the cluster itself"),
+				)
+			}
+			if remote.TLS != nil && remote.TLS.SecretRef.Name == "" {
+				errs = append(errs,
+					field.Required(remotePath.Child("tls", "secretRef", "name"), "secret name is required"),
+				)
+			}
+		}
+		if cluster.Spec.Replication.GetInitialFailoverVersion() >= cluster.Spec.Replication.GetFailoverVersionIncrement() {
+			errs = append(errs,
+				field.Invalid(
+					field.NewPath("spec", "replication", "initialFailoverVersion"),
+					cluster.Spec.Replication.GetInitialFailoverVersion(),
+					"initial failover version must be lower than the failover version increment",
+				),
+			)
+		}
+	}
+
 	// Check for per unit histogram boundaries if metrics is enabled
 	if cluster.Spec.Metrics.IsEnabled() && cluster.Spec.Metrics.PerUnitHistogramBoundaries != nil {
 		p := cluster.Spec.Metrics.PerUnitHistogramBoundaries
@@ -300,24 +727,87 @@ func (w *TemporalClusterWebhook) validateCluster(cluster *v1beta1.TemporalCluste
 		}
 	}
 
+	if cluster.Spec.Metrics.DatadogEnabled() && cluster.Spec.Metrics.Prometheus == nil {
+		errs = append(errs,
+			field.Required(
+				field.NewPath("spec", "metrics", "prometheus"),
+				"the prometheus reporter is required for the datadog openmetrics check to scrape the services",
+			),
+		)
+	}
+
+	// Kubernetes versions older than 1.25 don't enforce the CRD validation rules, enforce them here.
+	if !w.AvailableAPIs.CELValidation {
+		errs = append(errs, validateCELRules(cluster)...)
+	}
+
 	return warns, errs
 }
 
+// validateNodeTopology warns the user if the kubernetes nodes topology
+// doesn't allow pods to be spread as requested by the high availability mode.
+func (w *TemporalClusterWebhook) validateNodeTopology(ctx context.Context, cluster *v1beta1.TemporalCluster) admission.Warnings {
+	if !cluster.Spec.HighAvailability || w.Client == nil {
+		return nil
+	}
+
+	nodes := &corev1.NodeList{}
+	err := w.Client.List(ctx, nodes)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("Can't verify nodes topology for high availability mode: %s", err)}
+	}
+
+	var warns admission.Warnings
+
+	if len(nodes.Items) < int(v1beta1.MinHighAvailabilityReplicas) {
+		warns = append(warns,
+			fmt.Sprintf("High availability mode is enabled but the cluster only has %d node(s): replicas of the same service will share nodes.", len(nodes.Items)),
+		)
+	}
+
+	zones := map[string]struct{}{}
+	for _, node := range nodes.Items {
+		if zone, ok := node.Labels[corev1.LabelTopologyZone]; ok && zone != "" {
+			zones[zone] = struct{}{}
+		}
+	}
+
+	switch len(zones) {
+	case 0:
+		warns = append(warns,
+			fmt.Sprintf("High availability mode is enabled but no node has the %s label: pods can't be spread across zones.", corev1.LabelTopologyZone),
+		)
+	case 1:
+		warns = append(warns,
+			"High availability mode is enabled but all nodes are in a single zone: the cluster won't survive a zone outage.",
+		)
+	}
+
+	return warns
+}
+
 // ValidateCreate ensures the user is creating a consistent temporal cluster.
-func (w *TemporalClusterWebhook) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (w *TemporalClusterWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	cluster, err := w.getClusterFromRequest(obj)
 	if err != nil {
 		return nil, err
 	}
 
 	warns, errs := w.validateCluster(cluster)
+	authorizationWarns, authorizationErrs := validateSystemCallersIdentity(nil, cluster)
+	warns = append(warns, authorizationWarns...)
+	errs = append(errs, authorizationErrs...)
+	warns = append(warns, w.validateNodeTopology(ctx, cluster)...)
+	warns = append(warns, w.validateArchivalVolumeClaim(ctx, cluster)...)
+	errs = append(errs, w.validateTemplate(ctx, cluster)...)
+	errs = append(errs, w.validateOverrides(ctx, cluster)...)
 
-	return warns, w.aggregateClusterErrors(cluster, errs)
+	return warns, w.aggregateClusterErrors(ctx, cluster, errs)
 }
 
 // ValidateUpdate validates TemporalCluster updates.
 // It mainly check for sequential version upgrades.
-func (w *TemporalClusterWebhook) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (w *TemporalClusterWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	oldCluster, err := w.getClusterFromRequest(oldObj)
 	if err != nil {
 		return nil, err
@@ -329,6 +819,13 @@ func (w *TemporalClusterWebhook) ValidateUpdate(_ context.Context, oldObj, newOb
 	}
 
 	warns, errs := w.validateCluster(newCluster)
+	authorizationWarns, authorizationErrs := validateSystemCallersIdentity(oldCluster, newCluster)
+	warns = append(warns, authorizationWarns...)
+	errs = append(errs, authorizationErrs...)
+	warns = append(warns, w.validateNodeTopology(ctx, newCluster)...)
+	warns = append(warns, w.validateArchivalVolumeClaim(ctx, newCluster)...)
+	errs = append(errs, w.validateTemplate(ctx, newCluster)...)
+	errs = append(errs, w.validateOverrides(ctx, newCluster)...)
 
 	// Ensure user is doing a sequential version upgrade.
 	// See: https://docs.temporal.io/cluster-deployment-guide#upgrade-server
@@ -347,6 +844,42 @@ func (w *TemporalClusterWebhook) ValidateUpdate(_ context.Context, oldObj, newOb
 		)
 	}
 
+	// Blue/green upgrades run both versions against the same persistence:
+	// ensure it's not changed during the upgrade.
+	if newCluster.Spec.UpgradeStrategy.IsBlueGreen() && !newCluster.Spec.Version.Equal(oldCluster.Spec.Version.Version) {
+		if oldCluster.IsBlueGreenUpgradeInProgress() {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "version"),
+					fmt.Sprintf("A blue/green upgrade to version %s is in progress, wait for its completion before changing the version", oldCluster.Status.BlueGreen.TargetVersion),
+				),
+			)
+		}
+
+		if !apiequality.Semantic.DeepEqual(newCluster.Spec.Persistence, oldCluster.Spec.Persistence) {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "persistence"),
+					"Persistence can't be changed along with the version when using the blue/green upgrade strategy, as both versions share the same persistence",
+				),
+			)
+		}
+	}
+
+	errs = append(errs, validateDatabaseRename(oldCluster, newCluster)...)
+	warns = append(warns, persistenceEndpointChangeWarnings(oldCluster, newCluster)...)
+
+	// The certificates server names are derived from the cluster domain: changing it while mTLS is enabled
+	// breaks the connections between the pods using the previous and the new certificates.
+	if newCluster.GetClusterDomain() != oldCluster.GetClusterDomain() && oldCluster.MTLSWithCertManagerEnabled() {
+		errs = append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "clusterDomain"),
+				"Cluster domain can't be changed while mTLS is enabled using cert-manager",
+			),
+		)
+	}
+
 	// Ensure user can't update the spec.numHistoryShards.
 	// In a temporal cluster, the number of shards is set once and forever.
 	if newCluster.Spec.NumHistoryShards != oldCluster.Spec.NumHistoryShards {
@@ -358,7 +891,7 @@ func (w *TemporalClusterWebhook) ValidateUpdate(_ context.Context, oldObj, newOb
 		)
 	}
 
-	return warns, w.aggregateClusterErrors(newCluster, errs)
+	return warns, w.aggregateClusterErrors(ctx, newCluster, errs)
 }
 
 // ValidateDelete does nothing.
@@ -374,3 +907,647 @@ func (w *TemporalClusterWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error
 		WithValidator(w).
 		Complete()
 }
+
+// validateSystemCallersIdentity ensures the worker service and the operator keep an identity allowed to call
+// the cluster when authorization is enabled: they either call the internal frontend, which only trusts the
+// internode identities, or the public frontend if the internal frontend is explicitly disabled, in which case the
+// claim mapper must grant them admin claims.
+// Existing clusters which enabled authorization without configuring the internal frontend are only warned,
+// so they can still be updated until they are migrated. oldCluster is nil on creation.
+func validateSystemCallersIdentity(oldCluster, cluster *v1beta1.TemporalCluster) (admission.Warnings, field.ErrorList) {
+	var warns admission.Warnings
+	var errs field.ErrorList
+
+	if !cluster.Spec.Authorization.IsEnabled() {
+		return warns, errs
+	}
+
+	internalFrontendConfigured := func(c *v1beta1.TemporalCluster) bool {
+		return c.Spec.Services != nil && c.Spec.Services.InternalFrontend != nil
+	}
+
+	switch {
+	case internalFrontendConfigured(cluster):
+		if !cluster.Spec.Services.InternalFrontend.Enabled {
+			warns = append(warns,
+				"Authorization is enabled but the internal frontend is disabled: the worker service and the operator will call the public frontend and must be granted admin claims by your claim mapper.",
+			)
+		}
+	case !cluster.Spec.Version.GreaterOrEqual(version.V1_20_0):
+		warns = append(warns,
+			"Authorization is enabled on a temporal version without internal frontend: the worker service and the operator will call the public frontend and must be granted admin claims by your claim mapper.",
+		)
+	case oldCluster != nil && oldCluster.Spec.Authorization.IsEnabled() && !internalFrontendConfigured(oldCluster):
+		warns = append(warns,
+			"Authorization is enabled but the internal frontend isn't configured: set spec.services.internalFrontend.enabled to true to route the worker service and the operator through it, or to false if your claim mapper grants them admin claims.",
+		)
+	default:
+		errs = append(errs,
+			field.Required(
+				field.NewPath("spec", "services", "internalFrontend"),
+				"authorization requires the internal frontend to be configured: enable it to route the worker service and the operator through it, or disable it if your claim mapper grants them admin claims",
+			),
+		)
+	}
+
+	return warns, errs
+}
+
+// validateDatabaseRename ensures the default and visibility datastores databases are only renamed
+// when their migration is explicitly allowed, and only if the migration can be run by the operator.
+// Renames confirmed using the temporal.io/confirm-persistence-change annotation point to a database
+// already holding the cluster data, so they don't need any migration.
+func validateDatabaseRename(oldCluster, newCluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+
+	if newCluster.GetAnnotations()[v1beta1.ConfirmPersistenceChangeAnnotation] == "true" {
+		return errs
+	}
+
+	allowed := newCluster.GetAnnotations()[v1beta1.MigrateDatastoresAnnotation] == "true"
+
+	stores := []struct {
+		path     *field.Path
+		old, new *v1beta1.DatastoreSpec
+	}{
+		{field.NewPath("spec", "persistence", "defaultStore"), oldCluster.Spec.Persistence.DefaultStore, newCluster.Spec.Persistence.DefaultStore},
+		{field.NewPath("spec", "persistence", "visibilityStore"), oldCluster.Spec.Persistence.VisibilityStore, newCluster.Spec.Persistence.VisibilityStore},
+	}
+	for _, store := range stores {
+		if store.old == nil || store.new == nil || store.old.GetDatabaseName() == store.new.GetDatabaseName() {
+			continue
+		}
+
+		switch {
+		case store.old.SQL == nil || store.new.SQL == nil:
+			errs = append(errs,
+				field.Forbidden(store.path, fmt.Sprintf("Only SQL datastores databases can be migrated, set the %s annotation to \"true\" if the new keyspace already holds the cluster data", v1beta1.ConfirmPersistenceChangeAnnotation)),
+			)
+		case !allowed:
+			errs = append(errs,
+				field.Forbidden(
+					store.path.Child("sql", "databaseName"),
+					fmt.Sprintf("Renaming the database requires migrating its data, set the %s annotation to \"true\" to allow it, or the %s annotation to \"true\" if the new database already holds the cluster data", v1beta1.MigrateDatastoresAnnotation, v1beta1.ConfirmPersistenceChangeAnnotation),
+				),
+			)
+		case store.old.SQL.PluginName != store.new.SQL.PluginName || store.old.SQL.ConnectAddr != store.new.SQL.ConnectAddr:
+			errs = append(errs,
+				field.Forbidden(
+					store.path.Child("sql"),
+					"The database server can't be changed along with the database name",
+				),
+			)
+		}
+	}
+
+	return errs
+}
+
+// persistenceEndpointChangeWarnings warns about datastores connection address or SQL plugin changes which are not confirmed:
+// the operator stops reconciling the cluster until they are, as a mistyped address would make the cluster run on an empty database.
+func persistenceEndpointChangeWarnings(oldCluster, newCluster *v1beta1.TemporalCluster) admission.Warnings {
+	var warns admission.Warnings
+
+	if newCluster.GetAnnotations()[v1beta1.ConfirmPersistenceChangeAnnotation] == "true" {
+		return warns
+	}
+
+	oldStores := oldCluster.Spec.Persistence.GetDatastoresMap()
+	newStores := newCluster.Spec.Persistence.GetDatastoresMap()
+	for _, name := range []string{"defaultStore", "visibilityStore", "secondaryVisibilityStore", "advancedVisibilityStore"} {
+		oldStore, newStore := oldStores[name], newStores[name]
+		if oldStore == nil || newStore == nil || oldStore.SQL == nil || newStore.SQL == nil {
+			continue
+		}
+
+		status := &v1beta1.DatastoreStatus{
+			ConnectAddr: oldStore.SQL.ConnectAddr,
+			PluginName:  oldStore.SQL.PluginName,
+		}
+		if changes := status.EndpointChanges(newStore); len(changes) > 0 {
+			warns = append(warns,
+				fmt.Sprintf("spec.persistence.%s %s: the cluster won't be reconciled until the %s annotation is set to \"true\" to confirm the change", name, strings.Join(changes, ", "), v1beta1.ConfirmPersistenceChangeAnnotation),
+			)
+		}
+	}
+
+	return warns
+}
+
+// validateArchivalVolume ensures the filestore archival volume can be shared by the pods archiving to it.
+// validateArchivalProviders ensures the history and visibility archival providers can be used together.
+func validateArchivalProviders(archival *v1beta1.ClusterArchivalSpec) field.ErrorList {
+	var errs field.ErrorList
+
+	if len(archival.Providers()) == 0 || (archival.Provider != nil && archival.Provider.Kind() == v1beta1.UnknownArchivalProviderKind) {
+		return append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "archival", "provider"),
+				"Please provide an archival provider or disable cluster archival",
+			),
+		)
+	}
+
+	if archival.Provider == nil && archival.History != nil && archival.History.Enabled {
+		errs = append(errs,
+			field.Required(
+				field.NewPath("spec", "archival", "provider"),
+				"history archival requires spec.archival.provider, spec.archival.visibilityProvider is only used for visibility records",
+			),
+		)
+	}
+
+	providers := []struct {
+		name     string
+		provider *v1beta1.ArchivalProvider
+	}{
+		{"provider", archival.Provider},
+		{"visibilityProvider", archival.VisibilityProvider},
+	}
+	for _, p := range providers {
+		if p.provider == nil {
+			continue
+		}
+
+		if p.provider.Kind() == v1beta1.UnknownArchivalProviderKind {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "archival", p.name),
+					"Please provide an archival provider or remove it",
+				),
+			)
+		}
+
+		if p.provider.Kind() == v1beta1.S3ArchivalProviderKind && p.provider.S3.RoleName == nil && p.provider.S3.Credentials == nil {
+			errs = append(errs,
+				field.Forbidden(
+					field.NewPath("spec", "archival", p.name, "s3"),
+					fmt.Sprintf("Please provide s3 role name if using EKS or s3 credentials for s3 provider (spec.archival.%[1]s.s3.roleName or spec.archival.%[1]s.s3.credentials)", p.name),
+				),
+			)
+		}
+	}
+
+	if archival.Provider == nil || archival.VisibilityProvider == nil {
+		return errs
+	}
+
+	// S3 credentials are injected as environment variables and the role name as service account annotation,
+	// they are shared by both providers.
+	history, visibility := archival.Provider, archival.VisibilityProvider
+	if history.S3 != nil && visibility.S3 != nil &&
+		(!apiequality.Semantic.DeepEqual(history.S3.Credentials, visibility.S3.Credentials) ||
+			!apiequality.Semantic.DeepEqual(history.S3.RoleName, visibility.S3.RoleName)) {
+		errs = append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "archival", "visibilityProvider", "s3"),
+				"s3 credentials and role name are shared by the temporal services and must match spec.archival.provider.s3",
+			),
+		)
+	}
+
+	if history.Filestore != nil && history.Filestore.Volume != nil &&
+		visibility.Filestore != nil && visibility.Filestore.Volume != nil {
+		errs = append(errs,
+			field.Forbidden(
+				field.NewPath("spec", "archival", "visibilityProvider", "filestore", "volume"),
+				"only one filestore archival volume is supported, set it on spec.archival.provider.filestore",
+			),
+		)
+	}
+
+	return errs
+}
+
+func validateArchivalVolume(cluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+
+	volume := cluster.ArchivalVolume()
+	if volume == nil {
+		return errs
+	}
+
+	volumePath := field.NewPath("spec", "archival", "provider", "filestore", "volume")
+
+	specs := []struct {
+		name string
+		spec *v1beta1.ArchivalSpec
+	}{
+		{"history", cluster.Spec.Archival.History},
+		{"visibility", cluster.Spec.Archival.Visibility},
+	}
+	for _, archival := range specs {
+		name, spec := archival.name, archival.spec
+		if spec == nil || spec.Path == "" {
+			continue
+		}
+
+		mountPath := path.Clean(volume.MountPath)
+		archivalPath := path.Clean(spec.Path)
+		if mountPath != "/" && archivalPath != mountPath && !strings.HasPrefix(archivalPath, mountPath+"/") {
+			errs = append(errs, field.Invalid(
+				field.NewPath("spec", "archival", name, "path"),
+				spec.Path,
+				fmt.Sprintf("filestore archival path must be located under the volume mount path %s", volume.MountPath),
+			))
+		}
+	}
+
+	// The volume is mounted by the frontend, history and worker pods, which can run on different nodes.
+	if volume.ClaimTemplate != nil && !hasAccessMode(volume.ClaimTemplate.AccessModes, corev1.ReadWriteMany) {
+		errs = append(errs, field.Invalid(
+			volumePath.Child("claimTemplate", "accessModes"),
+			volume.ClaimTemplate.AccessModes,
+			"filestore archival volume is shared by the frontend, history and worker pods and requires the ReadWriteMany access mode",
+		))
+	}
+
+	return errs
+}
+
+// validateCELRules enforces the CEL validation rules of the TemporalCluster CRD, for kubernetes versions
+// not evaluating them. The rules must be kept in sync with the kubebuilder XValidation markers.
+func validateCELRules(cluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+
+	if volume := cluster.ArchivalVolume(); volume != nil && (volume.ClaimName != "") == (volume.ClaimTemplate != nil) {
+		errs = append(errs, field.Invalid(
+			field.NewPath("spec", "archival", "provider", "filestore", "volume"),
+			volume.ClaimName,
+			"exactly one of claimName or claimTemplate must be set",
+		))
+	}
+
+	if cluster.Spec.Authorization.CertificateClaimMapperEnabled() {
+		rulesPath := field.NewPath("spec", "authorization", "certificateClaimMapper", "rules")
+		for i, rule := range cluster.Spec.Authorization.CertificateClaimMapper.Rules {
+			if rule.CommonName == "" && rule.DNSName == "" && rule.JWTSubject == "" {
+				errs = append(errs, field.Required(rulesPath.Index(i), "commonName, dnsName or jwtSubject is required"))
+			}
+			if rule.JWTSubject != "" && (rule.CommonName != "" || rule.DNSName != "") {
+				errs = append(errs, field.Invalid(rulesPath.Index(i).Child("jwtSubject"), rule.JWTSubject, "jwtSubject can't be set with commonName or dnsName"))
+			}
+		}
+	}
+
+	return errs
+}
+
+func hasAccessMode(modes []corev1.PersistentVolumeAccessMode, mode corev1.PersistentVolumeAccessMode) bool {
+	for _, m := range modes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// validateArchivalVolumeClaim warns if the existing claim used for filestore archival
+// doesn't support being mounted by pods running on different nodes.
+func (w *TemporalClusterWebhook) validateArchivalVolumeClaim(ctx context.Context, cluster *v1beta1.TemporalCluster) admission.Warnings {
+	volume := cluster.ArchivalVolume()
+	if volume == nil || volume.ClaimName == "" || w.Client == nil {
+		return nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := w.Client.Get(ctx, client.ObjectKey{Namespace: cluster.GetNamespace(), Name: volume.ClaimName}, pvc)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("Can't verify filestore archival volume claim %s: %s", volume.ClaimName, err)}
+	}
+
+	if !hasAccessMode(pvc.Spec.AccessModes, corev1.ReadWriteMany) {
+		return admission.Warnings{
+			fmt.Sprintf("Filestore archival volume claim %s doesn't support the ReadWriteMany access mode: frontend, history and worker pods scheduled on different nodes won't be able to mount it.", volume.ClaimName),
+		}
+	}
+
+	return nil
+}
+
+// applyTemplate applies the TemporalClusterTemplate referenced by the cluster.
+// Missing templates are reported by validateTemplate.
+func (w *TemporalClusterWebhook) applyTemplate(ctx context.Context, cluster *v1beta1.TemporalCluster) error {
+	if cluster.Spec.TemplateRef == nil || w.Client == nil {
+		return nil
+	}
+
+	template := &v1beta1.TemporalClusterTemplate{}
+	err := w.Client.Get(ctx, client.ObjectKey{Name: cluster.Spec.TemplateRef.Name}, template)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("can't get cluster template: %w", err)
+	}
+
+	// Let validation reject clusters the template doesn't allow.
+	if len(validateTemplateUsage(template, cluster)) > 0 {
+		return nil
+	}
+
+	return template.Apply(cluster)
+}
+
+// validateTemplate ensures the cluster is allowed to instantiate the TemporalClusterTemplate it references.
+func (w *TemporalClusterWebhook) validateTemplate(ctx context.Context, cluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+
+	ref := cluster.Spec.TemplateRef
+	if ref == nil || w.Client == nil {
+		return errs
+	}
+
+	path := field.NewPath("spec", "templateRef")
+
+	template := &v1beta1.TemporalClusterTemplate{}
+	err := w.Client.Get(ctx, client.ObjectKey{Name: ref.Name}, template)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return append(errs, field.NotFound(path.Child("name"), ref.Name))
+		}
+		return append(errs, field.InternalError(path.Child("name"), err))
+	}
+
+	return append(errs, validateTemplateUsage(template, cluster)...)
+}
+
+// validateTemplateUsage ensures the template allows the cluster namespace and the size it requests.
+func validateTemplateUsage(template *v1beta1.TemporalClusterTemplate, cluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+
+	path := field.NewPath("spec", "templateRef")
+	ref := cluster.Spec.TemplateRef
+
+	if !template.Spec.IsNamespaceAllowed(cluster.GetNamespace()) {
+		errs = append(errs,
+			field.Forbidden(path.Child("name"), fmt.Sprintf("template can't be instantiated in namespace %s", cluster.GetNamespace())),
+		)
+	}
+
+	if (ref.Size != "" || template.Spec.DefaultSize != "") && template.Spec.GetSize(ref.Size) == nil {
+		errs = append(errs, field.NotFound(path.Child("size"), ref.Size))
+	}
+
+	return errs
+}
+
+// validateOverrides ensures the cluster spec stays valid once the TemporalClusterOverrides selecting it are applied.
+// Only errors introduced by the overrides are reported, errors of the cluster spec itself are reported by validateCluster.
+func (w *TemporalClusterWebhook) validateOverrides(ctx context.Context, cluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+
+	if w.Client == nil {
+		return errs
+	}
+
+	overrides := &v1beta1.TemporalClusterOverrideList{}
+	err := w.Client.List(ctx, overrides, client.InNamespace(cluster.GetNamespace()))
+	if err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return errs
+		}
+		return append(errs, field.InternalError(field.NewPath("metadata", "labels"), fmt.Errorf("can't list cluster overrides: %w", err)))
+	}
+
+	applied, patchedErrs, err := w.validateClusterWithOverrides(cluster, overrides.Items)
+	if err != nil {
+		return append(errs, field.Invalid(field.NewPath("metadata", "labels"), cluster.GetLabels(), err.Error()))
+	}
+
+	for _, err := range patchedErrs {
+		err.Detail = fmt.Sprintf("%s (with overrides %s applied)", err.Detail, strings.Join(applied, ", "))
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// validateClusterWithOverrides validates the cluster spec with the provided overrides applied, and returns the applied overrides
+// with the errors they introduce. Errors of the cluster spec itself are reported by validateCluster.
+func (w *TemporalClusterWebhook) validateClusterWithOverrides(cluster *v1beta1.TemporalCluster, overrides []v1beta1.TemporalClusterOverride) ([]string, field.ErrorList, error) {
+	var errs field.ErrorList
+
+	patched := cluster.DeepCopy()
+	applied, err := v1beta1.ApplyClusterOverrides(patched, overrides)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(applied) == 0 {
+		return applied, errs, nil
+	}
+	patched.Default()
+
+	_, baseErrs := w.validateCluster(cluster)
+	existing := map[string]bool{}
+	for _, err := range baseErrs {
+		existing[err.Error()] = true
+	}
+
+	_, patchedErrs := w.validateCluster(patched)
+	for _, err := range patchedErrs {
+		if existing[err.Error()] {
+			continue
+		}
+		errs = append(errs, err)
+	}
+
+	return applied, errs, nil
+}
+
+// validateMemoryProtection ensures the service resources allow its memory protection to be applied.
+func validateMemoryProtection(path *field.Path, service *v1beta1.ServiceSpec) field.ErrorList {
+	var errs field.ErrorList
+
+	protection := service.MemoryProtection
+	if protection == nil {
+		return errs
+	}
+
+	path = path.Child("memoryProtection")
+	resources := protection.GetResources(service.Resources)
+
+	if protection.Guaranteed && (service.Resources.Requests.Cpu().IsZero() || service.Resources.Requests.Memory().IsZero()) {
+		errs = append(errs, field.Forbidden(path.Child("guaranteed"), "guaranteed QoS class requires cpu and memory requests to be set"))
+	}
+
+	if protection.HeadroomPercent != nil && resources.Limits.Memory().IsZero() {
+		errs = append(errs, field.Forbidden(path.Child("headroomPercent"), "memory headroom requires a memory limit to be set"))
+	}
+
+	return errs
+}
+
+// validatePersistenceHooks ensures the persistence hooks can be run by the schema tools.
+func validatePersistenceHooks(cluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+
+	hooks := cluster.Spec.Persistence.Hooks
+	if hooks == nil {
+		return errs
+	}
+
+	stores := map[string]*v1beta1.DatastoreSpec{}
+	for _, store := range cluster.Spec.Persistence.GetDatastores() {
+		if store != nil {
+			stores[store.Name] = store
+		}
+	}
+
+	phases := []struct {
+		name  string
+		hooks []v1beta1.PersistenceHook
+	}{
+		{"preSetup", hooks.PreSetup},
+		{"postSetup", hooks.PostSetup},
+		{"preUpdate", hooks.PreUpdate},
+		{"postUpdate", hooks.PostUpdate},
+	}
+	for _, phase := range phases {
+		names := map[string]bool{}
+		for i, hook := range phase.hooks {
+			path := field.NewPath("spec", "persistence", "hooks", phase.name).Index(i)
+
+			if names[hook.Name] {
+				errs = append(errs, field.Duplicate(path.Child("name"), hook.Name))
+			}
+			names[hook.Name] = true
+
+			store, ok := stores[hook.Store]
+			switch {
+			case !ok:
+				errs = append(errs, field.NotFound(path.Child("store"), hook.Store))
+			case !store.IsSQL() && store.GetType() != v1beta1.CassandraDatastore:
+				errs = append(errs, field.Forbidden(path.Child("store"), "hooks are only supported for SQL and cassandra datastores"))
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateActionAnnotations ensures the action annotations values can be consumed by the operator.
+func validateActionAnnotations(cluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+
+	path := field.NewPath("metadata", "annotations")
+
+	if value, ok := cluster.GetAnnotations()[v1beta1.RestartServiceAnnotation]; ok {
+		services := []string{
+			v1beta1.AllServices,
+			string(primitives.FrontendService),
+			string(primitives.InternalFrontendService),
+			string(primitives.HistoryService),
+			string(primitives.MatchingService),
+			string(primitives.WorkerService),
+		}
+		for _, service := range strings.Split(value, ",") {
+			service = strings.TrimSpace(service)
+			if !slices.Contains(services, service) {
+				errs = append(errs, field.NotSupported(path.Key(v1beta1.RestartServiceAnnotation), service, services))
+			}
+		}
+	}
+
+	for _, annotation := range []string{v1beta1.RerunSchemaSetupAnnotation, v1beta1.RefreshCertificatesAnnotation, v1beta1.DeletionReportAnnotation} {
+		if value, ok := cluster.GetAnnotations()[annotation]; ok && value != "true" && value != "false" {
+			errs = append(errs, field.NotSupported(path.Key(annotation), value, []string{"true", "false"}))
+		}
+	}
+
+	if value, ok := cluster.GetAnnotations()[v1beta1.DebugPodAnnotation]; ok {
+		for _, msg := range validation.IsDNS1123Subdomain(value) {
+			errs = append(errs, field.Invalid(path.Key(v1beta1.DebugPodAnnotation), value, msg))
+		}
+	}
+
+	if _, ok := cluster.GetAnnotations()[v1beta1.DebugImageAnnotation]; ok {
+		if _, ok := cluster.GetAnnotations()[v1beta1.DebugPodAnnotation]; !ok {
+			errs = append(errs, field.Required(path.Key(v1beta1.DebugPodAnnotation), fmt.Sprintf("required when %s is set", v1beta1.DebugImageAnnotation)))
+		}
+	}
+
+	return errs
+}
+
+// validatePersistenceRateLimits ensures the persistence rate limits can be applied and are consistent.
+func validatePersistenceRateLimits(cluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+
+	limits := cluster.Spec.Persistence.RateLimits
+	if limits == nil {
+		return errs
+	}
+
+	path := field.NewPath("spec", "persistence", "rateLimits")
+
+	if cluster.Spec.DynamicConfig == nil {
+		errs = append(errs, field.Forbidden(path, "persistence rate limits require spec.dynamicConfig to be set"))
+	}
+
+	// A limit for all hosts can't be lower than the limit of a single host.
+	checkGlobalLimit := func(path *field.Path, hostLimit, globalLimit *int32) {
+		if hostLimit != nil && globalLimit != nil && *globalLimit < *hostLimit {
+			errs = append(errs, field.Invalid(path, *globalLimit, fmt.Sprintf("must be greater than or equal to the host limit (%d)", *hostLimit)))
+		}
+	}
+
+	services := []struct {
+		name   string
+		limits *v1beta1.ServicePersistenceRateLimitsSpec
+	}{
+		{"frontend", limits.Frontend},
+		{"history", limits.History},
+		{"matching", limits.Matching},
+		{"worker", limits.Worker},
+	}
+	for _, service := range services {
+		if service.limits == nil {
+			continue
+		}
+		checkGlobalLimit(path.Child(service.name, "globalMaxQPS"), service.limits.MaxQPS, service.limits.GlobalMaxQPS)
+	}
+
+	if scheduler := limits.TaskScheduler; scheduler != nil {
+		schedulerPath := path.Child("taskScheduler")
+		checkGlobalLimit(schedulerPath.Child("globalMaxQPS"), scheduler.MaxQPS, scheduler.GlobalMaxQPS)
+		checkGlobalLimit(schedulerPath.Child("globalNamespaceMaxQPS"), scheduler.NamespaceMaxQPS, scheduler.GlobalNamespaceMaxQPS)
+
+		if scheduler.MaxQPS != nil && scheduler.NamespaceMaxQPS != nil && *scheduler.NamespaceMaxQPS > *scheduler.MaxQPS {
+			errs = append(errs, field.Invalid(schedulerPath.Child("namespaceMaxQPS"), *scheduler.NamespaceMaxQPS, fmt.Sprintf("must be lower than or equal to the host limit (%d)", *scheduler.MaxQPS)))
+		}
+	}
+
+	return errs
+}
+
+func validatePodSecurity(cluster *v1beta1.TemporalCluster) field.ErrorList {
+	var errs field.ErrorList
+
+	podSecurity := cluster.Spec.PodSecurity
+	if podSecurity == nil {
+		return errs
+	}
+
+	path := field.NewPath("spec", "podSecurity")
+
+	if seccomp := podSecurity.SeccompProfile; seccomp != nil {
+		switch {
+		case seccomp.Type == corev1.SeccompProfileTypeLocalhost && ptr.Deref(seccomp.LocalhostProfile, "") == "":
+			errs = append(errs, field.Required(path.Child("seccompProfile", "localhostProfile"), "must be set when seccomp profile type is Localhost"))
+		case seccomp.Type == corev1.SeccompProfileTypeUnconfined && podSecurity.IsRestricted():
+			errs = append(errs, field.Forbidden(path.Child("seccompProfile", "type"), "the restricted profile doesn't allow an Unconfined seccomp profile"))
+		}
+	}
+
+	if appArmor := podSecurity.AppArmorProfile; appArmor != nil {
+		switch {
+		case appArmor.Type == corev1.AppArmorProfileTypeLocalhost && ptr.Deref(appArmor.LocalhostProfile, "") == "":
+			errs = append(errs, field.Required(path.Child("appArmorProfile", "localhostProfile"), "must be set when AppArmor profile type is Localhost"))
+		case appArmor.Type == corev1.AppArmorProfileTypeUnconfined && podSecurity.IsRestricted():
+			errs = append(errs, field.Forbidden(path.Child("appArmorProfile", "type"), "the restricted profile doesn't allow an Unconfined AppArmor profile"))
+		}
+	}
+
+	return errs
+}
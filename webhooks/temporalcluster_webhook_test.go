@@ -20,15 +20,21 @@ package webhooks_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
 	"github.com/alexandrevilain/temporal-operator/internal/discovery"
 	"github.com/alexandrevilain/temporal-operator/pkg/version"
 	"github.com/alexandrevilain/temporal-operator/webhooks"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestDefault(t *testing.T) {
@@ -90,6 +96,34 @@ func TestDefault(t *testing.T) {
 				return c
 			}(),
 		},
+		"authorization doesn't enable internal frontend": {
+			initialObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Authorization: &v1beta1.AuthorizationSpec{
+						Authorizer: "default",
+					},
+				},
+			},
+			expectedObject: func() runtime.Object {
+				c := &v1beta1.TemporalCluster{
+					TypeMeta: v1beta1.TemporalClusterTypeMeta,
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "fake",
+					},
+					Spec: v1beta1.TemporalClusterSpec{
+						Authorization: &v1beta1.AuthorizationSpec{
+							Authorizer: "default",
+						},
+					},
+				}
+				c.Default()
+				return c
+			}(),
+		},
 		"bad port on listen address": {
 			initialObject: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
@@ -142,6 +176,146 @@ func TestDefault(t *testing.T) {
 	}
 }
 
+func TestDefaultWithTemplate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1beta1.AddToScheme(scheme))
+
+	template := &v1beta1.TemporalClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "standard",
+		},
+		Spec: v1beta1.TemporalClusterTemplateSpec{
+			Template: v1beta1.TemporalClusterSpec{
+				Version:          version.MustNewVersionFromString("1.23.0"),
+				NumHistoryShards: 512,
+				Services: &v1beta1.ServicesSpec{
+					Frontend: &v1beta1.ServiceSpec{
+						Replicas: ptr.To[int32](2),
+					},
+				},
+			},
+			Sizes: []v1beta1.TemporalClusterTemplateSize{
+				{
+					Name: "large",
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							Replicas: ptr.To[int32](5),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cluster := &v1beta1.TemporalCluster{
+		TypeMeta: v1beta1.TemporalClusterTypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake",
+			Namespace: "team",
+		},
+		Spec: v1beta1.TemporalClusterSpec{
+			NumHistoryShards: 1,
+			TemplateRef: &v1beta1.TemporalClusterTemplateReference{
+				Name: "standard",
+				Size: "large",
+			},
+		},
+	}
+
+	wh := &webhooks.TemporalClusterWebhook{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(template).Build(),
+	}
+
+	err := wh.Default(context.Background(), cluster)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.23.0", cluster.Spec.Version.String())
+	assert.Equal(t, int32(512), cluster.Spec.NumHistoryShards)
+	assert.Equal(t, ptr.To[int32](2), cluster.Spec.Services.Frontend.Replicas)
+	assert.Equal(t, ptr.To[int32](5), cluster.Spec.Services.History.Replicas)
+	assert.Equal(t, "standard", cluster.Spec.TemplateRef.Name)
+}
+
+func TestValidateCreateWithOverrides(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1beta1.AddToScheme(scheme))
+
+	overrides := []client.Object{
+		&v1beta1.TemporalClusterOverride{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "production",
+				Namespace: "team",
+			},
+			Spec: v1beta1.TemporalClusterOverrideSpec{
+				ClusterSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"environment": "production"},
+				},
+				Patch: v1beta1.TemporalClusterSpec{
+					MTLS: &v1beta1.MTLSSpec{
+						Provider: v1beta1.CertManagerMTLSProvider,
+					},
+				},
+			},
+		},
+		&v1beta1.TemporalClusterOverride{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "staging",
+				Namespace: "team",
+			},
+			Spec: v1beta1.TemporalClusterOverrideSpec{
+				ClusterSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"environment": "staging"},
+				},
+				Patch: v1beta1.TemporalClusterSpec{
+					NumHistoryShards: 4,
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		labels      map[string]string
+		expectedErr string
+	}{
+		"works without matching override": {
+			labels: map[string]string{"environment": "staging"},
+		},
+		"error introduced by override": {
+			labels:      map[string]string{"environment": "production"},
+			expectedErr: "Can't use cert-manager as mTLS provider as it's not available in the cluster (with overrides production applied)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			wh := &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+				Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(overrides...).Build(),
+			}
+
+			cluster := &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake",
+					Namespace: "team",
+					Labels:    test.labels,
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.18.4"),
+					NumHistoryShards: 1,
+				},
+			}
+			assert.NoError(tt, wh.Default(context.Background(), cluster))
+
+			_, err := wh.ValidateCreate(context.Background(), cluster)
+			if test.expectedErr != "" {
+				assert.ErrorContains(tt, err, test.expectedErr)
+				return
+			}
+			assert.NoError(tt, err)
+		})
+	}
+}
+
 func TestValidateCreate(t *testing.T) {
 	tests := map[string]struct {
 		object      runtime.Object
@@ -179,24 +353,31 @@ func TestValidateCreate(t *testing.T) {
 			wh: &webhooks.TemporalClusterWebhook{
 				AvailableAPIs: &discovery.AvailableAPIs{},
 			},
-			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.version: Forbidden: Unsupported temporal version",
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.version: Forbidden: Unsupported temporal version: temporal version 4560.18.4 is newer than the latest version supported by this operator (>= 1.14.0 < 1.24.0), upgrade the operator first",
 		},
-		"error with version marked as broken": {
+		"error with s3 access for a gs backup bucket": {
 			object: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "fake",
 				},
 				Spec: v1beta1.TemporalClusterSpec{
-					Version: version.MustNewVersionFromString("1.21.0"),
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Backup: &v1beta1.BackupSpec{
+						Enabled: true,
+						URL:     "gs://backups/temporal",
+						S3: &v1beta1.BackupS3Spec{
+							Region: "eu-west-1",
+						},
+					},
 				},
 			},
 			wh: &webhooks.TemporalClusterWebhook{
 				AvailableAPIs: &discovery.AvailableAPIs{},
 			},
-			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.version: Forbidden: version 1.21.0 is marked as broken by the operator, please upgrade to 1.21.1 (if allowed)",
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.backup.s3: Forbidden: s3 can't be set for gs:// backup URLs",
 		},
-		"error when no cert manager and mTLS with cert-manager enabled": {
+		"error with service alias for an ip datastore address": {
 			object: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
 				ObjectMeta: metav1.ObjectMeta{
@@ -204,27 +385,25 @@ func TestValidateCreate(t *testing.T) {
 				},
 				Spec: v1beta1.TemporalClusterSpec{
 					Version: version.MustNewVersionFromString("1.18.4"),
-					MTLS: &v1beta1.MTLSSpec{
-						Provider: v1beta1.CertManagerMTLSProvider,
-						Internode: &v1beta1.InternodeMTLSSpec{
-							Enabled: true,
-						},
-						Frontend: &v1beta1.FrontendMTLSSpec{
-							Enabled: true,
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							SQL: &v1beta1.SQLSpec{
+								PluginName:  "postgres",
+								ConnectAddr: "10.0.0.12:5432",
+							},
+							ServiceAlias: &v1beta1.DatastoreServiceAliasSpec{
+								Enabled: true,
+							},
 						},
 					},
 				},
 			},
 			wh: &webhooks.TemporalClusterWebhook{
-				AvailableAPIs: &discovery.AvailableAPIs{
-					Istio:              false,
-					CertManager:        false,
-					PrometheusOperator: false,
-				},
+				AvailableAPIs: &discovery.AvailableAPIs{},
 			},
-			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.mTLS.provider: Invalid value: \"cert-manager\": Can't use cert-manager as mTLS provider as it's not available in the cluster",
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.persistence.defaultStore.serviceAlias: Forbidden: service alias requires the datastore connectAddr to be a DNS name, not an IP address",
 		},
-		"error with old elastic search version": {
+		"error with persistence hook on an elasticsearch datastore": {
 			object: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
 				ObjectMeta: metav1.ObjectMeta{
@@ -233,132 +412,1345 @@ func TestValidateCreate(t *testing.T) {
 				Spec: v1beta1.TemporalClusterSpec{
 					Version: version.MustNewVersionFromString("1.18.4"),
 					Persistence: v1beta1.TemporalPersistenceSpec{
-						AdvancedVisibilityStore: &v1beta1.DatastoreSpec{
+						VisibilityStore: &v1beta1.DatastoreSpec{
+							Name: v1beta1.VisibilityStoreName,
 							Elasticsearch: &v1beta1.ElasticsearchSpec{
-								Version: "v6",
+								Version: "v7",
+								URL:     "http://elasticsearch:9200",
+							},
+						},
+						Hooks: &v1beta1.PersistenceHooksSpec{
+							PostSetup: []v1beta1.PersistenceHook{
+								{
+									Name:  "settings",
+									Store: v1beta1.VisibilityStoreName,
+									ConfigMapRef: v1beta1.ConfigMapKeyReference{
+										Name: "hooks",
+										Key:  "settings.sql",
+									},
+								},
 							},
 						},
 					},
 				},
 			},
 			wh: &webhooks.TemporalClusterWebhook{
-				AvailableAPIs: &discovery.AvailableAPIs{
-					Istio:              false,
-					CertManager:        false,
-					PrometheusOperator: false,
-				},
+				AvailableAPIs: &discovery.AvailableAPIs{},
 			},
-			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.persistence.advancedVisibilityStore.elasticsearch.version: Forbidden: temporal cluster version >= 1.18.0 doesn't support ElasticSearch v6",
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.persistence.hooks.postSetup[0].store: Forbidden: hooks are only supported for SQL and cassandra datastores",
 		},
-	}
-
-	for name, test := range tests {
-		t.Run(name, func(tt *testing.T) {
-			_, err := test.wh.ValidateCreate(context.Background(), test.object)
-			if test.expectedErr != "" {
-				assert.Error(tt, err)
-				// Here contains is used to allow partial error checks.
-				// When version is wrong, it shows which versions are supported.
-				// If using "Equal" it would requires us to update tests
-				// for each new temporal version.
-				assert.Contains(tt, err.Error(), test.expectedErr)
-			} else {
-				assert.NoError(tt, err)
-			}
-		})
-	}
-}
-
-func TestValidateUpdate(t *testing.T) {
-	tests := map[string]struct {
-		oldlObject  runtime.Object
-		newObject   runtime.Object
-		expectedErr string
-	}{
-		"allowed upgrade": {
-			oldlObject: &v1beta1.TemporalCluster{
+		"error with unknown service in restart annotation": {
+			object: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "fake",
+					Annotations: map[string]string{
+						v1beta1.RestartServiceAnnotation: "history,scheduler",
+					},
 				},
 				Spec: v1beta1.TemporalClusterSpec{
-					Version:          version.MustNewVersionFromString("1.18.4"),
-					NumHistoryShards: int32(512),
+					Version: version.MustNewVersionFromString("1.18.4"),
 				},
 			},
-			newObject: &v1beta1.TemporalCluster{
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: metadata.annotations[temporal.io/restart-service]: Unsupported value: \"scheduler\": supported values: \"all\", \"frontend\", \"internal-frontend\", \"history\", \"matching\", \"worker\"",
+		},
+		"error with unknown time zone": {
+			object: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "fake",
 				},
 				Spec: v1beta1.TemporalClusterSpec{
-					Version:          version.MustNewVersionFromString("1.19.0"),
-					NumHistoryShards: int32(512),
+					Version:  version.MustNewVersionFromString("1.18.4"),
+					TimeZone: "Mars/Olympus_Mons",
 				},
 			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.timeZone: Invalid value: \"Mars/Olympus_Mons\": unknown time zone, it must be an IANA time zone name like Europe/Paris",
 		},
-		"version rollback": {
-			oldlObject: &v1beta1.TemporalCluster{
+		"error with debug image annotation without debug pod": {
+			object: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "fake",
+					Annotations: map[string]string{
+						v1beta1.DebugImageAnnotation: "nicolaka/netshoot",
+					},
 				},
 				Spec: v1beta1.TemporalClusterSpec{
-					Version: version.MustNewVersionFromString("1.19.0"),
+					Version: version.MustNewVersionFromString("1.18.4"),
 				},
 			},
-			newObject: &v1beta1.TemporalCluster{
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: metadata.annotations[temporal.io/debug-pod]: Required value: required when temporal.io/debug-image is set",
+		},
+		"error with standby cluster without active cluster": {
+			object: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "fake",
 				},
-				Spec:   v1beta1.TemporalClusterSpec{Version: version.MustNewVersionFromString("1.18.4")},
-				Status: v1beta1.TemporalClusterStatus{},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Replication: &v1beta1.ReplicationSpec{
+						Enabled: true,
+						Role:    v1beta1.StandbyReplicationRole,
+					},
+				},
 			},
-			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.version: Forbidden: Unauthorized version upgrade. Only sequential version upgrades are allowed (from v1.n.x to v1.n+1.x)",
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.replication.activeCluster: Invalid value: \"\": standby clusters must set the name of the active cluster",
 		},
-		"not a sequential version update": {
-			oldlObject: &v1beta1.TemporalCluster{
+		"error with version marked as broken": {
+			object: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "fake",
 				},
 				Spec: v1beta1.TemporalClusterSpec{
-					Version: version.MustNewVersionFromString("1.17.0"),
+					Version: version.MustNewVersionFromString("1.21.0"),
 				},
 			},
-			newObject: &v1beta1.TemporalCluster{
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.version: Forbidden: version 1.21.0 is marked as broken by the operator, please upgrade to 1.21.1 (if allowed)",
+		},
+		"error when no cert manager and mTLS with cert-manager enabled": {
+			object: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "fake",
 				},
-				Spec:   v1beta1.TemporalClusterSpec{Version: version.MustNewVersionFromString("1.19.4")},
-				Status: v1beta1.TemporalClusterStatus{},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					MTLS: &v1beta1.MTLSSpec{
+						Provider: v1beta1.CertManagerMTLSProvider,
+						Internode: &v1beta1.InternodeMTLSSpec{
+							Enabled: true,
+						},
+						Frontend: &v1beta1.FrontendMTLSSpec{
+							Enabled: true,
+						},
+					},
+				},
 			},
-			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.version: Forbidden: Unauthorized version upgrade. Only sequential version upgrades are allowed (from v1.n.x to v1.n+1.x)",
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{
+					Istio:              false,
+					CertManager:        false,
+					PrometheusOperator: false,
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.mTLS.provider: Invalid value: \"cert-manager\": Can't use cert-manager as mTLS provider as it's not available in the cluster",
 		},
-		"immutable numHistoryShards": {
-			oldlObject: &v1beta1.TemporalCluster{
+		"error with old elastic search version": {
+			object: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "fake",
 				},
 				Spec: v1beta1.TemporalClusterSpec{
-					Version:          version.MustNewVersionFromString("1.19.4"),
-					NumHistoryShards: int32(256),
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						AdvancedVisibilityStore: &v1beta1.DatastoreSpec{
+							Elasticsearch: &v1beta1.ElasticsearchSpec{
+								Version: "v6",
+							},
+						},
+					},
 				},
 			},
-			newObject: &v1beta1.TemporalCluster{
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{
+					Istio:              false,
+					CertManager:        false,
+					PrometheusOperator: false,
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.persistence.advancedVisibilityStore.elasticsearch.version: Forbidden: temporal cluster version >= 1.18.0 doesn't support ElasticSearch v6",
+		},
+		"error with managed elasticsearch using TLS": {
+			object: &v1beta1.TemporalCluster{
 				TypeMeta: v1beta1.TemporalClusterTypeMeta,
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "fake",
 				},
 				Spec: v1beta1.TemporalClusterSpec{
-					Version:          version.MustNewVersionFromString("1.19.4"),
-					NumHistoryShards: int32(512),
-				},
-			},
-			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.numHistoryShards: Forbidden: Number of history shards is immutable",
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						VisibilityStore: &v1beta1.DatastoreSpec{
+							Elasticsearch: &v1beta1.ElasticsearchSpec{
+								Version: "v7",
+								Managed: &v1beta1.ManagedElasticsearchSpec{
+									Enabled: true,
+								},
+							},
+							TLS: &v1beta1.DatastoreTLSSpec{
+								Enabled: true,
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{
+					Istio:              false,
+					CertManager:        false,
+					PrometheusOperator: false,
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.persistence.visibilityStore.elasticsearch.managed: Forbidden: managed Elasticsearch doesn't support TLS, disable the datastore tls",
+		},
+		"error with single replica in high availability mode": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.18.4"),
+					HighAvailability: true,
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.history.replicas: Invalid value: 1: high availability mode requires at least 2 replicas",
+		},
+		"error with active cluster when replication is disabled": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Replication: &v1beta1.ReplicationSpec{
+						ActiveCluster: "other",
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.replication.activeCluster: Forbidden: active cluster can only be set when replication is enabled",
+		},
+		"error with remote cluster referencing the cluster itself": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Replication: &v1beta1.ReplicationSpec{
+						Enabled: true,
+						RemoteClusters: []v1beta1.RemoteClusterSpec{
+							{
+								Name: "other",
+								ClusterRef: &v1beta1.ObjectReference{
+									Name: "fake",
+								},
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.replication.remoteClusters[0].clusterRef: Invalid value: \"fake\": remote cluster can't reference the cluster itself",
+		},
+		"error with invalid external frontend address": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Expose: &v1beta1.ExposeSpec{
+						ExternalFrontend: &v1beta1.ExternalFrontendSpec{
+							Addresses: []string{"lb.example.com"},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.expose.externalFrontend.addresses[0]: Invalid value: \"lb.example.com\": must be a valid IP address",
+		},
+		"error with drain duration without dynamic config": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							GracefulShutdown: &v1beta1.GracefulShutdownSpec{
+								DrainDuration: &metav1.Duration{Duration: 30 * time.Second},
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.history.gracefulShutdown.drainDuration: Forbidden: shutdown drain duration requires spec.dynamicConfig to be set",
+		},
+		"error with coordinated scale-down without drain duration": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Services: &v1beta1.ServicesSpec{
+						Matching: &v1beta1.ServiceSpec{
+							GracefulShutdown: &v1beta1.GracefulShutdownSpec{
+								CoordinatedScaleDown: true,
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.matching.gracefulShutdown.coordinatedScaleDown: Forbidden: coordinated scale-down requires gracefulShutdown.drainDuration to be set",
+		},
+		"error with mTLS renewBefore longer than certificates duration": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					MTLS: &v1beta1.MTLSSpec{
+						Provider:    v1beta1.CertManagerMTLSProvider,
+						RenewBefore: &metav1.Duration{Duration: 2 * time.Hour},
+						CertificatesDuration: &v1beta1.CertificatesDurationSpec{
+							InternodeCertificate: &metav1.Duration{Duration: time.Hour},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{CertManager: true},
+			},
+			expectedErr: "spec.mTLS.renewBefore: Invalid value: \"2h0m0s\": must be lower than spec.mTLS.certificatesDuration.internodeCertificate",
+		},
+		"error with traffic on history service": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							Traffic: &v1beta1.ServiceTrafficSpec{
+								TopologyAwareRouting: true,
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.history.traffic: Forbidden: traffic is only supported by the frontend service",
+		},
+		"error with drain on history service": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							Drain: true,
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.history.drain: Forbidden: drain is only supported by the frontend service",
+		},
+		"error with drain without frontend proxy": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{
+							Drain: true,
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.frontend.drain: Forbidden: drain mode requires the frontend proxy to be enabled, as the proxies reject the new workflow executions",
+		},
+		"error with warmup and linkerd internode mTLS": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					MTLS: &v1beta1.MTLSSpec{
+						Provider:  v1beta1.LinkerdMTLSProvider,
+						Internode: &v1beta1.InternodeMTLSSpec{Enabled: true},
+					},
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							Warmup: &v1beta1.WarmupSpec{Enabled: true},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.history.warmup: Forbidden: warmup requires internode mTLS to be disabled or provided by cert-manager, as the operator can't reach the history pods",
+		},
+		"error with frontend proxy without mTLS": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{
+							Proxy: &v1beta1.FrontendProxySpec{
+								Enabled: true,
+							},
+						},
+						InternalFrontend: &v1beta1.InternalFrontendServiceSpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.frontend.proxy.enabled: Forbidden: frontend proxy requires mTLS using cert-manager to be enabled for the frontend",
+		},
+		"error with frontend mirroring without blue/green upgrades": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Services: &v1beta1.ServicesSpec{
+						Frontend: &v1beta1.ServiceSpec{
+							Proxy: &v1beta1.FrontendProxySpec{
+								Enabled: true,
+								Mirroring: &v1beta1.FrontendMirroringSpec{
+									Enabled: true,
+								},
+							},
+						},
+						InternalFrontend: &v1beta1.InternalFrontendServiceSpec{
+							Enabled: true,
+						},
+					},
+					MTLS: &v1beta1.MTLSSpec{
+						Provider: v1beta1.CertManagerMTLSProvider,
+						Frontend: &v1beta1.FrontendMTLSSpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{
+					CertManager: true,
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.frontend.proxy.mirroring.enabled: Forbidden: frontend requests mirroring requires the BlueGreen upgrade strategy, as requests are mirrored to the target version frontend",
+		},
+		"error with memory headroom without memory limit": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							MemoryProtection: &v1beta1.MemoryProtectionSpec{
+								HeadroomPercent: ptr.To[int32](10),
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.history.memoryProtection.headroomPercent: Forbidden: memory headroom requires a memory limit to be set",
+		},
+		"error with filestore archival volume outside of the mount path": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Archival: &v1beta1.ClusterArchivalSpec{
+						Enabled: true,
+						Provider: &v1beta1.ArchivalProvider{
+							Filestore: &v1beta1.FilestoreArchiver{
+								Volume: &v1beta1.FilestoreVolumeSpec{
+									MountPath: "/etc/archival",
+									ClaimTemplate: &corev1.PersistentVolumeClaimSpec{
+										AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+									},
+								},
+							},
+						},
+						History: &v1beta1.ArchivalSpec{
+							Enabled: true,
+							Path:    "/tmp/archival",
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.archival.history.path: Invalid value: \"/tmp/archival\": filestore archival path must be located under the volume mount path /etc/archival",
+		},
+		"error with filestore archival volume without ReadWriteMany access mode": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Archival: &v1beta1.ClusterArchivalSpec{
+						Enabled: true,
+						Provider: &v1beta1.ArchivalProvider{
+							Filestore: &v1beta1.FilestoreArchiver{
+								Volume: &v1beta1.FilestoreVolumeSpec{
+									MountPath: "/etc/archival",
+									ClaimTemplate: &corev1.PersistentVolumeClaimSpec{
+										AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+									},
+								},
+							},
+						},
+						History: &v1beta1.ArchivalSpec{
+							Enabled: true,
+							Path:    "/etc/archival/history",
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.archival.provider.filestore.volume.claimTemplate.accessModes: Invalid value: []v1.PersistentVolumeAccessMode{\"ReadWriteOnce\"}: filestore archival volume is shared by the frontend, history and worker pods and requires the ReadWriteMany access mode",
+		},
+		"error with filestore archival volume claim name and template without CEL validation": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Archival: &v1beta1.ClusterArchivalSpec{
+						Enabled: true,
+						Provider: &v1beta1.ArchivalProvider{
+							Filestore: &v1beta1.FilestoreArchiver{
+								Volume: &v1beta1.FilestoreVolumeSpec{
+									MountPath: "/etc/archival",
+									ClaimName: "archival",
+									ClaimTemplate: &corev1.PersistentVolumeClaimSpec{
+										AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{
+					CELValidation: false,
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.archival.provider.filestore.volume: Invalid value: \"archival\": exactly one of claimName or claimTemplate must be set",
+		},
+		"error with visibility s3 archival provider using a different role": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Archival: &v1beta1.ClusterArchivalSpec{
+						Enabled: true,
+						Provider: &v1beta1.ArchivalProvider{
+							S3: &v1beta1.S3Archiver{
+								Region:   "eu-west-1",
+								RoleName: ptr.To("history-archival"),
+							},
+						},
+						VisibilityProvider: &v1beta1.ArchivalProvider{
+							S3: &v1beta1.S3Archiver{
+								Region:   "us-east-1",
+								RoleName: ptr.To("visibility-archival"),
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.archival.visibilityProvider.s3: Forbidden: s3 credentials and role name are shared by the temporal services and must match spec.archival.provider.s3",
+		},
+		"error with history archival without history provider": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Archival: &v1beta1.ClusterArchivalSpec{
+						Enabled: true,
+						VisibilityProvider: &v1beta1.ArchivalProvider{
+							GCS: &v1beta1.GCSArchiver{},
+						},
+						History: &v1beta1.ArchivalSpec{
+							Enabled: true,
+							Path:    "history-bucket/temporal",
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.archival.provider: Required value: history archival requires spec.archival.provider, spec.archival.visibilityProvider is only used for visibility records",
+		},
+		"error with recreate deployment strategy and rolling update parameters": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Services: &v1beta1.ServicesSpec{
+						History: &v1beta1.ServiceSpec{
+							DeploymentStrategy: &appsv1.DeploymentStrategy{
+								Type:          appsv1.RecreateDeploymentStrategyType,
+								RollingUpdate: &appsv1.RollingUpdateDeployment{},
+							},
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.history.deploymentStrategy.rollingUpdate: Forbidden: rollingUpdate can't be set when the deployment strategy type is Recreate",
+		},
+		"error with image verification without digests resolution": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					ImageVerification: &v1beta1.ImageVerificationSpec{
+						PublicKeySecretRef: v1beta1.SecretKeyReference{
+							Name: "cosign",
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.imageVerification: Forbidden: image verification requires spec.resolveImageDigests to be enabled",
+		},
+		"error with restricted pod security and unconfined seccomp profile": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					PodSecurity: &v1beta1.PodSecuritySpec{
+						Profile: v1beta1.RestrictedPodSecurityProfile,
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeUnconfined,
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.podSecurity.seccompProfile.type: Forbidden: the restricted profile doesn't allow an Unconfined seccomp profile",
+		},
+		"error with persistence global rate limit lower than host limit": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						RateLimits: &v1beta1.PersistenceRateLimitsSpec{
+							History: &v1beta1.ServicePersistenceRateLimitsSpec{
+								MaxQPS:       ptr.To[int32](3000),
+								GlobalMaxQPS: ptr.To[int32](1000),
+							},
+						},
+					},
+					DynamicConfig: &v1beta1.DynamicConfigSpec{},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.persistence.rateLimits.history.globalMaxQPS: Invalid value: 1000: must be greater than or equal to the host limit (3000)",
+		},
+		"error with authorization without internal frontend": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Authorization: &v1beta1.AuthorizationSpec{
+						Authorizer: "default",
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.internalFrontend: Required value: authorization requires the internal frontend to be configured",
+		},
+		"error with datadog enabled without prometheus": {
+			object: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					Metrics: &v1beta1.MetricsSpec{
+						Enabled: true,
+						Datadog: &v1beta1.DatadogSpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wh: &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.metrics.prometheus: Required value: the prometheus reporter is required for the datadog openmetrics check to scrape the services",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			_, err := test.wh.ValidateCreate(context.Background(), test.object)
+			if test.expectedErr != "" {
+				assert.Error(tt, err)
+				// Here contains is used to allow partial error checks.
+				// When version is wrong, it shows which versions are supported.
+				// If using "Equal" it would requires us to update tests
+				// for each new temporal version.
+				assert.Contains(tt, err.Error(), test.expectedErr)
+			} else {
+				assert.NoError(tt, err)
+			}
+		})
+	}
+}
+
+func TestValidateCreateWarnings(t *testing.T) {
+	tests := map[string]struct {
+		spec             v1beta1.TemporalClusterSpec
+		expectedWarnings []string
+	}{
+		"no warnings": {
+			spec: v1beta1.TemporalClusterSpec{
+				Version: version.MustNewVersionFromString("1.22.0"),
+			},
+		},
+		"deprecated advanced visibility store": {
+			spec: v1beta1.TemporalClusterSpec{
+				Version: version.MustNewVersionFromString("1.20.0"),
+				Persistence: v1beta1.TemporalPersistenceSpec{
+					AdvancedVisibilityStore: &v1beta1.DatastoreSpec{
+						Elasticsearch: &v1beta1.ElasticsearchSpec{},
+					},
+				},
+			},
+			expectedWarnings: []string{
+				"spec.persistence.advancedVisibilityStore is deprecated and will be removed in v1beta2: upgrade to temporal >= 1.21 and configure Elasticsearch as spec.persistence.visibilityStore instead",
+			},
+		},
+		"managed elasticsearch": {
+			spec: v1beta1.TemporalClusterSpec{
+				Version: version.MustNewVersionFromString("1.22.0"),
+				Persistence: v1beta1.TemporalPersistenceSpec{
+					VisibilityStore: &v1beta1.DatastoreSpec{
+						Elasticsearch: &v1beta1.ElasticsearchSpec{
+							Version: "v7",
+							Managed: &v1beta1.ManagedElasticsearchSpec{
+								Enabled: true,
+							},
+						},
+					},
+				},
+			},
+			expectedWarnings: []string{
+				"spec.persistence.visibilityStore.elasticsearch.managed is enabled: the operator runs a single-node Elasticsearch instance without security, replication nor backups. Only use it for development and test clusters.",
+			},
+		},
+		"highly available cluster without mTLS": {
+			spec: v1beta1.TemporalClusterSpec{
+				Version:          version.MustNewVersionFromString("1.22.0"),
+				HighAvailability: true,
+				NumHistoryShards: 512,
+			},
+			expectedWarnings: []string{
+				"spec.highAvailability is enabled but internode mTLS is disabled: temporal services communicate in clear text. Enable spec.mTLS.internode for production clusters.",
+				"spec.highAvailability is enabled but both frontend mTLS and authorization are disabled: any client reaching the frontend can manage the cluster. Enable spec.mTLS.frontend or spec.authorization for production clusters.",
+			},
+		},
+		"highly available cluster with few history shards": {
+			spec: v1beta1.TemporalClusterSpec{
+				Version:          version.MustNewVersionFromString("1.22.0"),
+				HighAvailability: true,
+				NumHistoryShards: 4,
+				MTLS: &v1beta1.MTLSSpec{
+					Internode: &v1beta1.InternodeMTLSSpec{Enabled: true},
+					Frontend:  &v1beta1.FrontendMTLSSpec{Enabled: true},
+				},
+			},
+			expectedWarnings: []string{
+				"spec.highAvailability is enabled but spec.numHistoryShards is 4: the number of history shards can't be changed once the cluster is created and limits how far the cluster can scale. Production clusters usually run at least 512 shards.",
+			},
+		},
+		"mTLS renewBefore shorter than the cluster rollout": {
+			spec: v1beta1.TemporalClusterSpec{
+				Version: version.MustNewVersionFromString("1.22.0"),
+				MTLS: &v1beta1.MTLSSpec{
+					Provider:    v1beta1.CertManagerMTLSProvider,
+					Internode:   &v1beta1.InternodeMTLSSpec{Enabled: true},
+					RenewBefore: &metav1.Duration{Duration: 10 * time.Minute},
+					CertificatesDuration: &v1beta1.CertificatesDurationSpec{
+						InternodeCertificate: &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+			expectedWarnings: []string{
+				"spec.mTLS.renewBefore is 10m0s but reloading the certificates and rolling all the cluster pods may take 1h6m0s (6m0s rollout): the operator renews the certificates 1h6m0s before they expire when their duration allows it.",
+				"spec.mTLS.certificatesDuration.internodeCertificate is 1h0m0s but reloading the certificates and rolling all the cluster pods may take 1h6m0s (6m0s rollout): pods may keep serving expired certificates.",
+			},
+		},
+		"history without memory limit": {
+			spec: v1beta1.TemporalClusterSpec{
+				Version: version.MustNewVersionFromString("1.22.0"),
+				Services: &v1beta1.ServicesSpec{
+					History: &v1beta1.ServiceSpec{},
+				},
+			},
+			expectedWarnings: []string{
+				"spec.services.history.resources has no memory limit: history pods can use all the node memory and get OOM killed along with their neighbors. Set a memory limit and spec.services.history.memoryProtection.headroomPercent.",
+			},
+		},
+		"authorization with internal frontend disabled": {
+			spec: v1beta1.TemporalClusterSpec{
+				Version: version.MustNewVersionFromString("1.22.0"),
+				Services: &v1beta1.ServicesSpec{
+					InternalFrontend: &v1beta1.InternalFrontendServiceSpec{
+						Enabled: false,
+					},
+				},
+				Authorization: &v1beta1.AuthorizationSpec{
+					Authorizer: "default",
+				},
+			},
+			expectedWarnings: []string{
+				"Authorization is enabled but the internal frontend is disabled: the worker service and the operator will call the public frontend and must be granted admin claims by your claim mapper.",
+			},
+		},
+		"frontend hostnames on a ClusterIP service": {
+			spec: v1beta1.TemporalClusterSpec{
+				Version: version.MustNewVersionFromString("1.22.0"),
+				Expose: &v1beta1.ExposeSpec{
+					Hostnames: &v1beta1.ExposeHostnamesSpec{
+						Frontend: []string{"temporal.example.com"},
+					},
+				},
+			},
+			expectedWarnings: []string{
+				"spec.expose.hostnames.frontend is set but the frontend Service is a ClusterIP Service: external-dns only publishes it when started with --publish-internal-services, and the records resolve to an in-cluster IP. Expose the frontend through a load balancer and map it using spec.expose.externalFrontend.externalName to reach it from outside kubernetes.",
+			},
+		},
+		"frontend hostnames on an ExternalName service": {
+			spec: v1beta1.TemporalClusterSpec{
+				Version: version.MustNewVersionFromString("1.22.0"),
+				Expose: &v1beta1.ExposeSpec{
+					Hostnames: &v1beta1.ExposeHostnamesSpec{
+						Frontend: []string{"temporal.example.com"},
+					},
+					ExternalFrontend: &v1beta1.ExternalFrontendSpec{
+						ExternalName: "temporal-lb.example.com",
+					},
+				},
+			},
+		},
+		"undersized history pod": {
+			spec: v1beta1.TemporalClusterSpec{
+				Version:          version.MustNewVersionFromString("1.22.0"),
+				NumHistoryShards: 4096,
+				Services: &v1beta1.ServicesSpec{
+					History: &v1beta1.ServiceSpec{
+						Replicas: ptr.To[int32](1),
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("500m"),
+							},
+						},
+					},
+				},
+			},
+			expectedWarnings: []string{
+				"sizing: each history pod owns 4096 shards (4096 shards for 1 replicas), more than the recommended 2048: increase spec.services.history.replicas to at least 2.",
+				"sizing: history pods have 500m CPU for 4096 shards each: at least 8192m CPU is recommended, increase spec.services.history.resources or spec.services.history.replicas.",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			wh := &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			}
+			cluster := &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: test.spec,
+			}
+			warns, _ := wh.ValidateCreate(context.Background(), cluster)
+			for _, expected := range test.expectedWarnings {
+				assert.Contains(tt, warns, expected)
+			}
+			if len(test.expectedWarnings) == 0 {
+				for _, warn := range warns {
+					assert.NotContains(tt, warn, "deprecated")
+					assert.NotContains(tt, warn, "spec.highAvailability")
+				}
+			}
+		})
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	tests := map[string]struct {
+		oldlObject  runtime.Object
+		newObject   runtime.Object
+		expectedErr string
+	}{
+		"allowed upgrade": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.18.4"),
+					NumHistoryShards: int32(512),
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.19.0"),
+					NumHistoryShards: int32(512),
+				},
+			},
+		},
+		"version rollback": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.19.0"),
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec:   v1beta1.TemporalClusterSpec{Version: version.MustNewVersionFromString("1.18.4")},
+				Status: v1beta1.TemporalClusterStatus{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.version: Forbidden: Unauthorized version upgrade. Only sequential version upgrades are allowed (from v1.n.x to v1.n+1.x)",
+		},
+		"not a sequential version update": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.17.0"),
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec:   v1beta1.TemporalClusterSpec{Version: version.MustNewVersionFromString("1.19.4")},
+				Status: v1beta1.TemporalClusterStatus{},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.version: Forbidden: Unauthorized version upgrade. Only sequential version upgrades are allowed (from v1.n.x to v1.n+1.x)",
+		},
+		"existing cluster with authorization without internal frontend": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Authorization: &v1beta1.AuthorizationSpec{
+						Authorizer: "default",
+					},
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "fake",
+					Labels: map[string]string{"team": "payments"},
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Authorization: &v1beta1.AuthorizationSpec{
+						Authorizer: "default",
+					},
+				},
+			},
+		},
+		"authorization enabled without internal frontend": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.22.0"),
+					Authorization: &v1beta1.AuthorizationSpec{
+						Authorizer: "default",
+					},
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.services.internalFrontend: Required value: authorization requires the internal frontend to be configured: enable it to route the worker service and the operator through it, or disable it if your claim mapper grants them admin claims",
+		},
+		"immutable numHistoryShards": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.19.4"),
+					NumHistoryShards: int32(256),
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.19.4"),
+					NumHistoryShards: int32(512),
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.numHistoryShards: Forbidden: Number of history shards is immutable",
+		},
+		"persistence changed along with a blue/green upgrade": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.18.4"),
+					UpgradeStrategy: &v1beta1.UpgradeStrategySpec{
+						Type: v1beta1.BlueGreenUpgradeStrategyType,
+					},
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							Name: "default",
+						},
+					},
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.19.0"),
+					UpgradeStrategy: &v1beta1.UpgradeStrategySpec{
+						Type: v1beta1.BlueGreenUpgradeStrategyType,
+					},
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							Name: "new",
+						},
+					},
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.persistence: Forbidden: Persistence can't be changed along with the version when using the blue/green upgrade strategy, as both versions share the same persistence",
+		},
+		"database renamed without migration annotation": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.19.0"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							Name: "default",
+							SQL: &v1beta1.SQLSpec{
+								PluginName:   "postgres12",
+								ConnectAddr:  "postgres:5432",
+								DatabaseName: "temporal",
+							},
+						},
+					},
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.19.0"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							Name: "default",
+							SQL: &v1beta1.SQLSpec{
+								PluginName:   "postgres12",
+								ConnectAddr:  "postgres:5432",
+								DatabaseName: "temporal_new",
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.persistence.defaultStore.sql.databaseName: Forbidden: Renaming the database requires migrating its data, set the temporal.io/migrate-datastores annotation to \"true\" to allow it, or the temporal.io/confirm-persistence-change annotation to \"true\" if the new database already holds the cluster data",
+		},
+		"database renamed with migration annotation": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.19.0"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							Name: "default",
+							SQL: &v1beta1.SQLSpec{
+								PluginName:   "postgres12",
+								ConnectAddr:  "postgres:5432",
+								DatabaseName: "temporal",
+							},
+						},
+					},
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+					Annotations: map[string]string{
+						v1beta1.MigrateDatastoresAnnotation: "true",
+					},
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.19.0"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							Name: "default",
+							SQL: &v1beta1.SQLSpec{
+								PluginName:   "postgres12",
+								ConnectAddr:  "postgres:5432",
+								DatabaseName: "temporal_new",
+							},
+						},
+					},
+				},
+			},
+		},
+		"database renamed with confirmation annotation": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.19.0"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							Name: "default",
+							SQL: &v1beta1.SQLSpec{
+								PluginName:   "postgres12",
+								ConnectAddr:  "postgres:5432",
+								DatabaseName: "temporal",
+							},
+						},
+					},
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+					Annotations: map[string]string{
+						v1beta1.ConfirmPersistenceChangeAnnotation: "true",
+					},
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version: version.MustNewVersionFromString("1.19.0"),
+					Persistence: v1beta1.TemporalPersistenceSpec{
+						DefaultStore: &v1beta1.DatastoreSpec{
+							Name: "default",
+							SQL: &v1beta1.SQLSpec{
+								PluginName:   "postgres12",
+								ConnectAddr:  "postgres-2:5432",
+								DatabaseName: "temporal_new",
+							},
+						},
+					},
+				},
+			},
+		},
+		"cluster domain change with cert-manager mTLS": {
+			oldlObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:       version.MustNewVersionFromString("1.19.0"),
+					ClusterDomain: "cluster.local",
+					MTLS: &v1beta1.MTLSSpec{
+						Provider: v1beta1.CertManagerMTLSProvider,
+						Internode: &v1beta1.InternodeMTLSSpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			newObject: &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:       version.MustNewVersionFromString("1.19.0"),
+					ClusterDomain: "corp.example",
+					MTLS: &v1beta1.MTLSSpec{
+						Provider: v1beta1.CertManagerMTLSProvider,
+						Internode: &v1beta1.InternodeMTLSSpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			expectedErr: "TemporalCluster.temporal.io \"fake\" is invalid: spec.clusterDomain: Forbidden: Cluster domain can't be changed while mTLS is enabled using cert-manager",
 		},
 	}
 
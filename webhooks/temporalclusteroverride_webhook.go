@@ -0,0 +1,158 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// TemporalClusterOverrideWebhook provides endpoints to validate TemporalClusterOverride objects.
+type TemporalClusterOverrideWebhook struct {
+	// Client is used to list the clusters selected by the override and their other overrides.
+	// If nil, the selected clusters aren't validated.
+	Client client.Reader
+	// ClusterWebhook validates the selected clusters with the override applied.
+	ClusterWebhook *TemporalClusterWebhook
+}
+
+func (w *TemporalClusterOverrideWebhook) getOverrideFromRequest(obj runtime.Object) (*v1beta1.TemporalClusterOverride, error) {
+	override, ok := obj.(*v1beta1.TemporalClusterOverride)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a TemporalClusterOverride but got a %T", obj))
+	}
+	return override, nil
+}
+
+func (w *TemporalClusterOverrideWebhook) aggregateOverrideErrors(ctx context.Context, override *v1beta1.TemporalClusterOverride, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	err := apierrors.NewInvalid(
+		v1beta1.GroupVersion.WithKind("TemporalClusterOverride").GroupKind(),
+		override.GetName(),
+		errs,
+	)
+	log.FromContext(ctx).Info("Rejecting invalid cluster override", "override", override.GetName(), "reason", err.Error())
+
+	return err
+}
+
+// validateOverride ensures the override selector is valid, and that the clusters it selects stay valid
+// once all their overrides, including the validated one, are applied.
+func (w *TemporalClusterOverrideWebhook) validateOverride(ctx context.Context, override *v1beta1.TemporalClusterOverride) field.ErrorList {
+	var errs field.ErrorList
+
+	_, err := metav1.LabelSelectorAsSelector(&override.Spec.ClusterSelector)
+	if err != nil {
+		return append(errs, field.Invalid(field.NewPath("spec", "clusterSelector"), override.Spec.ClusterSelector, err.Error()))
+	}
+
+	if w.Client == nil || w.ClusterWebhook == nil {
+		return errs
+	}
+
+	clusters := &v1beta1.TemporalClusterList{}
+	err = w.Client.List(ctx, clusters, client.InNamespace(override.GetNamespace()))
+	if err != nil {
+		return append(errs, field.InternalError(field.NewPath("spec", "clusterSelector"), fmt.Errorf("can't list clusters: %w", err)))
+	}
+
+	list := &v1beta1.TemporalClusterOverrideList{}
+	err = w.Client.List(ctx, list, client.InNamespace(override.GetNamespace()))
+	if err != nil {
+		return append(errs, field.InternalError(field.NewPath("spec", "patch"), fmt.Errorf("can't list cluster overrides: %w", err)))
+	}
+
+	// The validated override replaces its stored version.
+	overrides := []v1beta1.TemporalClusterOverride{*override}
+	for _, existing := range list.Items {
+		if existing.GetName() != override.GetName() {
+			overrides = append(overrides, existing)
+		}
+	}
+
+	path := field.NewPath("spec", "patch")
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+
+		matches, err := override.Matches(cluster)
+		if err != nil || !matches {
+			continue
+		}
+
+		applied, clusterErrs, err := w.ClusterWebhook.validateClusterWithOverrides(cluster, overrides)
+		if err != nil {
+			errs = append(errs, field.Forbidden(path, fmt.Sprintf("can't be applied to cluster %s: %s", cluster.GetName(), err)))
+			continue
+		}
+
+		for _, clusterErr := range clusterErrs {
+			errs = append(errs, field.Forbidden(path,
+				fmt.Sprintf("makes cluster %s invalid with overrides %s applied: %s", cluster.GetName(), strings.Join(applied, ", "), clusterErr.Error())))
+		}
+	}
+
+	return errs
+}
+
+// ValidateCreate validates TemporalClusterOverride creates.
+func (w *TemporalClusterOverrideWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	override, err := w.getOverrideFromRequest(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, w.aggregateOverrideErrors(ctx, override, w.validateOverride(ctx, override))
+}
+
+// ValidateUpdate validates TemporalClusterOverride updates.
+func (w *TemporalClusterOverrideWebhook) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	override, err := w.getOverrideFromRequest(newObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, w.aggregateOverrideErrors(ctx, override, w.validateOverride(ctx, override))
+}
+
+// ValidateDelete validates TemporalClusterOverride deletes.
+// Deleting an override restores the stored spec of the clusters it selects, which was validated when written.
+func (w *TemporalClusterOverrideWebhook) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// SetupWebhookWithManager adds webhooks to the provided manager.
+func (w *TemporalClusterOverrideWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1beta1.TemporalClusterOverride{}).
+		WithValidator(w).
+		Complete()
+}
@@ -0,0 +1,138 @@
+// Licensed to Alexandre VILAIN under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Alexandre VILAIN licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package webhooks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexandrevilain/temporal-operator/api/v1beta1"
+	"github.com/alexandrevilain/temporal-operator/internal/discovery"
+	"github.com/alexandrevilain/temporal-operator/pkg/version"
+	"github.com/alexandrevilain/temporal-operator/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTemporalClusterOverrideValidateCreate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	tests := map[string]struct {
+		override    *v1beta1.TemporalClusterOverride
+		expectedErr string
+	}{
+		"valid override": {
+			override: &v1beta1.TemporalClusterOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: "team"},
+				Spec: v1beta1.TemporalClusterOverrideSpec{
+					ClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"environment": "production"},
+					},
+					Patch: v1beta1.TemporalClusterSpec{
+						NumHistoryShards: 4,
+					},
+				},
+			},
+		},
+		"invalid selector": {
+			override: &v1beta1.TemporalClusterOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: "team"},
+				Spec: v1beta1.TemporalClusterOverrideSpec{
+					ClusterSelector: metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "environment", Operator: "Unknown"},
+						},
+					},
+				},
+			},
+			expectedErr: `TemporalClusterOverride.temporal.io "production" is invalid: spec.clusterSelector: Invalid value`,
+		},
+		"override making a selected cluster invalid": {
+			override: &v1beta1.TemporalClusterOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: "team"},
+				Spec: v1beta1.TemporalClusterOverrideSpec{
+					ClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"environment": "production"},
+					},
+					Patch: v1beta1.TemporalClusterSpec{
+						MTLS: &v1beta1.MTLSSpec{
+							Provider: v1beta1.CertManagerMTLSProvider,
+						},
+					},
+				},
+			},
+			expectedErr: "spec.patch: Forbidden: makes cluster fake invalid with overrides production applied",
+		},
+		"invalid patch not selecting any cluster": {
+			override: &v1beta1.TemporalClusterOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "team"},
+				Spec: v1beta1.TemporalClusterOverrideSpec{
+					ClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"environment": "staging"},
+					},
+					Patch: v1beta1.TemporalClusterSpec{
+						MTLS: &v1beta1.MTLSSpec{
+							Provider: v1beta1.CertManagerMTLSProvider,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(tt *testing.T) {
+			clusterWebhook := &webhooks.TemporalClusterWebhook{
+				AvailableAPIs: &discovery.AvailableAPIs{},
+			}
+
+			cluster := &v1beta1.TemporalCluster{
+				TypeMeta: v1beta1.TemporalClusterTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake",
+					Namespace: "team",
+					Labels:    map[string]string{"environment": "production"},
+				},
+				Spec: v1beta1.TemporalClusterSpec{
+					Version:          version.MustNewVersionFromString("1.18.4"),
+					NumHistoryShards: 1,
+				},
+			}
+			require.NoError(tt, clusterWebhook.Default(context.Background(), cluster))
+
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build()
+			clusterWebhook.Client = c
+
+			wh := &webhooks.TemporalClusterOverrideWebhook{
+				Client:         c,
+				ClusterWebhook: clusterWebhook,
+			}
+
+			_, err := wh.ValidateCreate(context.Background(), test.override)
+			if test.expectedErr != "" {
+				assert.ErrorContains(tt, err, test.expectedErr)
+				return
+			}
+			assert.NoError(tt, err)
+		})
+	}
+}